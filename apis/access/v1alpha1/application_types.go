@@ -0,0 +1,203 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+
+	account "github.com/benagricola/provider-cloudflare/apis/account/v1alpha1"
+)
+
+// AccessApplicationCorsHeaders are the CORS settings of an Access
+// Application.
+type AccessApplicationCorsHeaders struct {
+	// AllowedMethods are the HTTP methods that may be used in CORS
+	// requests.
+	// +optional
+	AllowedMethods []string `json:"allowedMethods,omitempty"`
+
+	// AllowedOrigins are the origins that may make CORS requests.
+	// +optional
+	AllowedOrigins []string `json:"allowedOrigins,omitempty"`
+
+	// AllowedHeaders are the HTTP headers that may be sent in CORS
+	// requests.
+	// +optional
+	AllowedHeaders []string `json:"allowedHeaders,omitempty"`
+
+	// AllowAllMethods allows all HTTP methods in CORS requests.
+	// +optional
+	AllowAllMethods *bool `json:"allowAllMethods,omitempty"`
+
+	// AllowAllHeaders allows all HTTP headers in CORS requests.
+	// +optional
+	AllowAllHeaders *bool `json:"allowAllHeaders,omitempty"`
+
+	// AllowAllOrigins allows CORS requests from all origins.
+	// +optional
+	AllowAllOrigins *bool `json:"allowAllOrigins,omitempty"`
+
+	// AllowCredentials allows credentials to be sent in CORS requests.
+	// +optional
+	AllowCredentials *bool `json:"allowCredentials,omitempty"`
+
+	// MaxAge is the number of seconds a CORS preflight response may be
+	// cached for.
+	// +kubebuilder:validation:Minimum=-1
+	// +kubebuilder:validation:Maximum=86400
+	// +optional
+	MaxAge *int64 `json:"maxAge,omitempty"`
+}
+
+// AccessApplicationParameters are the configurable fields of an Access
+// Application.
+type AccessApplicationParameters struct {
+	// Account is the account ID this Access Application is created under.
+	// +immutable
+	// +optional
+	Account *string `json:"account,omitempty"`
+
+	// AccountRef references the Account object this Access Application
+	// is created under.
+	// +immutable
+	// +optional
+	AccountRef *xpv1.Reference `json:"accountRef,omitempty"`
+
+	// AccountSelector selects the Account object this Access Application
+	// is created under.
+	// +immutable
+	// +optional
+	AccountSelector *xpv1.Selector `json:"accountSelector,omitempty"`
+
+	// Name of the Access Application.
+	Name string `json:"name"`
+
+	// Domain is the hostname and optional path protected by this
+	// Access Application.
+	Domain string `json:"domain"`
+
+	// SessionDuration is how long a session token is valid for, as a
+	// duration string such as 24h or 30m.
+	// +optional
+	SessionDuration *string `json:"sessionDuration,omitempty"`
+
+	// AllowedIdPs are the IDs of the identity providers users may
+	// authenticate with. All configured providers are allowed if empty.
+	// +optional
+	AllowedIdPs []string `json:"allowedIdps,omitempty"`
+
+	// AutoRedirectToIdentity skips the identity provider selection page
+	// when only a single identity provider is allowed.
+	// +optional
+	AutoRedirectToIdentity *bool `json:"autoRedirectToIdentity,omitempty"`
+
+	// EnableBindingCookie enables the binding cookie, which increases
+	// security against compromised authorization tokens.
+	// +optional
+	EnableBindingCookie *bool `json:"enableBindingCookie,omitempty"`
+
+	// CorsHeaders configures CORS for this Access Application.
+	// +optional
+	CorsHeaders *AccessApplicationCorsHeaders `json:"corsHeaders,omitempty"`
+
+	// CustomDenyMessage is shown to users who are denied access.
+	// +optional
+	CustomDenyMessage *string `json:"customDenyMessage,omitempty"`
+
+	// CustomDenyURL is a URL users are redirected to when denied access.
+	// +optional
+	CustomDenyURL *string `json:"customDenyUrl,omitempty"`
+}
+
+// AccessApplicationObservation are the observable fields of an Access
+// Application.
+type AccessApplicationObservation struct {
+	// AUD is the Application Audience tag of this Access Application,
+	// used to validate Access JWTs at the origin.
+	AUD string `json:"aud,omitempty"`
+}
+
+// An AccessApplicationSpec defines the desired state of an Access
+// Application.
+type AccessApplicationSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       AccessApplicationParameters `json:"forProvider"`
+}
+
+// An AccessApplicationStatus represents the observed state of an Access
+// Application.
+type AccessApplicationStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          AccessApplicationObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An AccessApplication represents a Cloudflare Access Application on an
+// Account.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="DOMAIN",type="string",JSONPath=".spec.forProvider.domain"
+// +kubebuilder:printcolumn:name="AUD",type="string",JSONPath=".status.atProvider.aud",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type AccessApplication struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AccessApplicationSpec   `json:"spec"`
+	Status AccessApplicationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AccessApplicationList contains a list of Access Application objects
+type AccessApplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AccessApplication `json:"items"`
+}
+
+// ResolveReferences resolves references to the Account that this Access
+// Application is created under.
+func (a *AccessApplication) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, a)
+
+	// Resolve spec.forProvider.account
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(a.Spec.ForProvider.Account),
+		Reference:    a.Spec.ForProvider.AccountRef,
+		Selector:     a.Spec.ForProvider.AccountSelector,
+		To:           reference.To{Managed: &account.Account{}, List: &account.AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.account")
+	}
+	a.Spec.ForProvider.Account = reference.ToPtrValue(rsp.ResolvedValue)
+	a.Spec.ForProvider.AccountRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+
+	account "github.com/benagricola/provider-cloudflare/apis/account/v1alpha1"
+)
+
+// AccessRule is a single condition of an Access Policy. Exactly one field
+// should be set on each rule; a rule with several fields set is expanded
+// into one condition per field.
+type AccessRule struct {
+	// Email matches a single email address.
+	// +optional
+	Email *string `json:"email,omitempty"`
+
+	// EmailDomain matches all email addresses of a domain.
+	// +optional
+	EmailDomain *string `json:"emailDomain,omitempty"`
+
+	// IP matches requests from an IP address or CIDR range.
+	// +optional
+	IP *string `json:"ip,omitempty"`
+
+	// Geo matches requests from a two-letter country code.
+	// +optional
+	Geo *string `json:"geo,omitempty"`
+
+	// Everyone matches all users.
+	// +optional
+	Everyone *bool `json:"everyone,omitempty"`
+
+	// Group matches members of the Access Group with this ID.
+	// +optional
+	Group *string `json:"group,omitempty"`
+
+	// ServiceToken matches requests carrying the Service Token with
+	// this ID.
+	// +optional
+	ServiceToken *string `json:"serviceToken,omitempty"`
+
+	// AnyValidServiceToken matches requests carrying any valid Service
+	// Token.
+	// +optional
+	AnyValidServiceToken *bool `json:"anyValidServiceToken,omitempty"`
+
+	// Certificate matches requests presenting any valid client
+	// certificate.
+	// +optional
+	Certificate *bool `json:"certificate,omitempty"`
+
+	// CommonName matches requests presenting a client certificate with
+	// this common name.
+	// +optional
+	CommonName *string `json:"commonName,omitempty"`
+
+	// AuthMethod matches users that authenticated with this AMR method.
+	// +optional
+	AuthMethod *string `json:"authMethod,omitempty"`
+
+	// LoginMethod matches users that authenticated with the identity
+	// provider with this ID.
+	// +optional
+	LoginMethod *string `json:"loginMethod,omitempty"`
+
+	// DevicePosture matches devices passing the device posture rule with
+	// this ID.
+	// +optional
+	DevicePosture *string `json:"devicePosture,omitempty"`
+}
+
+// AccessPolicyParameters are the configurable fields of an Access Policy.
+type AccessPolicyParameters struct {
+	// Account is the account ID the Access Application of this policy
+	// belongs to.
+	// +immutable
+	// +optional
+	Account *string `json:"account,omitempty"`
+
+	// AccountRef references the Account object the Access Application of
+	// this policy belongs to.
+	// +immutable
+	// +optional
+	AccountRef *xpv1.Reference `json:"accountRef,omitempty"`
+
+	// AccountSelector selects the Account object the Access Application
+	// of this policy belongs to.
+	// +immutable
+	// +optional
+	AccountSelector *xpv1.Selector `json:"accountSelector,omitempty"`
+
+	// Application is the ID of the Access Application this policy
+	// applies to.
+	// +immutable
+	// +optional
+	Application *string `json:"application,omitempty"`
+
+	// ApplicationRef references the AccessApplication object this policy
+	// applies to.
+	// +immutable
+	// +optional
+	ApplicationRef *xpv1.Reference `json:"applicationRef,omitempty"`
+
+	// ApplicationSelector selects the AccessApplication object this
+	// policy applies to.
+	// +immutable
+	// +optional
+	ApplicationSelector *xpv1.Selector `json:"applicationSelector,omitempty"`
+
+	// Name of the Access Policy.
+	Name string `json:"name"`
+
+	// Decision taken when a request matches this policy.
+	// +kubebuilder:validation:Enum=allow;deny;non_identity;bypass
+	Decision string `json:"decision"`
+
+	// Precedence of this policy relative to other policies on the same
+	// Access Application. Lower values are evaluated first.
+	// +optional
+	Precedence *int64 `json:"precedence,omitempty"`
+
+	// Include rules work like a logical OR. A user must satisfy at least
+	// one of them.
+	Include []AccessRule `json:"include"`
+
+	// Exclude rules work like a logical NOT. A user must not satisfy any
+	// of them.
+	// +optional
+	Exclude []AccessRule `json:"exclude,omitempty"`
+
+	// Require rules work like a logical AND. A user must satisfy all of
+	// them.
+	// +optional
+	Require []AccessRule `json:"require,omitempty"`
+}
+
+// AccessPolicyObservation are the observable fields of an Access Policy.
+type AccessPolicyObservation struct{}
+
+// An AccessPolicySpec defines the desired state of an Access Policy.
+type AccessPolicySpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       AccessPolicyParameters `json:"forProvider"`
+}
+
+// An AccessPolicyStatus represents the observed state of an Access Policy.
+type AccessPolicyStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          AccessPolicyObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An AccessPolicy represents a Cloudflare Access Policy on an Access
+// Application.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="DECISION",type="string",JSONPath=".spec.forProvider.decision"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type AccessPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AccessPolicySpec   `json:"spec"`
+	Status AccessPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AccessPolicyList contains a list of Access Policy objects
+type AccessPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AccessPolicy `json:"items"`
+}
+
+// ResolveReferences resolves references to the Account and Access
+// Application of this Access Policy.
+func (p *AccessPolicy) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, p)
+
+	// Resolve spec.forProvider.account
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(p.Spec.ForProvider.Account),
+		Reference:    p.Spec.ForProvider.AccountRef,
+		Selector:     p.Spec.ForProvider.AccountSelector,
+		To:           reference.To{Managed: &account.Account{}, List: &account.AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.account")
+	}
+	p.Spec.ForProvider.Account = reference.ToPtrValue(rsp.ResolvedValue)
+	p.Spec.ForProvider.AccountRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.application
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(p.Spec.ForProvider.Application),
+		Reference:    p.Spec.ForProvider.ApplicationRef,
+		Selector:     p.Spec.ForProvider.ApplicationSelector,
+		To:           reference.To{Managed: &AccessApplication{}, List: &AccessApplicationList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.application")
+	}
+	p.Spec.ForProvider.Application = reference.ToPtrValue(rsp.ResolvedValue)
+	p.Spec.ForProvider.ApplicationRef = rsp.ResolvedReference
+
+	return nil
+}
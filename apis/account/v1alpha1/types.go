@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// AccountSettings are the settings of an Account.
+type AccountSettings struct {
+	// EnforceTwoFactor requires all members of the Account to use
+	// two-factor authentication.
+	// +optional
+	EnforceTwoFactor *bool `json:"enforceTwoFactor,omitempty"`
+}
+
+// AccountParameters are the configurable fields of an Account.
+type AccountParameters struct {
+	// Name of the Account.
+	Name string `json:"name"`
+
+	// Type of the Account.
+	// +kubebuilder:validation:Enum=standard;enterprise
+	// +immutable
+	// +optional
+	Type *string `json:"type,omitempty"`
+
+	// Settings of the Account. Only the settings that are set
+	// are managed.
+	// +optional
+	Settings *AccountSettings `json:"settings,omitempty"`
+}
+
+// AccountObservation are the observable fields of an Account.
+type AccountObservation struct {
+	// Type of the Account.
+	Type string `json:"type,omitempty"`
+}
+
+// An AccountSpec defines the desired state of an Account.
+type AccountSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       AccountParameters `json:"forProvider"`
+}
+
+// An AccountStatus represents the observed state of an Account.
+type AccountStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          AccountObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An Account is a Cloudflare account, which owns Zones and other resources.
+// Creating and deleting Accounts requires the Tenant entitlement.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="NAME",type="string",JSONPath=".spec.forProvider.name"
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".status.atProvider.type"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type Account struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AccountSpec   `json:"spec"`
+	Status AccountStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AccountList contains a list of Account objects
+type AccountList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Account `json:"items"`
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	zone "github.com/benagricola/provider-cloudflare/apis/zone/v1alpha1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ProductBotFightMode is the bot protection product of Free plan
+	// Zones.
+	ProductBotFightMode = "bot_fight_mode"
+
+	// ProductSuperBotFightMode is the bot protection product of Pro and
+	// Business plan Zones.
+	ProductSuperBotFightMode = "super_bot_fight_mode"
+
+	// ProductBotManagement is the bot protection product of Enterprise
+	// plan Zones.
+	ProductBotManagement = "bot_management"
+)
+
+// BotManagementParameters are the configurable fields of the bot
+// protection of a Zone. Which fields can be set depends on the plan of
+// the Zone, which is reported in the capabilities of the BotManagement.
+type BotManagementParameters struct {
+	// ZoneID the bot protection is configured on.
+	// +immutable
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+
+	// ZoneRef references the Zone object the bot protection is configured
+	// on.
+	// +immutable
+	// +optional
+	ZoneRef *xpv1.Reference `json:"zoneRef,omitempty"`
+
+	// ZoneSelector selects the Zone object the bot protection is
+	// configured on.
+	// +immutable
+	// +optional
+	ZoneSelector *xpv1.Selector `json:"zoneSelector,omitempty"`
+
+	// FightMode enables Bot Fight Mode, which challenges requests from
+	// known bots. It is only supported on Free plan Zones.
+	// +optional
+	FightMode *bool `json:"fightMode,omitempty"`
+
+	// EnableJS injects JavaScript detections into HTML responses.
+	// +optional
+	EnableJS *bool `json:"enableJS,omitempty"`
+
+	// DefinitelyAutomated is the action applied to requests that are
+	// definitely automated. It is only supported by Super Bot Fight Mode.
+	// +kubebuilder:validation:Enum=allow;block;managed_challenge
+	// +optional
+	DefinitelyAutomated *string `json:"definitelyAutomated,omitempty"`
+
+	// LikelyAutomated is the action applied to requests that are likely
+	// automated. It is only supported by Super Bot Fight Mode on Business
+	// plan Zones.
+	// +kubebuilder:validation:Enum=allow;block;managed_challenge
+	// +optional
+	LikelyAutomated *string `json:"likelyAutomated,omitempty"`
+
+	// VerifiedBots is the action applied to requests from verified bots,
+	// such as search engine crawlers. It is only supported by Super Bot
+	// Fight Mode.
+	// +kubebuilder:validation:Enum=allow;block
+	// +optional
+	VerifiedBots *string `json:"verifiedBots,omitempty"`
+
+	// StaticResourceProtection applies the actions of Super Bot Fight Mode
+	// to requests for static resources, such as images.
+	// +optional
+	StaticResourceProtection *bool `json:"staticResourceProtection,omitempty"`
+
+	// OptimizeWordpress skips Super Bot Fight Mode for requests known to
+	// be made by WordPress.
+	// +optional
+	OptimizeWordpress *bool `json:"optimizeWordpress,omitempty"`
+
+	// AutoUpdateModel automatically uses the latest machine learning model
+	// to score requests. It is only supported by Bot Management.
+	// +optional
+	AutoUpdateModel *bool `json:"autoUpdateModel,omitempty"`
+
+	// SuppressSessionScore disables session scoring. It is only supported
+	// by Bot Management.
+	// +optional
+	SuppressSessionScore *bool `json:"suppressSessionScore,omitempty"`
+}
+
+// BotManagementObservation are the observable fields of the bot
+// protection of a Zone.
+type BotManagementObservation struct {
+	// Product protecting the Zone from bots, which depends on the plan of
+	// the Zone. One of bot_fight_mode, super_bot_fight_mode or
+	// bot_management.
+	Product string `json:"product,omitempty"`
+
+	// Capabilities lists the fields of the BotManagement that are
+	// supported by the plan of the Zone.
+	Capabilities []string `json:"capabilities,omitempty"`
+
+	// UsingLatestModel indicates if the latest machine learning model is
+	// used to score requests.
+	UsingLatestModel bool `json:"usingLatestModel,omitempty"`
+}
+
+// A BotManagementSpec defines the desired state of the bot protection of a
+// Zone.
+type BotManagementSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       BotManagementParameters `json:"forProvider"`
+}
+
+// A BotManagementStatus represents the observed state of the bot
+// protection of a Zone.
+type BotManagementStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          BotManagementObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A BotManagement configures the bot protection of a Zone. Bot protection
+// is a setting of the Zone, so it is left unchanged when the
+// BotManagement is deleted.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="PRODUCT",type="string",JSONPath=".status.atProvider.product"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type BotManagement struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BotManagementSpec   `json:"spec"`
+	Status BotManagementStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BotManagementList contains a list of BotManagement objects
+type BotManagementList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BotManagement `json:"items"`
+}
+
+// ResolveReferences resolves references to the Zone that the bot
+// protection is configured on.
+func (b *BotManagement) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, b)
+
+	// Resolve spec.forProvider.zone
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(b.Spec.ForProvider.Zone),
+		Reference:    b.Spec.ForProvider.ZoneRef,
+		Selector:     b.Spec.ForProvider.ZoneSelector,
+		To:           reference.To{Managed: &zone.Zone{}, List: &zone.ZoneList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.zone")
+	}
+	b.Spec.ForProvider.Zone = reference.ToPtrValue(rsp.ResolvedValue)
+	b.Spec.ForProvider.ZoneRef = rsp.ResolvedReference
+
+	return nil
+}
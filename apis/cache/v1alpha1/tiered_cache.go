@@ -0,0 +1,136 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	zone "github.com/benagricola/provider-cloudflare/apis/zone/v1alpha1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+)
+
+const (
+	// TopologyGeneric uses every Cloudflare data center as an upper tier.
+	TopologyGeneric = "generic"
+
+	// TopologySmart lets Cloudflare pick the upper tier data centers
+	// closest to the origin.
+	TopologySmart = "smart"
+)
+
+// TieredCacheParameters are the configurable fields of Tiered Cache on a
+// Zone.
+type TieredCacheParameters struct {
+	// ZoneID Tiered Cache is enabled on.
+	// +immutable
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+
+	// ZoneRef references the Zone object Tiered Cache is enabled on.
+	// +immutable
+	// +optional
+	ZoneRef *xpv1.Reference `json:"zoneRef,omitempty"`
+
+	// ZoneSelector selects the Zone object Tiered Cache is enabled on.
+	// +immutable
+	// +optional
+	ZoneSelector *xpv1.Selector `json:"zoneSelector,omitempty"`
+
+	// Topology of the cache tiers. The smart topology enables Smart
+	// Tiered Cache, which picks the upper tier closest to the origin.
+	// +kubebuilder:validation:Enum=generic;smart
+	// +kubebuilder:default=smart
+	// +optional
+	Topology *string `json:"topology,omitempty"`
+}
+
+// TieredCacheObservation are the observable fields of Tiered Cache on a
+// Zone.
+type TieredCacheObservation struct {
+	// Topology of the cache tiers.
+	Topology string `json:"topology,omitempty"`
+}
+
+// A TieredCacheSpec defines the desired state of Tiered Cache on a Zone.
+type TieredCacheSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TieredCacheParameters `json:"forProvider"`
+}
+
+// A TieredCacheStatus represents the observed state of Tiered Cache on a
+// Zone.
+type TieredCacheStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          TieredCacheObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A TieredCache enables Tiered Cache on a Zone, so that lower tier data
+// centers fetch content from upper tier data centers rather than the
+// origin. Tiered Cache is disabled on the Zone when the TieredCache is
+// deleted.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TOPOLOGY",type="string",JSONPath=".status.atProvider.topology"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type TieredCache struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TieredCacheSpec   `json:"spec"`
+	Status TieredCacheStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TieredCacheList contains a list of TieredCache objects
+type TieredCacheList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TieredCache `json:"items"`
+}
+
+// ResolveReferences resolves references to the Zone that Tiered Cache is
+// enabled on.
+func (t *TieredCache) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, t)
+
+	// Resolve spec.forProvider.zone
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(t.Spec.ForProvider.Zone),
+		Reference:    t.Spec.ForProvider.ZoneRef,
+		Selector:     t.Spec.ForProvider.ZoneSelector,
+		To:           reference.To{Managed: &zone.Zone{}, List: &zone.ZoneList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.zone")
+	}
+	t.Spec.ForProvider.Zone = reference.ToPtrValue(rsp.ResolvedValue)
+	t.Spec.ForProvider.ZoneRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -20,11 +20,25 @@ package apis
 import (
 	"k8s.io/apimachinery/pkg/runtime"
 
+	accessv1alpha1 "github.com/benagricola/provider-cloudflare/apis/access/v1alpha1"
+	accountv1alpha1 "github.com/benagricola/provider-cloudflare/apis/account/v1alpha1"
+	botsv1alpha1 "github.com/benagricola/provider-cloudflare/apis/bots/v1alpha1"
+	cachev1alpha1 "github.com/benagricola/provider-cloudflare/apis/cache/v1alpha1"
 	dnsv1alpha1 "github.com/benagricola/provider-cloudflare/apis/dns/v1alpha1"
+	emailroutingv1alpha1 "github.com/benagricola/provider-cloudflare/apis/emailrouting/v1alpha1"
 	firewallv1alpha1 "github.com/benagricola/provider-cloudflare/apis/firewall/v1alpha1"
+	healthchecksv1alpha1 "github.com/benagricola/provider-cloudflare/apis/healthchecks/v1alpha1"
+	loadbalancingv1alpha1 "github.com/benagricola/provider-cloudflare/apis/loadbalancing/v1alpha1"
+	queuesv1alpha1 "github.com/benagricola/provider-cloudflare/apis/queues/v1alpha1"
+	r2v1alpha1 "github.com/benagricola/provider-cloudflare/apis/r2/v1alpha1"
+	rulesetsv1alpha1 "github.com/benagricola/provider-cloudflare/apis/rulesets/v1alpha1"
 	spectrumv1alpha1 "github.com/benagricola/provider-cloudflare/apis/spectrum/v1alpha1"
+	sslv1alpha1 "github.com/benagricola/provider-cloudflare/apis/ssl/v1alpha1"
 	sslsaasv1alpha1 "github.com/benagricola/provider-cloudflare/apis/sslsaas/v1alpha1"
+	tunnelv1alpha1 "github.com/benagricola/provider-cloudflare/apis/tunnel/v1alpha1"
+	turnstilev1alpha1 "github.com/benagricola/provider-cloudflare/apis/turnstile/v1alpha1"
 	cloudflarev1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	waitingroomsv1alpha1 "github.com/benagricola/provider-cloudflare/apis/waitingrooms/v1alpha1"
 	workersv1alpha1 "github.com/benagricola/provider-cloudflare/apis/workers/v1alpha1"
 	zonev1alpha1 "github.com/benagricola/provider-cloudflare/apis/zone/v1alpha1"
 )
@@ -39,6 +53,20 @@ func init() {
 		zonev1alpha1.SchemeBuilder.AddToScheme,
 		firewallv1alpha1.SchemeBuilder.AddToScheme,
 		workersv1alpha1.SchemeBuilder.AddToScheme,
+		accountv1alpha1.SchemeBuilder.AddToScheme,
+		loadbalancingv1alpha1.SchemeBuilder.AddToScheme,
+		accessv1alpha1.SchemeBuilder.AddToScheme,
+		tunnelv1alpha1.SchemeBuilder.AddToScheme,
+		sslv1alpha1.SchemeBuilder.AddToScheme,
+		rulesetsv1alpha1.SchemeBuilder.AddToScheme,
+		cachev1alpha1.SchemeBuilder.AddToScheme,
+		botsv1alpha1.SchemeBuilder.AddToScheme,
+		healthchecksv1alpha1.SchemeBuilder.AddToScheme,
+		waitingroomsv1alpha1.SchemeBuilder.AddToScheme,
+		turnstilev1alpha1.SchemeBuilder.AddToScheme,
+		r2v1alpha1.SchemeBuilder.AddToScheme,
+		queuesv1alpha1.SchemeBuilder.AddToScheme,
+		emailroutingv1alpha1.SchemeBuilder.AddToScheme,
 	)
 }
 
@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+
+	"github.com/benagricola/provider-cloudflare/apis/zone/v1alpha1"
+)
+
+// DNSSECParameters are the configurable fields of DNSSEC on a Zone.
+type DNSSECParameters struct {
+	// ZoneID DNSSEC is enabled on.
+	// +immutable
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+
+	// ZoneRef references the Zone object DNSSEC is enabled on.
+	// +immutable
+	// +optional
+	ZoneRef *xpv1.Reference `json:"zoneRef,omitempty"`
+
+	// ZoneSelector selects the Zone object DNSSEC is enabled on.
+	// +immutable
+	// +optional
+	ZoneSelector *xpv1.Selector `json:"zoneSelector,omitempty"`
+}
+
+// DNSSECObservation is the observable fields of DNSSEC on a Zone.
+// The DS record and its parts should be published at the registrar
+// of the Zone for DNSSEC to take effect.
+type DNSSECObservation struct {
+	// Status of DNSSEC on the Zone.
+	Status string `json:"status,omitempty"`
+
+	// Flags of the DNSKEY record.
+	Flags int `json:"flags,omitempty"`
+
+	// Algorithm of the key.
+	Algorithm string `json:"algorithm,omitempty"`
+
+	// KeyType is the type of the key.
+	KeyType string `json:"keyType,omitempty"`
+
+	// DigestType is the type of the digest.
+	DigestType string `json:"digestType,omitempty"`
+
+	// DigestAlgorithm is the hash algorithm of the digest.
+	DigestAlgorithm string `json:"digestAlgorithm,omitempty"`
+
+	// Digest of the key.
+	Digest string `json:"digest,omitempty"`
+
+	// DS is the full DS record to publish at the registrar.
+	DS string `json:"ds,omitempty"`
+
+	// KeyTag of the key.
+	KeyTag int `json:"keyTag,omitempty"`
+
+	// PublicKey is the public key of the DNSKEY record.
+	PublicKey string `json:"publicKey,omitempty"`
+
+	// ModifiedOn indicates when DNSSEC was last modified
+	// on Cloudflare.
+	ModifiedOn *metav1.Time `json:"modifiedOn,omitempty"`
+}
+
+// A DNSSECSpec defines the desired state of DNSSEC on a Zone.
+type DNSSECSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       DNSSECParameters `json:"forProvider"`
+}
+
+// A DNSSECStatus represents the observed state of DNSSEC on a Zone.
+type DNSSECStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          DNSSECObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A DNSSEC enables DNSSEC on a Zone. DNSSEC is disabled on the
+// Zone when the DNSSEC is deleted.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.status"
+// +kubebuilder:printcolumn:name="DS",type="string",JSONPath=".status.atProvider.ds",priority=1
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type DNSSEC struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DNSSECSpec   `json:"spec"`
+	Status DNSSECStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DNSSECList contains a list of DNSSEC objects
+type DNSSECList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSSEC `json:"items"`
+}
+
+// ResolveReferences resolves references to the Zone that DNSSEC
+// is enabled on.
+func (d *DNSSEC) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, d)
+
+	// Resolve spec.forProvider.zone
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(d.Spec.ForProvider.Zone),
+		Reference:    d.Spec.ForProvider.ZoneRef,
+		Selector:     d.Spec.ForProvider.ZoneSelector,
+		To:           reference.To{Managed: &v1alpha1.Zone{}, List: &v1alpha1.ZoneList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.zone")
+	}
+	d.Spec.ForProvider.Zone = reference.ToPtrValue(rsp.ResolvedValue)
+	d.Spec.ForProvider.ZoneRef = rsp.ResolvedReference
+
+	return nil
+}
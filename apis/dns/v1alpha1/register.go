@@ -45,6 +45,15 @@ var (
 	RecordGroupVersionKind = SchemeGroupVersion.WithKind(RecordKind)
 )
 
+// DNSSEC type metadata.
+var (
+	DNSSECKind             = reflect.TypeOf(DNSSEC{}).Name()
+	DNSSECGroupKind        = schema.GroupKind{Group: Group, Kind: DNSSECKind}.String()
+	DNSSECKindAPIVersion   = DNSSECKind + "." + SchemeGroupVersion.String()
+	DNSSECGroupVersionKind = SchemeGroupVersion.WithKind(DNSSECKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&Record{}, &RecordList{})
+	SchemeBuilder.Register(&DNSSEC{}, &DNSSECList{})
 }
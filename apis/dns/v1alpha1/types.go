@@ -30,6 +30,143 @@ import (
 	"github.com/benagricola/provider-cloudflare/apis/zone/v1alpha1"
 )
 
+// RecordSettings are the per-record settings of a DNS Record.
+type RecordSettings struct {
+	// FlattenCNAME flattens a CNAME record to the addresses of its
+	// target, rather than returning the CNAME itself.
+	// +optional
+	FlattenCNAME *bool `json:"flattenCname,omitempty"`
+
+	// IPv4Only answers proxied records with IPv4 addresses only.
+	// +optional
+	IPv4Only *bool `json:"ipv4Only,omitempty"`
+
+	// IPv6Only answers proxied records with IPv6 addresses only.
+	// +optional
+	IPv6Only *bool `json:"ipv6Only,omitempty"`
+}
+
+// RecordData is the structured data of SRV, LOC and CAA DNS Records.
+// Cloudflare generates the content of these records from their data.
+type RecordData struct {
+	// Service of an SRV record, such as _sip.
+	// +optional
+	Service *string `json:"service,omitempty"`
+
+	// Proto of an SRV record, such as _tcp.
+	// +optional
+	Proto *string `json:"proto,omitempty"`
+
+	// Name of an SRV record.
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// Priority of an SRV record.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
+
+	// Weight of an SRV record.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+
+	// Port of an SRV record.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+
+	// Target of an SRV record.
+	// +optional
+	Target *string `json:"target,omitempty"`
+
+	// Flags of a CAA record.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=255
+	// +optional
+	Flags *int32 `json:"flags,omitempty"`
+
+	// Tag of a CAA record.
+	// +kubebuilder:validation:Enum=issue;issuewild;iodef
+	// +optional
+	Tag *string `json:"tag,omitempty"`
+
+	// Value of a CAA record.
+	// +optional
+	Value *string `json:"value,omitempty"`
+
+	// LatDegrees is the degrees of latitude of a LOC record.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=90
+	// +optional
+	LatDegrees *int32 `json:"latDegrees,omitempty"`
+
+	// LatMinutes is the minutes of latitude of a LOC record.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=59
+	// +optional
+	LatMinutes *int32 `json:"latMinutes,omitempty"`
+
+	// LatSeconds is the seconds of latitude of a LOC record, as a
+	// decimal number.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +optional
+	LatSeconds *string `json:"latSeconds,omitempty"`
+
+	// LatDirection is the direction of latitude of a LOC record.
+	// +kubebuilder:validation:Enum=N;S
+	// +optional
+	LatDirection *string `json:"latDirection,omitempty"`
+
+	// LongDegrees is the degrees of longitude of a LOC record.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=180
+	// +optional
+	LongDegrees *int32 `json:"longDegrees,omitempty"`
+
+	// LongMinutes is the minutes of longitude of a LOC record.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=59
+	// +optional
+	LongMinutes *int32 `json:"longMinutes,omitempty"`
+
+	// LongSeconds is the seconds of longitude of a LOC record, as a
+	// decimal number.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +optional
+	LongSeconds *string `json:"longSeconds,omitempty"`
+
+	// LongDirection is the direction of longitude of a LOC record.
+	// +kubebuilder:validation:Enum=E;W
+	// +optional
+	LongDirection *string `json:"longDirection,omitempty"`
+
+	// Altitude of a LOC record in meters, as a decimal number.
+	// +kubebuilder:validation:Pattern=`^-?[0-9]+(\.[0-9]+)?$`
+	// +optional
+	Altitude *string `json:"altitude,omitempty"`
+
+	// Size of a LOC record in meters, as a decimal number.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +optional
+	Size *string `json:"size,omitempty"`
+
+	// PrecisionHorz is the horizontal precision of a LOC record in
+	// meters, as a decimal number.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +optional
+	PrecisionHorz *string `json:"precisionHorz,omitempty"`
+
+	// PrecisionVert is the vertical precision of a LOC record in
+	// meters, as a decimal number.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +optional
+	PrecisionVert *string `json:"precisionVert,omitempty"`
+}
+
 // RecordParameters are the configurable fields of a DNS Record.
 type RecordParameters struct {
 	// Type is the type of DNS Record.
@@ -43,8 +180,14 @@ type RecordParameters struct {
 	// +kubebuilder:validation:MaxLength=255
 	Name string `json:"name"`
 
-	// Content of the DNS Record
-	Content string `json:"content"`
+	// Content of the DNS Record. Required unless Data is set.
+	// +optional
+	Content string `json:"content,omitempty"`
+
+	// Data is the structured data of SRV, LOC and CAA records, which
+	// is used instead of Content.
+	// +optional
+	Data *RecordData `json:"data,omitempty"`
 
 	// TTL of the DNS Record.
 	// +kubebuilder:default=1
@@ -53,6 +196,10 @@ type RecordParameters struct {
 	TTL *int64 `json:"ttl,omitempty"`
 
 	// Proxied enables or disables proxying traffic via Cloudflare.
+	// When unset, records are not proxied unless the ProviderConfig sets
+	// proxyRecordsByDefault and the record is of a type that can be
+	// proxied (A, AAAA and CNAME). Wildcard records are only proxied by
+	// default on zones on the Enterprise plan.
 	// +optional
 	Proxied *bool `json:"proxied,omitempty"`
 
@@ -62,6 +209,11 @@ type RecordParameters struct {
 	// +optional
 	Priority *int32 `json:"priority,omitempty"`
 
+	// Settings of the DNS Record. Only the settings that are set
+	// are managed.
+	// +optional
+	Settings *RecordSettings `json:"settings,omitempty"`
+
 	// ZoneID this DNS Record is managed on.
 	// +immutable
 	// +optional
@@ -95,6 +247,10 @@ type RecordObservation struct {
 	// Locked indicates if this record is locked or not.
 	Locked bool `json:"locked,omitempty"`
 
+	// TTL is the TTL of the DNS Record in seconds, or 1
+	// when the TTL is automatic.
+	TTL int64 `json:"ttl,omitempty"`
+
 	// CreatedOn indicates when this record was created
 	// on Cloudflare.
 	CreatedOn *metav1.Time `json:"createdOn,omitempty"`
@@ -102,6 +258,10 @@ type RecordObservation struct {
 	// ModifiedOn indicates when this record was modified
 	// on Cloudflare.
 	ModifiedOn *metav1.Time `json:"modifiedOn,omitempty"`
+
+	// Settings contains the observed settings of the DNS Record,
+	// when any settings are managed.
+	Settings *RecordSettings `json:"settings,omitempty"`
 }
 
 // A RecordSpec defines the desired state of a DNS Record.
@@ -123,7 +283,7 @@ type RecordStatus struct {
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="FQDN",type="string",JSONPath=".status.atProvider.fqdn"
-// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".status.atProvider.createdOn"
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
 type Record struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -25,6 +25,148 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSSEC) DeepCopyInto(out *DNSSEC) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSEC.
+func (in *DNSSEC) DeepCopy() *DNSSEC {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSSEC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSSEC) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSSECList) DeepCopyInto(out *DNSSECList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSSEC, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSECList.
+func (in *DNSSECList) DeepCopy() *DNSSECList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSSECList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSSECList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSSECObservation) DeepCopyInto(out *DNSSECObservation) {
+	*out = *in
+	if in.ModifiedOn != nil {
+		in, out := &in.ModifiedOn, &out.ModifiedOn
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSECObservation.
+func (in *DNSSECObservation) DeepCopy() *DNSSECObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSSECObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSSECParameters) DeepCopyInto(out *DNSSECParameters) {
+	*out = *in
+	if in.Zone != nil {
+		in, out := &in.Zone, &out.Zone
+		*out = new(string)
+		**out = **in
+	}
+	if in.ZoneRef != nil {
+		in, out := &in.ZoneRef, &out.ZoneRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.ZoneSelector != nil {
+		in, out := &in.ZoneSelector, &out.ZoneSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSECParameters.
+func (in *DNSSECParameters) DeepCopy() *DNSSECParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSSECParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSSECSpec) DeepCopyInto(out *DNSSECSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSECSpec.
+func (in *DNSSECSpec) DeepCopy() *DNSSECSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSSECSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSSECStatus) DeepCopyInto(out *DNSSECStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSECStatus.
+func (in *DNSSECStatus) DeepCopy() *DNSSECStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSSECStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Record) DeepCopyInto(out *Record) {
 	*out = *in
@@ -52,6 +194,131 @@ func (in *Record) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordData) DeepCopyInto(out *RecordData) {
+	*out = *in
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(string)
+		**out = **in
+	}
+	if in.Proto != nil {
+		in, out := &in.Proto, &out.Proto
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(string)
+		**out = **in
+	}
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Tag != nil {
+		in, out := &in.Tag, &out.Tag
+		*out = new(string)
+		**out = **in
+	}
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(string)
+		**out = **in
+	}
+	if in.LatDegrees != nil {
+		in, out := &in.LatDegrees, &out.LatDegrees
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LatMinutes != nil {
+		in, out := &in.LatMinutes, &out.LatMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LatSeconds != nil {
+		in, out := &in.LatSeconds, &out.LatSeconds
+		*out = new(string)
+		**out = **in
+	}
+	if in.LatDirection != nil {
+		in, out := &in.LatDirection, &out.LatDirection
+		*out = new(string)
+		**out = **in
+	}
+	if in.LongDegrees != nil {
+		in, out := &in.LongDegrees, &out.LongDegrees
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LongMinutes != nil {
+		in, out := &in.LongMinutes, &out.LongMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LongSeconds != nil {
+		in, out := &in.LongSeconds, &out.LongSeconds
+		*out = new(string)
+		**out = **in
+	}
+	if in.LongDirection != nil {
+		in, out := &in.LongDirection, &out.LongDirection
+		*out = new(string)
+		**out = **in
+	}
+	if in.Altitude != nil {
+		in, out := &in.Altitude, &out.Altitude
+		*out = new(string)
+		**out = **in
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		*out = new(string)
+		**out = **in
+	}
+	if in.PrecisionHorz != nil {
+		in, out := &in.PrecisionHorz, &out.PrecisionHorz
+		*out = new(string)
+		**out = **in
+	}
+	if in.PrecisionVert != nil {
+		in, out := &in.PrecisionVert, &out.PrecisionVert
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordData.
+func (in *RecordData) DeepCopy() *RecordData {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordData)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RecordList) DeepCopyInto(out *RecordList) {
 	*out = *in
@@ -95,6 +362,11 @@ func (in *RecordObservation) DeepCopyInto(out *RecordObservation) {
 		in, out := &in.ModifiedOn, &out.ModifiedOn
 		*out = (*in).DeepCopy()
 	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = new(RecordSettings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordObservation.
@@ -115,6 +387,11 @@ func (in *RecordParameters) DeepCopyInto(out *RecordParameters) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = new(RecordData)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TTL != nil {
 		in, out := &in.TTL, &out.TTL
 		*out = new(int64)
@@ -130,6 +407,11 @@ func (in *RecordParameters) DeepCopyInto(out *RecordParameters) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = new(RecordSettings)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Zone != nil {
 		in, out := &in.Zone, &out.Zone
 		*out = new(string)
@@ -157,6 +439,36 @@ func (in *RecordParameters) DeepCopy() *RecordParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordSettings) DeepCopyInto(out *RecordSettings) {
+	*out = *in
+	if in.FlattenCNAME != nil {
+		in, out := &in.FlattenCNAME, &out.FlattenCNAME
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IPv4Only != nil {
+		in, out := &in.IPv4Only, &out.IPv4Only
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IPv6Only != nil {
+		in, out := &in.IPv6Only, &out.IPv6Only
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordSettings.
+func (in *RecordSettings) DeepCopy() *RecordSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RecordSpec) DeepCopyInto(out *RecordSpec) {
 	*out = *in
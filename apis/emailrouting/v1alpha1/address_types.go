@@ -0,0 +1,136 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+
+	account "github.com/benagricola/provider-cloudflare/apis/account/v1alpha1"
+)
+
+// EmailRoutingAddressParameters are the configurable fields of an Email
+// Routing destination address.
+type EmailRoutingAddressParameters struct {
+	// Account is the account ID this destination address is created
+	// under.
+	// +immutable
+	// +optional
+	Account *string `json:"account,omitempty"`
+
+	// AccountRef references the Account object this destination address
+	// is created under.
+	// +immutable
+	// +optional
+	AccountRef *xpv1.Reference `json:"accountRef,omitempty"`
+
+	// AccountSelector selects the Account object this destination address
+	// is created under.
+	// +immutable
+	// +optional
+	AccountSelector *xpv1.Selector `json:"accountSelector,omitempty"`
+
+	// Email is the destination address emails are forwarded to.
+	// +immutable
+	Email string `json:"email"`
+}
+
+// EmailRoutingAddressObservation are the observable fields of an Email
+// Routing destination address.
+type EmailRoutingAddressObservation struct {
+	// Verified indicates whether the owner of the destination address has
+	// verified it. Emails are only forwarded to verified addresses.
+	Verified bool `json:"verified,omitempty"`
+
+	// VerifiedOn indicates when the destination address was verified.
+	VerifiedOn *metav1.Time `json:"verifiedOn,omitempty"`
+
+	// CreatedOn indicates when the destination address was created.
+	CreatedOn *metav1.Time `json:"createdOn,omitempty"`
+}
+
+// An EmailRoutingAddressSpec defines the desired state of an Email Routing
+// destination address.
+type EmailRoutingAddressSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       EmailRoutingAddressParameters `json:"forProvider"`
+}
+
+// An EmailRoutingAddressStatus represents the observed state of an Email
+// Routing destination address.
+type EmailRoutingAddressStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          EmailRoutingAddressObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An EmailRoutingAddress is a destination address that Email Routing
+// Rules forward emails to. Cloudflare emails the address a verification
+// link when it is created, and the EmailRoutingAddress is not available
+// until the link has been followed.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EMAIL",type="string",JSONPath=".spec.forProvider.email"
+// +kubebuilder:printcolumn:name="VERIFIED",type="boolean",JSONPath=".status.atProvider.verified"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type EmailRoutingAddress struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EmailRoutingAddressSpec   `json:"spec"`
+	Status EmailRoutingAddressStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EmailRoutingAddressList contains a list of EmailRoutingAddress objects
+type EmailRoutingAddressList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EmailRoutingAddress `json:"items"`
+}
+
+// ResolveReferences resolves references to the Account that this
+// destination address is created under.
+func (a *EmailRoutingAddress) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, a)
+
+	// Resolve spec.forProvider.account
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(a.Spec.ForProvider.Account),
+		Reference:    a.Spec.ForProvider.AccountRef,
+		Selector:     a.Spec.ForProvider.AccountSelector,
+		To:           reference.To{Managed: &account.Account{}, List: &account.AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.account")
+	}
+	a.Spec.ForProvider.Account = reference.ToPtrValue(rsp.ResolvedValue)
+	a.Spec.ForProvider.AccountRef = rsp.ResolvedReference
+
+	return nil
+}
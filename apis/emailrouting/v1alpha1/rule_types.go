@@ -0,0 +1,182 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+
+	zone "github.com/benagricola/provider-cloudflare/apis/zone/v1alpha1"
+)
+
+// EmailRoutingRuleMatcher matches the emails an Email Routing Rule
+// applies to.
+type EmailRoutingRuleMatcher struct {
+	// Type of the matcher. A literal matcher matches emails whose Field
+	// equals Value, while an all matcher matches every email.
+	// +kubebuilder:validation:Enum=literal;all
+	Type string `json:"type"`
+
+	// Field of the email to match, such as to.
+	// +optional
+	Field *string `json:"field,omitempty"`
+
+	// Value the field of the email must equal.
+	// +optional
+	Value *string `json:"value,omitempty"`
+}
+
+// EmailRoutingRuleAction is an action taken on emails matched by an Email
+// Routing Rule.
+type EmailRoutingRuleAction struct {
+	// Type of the action. Forward actions send matched emails to the
+	// verified destination addresses in Value, worker actions send them to
+	// the Worker Script named in Value, and drop actions discard them.
+	// +kubebuilder:validation:Enum=forward;worker;drop
+	Type string `json:"type"`
+
+	// Value of the action, such as the destination addresses of a forward
+	// action.
+	// +optional
+	Value []string `json:"value,omitempty"`
+}
+
+// EmailRoutingRuleParameters are the configurable fields of an Email
+// Routing Rule.
+type EmailRoutingRuleParameters struct {
+	// ZoneID this Email Routing Rule is created in.
+	// +immutable
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+
+	// ZoneRef references the Zone object this Email Routing Rule is
+	// created in.
+	// +immutable
+	// +optional
+	ZoneRef *xpv1.Reference `json:"zoneRef,omitempty"`
+
+	// ZoneSelector selects the Zone object this Email Routing Rule is
+	// created in.
+	// +immutable
+	// +optional
+	ZoneSelector *xpv1.Selector `json:"zoneSelector,omitempty"`
+
+	// CatchAll manages the catch-all rule of the Zone, which applies to
+	// emails not matched by any other rule, rather than creating a new
+	// rule. Matchers are ignored for the catch-all rule.
+	// +immutable
+	// +optional
+	CatchAll *bool `json:"catchAll,omitempty"`
+
+	// Name of the Email Routing Rule.
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// Enabled controls whether the Email Routing Rule is applied.
+	// +kubebuilder:default=true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Priority of the Email Routing Rule. Rules with a lower priority are
+	// applied first.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Priority *int `json:"priority,omitempty"`
+
+	// Matchers match the emails the Email Routing Rule applies to.
+	// +optional
+	Matchers []EmailRoutingRuleMatcher `json:"matchers,omitempty"`
+
+	// Actions taken on matched emails.
+	// +kubebuilder:validation:MinItems=1
+	Actions []EmailRoutingRuleAction `json:"actions"`
+}
+
+// EmailRoutingRuleObservation are the observable fields of an Email
+// Routing Rule.
+type EmailRoutingRuleObservation struct{}
+
+// An EmailRoutingRuleSpec defines the desired state of an Email Routing
+// Rule.
+type EmailRoutingRuleSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       EmailRoutingRuleParameters `json:"forProvider"`
+}
+
+// An EmailRoutingRuleStatus represents the observed state of an Email
+// Routing Rule.
+type EmailRoutingRuleStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          EmailRoutingRuleObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An EmailRoutingRule routes emails sent to addresses of a Zone, such as
+// by forwarding them to verified EmailRoutingAddresses. The catch-all rule
+// of the Zone is disabled, rather than deleted, when an EmailRoutingRule
+// managing it is deleted.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="ENABLED",type="boolean",JSONPath=".spec.forProvider.enabled"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type EmailRoutingRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EmailRoutingRuleSpec   `json:"spec"`
+	Status EmailRoutingRuleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EmailRoutingRuleList contains a list of EmailRoutingRule objects
+type EmailRoutingRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EmailRoutingRule `json:"items"`
+}
+
+// ResolveReferences resolves references to the Zone that this Email
+// Routing Rule is created in.
+func (rl *EmailRoutingRule) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, rl)
+
+	// Resolve spec.forProvider.zone
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(rl.Spec.ForProvider.Zone),
+		Reference:    rl.Spec.ForProvider.ZoneRef,
+		Selector:     rl.Spec.ForProvider.ZoneSelector,
+		To:           reference.To{Managed: &zone.Zone{}, List: &zone.ZoneList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.zone")
+	}
+	rl.Spec.ForProvider.Zone = reference.ToPtrValue(rsp.ResolvedValue)
+	rl.Spec.ForProvider.ZoneRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+
+	zone "github.com/benagricola/provider-cloudflare/apis/zone/v1alpha1"
+)
+
+// EmailRoutingSettingsParameters are the configurable fields of Email
+// Routing on a Zone.
+type EmailRoutingSettingsParameters struct {
+	// ZoneID Email Routing is enabled on.
+	// +immutable
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+
+	// ZoneRef references the Zone object Email Routing is enabled on.
+	// +immutable
+	// +optional
+	ZoneRef *xpv1.Reference `json:"zoneRef,omitempty"`
+
+	// ZoneSelector selects the Zone object Email Routing is enabled on.
+	// +immutable
+	// +optional
+	ZoneSelector *xpv1.Selector `json:"zoneSelector,omitempty"`
+}
+
+// EmailRoutingSettingsObservation are the observable fields of Email
+// Routing on a Zone.
+type EmailRoutingSettingsObservation struct {
+	// Name of the Zone Email Routing is enabled on.
+	Name string `json:"name,omitempty"`
+
+	// Status of Email Routing, such as ready, unconfigured or
+	// misconfigured.
+	Status string `json:"status,omitempty"`
+
+	// CreatedOn indicates when Email Routing was first enabled.
+	CreatedOn *metav1.Time `json:"createdOn,omitempty"`
+
+	// ModifiedOn indicates when Email Routing was last modified.
+	ModifiedOn *metav1.Time `json:"modifiedOn,omitempty"`
+}
+
+// An EmailRoutingSettingsSpec defines the desired state of Email Routing
+// on a Zone.
+type EmailRoutingSettingsSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       EmailRoutingSettingsParameters `json:"forProvider"`
+}
+
+// An EmailRoutingSettingsStatus represents the observed state of Email
+// Routing on a Zone.
+type EmailRoutingSettingsStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          EmailRoutingSettingsObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An EmailRoutingSettings enables Email Routing on a Zone, adding the MX
+// and SPF records it requires. Email Routing is disabled on the Zone when
+// the EmailRoutingSettings is deleted.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=emailroutingsettings,scope=Cluster,categories={crossplane,managed,cloudflare}
+type EmailRoutingSettings struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EmailRoutingSettingsSpec   `json:"spec"`
+	Status EmailRoutingSettingsStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EmailRoutingSettingsList contains a list of EmailRoutingSettings objects
+type EmailRoutingSettingsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EmailRoutingSettings `json:"items"`
+}
+
+// ResolveReferences resolves references to the Zone that Email Routing is
+// enabled on.
+func (s *EmailRoutingSettings) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, s)
+
+	// Resolve spec.forProvider.zone
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(s.Spec.ForProvider.Zone),
+		Reference:    s.Spec.ForProvider.ZoneRef,
+		Selector:     s.Spec.ForProvider.ZoneSelector,
+		To:           reference.To{Managed: &zone.Zone{}, List: &zone.ZoneList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.zone")
+	}
+	s.Spec.ForProvider.Zone = reference.ToPtrValue(rsp.ResolvedValue)
+	s.Spec.ForProvider.ZoneRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+
+	zone "github.com/benagricola/provider-cloudflare/apis/zone/v1alpha1"
+
+	"github.com/pkg/errors"
+)
+
+// RateLimitMethod is a HTTP method matched by a Rate Limit.
+// +kubebuilder:validation:Enum=GET;POST;PUT;DELETE;PATCH;HEAD;_ALL_
+type RateLimitMethod string
+
+// RateLimitScheme is a HTTP scheme matched by a Rate Limit.
+// +kubebuilder:validation:Enum=HTTP;HTTPS;_ALL_
+type RateLimitScheme string
+
+// RateLimitRequestMatch matches the requests counted by a Rate Limit.
+type RateLimitRequestMatch struct {
+	// Methods are the HTTP methods to match. All methods are
+	// matched when unset.
+	// +optional
+	Methods []RateLimitMethod `json:"methods,omitempty"`
+
+	// Schemes are the HTTP schemes to match. All schemes are
+	// matched when unset.
+	// +optional
+	Schemes []RateLimitScheme `json:"schemes,omitempty"`
+
+	// URL pattern to match, which may contain wildcards.
+	// +kubebuilder:validation:MaxLength=1024
+	// +optional
+	URL *string `json:"url,omitempty"`
+}
+
+// RateLimitResponseHeader matches a header of the response to a request
+// counted by a Rate Limit.
+type RateLimitResponseHeader struct {
+	// Name of the response header.
+	Name string `json:"name"`
+
+	// Op is the operator used to compare the header value.
+	// +kubebuilder:validation:Enum=eq;ne
+	Op string `json:"op"`
+
+	// Value the response header is compared with.
+	Value string `json:"value"`
+}
+
+// RateLimitResponseMatch matches the responses to requests counted by a
+// Rate Limit.
+type RateLimitResponseMatch struct {
+	// Statuses are the HTTP response status codes to match.
+	// +optional
+	Statuses []int64 `json:"statuses,omitempty"`
+
+	// OriginTraffic matches only traffic that is sent to the origin,
+	// rather than served from cache.
+	// +optional
+	OriginTraffic *bool `json:"originTraffic,omitempty"`
+
+	// Headers are the response headers to match.
+	// +optional
+	Headers []RateLimitResponseHeader `json:"headers,omitempty"`
+}
+
+// RateLimitMatch determines which traffic is counted by a Rate Limit.
+type RateLimitMatch struct {
+	// Request matches the requests to count.
+	// +optional
+	Request *RateLimitRequestMatch `json:"request,omitempty"`
+
+	// Response matches the responses to count.
+	// +optional
+	Response *RateLimitResponseMatch `json:"response,omitempty"`
+}
+
+// RateLimitActionResponse is a custom response returned to clients
+// that reach a Rate Limit.
+type RateLimitActionResponse struct {
+	// ContentType of the response body.
+	// +kubebuilder:validation:Enum=text/plain;text/xml;application/json
+	ContentType string `json:"contentType"`
+
+	// Body of the response.
+	// +kubebuilder:validation:MaxLength=10240
+	Body string `json:"body"`
+}
+
+// RateLimitAction is the action taken when a Rate Limit is reached.
+type RateLimitAction struct {
+	// Mode is the action to apply to matching traffic.
+	// +kubebuilder:validation:Enum=simulate;ban;challenge;js_challenge
+	Mode string `json:"mode"`
+
+	// Timeout is the time in seconds that the action is applied
+	// for. Required for the simulate and ban modes.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=86400
+	// +optional
+	Timeout *int64 `json:"timeout,omitempty"`
+
+	// Response is a custom response returned to clients that reach
+	// the Rate Limit, for the simulate and ban modes.
+	// +optional
+	Response *RateLimitActionResponse `json:"response,omitempty"`
+}
+
+// RateLimitBypass is a criteria for traffic that bypasses a Rate Limit.
+type RateLimitBypass struct {
+	// Name of the bypass criteria.
+	// +kubebuilder:validation:Enum=url
+	Name string `json:"name"`
+
+	// Value traffic is compared with to bypass the Rate Limit.
+	Value string `json:"value"`
+}
+
+// RateLimitParameters are the configurable fields of a Rate Limit.
+type RateLimitParameters struct {
+	// Description is a human readable description of this Rate Limit.
+	// +kubebuilder:validation:MaxLength=1024
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Disabled indicates if this Rate Limit is disabled or not.
+	// +optional
+	Disabled *bool `json:"disabled,omitempty"`
+
+	// Threshold is the number of requests within the period that
+	// triggers the Rate Limit.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000000
+	Threshold int64 `json:"threshold"`
+
+	// Period in seconds over which requests are counted.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=86400
+	Period int64 `json:"period"`
+
+	// Match determines which traffic is counted by this Rate Limit.
+	// +optional
+	Match *RateLimitMatch `json:"match,omitempty"`
+
+	// Action is the action taken when this Rate Limit is reached.
+	Action RateLimitAction `json:"action"`
+
+	// Bypass lists criteria for traffic that bypasses this Rate Limit.
+	// +optional
+	Bypass []RateLimitBypass `json:"bypass,omitempty"`
+
+	// CorrelateBy counts traffic using a correlation other than the
+	// client IP address.
+	// +kubebuilder:validation:Enum=nat
+	// +optional
+	CorrelateBy *string `json:"correlateBy,omitempty"`
+
+	// ZoneID this Rate Limit is for.
+	// +immutable
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+
+	// ZoneRef references the zone object this Rate Limit is for.
+	// +immutable
+	// +optional
+	ZoneRef *xpv1.Reference `json:"zoneRef,omitempty"`
+
+	// ZoneSelector selects the zone object this Rate Limit is for.
+	// +immutable
+	// +optional
+	ZoneSelector *xpv1.Selector `json:"zoneSelector,omitempty"`
+}
+
+// RateLimitObservation is the observable fields of a Rate Limit.
+type RateLimitObservation struct{}
+
+// A RateLimitSpec defines the desired state of a Rate Limit.
+type RateLimitSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RateLimitParameters `json:"forProvider"`
+}
+
+// A RateLimitStatus represents the observed state of a Rate Limit.
+type RateLimitStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          RateLimitObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RateLimit limits the rate of matching traffic to a Zone.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="THRESHOLD",type="integer",JSONPath=".spec.forProvider.threshold"
+// +kubebuilder:printcolumn:name="PERIOD",type="integer",JSONPath=".spec.forProvider.period"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type RateLimit struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RateLimitSpec   `json:"spec"`
+	Status RateLimitStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RateLimitList contains a list of RateLimit
+type RateLimitList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RateLimit `json:"items"`
+}
+
+// ResolveReferences of this Rate Limit
+func (rl *RateLimit) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, rl)
+
+	// Resolve spec.forProvider.zone
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(rl.Spec.ForProvider.Zone),
+		Reference:    rl.Spec.ForProvider.ZoneRef,
+		Selector:     rl.Spec.ForProvider.ZoneSelector,
+		To:           reference.To{Managed: &zone.Zone{}, List: &zone.ZoneList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.zone")
+	}
+	rl.Spec.ForProvider.Zone = reference.ToPtrValue(rsp.ResolvedValue)
+	rl.Spec.ForProvider.ZoneRef = rsp.ResolvedReference
+	return nil
+}
@@ -53,7 +53,25 @@ var (
 	FilterGroupVersionKind = SchemeGroupVersion.WithKind(FilterKind)
 )
 
+// RateLimit type metadata.
+var (
+	RateLimitKind             = reflect.TypeOf(RateLimit{}).Name()
+	RateLimitGroupKind        = schema.GroupKind{Group: Group, Kind: RateLimitKind}.String()
+	RateLimitKindAPIVersion   = RateLimitKind + "." + SchemeGroupVersion.String()
+	RateLimitGroupVersionKind = SchemeGroupVersion.WithKind(RateLimitKind)
+)
+
+// RuleOrder type metadata.
+var (
+	RuleOrderKind             = reflect.TypeOf(RuleOrder{}).Name()
+	RuleOrderGroupKind        = schema.GroupKind{Group: Group, Kind: RuleOrderKind}.String()
+	RuleOrderKindAPIVersion   = RuleOrderKind + "." + SchemeGroupVersion.String()
+	RuleOrderGroupVersionKind = SchemeGroupVersion.WithKind(RuleOrderKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&Rule{}, &RuleList{})
 	SchemeBuilder.Register(&Filter{}, &FilterList{})
+	SchemeBuilder.Register(&RateLimit{}, &RateLimitList{})
+	SchemeBuilder.Register(&RuleOrder{}, &RuleOrderList{})
 }
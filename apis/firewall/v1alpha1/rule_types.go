@@ -76,7 +76,9 @@ type RuleParameters struct {
 
 	// Priority is the priority of this Firewall Rule, that controls
 	// processing order. Rules without a priority set will be sequenced
-	// after rules with a priority set.
+	// after rules with a priority set. The priority of Rules ordered by a
+	// RuleOrder is assigned by the RuleOrder, and this priority is ignored
+	// until the RuleOrder is deleted.
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=2147483647
 	// +optional
@@ -99,7 +101,11 @@ type RuleParameters struct {
 }
 
 // RuleObservation is the observable fields of a Rule.
-type RuleObservation struct{}
+type RuleObservation struct {
+	// OrderedBy is the name of the RuleOrder that assigns the priority of
+	// this Rule, if any.
+	OrderedBy string `json:"orderedBy,omitempty"`
+}
 
 // A RuleSpec defines the desired state of a Rule.
 type RuleSpec struct {
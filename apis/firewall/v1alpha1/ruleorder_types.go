@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// RuleOrderParameters are the configurable fields of a RuleOrder.
+type RuleOrderParameters struct {
+	// RuleRefs references the Rules to order, starting with the Rule that
+	// should be processed first. Each Rule is assigned a priority higher than
+	// the Rule before it, taking precedence over any priority set on the Rule
+	// itself. All Rules must be on the same Zone.
+	// +kubebuilder:validation:MinItems=1
+	RuleRefs []xpv1.Reference `json:"ruleRefs"`
+
+	// StartPriority is the priority assigned to the first Rule.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=2147483647
+	// +kubebuilder:default=1
+	// +optional
+	StartPriority *int32 `json:"startPriority,omitempty"`
+
+	// PriorityStep is the difference in priority between consecutive
+	// Rules. Leaving gaps allows Rules that are not ordered by this
+	// RuleOrder to be placed between them.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	PriorityStep *int32 `json:"priorityStep,omitempty"`
+}
+
+// RuleOrderRuleObservation is the observed ordering of a Rule in a
+// RuleOrder.
+type RuleOrderRuleObservation struct {
+	// Name of the Rule.
+	Name string `json:"name"`
+
+	// ID of the Firewall Rule, once it has been created.
+	ID string `json:"id,omitempty"`
+
+	// Priority is the priority this RuleOrder assigns to the Rule.
+	Priority int32 `json:"priority"`
+
+	// ObservedPriority is the priority of the Firewall Rule in Cloudflare.
+	ObservedPriority *int32 `json:"observedPriority,omitempty"`
+}
+
+// RuleOrderObservation are the observable fields of a RuleOrder.
+type RuleOrderObservation struct {
+	// Zone is the ID of the Zone the ordered Rules are on.
+	Zone string `json:"zone,omitempty"`
+
+	// Rules are the ordered Rules, starting with the Rule that is processed
+	// first.
+	Rules []RuleOrderRuleObservation `json:"rules,omitempty"`
+}
+
+// A RuleOrderSpec defines the desired state of a RuleOrder.
+type RuleOrderSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RuleOrderParameters `json:"forProvider"`
+}
+
+// A RuleOrderStatus represents the observed state of a RuleOrder.
+type RuleOrderStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          RuleOrderObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RuleOrder processes a set of Firewall Rules in a declared order, by
+// assigning them priorities and restoring their order if they are
+// reordered outside of Crossplane.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type RuleOrder struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RuleOrderSpec   `json:"spec"`
+	Status RuleOrderStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RuleOrderList contains a list of RuleOrder
+type RuleOrderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RuleOrder `json:"items"`
+}
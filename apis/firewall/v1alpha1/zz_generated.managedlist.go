@@ -28,6 +28,15 @@ func (l *FilterList) GetItems() []resource.Managed {
 	return items
 }
 
+// GetItems of this RateLimitList.
+func (l *RateLimitList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
 // GetItems of this RuleList.
 func (l *RuleList) GetItems() []resource.Managed {
 	items := make([]resource.Managed, len(l.Items))
@@ -36,3 +45,12 @@ func (l *RuleList) GetItems() []resource.Managed {
 	}
 	return items
 }
+
+// GetItems of this RuleOrderList.
+func (l *RuleOrderList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
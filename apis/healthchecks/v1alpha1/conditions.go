@@ -0,0 +1,70 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TypeHealthy indicates whether the origin checked by a HealthCheck was
+// healthy when it was last checked.
+const TypeHealthy xpv1.ConditionType = "Healthy"
+
+// Reasons an origin is or is not healthy.
+const (
+	ReasonHealthy       xpv1.ConditionReason = "Healthy"
+	ReasonUnhealthy     xpv1.ConditionReason = "Unhealthy"
+	ReasonHealthUnknown xpv1.ConditionReason = "HealthUnknown"
+)
+
+// Healthy returns a condition that indicates the origin passed its most
+// recent health check.
+func Healthy() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeHealthy,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonHealthy,
+	}
+}
+
+// Unhealthy returns a condition that indicates the origin failed its most
+// recent health check for the passed reason.
+func Unhealthy(reason string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeHealthy,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUnhealthy,
+		Message:            reason,
+	}
+}
+
+// HealthUnknown returns a condition that indicates the health of the
+// origin is not known, because it has not been checked yet or health
+// checks are suspended.
+func HealthUnknown() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeHealthy,
+		Status:             corev1.ConditionUnknown,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonHealthUnknown,
+	}
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	zone "github.com/benagricola/provider-cloudflare/apis/zone/v1alpha1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+)
+
+// HealthCheckHTTPConfig configures HTTP and HTTPS health checks.
+type HealthCheckHTTPConfig struct {
+	// Method is the HTTP method used for health checks.
+	// +kubebuilder:validation:Enum=GET;HEAD
+	// +optional
+	Method *string `json:"method,omitempty"`
+
+	// Port is the port to send health checks to.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port *int `json:"port,omitempty"`
+
+	// Path is the endpoint path of health checks.
+	// +optional
+	Path *string `json:"path,omitempty"`
+
+	// ExpectedCodes are the expected HTTP response codes or code ranges
+	// of health checks, e.g. "2xx".
+	// +optional
+	ExpectedCodes []string `json:"expectedCodes,omitempty"`
+
+	// ExpectedBody is a case-insensitive sub-string to look for in the
+	// response body of health checks.
+	// +optional
+	ExpectedBody *string `json:"expectedBody,omitempty"`
+
+	// FollowRedirects follows redirects returned by the origin.
+	// +optional
+	FollowRedirects *bool `json:"followRedirects,omitempty"`
+
+	// AllowInsecure skips validation of the certificate of the origin
+	// for HTTPS health checks.
+	// +optional
+	AllowInsecure *bool `json:"allowInsecure,omitempty"`
+
+	// Header is a list of HTTP request headers to send with health
+	// checks.
+	// +optional
+	Header map[string][]string `json:"header,omitempty"`
+}
+
+// HealthCheckTCPConfig configures TCP health checks.
+type HealthCheckTCPConfig struct {
+	// Method is the TCP connection method used for health checks.
+	// +kubebuilder:validation:Enum=connection_established
+	// +optional
+	Method *string `json:"method,omitempty"`
+
+	// Port is the port to send health checks to.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port *int `json:"port,omitempty"`
+}
+
+// HealthCheckParameters are the configurable fields of a HealthCheck.
+type HealthCheckParameters struct {
+	// Name of the HealthCheck.
+	Name string `json:"name"`
+
+	// Description is a human readable description of the HealthCheck.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Address is the hostname or IP address of the origin to check.
+	Address string `json:"address"`
+
+	// Type is the protocol to use for health checks.
+	// +kubebuilder:validation:Enum=HTTP;HTTPS;TCP
+	// +optional
+	Type *string `json:"type,omitempty"`
+
+	// Suspended stops health checks from being sent.
+	// +optional
+	Suspended *bool `json:"suspended,omitempty"`
+
+	// CheckRegions are the regions health checks are sent from.
+	// +optional
+	CheckRegions []HealthCheckRegion `json:"checkRegions,omitempty"`
+
+	// Retries is the number of retries to attempt before marking the
+	// origin as unhealthy.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=5
+	// +optional
+	Retries *int `json:"retries,omitempty"`
+
+	// Timeout is the timeout in seconds of each health check.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	Timeout *int `json:"timeout,omitempty"`
+
+	// Interval is the number of seconds between health checks.
+	// +kubebuilder:validation:Minimum=5
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	Interval *int `json:"interval,omitempty"`
+
+	// ConsecutiveSuccesses is the number of consecutive successful
+	// health checks before the origin is marked as healthy.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ConsecutiveSuccesses *int `json:"consecutiveSuccesses,omitempty"`
+
+	// ConsecutiveFails is the number of consecutive failed health checks
+	// before the origin is marked as unhealthy.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ConsecutiveFails *int `json:"consecutiveFails,omitempty"`
+
+	// HTTPConfig configures HTTP and HTTPS health checks.
+	// +optional
+	HTTPConfig *HealthCheckHTTPConfig `json:"httpConfig,omitempty"`
+
+	// TCPConfig configures TCP health checks.
+	// +optional
+	TCPConfig *HealthCheckTCPConfig `json:"tcpConfig,omitempty"`
+
+	// ZoneID this HealthCheck is for.
+	// +immutable
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+
+	// ZoneRef references the zone object this HealthCheck is for.
+	// +immutable
+	// +optional
+	ZoneRef *xpv1.Reference `json:"zoneRef,omitempty"`
+
+	// ZoneSelector selects the zone object this HealthCheck is for.
+	// +immutable
+	// +optional
+	ZoneSelector *xpv1.Selector `json:"zoneSelector,omitempty"`
+}
+
+// HealthCheckRegion is a region health checks are sent from.
+// +kubebuilder:validation:Enum=WNAM;ENAM;WEU;EEU;NSAM;SSAM;OC;ME;NAF;SAF;IN;SEAS;NEAS;ALL_REGIONS
+type HealthCheckRegion string
+
+// HealthCheckObservation are the observable fields of a HealthCheck.
+type HealthCheckObservation struct {
+	// Status is the result of the most recent health check, one of
+	// unknown, healthy, unhealthy or suspended.
+	Status string `json:"status,omitempty"`
+
+	// FailureReason is the reason the most recent health check failed.
+	FailureReason string `json:"failureReason,omitempty"`
+
+	// CreatedOn indicates when the HealthCheck was created.
+	CreatedOn *metav1.Time `json:"createdOn,omitempty"`
+
+	// ModifiedOn indicates when the HealthCheck was last modified.
+	ModifiedOn *metav1.Time `json:"modifiedOn,omitempty"`
+}
+
+// A HealthCheckSpec defines the desired state of a HealthCheck.
+type HealthCheckSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       HealthCheckParameters `json:"forProvider"`
+}
+
+// A HealthCheckStatus represents the observed state of a HealthCheck.
+type HealthCheckStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          HealthCheckObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A HealthCheck is a Cloudflare standalone health check, which monitors
+// the health of an origin independently of Load Balancing. The result of
+// the most recent check is reported by the Healthy condition.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="HEALTHY",type="string",JSONPath=".status.conditions[?(@.type=='Healthy')].status"
+// +kubebuilder:printcolumn:name="ADDRESS",type="string",JSONPath=".spec.forProvider.address"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type HealthCheck struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HealthCheckSpec   `json:"spec"`
+	Status HealthCheckStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// HealthCheckList contains a list of HealthCheck objects
+type HealthCheckList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HealthCheck `json:"items"`
+}
+
+// ResolveReferences resolves references to the Zone that this HealthCheck
+// is for.
+func (h *HealthCheck) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, h)
+
+	// Resolve spec.forProvider.zone
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(h.Spec.ForProvider.Zone),
+		Reference:    h.Spec.ForProvider.ZoneRef,
+		Selector:     h.Spec.ForProvider.ZoneSelector,
+		To:           reference.To{Managed: &zone.Zone{}, List: &zone.ZoneList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.zone")
+	}
+	h.Spec.ForProvider.Zone = reference.ToPtrValue(rsp.ResolvedValue)
+	h.Spec.ForProvider.ZoneRef = rsp.ResolvedReference
+
+	return nil
+}
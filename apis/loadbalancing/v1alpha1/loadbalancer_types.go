@@ -0,0 +1,228 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+
+	zone "github.com/benagricola/provider-cloudflare/apis/zone/v1alpha1"
+
+	"github.com/pkg/errors"
+)
+
+// LoadBalancerParameters are the configurable fields of a LoadBalancer.
+type LoadBalancerParameters struct {
+	// DefaultPools are the Pool IDs to steer traffic to, in failover
+	// order, when no region or PoP specific pools apply.
+	// +optional
+	DefaultPools []string `json:"defaultPools,omitempty"`
+
+	// DefaultPoolRefs references the LoadBalancerPool objects to
+	// steer traffic to, in failover order.
+	// +optional
+	DefaultPoolRefs []xpv1.Reference `json:"defaultPoolRefs,omitempty"`
+
+	// DefaultPoolSelector selects the LoadBalancerPool objects to
+	// steer traffic to.
+	// +optional
+	DefaultPoolSelector *xpv1.Selector `json:"defaultPoolSelector,omitempty"`
+
+	// Description is a human readable description of this
+	// LoadBalancer.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Enabled indicates whether this LoadBalancer serves traffic.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// FallbackPool is the Pool ID to steer traffic to when all
+	// other pools are unhealthy.
+	// +optional
+	FallbackPool *string `json:"fallbackPool,omitempty"`
+
+	// FallbackPoolRef references the LoadBalancerPool object to
+	// steer traffic to when all other pools are unhealthy.
+	// +optional
+	FallbackPoolRef *xpv1.Reference `json:"fallbackPoolRef,omitempty"`
+
+	// FallbackPoolSelector selects the LoadBalancerPool object to
+	// steer traffic to when all other pools are unhealthy.
+	// +optional
+	FallbackPoolSelector *xpv1.Selector `json:"fallbackPoolSelector,omitempty"`
+
+	// Name is the DNS hostname this LoadBalancer is served on.
+	// +kubebuilder:validation:Format=hostname
+	// +immutable
+	Name string `json:"name"`
+
+	// PopPools maps Cloudflare PoP codes to a list of Pool IDs,
+	// in failover order, to steer traffic from that PoP to.
+	// +optional
+	PopPools map[string][]string `json:"popPools,omitempty"`
+
+	// Proxied indicates whether traffic passes through Cloudflare.
+	// +optional
+	Proxied *bool `json:"proxied,omitempty"`
+
+	// RegionPools maps region codes to a list of Pool IDs, in
+	// failover order, to steer traffic from that region to.
+	// +optional
+	RegionPools map[string][]string `json:"regionPools,omitempty"`
+
+	// SessionAffinity is the session affinity method used to pin
+	// clients to an origin.
+	// +kubebuilder:validation:Enum=none;cookie;ip_cookie
+	// +optional
+	SessionAffinity *string `json:"sessionAffinity,omitempty"`
+
+	// SessionAffinityTTL is the time in seconds that a client
+	// remains pinned to an origin.
+	// +kubebuilder:validation:Minimum=1800
+	// +kubebuilder:validation:Maximum=604800
+	// +optional
+	SessionAffinityTTL *int64 `json:"sessionAffinityTtl,omitempty"`
+
+	// SteeringPolicy is the method used to steer traffic between
+	// pools.
+	// +kubebuilder:validation:Enum=off;geo;random;dynamic_latency;proximity
+	// +optional
+	SteeringPolicy *string `json:"steeringPolicy,omitempty"`
+
+	// TTL is the time to live of the LoadBalancer's DNS record.
+	// Only applies when the LoadBalancer is not proxied.
+	// +optional
+	TTL *int64 `json:"ttl,omitempty"`
+
+	// ZoneID this LoadBalancer is managed on.
+	// +immutable
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+
+	// ZoneRef references the Zone object this LoadBalancer is
+	// managed on.
+	// +immutable
+	// +optional
+	ZoneRef *xpv1.Reference `json:"zoneRef,omitempty"`
+
+	// ZoneSelector selects the Zone object this LoadBalancer is
+	// managed on.
+	// +immutable
+	// +optional
+	ZoneSelector *xpv1.Selector `json:"zoneSelector,omitempty"`
+}
+
+// LoadBalancerObservation are the observable fields of a LoadBalancer.
+type LoadBalancerObservation struct {
+	CreatedOn  *metav1.Time `json:"createdOn,omitempty"`
+	ModifiedOn *metav1.Time `json:"modifiedOn,omitempty"`
+}
+
+// A LoadBalancerSpec defines the desired state of a LoadBalancer.
+type LoadBalancerSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       LoadBalancerParameters `json:"forProvider"`
+}
+
+// A LoadBalancerStatus represents the observed state of a LoadBalancer.
+type LoadBalancerStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          LoadBalancerObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A LoadBalancer steers traffic for a hostname on a Zone across one
+// or more LoadBalancerPools.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="NAME",type="string",JSONPath=".spec.forProvider.name"
+// +kubebuilder:printcolumn:name="STEERING",type="string",JSONPath=".spec.forProvider.steeringPolicy"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type LoadBalancer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LoadBalancerSpec   `json:"spec"`
+	Status LoadBalancerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LoadBalancerList contains a list of LoadBalancer
+type LoadBalancerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LoadBalancer `json:"items"`
+}
+
+// ResolveReferences of this LoadBalancer
+func (lb *LoadBalancer) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, lb)
+
+	// Resolve spec.forProvider.zone
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(lb.Spec.ForProvider.Zone),
+		Reference:    lb.Spec.ForProvider.ZoneRef,
+		Selector:     lb.Spec.ForProvider.ZoneSelector,
+		To:           reference.To{Managed: &zone.Zone{}, List: &zone.ZoneList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.zone")
+	}
+	lb.Spec.ForProvider.Zone = reference.ToPtrValue(rsp.ResolvedValue)
+	lb.Spec.ForProvider.ZoneRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.fallbackPool
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(lb.Spec.ForProvider.FallbackPool),
+		Reference:    lb.Spec.ForProvider.FallbackPoolRef,
+		Selector:     lb.Spec.ForProvider.FallbackPoolSelector,
+		To:           reference.To{Managed: &LoadBalancerPool{}, List: &LoadBalancerPoolList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.fallbackPool")
+	}
+	lb.Spec.ForProvider.FallbackPool = reference.ToPtrValue(rsp.ResolvedValue)
+	lb.Spec.ForProvider.FallbackPoolRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.defaultPools
+	mrsp, err := r.ResolveMultiple(ctx, reference.MultiResolutionRequest{
+		CurrentValues: lb.Spec.ForProvider.DefaultPools,
+		References:    lb.Spec.ForProvider.DefaultPoolRefs,
+		Selector:      lb.Spec.ForProvider.DefaultPoolSelector,
+		To:            reference.To{Managed: &LoadBalancerPool{}, List: &LoadBalancerPoolList{}},
+		Extract:       reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.defaultPools")
+	}
+	lb.Spec.ForProvider.DefaultPools = mrsp.ResolvedValues
+	lb.Spec.ForProvider.DefaultPoolRefs = mrsp.ResolvedReferences
+
+	return nil
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+
+	account "github.com/benagricola/provider-cloudflare/apis/account/v1alpha1"
+
+	"github.com/pkg/errors"
+)
+
+// LoadBalancerMonitorParameters are the configurable fields of a
+// LoadBalancerMonitor.
+type LoadBalancerMonitorParameters struct {
+	// Account is the account ID this Monitor is created under.
+	// +immutable
+	// +optional
+	Account *string `json:"account,omitempty"`
+
+	// AccountRef references the Account object this Monitor is
+	// created under.
+	// +immutable
+	// +optional
+	AccountRef *xpv1.Reference `json:"accountRef,omitempty"`
+
+	// AccountSelector selects the Account object this Monitor is
+	// created under.
+	// +immutable
+	// +optional
+	AccountSelector *xpv1.Selector `json:"accountSelector,omitempty"`
+
+	// AllowInsecure skips validation of the origin's certificate
+	// for HTTPS health checks.
+	// +optional
+	AllowInsecure *bool `json:"allowInsecure,omitempty"`
+
+	// Description is a human readable description of this Monitor.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// ExpectedBody is a case-insensitive sub-string to look for in
+	// the response body of HTTP(S) health checks.
+	// +optional
+	ExpectedBody *string `json:"expectedBody,omitempty"`
+
+	// ExpectedCodes is the expected HTTP response code or code
+	// range of HTTP(S) health checks, e.g. "2xx".
+	// +optional
+	ExpectedCodes *string `json:"expectedCodes,omitempty"`
+
+	// FollowRedirects follows redirects returned by the origin
+	// during HTTP(S) health checks.
+	// +optional
+	FollowRedirects *bool `json:"followRedirects,omitempty"`
+
+	// Header is a list of HTTP request headers to send with
+	// HTTP(S) health checks.
+	// +optional
+	Header map[string][]string `json:"header,omitempty"`
+
+	// Interval is the number of seconds between health checks.
+	// +kubebuilder:validation:Minimum=5
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	Interval *int64 `json:"interval,omitempty"`
+
+	// Method is the HTTP method used for HTTP(S) health checks,
+	// or the connection method for TCP health checks.
+	// +optional
+	Method *string `json:"method,omitempty"`
+
+	// Path is the endpoint path of HTTP(S) health checks.
+	// +optional
+	Path *string `json:"path,omitempty"`
+
+	// Port is the port to send health checks to. Defaults to the
+	// port of the origin.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+
+	// ProbeZone is the Zone name to use in the Host header of
+	// health checks sent from Cloudflare.
+	// +optional
+	ProbeZone *string `json:"probeZone,omitempty"`
+
+	// Retries is the number of retries to attempt before marking
+	// an origin as unhealthy.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=5
+	// +optional
+	Retries *int64 `json:"retries,omitempty"`
+
+	// Timeout is the timeout in seconds of each health check.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	Timeout *int64 `json:"timeout,omitempty"`
+
+	// Type is the protocol to use for health checks.
+	// +kubebuilder:validation:Enum=http;https;tcp;udp_icmp;icmp_ping;smtp
+	// +optional
+	Type *string `json:"type,omitempty"`
+}
+
+// LoadBalancerMonitorObservation are the observable fields of a
+// LoadBalancerMonitor.
+type LoadBalancerMonitorObservation struct {
+	CreatedOn  *metav1.Time `json:"createdOn,omitempty"`
+	ModifiedOn *metav1.Time `json:"modifiedOn,omitempty"`
+}
+
+// A LoadBalancerMonitorSpec defines the desired state of a
+// LoadBalancerMonitor.
+type LoadBalancerMonitorSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       LoadBalancerMonitorParameters `json:"forProvider"`
+}
+
+// A LoadBalancerMonitorStatus represents the observed state of a
+// LoadBalancerMonitor.
+type LoadBalancerMonitorStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          LoadBalancerMonitorObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A LoadBalancerMonitor health checks the origins of one or more
+// LoadBalancerPools.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".spec.forProvider.type"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type LoadBalancerMonitor struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LoadBalancerMonitorSpec   `json:"spec"`
+	Status LoadBalancerMonitorStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LoadBalancerMonitorList contains a list of LoadBalancerMonitor
+type LoadBalancerMonitorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LoadBalancerMonitor `json:"items"`
+}
+
+// ResolveReferences of this LoadBalancerMonitor
+func (m *LoadBalancerMonitor) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, m)
+
+	// Resolve spec.forProvider.account
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(m.Spec.ForProvider.Account),
+		Reference:    m.Spec.ForProvider.AccountRef,
+		Selector:     m.Spec.ForProvider.AccountSelector,
+		To:           reference.To{Managed: &account.Account{}, List: &account.AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.account")
+	}
+	m.Spec.ForProvider.Account = reference.ToPtrValue(rsp.ResolvedValue)
+	m.Spec.ForProvider.AccountRef = rsp.ResolvedReference
+
+	return nil
+}
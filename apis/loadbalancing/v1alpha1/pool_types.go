@@ -0,0 +1,213 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+
+	account "github.com/benagricola/provider-cloudflare/apis/account/v1alpha1"
+
+	"github.com/pkg/errors"
+)
+
+// LoadBalancerPoolCheckRegion identifies a region from which health
+// checks are sent to the origins of a pool.
+// +kubebuilder:validation:Enum=WNAM;ENAM;WEU;EEU;NSAM;SSAM;OC;ME;NAF;SAF;SAS;SEAS;NEAS;ALL_REGIONS
+type LoadBalancerPoolCheckRegion string
+
+// A LoadBalancerOrigin is a single origin server in a
+// LoadBalancerPool.
+type LoadBalancerOrigin struct {
+	// Address is the IP address or hostname of the origin.
+	Address string `json:"address"`
+
+	// Enabled indicates whether this origin may receive traffic.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Header is a list of HTTP request headers to send to this
+	// origin. Only the Host header is supported by Cloudflare.
+	// +optional
+	Header map[string][]string `json:"header,omitempty"`
+
+	// Name is a human readable name for this origin.
+	Name string `json:"name"`
+
+	// Weight is the share of pool traffic sent to this origin,
+	// as a decimal between 0 and 1 with up to 2 decimal places.
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]{1,2})?|1(\.0{1,2})?)$`
+	// +optional
+	Weight *string `json:"weight,omitempty"`
+}
+
+// LoadBalancerPoolParameters are the configurable fields of a
+// LoadBalancerPool.
+type LoadBalancerPoolParameters struct {
+	// Account is the account ID this Pool is created under.
+	// +immutable
+	// +optional
+	Account *string `json:"account,omitempty"`
+
+	// AccountRef references the Account object this Pool is
+	// created under.
+	// +immutable
+	// +optional
+	AccountRef *xpv1.Reference `json:"accountRef,omitempty"`
+
+	// AccountSelector selects the Account object this Pool is
+	// created under.
+	// +immutable
+	// +optional
+	AccountSelector *xpv1.Selector `json:"accountSelector,omitempty"`
+
+	// CheckRegions are the regions from which health checks are
+	// sent to the origins of this Pool.
+	// +optional
+	CheckRegions []LoadBalancerPoolCheckRegion `json:"checkRegions,omitempty"`
+
+	// Description is a human readable description of this Pool.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Enabled indicates whether this Pool may receive traffic.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// MinimumOrigins is the number of healthy origins required
+	// for this Pool to be considered healthy.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinimumOrigins *int64 `json:"minimumOrigins,omitempty"`
+
+	// Monitor is the ID of the LoadBalancerMonitor used to health
+	// check the origins of this Pool.
+	// +optional
+	Monitor *string `json:"monitor,omitempty"`
+
+	// MonitorRef references the LoadBalancerMonitor object used to
+	// health check the origins of this Pool.
+	// +optional
+	MonitorRef *xpv1.Reference `json:"monitorRef,omitempty"`
+
+	// MonitorSelector selects the LoadBalancerMonitor object used
+	// to health check the origins of this Pool.
+	// +optional
+	MonitorSelector *xpv1.Selector `json:"monitorSelector,omitempty"`
+
+	// Name is a short name for this Pool. Only alphanumeric
+	// characters, hyphens and underscores are allowed.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_-]+$`
+	Name string `json:"name"`
+
+	// NotificationEmail is an email address to send health status
+	// notifications to.
+	// +optional
+	NotificationEmail *string `json:"notificationEmail,omitempty"`
+
+	// Origins are the origin servers in this Pool.
+	// +kubebuilder:validation:MinItems=1
+	Origins []LoadBalancerOrigin `json:"origins"`
+}
+
+// LoadBalancerPoolObservation are the observable fields of a
+// LoadBalancerPool.
+type LoadBalancerPoolObservation struct {
+	CreatedOn  *metav1.Time `json:"createdOn,omitempty"`
+	ModifiedOn *metav1.Time `json:"modifiedOn,omitempty"`
+}
+
+// A LoadBalancerPoolSpec defines the desired state of a
+// LoadBalancerPool.
+type LoadBalancerPoolSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       LoadBalancerPoolParameters `json:"forProvider"`
+}
+
+// A LoadBalancerPoolStatus represents the observed state of a
+// LoadBalancerPool.
+type LoadBalancerPoolStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          LoadBalancerPoolObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A LoadBalancerPool is a group of origin servers that LoadBalancers
+// steer traffic to.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="NAME",type="string",JSONPath=".spec.forProvider.name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type LoadBalancerPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LoadBalancerPoolSpec   `json:"spec"`
+	Status LoadBalancerPoolStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LoadBalancerPoolList contains a list of LoadBalancerPool
+type LoadBalancerPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LoadBalancerPool `json:"items"`
+}
+
+// ResolveReferences of this LoadBalancerPool
+func (p *LoadBalancerPool) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, p)
+
+	// Resolve spec.forProvider.account
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(p.Spec.ForProvider.Account),
+		Reference:    p.Spec.ForProvider.AccountRef,
+		Selector:     p.Spec.ForProvider.AccountSelector,
+		To:           reference.To{Managed: &account.Account{}, List: &account.AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.account")
+	}
+	p.Spec.ForProvider.Account = reference.ToPtrValue(rsp.ResolvedValue)
+	p.Spec.ForProvider.AccountRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.monitor
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(p.Spec.ForProvider.Monitor),
+		Reference:    p.Spec.ForProvider.MonitorRef,
+		Selector:     p.Spec.ForProvider.MonitorSelector,
+		To:           reference.To{Managed: &LoadBalancerMonitor{}, List: &LoadBalancerMonitorList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.monitor")
+	}
+	p.Spec.ForProvider.Monitor = reference.ToPtrValue(rsp.ResolvedValue)
+	p.Spec.ForProvider.MonitorRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+
+	account "github.com/benagricola/provider-cloudflare/apis/account/v1alpha1"
+)
+
+// QueueParameters are the configurable fields of a Queue.
+type QueueParameters struct {
+	// Account is the account ID this Queue is created under.
+	// +immutable
+	// +optional
+	Account *string `json:"account,omitempty"`
+
+	// AccountRef references the Account object this Queue is created
+	// under.
+	// +immutable
+	// +optional
+	AccountRef *xpv1.Reference `json:"accountRef,omitempty"`
+
+	// AccountSelector selects the Account object this Queue is created
+	// under.
+	// +immutable
+	// +optional
+	AccountSelector *xpv1.Selector `json:"accountSelector,omitempty"`
+
+	// Name of the Queue. Defaults to the name of this resource.
+	// +optional
+	Name *string `json:"name,omitempty"`
+}
+
+// QueueProducer is a Worker that sends messages to a Queue.
+type QueueProducer struct {
+	// Script is the name of the Worker Script.
+	Script string `json:"script,omitempty"`
+
+	// Environment of the Worker Script.
+	Environment string `json:"environment,omitempty"`
+}
+
+// QueueConsumer is a Worker, or a pull consumer, that receives messages
+// from a Queue.
+type QueueConsumer struct {
+	// Script is the name of the Worker Script, if this is a Worker
+	// consumer.
+	Script string `json:"script,omitempty"`
+
+	// Environment of the Worker Script.
+	Environment string `json:"environment,omitempty"`
+
+	// Type of the consumer, either worker or http_pull.
+	Type string `json:"type,omitempty"`
+
+	// DeadLetterQueue is the name of the Queue messages are sent to once
+	// they have been retried MaxRetries times.
+	DeadLetterQueue string `json:"deadLetterQueue,omitempty"`
+
+	// BatchSize is the maximum number of messages delivered to the
+	// consumer at once.
+	BatchSize int `json:"batchSize,omitempty"`
+
+	// MaxRetries is the maximum number of times delivery of a message is
+	// retried.
+	MaxRetries int `json:"maxRetries,omitempty"`
+
+	// MaxWaitTimeMs is the maximum number of milliseconds to wait for a
+	// batch to fill before delivering it.
+	MaxWaitTimeMs int `json:"maxWaitTimeMs,omitempty"`
+}
+
+// QueueObservation are the observable fields of a Queue.
+type QueueObservation struct {
+	// Producers are the Workers that send messages to the Queue.
+	Producers []QueueProducer `json:"producers,omitempty"`
+
+	// Consumers are the consumers that receive messages from the Queue.
+	Consumers []QueueConsumer `json:"consumers,omitempty"`
+
+	// CreatedOn indicates when the Queue was created.
+	CreatedOn *metav1.Time `json:"createdOn,omitempty"`
+
+	// ModifiedOn indicates when the Queue was last modified.
+	ModifiedOn *metav1.Time `json:"modifiedOn,omitempty"`
+}
+
+// A QueueSpec defines the desired state of a Queue.
+type QueueSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       QueueParameters `json:"forProvider"`
+}
+
+// A QueueStatus represents the observed state of a Queue.
+type QueueStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          QueueObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Queue is a Cloudflare Queue, which Workers use to send and receive
+// messages. Producer and consumer bindings are configured on the Workers
+// themselves, and are reported in the status of the Queue.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="NAME",type="string",JSONPath=".spec.forProvider.name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type Queue struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   QueueSpec   `json:"spec"`
+	Status QueueStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// QueueList contains a list of Queue objects
+type QueueList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Queue `json:"items"`
+}
+
+// ResolveReferences resolves references to the Account that this Queue
+// is created under.
+func (q *Queue) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, q)
+
+	// Resolve spec.forProvider.account
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(q.Spec.ForProvider.Account),
+		Reference:    q.Spec.ForProvider.AccountRef,
+		Selector:     q.Spec.ForProvider.AccountSelector,
+		To:           reference.To{Managed: &account.Account{}, List: &account.AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.account")
+	}
+	q.Spec.ForProvider.Account = reference.ToPtrValue(rsp.ResolvedValue)
+	q.Spec.ForProvider.AccountRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+
+	account "github.com/benagricola/provider-cloudflare/apis/account/v1alpha1"
+)
+
+// R2BucketParameters are the configurable fields of an R2Bucket.
+type R2BucketParameters struct {
+	// Account is the account ID this R2Bucket is created under.
+	// +immutable
+	// +optional
+	Account *string `json:"account,omitempty"`
+
+	// AccountRef references the Account object this R2Bucket is created
+	// under.
+	// +immutable
+	// +optional
+	AccountRef *xpv1.Reference `json:"accountRef,omitempty"`
+
+	// AccountSelector selects the Account object this R2Bucket is created
+	// under.
+	// +immutable
+	// +optional
+	AccountSelector *xpv1.Selector `json:"accountSelector,omitempty"`
+
+	// Name of the R2Bucket. Defaults to the name of this resource.
+	// +immutable
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// LocationHint is the region the R2Bucket should preferably be
+	// created in. Cloudflare may create the bucket elsewhere, and
+	// reports the actual location once it is created.
+	// +kubebuilder:validation:Enum=apac;eeur;enam;weur;wnam
+	// +immutable
+	// +optional
+	LocationHint *string `json:"locationHint,omitempty"`
+}
+
+// R2BucketObservation are the observable fields of an R2Bucket.
+type R2BucketObservation struct {
+	// Location the R2Bucket was created in.
+	Location string `json:"location,omitempty"`
+
+	// CreationDate indicates when the R2Bucket was created.
+	CreationDate *metav1.Time `json:"creationDate,omitempty"`
+}
+
+// An R2BucketSpec defines the desired state of an R2Bucket.
+type R2BucketSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       R2BucketParameters `json:"forProvider"`
+}
+
+// An R2BucketStatus represents the observed state of an R2Bucket.
+type R2BucketStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          R2BucketObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An R2Bucket is a Cloudflare R2 object storage bucket. A bucket must be
+// empty before it can be deleted.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="LOCATION",type="string",JSONPath=".status.atProvider.location"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type R2Bucket struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   R2BucketSpec   `json:"spec"`
+	Status R2BucketStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// R2BucketList contains a list of R2Bucket objects
+type R2BucketList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []R2Bucket `json:"items"`
+}
+
+// ResolveReferences resolves references to the Account that this R2Bucket
+// is created under.
+func (b *R2Bucket) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, b)
+
+	// Resolve spec.forProvider.account
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(b.Spec.ForProvider.Account),
+		Reference:    b.Spec.ForProvider.AccountRef,
+		Selector:     b.Spec.ForProvider.AccountSelector,
+		To:           reference.To{Managed: &account.Account{}, List: &account.AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.account")
+	}
+	b.Spec.ForProvider.Account = reference.ToPtrValue(rsp.ResolvedValue)
+	b.Spec.ForProvider.AccountRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -0,0 +1,419 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+
+	zone "github.com/benagricola/provider-cloudflare/apis/zone/v1alpha1"
+
+	"github.com/pkg/errors"
+)
+
+// RulesetSkipProduct identifies a legacy security product that is skipped
+// when the skip action is used.
+// +kubebuilder:validation:Enum=zoneLockdown;uaBlock;bic;hot;securityLevel;rateLimit;waf
+type RulesetSkipProduct string
+
+// RulesetBlockResponse is a custom response sent when the block action is
+// used.
+type RulesetBlockResponse struct {
+	// StatusCode of the response.
+	// +kubebuilder:validation:Minimum=400
+	// +kubebuilder:validation:Maximum=499
+	StatusCode int `json:"statusCode"`
+
+	// ContentType of the response.
+	// +kubebuilder:validation:Enum=application/json;text/html;text/plain;text/xml
+	// +optional
+	ContentType *string `json:"contentType,omitempty"`
+
+	// Content of the response.
+	Content string `json:"content"`
+}
+
+// RulesetRewriteValue rewrites a part of the URL of a request, either to
+// a static value or to the result of an expression.
+type RulesetRewriteValue struct {
+	// Value is the static value to rewrite to.
+	// +optional
+	Value *string `json:"value,omitempty"`
+
+	// Expression is evaluated to dynamically compute the value to rewrite
+	// to.
+	// +optional
+	Expression *string `json:"expression,omitempty"`
+}
+
+// RulesetURIRewrite rewrites the URL of a request when the rewrite action
+// is used in the http_request_transform phase.
+type RulesetURIRewrite struct {
+	// Path rewrites the path of the URL.
+	// +optional
+	Path *RulesetRewriteValue `json:"path,omitempty"`
+
+	// Query rewrites the query string of the URL. An empty value removes
+	// the query string.
+	// +optional
+	Query *RulesetRewriteValue `json:"query,omitempty"`
+}
+
+// RulesetHeader modifies a HTTP header when the rewrite action is used in
+// the http_request_late_transform or http_response_headers_transform
+// phase.
+type RulesetHeader struct {
+	// Name of the header.
+	Name string `json:"name"`
+
+	// Operation applied to the header. The set operation replaces the
+	// header, while the add operation adds another header with the same
+	// name to responses.
+	// +kubebuilder:validation:Enum=set;add;remove
+	Operation string `json:"operation"`
+
+	// Value is the static value of the header.
+	// +optional
+	Value *string `json:"value,omitempty"`
+
+	// Expression is evaluated to dynamically compute the value of the
+	// header.
+	// +optional
+	Expression *string `json:"expression,omitempty"`
+}
+
+// RulesetCacheTTL configures how long content is cached when the
+// set_cache_settings action is used.
+type RulesetCacheTTL struct {
+	// Mode controls whether the TTL is taken from the origin or
+	// overridden.
+	// +kubebuilder:validation:Enum=respect_origin;bypass_by_default;override_origin;bypass
+	Mode string `json:"mode"`
+
+	// Default is the TTL in seconds used when the origin TTL is
+	// overridden, or when the origin does not set one.
+	// +optional
+	Default *int `json:"default,omitempty"`
+}
+
+// RulesetCategoryOverride overrides the rules of a managed ruleset that
+// have a given tag.
+type RulesetCategoryOverride struct {
+	// Category is the tag of the rules to override.
+	Category string `json:"category"`
+
+	// Enabled enables or disables the rules.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Action overrides the action of the rules.
+	// +optional
+	Action *string `json:"action,omitempty"`
+}
+
+// RulesetRuleOverride overrides a single rule of a managed ruleset.
+type RulesetRuleOverride struct {
+	// ID of the rule to override.
+	ID string `json:"id"`
+
+	// Enabled enables or disables the rule.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Action overrides the action of the rule.
+	// +optional
+	Action *string `json:"action,omitempty"`
+
+	// ScoreThreshold overrides the anomaly score threshold of the rule.
+	// +optional
+	ScoreThreshold *int `json:"scoreThreshold,omitempty"`
+
+	// SensitivityLevel overrides the sensitivity level of the rule.
+	// +kubebuilder:validation:Enum=default;medium;low;eoff
+	// +optional
+	SensitivityLevel *string `json:"sensitivityLevel,omitempty"`
+}
+
+// RulesetOverrides override the behaviour of a managed ruleset deployed
+// with the execute action.
+type RulesetOverrides struct {
+	// Enabled enables or disables all rules of the managed ruleset.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Action overrides the action of all rules of the managed ruleset.
+	// +optional
+	Action *string `json:"action,omitempty"`
+
+	// SensitivityLevel overrides the sensitivity level of all rules of
+	// the managed ruleset.
+	// +kubebuilder:validation:Enum=default;medium;low;eoff
+	// +optional
+	SensitivityLevel *string `json:"sensitivityLevel,omitempty"`
+
+	// Categories overrides the rules of the managed ruleset by tag.
+	// +optional
+	Categories []RulesetCategoryOverride `json:"categories,omitempty"`
+
+	// Rules overrides individual rules of the managed ruleset.
+	// +optional
+	Rules []RulesetRuleOverride `json:"rules,omitempty"`
+}
+
+// RulesetRuleActionParameters are the parameters of the action of a rule.
+type RulesetRuleActionParameters struct {
+	// ID of the managed ruleset deployed by the execute action.
+	// +optional
+	ID *string `json:"id,omitempty"`
+
+	// Overrides of the managed ruleset deployed by the execute action.
+	// +optional
+	Overrides *RulesetOverrides `json:"overrides,omitempty"`
+
+	// Ruleset skips the remaining rules of the current ruleset when the
+	// skip action is used.
+	// +kubebuilder:validation:Enum=current
+	// +optional
+	Ruleset *string `json:"ruleset,omitempty"`
+
+	// Phases lists the phases skipped when the skip action is used.
+	// +optional
+	Phases []string `json:"phases,omitempty"`
+
+	// Products lists the legacy security products skipped when the skip
+	// action is used.
+	// +optional
+	Products []RulesetSkipProduct `json:"products,omitempty"`
+
+	// Response is a custom response sent when the block action is used.
+	// +optional
+	Response *RulesetBlockResponse `json:"response,omitempty"`
+
+	// URI rewrites the URL of a request when the rewrite action is used.
+	// +optional
+	URI *RulesetURIRewrite `json:"uri,omitempty"`
+
+	// Headers modifies the HTTP headers of a request or response when the
+	// rewrite action is used.
+	// +optional
+	Headers []RulesetHeader `json:"headers,omitempty"`
+
+	// Cache marks matching requests as eligible or ineligible for caching
+	// when the set_cache_settings action is used.
+	// +optional
+	Cache *bool `json:"cache,omitempty"`
+
+	// EdgeTTL configures how long content is cached at the Cloudflare
+	// edge when the set_cache_settings action is used.
+	// +optional
+	EdgeTTL *RulesetCacheTTL `json:"edgeTTL,omitempty"`
+
+	// BrowserTTL configures how long content is cached by browsers when
+	// the set_cache_settings action is used.
+	// +optional
+	BrowserTTL *RulesetCacheTTL `json:"browserTTL,omitempty"`
+
+	// RespectStrongETags uses strong ETag headers from the origin when the
+	// set_cache_settings action is used.
+	// +optional
+	RespectStrongETags *bool `json:"respectStrongETags,omitempty"`
+
+	// OriginErrorPagePassthru serves error pages from the origin rather
+	// than Cloudflare error pages when the set_cache_settings action is
+	// used.
+	// +optional
+	OriginErrorPagePassthru *bool `json:"originErrorPagePassthru,omitempty"`
+}
+
+// RulesetRuleRateLimit configures the rate limiting of a rule in the
+// http_ratelimit phase.
+type RulesetRuleRateLimit struct {
+	// Characteristics of a request that are used to count requests, such
+	// as ip.src or cf.colo.id.
+	// +kubebuilder:validation:MinItems=1
+	Characteristics []string `json:"characteristics"`
+
+	// Period in seconds over which requests are counted.
+	// +kubebuilder:validation:Enum=10;60;120;300;600;3600
+	Period int `json:"period"`
+
+	// RequestsPerPeriod is the number of requests allowed in a period
+	// before the action of the rule is applied.
+	RequestsPerPeriod int `json:"requestsPerPeriod"`
+
+	// MitigationTimeout is the number of seconds the action of the rule
+	// is applied for once the rate limit is exceeded.
+	// +optional
+	MitigationTimeout *int `json:"mitigationTimeout,omitempty"`
+
+	// CountingExpression matches the requests that are counted, if it
+	// differs from the expression of the rule.
+	// +optional
+	CountingExpression *string `json:"countingExpression,omitempty"`
+
+	// RequestsToOrigin counts only requests that are sent to the origin.
+	// +optional
+	RequestsToOrigin *bool `json:"requestsToOrigin,omitempty"`
+}
+
+// RulesetRule is a rule of a Ruleset.
+type RulesetRule struct {
+	// Action is the action to apply to a matching request.
+	// +kubebuilder:validation:Enum=block;challenge;js_challenge;managed_challenge;log;skip;execute;rewrite;set_cache_settings
+	Action string `json:"action"`
+
+	// ActionParameters are the parameters of the action.
+	// +optional
+	ActionParameters *RulesetRuleActionParameters `json:"actionParameters,omitempty"`
+
+	// Expression matching the requests the rule applies to.
+	// +kubebuilder:validation:MaxLength=4096
+	Expression string `json:"expression"`
+
+	// Description is a human readable description of the rule.
+	// +kubebuilder:validation:MaxLength=500
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Enabled indicates if the rule is enabled. Rules are enabled by
+	// default.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// RateLimit configures the rate limiting of the rule. It is required
+	// for rules in the http_ratelimit phase.
+	// +optional
+	RateLimit *RulesetRuleRateLimit `json:"rateLimit,omitempty"`
+}
+
+// RulesetParameters are the configurable fields of a Ruleset.
+type RulesetParameters struct {
+	// Name of the Ruleset.
+	// +immutable
+	Name string `json:"name"`
+
+	// Description is a human readable description of the Ruleset.
+	// +kubebuilder:validation:MaxLength=500
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Phase the Ruleset is the entry point of. A Zone has at most one
+	// Ruleset per phase. Transform Rules are evaluated in the
+	// http_request_transform phase for URL rewrites, the
+	// http_request_late_transform phase for request header modification
+	// and the http_response_headers_transform phase for response header
+	// modification. Cache Rules are evaluated in the
+	// http_request_cache_settings phase.
+	// +kubebuilder:validation:Enum=http_request_firewall_custom;http_ratelimit;http_request_firewall_managed;http_request_transform;http_request_late_transform;http_response_headers_transform;http_request_cache_settings
+	// +immutable
+	Phase string `json:"phase"`
+
+	// Rules of the Ruleset, which are evaluated in order.
+	// +optional
+	Rules []RulesetRule `json:"rules,omitempty"`
+
+	// ZoneID this Ruleset is for.
+	// +immutable
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+
+	// ZoneRef references the zone object this Ruleset is for.
+	// +immutable
+	// +optional
+	ZoneRef *xpv1.Reference `json:"zoneRef,omitempty"`
+
+	// ZoneSelector selects the zone object this Ruleset is for.
+	// +immutable
+	// +optional
+	ZoneSelector *xpv1.Selector `json:"zoneSelector,omitempty"`
+}
+
+// RulesetObservation is the observable fields of a Ruleset.
+type RulesetObservation struct {
+	// Version of the Ruleset, which is incremented on each update.
+	Version string `json:"version,omitempty"`
+
+	// LastUpdated indicates when the Ruleset was last updated.
+	LastUpdated *metav1.Time `json:"lastUpdated,omitempty"`
+}
+
+// A RulesetSpec defines the desired state of a Ruleset.
+type RulesetSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RulesetParameters `json:"forProvider"`
+}
+
+// A RulesetStatus represents the observed state of a Ruleset.
+type RulesetStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          RulesetObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Ruleset is the entry point ruleset of a phase of a Zone, evaluated
+// by the Cloudflare Rulesets engine. It replaces Filters and Rules, and
+// manages Transform Rules and Cache Rules.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="PHASE",type="string",JSONPath=".spec.forProvider.phase"
+// +kubebuilder:printcolumn:name="VERSION",type="string",JSONPath=".status.atProvider.version"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type Ruleset struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RulesetSpec   `json:"spec"`
+	Status RulesetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RulesetList contains a list of Ruleset
+type RulesetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Ruleset `json:"items"`
+}
+
+// ResolveReferences resolves references to the Zone of this Ruleset.
+func (rs *Ruleset) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, rs)
+
+	// Resolve spec.forProvider.zone
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(rs.Spec.ForProvider.Zone),
+		Reference:    rs.Spec.ForProvider.ZoneRef,
+		Selector:     rs.Spec.ForProvider.ZoneSelector,
+		To:           reference.To{Managed: &zone.Zone{}, List: &zone.ZoneList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.zone")
+	}
+	rs.Spec.ForProvider.Zone = reference.ToPtrValue(rsp.ResolvedValue)
+	rs.Spec.ForProvider.ZoneRef = rsp.ResolvedReference
+
+	return nil
+}
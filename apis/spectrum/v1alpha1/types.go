@@ -47,11 +47,18 @@ type SpectrumApplicationOriginDNS struct {
 	// Name is the name of the Origin DNS for the Spectrum Application
 	// +kubebuilder:validation:Format=hostname
 	Name string `json:"name"`
+
+	// TTL is the number of seconds Cloudflare caches the resolution of
+	// the Origin DNS name for.
+	// +kubebuilder:validation:Minimum=600
+	// +optional
+	TTL *int64 `json:"ttl,omitempty"`
 }
 
 // SpectrumApplicationOriginPort holds the origin ports for a Spectrum Application
 type SpectrumApplicationOriginPort struct {
-	// Port is a singular port for a Spectrum Application
+	// Port is a singular port for a Spectrum Application.
+	// It may not be set together with Start and End.
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=65535
 	// +optional
@@ -89,12 +96,15 @@ type SpectrumApplicationEdgeIPs struct {
 // ApplicationParameters are the configurable fields of a Spectrum Application.
 type ApplicationParameters struct {
 	// Protocol port configuration at Cloudflare’s edge.
+	// +kubebuilder:validation:Pattern=`^(tcp|udp)/[0-9]+(-[0-9]+)?$`
 	Protocol string `json:"protocol"`
 
 	// The name and type of DNS record for the Spectrum application.
 	DNS SpectrumApplicationDNS `json:"dns,omitempty"`
 
 	// OriginDirect is a list of destination addresses to the origin.
+	// Each address is a scheme, IP or hostname and port, such as
+	// tcp://192.0.2.1:22 or tcp://host.example.com:22.
 	OriginDirect []string `json:"originDirect,omitempty"`
 
 	// OriginPort is the port range when using Origin DNS
@@ -110,6 +120,10 @@ type ApplicationParameters struct {
 	IPFirewall *bool `json:"ipFirewall,omitempty"`
 
 	// ProxyProtocol enables / sets the Proxy Protocol to the origin.
+	// Proxy protocols may only be used with the direct traffic type.
+	// v1 may only be used with TCP and simple with UDP applications and
+	// origin direct targets. Proxy protocols require an origin direct or
+	// origin DNS.
 	// +kubebuilder:validation:Enum=off;v1;v2;simple
 	// +optional
 	ProxyProtocol *string `json:"proxyProtocol,omitempty"`
@@ -120,6 +134,7 @@ type ApplicationParameters struct {
 	TLS *string `json:"tls,omitempty"`
 
 	// TrafficType determines how data travels from the edge to the origin.
+	// TLS cannot be off when TrafficType is https.
 	// +kubebuilder:validation:Enum=direct;http;https
 	// +optional
 	TrafficType *string `json:"trafficType,omitempty"`
@@ -132,16 +147,20 @@ type ApplicationParameters struct {
 	// +optional
 	ArgoSmartRouting *bool `json:"argoSmartRouting,omitempty"`
 
-	// ZoneID this Spectrum Application is managed on.
+	// ZoneID this Spectrum Application is managed on. This is the ID of
+	// the Zone, not its domain name.
+	// +kubebuilder:validation:Pattern=`^[0-9a-f]{32}$`
 	// +immutable
 	// +optional
 	Zone *string `json:"zone,omitempty"`
 
 	// ZoneRef references the Zone object this Spectrum Application is managed on.
+	// +immutable
 	// +optional
 	ZoneRef *xpv1.Reference `json:"zoneRef,omitempty"`
 
 	// ZoneSelector selects the Zone object this Spectrum Application is managed on.
+	// +immutable
 	// +optional
 	ZoneSelector *xpv1.Selector `json:"zoneSelector,omitempty"`
 }
@@ -150,6 +169,21 @@ type ApplicationParameters struct {
 type ApplicationObservation struct {
 	CreatedOn  *metav1.Time `json:"createdOn,omitempty"`
 	ModifiedOn *metav1.Time `json:"modifiedOn,omitempty"`
+
+	// EdgeIPs are the anycast edge IPs assigned to this application.
+	EdgeIPs []string `json:"edgeIPs,omitempty"`
+
+	// DNSName is the DNS name assigned to this application, which
+	// clients connect to.
+	DNSName string `json:"dnsName,omitempty"`
+
+	// Protocol is the port configuration at the edge of this
+	// application, such as tcp/22.
+	Protocol string `json:"protocol,omitempty"`
+
+	// OriginDNSTTL is the observed TTL of the Origin DNS name. It is only
+	// observed when spec.forProvider.originDNS.ttl is set.
+	OriginDNSTTL *int64 `json:"originDNSTTL,omitempty"`
 }
 
 // A ApplicationSpec defines the desired state of a Spectrum Application.
@@ -95,6 +95,16 @@ func (in *ApplicationObservation) DeepCopyInto(out *ApplicationObservation) {
 		in, out := &in.ModifiedOn, &out.ModifiedOn
 		*out = (*in).DeepCopy()
 	}
+	if in.EdgeIPs != nil {
+		in, out := &in.EdgeIPs, &out.EdgeIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OriginDNSTTL != nil {
+		in, out := &in.OriginDNSTTL, &out.OriginDNSTTL
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationObservation.
@@ -124,7 +134,7 @@ func (in *ApplicationParameters) DeepCopyInto(out *ApplicationParameters) {
 	if in.OriginDNS != nil {
 		in, out := &in.OriginDNS, &out.OriginDNS
 		*out = new(SpectrumApplicationOriginDNS)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.IPFirewall != nil {
 		in, out := &in.IPFirewall, &out.IPFirewall
@@ -260,6 +270,11 @@ func (in *SpectrumApplicationEdgeIPs) DeepCopy() *SpectrumApplicationEdgeIPs {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpectrumApplicationOriginDNS) DeepCopyInto(out *SpectrumApplicationOriginDNS) {
 	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpectrumApplicationOriginDNS.
@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	zone "github.com/benagricola/provider-cloudflare/apis/zone/v1alpha1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+)
+
+// AuthenticatedOriginPullsParameters are the configurable fields of
+// zone-level Authenticated Origin Pulls.
+type AuthenticatedOriginPullsParameters struct {
+	// ZoneID Authenticated Origin Pulls are enabled on.
+	// +immutable
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+
+	// ZoneRef references the Zone object Authenticated Origin Pulls are
+	// enabled on.
+	// +immutable
+	// +optional
+	ZoneRef *xpv1.Reference `json:"zoneRef,omitempty"`
+
+	// ZoneSelector selects the Zone object Authenticated Origin Pulls are
+	// enabled on.
+	// +immutable
+	// +optional
+	ZoneSelector *xpv1.Selector `json:"zoneSelector,omitempty"`
+}
+
+// AuthenticatedOriginPullsObservation are the observable fields of
+// zone-level Authenticated Origin Pulls.
+type AuthenticatedOriginPullsObservation struct {
+	// Enabled indicates whether Authenticated Origin Pulls are enabled on
+	// the Zone.
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// An AuthenticatedOriginPullsSpec defines the desired state of zone-level
+// Authenticated Origin Pulls.
+type AuthenticatedOriginPullsSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       AuthenticatedOriginPullsParameters `json:"forProvider"`
+}
+
+// An AuthenticatedOriginPullsStatus represents the observed state of
+// zone-level Authenticated Origin Pulls.
+type AuthenticatedOriginPullsStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          AuthenticatedOriginPullsObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An AuthenticatedOriginPulls enables zone-level Authenticated Origin
+// Pulls, so that Cloudflare presents a client certificate to origins of
+// the Zone. The most recently uploaded zone-level OriginPullCertificate is
+// presented, or a Cloudflare certificate if none has been uploaded.
+// Authenticated Origin Pulls are disabled on the Zone when the
+// AuthenticatedOriginPulls is deleted.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=authenticatedoriginpulls,scope=Cluster,categories={crossplane,managed,cloudflare}
+type AuthenticatedOriginPulls struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AuthenticatedOriginPullsSpec   `json:"spec"`
+	Status AuthenticatedOriginPullsStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AuthenticatedOriginPullsList contains a list of AuthenticatedOriginPulls
+// objects
+type AuthenticatedOriginPullsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AuthenticatedOriginPulls `json:"items"`
+}
+
+// ResolveReferences resolves references to the Zone that Authenticated
+// Origin Pulls are enabled on.
+func (a *AuthenticatedOriginPulls) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, a)
+
+	// Resolve spec.forProvider.zone
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(a.Spec.ForProvider.Zone),
+		Reference:    a.Spec.ForProvider.ZoneRef,
+		Selector:     a.Spec.ForProvider.ZoneSelector,
+		To:           reference.To{Managed: &zone.Zone{}, List: &zone.ZoneList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.zone")
+	}
+	a.Spec.ForProvider.Zone = reference.ToPtrValue(rsp.ResolvedValue)
+	a.Spec.ForProvider.ZoneRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	zone "github.com/benagricola/provider-cloudflare/apis/zone/v1alpha1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+)
+
+// CustomCertificateParameters are the configurable fields of a Custom
+// Certificate.
+type CustomCertificateParameters struct {
+	// ZoneID this Custom Certificate is uploaded to.
+	// +immutable
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+
+	// ZoneRef references the Zone object this Custom Certificate is
+	// uploaded to.
+	// +immutable
+	// +optional
+	ZoneRef *xpv1.Reference `json:"zoneRef,omitempty"`
+
+	// ZoneSelector selects the Zone object this Custom Certificate is
+	// uploaded to.
+	// +immutable
+	// +optional
+	ZoneSelector *xpv1.Selector `json:"zoneSelector,omitempty"`
+
+	// Certificate is the PEM encoded certificate, optionally followed by
+	// its intermediate certificates.
+	// +optional
+	Certificate *string `json:"certificate,omitempty"`
+
+	// CertificateSecretRef selects a Secret key containing the PEM
+	// encoded certificate. Takes precedence over Certificate.
+	// +optional
+	CertificateSecretRef *xpv1.SecretKeySelector `json:"certificateSecretRef,omitempty"`
+
+	// PrivateKeySecretRef selects a Secret key containing the PEM encoded
+	// private key of the certificate.
+	PrivateKeySecretRef xpv1.SecretKeySelector `json:"privateKeySecretRef"`
+
+	// BundleMethod controls how the certificate chain is built.
+	// +kubebuilder:validation:Enum=ubiquitous;optimal;force
+	// +optional
+	BundleMethod *string `json:"bundleMethod,omitempty"`
+
+	// GeoRestrictions restricts the data centers the private key of the
+	// certificate is stored in.
+	// +kubebuilder:validation:Enum=us;eu;highest_security
+	// +optional
+	GeoRestrictions *string `json:"geoRestrictions,omitempty"`
+}
+
+// CustomCertificateObservation are the observable fields of a Custom
+// Certificate.
+type CustomCertificateObservation struct {
+	// Hosts the certificate is valid for.
+	Hosts []string `json:"hosts,omitempty"`
+
+	// Issuer of the certificate.
+	Issuer string `json:"issuer,omitempty"`
+
+	// Signature algorithm of the certificate.
+	Signature string `json:"signature,omitempty"`
+
+	// Status of the certificate.
+	Status string `json:"status,omitempty"`
+
+	// UploadedOn indicates when the certificate was uploaded.
+	UploadedOn *metav1.Time `json:"uploadedOn,omitempty"`
+
+	// ExpiresOn indicates when the certificate expires.
+	ExpiresOn *metav1.Time `json:"expiresOn,omitempty"`
+}
+
+// A CustomCertificateSpec defines the desired state of a Custom
+// Certificate.
+type CustomCertificateSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       CustomCertificateParameters `json:"forProvider"`
+}
+
+// A CustomCertificateStatus represents the observed state of a Custom
+// Certificate.
+type CustomCertificateStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          CustomCertificateObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A CustomCertificate is a custom edge certificate uploaded to a Zone.
+// The certificate is uploaded again when it is renewed, such as when
+// the Secret it is read from changes.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.status"
+// +kubebuilder:printcolumn:name="EXPIRES",type="string",JSONPath=".status.atProvider.expiresOn"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type CustomCertificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CustomCertificateSpec   `json:"spec"`
+	Status CustomCertificateStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CustomCertificateList contains a list of Custom Certificate objects
+type CustomCertificateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CustomCertificate `json:"items"`
+}
+
+// ResolveReferences resolves references to the Zone of this Custom
+// Certificate.
+func (cc *CustomCertificate) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, cc)
+
+	// Resolve spec.forProvider.zone
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(cc.Spec.ForProvider.Zone),
+		Reference:    cc.Spec.ForProvider.ZoneRef,
+		Selector:     cc.Spec.ForProvider.ZoneSelector,
+		To:           reference.To{Managed: &zone.Zone{}, List: &zone.ZoneList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.zone")
+	}
+	cc.Spec.ForProvider.Zone = reference.ToPtrValue(rsp.ResolvedValue)
+	cc.Spec.ForProvider.ZoneRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	zone "github.com/benagricola/provider-cloudflare/apis/zone/v1alpha1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+)
+
+// HostnameOriginPullParameters are the configurable fields of
+// Authenticated Origin Pulls for a hostname.
+type HostnameOriginPullParameters struct {
+	// ZoneID the hostname belongs to.
+	// +immutable
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+
+	// ZoneRef references the Zone object the hostname belongs to.
+	// +immutable
+	// +optional
+	ZoneRef *xpv1.Reference `json:"zoneRef,omitempty"`
+
+	// ZoneSelector selects the Zone object the hostname belongs to.
+	// +immutable
+	// +optional
+	ZoneSelector *xpv1.Selector `json:"zoneSelector,omitempty"`
+
+	// Hostname Authenticated Origin Pulls are configured for.
+	// +immutable
+	Hostname string `json:"hostname"`
+
+	// Certificate is the ID of the per-hostname client certificate
+	// presented to the origin of the hostname.
+	// +optional
+	Certificate *string `json:"certificate,omitempty"`
+
+	// CertificateRef references the OriginPullCertificate presented to the
+	// origin of the hostname.
+	// +optional
+	CertificateRef *xpv1.Reference `json:"certificateRef,omitempty"`
+
+	// CertificateSelector selects the OriginPullCertificate presented to
+	// the origin of the hostname.
+	// +optional
+	CertificateSelector *xpv1.Selector `json:"certificateSelector,omitempty"`
+
+	// Enabled controls whether the certificate is presented to the origin
+	// of the hostname.
+	// +kubebuilder:default=true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// HostnameOriginPullObservation are the observable fields of
+// Authenticated Origin Pulls for a hostname.
+type HostnameOriginPullObservation struct {
+	// Status of Authenticated Origin Pulls for the hostname, such as
+	// pending_deployment or active.
+	Status string `json:"status,omitempty"`
+
+	// CertificateStatus is the status of the certificate presented to the
+	// origin of the hostname.
+	CertificateStatus string `json:"certificateStatus,omitempty"`
+
+	// ExpiresOn indicates when the certificate presented to the origin of
+	// the hostname expires.
+	ExpiresOn *metav1.Time `json:"expiresOn,omitempty"`
+}
+
+// A HostnameOriginPullSpec defines the desired state of Authenticated
+// Origin Pulls for a hostname.
+type HostnameOriginPullSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       HostnameOriginPullParameters `json:"forProvider"`
+}
+
+// A HostnameOriginPullStatus represents the observed state of
+// Authenticated Origin Pulls for a hostname.
+type HostnameOriginPullStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          HostnameOriginPullObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A HostnameOriginPull configures per-hostname Authenticated Origin Pulls,
+// so that Cloudflare presents a per-hostname OriginPullCertificate to the
+// origin of a hostname. Per-hostname settings take precedence over
+// zone-level Authenticated Origin Pulls.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="HOSTNAME",type="string",JSONPath=".spec.forProvider.hostname"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type HostnameOriginPull struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HostnameOriginPullSpec   `json:"spec"`
+	Status HostnameOriginPullStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// HostnameOriginPullList contains a list of HostnameOriginPull objects
+type HostnameOriginPullList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HostnameOriginPull `json:"items"`
+}
+
+// ResolveReferences resolves references to the Zone and the
+// OriginPullCertificate of this HostnameOriginPull.
+func (h *HostnameOriginPull) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, h)
+
+	// Resolve spec.forProvider.zone
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(h.Spec.ForProvider.Zone),
+		Reference:    h.Spec.ForProvider.ZoneRef,
+		Selector:     h.Spec.ForProvider.ZoneSelector,
+		To:           reference.To{Managed: &zone.Zone{}, List: &zone.ZoneList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.zone")
+	}
+	h.Spec.ForProvider.Zone = reference.ToPtrValue(rsp.ResolvedValue)
+	h.Spec.ForProvider.ZoneRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.certificate
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(h.Spec.ForProvider.Certificate),
+		Reference:    h.Spec.ForProvider.CertificateRef,
+		Selector:     h.Spec.ForProvider.CertificateSelector,
+		To:           reference.To{Managed: &OriginPullCertificate{}, List: &OriginPullCertificateList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.certificate")
+	}
+	h.Spec.ForProvider.Certificate = reference.ToPtrValue(rsp.ResolvedValue)
+	h.Spec.ForProvider.CertificateRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// OriginCACertificateParameters are the configurable fields of an Origin
+// CA Certificate.
+type OriginCACertificateParameters struct {
+	// Hostnames the certificate is issued for, which may include
+	// wildcards such as *.example.com.
+	// +kubebuilder:validation:MinItems=1
+	// +immutable
+	Hostnames []string `json:"hostnames"`
+
+	// RequestType is the type of private key generated for the
+	// certificate, either origin-rsa or origin-ecc.
+	// +kubebuilder:validation:Enum=origin-rsa;origin-ecc
+	// +kubebuilder:default=origin-rsa
+	// +immutable
+	// +optional
+	RequestType *string `json:"requestType,omitempty"`
+
+	// RequestedValidity is the number of days the certificate is valid
+	// for.
+	// +kubebuilder:validation:Enum=7;30;90;365;730;1095;5475
+	// +kubebuilder:default=5475
+	// +immutable
+	// +optional
+	RequestedValidity *int64 `json:"requestedValidity,omitempty"`
+}
+
+// OriginCACertificateObservation are the observable fields of an Origin
+// CA Certificate.
+type OriginCACertificateObservation struct {
+	// ExpiresOn indicates when the certificate expires.
+	ExpiresOn *metav1.Time `json:"expiresOn,omitempty"`
+}
+
+// An OriginCACertificateSpec defines the desired state of an Origin CA
+// Certificate.
+type OriginCACertificateSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       OriginCACertificateParameters `json:"forProvider"`
+}
+
+// An OriginCACertificateStatus represents the observed state of an Origin
+// CA Certificate.
+type OriginCACertificateStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          OriginCACertificateObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An OriginCACertificate is a certificate issued by the Cloudflare Origin
+// CA, used to encrypt traffic between Cloudflare and an origin. The issued
+// certificate and its private key are written to the connection secret.
+// Issuing Origin CA certificates requires an API token.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXPIRES",type="string",JSONPath=".status.atProvider.expiresOn"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type OriginCACertificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OriginCACertificateSpec   `json:"spec"`
+	Status OriginCACertificateStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OriginCACertificateList contains a list of Origin CA Certificate objects
+type OriginCACertificateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OriginCACertificate `json:"items"`
+}
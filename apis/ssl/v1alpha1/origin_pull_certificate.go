@@ -0,0 +1,155 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	zone "github.com/benagricola/provider-cloudflare/apis/zone/v1alpha1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+)
+
+// OriginPullCertificateParameters are the configurable fields of an
+// Origin Pull Certificate.
+type OriginPullCertificateParameters struct {
+	// ZoneID this Origin Pull Certificate is uploaded to.
+	// +immutable
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+
+	// ZoneRef references the Zone object this Origin Pull Certificate is
+	// uploaded to.
+	// +immutable
+	// +optional
+	ZoneRef *xpv1.Reference `json:"zoneRef,omitempty"`
+
+	// ZoneSelector selects the Zone object this Origin Pull Certificate
+	// is uploaded to.
+	// +immutable
+	// +optional
+	ZoneSelector *xpv1.Selector `json:"zoneSelector,omitempty"`
+
+	// PerHostname uploads the certificate for use with per-hostname
+	// Authenticated Origin Pulls, rather than zone-level Authenticated
+	// Origin Pulls.
+	// +immutable
+	// +optional
+	PerHostname *bool `json:"perHostname,omitempty"`
+
+	// CertificateSecretRef selects a Secret key containing the PEM
+	// encoded client certificate.
+	CertificateSecretRef xpv1.SecretKeySelector `json:"certificateSecretRef"`
+
+	// PrivateKeySecretRef selects a Secret key containing the PEM encoded
+	// private key of the client certificate.
+	PrivateKeySecretRef xpv1.SecretKeySelector `json:"privateKeySecretRef"`
+}
+
+// OriginPullCertificateObservation are the observable fields of an
+// Origin Pull Certificate.
+type OriginPullCertificateObservation struct {
+	// Issuer of the certificate.
+	Issuer string `json:"issuer,omitempty"`
+
+	// Signature algorithm of the certificate.
+	Signature string `json:"signature,omitempty"`
+
+	// SerialNumber of the certificate.
+	SerialNumber string `json:"serialNumber,omitempty"`
+
+	// Status of the certificate, such as pending_deployment or active.
+	Status string `json:"status,omitempty"`
+
+	// UploadedOn indicates when the certificate was uploaded.
+	UploadedOn *metav1.Time `json:"uploadedOn,omitempty"`
+
+	// ExpiresOn indicates when the certificate expires.
+	ExpiresOn *metav1.Time `json:"expiresOn,omitempty"`
+}
+
+// An OriginPullCertificateSpec defines the desired state of an Origin
+// Pull Certificate.
+type OriginPullCertificateSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       OriginPullCertificateParameters `json:"forProvider"`
+}
+
+// An OriginPullCertificateStatus represents the observed state of an
+// Origin Pull Certificate.
+type OriginPullCertificateStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          OriginPullCertificateObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An OriginPullCertificate is a client certificate uploaded to a Zone,
+// which Cloudflare presents to origins when Authenticated Origin Pulls
+// are enabled. Uploaded certificates cannot be modified, so the
+// certificate is replaced when the Secret it is read from changes.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.status"
+// +kubebuilder:printcolumn:name="EXPIRES",type="string",JSONPath=".status.atProvider.expiresOn"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type OriginPullCertificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OriginPullCertificateSpec   `json:"spec"`
+	Status OriginPullCertificateStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OriginPullCertificateList contains a list of Origin Pull Certificate
+// objects
+type OriginPullCertificateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OriginPullCertificate `json:"items"`
+}
+
+// ResolveReferences resolves references to the Zone of this Origin Pull
+// Certificate.
+func (oc *OriginPullCertificate) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, oc)
+
+	// Resolve spec.forProvider.zone
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(oc.Spec.ForProvider.Zone),
+		Reference:    oc.Spec.ForProvider.ZoneRef,
+		Selector:     oc.Spec.ForProvider.ZoneSelector,
+		To:           reference.To{Managed: &zone.Zone{}, List: &zone.ZoneList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.zone")
+	}
+	oc.Spec.ForProvider.Zone = reference.ToPtrValue(rsp.ResolvedValue)
+	oc.Spec.ForProvider.ZoneRef = rsp.ResolvedReference
+
+	return nil
+}
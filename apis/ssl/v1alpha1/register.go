@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+// Package type metadata.
+const (
+	Group   = "ssl.cloudflare.crossplane.io"
+	Version = "v1alpha1"
+)
+
+var (
+	// SchemeGroupVersion is group version used to register these objects
+	SchemeGroupVersion = schema.GroupVersion{Group: Group, Version: Version}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
+	SchemeBuilder = &scheme.Builder{GroupVersion: SchemeGroupVersion}
+)
+
+// AuthenticatedOriginPulls type metadata.
+var (
+	AuthenticatedOriginPullsKind             = reflect.TypeOf(AuthenticatedOriginPulls{}).Name()
+	AuthenticatedOriginPullsGroupKind        = schema.GroupKind{Group: Group, Kind: AuthenticatedOriginPullsKind}.String()
+	AuthenticatedOriginPullsKindAPIVersion   = AuthenticatedOriginPullsKind + "." + SchemeGroupVersion.String()
+	AuthenticatedOriginPullsGroupVersionKind = SchemeGroupVersion.WithKind(AuthenticatedOriginPullsKind)
+)
+
+// CustomCertificate type metadata.
+var (
+	CustomCertificateKind             = reflect.TypeOf(CustomCertificate{}).Name()
+	CustomCertificateGroupKind        = schema.GroupKind{Group: Group, Kind: CustomCertificateKind}.String()
+	CustomCertificateKindAPIVersion   = CustomCertificateKind + "." + SchemeGroupVersion.String()
+	CustomCertificateGroupVersionKind = SchemeGroupVersion.WithKind(CustomCertificateKind)
+)
+
+// HostnameOriginPull type metadata.
+var (
+	HostnameOriginPullKind             = reflect.TypeOf(HostnameOriginPull{}).Name()
+	HostnameOriginPullGroupKind        = schema.GroupKind{Group: Group, Kind: HostnameOriginPullKind}.String()
+	HostnameOriginPullKindAPIVersion   = HostnameOriginPullKind + "." + SchemeGroupVersion.String()
+	HostnameOriginPullGroupVersionKind = SchemeGroupVersion.WithKind(HostnameOriginPullKind)
+)
+
+// OriginCACertificate type metadata.
+var (
+	OriginCACertificateKind             = reflect.TypeOf(OriginCACertificate{}).Name()
+	OriginCACertificateGroupKind        = schema.GroupKind{Group: Group, Kind: OriginCACertificateKind}.String()
+	OriginCACertificateKindAPIVersion   = OriginCACertificateKind + "." + SchemeGroupVersion.String()
+	OriginCACertificateGroupVersionKind = SchemeGroupVersion.WithKind(OriginCACertificateKind)
+)
+
+// OriginPullCertificate type metadata.
+var (
+	OriginPullCertificateKind             = reflect.TypeOf(OriginPullCertificate{}).Name()
+	OriginPullCertificateGroupKind        = schema.GroupKind{Group: Group, Kind: OriginPullCertificateKind}.String()
+	OriginPullCertificateKindAPIVersion   = OriginPullCertificateKind + "." + SchemeGroupVersion.String()
+	OriginPullCertificateGroupVersionKind = SchemeGroupVersion.WithKind(OriginPullCertificateKind)
+)
+
+// TotalTLS type metadata.
+var (
+	TotalTLSKind             = reflect.TypeOf(TotalTLS{}).Name()
+	TotalTLSGroupKind        = schema.GroupKind{Group: Group, Kind: TotalTLSKind}.String()
+	TotalTLSKindAPIVersion   = TotalTLSKind + "." + SchemeGroupVersion.String()
+	TotalTLSGroupVersionKind = SchemeGroupVersion.WithKind(TotalTLSKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&AuthenticatedOriginPulls{}, &AuthenticatedOriginPullsList{})
+	SchemeBuilder.Register(&CustomCertificate{}, &CustomCertificateList{})
+	SchemeBuilder.Register(&HostnameOriginPull{}, &HostnameOriginPullList{})
+	SchemeBuilder.Register(&OriginCACertificate{}, &OriginCACertificateList{})
+	SchemeBuilder.Register(&OriginPullCertificate{}, &OriginPullCertificateList{})
+	SchemeBuilder.Register(&TotalTLS{}, &TotalTLSList{})
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	zone "github.com/benagricola/provider-cloudflare/apis/zone/v1alpha1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+)
+
+// TotalTLSParameters are the configurable fields of Total TLS on a Zone.
+type TotalTLSParameters struct {
+	// ZoneID Total TLS is enabled on.
+	// +immutable
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+
+	// ZoneRef references the Zone object Total TLS is enabled on.
+	// +immutable
+	// +optional
+	ZoneRef *xpv1.Reference `json:"zoneRef,omitempty"`
+
+	// ZoneSelector selects the Zone object Total TLS is enabled on.
+	// +immutable
+	// +optional
+	ZoneSelector *xpv1.Selector `json:"zoneSelector,omitempty"`
+
+	// CertificateAuthority issues the certificates of proxied hostnames.
+	// Cloudflare picks a certificate authority when none is set.
+	// +kubebuilder:validation:Enum=google;lets_encrypt;ssl_com
+	// +optional
+	CertificateAuthority *string `json:"certificateAuthority,omitempty"`
+}
+
+// HostnameCertificate is the status of the certificate covering a
+// hostname of a Zone.
+type HostnameCertificate struct {
+	// Hostname covered by the certificate.
+	Hostname string `json:"hostname"`
+
+	// CertificatePackID is the ID of the certificate pack the
+	// certificate belongs to.
+	CertificatePackID string `json:"certificatePackId,omitempty"`
+
+	// Type of the certificate pack, such as universal or advanced.
+	Type string `json:"type,omitempty"`
+
+	// CertificateAuthority that issued the certificate.
+	CertificateAuthority string `json:"certificateAuthority,omitempty"`
+
+	// Status of the certificate, such as pending_validation or active.
+	Status string `json:"status,omitempty"`
+}
+
+// TotalTLSObservation are the observable fields of Total TLS on a Zone.
+type TotalTLSObservation struct {
+	// CertificateAuthority issuing the certificates of proxied hostnames.
+	CertificateAuthority string `json:"certificateAuthority,omitempty"`
+
+	// ValidityDays is the validity period of issued certificates.
+	ValidityDays int `json:"validityDays,omitempty"`
+
+	// Certificates lists the status of the certificate covering each
+	// hostname of the Zone.
+	Certificates []HostnameCertificate `json:"certificates,omitempty"`
+}
+
+// A TotalTLSSpec defines the desired state of Total TLS on a Zone.
+type TotalTLSSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TotalTLSParameters `json:"forProvider"`
+}
+
+// A TotalTLSStatus represents the observed state of Total TLS on a Zone.
+type TotalTLSStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          TotalTLSObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A TotalTLS enables Total TLS on a Zone, issuing an edge certificate
+// for every proxied hostname of the Zone. Total TLS is disabled on the
+// Zone when the TotalTLS is deleted.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="CA",type="string",JSONPath=".status.atProvider.certificateAuthority"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=totaltls,scope=Cluster,categories={crossplane,managed,cloudflare}
+type TotalTLS struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TotalTLSSpec   `json:"spec"`
+	Status TotalTLSStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TotalTLSList contains a list of TotalTLS objects
+type TotalTLSList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TotalTLS `json:"items"`
+}
+
+// ResolveReferences resolves references to the Zone that Total TLS is
+// enabled on.
+func (t *TotalTLS) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, t)
+
+	// Resolve spec.forProvider.zone
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(t.Spec.ForProvider.Zone),
+		Reference:    t.Spec.ForProvider.ZoneRef,
+		Selector:     t.Spec.ForProvider.ZoneSelector,
+		To:           reference.To{Managed: &zone.Zone{}, List: &zone.ZoneList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.zone")
+	}
+	t.Spec.ForProvider.Zone = reference.ToPtrValue(rsp.ResolvedValue)
+	t.Spec.ForProvider.ZoneRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Reasons a Custom Hostname is not available.
+const (
+	ReasonCertificateNotActive xpv1.ConditionReason = "CertificateNotActive"
+)
+
+// Reasons a Fallback Origin is not available.
+const (
+	ReasonFallbackOriginNotActive xpv1.ConditionReason = "FallbackOriginNotActive"
+)
+
+// CertificateNotActive returns a condition that indicates the Custom
+// Hostname is not available because its certificate is in the passed
+// status rather than active.
+func CertificateNotActive(status string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCertificateNotActive,
+		Message:            fmt.Sprintf("The SSL certificate of this custom hostname has status %q", status),
+	}
+}
+
+// FallbackOriginNotActive returns a condition that indicates the Fallback
+// Origin is not available because it is in the passed status rather than
+// active, along with any errors Cloudflare reported while deploying it.
+func FallbackOriginNotActive(status string, errs []string) xpv1.Condition {
+	msg := fmt.Sprintf("The fallback origin has status %q", status)
+	if len(errs) > 0 {
+		msg += ": " + strings.Join(errs, "; ")
+	}
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonFallbackOriginNotActive,
+		Message:            msg,
+	}
+}
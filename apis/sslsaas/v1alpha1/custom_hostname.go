@@ -55,6 +55,8 @@ type CustomHostnameSSLSettings struct {
 	MinTLSVersion *string `json:"minTLSVersion,omitempty"`
 
 	// An allowlist of ciphers for TLS termination. These ciphers must be in the BoringSSL format.
+	// An empty list means the same as an unset one: the Cloudflare default
+	// ciphers are used and are not managed.
 	Ciphers []string `json:"ciphers,omitempty"`
 
 	// Fields not supported in the go library yet
@@ -134,6 +136,35 @@ type CustomHostnameSSL struct {
 
 }
 
+// CustomHostnameSSLValidationRecord represents a record that must be
+// published to pass domain control validation (DCV) for the certificate
+// of a custom hostname.
+type CustomHostnameSSLValidationRecord struct {
+	// TXTName is the name of the TXT record that must be created when
+	// using the txt method.
+	// +optional
+	TXTName string `json:"txtName,omitempty"`
+
+	// TXTValue is the value of the TXT record that must be created when
+	// using the txt method.
+	// +optional
+	TXTValue string `json:"txtValue,omitempty"`
+
+	// HTTPURL is the location where a file must be made available when
+	// using the http method.
+	// +optional
+	HTTPURL string `json:"httpURL,omitempty"`
+
+	// HTTPBody is the contents of the above file.
+	// +optional
+	HTTPBody string `json:"httpBody,omitempty"`
+
+	// Emails are the addresses that validation emails are sent to when
+	// using the email method.
+	// +optional
+	Emails []string `json:"emails,omitempty"`
+}
+
 // CustomHostnameSSLObserved represents the Observed SSL section in a given custom hostname.
 type CustomHostnameSSLObserved struct {
 	Status               string                                         `json:"status"`
@@ -144,11 +175,24 @@ type CustomHostnameSSLObserved struct {
 	CnameName            string                                         `json:"cname"`
 	CnameTarget          string                                         `json:"cnameTarget"`
 
+	// Method is the domain control validation (DCV) method that the
+	// validation records of the certificate were issued for.
+	// +optional
+	Method string `json:"method,omitempty"`
+
+	// ExpiresOn is the time the active certificate for the Custom Hostname
+	// expires.
+	// +optional
+	ExpiresOn *metav1.Time `json:"expiresOn,omitempty"`
+
+	// ValidationRecords are the records that must be published to pass
+	// domain control validation (DCV) for the certificate, using the
+	// method above.
+	// +optional
+	ValidationRecords []CustomHostnameSSLValidationRecord `json:"validationRecords,omitempty"`
+
 	// Following fields are in the API but not supported in go library yet
-	// TxtName          string                              `json:"txt_name,omitempty"`
-	// TxtValue         string                              `json:"txt_value,omitempty"`
 	// UploadedOn metav1.Time `json:"uploaded_on,omitempty"`
-	// ExpiresOn  metav1.Time `json:"expires_on,omitempty"`
 
 	// Waiting on 0.15 to release
 	// Issuer           string                              `json:"issuer,omitempty"`
@@ -170,6 +214,14 @@ type CustomHostnameParameters struct {
 	// +optional
 	SSL CustomHostnameSSL `json:"ssl,omitempty"`
 
+	// CustomMetadata is a map of keys and values that Cloudflare may act on
+	// for this Custom Hostname. Both keys and values must be strings. Keys
+	// may be up to 255 letters, digits, '-', '_' and '.', values must not
+	// contain control characters, and the metadata must be at most 4096
+	// bytes when encoded as JSON.
+	// +optional
+	CustomMetadata map[string]string `json:"customMetadata,omitempty"`
+
 	// CustomOriginServer for a Custom Hostname
 	// A valid hostname that’s been added to your DNS zone as an A, AAAA, or CNAME record.
 	// +optional
@@ -184,6 +236,25 @@ type CustomHostnameParameters struct {
 	// +optional
 	CustomOriginServerSelector *xpv1.Selector `json:"customOriginServerSelector,omitempty"`
 
+	// ValidationRecordZone is the ID of a Zone on which TXT records are
+	// created to pass domain control validation (DCV) for the certificate
+	// when using the txt method. This must be the Zone that the hostname
+	// itself is on. The records are deleted once Cloudflare no longer
+	// returns them, such as once the certificate is active, when this field
+	// is removed, and along with the custom hostname.
+	// +optional
+	ValidationRecordZone *string `json:"validationRecordZone,omitempty"`
+
+	// ValidationRecordZoneRef references the Zone object on which the TXT
+	// validation records are created.
+	// +optional
+	ValidationRecordZoneRef *xpv1.Reference `json:"validationRecordZoneRef,omitempty"`
+
+	// ValidationRecordZoneSelector selects the Zone object on which the
+	// TXT validation records are created.
+	// +optional
+	ValidationRecordZoneSelector *xpv1.Selector `json:"validationRecordZoneSelector,omitempty"`
+
 	// ZoneID this custom hostname is for.
 	// +immutable
 	// +optional
@@ -199,12 +270,36 @@ type CustomHostnameParameters struct {
 	ZoneSelector *xpv1.Selector `json:"zoneSelector,omitempty"`
 }
 
+// CustomHostnameValidationDNSRecord is a TXT record that was created on
+// the ValidationRecordZone for a validation record of a custom hostname.
+type CustomHostnameValidationDNSRecord struct {
+	// Zone is the ID of the zone the DNS record was created on.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+
+	// ID of the DNS record.
+	ID string `json:"id"`
+
+	// Name of the TXT record.
+	Name string `json:"name"`
+
+	// Value of the TXT record.
+	Value string `json:"value"`
+}
+
 // CustomHostnameObservation are the observable fields of a custom hostname.
 type CustomHostnameObservation struct {
 	Status                cloudflare.CustomHostnameStatus     `json:"status"`
 	OwnershipVerification CustomHostnameOwnershipVerification `json:"ownershipVerification,omitempty"`
 	VerificationErrors    []string                            `json:"verificationErrors,omitempty"`
 	SSL                   CustomHostnameSSLObserved           `json:"ssl,omitempty"`
+
+	// ValidationDNSRecords are the TXT records that were created on the
+	// ValidationRecordZone, so that they can be deleted once they are no
+	// longer needed. The records are also tagged with a comment naming
+	// the custom hostname, so that they can be found if this is lost.
+	// +optional
+	ValidationDNSRecords []CustomHostnameValidationDNSRecord `json:"validationDNSRecords,omitempty"`
 }
 
 // A CustomHostnameSpec defines the desired state of a custom hostname.
@@ -277,5 +372,19 @@ func (dr *CustomHostname) ResolveReferences(ctx context.Context, c client.Reader
 	dr.Spec.ForProvider.Zone = reference.ToPtrValue(rsp.ResolvedValue)
 	dr.Spec.ForProvider.ZoneRef = rsp.ResolvedReference
 
+	// Resolve spec.forProvider.validationRecordZone
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(dr.Spec.ForProvider.ValidationRecordZone),
+		Reference:    dr.Spec.ForProvider.ValidationRecordZoneRef,
+		Selector:     dr.Spec.ForProvider.ValidationRecordZoneSelector,
+		To:           reference.To{Managed: &zone.Zone{}, List: &zone.ZoneList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.validationRecordZone")
+	}
+	dr.Spec.ForProvider.ValidationRecordZone = reference.ToPtrValue(rsp.ResolvedValue)
+	dr.Spec.ForProvider.ValidationRecordZoneRef = rsp.ResolvedReference
+
 	return nil
 }
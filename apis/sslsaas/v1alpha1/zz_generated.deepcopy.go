@@ -95,6 +95,11 @@ func (in *CustomHostnameObservation) DeepCopyInto(out *CustomHostnameObservation
 		copy(*out, *in)
 	}
 	in.SSL.DeepCopyInto(&out.SSL)
+	if in.ValidationDNSRecords != nil {
+		in, out := &in.ValidationDNSRecords, &out.ValidationDNSRecords
+		*out = make([]CustomHostnameValidationDNSRecord, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomHostnameObservation.
@@ -191,6 +196,13 @@ func (in *CustomHostnameOwnershipVerificationHTTP) DeepCopy() *CustomHostnameOwn
 func (in *CustomHostnameParameters) DeepCopyInto(out *CustomHostnameParameters) {
 	*out = *in
 	in.SSL.DeepCopyInto(&out.SSL)
+	if in.CustomMetadata != nil {
+		in, out := &in.CustomMetadata, &out.CustomMetadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.CustomOriginServer != nil {
 		in, out := &in.CustomOriginServer, &out.CustomOriginServer
 		*out = new(string)
@@ -206,6 +218,21 @@ func (in *CustomHostnameParameters) DeepCopyInto(out *CustomHostnameParameters)
 		*out = new(v1.Selector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ValidationRecordZone != nil {
+		in, out := &in.ValidationRecordZone, &out.ValidationRecordZone
+		*out = new(string)
+		**out = **in
+	}
+	if in.ValidationRecordZoneRef != nil {
+		in, out := &in.ValidationRecordZoneRef, &out.ValidationRecordZoneRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.ValidationRecordZoneSelector != nil {
+		in, out := &in.ValidationRecordZoneSelector, &out.ValidationRecordZoneSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Zone != nil {
 		in, out := &in.Zone, &out.Zone
 		*out = new(string)
@@ -282,6 +309,17 @@ func (in *CustomHostnameSSLObserved) DeepCopyInto(out *CustomHostnameSSLObserved
 		*out = make([]cloudflare_go.CustomHostnameSSLValidationErrors, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExpiresOn != nil {
+		in, out := &in.ExpiresOn, &out.ExpiresOn
+		*out = (*in).DeepCopy()
+	}
+	if in.ValidationRecords != nil {
+		in, out := &in.ValidationRecords, &out.ValidationRecords
+		*out = make([]CustomHostnameSSLValidationRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomHostnameSSLObserved.
@@ -344,6 +382,26 @@ func (in *CustomHostnameSSLValidationErrors) DeepCopy() *CustomHostnameSSLValida
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomHostnameSSLValidationRecord) DeepCopyInto(out *CustomHostnameSSLValidationRecord) {
+	*out = *in
+	if in.Emails != nil {
+		in, out := &in.Emails, &out.Emails
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomHostnameSSLValidationRecord.
+func (in *CustomHostnameSSLValidationRecord) DeepCopy() *CustomHostnameSSLValidationRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomHostnameSSLValidationRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomHostnameSpec) DeepCopyInto(out *CustomHostnameSpec) {
 	*out = *in
@@ -378,6 +436,21 @@ func (in *CustomHostnameStatus) DeepCopy() *CustomHostnameStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomHostnameValidationDNSRecord) DeepCopyInto(out *CustomHostnameValidationDNSRecord) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomHostnameValidationDNSRecord.
+func (in *CustomHostnameValidationDNSRecord) DeepCopy() *CustomHostnameValidationDNSRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomHostnameValidationDNSRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FallbackOrigin) DeepCopyInto(out *FallbackOrigin) {
 	*out = *in
@@ -0,0 +1,248 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+
+	account "github.com/benagricola/provider-cloudflare/apis/account/v1alpha1"
+)
+
+// TunnelOriginRequest configures how cloudflared proxies requests to an
+// origin service. Settings that are not set use the cloudflared defaults.
+type TunnelOriginRequest struct {
+	// ConnectTimeout is the timeout in seconds for establishing a new TCP
+	// connection to the origin.
+	// +optional
+	ConnectTimeout *int64 `json:"connectTimeout,omitempty"`
+
+	// TLSTimeout is the timeout in seconds for completing a TLS handshake
+	// with the origin.
+	// +optional
+	TLSTimeout *int64 `json:"tlsTimeout,omitempty"`
+
+	// TCPKeepAlive is the keepalive interval in seconds for TCP
+	// connections to the origin.
+	// +optional
+	TCPKeepAlive *int64 `json:"tcpKeepAlive,omitempty"`
+
+	// NoHappyEyeballs disables the Happy Eyeballs IPv4/IPv6 fallback.
+	// +optional
+	NoHappyEyeballs *bool `json:"noHappyEyeballs,omitempty"`
+
+	// KeepAliveConnections is the maximum number of idle keepalive
+	// connections to the origin.
+	// +optional
+	KeepAliveConnections *int64 `json:"keepAliveConnections,omitempty"`
+
+	// KeepAliveTimeout is the timeout in seconds after which an idle
+	// keepalive connection is closed.
+	// +optional
+	KeepAliveTimeout *int64 `json:"keepAliveTimeout,omitempty"`
+
+	// HTTPHostHeader overrides the Host header sent to the origin.
+	// +optional
+	HTTPHostHeader *string `json:"httpHostHeader,omitempty"`
+
+	// OriginServerName is the hostname expected on the origin server
+	// certificate.
+	// +optional
+	OriginServerName *string `json:"originServerName,omitempty"`
+
+	// CAPool is the path to a CA bundle used to verify the origin server
+	// certificate.
+	// +optional
+	CAPool *string `json:"caPool,omitempty"`
+
+	// NoTLSVerify disables verification of the origin server certificate.
+	// +optional
+	NoTLSVerify *bool `json:"noTLSVerify,omitempty"`
+
+	// DisableChunkedEncoding disables chunked transfer encoding to the
+	// origin.
+	// +optional
+	DisableChunkedEncoding *bool `json:"disableChunkedEncoding,omitempty"`
+
+	// ProxyType configures the built in proxy of cloudflared.
+	// +kubebuilder:validation:Enum="";socks
+	// +optional
+	ProxyType *string `json:"proxyType,omitempty"`
+}
+
+// TunnelIngressRule routes requests matching a hostname and path to an
+// origin service.
+type TunnelIngressRule struct {
+	// Hostname matched by this rule. Matches all hostnames if not set.
+	// +optional
+	Hostname *string `json:"hostname,omitempty"`
+
+	// Path matched by this rule, as a regular expression. Matches all
+	// paths if not set.
+	// +optional
+	Path *string `json:"path,omitempty"`
+
+	// Service requests are proxied to, such as http://localhost:8080 or
+	// http_status:404.
+	Service string `json:"service"`
+
+	// OriginRequest overrides the default origin request settings for
+	// this rule.
+	// +optional
+	OriginRequest *TunnelOriginRequest `json:"originRequest,omitempty"`
+}
+
+// TunnelConfigurationParameters are the configurable fields of a Tunnel
+// Configuration.
+type TunnelConfigurationParameters struct {
+	// Account is the account ID of the configured Tunnel.
+	// +immutable
+	// +optional
+	Account *string `json:"account,omitempty"`
+
+	// AccountRef references the Account object of the configured Tunnel.
+	// +immutable
+	// +optional
+	AccountRef *xpv1.Reference `json:"accountRef,omitempty"`
+
+	// AccountSelector selects the Account object of the configured
+	// Tunnel.
+	// +immutable
+	// +optional
+	AccountSelector *xpv1.Selector `json:"accountSelector,omitempty"`
+
+	// Tunnel is the ID of the configured Tunnel.
+	// +immutable
+	// +optional
+	Tunnel *string `json:"tunnel,omitempty"`
+
+	// TunnelRef references the configured Tunnel object.
+	// +immutable
+	// +optional
+	TunnelRef *xpv1.Reference `json:"tunnelRef,omitempty"`
+
+	// TunnelSelector selects the configured Tunnel object.
+	// +immutable
+	// +optional
+	TunnelSelector *xpv1.Selector `json:"tunnelSelector,omitempty"`
+
+	// Ingress rules, evaluated in order. The last rule must match all
+	// requests, and is typically a http_status:404 service.
+	// +kubebuilder:validation:MinItems=1
+	Ingress []TunnelIngressRule `json:"ingress"`
+
+	// OriginRequest is the default origin request settings for all
+	// ingress rules.
+	// +optional
+	OriginRequest *TunnelOriginRequest `json:"originRequest,omitempty"`
+
+	// WarpRouting enables routing private network traffic from WARP
+	// clients through the Tunnel.
+	// +optional
+	WarpRouting *bool `json:"warpRouting,omitempty"`
+}
+
+// TunnelConfigurationObservation are the observable fields of a Tunnel
+// Configuration.
+type TunnelConfigurationObservation struct {
+	// Version of the configuration, incremented on every change.
+	Version int64 `json:"version,omitempty"`
+}
+
+// A TunnelConfigurationSpec defines the desired state of a Tunnel
+// Configuration.
+type TunnelConfigurationSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TunnelConfigurationParameters `json:"forProvider"`
+}
+
+// A TunnelConfigurationStatus represents the observed state of a Tunnel
+// Configuration.
+type TunnelConfigurationStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          TunnelConfigurationObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A TunnelConfiguration is the remotely managed configuration of a Tunnel,
+// which cloudflared fetches when run with a Tunnel token. There is a
+// single configuration per Tunnel.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="VERSION",type="integer",JSONPath=".status.atProvider.version"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type TunnelConfiguration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TunnelConfigurationSpec   `json:"spec"`
+	Status TunnelConfigurationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TunnelConfigurationList contains a list of Tunnel Configuration objects
+type TunnelConfigurationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TunnelConfiguration `json:"items"`
+}
+
+// ResolveReferences resolves references to the Account and Tunnel of this
+// Tunnel Configuration.
+func (tc *TunnelConfiguration) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, tc)
+
+	// Resolve spec.forProvider.account
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(tc.Spec.ForProvider.Account),
+		Reference:    tc.Spec.ForProvider.AccountRef,
+		Selector:     tc.Spec.ForProvider.AccountSelector,
+		To:           reference.To{Managed: &account.Account{}, List: &account.AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.account")
+	}
+	tc.Spec.ForProvider.Account = reference.ToPtrValue(rsp.ResolvedValue)
+	tc.Spec.ForProvider.AccountRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.tunnel
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(tc.Spec.ForProvider.Tunnel),
+		Reference:    tc.Spec.ForProvider.TunnelRef,
+		Selector:     tc.Spec.ForProvider.TunnelSelector,
+		To:           reference.To{Managed: &Tunnel{}, List: &TunnelList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.tunnel")
+	}
+	tc.Spec.ForProvider.Tunnel = reference.ToPtrValue(rsp.ResolvedValue)
+	tc.Spec.ForProvider.TunnelRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+
+	account "github.com/benagricola/provider-cloudflare/apis/account/v1alpha1"
+)
+
+// TunnelRouteParameters are the configurable fields of a Tunnel Route.
+type TunnelRouteParameters struct {
+	// Account is the account ID this Tunnel Route is created under.
+	// +immutable
+	// +optional
+	Account *string `json:"account,omitempty"`
+
+	// AccountRef references the Account object this Tunnel Route is
+	// created under.
+	// +immutable
+	// +optional
+	AccountRef *xpv1.Reference `json:"accountRef,omitempty"`
+
+	// AccountSelector selects the Account object this Tunnel Route is
+	// created under.
+	// +immutable
+	// +optional
+	AccountSelector *xpv1.Selector `json:"accountSelector,omitempty"`
+
+	// Tunnel is the ID of the Tunnel traffic to the network is routed
+	// through.
+	// +optional
+	Tunnel *string `json:"tunnel,omitempty"`
+
+	// TunnelRef references the Tunnel object traffic to the network is
+	// routed through.
+	// +optional
+	TunnelRef *xpv1.Reference `json:"tunnelRef,omitempty"`
+
+	// TunnelSelector selects the Tunnel object traffic to the network is
+	// routed through.
+	// +optional
+	TunnelSelector *xpv1.Selector `json:"tunnelSelector,omitempty"`
+
+	// Network is the private network CIDR routed through the Tunnel.
+	Network string `json:"network"`
+
+	// Comment describing the Tunnel Route.
+	// +optional
+	Comment *string `json:"comment,omitempty"`
+
+	// VirtualNetworkID is the ID of the virtual network the route is
+	// added to. The default virtual network is used if not set.
+	// +immutable
+	// +optional
+	VirtualNetworkID *string `json:"virtualNetworkId,omitempty"`
+}
+
+// TunnelRouteObservation are the observable fields of a Tunnel Route.
+type TunnelRouteObservation struct{}
+
+// A TunnelRouteSpec defines the desired state of a Tunnel Route.
+type TunnelRouteSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TunnelRouteParameters `json:"forProvider"`
+}
+
+// A TunnelRouteStatus represents the observed state of a Tunnel Route.
+type TunnelRouteStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          TunnelRouteObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A TunnelRoute routes a private network CIDR through a Tunnel.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="NETWORK",type="string",JSONPath=".spec.forProvider.network"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type TunnelRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TunnelRouteSpec   `json:"spec"`
+	Status TunnelRouteStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TunnelRouteList contains a list of Tunnel Route objects
+type TunnelRouteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TunnelRoute `json:"items"`
+}
+
+// ResolveReferences resolves references to the Account and Tunnel of this
+// Tunnel Route.
+func (tr *TunnelRoute) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, tr)
+
+	// Resolve spec.forProvider.account
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(tr.Spec.ForProvider.Account),
+		Reference:    tr.Spec.ForProvider.AccountRef,
+		Selector:     tr.Spec.ForProvider.AccountSelector,
+		To:           reference.To{Managed: &account.Account{}, List: &account.AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.account")
+	}
+	tr.Spec.ForProvider.Account = reference.ToPtrValue(rsp.ResolvedValue)
+	tr.Spec.ForProvider.AccountRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.tunnel
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(tr.Spec.ForProvider.Tunnel),
+		Reference:    tr.Spec.ForProvider.TunnelRef,
+		Selector:     tr.Spec.ForProvider.TunnelSelector,
+		To:           reference.To{Managed: &Tunnel{}, List: &TunnelList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.tunnel")
+	}
+	tr.Spec.ForProvider.Tunnel = reference.ToPtrValue(rsp.ResolvedValue)
+	tr.Spec.ForProvider.TunnelRef = rsp.ResolvedReference
+
+	return nil
+}
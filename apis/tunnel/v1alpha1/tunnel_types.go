@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+
+	account "github.com/benagricola/provider-cloudflare/apis/account/v1alpha1"
+)
+
+// TunnelParameters are the configurable fields of a Tunnel.
+type TunnelParameters struct {
+	// Account is the account ID this Tunnel is created under.
+	// +immutable
+	// +optional
+	Account *string `json:"account,omitempty"`
+
+	// AccountRef references the Account object this Tunnel is created
+	// under.
+	// +immutable
+	// +optional
+	AccountRef *xpv1.Reference `json:"accountRef,omitempty"`
+
+	// AccountSelector selects the Account object this Tunnel is created
+	// under.
+	// +immutable
+	// +optional
+	AccountSelector *xpv1.Selector `json:"accountSelector,omitempty"`
+
+	// Name of the Tunnel. Defaults to the name of this resource.
+	// +immutable
+	// +optional
+	Name *string `json:"name,omitempty"`
+}
+
+// TunnelConnection is a connection from a cloudflared instance to the
+// Cloudflare edge.
+type TunnelConnection struct {
+	// ColoName is the name of the Cloudflare data centre the connection
+	// terminates in.
+	ColoName string `json:"coloName,omitempty"`
+
+	// UUID of the connection.
+	UUID string `json:"uuid,omitempty"`
+
+	// IsPendingReconnect is true if the connection is being re-established.
+	IsPendingReconnect bool `json:"isPendingReconnect,omitempty"`
+}
+
+// TunnelObservation are the observable fields of a Tunnel.
+type TunnelObservation struct {
+	// ID of the Tunnel.
+	ID string `json:"id,omitempty"`
+
+	// Connections currently established to the Tunnel.
+	Connections []TunnelConnection `json:"connections,omitempty"`
+}
+
+// A TunnelSpec defines the desired state of a Tunnel.
+type TunnelSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TunnelParameters `json:"forProvider"`
+}
+
+// A TunnelStatus represents the observed state of a Tunnel.
+type TunnelStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          TunnelObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Tunnel is a named Cloudflare Tunnel that cloudflared instances connect
+// to. The tunnel credentials are written to the connection secret when the
+// Tunnel is created and cannot be retrieved afterwards.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.atProvider.id"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type Tunnel struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TunnelSpec   `json:"spec"`
+	Status TunnelStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TunnelList contains a list of Tunnel objects
+type TunnelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Tunnel `json:"items"`
+}
+
+// ResolveReferences resolves references to the Account that this Tunnel
+// is created under.
+func (t *Tunnel) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, t)
+
+	// Resolve spec.forProvider.account
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(t.Spec.ForProvider.Account),
+		Reference:    t.Spec.ForProvider.AccountRef,
+		Selector:     t.Spec.ForProvider.AccountSelector,
+		To:           reference.To{Managed: &account.Account{}, List: &account.AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.account")
+	}
+	t.Spec.ForProvider.Account = reference.ToPtrValue(rsp.ResolvedValue)
+	t.Spec.ForProvider.AccountRef = rsp.ResolvedReference
+
+	return nil
+}
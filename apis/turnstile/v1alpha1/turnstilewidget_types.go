@@ -0,0 +1,164 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+
+	account "github.com/benagricola/provider-cloudflare/apis/account/v1alpha1"
+)
+
+// Modes of a TurnstileWidget.
+const (
+	// ModeManaged shows a checkbox to visitors only when Cloudflare
+	// cannot otherwise verify they are human.
+	ModeManaged = "managed"
+
+	// ModeNonInteractive shows a loading indicator, but never requires
+	// visitors to interact with the widget.
+	ModeNonInteractive = "non-interactive"
+
+	// ModeInvisible does not show the widget to visitors.
+	ModeInvisible = "invisible"
+)
+
+// TurnstileWidgetParameters are the configurable fields of a
+// TurnstileWidget.
+type TurnstileWidgetParameters struct {
+	// Account is the account ID this TurnstileWidget is created under.
+	// +immutable
+	// +optional
+	Account *string `json:"account,omitempty"`
+
+	// AccountRef references the Account object this TurnstileWidget is
+	// created under.
+	// +immutable
+	// +optional
+	AccountRef *xpv1.Reference `json:"accountRef,omitempty"`
+
+	// AccountSelector selects the Account object this TurnstileWidget is
+	// created under.
+	// +immutable
+	// +optional
+	AccountSelector *xpv1.Selector `json:"accountSelector,omitempty"`
+
+	// Name of the TurnstileWidget, shown in the Cloudflare dashboard.
+	Name string `json:"name"`
+
+	// Domains the TurnstileWidget may be embedded on.
+	// +kubebuilder:validation:MinItems=1
+	Domains []string `json:"domains"`
+
+	// Mode controls how the TurnstileWidget is shown to visitors.
+	// +kubebuilder:validation:Enum=managed;non-interactive;invisible
+	// +kubebuilder:default=managed
+	// +optional
+	Mode *string `json:"mode,omitempty"`
+
+	// BotFightMode issues computationally expensive challenges to
+	// visitors that are likely to be bots.
+	// +optional
+	BotFightMode *bool `json:"botFightMode,omitempty"`
+
+	// Region the TurnstileWidget is served from.
+	// +kubebuilder:validation:Enum=world
+	// +optional
+	Region *string `json:"region,omitempty"`
+}
+
+// TurnstileWidgetObservation are the observable fields of a
+// TurnstileWidget.
+type TurnstileWidgetObservation struct {
+	// Sitekey of the TurnstileWidget, used to embed it in a page.
+	Sitekey string `json:"sitekey,omitempty"`
+
+	// CreatedOn indicates when the TurnstileWidget was created.
+	CreatedOn *metav1.Time `json:"createdOn,omitempty"`
+
+	// ModifiedOn indicates when the TurnstileWidget was last modified.
+	ModifiedOn *metav1.Time `json:"modifiedOn,omitempty"`
+}
+
+// A TurnstileWidgetSpec defines the desired state of a TurnstileWidget.
+type TurnstileWidgetSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TurnstileWidgetParameters `json:"forProvider"`
+}
+
+// A TurnstileWidgetStatus represents the observed state of a
+// TurnstileWidget.
+type TurnstileWidgetStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          TurnstileWidgetObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A TurnstileWidget is a Cloudflare Turnstile widget, a CAPTCHA
+// alternative that can be embedded in pages. The sitekey and secret of the
+// widget are written to the connection secret.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="SITEKEY",type="string",JSONPath=".status.atProvider.sitekey"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type TurnstileWidget struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TurnstileWidgetSpec   `json:"spec"`
+	Status TurnstileWidgetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TurnstileWidgetList contains a list of TurnstileWidget objects
+type TurnstileWidgetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TurnstileWidget `json:"items"`
+}
+
+// ResolveReferences resolves references to the Account that this
+// TurnstileWidget is created under.
+func (t *TurnstileWidget) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, t)
+
+	// Resolve spec.forProvider.account
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(t.Spec.ForProvider.Account),
+		Reference:    t.Spec.ForProvider.AccountRef,
+		Selector:     t.Spec.ForProvider.AccountSelector,
+		To:           reference.To{Managed: &account.Account{}, List: &account.AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.account")
+	}
+	t.Spec.ForProvider.Account = reference.ToPtrValue(rsp.ResolvedValue)
+	t.Spec.ForProvider.AccountRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -26,6 +26,45 @@ import (
 type ProviderConfigSpec struct {
 	// Credentials required to authenticate to this provider.
 	Credentials ProviderCredentials `json:"credentials"`
+
+	// RateLimit configures the rate at which requests are made to the
+	// Cloudflare API. Every resource using this ProviderConfig shares the
+	// same request budget.
+	// +optional
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+
+	// ProxyRecordsByDefault proxies DNS Records of a type that can be
+	// proxied (A, AAAA and CNAME) through Cloudflare when they do not set
+	// proxied. Wildcard records are only proxied on zones on the
+	// Enterprise plan. Records are not proxied by default, as in the
+	// Cloudflare API. An explicitly set proxied always takes precedence.
+	// +optional
+	ProxyRecordsByDefault *bool `json:"proxyRecordsByDefault,omitempty"`
+}
+
+// RateLimit configures the rate at which requests are made to the
+// Cloudflare API, and how requests rate limited by Cloudflare are retried.
+type RateLimit struct {
+	// RequestsPerSecond is the sustained rate of requests made to the
+	// Cloudflare API. Defaults to 4, which matches the Cloudflare limit of
+	// 1200 requests per five minutes.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RequestsPerSecond *int `json:"requestsPerSecond,omitempty"`
+
+	// Burst is the number of requests that may be made at once before the
+	// sustained rate applies. Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Burst *int `json:"burst,omitempty"`
+
+	// MaxRetries is the number of times a request that was rate limited
+	// by Cloudflare, failed with a server error or could not be made is
+	// retried, honouring any Retry-After header and otherwise backing off
+	// exponentially. Defaults to 5.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRetries *int `json:"maxRetries,omitempty"`
 }
 
 // ProviderCredentials required to authenticate.
@@ -0,0 +1,177 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	zone "github.com/benagricola/provider-cloudflare/apis/zone/v1alpha1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+)
+
+// WaitingRoomParameters are the configurable fields of a WaitingRoom.
+type WaitingRoomParameters struct {
+	// Name of the WaitingRoom.
+	Name string `json:"name"`
+
+	// Description is a human readable description of the WaitingRoom.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Host is the hostname the WaitingRoom is applied to.
+	Host string `json:"host"`
+
+	// Path is the path the WaitingRoom is applied to. Requests for Host
+	// with paths beneath Path are also queued.
+	// +optional
+	Path *string `json:"path,omitempty"`
+
+	// TotalActiveUsers is the total number of active users allowed on
+	// the origin at once.
+	// +kubebuilder:validation:Minimum=200
+	TotalActiveUsers int `json:"totalActiveUsers"`
+
+	// NewUsersPerMinute is the number of new users allowed onto the
+	// origin each minute.
+	// +kubebuilder:validation:Minimum=200
+	NewUsersPerMinute int `json:"newUsersPerMinute"`
+
+	// QueueingMethod is the order in which queued users are let onto the
+	// origin.
+	// +kubebuilder:validation:Enum=fifo;random;passthrough;reject
+	// +optional
+	QueueingMethod *string `json:"queueingMethod,omitempty"`
+
+	// QueueAll queues all users, regardless of the number of active
+	// users on the origin.
+	// +optional
+	QueueAll *bool `json:"queueAll,omitempty"`
+
+	// CustomPageHTML is the HTML of the page shown to queued users.
+	// +optional
+	CustomPageHTML *string `json:"customPageHTML,omitempty"`
+
+	// SessionDuration is the number of minutes a user may be inactive
+	// before their session expires.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=30
+	// +optional
+	SessionDuration *int `json:"sessionDuration,omitempty"`
+
+	// DisableSessionRenewal stops the sessions of users on the origin
+	// being renewed, so they are queued again once SessionDuration has
+	// passed.
+	// +optional
+	DisableSessionRenewal *bool `json:"disableSessionRenewal,omitempty"`
+
+	// Suspended stops users being queued, sending all requests to the
+	// origin.
+	// +optional
+	Suspended *bool `json:"suspended,omitempty"`
+
+	// ZoneID this WaitingRoom is for.
+	// +immutable
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+
+	// ZoneRef references the zone object this WaitingRoom is for.
+	// +immutable
+	// +optional
+	ZoneRef *xpv1.Reference `json:"zoneRef,omitempty"`
+
+	// ZoneSelector selects the zone object this WaitingRoom is for.
+	// +immutable
+	// +optional
+	ZoneSelector *xpv1.Selector `json:"zoneSelector,omitempty"`
+}
+
+// WaitingRoomObservation are the observable fields of a WaitingRoom.
+type WaitingRoomObservation struct {
+	// CreatedOn indicates when the WaitingRoom was created.
+	CreatedOn *metav1.Time `json:"createdOn,omitempty"`
+
+	// ModifiedOn indicates when the WaitingRoom was last modified.
+	ModifiedOn *metav1.Time `json:"modifiedOn,omitempty"`
+}
+
+// A WaitingRoomSpec defines the desired state of a WaitingRoom.
+type WaitingRoomSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       WaitingRoomParameters `json:"forProvider"`
+}
+
+// A WaitingRoomStatus represents the observed state of a WaitingRoom.
+type WaitingRoomStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          WaitingRoomObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A WaitingRoom is a Cloudflare Waiting Room, which queues users when the
+// traffic to a host and path exceeds what the origin can handle.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="HOST",type="string",JSONPath=".spec.forProvider.host"
+// +kubebuilder:printcolumn:name="PATH",type="string",JSONPath=".spec.forProvider.path"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type WaitingRoom struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WaitingRoomSpec   `json:"spec"`
+	Status WaitingRoomStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WaitingRoomList contains a list of WaitingRoom objects
+type WaitingRoomList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WaitingRoom `json:"items"`
+}
+
+// ResolveReferences resolves references to the Zone that this WaitingRoom
+// is for.
+func (w *WaitingRoom) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, w)
+
+	// Resolve spec.forProvider.zone
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(w.Spec.ForProvider.Zone),
+		Reference:    w.Spec.ForProvider.ZoneRef,
+		Selector:     w.Spec.ForProvider.ZoneSelector,
+		To:           reference.To{Managed: &zone.Zone{}, List: &zone.ZoneList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.zone")
+	}
+	w.Spec.ForProvider.Zone = reference.ToPtrValue(rsp.ResolvedValue)
+	w.Spec.ForProvider.ZoneRef = rsp.ResolvedReference
+
+	return nil
+}
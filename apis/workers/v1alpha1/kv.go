@@ -0,0 +1,255 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+
+	account "github.com/benagricola/provider-cloudflare/apis/account/v1alpha1"
+)
+
+// WorkersKVNamespaceParameters are the configurable fields of a Workers KV
+// Namespace.
+type WorkersKVNamespaceParameters struct {
+	// Account is the account ID this KV Namespace is created under.
+	// +immutable
+	// +optional
+	Account *string `json:"account,omitempty"`
+
+	// AccountRef references the Account object this KV Namespace is
+	// created under.
+	// +immutable
+	// +optional
+	AccountRef *xpv1.Reference `json:"accountRef,omitempty"`
+
+	// AccountSelector selects the Account object this KV Namespace is
+	// created under.
+	// +immutable
+	// +optional
+	AccountSelector *xpv1.Selector `json:"accountSelector,omitempty"`
+
+	// Title is a human readable name for the KV Namespace. Defaults to
+	// the name of this resource.
+	// +optional
+	Title *string `json:"title,omitempty"`
+}
+
+// WorkersKVNamespaceObservation are the observable fields of a Workers KV
+// Namespace.
+type WorkersKVNamespaceObservation struct{}
+
+// A WorkersKVNamespaceSpec defines the desired state of a Workers KV
+// Namespace.
+type WorkersKVNamespaceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       WorkersKVNamespaceParameters `json:"forProvider"`
+}
+
+// A WorkersKVNamespaceStatus represents the observed state of a Workers KV
+// Namespace.
+type WorkersKVNamespaceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          WorkersKVNamespaceObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A WorkersKVNamespace represents a single Workers KV Namespace on an
+// Account.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TITLE",type="string",JSONPath=".spec.forProvider.title"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type WorkersKVNamespace struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WorkersKVNamespaceSpec   `json:"spec"`
+	Status WorkersKVNamespaceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WorkersKVNamespaceList contains a list of Workers KV Namespace objects
+type WorkersKVNamespaceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkersKVNamespace `json:"items"`
+}
+
+// ResolveReferences resolves references to the Account that this Workers
+// KV Namespace is created under.
+func (ns *WorkersKVNamespace) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, ns)
+
+	// Resolve spec.forProvider.account
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(ns.Spec.ForProvider.Account),
+		Reference:    ns.Spec.ForProvider.AccountRef,
+		Selector:     ns.Spec.ForProvider.AccountSelector,
+		To:           reference.To{Managed: &account.Account{}, List: &account.AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.account")
+	}
+	ns.Spec.ForProvider.Account = reference.ToPtrValue(rsp.ResolvedValue)
+	ns.Spec.ForProvider.AccountRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// WorkersKVPairParameters are the configurable fields of a Workers KV
+// key/value pair.
+type WorkersKVPairParameters struct {
+	// Account is the account ID the KV Namespace of this pair belongs to.
+	// +immutable
+	// +optional
+	Account *string `json:"account,omitempty"`
+
+	// AccountRef references the Account object the KV Namespace of this
+	// pair belongs to.
+	// +immutable
+	// +optional
+	AccountRef *xpv1.Reference `json:"accountRef,omitempty"`
+
+	// AccountSelector selects the Account object the KV Namespace of
+	// this pair belongs to.
+	// +immutable
+	// +optional
+	AccountSelector *xpv1.Selector `json:"accountSelector,omitempty"`
+
+	// Namespace is the ID of the KV Namespace this pair is stored in.
+	// +immutable
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+
+	// NamespaceRef references the WorkersKVNamespace object this pair is
+	// stored in.
+	// +immutable
+	// +optional
+	NamespaceRef *xpv1.Reference `json:"namespaceRef,omitempty"`
+
+	// NamespaceSelector selects the WorkersKVNamespace object this pair
+	// is stored in.
+	// +immutable
+	// +optional
+	NamespaceSelector *xpv1.Selector `json:"namespaceSelector,omitempty"`
+
+	// Key of the pair.
+	// +immutable
+	Key string `json:"key"`
+
+	// Value of the pair.
+	// +optional
+	Value *string `json:"value,omitempty"`
+
+	// ValueSecretRef selects a Secret key containing the value of the
+	// pair. Takes precedence over Value.
+	// +optional
+	ValueSecretRef *xpv1.SecretKeySelector `json:"valueSecretRef,omitempty"`
+}
+
+// WorkersKVPairObservation are the observable fields of a Workers KV
+// key/value pair.
+type WorkersKVPairObservation struct{}
+
+// A WorkersKVPairSpec defines the desired state of a Workers KV key/value
+// pair.
+type WorkersKVPairSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       WorkersKVPairParameters `json:"forProvider"`
+}
+
+// A WorkersKVPairStatus represents the observed state of a Workers KV
+// key/value pair.
+type WorkersKVPairStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          WorkersKVPairObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A WorkersKVPair represents a single key/value pair stored in a Workers KV
+// Namespace.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="KEY",type="string",JSONPath=".spec.forProvider.key"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type WorkersKVPair struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WorkersKVPairSpec   `json:"spec"`
+	Status WorkersKVPairStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WorkersKVPairList contains a list of Workers KV key/value pair objects
+type WorkersKVPairList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkersKVPair `json:"items"`
+}
+
+// ResolveReferences resolves references to the Account and KV Namespace
+// that this Workers KV pair is stored in.
+func (p *WorkersKVPair) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, p)
+
+	// Resolve spec.forProvider.account
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(p.Spec.ForProvider.Account),
+		Reference:    p.Spec.ForProvider.AccountRef,
+		Selector:     p.Spec.ForProvider.AccountSelector,
+		To:           reference.To{Managed: &account.Account{}, List: &account.AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.account")
+	}
+	p.Spec.ForProvider.Account = reference.ToPtrValue(rsp.ResolvedValue)
+	p.Spec.ForProvider.AccountRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.namespace
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(p.Spec.ForProvider.Namespace),
+		Reference:    p.Spec.ForProvider.NamespaceRef,
+		Selector:     p.Spec.ForProvider.NamespaceSelector,
+		To:           reference.To{Managed: &WorkersKVNamespace{}, List: &WorkersKVNamespaceList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.namespace")
+	}
+	p.Spec.ForProvider.Namespace = reference.ToPtrValue(rsp.ResolvedValue)
+	p.Spec.ForProvider.NamespaceRef = rsp.ResolvedReference
+
+	return nil
+}
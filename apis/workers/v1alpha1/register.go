@@ -45,6 +45,33 @@ var (
 	RouteGroupVersionKind = SchemeGroupVersion.WithKind(RouteKind)
 )
 
+// WorkerScript type metadata.
+var (
+	WorkerScriptKind             = reflect.TypeOf(WorkerScript{}).Name()
+	WorkerScriptGroupKind        = schema.GroupKind{Group: Group, Kind: WorkerScriptKind}.String()
+	WorkerScriptKindAPIVersion   = WorkerScriptKind + "." + SchemeGroupVersion.String()
+	WorkerScriptGroupVersionKind = SchemeGroupVersion.WithKind(WorkerScriptKind)
+)
+
+// WorkersKVNamespace type metadata.
+var (
+	WorkersKVNamespaceKind             = reflect.TypeOf(WorkersKVNamespace{}).Name()
+	WorkersKVNamespaceGroupKind        = schema.GroupKind{Group: Group, Kind: WorkersKVNamespaceKind}.String()
+	WorkersKVNamespaceKindAPIVersion   = WorkersKVNamespaceKind + "." + SchemeGroupVersion.String()
+	WorkersKVNamespaceGroupVersionKind = SchemeGroupVersion.WithKind(WorkersKVNamespaceKind)
+)
+
+// WorkersKVPair type metadata.
+var (
+	WorkersKVPairKind             = reflect.TypeOf(WorkersKVPair{}).Name()
+	WorkersKVPairGroupKind        = schema.GroupKind{Group: Group, Kind: WorkersKVPairKind}.String()
+	WorkersKVPairKindAPIVersion   = WorkersKVPairKind + "." + SchemeGroupVersion.String()
+	WorkersKVPairGroupVersionKind = SchemeGroupVersion.WithKind(WorkersKVPairKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&Route{}, &RouteList{})
+	SchemeBuilder.Register(&WorkerScript{}, &WorkerScriptList{})
+	SchemeBuilder.Register(&WorkersKVNamespace{}, &WorkersKVNamespaceList{})
+	SchemeBuilder.Register(&WorkersKVPair{}, &WorkersKVPairList{})
 }
@@ -34,7 +34,10 @@ type RouteParameters struct {
 	// Pattern is the URL pattern of the route.
 	Pattern string `json:"pattern"`
 
-	// Script is the name of the worker script.
+	// Script is the name of the worker script. When unset, it is
+	// initialized from the script of an existing route. Set it to an
+	// empty string to remove the script from the route, so that no worker
+	// runs on requests matching the pattern.
 	// +optional
 	Script *string `json:"script,omitempty"`
 
@@ -0,0 +1,225 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+
+	account "github.com/benagricola/provider-cloudflare/apis/account/v1alpha1"
+)
+
+// A ConfigMapKeySelector is a reference to a ConfigMap key in an arbitrary
+// namespace.
+type ConfigMapKeySelector struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+
+	// The key to select.
+	Key string `json:"key"`
+}
+
+// WorkerScriptSource specifies where the content of a Worker Script is read
+// from. Exactly one source should be set.
+type WorkerScriptSource struct {
+	// Inline is the content of the script.
+	// +optional
+	Inline *string `json:"inline,omitempty"`
+
+	// ConfigMapRef selects a ConfigMap key containing the content of
+	// the script.
+	// +optional
+	ConfigMapRef *ConfigMapKeySelector `json:"configMapRef,omitempty"`
+
+	// SecretRef selects a Secret key containing the content of the
+	// script.
+	// +optional
+	SecretRef *xpv1.SecretKeySelector `json:"secretRef,omitempty"`
+
+	// URL is an https URL the content of the script is downloaded from
+	// when the script is created or updated. The host of the URL must be
+	// allowed by the provider's --worker-script-host flag.
+	// +optional
+	URL *string `json:"url,omitempty"`
+
+	// SHA256 is the hex encoded SHA-256 checksum of the content
+	// downloaded from URL. Content that does not match is not uploaded.
+	// Content downloaded from a URL is not downloaded again to check for
+	// drift; when a checksum is set the uploaded script is compared
+	// against it instead.
+	// +kubebuilder:validation:Pattern=`^[0-9a-fA-F]{64}$`
+	// +optional
+	SHA256 *string `json:"sha256,omitempty"`
+}
+
+// WorkerScriptBinding binds a resource to a variable in the global scope
+// of a Worker Script.
+type WorkerScriptBinding struct {
+	// Name of the global variable the binding is exposed as.
+	Name string `json:"name"`
+
+	// Type of the binding.
+	// +kubebuilder:validation:Enum=kv_namespace;plain_text;secret_text
+	Type string `json:"type"`
+
+	// Namespace is the ID of the KV Namespace to bind. Required for
+	// kv_namespace bindings.
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+
+	// NamespaceRef references the WorkersKVNamespace object to bind.
+	// +optional
+	NamespaceRef *xpv1.Reference `json:"namespaceRef,omitempty"`
+
+	// NamespaceSelector selects the WorkersKVNamespace object to bind.
+	// +optional
+	NamespaceSelector *xpv1.Selector `json:"namespaceSelector,omitempty"`
+
+	// Text is the value of a plain_text binding.
+	// +optional
+	Text *string `json:"text,omitempty"`
+
+	// SecretRef selects a Secret key containing the value of a
+	// secret_text binding.
+	// +optional
+	SecretRef *xpv1.SecretKeySelector `json:"secretRef,omitempty"`
+}
+
+// WorkerScriptParameters are the configurable fields of a Worker Script.
+type WorkerScriptParameters struct {
+	// Account is the account ID this Worker Script is uploaded to.
+	// +immutable
+	// +optional
+	Account *string `json:"account,omitempty"`
+
+	// AccountRef references the Account object this Worker Script is
+	// uploaded to.
+	// +immutable
+	// +optional
+	AccountRef *xpv1.Reference `json:"accountRef,omitempty"`
+
+	// AccountSelector selects the Account object this Worker Script is
+	// uploaded to.
+	// +immutable
+	// +optional
+	AccountSelector *xpv1.Selector `json:"accountSelector,omitempty"`
+
+	// Name of the Worker Script, as referenced by Worker Routes.
+	// +immutable
+	Name string `json:"name"`
+
+	// Script is the source of the content of the Worker Script.
+	Script WorkerScriptSource `json:"script"`
+
+	// Bindings are the resources bound to the Worker Script.
+	// +optional
+	Bindings []WorkerScriptBinding `json:"bindings,omitempty"`
+}
+
+// WorkerScriptObservation are the observable fields of a Worker Script.
+type WorkerScriptObservation struct {
+	ETag       string       `json:"etag,omitempty"`
+	Size       int          `json:"size,omitempty"`
+	CreatedOn  *metav1.Time `json:"createdOn,omitempty"`
+	ModifiedOn *metav1.Time `json:"modifiedOn,omitempty"`
+}
+
+// A WorkerScriptSpec defines the desired state of a Worker Script.
+type WorkerScriptSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       WorkerScriptParameters `json:"forProvider"`
+}
+
+// A WorkerScriptStatus represents the observed state of a Worker Script.
+type WorkerScriptStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          WorkerScriptObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A WorkerScript represents a single Worker Script uploaded to an Account.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="NAME",type="string",JSONPath=".spec.forProvider.name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,cloudflare}
+type WorkerScript struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WorkerScriptSpec   `json:"spec"`
+	Status WorkerScriptStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WorkerScriptList contains a list of Worker Script objects
+type WorkerScriptList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkerScript `json:"items"`
+}
+
+// ResolveReferences resolves references to the Account that this Worker
+// Script is uploaded to, and to the KV Namespaces bound to it.
+func (ws *WorkerScript) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, ws)
+
+	// Resolve spec.forProvider.account
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(ws.Spec.ForProvider.Account),
+		Reference:    ws.Spec.ForProvider.AccountRef,
+		Selector:     ws.Spec.ForProvider.AccountSelector,
+		To:           reference.To{Managed: &account.Account{}, List: &account.AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.account")
+	}
+	ws.Spec.ForProvider.Account = reference.ToPtrValue(rsp.ResolvedValue)
+	ws.Spec.ForProvider.AccountRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.bindings[].namespace
+	for i := range ws.Spec.ForProvider.Bindings {
+		b := &ws.Spec.ForProvider.Bindings[i]
+		rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+			CurrentValue: reference.FromPtrValue(b.Namespace),
+			Reference:    b.NamespaceRef,
+			Selector:     b.NamespaceSelector,
+			To:           reference.To{Managed: &WorkersKVNamespace{}, List: &WorkersKVNamespaceList{}},
+			Extract:      reference.ExternalName(),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "spec.forProvider.bindings[%d].namespace", i)
+		}
+		b.Namespace = reference.ToPtrValue(rsp.ResolvedValue)
+		b.NamespaceRef = rsp.ResolvedReference
+	}
+
+	return nil
+}
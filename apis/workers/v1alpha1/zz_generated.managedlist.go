@@ -27,3 +27,30 @@ func (l *RouteList) GetItems() []resource.Managed {
 	}
 	return items
 }
+
+// GetItems of this WorkerScriptList.
+func (l *WorkerScriptList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
+// GetItems of this WorkersKVNamespaceList.
+func (l *WorkersKVNamespaceList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
+// GetItems of this WorkersKVPairList.
+func (l *WorkersKVPairList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TypeSettingsEditable indicates whether the settings of a Zone
+// can all be edited.
+const TypeSettingsEditable xpv1.ConditionType = "SettingsEditable"
+
+// TypeSettingsApplied indicates whether the desired settings of a Zone
+// were all applied.
+const TypeSettingsApplied xpv1.ConditionType = "SettingsApplied"
+
+// TypeSettingsEntitled indicates whether the account of a Zone is
+// entitled to use all of its desired settings.
+const TypeSettingsEntitled xpv1.ConditionType = "SettingsEntitled"
+
+// Reasons a Zone is not available.
+const (
+	ReasonHeld              xpv1.ConditionReason = "ZoneHeld"
+	ReasonPendingActivation xpv1.ConditionReason = "PendingActivation"
+)
+
+// Reasons the settings of a Zone can or cannot be edited.
+const (
+	ReasonSettingsEditable    xpv1.ConditionReason = "AllSettingsEditable"
+	ReasonSettingsNotEditable xpv1.ConditionReason = "SettingsNotEditable"
+)
+
+// Reasons the settings of a Zone were or were not applied.
+const (
+	ReasonSettingsApplied    xpv1.ConditionReason = "AllSettingsApplied"
+	ReasonSettingsNotApplied xpv1.ConditionReason = "SettingsNotApplied"
+	ReasonSettingsDeferred   xpv1.ConditionReason = "SettingsDeferred"
+)
+
+// Reasons the account of a Zone is or is not entitled to its settings.
+const (
+	ReasonSettingsEntitled    xpv1.ConditionReason = "AllSettingsEntitled"
+	ReasonSettingsNotEntitled xpv1.ConditionReason = "SettingsNotEntitled"
+)
+
+// Held returns a condition that indicates the Zone cannot be created
+// because a hold exists on the domain.
+func Held() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonHeld,
+		Message: "A zone hold exists on this domain. The hold must be released " +
+			"by the account that placed it before the zone can be created.",
+	}
+}
+
+// PendingActivation returns a condition that indicates the partial Zone
+// is not available until the passed DNS records are created with the DNS
+// provider of the Zone, and Cloudflare has activated it.
+func PendingActivation(records []string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPendingActivation,
+		Message: "The zone will be activated once these records are created " +
+			"with its DNS provider: " + strings.Join(records, "; "),
+	}
+}
+
+// SettingsEditable returns a condition that indicates all of the settings
+// of the Zone can be edited.
+func SettingsEditable() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeSettingsEditable,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSettingsEditable,
+	}
+}
+
+// SettingsNotEditable returns a condition that indicates the passed
+// settings of the Zone cannot be edited, usually because they are not
+// available on the plan of the Zone.
+func SettingsNotEditable(keys []string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeSettingsEditable,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSettingsNotEditable,
+		Message:            "Settings cannot be edited on this zone: " + strings.Join(keys, ", "),
+	}
+}
+
+// SettingsApplied returns a condition that indicates all of the desired
+// settings of the Zone were applied.
+func SettingsApplied() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeSettingsApplied,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSettingsApplied,
+	}
+}
+
+// SettingsNotApplied returns a condition that indicates the passed
+// settings of the Zone could not be applied. Other settings of the Zone
+// are still applied.
+func SettingsNotApplied(keys []string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeSettingsApplied,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSettingsNotApplied,
+		Message:            "Settings could not be applied to this zone: " + strings.Join(keys, ", "),
+	}
+}
+
+// SettingsDeferred returns a condition that indicates the settings of
+// the Zone were not applied because it is not active yet. They will be
+// applied once the Zone is active.
+func SettingsDeferred(status string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeSettingsApplied,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSettingsDeferred,
+		Message:            "Settings will be applied once the zone is active, its status is " + status,
+	}
+}
+
+// SettingsEntitled returns a condition that indicates the account of the
+// Zone is entitled to use all of its desired settings.
+func SettingsEntitled() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeSettingsEntitled,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSettingsEntitled,
+	}
+}
+
+// SettingsNotEntitled returns a condition that indicates the passed
+// settings of the Zone could not be applied because its account is not
+// entitled to use them. An entitlement must be added to the account by
+// Cloudflare before these settings can be applied.
+func SettingsNotEntitled(keys []string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeSettingsEntitled,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonSettingsNotEntitled,
+		Message:            "The account is not entitled to use these settings: " + strings.Join(keys, ", "),
+	}
+}
@@ -17,9 +17,27 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+
+	accountv1alpha1 "github.com/benagricola/provider-cloudflare/apis/account/v1alpha1"
+)
+
+// Policies that determine how the settings of a Zone are managed.
+const (
+	// SettingsPolicyManaged late initializes every setting that is not
+	// set in spec from Cloudflare, and enforces all of them.
+	SettingsPolicyManaged = "Managed"
+
+	// SettingsPolicyManagedSubsetOnly enforces only the settings that
+	// are set in spec, and leaves all others alone.
+	SettingsPolicyManagedSubsetOnly = "ManagedSubsetOnly"
 )
 
 // MinifySettings represents the minify settings on a Zone
@@ -58,6 +76,8 @@ type StrictTransportSecuritySettings struct {
 	// +optional
 	Enabled *bool `json:"enabled,omitempty"`
 	// MaxAge defines the maximum age in seconds of the STS
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=31536000
 	// +optional
 	MaxAge *int64 `json:"maxAge,omitempty"`
 	// IncludeSubdomains defines whether or not to include all subdomains
@@ -123,7 +143,9 @@ type ZoneSettings struct {
 	// +optional
 	ChallengeTTL *int64 `json:"challengeTtl,omitempty"`
 
-	// Ciphers configures which ciphers are allowed for TLS termination
+	// Ciphers configures which ciphers are allowed for TLS termination.
+	// An empty list means the same as an unset one: the Cloudflare
+	// default ciphers are used and are not managed.
 	// +optional
 	Ciphers []string `json:"ciphers,omitempty"`
 
@@ -137,6 +159,11 @@ type ZoneSettings struct {
 	// +optional
 	DevelopmentMode *string `json:"developmentMode,omitempty"`
 
+	// EarlyHints enables or disables sending 103 Early Hints responses
+	// +kubebuilder:validation:Enum=off;on
+	// +optional
+	EarlyHints *string `json:"earlyHints,omitempty"`
+
 	// EdgeCacheTTL configures the edge cache ttl
 	// +optional
 	EdgeCacheTTL *int64 `json:"edgeCacheTtl,omitempty"`
@@ -146,6 +173,12 @@ type ZoneSettings struct {
 	// +optional
 	EmailObfuscation *string `json:"emailObfuscation,omitempty"`
 
+	// H2Prioritization configures enhanced HTTP/2 prioritization. It is
+	// only available on paid plans.
+	// +kubebuilder:validation:Enum=off;on;custom
+	// +optional
+	H2Prioritization *string `json:"h2Prioritization,omitempty"`
+
 	// HotlinkProtection enables or disables Hotlink protection
 	// +kubebuilder:validation:Enum=off;on
 	// +optional
@@ -161,6 +194,12 @@ type ZoneSettings struct {
 	// +optional
 	HTTP3 *string `json:"http3,omitempty"`
 
+	// ImageResizing configures Image Resizing. It is only available on
+	// paid plans.
+	// +kubebuilder:validation:Enum=off;on;open
+	// +optional
+	ImageResizing *string `json:"imageResizing,omitempty"`
+
 	// IPGeolocation enables or disables IP Geolocation
 	// +kubebuilder:validation:Enum=off;on
 	// +optional
@@ -176,7 +215,7 @@ type ZoneSettings struct {
 	// +optional
 	LogToCloudflare *string `json:"logToCloudflare,omitempty"`
 
-	// MaxUpload configures the maximum upload payload size
+	// MaxUpload configures the maximum upload payload size in MB
 	// +optional
 	MaxUpload *int64 `json:"maxUpload,omitempty"`
 
@@ -218,6 +257,12 @@ type ZoneSettings struct {
 	// +optional
 	OriginErrorPagePassThru *string `json:"originErrorPagePassThru,omitempty"`
 
+	// OriginMaxHTTPVersion configures the highest HTTP version used
+	// to connect to the origin
+	// +kubebuilder:validation:Enum="1";"2"
+	// +optional
+	OriginMaxHTTPVersion *string `json:"originMaxHttpVersion,omitempty"`
+
 	// Polish configures the Polish setting
 	// +kubebuilder:validation:Enum=off;lossless;lossy
 	// +optional
@@ -328,6 +373,17 @@ type ZoneParameters struct {
 	// +optional
 	AccountID *string `json:"accountId,omitempty"`
 
+	// AccountRef references the Account object under which this
+	// Zone will be created.
+	// +immutable
+	// +optional
+	AccountRef *xpv1.Reference `json:"accountRef,omitempty"`
+
+	// AccountSelector selects the Account object under which this
+	// Zone will be created.
+	// +optional
+	AccountSelector *xpv1.Selector `json:"accountSelector,omitempty"`
+
 	// TODO: Work out what to do with this one. In Cloudflare, it causes
 	// Existing DNS Records to be imported, which means we have
 	// records in Cloudflare that would not be managed by Crossplane.
@@ -368,6 +424,27 @@ type ZoneParameters struct {
 	// +optional
 	Settings ZoneSettings `json:"settings,omitempty"`
 
+	// SettingsAllowlist lists the keys of the settings, such as
+	// always_use_https, that are managed on this zone. Settings that
+	// are not listed are never compared, updated or late initialized,
+	// so that they may be managed by other tools. All settings are
+	// managed when it is empty.
+	// +optional
+	SettingsAllowlist []string `json:"settingsAllowlist,omitempty"`
+
+	// SettingsManagementPolicy determines how the settings of this
+	// zone are managed. Managed late initializes every setting that
+	// is not set in spec, and enforces all of them. ManagedSubsetOnly
+	// enforces only the settings that are set in spec and late
+	// initializes none, so settings changed elsewhere are left alone.
+	// Either policy only applies to the settings in SettingsAllowlist
+	// when it is set. Settings are never changed when the zone has the
+	// ObserveOnly management policy.
+	// +kubebuilder:validation:Enum=Managed;ManagedSubsetOnly
+	// +kubebuilder:default=Managed
+	// +optional
+	SettingsManagementPolicy *string `json:"settingsManagementPolicy,omitempty"`
+
 	// VanityNameServers lists an array of domains to use for custom
 	// nameservers.
 	// +optional
@@ -436,6 +513,43 @@ type ZoneObservation struct {
 	// VanityNameServers lists the currently assigned vanity
 	// name server addresses.
 	VanityNameServers []string `json:"vanityNameServers,omitempty"`
+
+	// SettingsHash is a hash of the settings last observed
+	// on this Zone.
+	SettingsHash string `json:"settingsHash,omitempty"`
+
+	// SettingHashes contains a short hash of the value of each
+	// setting last observed on this Zone, keyed by the ID of the
+	// setting, so that the settings that change can be told apart
+	// without storing their values.
+	SettingHashes map[string]string `json:"settingHashes,omitempty"`
+
+	// SettingsLastChanged indicates when the settings observed
+	// on this Zone last changed.
+	SettingsLastChanged *metav1.Time `json:"settingsLastChanged,omitempty"`
+
+	// LastChangedSettings lists the settings whose observed value
+	// changed when the settings observed on this Zone last changed.
+	LastChangedSettings []string `json:"lastChangedSettings,omitempty"`
+
+	// ChangedSettings lists the settings whose observed value
+	// differs from their desired value.
+	ChangedSettings []string `json:"changedSettings,omitempty"`
+
+	// ManagedSettings lists the settings that are set in the spec of
+	// this Zone, including those late initialized from Cloudflare.
+	// All other settings are left at their Cloudflare default.
+	ManagedSettings []string `json:"managedSettings,omitempty"`
+
+	// PlanGatedSettings lists the settings that are enabled in the spec
+	// of this Zone but cannot be enabled on its plan.
+	PlanGatedSettings []string `json:"planGatedSettings,omitempty"`
+
+	// UnappliedSettings lists the settings that could not be applied
+	// the last time the settings of this Zone were updated. They are
+	// updated on their own until they are applied, so that the other
+	// settings can still be updated together.
+	UnappliedSettings []string `json:"unappliedSettings,omitempty"`
 }
 
 // A ZoneSpec defines the desired state of a Zone.
@@ -477,3 +591,25 @@ type ZoneList struct {
 	metav1.ListMeta `json:"metadata,omitempty"`
 	Items           []Zone `json:"items"`
 }
+
+// ResolveReferences resolves references to the Account that this Zone
+// is created under.
+func (z *Zone) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, z)
+
+	// Resolve spec.forProvider.accountId
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(z.Spec.ForProvider.AccountID),
+		Reference:    z.Spec.ForProvider.AccountRef,
+		Selector:     z.Spec.ForProvider.AccountSelector,
+		To:           reference.To{Managed: &accountv1alpha1.Account{}, List: &accountv1alpha1.AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.accountId")
+	}
+	z.Spec.ForProvider.AccountID = reference.ToPtrValue(rsp.ResolvedValue)
+	z.Spec.ForProvider.AccountRef = rsp.ResolvedReference
+
+	return nil
+}
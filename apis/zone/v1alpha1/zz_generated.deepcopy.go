@@ -21,6 +21,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"github.com/crossplane/crossplane-runtime/apis/common/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -221,6 +222,42 @@ func (in *ZoneObservation) DeepCopyInto(out *ZoneObservation) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SettingHashes != nil {
+		in, out := &in.SettingHashes, &out.SettingHashes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SettingsLastChanged != nil {
+		in, out := &in.SettingsLastChanged, &out.SettingsLastChanged
+		*out = (*in).DeepCopy()
+	}
+	if in.LastChangedSettings != nil {
+		in, out := &in.LastChangedSettings, &out.LastChangedSettings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ChangedSettings != nil {
+		in, out := &in.ChangedSettings, &out.ChangedSettings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedSettings != nil {
+		in, out := &in.ManagedSettings, &out.ManagedSettings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PlanGatedSettings != nil {
+		in, out := &in.PlanGatedSettings, &out.PlanGatedSettings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnappliedSettings != nil {
+		in, out := &in.UnappliedSettings, &out.UnappliedSettings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneObservation.
@@ -241,6 +278,16 @@ func (in *ZoneParameters) DeepCopyInto(out *ZoneParameters) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.AccountRef != nil {
+		in, out := &in.AccountRef, &out.AccountRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.AccountSelector != nil {
+		in, out := &in.AccountSelector, &out.AccountSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Paused != nil {
 		in, out := &in.Paused, &out.Paused
 		*out = new(bool)
@@ -257,6 +304,16 @@ func (in *ZoneParameters) DeepCopyInto(out *ZoneParameters) {
 		**out = **in
 	}
 	in.Settings.DeepCopyInto(&out.Settings)
+	if in.SettingsAllowlist != nil {
+		in, out := &in.SettingsAllowlist, &out.SettingsAllowlist
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SettingsManagementPolicy != nil {
+		in, out := &in.SettingsManagementPolicy, &out.SettingsManagementPolicy
+		*out = new(string)
+		**out = **in
+	}
 	if in.VanityNameServers != nil {
 		in, out := &in.VanityNameServers, &out.VanityNameServers
 		*out = make([]string, len(*in))
@@ -337,6 +394,11 @@ func (in *ZoneSettings) DeepCopyInto(out *ZoneSettings) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.EarlyHints != nil {
+		in, out := &in.EarlyHints, &out.EarlyHints
+		*out = new(string)
+		**out = **in
+	}
 	if in.EdgeCacheTTL != nil {
 		in, out := &in.EdgeCacheTTL, &out.EdgeCacheTTL
 		*out = new(int64)
@@ -347,6 +409,11 @@ func (in *ZoneSettings) DeepCopyInto(out *ZoneSettings) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.H2Prioritization != nil {
+		in, out := &in.H2Prioritization, &out.H2Prioritization
+		*out = new(string)
+		**out = **in
+	}
 	if in.HotlinkProtection != nil {
 		in, out := &in.HotlinkProtection, &out.HotlinkProtection
 		*out = new(string)
@@ -362,6 +429,11 @@ func (in *ZoneSettings) DeepCopyInto(out *ZoneSettings) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ImageResizing != nil {
+		in, out := &in.ImageResizing, &out.ImageResizing
+		*out = new(string)
+		**out = **in
+	}
 	if in.IPGeolocation != nil {
 		in, out := &in.IPGeolocation, &out.IPGeolocation
 		*out = new(string)
@@ -422,6 +494,11 @@ func (in *ZoneSettings) DeepCopyInto(out *ZoneSettings) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.OriginMaxHTTPVersion != nil {
+		in, out := &in.OriginMaxHTTPVersion, &out.OriginMaxHTTPVersion
+		*out = new(string)
+		**out = **in
+	}
 	if in.Polish != nil {
 		in, out := &in.Polish, &out.Polish
 		*out = new(string)
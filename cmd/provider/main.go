@@ -19,6 +19,7 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"gopkg.in/alecthomas/kingpin.v2"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -29,6 +30,7 @@ import (
 
 	"github.com/benagricola/provider-cloudflare/apis"
 	"github.com/benagricola/provider-cloudflare/internal/controller"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
 )
 
 func main() {
@@ -37,6 +39,16 @@ func main() {
 		debug          = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
 		syncPeriod     = app.Flag("sync", "Controller manager sync period such as 300ms, 1.5h, or 2h45m").Short('s').Default("1h").Duration()
 		leaderElection = app.Flag("leader-election", "Use leader election for the controller manager.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
+		pollInterval   = app.Flag("poll", "Poll interval controls how often an individual resource should be checked for drift.").Default(options.DefaultPollInterval.String()).Duration()
+		maxConcurrency = app.Flag("max-concurrent-reconciles", "Maximum number of resources of each kind that may be reconciled at once.").Default(strconv.Itoa(options.DefaultMaxConcurrentReconciles)).Int()
+		timeout        = app.Flag("timeout", "Maximum time allowed for all the Cloudflare API calls made while reconciling a resource.").Default(options.DefaultTimeout.String()).Duration()
+		chZoneQPS      = app.Flag("custom-hostname-zone-qps", "Maximum rate of Custom Hostname API calls per second made to each zone.").Default(strconv.Itoa(options.DefaultCustomHostnameZoneQPS)).Float32()
+		chZoneBurst    = app.Flag("custom-hostname-zone-burst", "Maximum number of Custom Hostname API calls made to each zone in a burst.").Default(strconv.Itoa(options.DefaultCustomHostnameZoneBurst)).Int()
+		scriptHosts    = app.Flag("worker-script-host", "Host that Worker Script content may be downloaded from over https. May be repeated. Scripts cannot be downloaded from a URL unless their host is allowed.").Strings()
+
+		pollOverrides           = app.Flag("poll-override", "Poll interval of a single controller, as GROUPKIND=DURATION e.g. zone.zone.cloudflare.crossplane.io=30m. May be repeated.").StringMap()
+		maxConcurrencyOverrides = app.Flag("max-concurrent-reconciles-override", "Maximum concurrent reconciles of a single controller, as GROUPKIND=COUNT. May be repeated.").StringMap()
+		timeoutOverrides        = app.Flag("timeout-override", "Reconcile timeout of a single controller, as GROUPKIND=DURATION. May be repeated.").StringMap()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -49,7 +61,10 @@ func main() {
 		ctrl.SetLogger(zl)
 	}
 
-	log.Debug("Starting", "sync-period", syncPeriod.String())
+	log.Debug("Starting", "sync-period", syncPeriod.String(), "poll-interval", pollInterval.String())
+
+	overrides, err := options.ParseOverrides(*pollOverrides, *maxConcurrencyOverrides, *timeoutOverrides)
+	kingpin.FatalIfError(err, "Cannot parse controller option overrides")
 
 	cfg, err := ctrl.GetConfig()
 	kingpin.FatalIfError(err, "Cannot get API server rest config")
@@ -61,8 +76,19 @@ func main() {
 	})
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 
-	rl := ratelimiter.NewDefaultProviderRateLimiter(ratelimiter.DefaultProviderRPS)
+	o := options.Options{
+		Logger:                  log,
+		GlobalRateLimiter:       ratelimiter.NewDefaultProviderRateLimiter(ratelimiter.DefaultProviderRPS),
+		PollInterval:            *pollInterval,
+		MaxConcurrentReconciles: *maxConcurrency,
+		Timeout:                 *timeout,
+		CustomHostnameZoneQPS:   *chZoneQPS,
+		CustomHostnameZoneBurst: *chZoneBurst,
+		WorkerScriptHosts:       *scriptHosts,
+		Overrides:               overrides,
+	}
+
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add Template APIs to scheme")
-	kingpin.FatalIfError(controller.Setup(mgr, log, rl), "Cannot setup Template controllers")
+	kingpin.FatalIfError(controller.Setup(mgr, o), "Cannot setup Template controllers")
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
 }
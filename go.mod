@@ -9,6 +9,7 @@ require (
 	github.com/google/go-cmp v0.5.6
 	github.com/pkg/errors v0.9.1
 	github.com/prometheus/client_golang v1.10.0
+	golang.org/x/time v0.0.0-20201208040808-7e3f01d25324
 	gopkg.in/alecthomas/kingpin.v2 v2.2.6
 	k8s.io/api v0.20.2
 	k8s.io/apimachinery v0.21.1
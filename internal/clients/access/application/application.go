@@ -0,0 +1,223 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+
+	"github.com/benagricola/provider-cloudflare/apis/access/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+const (
+	errCreateApplication = "error creating access application"
+	errUpdateApplication = "error updating access application"
+	errSpecNil           = "access application spec is empty"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with Access Applications.
+type Client interface {
+	AccessApplication(ctx context.Context, accountID, applicationID string) (cloudflare.AccessApplication, error)
+	CreateAccessApplication(ctx context.Context, accountID string, accessApplication cloudflare.AccessApplication) (cloudflare.AccessApplication, error)
+	UpdateAccessApplication(ctx context.Context, accountID string, accessApplication cloudflare.AccessApplication) (cloudflare.AccessApplication, error)
+	DeleteAccessApplication(ctx context.Context, accountID, applicationID string) error
+}
+
+// NewClient returns a new Cloudflare API client for working with Access
+// Applications.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// IsApplicationNotFound returns true if the passed error indicates
+// an Access Application was not found.
+func IsApplicationNotFound(err error) bool {
+	return clients.IsNotFound(err)
+}
+
+// GenerateObservation creates an observation of a Cloudflare Access
+// Application.
+func GenerateObservation(in cloudflare.AccessApplication) v1alpha1.AccessApplicationObservation {
+	return v1alpha1.AccessApplicationObservation{
+		AUD: in.AUD,
+	}
+}
+
+// LateInitialize initializes AccessApplicationParameters based on the
+// remote resource.
+func LateInitialize(spec *v1alpha1.AccessApplicationParameters, a cloudflare.AccessApplication) bool {
+	if spec == nil {
+		return false
+	}
+
+	li := false
+
+	if spec.SessionDuration == nil && a.SessionDuration != "" {
+		spec.SessionDuration = &a.SessionDuration
+		li = true
+	}
+
+	if spec.AutoRedirectToIdentity == nil {
+		spec.AutoRedirectToIdentity = &a.AutoRedirectToIdentity
+		li = true
+	}
+
+	if spec.EnableBindingCookie == nil {
+		spec.EnableBindingCookie = &a.EnableBindingCookie
+		li = true
+	}
+
+	return li
+}
+
+// UpToDate checks if the remote Access Application is up to date with the
+// requested resource parameters.
+func UpToDate(spec *v1alpha1.AccessApplicationParameters, a cloudflare.AccessApplication) bool { //nolint:gocyclo
+	// NOTE: The complexity here is simply repeated if statements
+	// checking for updated fields. Optional fields that are not set
+	// are not compared, as Cloudflare defaults them.
+	if spec == nil {
+		return true
+	}
+
+	if spec.Name != a.Name || spec.Domain != a.Domain {
+		return false
+	}
+
+	if spec.SessionDuration != nil && *spec.SessionDuration != a.SessionDuration {
+		return false
+	}
+
+	if !cmp.Equal(spec.AllowedIdPs, a.AllowedIdps, cmpopts.EquateEmpty()) {
+		return false
+	}
+
+	if spec.AutoRedirectToIdentity != nil && *spec.AutoRedirectToIdentity != a.AutoRedirectToIdentity {
+		return false
+	}
+
+	if spec.EnableBindingCookie != nil && *spec.EnableBindingCookie != a.EnableBindingCookie {
+		return false
+	}
+
+	if spec.CorsHeaders != nil && !cmp.Equal(corsHeaders(spec.CorsHeaders), a.CorsHeaders, cmpopts.EquateEmpty()) {
+		return false
+	}
+
+	if spec.CustomDenyMessage != nil && *spec.CustomDenyMessage != a.CustomDenyMessage {
+		return false
+	}
+
+	if spec.CustomDenyURL != nil && *spec.CustomDenyURL != a.CustomDenyURL {
+		return false
+	}
+
+	return true
+}
+
+func corsHeaders(in *v1alpha1.AccessApplicationCorsHeaders) *cloudflare.AccessApplicationCorsHeaders {
+	if in == nil {
+		return nil
+	}
+
+	out := &cloudflare.AccessApplicationCorsHeaders{
+		AllowedMethods: in.AllowedMethods,
+		AllowedOrigins: in.AllowedOrigins,
+		AllowedHeaders: in.AllowedHeaders,
+	}
+
+	if in.AllowAllMethods != nil {
+		out.AllowAllMethods = *in.AllowAllMethods
+	}
+	if in.AllowAllHeaders != nil {
+		out.AllowAllHeaders = *in.AllowAllHeaders
+	}
+	if in.AllowAllOrigins != nil {
+		out.AllowAllOrigins = *in.AllowAllOrigins
+	}
+	if in.AllowCredentials != nil {
+		out.AllowCredentials = *in.AllowCredentials
+	}
+	if in.MaxAge != nil {
+		out.MaxAge = int(*in.MaxAge)
+	}
+
+	return out
+}
+
+// accessApplication converts AccessApplicationParameters into a cloudflare
+// Access Application.
+func accessApplication(spec *v1alpha1.AccessApplicationParameters) cloudflare.AccessApplication {
+	a := cloudflare.AccessApplication{
+		Name:        spec.Name,
+		Domain:      spec.Domain,
+		AllowedIdps: spec.AllowedIdPs,
+		CorsHeaders: corsHeaders(spec.CorsHeaders),
+	}
+
+	if spec.SessionDuration != nil {
+		a.SessionDuration = *spec.SessionDuration
+	}
+
+	if spec.AutoRedirectToIdentity != nil {
+		a.AutoRedirectToIdentity = *spec.AutoRedirectToIdentity
+	}
+
+	if spec.EnableBindingCookie != nil {
+		a.EnableBindingCookie = *spec.EnableBindingCookie
+	}
+
+	if spec.CustomDenyMessage != nil {
+		a.CustomDenyMessage = *spec.CustomDenyMessage
+	}
+
+	if spec.CustomDenyURL != nil {
+		a.CustomDenyURL = *spec.CustomDenyURL
+	}
+
+	return a
+}
+
+// CreateApplication creates a new Access Application.
+func CreateApplication(ctx context.Context, client Client, spec *v1alpha1.AccessApplicationParameters) (*cloudflare.AccessApplication, error) {
+	if spec == nil {
+		return nil, errors.New(errSpecNil)
+	}
+
+	res, err := client.CreateAccessApplication(ctx, *spec.Account, accessApplication(spec))
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateApplication)
+	}
+
+	return &res, nil
+}
+
+// UpdateApplication updates mutable values on an Access Application.
+func UpdateApplication(ctx context.Context, client Client, applicationID string, spec *v1alpha1.AccessApplicationParameters) error {
+	a := accessApplication(spec)
+	a.ID = applicationID
+
+	_, err := client.UpdateAccessApplication(ctx, *spec.Account, a)
+	return errors.Wrap(err, errUpdateApplication)
+}
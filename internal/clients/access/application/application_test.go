@@ -0,0 +1,212 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/benagricola/provider-cloudflare/apis/access/v1alpha1"
+
+	ptr "k8s.io/utils/pointer"
+)
+
+func dashboardApplication() *v1alpha1.AccessApplicationParameters {
+	return &v1alpha1.AccessApplicationParameters{
+		Account:         ptr.StringPtr("372e67954025e0ba6aaa6d586b9e0b59"),
+		Name:            "Dashboard",
+		Domain:          "dashboard.example.com",
+		SessionDuration: ptr.StringPtr("12h"),
+		AllowedIdPs:     []string{"f174e90a-fafe-4643-bbbc-4a0ed4fc8415"},
+		CorsHeaders: &v1alpha1.AccessApplicationCorsHeaders{
+			AllowedOrigins:   []string{"https://example.com"},
+			AllowAllMethods:  ptr.BoolPtr(true),
+			AllowCredentials: ptr.BoolPtr(true),
+			MaxAge:           ptr.Int64Ptr(600),
+		},
+	}
+}
+
+func dashboardAccessApplication() cloudflare.AccessApplication {
+	return cloudflare.AccessApplication{
+		ID:              "480f4f69-1a28-4fdd-9240-1ed29f0ac1db",
+		AUD:             "737646a56ab1df6ec9bddc7e5ca84eaf3b0768850f3ffb5d74f1534911fe3893",
+		Name:            "Dashboard",
+		Domain:          "dashboard.example.com",
+		SessionDuration: "12h",
+		AllowedIdps:     []string{"f174e90a-fafe-4643-bbbc-4a0ed4fc8415"},
+		CorsHeaders: &cloudflare.AccessApplicationCorsHeaders{
+			AllowedOrigins:   []string{"https://example.com"},
+			AllowAllMethods:  true,
+			AllowCredentials: true,
+			MaxAge:           600,
+		},
+	}
+}
+
+func TestLateInitialize(t *testing.T) {
+	type args struct {
+		ap *v1alpha1.AccessApplicationParameters
+		a  cloudflare.AccessApplication
+	}
+
+	type want struct {
+		o  bool
+		ap *v1alpha1.AccessApplicationParameters
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"LateInitSpecNil": {
+			reason: "LateInit should return false when not passed a spec",
+			args:   args{},
+			want: want{
+				o: false,
+			},
+		},
+		"LateInitDontUpdate": {
+			reason: "LateInit should not update already-set spec fields from an Access Application",
+			args: args{
+				ap: &v1alpha1.AccessApplicationParameters{
+					SessionDuration:        ptr.StringPtr("12h"),
+					AutoRedirectToIdentity: ptr.BoolPtr(false),
+					EnableBindingCookie:    ptr.BoolPtr(false),
+				},
+				a: cloudflare.AccessApplication{
+					SessionDuration:        "24h",
+					AutoRedirectToIdentity: true,
+					EnableBindingCookie:    true,
+				},
+			},
+			want: want{
+				o: false,
+				ap: &v1alpha1.AccessApplicationParameters{
+					SessionDuration:        ptr.StringPtr("12h"),
+					AutoRedirectToIdentity: ptr.BoolPtr(false),
+					EnableBindingCookie:    ptr.BoolPtr(false),
+				},
+			},
+		},
+		"LateInitUpdate": {
+			reason: "LateInit should update unset spec fields from an Access Application",
+			args: args{
+				ap: &v1alpha1.AccessApplicationParameters{},
+				a: cloudflare.AccessApplication{
+					SessionDuration:        "24h",
+					AutoRedirectToIdentity: true,
+				},
+			},
+			want: want{
+				o: true,
+				ap: &v1alpha1.AccessApplicationParameters{
+					SessionDuration:        ptr.StringPtr("24h"),
+					AutoRedirectToIdentity: ptr.BoolPtr(true),
+					EnableBindingCookie:    ptr.BoolPtr(false),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := LateInitialize(tc.args.ap, tc.args.a)
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nLateInitialize(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ap, tc.args.ap); diff != "" {
+				t.Errorf("\n%s\nLateInitialize(...): -want spec, +got spec:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpToDate(t *testing.T) {
+	type args struct {
+		ap *v1alpha1.AccessApplicationParameters
+		a  cloudflare.AccessApplication
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   bool
+	}{
+		"UpToDateSpecNil": {
+			reason: "UpToDate should return true when not passed a spec",
+			args:   args{},
+			want:   true,
+		},
+		"UpToDate": {
+			reason: "UpToDate should return true when the Access Application matches the spec",
+			args: args{
+				ap: dashboardApplication(),
+				a:  dashboardAccessApplication(),
+			},
+			want: true,
+		},
+		"NotUpToDateDomain": {
+			reason: "UpToDate should return false when the domain differs",
+			args: args{
+				ap: dashboardApplication(),
+				a: func() cloudflare.AccessApplication {
+					a := dashboardAccessApplication()
+					a.Domain = "admin.example.com"
+					return a
+				}(),
+			},
+			want: false,
+		},
+		"NotUpToDateIdPs": {
+			reason: "UpToDate should return false when the allowed identity providers differ",
+			args: args{
+				ap: dashboardApplication(),
+				a: func() cloudflare.AccessApplication {
+					a := dashboardAccessApplication()
+					a.AllowedIdps = nil
+					return a
+				}(),
+			},
+			want: false,
+		},
+		"NotUpToDateCors": {
+			reason: "UpToDate should return false when the CORS headers differ",
+			args: args{
+				ap: dashboardApplication(),
+				a: func() cloudflare.AccessApplication {
+					a := dashboardAccessApplication()
+					a.CorsHeaders.MaxAge = 60
+					return a
+				}(),
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := UpToDate(tc.args.ap, tc.args.a)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// A MockClient acts as a testable representation of the Cloudflare API.
+type MockClient struct {
+	MockAccessApplication       func(ctx context.Context, accountID, applicationID string) (cloudflare.AccessApplication, error)
+	MockCreateAccessApplication func(ctx context.Context, accountID string, accessApplication cloudflare.AccessApplication) (cloudflare.AccessApplication, error)
+	MockUpdateAccessApplication func(ctx context.Context, accountID string, accessApplication cloudflare.AccessApplication) (cloudflare.AccessApplication, error)
+	MockDeleteAccessApplication func(ctx context.Context, accountID, applicationID string) error
+}
+
+// AccessApplication mocks the AccessApplication method of the Cloudflare API.
+func (m MockClient) AccessApplication(ctx context.Context, accountID, applicationID string) (cloudflare.AccessApplication, error) {
+	return m.MockAccessApplication(ctx, accountID, applicationID)
+}
+
+// CreateAccessApplication mocks the CreateAccessApplication method of the Cloudflare API.
+func (m MockClient) CreateAccessApplication(ctx context.Context, accountID string, accessApplication cloudflare.AccessApplication) (cloudflare.AccessApplication, error) {
+	return m.MockCreateAccessApplication(ctx, accountID, accessApplication)
+}
+
+// UpdateAccessApplication mocks the UpdateAccessApplication method of the Cloudflare API.
+func (m MockClient) UpdateAccessApplication(ctx context.Context, accountID string, accessApplication cloudflare.AccessApplication) (cloudflare.AccessApplication, error) {
+	return m.MockUpdateAccessApplication(ctx, accountID, accessApplication)
+}
+
+// DeleteAccessApplication mocks the DeleteAccessApplication method of the Cloudflare API.
+func (m MockClient) DeleteAccessApplication(ctx context.Context, accountID, applicationID string) error {
+	return m.MockDeleteAccessApplication(ctx, accountID, applicationID)
+}
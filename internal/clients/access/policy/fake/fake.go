@@ -0,0 +1,51 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// A MockClient acts as a testable representation of the Cloudflare API.
+type MockClient struct {
+	MockAccessPolicy       func(ctx context.Context, accountID, applicationID, policyID string) (cloudflare.AccessPolicy, error)
+	MockCreateAccessPolicy func(ctx context.Context, accountID, applicationID string, accessPolicy cloudflare.AccessPolicy) (cloudflare.AccessPolicy, error)
+	MockUpdateAccessPolicy func(ctx context.Context, accountID, applicationID string, accessPolicy cloudflare.AccessPolicy) (cloudflare.AccessPolicy, error)
+	MockDeleteAccessPolicy func(ctx context.Context, accountID, applicationID, accessPolicyID string) error
+}
+
+// AccessPolicy mocks the AccessPolicy method of the Cloudflare API.
+func (m MockClient) AccessPolicy(ctx context.Context, accountID, applicationID, policyID string) (cloudflare.AccessPolicy, error) {
+	return m.MockAccessPolicy(ctx, accountID, applicationID, policyID)
+}
+
+// CreateAccessPolicy mocks the CreateAccessPolicy method of the Cloudflare API.
+func (m MockClient) CreateAccessPolicy(ctx context.Context, accountID, applicationID string, accessPolicy cloudflare.AccessPolicy) (cloudflare.AccessPolicy, error) {
+	return m.MockCreateAccessPolicy(ctx, accountID, applicationID, accessPolicy)
+}
+
+// UpdateAccessPolicy mocks the UpdateAccessPolicy method of the Cloudflare API.
+func (m MockClient) UpdateAccessPolicy(ctx context.Context, accountID, applicationID string, accessPolicy cloudflare.AccessPolicy) (cloudflare.AccessPolicy, error) {
+	return m.MockUpdateAccessPolicy(ctx, accountID, applicationID, accessPolicy)
+}
+
+// DeleteAccessPolicy mocks the DeleteAccessPolicy method of the Cloudflare API.
+func (m MockClient) DeleteAccessPolicy(ctx context.Context, accountID, applicationID, accessPolicyID string) error {
+	return m.MockDeleteAccessPolicy(ctx, accountID, applicationID, accessPolicyID)
+}
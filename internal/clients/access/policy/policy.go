@@ -0,0 +1,195 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+
+	"github.com/benagricola/provider-cloudflare/apis/access/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+const (
+	errCreatePolicy = "error creating access policy"
+	errUpdatePolicy = "error updating access policy"
+	errSpecNil      = "access policy spec is empty"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with Access Policies.
+type Client interface {
+	AccessPolicy(ctx context.Context, accountID, applicationID, policyID string) (cloudflare.AccessPolicy, error)
+	CreateAccessPolicy(ctx context.Context, accountID, applicationID string, accessPolicy cloudflare.AccessPolicy) (cloudflare.AccessPolicy, error)
+	UpdateAccessPolicy(ctx context.Context, accountID, applicationID string, accessPolicy cloudflare.AccessPolicy) (cloudflare.AccessPolicy, error)
+	DeleteAccessPolicy(ctx context.Context, accountID, applicationID, accessPolicyID string) error
+}
+
+// NewClient returns a new Cloudflare API client for working with Access
+// Policies.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// IsPolicyNotFound returns true if the passed error indicates
+// an Access Policy was not found.
+func IsPolicyNotFound(err error) bool {
+	return clients.IsNotFound(err)
+}
+
+// LateInitialize initializes AccessPolicyParameters based on the remote
+// resource.
+func LateInitialize(spec *v1alpha1.AccessPolicyParameters, p cloudflare.AccessPolicy) bool {
+	if spec == nil {
+		return false
+	}
+
+	li := false
+
+	if spec.Precedence == nil {
+		pr := int64(p.Precedence)
+		spec.Precedence = &pr
+		li = true
+	}
+
+	return li
+}
+
+// UpToDate checks if the remote Access Policy is up to date with the
+// requested resource parameters.
+func UpToDate(spec *v1alpha1.AccessPolicyParameters, p cloudflare.AccessPolicy) bool {
+	if spec == nil {
+		return true
+	}
+
+	if spec.Name != p.Name || spec.Decision != p.Decision {
+		return false
+	}
+
+	if spec.Precedence != nil && *spec.Precedence != int64(p.Precedence) {
+		return false
+	}
+
+	return cmp.Equal(rules(spec.Include), p.Include, cmpopts.EquateEmpty()) &&
+		cmp.Equal(rules(spec.Exclude), p.Exclude, cmpopts.EquateEmpty()) &&
+		cmp.Equal(rules(spec.Require), p.Require, cmpopts.EquateEmpty())
+}
+
+// rules converts AccessRules into the generic form used by the Cloudflare
+// API, which matches the shape of rules decoded from API responses.
+// A rule with several fields set produces one condition per field.
+func rules(in []v1alpha1.AccessRule) []interface{} { //nolint:gocyclo
+	// NOTE: The complexity here is simply one if statement per rule type.
+	if in == nil {
+		return nil
+	}
+
+	out := make([]interface{}, 0, len(in))
+	add := func(name string, v map[string]interface{}) {
+		out = append(out, map[string]interface{}{name: v})
+	}
+
+	for _, r := range in {
+		if r.Email != nil {
+			add("email", map[string]interface{}{"email": *r.Email})
+		}
+		if r.EmailDomain != nil {
+			add("email_domain", map[string]interface{}{"domain": *r.EmailDomain})
+		}
+		if r.IP != nil {
+			add("ip", map[string]interface{}{"ip": *r.IP})
+		}
+		if r.Geo != nil {
+			add("geo", map[string]interface{}{"country_code": *r.Geo})
+		}
+		if r.Everyone != nil && *r.Everyone {
+			add("everyone", map[string]interface{}{})
+		}
+		if r.Group != nil {
+			add("group", map[string]interface{}{"id": *r.Group})
+		}
+		if r.ServiceToken != nil {
+			add("service_token", map[string]interface{}{"token_id": *r.ServiceToken})
+		}
+		if r.AnyValidServiceToken != nil && *r.AnyValidServiceToken {
+			add("any_valid_service_token", map[string]interface{}{})
+		}
+		if r.Certificate != nil && *r.Certificate {
+			add("certificate", map[string]interface{}{})
+		}
+		if r.CommonName != nil {
+			add("common_name", map[string]interface{}{"common_name": *r.CommonName})
+		}
+		if r.AuthMethod != nil {
+			add("auth_method", map[string]interface{}{"auth_method": *r.AuthMethod})
+		}
+		if r.LoginMethod != nil {
+			add("login_method", map[string]interface{}{"id": *r.LoginMethod})
+		}
+		if r.DevicePosture != nil {
+			add("device_posture", map[string]interface{}{"integration_uid": *r.DevicePosture})
+		}
+	}
+
+	return out
+}
+
+// accessPolicy converts AccessPolicyParameters into a cloudflare Access
+// Policy.
+func accessPolicy(spec *v1alpha1.AccessPolicyParameters) cloudflare.AccessPolicy {
+	p := cloudflare.AccessPolicy{
+		Name:     spec.Name,
+		Decision: spec.Decision,
+		Include:  rules(spec.Include),
+		Exclude:  rules(spec.Exclude),
+		Require:  rules(spec.Require),
+	}
+
+	if spec.Precedence != nil {
+		p.Precedence = int(*spec.Precedence)
+	}
+
+	return p
+}
+
+// CreatePolicy creates a new Access Policy.
+func CreatePolicy(ctx context.Context, client Client, spec *v1alpha1.AccessPolicyParameters) (*cloudflare.AccessPolicy, error) {
+	if spec == nil {
+		return nil, errors.New(errSpecNil)
+	}
+
+	res, err := client.CreateAccessPolicy(ctx, *spec.Account, *spec.Application, accessPolicy(spec))
+	if err != nil {
+		return nil, errors.Wrap(err, errCreatePolicy)
+	}
+
+	return &res, nil
+}
+
+// UpdatePolicy updates mutable values on an Access Policy.
+func UpdatePolicy(ctx context.Context, client Client, policyID string, spec *v1alpha1.AccessPolicyParameters) error {
+	p := accessPolicy(spec)
+	p.ID = policyID
+
+	_, err := client.UpdateAccessPolicy(ctx, *spec.Account, *spec.Application, p)
+	return errors.Wrap(err, errUpdatePolicy)
+}
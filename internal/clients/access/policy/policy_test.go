@@ -0,0 +1,208 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/benagricola/provider-cloudflare/apis/access/v1alpha1"
+
+	ptr "k8s.io/utils/pointer"
+)
+
+func staffPolicy() *v1alpha1.AccessPolicyParameters {
+	return &v1alpha1.AccessPolicyParameters{
+		Name:       "Staff",
+		Decision:   "allow",
+		Precedence: ptr.Int64Ptr(1),
+		Include: []v1alpha1.AccessRule{
+			{EmailDomain: ptr.StringPtr("example.com")},
+			{Group: ptr.StringPtr("aa0a4aab-672b-4bdb-bc33-a59f1130a11f")},
+		},
+		Exclude: []v1alpha1.AccessRule{
+			{Email: ptr.StringPtr("contractor@example.com")},
+		},
+		Require: []v1alpha1.AccessRule{
+			{Geo: ptr.StringPtr("GB")},
+			{Certificate: ptr.BoolPtr(true)},
+		},
+	}
+}
+
+// staffAccessPolicy returns the policy as decoded from an API response.
+func staffAccessPolicy(t *testing.T) cloudflare.AccessPolicy {
+	t.Helper()
+
+	var p cloudflare.AccessPolicy
+	body := `{
+		"id": "699d98642c564d2e855e9661899b7252",
+		"precedence": 1,
+		"decision": "allow",
+		"name": "Staff",
+		"include": [
+			{"email_domain": {"domain": "example.com"}},
+			{"group": {"id": "aa0a4aab-672b-4bdb-bc33-a59f1130a11f"}}
+		],
+		"exclude": [
+			{"email": {"email": "contractor@example.com"}}
+		],
+		"require": [
+			{"geo": {"country_code": "GB"}},
+			{"certificate": {}}
+		]
+	}`
+	if err := json.Unmarshal([]byte(body), &p); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestLateInitialize(t *testing.T) {
+	type args struct {
+		pp *v1alpha1.AccessPolicyParameters
+		p  cloudflare.AccessPolicy
+	}
+
+	type want struct {
+		o  bool
+		pp *v1alpha1.AccessPolicyParameters
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"LateInitSpecNil": {
+			reason: "LateInit should return false when not passed a spec",
+			args:   args{},
+			want: want{
+				o: false,
+			},
+		},
+		"LateInitDontUpdate": {
+			reason: "LateInit should not update already-set spec fields from an Access Policy",
+			args: args{
+				pp: &v1alpha1.AccessPolicyParameters{Precedence: ptr.Int64Ptr(1)},
+				p:  cloudflare.AccessPolicy{Precedence: 2},
+			},
+			want: want{
+				o:  false,
+				pp: &v1alpha1.AccessPolicyParameters{Precedence: ptr.Int64Ptr(1)},
+			},
+		},
+		"LateInitUpdate": {
+			reason: "LateInit should update unset spec fields from an Access Policy",
+			args: args{
+				pp: &v1alpha1.AccessPolicyParameters{},
+				p:  cloudflare.AccessPolicy{Precedence: 2},
+			},
+			want: want{
+				o:  true,
+				pp: &v1alpha1.AccessPolicyParameters{Precedence: ptr.Int64Ptr(2)},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := LateInitialize(tc.args.pp, tc.args.p)
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nLateInitialize(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.pp, tc.args.pp); diff != "" {
+				t.Errorf("\n%s\nLateInitialize(...): -want spec, +got spec:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpToDate(t *testing.T) {
+	type args struct {
+		pp *v1alpha1.AccessPolicyParameters
+		p  cloudflare.AccessPolicy
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   bool
+	}{
+		"UpToDateSpecNil": {
+			reason: "UpToDate should return true when not passed a spec",
+			args:   args{},
+			want:   true,
+		},
+		"UpToDate": {
+			reason: "UpToDate should return true when the Access Policy matches the spec",
+			args: args{
+				pp: staffPolicy(),
+				p:  staffAccessPolicy(t),
+			},
+			want: true,
+		},
+		"NotUpToDateDecision": {
+			reason: "UpToDate should return false when the decision differs",
+			args: args{
+				pp: staffPolicy(),
+				p: func() cloudflare.AccessPolicy {
+					p := staffAccessPolicy(t)
+					p.Decision = "deny"
+					return p
+				}(),
+			},
+			want: false,
+		},
+		"NotUpToDateInclude": {
+			reason: "UpToDate should return false when the include rules differ",
+			args: args{
+				pp: staffPolicy(),
+				p: func() cloudflare.AccessPolicy {
+					p := staffAccessPolicy(t)
+					p.Include = p.Include[:1]
+					return p
+				}(),
+			},
+			want: false,
+		},
+		"NotUpToDateRequire": {
+			reason: "UpToDate should return false when the require rules differ",
+			args: args{
+				pp: func() *v1alpha1.AccessPolicyParameters {
+					pp := staffPolicy()
+					pp.Require[0].Geo = ptr.StringPtr("US")
+					return pp
+				}(),
+				p: staffAccessPolicy(t),
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := UpToDate(tc.args.pp, tc.args.p)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
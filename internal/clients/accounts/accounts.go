@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accounts
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cloudflare/cloudflare-go"
+
+	"github.com/benagricola/provider-cloudflare/apis/account/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with Accounts.
+type Client interface {
+	Account(ctx context.Context, accountID string) (cloudflare.Account, cloudflare.ResultInfo, error)
+	CreateAccount(ctx context.Context, account cloudflare.Account) (cloudflare.Account, error)
+	UpdateAccount(ctx context.Context, accountID string, account cloudflare.Account) (cloudflare.Account, error)
+	DeleteAccount(ctx context.Context, accountID string) error
+}
+
+// NewClient returns a new Cloudflare API client for working with Accounts.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// IsAccountNotFound returns true if the passed error indicates
+// an Account was not found. Cloudflare returns error code 7003 both for
+// an Account that is gone and for one the credentials in use cannot
+// access, so the Account is only gone if the response is a 404.
+func IsAccountNotFound(err error) bool {
+	return clients.IsNotFound(err)
+}
+
+// GenerateObservation creates an observation of a Cloudflare Account.
+func GenerateObservation(in cloudflare.Account) v1alpha1.AccountObservation {
+	return v1alpha1.AccountObservation{
+		Type: in.Type,
+	}
+}
+
+// LateInitialize initializes AccountParameters based on the remote resource.
+func LateInitialize(spec *v1alpha1.AccountParameters, o cloudflare.Account) bool {
+	if spec == nil {
+		return false
+	}
+
+	li := false
+	if spec.Type == nil && o.Type != "" {
+		spec.Type = &o.Type
+		li = true
+	}
+	return li
+}
+
+// UpToDate checks if the remote Account is up to date with the
+// requested resource parameters.
+func UpToDate(spec *v1alpha1.AccountParameters, o cloudflare.Account) bool {
+	if spec == nil {
+		return true
+	}
+
+	if spec.Name != o.Name {
+		return false
+	}
+
+	// Only the settings that are set are managed, and Cloudflare
+	// omits settings that are disabled.
+	if spec.Settings != nil && spec.Settings.EnforceTwoFactor != nil {
+		enforced := o.Settings != nil && o.Settings.EnforceTwoFactor
+		if *spec.Settings.EnforceTwoFactor != enforced {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ParametersToAccount returns a Cloudflare API representation of an
+// Account from our AccountParameters. Settings that are not managed keep
+// their current value from the passed Account.
+func ParametersToAccount(spec v1alpha1.AccountParameters, current *cloudflare.AccountSettings) cloudflare.Account {
+	a := cloudflare.Account{
+		Name: spec.Name,
+	}
+	if spec.Type != nil {
+		a.Type = *spec.Type
+	}
+
+	if spec.Settings != nil && spec.Settings.EnforceTwoFactor != nil {
+		s := cloudflare.AccountSettings{}
+		if current != nil {
+			s = *current
+		}
+		s.EnforceTwoFactor = *spec.Settings.EnforceTwoFactor
+		a.Settings = &s
+	}
+	return a
+}
+
+// UpdateAccount updates mutable values on an Account.
+func UpdateAccount(ctx context.Context, client Client, accountID string, spec v1alpha1.AccountParameters) error {
+	o, _, err := client.Account(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	// The type of an Account cannot be changed once it is created.
+	a := ParametersToAccount(spec, o.Settings)
+	a.Type = ""
+
+	_, err = client.UpdateAccount(ctx, accountID, a)
+	return err
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accounts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/account/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/accounts/fake"
+
+	ptr "k8s.io/utils/pointer"
+)
+
+func TestLateInitialize(t *testing.T) {
+	type args struct {
+		ap *v1alpha1.AccountParameters
+		a  cloudflare.Account
+	}
+
+	type want struct {
+		o  bool
+		ap *v1alpha1.AccountParameters
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"SpecNil": {
+			reason: "LateInitialize should return false when not passed a spec",
+			args:   args{},
+			want: want{
+				o: false,
+			},
+		},
+		"Type": {
+			reason: "LateInitialize should initialize the type of the Account",
+			args: args{
+				ap: &v1alpha1.AccountParameters{Name: "foo"},
+				a:  cloudflare.Account{Name: "foo", Type: "standard"},
+			},
+			want: want{
+				o:  true,
+				ap: &v1alpha1.AccountParameters{Name: "foo", Type: ptr.StringPtr("standard")},
+			},
+		},
+		"TypeSet": {
+			reason: "LateInitialize should not overwrite the type of the Account",
+			args: args{
+				ap: &v1alpha1.AccountParameters{Name: "foo", Type: ptr.StringPtr("enterprise")},
+				a:  cloudflare.Account{Name: "foo", Type: "standard"},
+			},
+			want: want{
+				o:  false,
+				ap: &v1alpha1.AccountParameters{Name: "foo", Type: ptr.StringPtr("enterprise")},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := LateInitialize(tc.args.ap, tc.args.a)
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nLateInitialize(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ap, tc.args.ap); diff != "" {
+				t.Errorf("\n%s\nLateInitialize(...): -want spec, +got spec:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpToDate(t *testing.T) {
+	type args struct {
+		ap *v1alpha1.AccountParameters
+		a  cloudflare.Account
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   bool
+	}{
+		"SpecNil": {
+			reason: "UpToDate should return true when not passed a spec",
+			args:   args{},
+			want:   true,
+		},
+		"NameChanged": {
+			reason: "UpToDate should return false if the name has changed",
+			args: args{
+				ap: &v1alpha1.AccountParameters{Name: "bar"},
+				a:  cloudflare.Account{Name: "foo"},
+			},
+			want: false,
+		},
+		"SettingsUnmanaged": {
+			reason: "UpToDate should return true if no settings are managed",
+			args: args{
+				ap: &v1alpha1.AccountParameters{Name: "foo"},
+				a: cloudflare.Account{
+					Name:     "foo",
+					Settings: &cloudflare.AccountSettings{EnforceTwoFactor: true},
+				},
+			},
+			want: true,
+		},
+		"EnforceTwoFactorChanged": {
+			reason: "UpToDate should return false if two-factor enforcement has changed",
+			args: args{
+				ap: &v1alpha1.AccountParameters{
+					Name:     "foo",
+					Settings: &v1alpha1.AccountSettings{EnforceTwoFactor: ptr.BoolPtr(true)},
+				},
+				a: cloudflare.Account{Name: "foo"},
+			},
+			want: false,
+		},
+		"EnforceTwoFactorOmitted": {
+			reason: "UpToDate should treat settings omitted by Cloudflare as disabled",
+			args: args{
+				ap: &v1alpha1.AccountParameters{
+					Name:     "foo",
+					Settings: &v1alpha1.AccountSettings{EnforceTwoFactor: ptr.BoolPtr(false)},
+				},
+				a: cloudflare.Account{Name: "foo"},
+			},
+			want: true,
+		},
+		"Identical": {
+			reason: "UpToDate should return true if the spec matches the Account",
+			args: args{
+				ap: &v1alpha1.AccountParameters{
+					Name:     "foo",
+					Type:     ptr.StringPtr("standard"),
+					Settings: &v1alpha1.AccountSettings{EnforceTwoFactor: ptr.BoolPtr(true)},
+				},
+				a: cloudflare.Account{
+					Name:     "foo",
+					Type:     "standard",
+					Settings: &cloudflare.AccountSettings{EnforceTwoFactor: true},
+				},
+			},
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := UpToDate(tc.args.ap, tc.args.a)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdateAccount(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		client Client
+		ap     v1alpha1.AccountParameters
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   error
+	}{
+		"ErrLookup": {
+			reason: "UpdateAccount should return errors looking up the Account",
+			args: args{
+				client: fake.MockClient{
+					MockAccount: func(ctx context.Context, accountID string) (cloudflare.Account, cloudflare.ResultInfo, error) {
+						return cloudflare.Account{}, cloudflare.ResultInfo{}, errBoom
+					},
+				},
+			},
+			want: errBoom,
+		},
+		"Success": {
+			reason: "UpdateAccount should update the name and managed settings, but not the type",
+			args: args{
+				client: fake.MockClient{
+					MockAccount: func(ctx context.Context, accountID string) (cloudflare.Account, cloudflare.ResultInfo, error) {
+						return cloudflare.Account{ID: accountID, Name: "foo", Type: "standard"}, cloudflare.ResultInfo{}, nil
+					},
+					MockUpdateAccount: func(ctx context.Context, accountID string, a cloudflare.Account) (cloudflare.Account, error) {
+						want := cloudflare.Account{
+							Name:     "bar",
+							Settings: &cloudflare.AccountSettings{EnforceTwoFactor: true},
+						}
+						if diff := cmp.Diff(want, a); diff != "" {
+							return cloudflare.Account{}, errors.Errorf("unexpected account: %s", diff)
+						}
+						return a, nil
+					},
+				},
+				ap: v1alpha1.AccountParameters{
+					Name:     "bar",
+					Type:     ptr.StringPtr("standard"),
+					Settings: &v1alpha1.AccountSettings{EnforceTwoFactor: ptr.BoolPtr(true)},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := UpdateAccount(context.Background(), tc.args.client, "1234", tc.args.ap)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdateAccount(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
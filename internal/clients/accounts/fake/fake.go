@@ -0,0 +1,51 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// A MockClient acts as a testable representation of the Cloudflare API.
+type MockClient struct {
+	MockAccount       func(ctx context.Context, accountID string) (cloudflare.Account, cloudflare.ResultInfo, error)
+	MockCreateAccount func(ctx context.Context, account cloudflare.Account) (cloudflare.Account, error)
+	MockUpdateAccount func(ctx context.Context, accountID string, account cloudflare.Account) (cloudflare.Account, error)
+	MockDeleteAccount func(ctx context.Context, accountID string) error
+}
+
+// Account mocks the Account method of the Cloudflare API.
+func (m MockClient) Account(ctx context.Context, accountID string) (cloudflare.Account, cloudflare.ResultInfo, error) {
+	return m.MockAccount(ctx, accountID)
+}
+
+// CreateAccount mocks the CreateAccount method of the Cloudflare API.
+func (m MockClient) CreateAccount(ctx context.Context, account cloudflare.Account) (cloudflare.Account, error) {
+	return m.MockCreateAccount(ctx, account)
+}
+
+// UpdateAccount mocks the UpdateAccount method of the Cloudflare API.
+func (m MockClient) UpdateAccount(ctx context.Context, accountID string, account cloudflare.Account) (cloudflare.Account, error) {
+	return m.MockUpdateAccount(ctx, accountID, account)
+}
+
+// DeleteAccount mocks the DeleteAccount method of the Cloudflare API.
+func (m MockClient) DeleteAccount(ctx context.Context, accountID string) error {
+	return m.MockDeleteAccount(ctx, accountID)
+}
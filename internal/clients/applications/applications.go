@@ -18,12 +18,18 @@ package applications
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/cloudflare/cloudflare-go"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -31,14 +37,116 @@ import (
 	clients "github.com/benagricola/provider-cloudflare/internal/clients"
 )
 
+const (
+	// connectionDetailEdgeIPs is the connection detail containing the
+	// comma separated anycast edge IPs of a Spectrum Application.
+	connectionDetailEdgeIPs = "edgeIPs"
+
+	// connectionDetailDNSName is the connection detail containing the
+	// DNS name assigned to a Spectrum Application.
+	connectionDetailDNSName = "dnsName"
+)
+
 const (
 	// Cloudflare returns this code when a application isnt found.
-	errApplicationNotFound = "10006"
+	errApplicationNotFound = 10006
 
 	// Returned when an invalid IP is supplied within spec
 	errApplicationInvalidIP = "invalid IP within Edge IPs"
+
+	// Returned when an invalid origin port or port range is supplied within spec
+	errApplicationOriginPortBounds     = "origin port %d must be between %d and %d"
+	errApplicationOriginPortRange      = "origin port range start %d must be lower than end %d"
+	errApplicationOriginPortIncomplete = "origin port range requires both start and end"
+	errApplicationOriginPortExclusive  = "origin port may be a port or a range, not both"
+
+	minOriginPort = 1
+	maxOriginPort = 65535
+
+	// Returned when an invalid traffic type is supplied within spec
+	errApplicationInvalidTrafficType = "unsupported traffic type %q"
+
+	// Cloudflare terminates TLS at the edge for https traffic, so it
+	// cannot be turned off.
+	errApplicationHTTPSTLSOff = "traffic type https requires TLS, but TLS is off"
+
+	// Proxy protocols are only sent for traffic that Cloudflare proxies
+	// directly to an origin, and each version only supports some
+	// transports, both at the edge and at the origin.
+	errApplicationInvalidProxyProtocol      = "unsupported proxy protocol %q"
+	errApplicationProxyProtocolTrafficType  = "proxy protocol %q requires traffic type direct, not %q"
+	errApplicationProxyProtocolScheme       = "proxy protocol %q cannot be used with %s applications"
+	errApplicationProxyProtocolNoOrigin     = "proxy protocol %q requires an origin direct or origin DNS to send its header to"
+	errApplicationProxyProtocolOriginScheme = "proxy protocol %q cannot be sent to %s origin %q"
+
+	// Returned when the zone within spec is not a zone ID, such as when
+	// the domain name of the zone is supplied instead.
+	errApplicationInvalidZone = "zone %q is not a zone ID"
+
+	// Returned when an invalid edge IPs type is supplied within spec
+	errApplicationInvalidEdgeIPsType = "unsupported edge IPs type %q"
+
+	// Dynamic edge IPs are assigned by Cloudflare, but the IP versions
+	// they are assigned for must be chosen when the app is created.
+	errApplicationEdgeIPsNoConnectivity = "dynamic edge IPs require connectivity"
+	errApplicationInvalidConnectivity   = "unsupported edge IPs connectivity %q"
+
+	// Returned when an invalid protocol is supplied within spec
+	errApplicationInvalidProtocol       = "protocol %q must be a scheme and port or port range, such as tcp/22 or udp/1000-2000"
+	errApplicationInvalidProtocolScheme = "unsupported protocol scheme %q"
+	errApplicationProtocolPortBounds    = "protocol port %q must be between %d and %d"
+	errApplicationProtocolPortRange     = "protocol port range start %d must be lower than end %d"
+
+	// Returned when an invalid origin direct target is supplied within spec
+	errApplicationInvalidOriginDirect       = "origin direct %q must be a scheme, host and port, such as tcp://192.0.2.1:22 or tcp://host.example.com:22"
+	errApplicationInvalidOriginDirectScheme = "unsupported origin direct scheme %q"
+	errApplicationOriginDirectPortBounds    = "origin direct port %q must be between %d and %d"
 )
 
+// protocolSchemes are the protocols a Spectrum Application may proxy.
+var protocolSchemes = map[string]bool{
+	"tcp": true,
+	"udp": true,
+}
+
+// trafficTypes are the traffic types a Spectrum Application may use.
+// These must be kept in sync with the enum on ApplicationParameters.TrafficType.
+var trafficTypes = map[string]bool{
+	"direct": true,
+	"http":   true,
+	"https":  true,
+}
+
+// edgeIPsTypes are the edge IP configurations a Spectrum Application may use.
+// These must be kept in sync with the enum on SpectrumApplicationEdgeIPs.Type.
+var edgeIPsTypes = map[string]bool{
+	string(cloudflare.SpectrumEdgeTypeDynamic): true,
+	string(cloudflare.SpectrumEdgeTypeStatic):  true,
+}
+
+// proxyProtocolOff disables sending a proxy protocol header to the origin.
+const proxyProtocolOff = "off"
+
+// proxyProtocolSchemes are the protocol schemes each proxy protocol may
+// be used with. Version 1 is a text format that only supports TCP, while
+// the simple proxy protocol only supports UDP. These must be kept in sync
+// with the enum on ApplicationParameters.ProxyProtocol.
+var proxyProtocolSchemes = map[string]map[string]bool{
+	proxyProtocolOff: {"tcp": true, "udp": true},
+	"v1":             {"tcp": true},
+	"v2":             {"tcp": true, "udp": true},
+	"simple":         {"udp": true},
+}
+
+// edgeIPsConnectivities are the IP versions dynamic edge IPs may be
+// assigned for. These must be kept in sync with the enum on
+// SpectrumApplicationEdgeIPs.Connectivity.
+var edgeIPsConnectivities = map[string]bool{
+	string(cloudflare.SpectrumConnectivityAll):  true,
+	string(cloudflare.SpectrumConnectivityIPv4): true,
+	string(cloudflare.SpectrumConnectivityIPv6): true,
+}
+
 // Client is a Cloudflare API client that implements methods for working
 // with Spectrum Applications.
 type Client interface {
@@ -46,6 +154,22 @@ type Client interface {
 	SpectrumApplication(ctx context.Context, zoneID string, applicationID string) (cloudflare.SpectrumApplication, error)
 	UpdateSpectrumApplication(ctx context.Context, zoneID, appID string, appDetails cloudflare.SpectrumApplication) (cloudflare.SpectrumApplication, error)
 	DeleteSpectrumApplication(ctx context.Context, zoneID string, applicationID string) error
+	RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error)
+}
+
+// originDNS represents the origin DNS of a Spectrum Application, including
+// the TTL that is in the API but not supported in the go library yet.
+type originDNS struct {
+	Name string `json:"name"`
+	TTL  *int64 `json:"ttl,omitempty"`
+}
+
+// application is a Spectrum Application whose origin DNS includes its TTL.
+// The origin DNS field shadows that of the embedded application when it is
+// encoded.
+type application struct {
+	cloudflare.SpectrumApplication
+	OriginDNS *originDNS `json:"origin_dns,omitempty"`
 }
 
 // NewClient returns a new Cloudflare API client for working with Spectrum Applications.
@@ -56,7 +180,7 @@ func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
 // IsApplicationNotFound returns true if the passed error indicates
 // a spectrum application was not found.
 func IsApplicationNotFound(err error) bool {
-	return strings.Contains(err.Error(), errApplicationNotFound)
+	return clients.IsNotFound(err) || clients.HasErrorCode(err, errApplicationNotFound)
 }
 
 // ConvertIPs converts slice of IPs in string form
@@ -74,6 +198,260 @@ func ConvertIPs(ips []string) ([]net.IP, error) {
 	return rips, nil
 }
 
+// validOriginPort returns an error if the passed port is out of bounds.
+func validOriginPort(p *uint32) error {
+	if p != nil && (*p < minOriginPort || *p > maxOriginPort) {
+		return fmt.Errorf(errApplicationOriginPortBounds, *p, minOriginPort, maxOriginPort)
+	}
+	return nil
+}
+
+// ValidateOriginPort returns an error if the passed origin port
+// or port range is not valid.
+func ValidateOriginPort(op *v1alpha1.SpectrumApplicationOriginPort) error {
+	if op == nil {
+		return nil
+	}
+
+	for _, p := range []*uint32{op.Port, op.Start, op.End} {
+		if err := validOriginPort(p); err != nil {
+			return err
+		}
+	}
+
+	if op.Start == nil && op.End == nil {
+		return nil
+	}
+
+	if op.Port != nil {
+		return errors.New(errApplicationOriginPortExclusive)
+	}
+
+	if op.Start == nil || op.End == nil {
+		return errors.New(errApplicationOriginPortIncomplete)
+	}
+
+	if *op.Start >= *op.End {
+		return fmt.Errorf(errApplicationOriginPortRange, *op.Start, *op.End)
+	}
+
+	return nil
+}
+
+// ValidateTrafficType returns an error if the passed traffic type
+// is not supported.
+func ValidateTrafficType(tt *string) error {
+	if tt != nil && !trafficTypes[*tt] {
+		return fmt.Errorf(errApplicationInvalidTrafficType, *tt)
+	}
+	return nil
+}
+
+// ValidateTrafficTypeTLS returns an error if the passed traffic type
+// cannot be used with the passed TLS termination.
+func ValidateTrafficTypeTLS(tt, tls *string) error {
+	if tt != nil && *tt == "https" && tls != nil && *tls == "off" {
+		return errors.New(errApplicationHTTPSTLSOff)
+	}
+	return nil
+}
+
+// ValidateProxyProtocol returns an error if the proxy protocol of the
+// passed parameters cannot be used with their protocol, traffic type or
+// origin. The protocol and origin direct targets must already be valid.
+// The edge IPs do not restrict the proxy protocol, as its header describes
+// the connection of the client whichever edge IPs it connected to.
+func ValidateProxyProtocol(spec *v1alpha1.ApplicationParameters) error {
+	pp := spec.ProxyProtocol
+	if pp == nil {
+		return nil
+	}
+
+	schemes, ok := proxyProtocolSchemes[*pp]
+	if !ok {
+		return fmt.Errorf(errApplicationInvalidProxyProtocol, *pp)
+	}
+	if *pp == proxyProtocolOff {
+		return nil
+	}
+
+	// Traffic type defaults to direct when it is not set.
+	if tt := spec.TrafficType; tt != nil && *tt != "direct" {
+		return fmt.Errorf(errApplicationProxyProtocolTrafficType, *pp, *tt)
+	}
+
+	scheme := strings.Split(spec.Protocol, "/")[0]
+	if !schemes[scheme] {
+		return fmt.Errorf(errApplicationProxyProtocolScheme, *pp, scheme)
+	}
+
+	if len(spec.OriginDirect) == 0 && spec.OriginDNS == nil {
+		return fmt.Errorf(errApplicationProxyProtocolNoOrigin, *pp)
+	}
+
+	// The header is sent over the transport of each origin direct
+	// target, which may differ from that of the application.
+	for _, t := range spec.OriginDirect {
+		u, err := url.Parse(t)
+		if err != nil {
+			return fmt.Errorf(errApplicationInvalidOriginDirect, t)
+		}
+		if !schemes[u.Scheme] {
+			return fmt.Errorf(errApplicationProxyProtocolOriginScheme, *pp, u.Scheme, t)
+		}
+	}
+	return nil
+}
+
+// protocolPort returns the passed protocol port, or an error if
+// it is out of bounds.
+func protocolPort(p string) (uint64, error) {
+	n, err := strconv.ParseUint(p, 10, 32)
+	if err != nil || n < minOriginPort || n > maxOriginPort {
+		return 0, fmt.Errorf(errApplicationProtocolPortBounds, p, minOriginPort, maxOriginPort)
+	}
+	return n, nil
+}
+
+// ValidateProtocol returns an error if the passed protocol does not
+// have a supported scheme and a valid port or port range.
+func ValidateProtocol(p string) error {
+	parts := strings.Split(p, "/")
+	if len(parts) != 2 {
+		return fmt.Errorf(errApplicationInvalidProtocol, p)
+	}
+
+	if !protocolSchemes[parts[0]] {
+		return fmt.Errorf(errApplicationInvalidProtocolScheme, parts[0])
+	}
+
+	ports := strings.Split(parts[1], "-")
+	if len(ports) > 2 {
+		return fmt.Errorf(errApplicationInvalidProtocol, p)
+	}
+
+	start, err := protocolPort(ports[0])
+	if err != nil {
+		return err
+	}
+
+	if len(ports) == 1 {
+		return nil
+	}
+
+	end, err := protocolPort(ports[1])
+	if err != nil {
+		return err
+	}
+
+	if start >= end {
+		return fmt.Errorf(errApplicationProtocolPortRange, start, end)
+	}
+
+	return nil
+}
+
+// ValidateOriginDirect returns an error if any of the passed origin
+// direct targets does not have a supported scheme, an IP or hostname
+// and a valid port.
+func ValidateOriginDirect(od []string) error {
+	for _, t := range od {
+		u, err := url.Parse(t)
+		if err != nil || u.Hostname() == "" || u.Port() == "" || u.Path != "" || u.User != nil {
+			return fmt.Errorf(errApplicationInvalidOriginDirect, t)
+		}
+
+		if !protocolSchemes[u.Scheme] {
+			return fmt.Errorf(errApplicationInvalidOriginDirectScheme, u.Scheme)
+		}
+
+		n, err := strconv.ParseUint(u.Port(), 10, 32)
+		if err != nil || n < minOriginPort || n > maxOriginPort {
+			return fmt.Errorf(errApplicationOriginDirectPortBounds, u.Port(), minOriginPort, maxOriginPort)
+		}
+	}
+	return nil
+}
+
+// ValidateEdgeIPs returns an error if the passed edge IP
+// configuration is not supported.
+func ValidateEdgeIPs(eips *v1alpha1.SpectrumApplicationEdgeIPs) error {
+	if eips != nil && !edgeIPsTypes[eips.Type] {
+		return fmt.Errorf(errApplicationInvalidEdgeIPsType, eips.Type)
+	}
+	return nil
+}
+
+// ValidateCreateEdgeIPs returns an error if the passed edge IP
+// configuration cannot be used to create a Spectrum Application.
+// Dynamic edge IPs do not need IPs, as Cloudflare assigns them, but do
+// need the IP versions to assign them for.
+func ValidateCreateEdgeIPs(eips *v1alpha1.SpectrumApplicationEdgeIPs) error {
+	if err := ValidateEdgeIPs(eips); err != nil {
+		return err
+	}
+	if eips == nil || eips.Type != string(cloudflare.SpectrumEdgeTypeDynamic) {
+		return nil
+	}
+	if eips.Connectivity == nil {
+		return errors.New(errApplicationEdgeIPsNoConnectivity)
+	}
+	if !edgeIPsConnectivities[*eips.Connectivity] {
+		return fmt.Errorf(errApplicationInvalidConnectivity, *eips.Connectivity)
+	}
+	return nil
+}
+
+// zoneIDPattern matches Cloudflare zone IDs.
+var zoneIDPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// ValidateZone returns an error if the passed zone is not a zone ID.
+// Zone IDs are 32 lower case hexadecimal characters.
+func ValidateZone(zone string) error {
+	if !zoneIDPattern.MatchString(zone) {
+		return fmt.Errorf(errApplicationInvalidZone, zone)
+	}
+	return nil
+}
+
+// Validate returns an error if the passed ApplicationParameters
+// would be rejected by Cloudflare.
+func Validate(spec *v1alpha1.ApplicationParameters) error {
+	if spec.Zone != nil {
+		if err := ValidateZone(*spec.Zone); err != nil {
+			return err
+		}
+	}
+	if err := ValidateOriginPort(spec.OriginPort); err != nil {
+		return err
+	}
+	if err := ValidateEdgeIPs(spec.EdgeIPs); err != nil {
+		return err
+	}
+	if err := ValidateTrafficType(spec.TrafficType); err != nil {
+		return err
+	}
+	if err := ValidateTrafficTypeTLS(spec.TrafficType, spec.TLS); err != nil {
+		return err
+	}
+	if err := ValidateOriginDirect(spec.OriginDirect); err != nil {
+		return err
+	}
+	if err := ValidateProtocol(spec.Protocol); err != nil {
+		return err
+	}
+	return ValidateProxyProtocol(spec)
+}
+
+// ValidateCreate returns an error if the passed ApplicationParameters
+// could not be used to create a Spectrum Application.
+func ValidateCreate(spec *v1alpha1.ApplicationParameters) error {
+	if err := ValidateCreateEdgeIPs(spec.EdgeIPs); err != nil {
+		return err
+	}
+	return Validate(spec)
+}
+
 // edgeIPsDontMatch returns true if the spec and observed IPs do not match
 // returns false if the spec IPs do match
 func edgeIPsDontMatch(spec []string, o []net.IP) bool {
@@ -90,6 +468,23 @@ func edgeIPsDontMatch(spec []string, o []net.IP) bool {
 	return !cmp.Equal(a, b)
 }
 
+// originDirectDontMatch returns true if the spec and observed origin
+// direct targets do not match. Hostnames are case insensitive, so
+// targets are compared without regard to case or order.
+func originDirectDontMatch(spec []string, o []string) bool {
+	a := make(map[string]struct{})
+	for _, t := range spec {
+		a[strings.ToLower(t)] = struct{}{}
+	}
+
+	b := make(map[string]struct{})
+	for _, t := range o {
+		b[strings.ToLower(t)] = struct{}{}
+	}
+
+	return !cmp.Equal(a, b)
+}
+
 // edgeIPsToStrings returns a string array of inputted net.IPs
 func edgeIPsToStrings(i []net.IP) []string {
 	o := make([]string, len(i))
@@ -110,9 +505,36 @@ func GenerateObservation(in cloudflare.SpectrumApplication) v1alpha1.Application
 		o.ModifiedOn = &metav1.Time{Time: *in.ModifiedOn}
 	}
 
+	if in.EdgeIPs != nil && len(in.EdgeIPs.IPs) > 0 {
+		o.EdgeIPs = edgeIPsToStrings(in.EdgeIPs.IPs)
+	}
+
+	o.DNSName = in.DNS.Name
+	o.Protocol = in.Protocol
+
 	return o
 }
 
+// ConnectionDetails returns the connection details of a Spectrum Application
+// from its observation, so that they always match its status. The edge IPs
+// and DNS name are always included, so that they are cleared from the
+// connection secret if they are no longer assigned.
+func ConnectionDetails(o v1alpha1.ApplicationObservation) managed.ConnectionDetails {
+	return managed.ConnectionDetails{
+		connectionDetailEdgeIPs: []byte(strings.Join(o.EdgeIPs, ",")),
+		connectionDetailDNSName: []byte(o.DNSName),
+	}
+}
+
+// EdgeIPsAssigned returns false if the passed Spectrum Application uses
+// dynamic edge IPs that Cloudflare has not assigned yet.
+func EdgeIPsAssigned(in cloudflare.SpectrumApplication) bool {
+	if in.EdgeIPs == nil || in.EdgeIPs.Type != cloudflare.SpectrumEdgeTypeDynamic {
+		return true
+	}
+	return len(in.EdgeIPs.IPs) > 0
+}
+
 // LateInitialize initializes ApplicationParameters based on the remote resource
 func LateInitialize(spec *v1alpha1.ApplicationParameters, o cloudflare.SpectrumApplication) bool {
 
@@ -135,9 +557,101 @@ func LateInitialize(spec *v1alpha1.ApplicationParameters, o cloudflare.SpectrumA
 		}
 		li = true
 	}
+
+	// Cloudflare replaces the whole application on update, so enabled Argo
+	// Smart Routing must be known to avoid disabling it when other fields
+	// change.
+	if spec.ArgoSmartRouting == nil && o.ArgoSmartRouting {
+		asr := o.ArgoSmartRouting
+		spec.ArgoSmartRouting = &asr
+		li = true
+	}
 	return li
 }
 
+// hasOriginDNSTTL returns true if the passed origin DNS sets a TTL, which
+// must be observed and sent using raw API requests.
+func hasOriginDNSTTL(odns *v1alpha1.SpectrumApplicationOriginDNS) bool {
+	return odns != nil && odns.TTL != nil
+}
+
+// GetSpectrumApplication returns a Spectrum Application and its origin DNS
+// TTL, which is nil unless the passed parameters set one. The TTL is not
+// supported in the go library yet, so if it is set the application and its
+// TTL are both decoded from a single raw API request.
+func GetSpectrumApplication(ctx context.Context, client Client, zoneID, applicationID string, spec *v1alpha1.ApplicationParameters) (cloudflare.SpectrumApplication, *int64, error) {
+	if !hasOriginDNSTTL(spec.OriginDNS) {
+		ap, err := client.SpectrumApplication(ctx, zoneID, applicationID)
+		return ap, nil, err
+	}
+
+	raw, err := client.RawContext(ctx, http.MethodGet, "/zones/"+zoneID+"/spectrum/apps/"+applicationID, nil)
+	if err != nil {
+		return cloudflare.SpectrumApplication{}, nil, err
+	}
+
+	ap := cloudflare.SpectrumApplication{}
+	if err := json.Unmarshal(raw, &ap); err != nil {
+		return cloudflare.SpectrumApplication{}, nil, err
+	}
+	ttl, err := ParseOriginDNSTTL(raw)
+	if err != nil {
+		return cloudflare.SpectrumApplication{}, nil, err
+	}
+	return ap, ttl, nil
+}
+
+// ParseOriginDNSTTL returns the origin DNS TTL of a Spectrum Application
+// from a raw Spectrum Application response.
+func ParseOriginDNSTTL(raw json.RawMessage) (*int64, error) {
+	a := struct {
+		OriginDNS *originDNS `json:"origin_dns"`
+	}{}
+	if err := json.Unmarshal(raw, &a); err != nil {
+		return nil, err
+	}
+	if a.OriginDNS == nil {
+		return nil, nil
+	}
+	return a.OriginDNS.TTL, nil
+}
+
+// OriginDNSTTLUpToDate checks if the observed origin DNS TTL is up to date
+// with the requested parameters. The TTL is not managed unless it is set.
+func OriginDNSTTLUpToDate(spec *v1alpha1.ApplicationParameters, ttl *int64) bool {
+	if spec == nil || !hasOriginDNSTTL(spec.OriginDNS) {
+		return true
+	}
+	return ttl != nil && *ttl == *spec.OriginDNS.TTL
+}
+
+// sendApplication sends the passed Spectrum Application using a raw API
+// request, so that the TTL of the passed origin DNS is included.
+func sendApplication(ctx context.Context, client Client, method, endpoint string, ap cloudflare.SpectrumApplication, odns *v1alpha1.SpectrumApplicationOriginDNS) (cloudflare.SpectrumApplication, error) {
+	raw, err := client.RawContext(ctx, method, endpoint, application{
+		SpectrumApplication: ap,
+		OriginDNS:           &originDNS{Name: odns.Name, TTL: odns.TTL},
+	})
+	if err != nil {
+		return cloudflare.SpectrumApplication{}, err
+	}
+
+	res := cloudflare.SpectrumApplication{}
+	if err := json.Unmarshal(raw, &res); err != nil {
+		return cloudflare.SpectrumApplication{}, err
+	}
+	return res, nil
+}
+
+// CreateSpectrumApplication creates the passed Spectrum Application, including
+// the TTL of the passed origin DNS if it sets one.
+func CreateSpectrumApplication(ctx context.Context, client Client, zoneID string, ap cloudflare.SpectrumApplication, odns *v1alpha1.SpectrumApplicationOriginDNS) (cloudflare.SpectrumApplication, error) {
+	if hasOriginDNSTTL(odns) {
+		return sendApplication(ctx, client, http.MethodPost, "/zones/"+zoneID+"/spectrum/apps", ap, odns)
+	}
+	return client.CreateSpectrumApplication(ctx, zoneID, ap)
+}
+
 // UpToDate checks if the remote Application is up to date with the
 // requested resource parameters.
 func UpToDate(spec *v1alpha1.ApplicationParameters, o cloudflare.SpectrumApplication) bool { //nolint:gocyclo
@@ -153,7 +667,8 @@ func UpToDate(spec *v1alpha1.ApplicationParameters, o cloudflare.SpectrumApplica
 		return false
 	}
 
-	if spec.DNS.Name != o.DNS.Name {
+	// DNS names are case insensitive.
+	if !strings.EqualFold(spec.DNS.Name, o.DNS.Name) {
 		return false
 	}
 
@@ -179,7 +694,7 @@ func UpToDate(spec *v1alpha1.ApplicationParameters, o cloudflare.SpectrumApplica
 		return false
 	}
 
-	if spec.OriginDNS != nil && spec.OriginDNS.Name != o.OriginDNS.Name {
+	if spec.OriginDNS != nil && (o.OriginDNS == nil || !strings.EqualFold(spec.OriginDNS.Name, o.OriginDNS.Name)) {
 		return false
 	}
 
@@ -188,7 +703,7 @@ func UpToDate(spec *v1alpha1.ApplicationParameters, o cloudflare.SpectrumApplica
 	}
 
 	if spec.EdgeIPs != nil {
-		if o.EdgeIPs.Type != cloudflare.SpectrumApplicationEdgeType(spec.EdgeIPs.Type) {
+		if o.EdgeIPs == nil || o.EdgeIPs.Type != cloudflare.SpectrumApplicationEdgeType(spec.EdgeIPs.Type) {
 			return false
 		}
 
@@ -205,7 +720,7 @@ func UpToDate(spec *v1alpha1.ApplicationParameters, o cloudflare.SpectrumApplica
 		return false
 	}
 
-	if !cmp.Equal(spec.OriginDirect, o.OriginDirect) {
+	if originDirectDontMatch(spec.OriginDirect, o.OriginDirect) {
 		return false
 	}
 
@@ -232,8 +747,12 @@ func UpToDate(spec *v1alpha1.ApplicationParameters, o cloudflare.SpectrumApplica
 	return true
 }
 
-// UpdateSpectrumApplication updates mutable values on a Spectrum Application.
-func UpdateSpectrumApplication(ctx context.Context, client Client, applicationID string, spec *v1alpha1.ApplicationParameters) error { //nolint:gocyclo
+// UpdateSpectrumApplication updates mutable values on a Spectrum Application,
+// returning the updated Spectrum Application.
+func UpdateSpectrumApplication(ctx context.Context, client Client, applicationID string, spec *v1alpha1.ApplicationParameters) (cloudflare.SpectrumApplication, error) { //nolint:gocyclo
+	if err := Validate(spec); err != nil {
+		return cloudflare.SpectrumApplication{}, err
+	}
 
 	dns := cloudflare.SpectrumApplicationDNS{
 		Type: spec.DNS.Type,
@@ -276,12 +795,15 @@ func UpdateSpectrumApplication(ctx context.Context, client Client, applicationID
 		if spec.EdgeIPs.IPs != nil {
 			ips, iperr := ConvertIPs(spec.EdgeIPs.IPs)
 			if iperr != nil {
-				return iperr
+				return cloudflare.SpectrumApplication{}, iperr
 			}
 			eips.IPs = ips
 		}
 	}
 
+	// The application is identified by the ID in the request path, so the
+	// ID and other fields assigned by Cloudflare, such as its timestamps,
+	// are left unset and omitted from the request body.
 	ap := cloudflare.SpectrumApplication{
 		Protocol:     spec.Protocol,
 		DNS:          dns,
@@ -295,6 +817,9 @@ func UpdateSpectrumApplication(ctx context.Context, client Client, applicationID
 		ap.ProxyProtocol = cloudflare.ProxyProtocol(*spec.ProxyProtocol)
 	}
 
+	// Cloudflare replaces the whole application on update, and a false
+	// IPFirewall is omitted from the request, so disabling it is applied
+	// by Cloudflare resetting it to its default of false.
 	if spec.IPFirewall != nil {
 		ap.IPFirewall = *spec.IPFirewall
 	}
@@ -311,8 +836,9 @@ func UpdateSpectrumApplication(ctx context.Context, client Client, applicationID
 		ap.ArgoSmartRouting = *spec.ArgoSmartRouting
 	}
 
-	_, err := client.UpdateSpectrumApplication(ctx, *spec.Zone, applicationID, ap)
-
-	return err
+	if hasOriginDNSTTL(spec.OriginDNS) {
+		return sendApplication(ctx, client, http.MethodPut, "/zones/"+*spec.Zone+"/spectrum/apps/"+applicationID, ap, spec.OriginDNS)
+	}
 
+	return client.UpdateSpectrumApplication(ctx, *spec.Zone, applicationID, ap)
 }
@@ -18,6 +18,8 @@ package applications
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net"
 	"testing"
 
@@ -29,10 +31,14 @@ import (
 
 	ptr "k8s.io/utils/pointer"
 
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
 	"github.com/benagricola/provider-cloudflare/apis/spectrum/v1alpha1"
 	"github.com/benagricola/provider-cloudflare/internal/clients/applications/fake"
 )
 
+const zoneID = "023e105f4ecef8ad9ca31a8372d0c353"
+
 func TestUpToDate(t *testing.T) {
 
 	port := uint32(2022)
@@ -107,14 +113,50 @@ func TestUpToDate(t *testing.T) {
 			args: args{
 				rp: &v1alpha1.ApplicationParameters{
 					EdgeIPs: &v1alpha1.SpectrumApplicationEdgeIPs{
-						IPs: []string{"192.0.2.2", "2001:db8::1"},
+						Type: "static",
+						IPs:  []string{"192.0.2.2", "2001:db8::1"},
+					},
+				},
+				r: cloudflare.SpectrumApplication{
+					EdgeIPs: &cloudflare.SpectrumApplicationEdgeIPs{
+						Type: "static",
+						IPs:  []net.IP{net.ParseIP("192.0.2.1"), net.ParseIP("2001:db8::1")},
+					},
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"UpToDateDifferentEdgeIPsType": {
+			reason: "UpToDate should return false when the EdgeIPs type does not match",
+			args: args{
+				rp: &v1alpha1.ApplicationParameters{
+					EdgeIPs: &v1alpha1.SpectrumApplicationEdgeIPs{
+						Type: "static",
+						IPs:  []string{"192.0.2.1"},
 					},
 				},
 				r: cloudflare.SpectrumApplication{
 					EdgeIPs: &cloudflare.SpectrumApplicationEdgeIPs{
-						IPs: []net.IP{net.ParseIP("192.0.2.1"), net.ParseIP("2001:db8::1")},
+						Type:         cloudflare.SpectrumEdgeTypeDynamic,
+						Connectivity: &connectivityAll,
+					},
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"UpToDateNoObservedEdgeIPs": {
+			reason: "UpToDate should return false and not panic when EdgeIPs are specified but not observed",
+			args: args{
+				rp: &v1alpha1.ApplicationParameters{
+					EdgeIPs: &v1alpha1.SpectrumApplicationEdgeIPs{
+						Type: "dynamic",
 					},
 				},
+				r: cloudflare.SpectrumApplication{},
 			},
 			want: want{
 				o: false,
@@ -125,12 +167,14 @@ func TestUpToDate(t *testing.T) {
 			args: args{
 				rp: &v1alpha1.ApplicationParameters{
 					EdgeIPs: &v1alpha1.SpectrumApplicationEdgeIPs{
-						IPs: []string{"2001:db8::1", "192.0.2.1"},
+						Type: "static",
+						IPs:  []string{"2001:db8::1", "192.0.2.1"},
 					},
 				},
 				r: cloudflare.SpectrumApplication{
 					EdgeIPs: &cloudflare.SpectrumApplicationEdgeIPs{
-						IPs: []net.IP{net.ParseIP("192.0.2.1"), net.ParseIP("2001:db8::1")},
+						Type: "static",
+						IPs:  []net.IP{net.ParseIP("192.0.2.1"), net.ParseIP("2001:db8::1")},
 					},
 				},
 			},
@@ -306,6 +350,124 @@ func TestUpToDate(t *testing.T) {
 				o: true,
 			},
 		},
+		"UpToDateTrafficTypeHTTPToHTTPS": {
+			reason: "UpToDate should return false if the traffic type has changed from http to https",
+			args: args{
+				rp: &v1alpha1.ApplicationParameters{
+					Protocol:    "tcp/443",
+					TrafficType: ptr.StringPtr("https"),
+					TLS:         ptr.StringPtr("full"),
+				},
+				r: cloudflare.SpectrumApplication{
+					Protocol:    "tcp/443",
+					TrafficType: "http",
+					TLS:         "full",
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"UpToDateOriginDirectHostname": {
+			reason: "UpToDate should return true if hostname origin direct targets match regardless of case or order",
+			args: args{
+				rp: &v1alpha1.ApplicationParameters{
+					Protocol:     "tcp/22",
+					OriginDirect: []string{"tcp://Host.Example.com:22", "tcp://192.0.2.1:22"},
+				},
+				r: cloudflare.SpectrumApplication{
+					Protocol:     "tcp/22",
+					OriginDirect: []string{"tcp://192.0.2.1:22", "tcp://host.example.com:22"},
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"NotUpToDateOriginDirectHostname": {
+			reason: "UpToDate should return false if hostname origin direct targets differ",
+			args: args{
+				rp: &v1alpha1.ApplicationParameters{
+					Protocol:     "tcp/22",
+					OriginDirect: []string{"tcp://host.example.com:22"},
+				},
+				r: cloudflare.SpectrumApplication{
+					Protocol:     "tcp/22",
+					OriginDirect: []string{"tcp://other.example.com:22"},
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"NotUpToDateOriginDirectHostnamePort": {
+			reason: "UpToDate should return false if hostname origin direct target ports differ",
+			args: args{
+				rp: &v1alpha1.ApplicationParameters{
+					Protocol:     "tcp/22",
+					OriginDirect: []string{"tcp://host.example.com:22"},
+				},
+				r: cloudflare.SpectrumApplication{
+					Protocol:     "tcp/22",
+					OriginDirect: []string{"tcp://host.example.com:2222"},
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"UpToDateDNSNameCase": {
+			reason: "UpToDate should return true if DNS names only differ in case",
+			args: args{
+				rp: &v1alpha1.ApplicationParameters{
+					DNS: v1alpha1.SpectrumApplicationDNS{Type: "CNAME", Name: "SSH.example.com"},
+				},
+				r: cloudflare.SpectrumApplication{
+					DNS: cloudflare.SpectrumApplicationDNS{Type: "CNAME", Name: "ssh.example.com"},
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"UpToDateOriginDNSNameCase": {
+			reason: "UpToDate should return true if Origin DNS names only differ in case",
+			args: args{
+				rp: &v1alpha1.ApplicationParameters{
+					OriginDNS: &v1alpha1.SpectrumApplicationOriginDNS{Name: "Origin.example.com"},
+				},
+				r: cloudflare.SpectrumApplication{
+					OriginDNS: &cloudflare.SpectrumApplicationOriginDNS{Name: "origin.example.com"},
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"NotUpToDateNoOriginDNS": {
+			reason: "UpToDate should return false and not panic if Origin DNS is not observed",
+			args: args{
+				rp: &v1alpha1.ApplicationParameters{
+					OriginDNS: &v1alpha1.SpectrumApplicationOriginDNS{Name: "origin.example.com"},
+				},
+				r: cloudflare.SpectrumApplication{},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"NotUpToDateArgoSmartRouting": {
+			reason: "UpToDate should return false if Argo Smart Routing differs",
+			args: args{
+				rp: &v1alpha1.ApplicationParameters{
+					ArgoSmartRouting: ptr.BoolPtr(true),
+				},
+				r: cloudflare.SpectrumApplication{},
+			},
+			want: want{
+				o: false,
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -358,7 +520,8 @@ func TestUpdateSpectrumApplication(t *testing.T) {
 			args: args{
 				id: "1234",
 				ap: &v1alpha1.ApplicationParameters{
-					Zone: ptr.StringPtr("test"),
+					Protocol: "tcp/22",
+					Zone:     ptr.StringPtr(zoneID),
 				},
 			},
 			want: want{
@@ -382,7 +545,8 @@ func TestUpdateSpectrumApplication(t *testing.T) {
 			args: args{
 				id: "1234",
 				ap: &v1alpha1.ApplicationParameters{
-					Zone:       ptr.StringPtr("test"),
+					Protocol:   "tcp/22",
+					Zone:       ptr.StringPtr(zoneID),
 					OriginPort: &v1alpha1.SpectrumApplicationOriginPort{},
 					OriginDNS: &v1alpha1.SpectrumApplicationOriginDNS{
 						Name: "test.com",
@@ -400,10 +564,961 @@ func TestUpdateSpectrumApplication(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			got := UpdateSpectrumApplication(tc.args.ctx, tc.fields.client, tc.args.id, tc.args.ap)
+			_, got := UpdateSpectrumApplication(tc.args.ctx, tc.fields.client, tc.args.id, tc.args.ap)
 			if diff := cmp.Diff(tc.want.o, got); diff != "" {
 				t.Errorf("\n%s\nUpdateSpectrumApplication(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
+
+func TestUpdateSpectrumApplicationPayload(t *testing.T) {
+	spec := &v1alpha1.ApplicationParameters{
+		Protocol: "tcp/22",
+		Zone:     ptr.StringPtr(zoneID),
+		DNS: v1alpha1.SpectrumApplicationDNS{
+			Type: "CNAME",
+			Name: "spectrum.example.com",
+		},
+		OriginDirect: []string{"tcp://192.0.2.1:22"},
+		IPFirewall:   ptr.BoolPtr(true),
+		TLS:          ptr.StringPtr("off"),
+		TrafficType:  ptr.StringPtr("direct"),
+	}
+
+	var (
+		gotZoneID string
+		gotAppID  string
+		gotBody   map[string]interface{}
+	)
+	client := fake.MockClient{
+		MockUpdateSpectrumApplication: func(ctx context.Context, zoneID, appID string, appDetails cloudflare.SpectrumApplication) (cloudflare.SpectrumApplication, error) {
+			gotZoneID, gotAppID = zoneID, appID
+			b, err := json.Marshal(appDetails)
+			if err != nil {
+				return cloudflare.SpectrumApplication{}, err
+			}
+			if err := json.Unmarshal(b, &gotBody); err != nil {
+				return cloudflare.SpectrumApplication{}, err
+			}
+			return appDetails, nil
+		},
+	}
+
+	if _, err := UpdateSpectrumApplication(context.Background(), client, "1234", spec); err != nil {
+		t.Fatalf("UpdateSpectrumApplication(...): unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(zoneID, gotZoneID); diff != "" {
+		t.Errorf("UpdateSpectrumApplication(...): -want zone ID, +got zone ID:\n%s\n", diff)
+	}
+	if diff := cmp.Diff("1234", gotAppID); diff != "" {
+		t.Errorf("UpdateSpectrumApplication(...): -want application ID, +got application ID:\n%s\n", diff)
+	}
+
+	// Fields assigned by Cloudflare must not be sent in the body.
+	for _, k := range []string{"id", "created_on", "modified_on"} {
+		if _, ok := gotBody[k]; ok {
+			t.Errorf("UpdateSpectrumApplication(...): request body should not contain %q: %v", k, gotBody)
+		}
+	}
+
+	want := map[string]interface{}{
+		"protocol": "tcp/22",
+		"dns": map[string]interface{}{
+			"type": "CNAME",
+			"name": "spectrum.example.com",
+		},
+		"origin_direct": []interface{}{"tcp://192.0.2.1:22"},
+		"ip_firewall":   true,
+		"tls":           "off",
+		"traffic_type":  "direct",
+	}
+	if diff := cmp.Diff(want, gotBody); diff != "" {
+		t.Errorf("UpdateSpectrumApplication(...): -want request body, +got request body:\n%s\n", diff)
+	}
+}
+
+func TestUpdateSpectrumApplicationOriginDNSTTL(t *testing.T) {
+	ttl := int64(600)
+	spec := &v1alpha1.ApplicationParameters{
+		Protocol: "tcp/22",
+		Zone:     ptr.StringPtr(zoneID),
+		DNS: v1alpha1.SpectrumApplicationDNS{
+			Type: "CNAME",
+			Name: "spectrum.example.com",
+		},
+		OriginDNS: &v1alpha1.SpectrumApplicationOriginDNS{
+			Name: "origin.example.com",
+			TTL:  &ttl,
+		},
+		ArgoSmartRouting: ptr.BoolPtr(true),
+	}
+
+	var (
+		gotMethod   string
+		gotEndpoint string
+		gotBody     map[string]interface{}
+	)
+	client := fake.MockClient{
+		MockRawContext: func(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+			gotMethod, gotEndpoint = method, endpoint
+			b, err := json.Marshal(data)
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(b, &gotBody); err != nil {
+				return nil, err
+			}
+			return json.RawMessage(`{"id":"1234","protocol":"tcp/22"}`), nil
+		},
+	}
+
+	got, err := UpdateSpectrumApplication(context.Background(), client, "1234", spec)
+	if err != nil {
+		t.Fatalf("UpdateSpectrumApplication(...): unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(cloudflare.SpectrumApplication{ID: "1234", Protocol: "tcp/22"}, got); diff != "" {
+		t.Errorf("UpdateSpectrumApplication(...): -want, +got:\n%s\n", diff)
+	}
+	if diff := cmp.Diff("PUT", gotMethod); diff != "" {
+		t.Errorf("UpdateSpectrumApplication(...): -want method, +got method:\n%s\n", diff)
+	}
+	if diff := cmp.Diff("/zones/"+zoneID+"/spectrum/apps/1234", gotEndpoint); diff != "" {
+		t.Errorf("UpdateSpectrumApplication(...): -want endpoint, +got endpoint:\n%s\n", diff)
+	}
+
+	want := map[string]interface{}{
+		"protocol": "tcp/22",
+		"dns": map[string]interface{}{
+			"type": "CNAME",
+			"name": "spectrum.example.com",
+		},
+		"origin_dns": map[string]interface{}{
+			"name": "origin.example.com",
+			"ttl":  float64(600),
+		},
+		"argo_smart_routing": true,
+	}
+	if diff := cmp.Diff(want, gotBody); diff != "" {
+		t.Errorf("UpdateSpectrumApplication(...): -want request body, +got request body:\n%s\n", diff)
+	}
+}
+
+func TestGetSpectrumApplication(t *testing.T) {
+	errBoom := errors.New("boom")
+	ttl := int64(600)
+
+	type want struct {
+		ap  cloudflare.SpectrumApplication
+		ttl *int64
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		client Client
+		spec   *v1alpha1.ApplicationParameters
+		want   want
+	}{
+		"NoTTL": {
+			reason: "The application should be looked up without its TTL if the parameters do not set one",
+			client: fake.MockClient{
+				MockSpectrumApplication: func(ctx context.Context, zoneID, applicationID string) (cloudflare.SpectrumApplication, error) {
+					return cloudflare.SpectrumApplication{ID: applicationID}, nil
+				},
+			},
+			spec: &v1alpha1.ApplicationParameters{},
+			want: want{
+				ap: cloudflare.SpectrumApplication{ID: "1234"},
+			},
+		},
+		"TTL": {
+			reason: "The application and its TTL should be decoded from a single raw request if the parameters set a TTL",
+			client: fake.MockClient{
+				MockRawContext: func(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+					return json.RawMessage(`{"id":"1234","protocol":"tcp/22","origin_dns":{"name":"origin.example.com","ttl":900}}`), nil
+				},
+			},
+			spec: &v1alpha1.ApplicationParameters{
+				OriginDNS: &v1alpha1.SpectrumApplicationOriginDNS{Name: "origin.example.com", TTL: &ttl},
+			},
+			want: want{
+				ap: cloudflare.SpectrumApplication{
+					ID:        "1234",
+					Protocol:  "tcp/22",
+					OriginDNS: &cloudflare.SpectrumApplicationOriginDNS{Name: "origin.example.com"},
+				},
+				ttl: ptr.Int64Ptr(900),
+			},
+		},
+		"TTLError": {
+			reason: "Errors looking up the application with its TTL should be returned",
+			client: fake.MockClient{
+				MockRawContext: func(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+					return nil, errBoom
+				},
+			},
+			spec: &v1alpha1.ApplicationParameters{
+				OriginDNS: &v1alpha1.SpectrumApplicationOriginDNS{Name: "origin.example.com", TTL: &ttl},
+			},
+			want: want{
+				err: errBoom,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ap, ttl, err := GetSpectrumApplication(context.Background(), tc.client, zoneID, "1234", tc.spec)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGetSpectrumApplication(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ap, ap); diff != "" {
+				t.Errorf("\n%s\nGetSpectrumApplication(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ttl, ttl); diff != "" {
+				t.Errorf("\n%s\nGetSpectrumApplication(...): -want TTL, +got TTL:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestParseOriginDNSTTL(t *testing.T) {
+	ttl := int64(900)
+
+	cases := map[string]struct {
+		reason string
+		raw    json.RawMessage
+		want   *int64
+	}{
+		"NoOriginDNS": {
+			reason: "A nil TTL should be returned without an Origin DNS",
+			raw:    json.RawMessage(`{"id":"1234"}`),
+		},
+		"NoTTL": {
+			reason: "A nil TTL should be returned if the Origin DNS has no TTL",
+			raw:    json.RawMessage(`{"origin_dns":{"name":"origin.example.com"}}`),
+		},
+		"TTL": {
+			reason: "The TTL of the Origin DNS should be returned",
+			raw:    json.RawMessage(`{"origin_dns":{"name":"origin.example.com","ttl":900}}`),
+			want:   &ttl,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseOriginDNSTTL(tc.raw)
+			if err != nil {
+				t.Fatalf("\n%s\nParseOriginDNSTTL(...): unexpected error: %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nParseOriginDNSTTL(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestOriginDNSTTLUpToDate(t *testing.T) {
+	ttl := int64(600)
+	other := int64(900)
+
+	cases := map[string]struct {
+		reason string
+		spec   *v1alpha1.ApplicationParameters
+		ttl    *int64
+		want   bool
+	}{
+		"Unmanaged": {
+			reason: "The TTL should be up to date if it is not set",
+			spec:   &v1alpha1.ApplicationParameters{OriginDNS: &v1alpha1.SpectrumApplicationOriginDNS{Name: "origin.example.com"}},
+			ttl:    &other,
+			want:   true,
+		},
+		"Matching": {
+			reason: "The TTL should be up to date if it matches",
+			spec:   &v1alpha1.ApplicationParameters{OriginDNS: &v1alpha1.SpectrumApplicationOriginDNS{Name: "origin.example.com", TTL: &ttl}},
+			ttl:    &ttl,
+			want:   true,
+		},
+		"Different": {
+			reason: "The TTL should not be up to date if it differs",
+			spec:   &v1alpha1.ApplicationParameters{OriginDNS: &v1alpha1.SpectrumApplicationOriginDNS{Name: "origin.example.com", TTL: &ttl}},
+			ttl:    &other,
+			want:   false,
+		},
+		"NotObserved": {
+			reason: "The TTL should not be up to date if it is not observed",
+			spec:   &v1alpha1.ApplicationParameters{OriginDNS: &v1alpha1.SpectrumApplicationOriginDNS{Name: "origin.example.com", TTL: &ttl}},
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := OriginDNSTTLUpToDate(tc.spec, tc.ttl)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nOriginDNSTTLUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeArgoSmartRouting(t *testing.T) {
+	spec := &v1alpha1.ApplicationParameters{}
+	if !LateInitialize(spec, cloudflare.SpectrumApplication{ArgoSmartRouting: true}) {
+		t.Fatalf("LateInitialize(...): expected enabled Argo Smart Routing to be late initialized")
+	}
+	if diff := cmp.Diff(ptr.BoolPtr(true), spec.ArgoSmartRouting); diff != "" {
+		t.Errorf("LateInitialize(...): -want, +got:\n%s\n", diff)
+	}
+
+	spec = &v1alpha1.ApplicationParameters{}
+	if LateInitialize(spec, cloudflare.SpectrumApplication{}) {
+		t.Errorf("LateInitialize(...): disabled Argo Smart Routing should not be late initialized")
+	}
+}
+
+func TestIPFirewallToggle(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		observed bool
+		desired  bool
+	}{
+		"Enable": {
+			reason:   "Enabling IPFirewall on an existing application should be detected and applied",
+			observed: false,
+			desired:  true,
+		},
+		"Disable": {
+			reason:   "Disabling IPFirewall on an existing application should be detected and applied",
+			observed: true,
+			desired:  false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			spec := &v1alpha1.ApplicationParameters{
+				Protocol:   "tcp/22",
+				Zone:       ptr.StringPtr(zoneID),
+				IPFirewall: ptr.BoolPtr(tc.desired),
+			}
+			observed := cloudflare.SpectrumApplication{
+				ID:         "1234",
+				Protocol:   "tcp/22",
+				IPFirewall: tc.observed,
+			}
+
+			if UpToDate(spec, observed) {
+				t.Fatalf("\n%s\nUpToDate(...): want false when IPFirewall differs, got true\n", tc.reason)
+			}
+
+			client := fake.MockClient{
+				MockUpdateSpectrumApplication: func(ctx context.Context, zoneID, appID string, appDetails cloudflare.SpectrumApplication) (cloudflare.SpectrumApplication, error) {
+					appDetails.ID = appID
+					return appDetails, nil
+				},
+			}
+
+			updated, err := UpdateSpectrumApplication(context.Background(), client, observed.ID, spec)
+			if err != nil {
+				t.Fatalf("\n%s\nUpdateSpectrumApplication(...): unexpected error: %s\n", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.desired, updated.IPFirewall); diff != "" {
+				t.Errorf("\n%s\nUpdateSpectrumApplication(...): -want IPFirewall, +got IPFirewall:\n%s\n", tc.reason, diff)
+			}
+			if !UpToDate(spec, updated) {
+				t.Errorf("\n%s\nUpToDate(...): want true after IPFirewall was applied, got false\n", tc.reason)
+			}
+		})
+	}
+}
+
+func TestValidateOriginPort(t *testing.T) {
+	zero := uint32(0)
+	port := uint32(22)
+	low := uint32(1000)
+	high := uint32(2000)
+	outOfBounds := uint32(65536)
+
+	type args struct {
+		op *v1alpha1.SpectrumApplicationOriginPort
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ValidNil": {
+			reason: "ValidateOriginPort should return no error when no origin port is set",
+			args:   args{},
+			want:   want{},
+		},
+		"ValidPort": {
+			reason: "ValidateOriginPort should return no error for a port within bounds",
+			args: args{
+				op: &v1alpha1.SpectrumApplicationOriginPort{
+					Port: &port,
+				},
+			},
+			want: want{},
+		},
+		"ValidRange": {
+			reason: "ValidateOriginPort should return no error for a range within bounds",
+			args: args{
+				op: &v1alpha1.SpectrumApplicationOriginPort{
+					Start: &low,
+					End:   &high,
+				},
+			},
+			want: want{},
+		},
+		"InvalidPortZero": {
+			reason: "ValidateOriginPort should return an error for a port of zero",
+			args: args{
+				op: &v1alpha1.SpectrumApplicationOriginPort{
+					Port: &zero,
+				},
+			},
+			want: want{
+				err: fmt.Errorf(errApplicationOriginPortBounds, 0, minOriginPort, maxOriginPort),
+			},
+		},
+		"InvalidRangeEndOutOfBounds": {
+			reason: "ValidateOriginPort should return an error when the end of a range is out of bounds",
+			args: args{
+				op: &v1alpha1.SpectrumApplicationOriginPort{
+					Start: &low,
+					End:   &outOfBounds,
+				},
+			},
+			want: want{
+				err: fmt.Errorf(errApplicationOriginPortBounds, 65536, minOriginPort, maxOriginPort),
+			},
+		},
+		"InvalidRangeReversed": {
+			reason: "ValidateOriginPort should return an error when start is higher than end",
+			args: args{
+				op: &v1alpha1.SpectrumApplicationOriginPort{
+					Start: &high,
+					End:   &low,
+				},
+			},
+			want: want{
+				err: fmt.Errorf(errApplicationOriginPortRange, 2000, 1000),
+			},
+		},
+		"InvalidRangeEqual": {
+			reason: "ValidateOriginPort should return an error when start is equal to end",
+			args: args{
+				op: &v1alpha1.SpectrumApplicationOriginPort{
+					Start: &low,
+					End:   &low,
+				},
+			},
+			want: want{
+				err: fmt.Errorf(errApplicationOriginPortRange, 1000, 1000),
+			},
+		},
+		"InvalidPortAndRange": {
+			reason: "ValidateOriginPort should return an error when both a port and a range are set",
+			args: args{
+				op: &v1alpha1.SpectrumApplicationOriginPort{
+					Port:  &port,
+					Start: &low,
+					End:   &high,
+				},
+			},
+			want: want{
+				err: errors.New(errApplicationOriginPortExclusive),
+			},
+		},
+		"InvalidPortAndStart": {
+			reason: "ValidateOriginPort should return an error when both a port and the start of a range are set",
+			args: args{
+				op: &v1alpha1.SpectrumApplicationOriginPort{
+					Port:  &port,
+					Start: &low,
+				},
+			},
+			want: want{
+				err: errors.New(errApplicationOriginPortExclusive),
+			},
+		},
+		"InvalidRangeIncomplete": {
+			reason: "ValidateOriginPort should return an error when only one end of a range is set",
+			args: args{
+				op: &v1alpha1.SpectrumApplicationOriginPort{
+					Start: &low,
+				},
+			},
+			want: want{
+				err: fmt.Errorf(errApplicationOriginPortIncomplete),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateOriginPort(tc.args.op)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidateOriginPort(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidateTrafficType(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		tt     *string
+		want   error
+	}{
+		"ValidNil": {
+			reason: "ValidateTrafficType should return no error when no traffic type is set",
+		},
+		"ValidDirect": {
+			reason: "ValidateTrafficType should return no error for a direct traffic type",
+			tt:     ptr.StringPtr("direct"),
+		},
+		"ValidHTTPS": {
+			reason: "ValidateTrafficType should return no error for an https traffic type",
+			tt:     ptr.StringPtr("https"),
+		},
+		"InvalidTrafficType": {
+			reason: "ValidateTrafficType should return an error for an unsupported traffic type",
+			tt:     ptr.StringPtr("HTTP"),
+			want:   fmt.Errorf(errApplicationInvalidTrafficType, "HTTP"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateTrafficType(tc.tt)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidateTrafficType(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidateTrafficTypeTLS(t *testing.T) {
+	type args struct {
+		tt  *string
+		tls *string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   error
+	}{
+		"ValidNil": {
+			reason: "ValidateTrafficTypeTLS should return no error when neither is set",
+		},
+		"ValidHTTPSFull": {
+			reason: "ValidateTrafficTypeTLS should return no error for https traffic with TLS",
+			args:   args{tt: ptr.StringPtr("https"), tls: ptr.StringPtr("full")},
+		},
+		"ValidHTTPSUnset": {
+			reason: "ValidateTrafficTypeTLS should return no error for https traffic when TLS is not managed",
+			args:   args{tt: ptr.StringPtr("https")},
+		},
+		"ValidHTTPOff": {
+			reason: "ValidateTrafficTypeTLS should return no error for http traffic without TLS",
+			args:   args{tt: ptr.StringPtr("http"), tls: ptr.StringPtr("off")},
+		},
+		"InvalidHTTPSOff": {
+			reason: "ValidateTrafficTypeTLS should return an error for https traffic without TLS",
+			args:   args{tt: ptr.StringPtr("https"), tls: ptr.StringPtr("off")},
+			want:   fmt.Errorf(errApplicationHTTPSTLSOff),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateTrafficTypeTLS(tc.args.tt, tc.args.tls)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidateTrafficTypeTLS(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidateProxyProtocol(t *testing.T) {
+	dynamicIPv6 := &v1alpha1.SpectrumApplicationEdgeIPs{
+		Type:         string(cloudflare.SpectrumEdgeTypeDynamic),
+		Connectivity: ptr.StringPtr(string(cloudflare.SpectrumConnectivityIPv6)),
+	}
+	static := &v1alpha1.SpectrumApplicationEdgeIPs{
+		Type: string(cloudflare.SpectrumEdgeTypeStatic),
+		IPs:  []string{"192.0.2.1", "2001:db8::1"},
+	}
+	odns := &v1alpha1.SpectrumApplicationOriginDNS{Name: "origin.example.com"}
+
+	cases := map[string]struct {
+		reason string
+		spec   *v1alpha1.ApplicationParameters
+		want   error
+	}{
+		"ValidNil": {
+			reason: "ValidateProxyProtocol should return no error when no proxy protocol is set",
+			spec:   &v1alpha1.ApplicationParameters{Protocol: "tcp/22", TrafficType: ptr.StringPtr("https")},
+		},
+		"ValidOffHTTPS": {
+			reason: "ValidateProxyProtocol should return no error when the proxy protocol is off for https traffic",
+			spec:   &v1alpha1.ApplicationParameters{ProxyProtocol: ptr.StringPtr("off"), Protocol: "tcp/443", TrafficType: ptr.StringPtr("https")},
+		},
+		"ValidOffNoOrigin": {
+			reason: "ValidateProxyProtocol should return no error when the proxy protocol is off without an origin",
+			spec:   &v1alpha1.ApplicationParameters{ProxyProtocol: ptr.StringPtr("off"), Protocol: "tcp/22"},
+		},
+		"ValidV1TCPOriginDirect": {
+			reason: "ValidateProxyProtocol should return no error for v1 with a TCP application and a TCP origin direct",
+			spec:   &v1alpha1.ApplicationParameters{ProxyProtocol: ptr.StringPtr("v1"), Protocol: "tcp/22", TrafficType: ptr.StringPtr("direct"), OriginDirect: []string{"tcp://192.0.2.1:22"}},
+		},
+		"ValidV1StaticEdgeIPs": {
+			reason: "ValidateProxyProtocol should return no error for v1 with static edge IPs of both IP versions",
+			spec:   &v1alpha1.ApplicationParameters{ProxyProtocol: ptr.StringPtr("v1"), Protocol: "tcp/22", EdgeIPs: static, OriginDNS: odns},
+		},
+		"ValidV2UDPOriginDNS": {
+			reason: "ValidateProxyProtocol should return no error for v2 with a UDP application and an origin DNS",
+			spec:   &v1alpha1.ApplicationParameters{ProxyProtocol: ptr.StringPtr("v2"), Protocol: "udp/53", OriginDNS: odns},
+		},
+		"ValidV2DynamicEdgeIPs": {
+			reason: "ValidateProxyProtocol should return no error for v2 with dynamic IPv6 edge IPs",
+			spec:   &v1alpha1.ApplicationParameters{ProxyProtocol: ptr.StringPtr("v2"), Protocol: "tcp/22", EdgeIPs: dynamicIPv6, OriginDirect: []string{"tcp://[2001:db8::2]:22"}},
+		},
+		"ValidV2MixedOriginDirect": {
+			reason: "ValidateProxyProtocol should return no error for v2 with TCP and UDP origin direct targets",
+			spec:   &v1alpha1.ApplicationParameters{ProxyProtocol: ptr.StringPtr("v2"), Protocol: "udp/53", OriginDirect: []string{"udp://192.0.2.1:53", "tcp://192.0.2.2:53"}},
+		},
+		"ValidSimpleUDP": {
+			reason: "ValidateProxyProtocol should return no error for simple with a UDP application and a UDP origin direct",
+			spec:   &v1alpha1.ApplicationParameters{ProxyProtocol: ptr.StringPtr("simple"), Protocol: "udp/1000-2000", TrafficType: ptr.StringPtr("direct"), OriginDirect: []string{"udp://192.0.2.1:1000"}},
+		},
+		"ValidSimpleDynamicEdgeIPs": {
+			reason: "ValidateProxyProtocol should return no error for simple with dynamic IPv6 edge IPs and an origin DNS",
+			spec:   &v1alpha1.ApplicationParameters{ProxyProtocol: ptr.StringPtr("simple"), Protocol: "udp/53", EdgeIPs: dynamicIPv6, OriginDNS: odns},
+		},
+		"InvalidProxyProtocol": {
+			reason: "ValidateProxyProtocol should return an error for an unsupported proxy protocol",
+			spec:   &v1alpha1.ApplicationParameters{ProxyProtocol: ptr.StringPtr("v3"), Protocol: "tcp/22"},
+			want:   fmt.Errorf(errApplicationInvalidProxyProtocol, "v3"),
+		},
+		"InvalidV1UDP": {
+			reason: "ValidateProxyProtocol should return an error for v1 with a UDP application",
+			spec:   &v1alpha1.ApplicationParameters{ProxyProtocol: ptr.StringPtr("v1"), Protocol: "udp/53", OriginDNS: odns},
+			want:   fmt.Errorf(errApplicationProxyProtocolScheme, "v1", "udp"),
+		},
+		"InvalidSimpleTCP": {
+			reason: "ValidateProxyProtocol should return an error for simple with a TCP application",
+			spec:   &v1alpha1.ApplicationParameters{ProxyProtocol: ptr.StringPtr("simple"), Protocol: "tcp/22", OriginDNS: odns},
+			want:   fmt.Errorf(errApplicationProxyProtocolScheme, "simple", "tcp"),
+		},
+		"InvalidV2HTTP": {
+			reason: "ValidateProxyProtocol should return an error for v2 with http traffic",
+			spec:   &v1alpha1.ApplicationParameters{ProxyProtocol: ptr.StringPtr("v2"), Protocol: "tcp/80", TrafficType: ptr.StringPtr("http"), OriginDNS: odns},
+			want:   fmt.Errorf(errApplicationProxyProtocolTrafficType, "v2", "http"),
+		},
+		"InvalidV1HTTPS": {
+			reason: "ValidateProxyProtocol should return an error for v1 with https traffic",
+			spec:   &v1alpha1.ApplicationParameters{ProxyProtocol: ptr.StringPtr("v1"), Protocol: "tcp/443", TrafficType: ptr.StringPtr("https"), OriginDNS: odns},
+			want:   fmt.Errorf(errApplicationProxyProtocolTrafficType, "v1", "https"),
+		},
+		"InvalidV2NoOrigin": {
+			reason: "ValidateProxyProtocol should return an error for v2 without an origin to send its header to",
+			spec:   &v1alpha1.ApplicationParameters{ProxyProtocol: ptr.StringPtr("v2"), Protocol: "tcp/22", EdgeIPs: static},
+			want:   fmt.Errorf(errApplicationProxyProtocolNoOrigin, "v2"),
+		},
+		"InvalidSimpleNoOrigin": {
+			reason: "ValidateProxyProtocol should return an error for simple without an origin, whatever its edge IPs",
+			spec:   &v1alpha1.ApplicationParameters{ProxyProtocol: ptr.StringPtr("simple"), Protocol: "udp/53", EdgeIPs: dynamicIPv6},
+			want:   fmt.Errorf(errApplicationProxyProtocolNoOrigin, "simple"),
+		},
+		"InvalidV1UDPOriginDirect": {
+			reason: "ValidateProxyProtocol should return an error for v1 with a UDP origin direct",
+			spec:   &v1alpha1.ApplicationParameters{ProxyProtocol: ptr.StringPtr("v1"), Protocol: "tcp/53", OriginDirect: []string{"tcp://192.0.2.1:53", "udp://192.0.2.2:53"}},
+			want:   fmt.Errorf(errApplicationProxyProtocolOriginScheme, "v1", "udp", "udp://192.0.2.2:53"),
+		},
+		"InvalidSimpleTCPOriginDirect": {
+			reason: "ValidateProxyProtocol should return an error for simple with a TCP origin direct",
+			spec:   &v1alpha1.ApplicationParameters{ProxyProtocol: ptr.StringPtr("simple"), Protocol: "udp/53", EdgeIPs: static, OriginDirect: []string{"tcp://192.0.2.1:53"}},
+			want:   fmt.Errorf(errApplicationProxyProtocolOriginScheme, "simple", "tcp", "tcp://192.0.2.1:53"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateProxyProtocol(tc.spec)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidateProxyProtocol(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidateEdgeIPs(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		eips   *v1alpha1.SpectrumApplicationEdgeIPs
+		want   error
+	}{
+		"ValidNil": {
+			reason: "ValidateEdgeIPs should return no error when no edge IPs are set",
+		},
+		"ValidDynamic": {
+			reason: "ValidateEdgeIPs should return no error for a dynamic edge IPs type",
+			eips: &v1alpha1.SpectrumApplicationEdgeIPs{
+				Type: "dynamic",
+			},
+		},
+		"ValidStatic": {
+			reason: "ValidateEdgeIPs should return no error for a static edge IPs type",
+			eips: &v1alpha1.SpectrumApplicationEdgeIPs{
+				Type: "static",
+				IPs:  []string{"192.0.2.1"},
+			},
+		},
+		"InvalidEmpty": {
+			reason: "ValidateEdgeIPs should return an error when the edge IPs type is not set",
+			eips: &v1alpha1.SpectrumApplicationEdgeIPs{
+				IPs: []string{"192.0.2.1"},
+			},
+			want: fmt.Errorf(errApplicationInvalidEdgeIPsType, ""),
+		},
+		"InvalidEdgeIPsType": {
+			reason: "ValidateEdgeIPs should return an error for an unsupported edge IPs type",
+			eips: &v1alpha1.SpectrumApplicationEdgeIPs{
+				Type: "anycast",
+			},
+			want: fmt.Errorf(errApplicationInvalidEdgeIPsType, "anycast"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateEdgeIPs(tc.eips)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidateEdgeIPs(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidateCreateEdgeIPs(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		eips   *v1alpha1.SpectrumApplicationEdgeIPs
+		want   error
+	}{
+		"ValidNil": {
+			reason: "ValidateCreateEdgeIPs should return no error when no edge IPs are set",
+		},
+		"ValidDynamicNoIPs": {
+			reason: "ValidateCreateEdgeIPs should return no error for dynamic edge IPs without IPs",
+			eips: &v1alpha1.SpectrumApplicationEdgeIPs{
+				Type:         "dynamic",
+				Connectivity: ptr.StringPtr("all"),
+			},
+		},
+		"ValidStatic": {
+			reason: "ValidateCreateEdgeIPs should return no error for static edge IPs without connectivity",
+			eips: &v1alpha1.SpectrumApplicationEdgeIPs{
+				Type: "static",
+				IPs:  []string{"192.0.2.1"},
+			},
+		},
+		"InvalidDynamicNoConnectivity": {
+			reason: "ValidateCreateEdgeIPs should return an error for dynamic edge IPs without connectivity",
+			eips: &v1alpha1.SpectrumApplicationEdgeIPs{
+				Type: "dynamic",
+			},
+			want: fmt.Errorf(errApplicationEdgeIPsNoConnectivity),
+		},
+		"InvalidDynamicConnectivity": {
+			reason: "ValidateCreateEdgeIPs should return an error for unsupported dynamic edge IPs connectivity",
+			eips: &v1alpha1.SpectrumApplicationEdgeIPs{
+				Type:         "dynamic",
+				Connectivity: ptr.StringPtr("static"),
+			},
+			want: fmt.Errorf(errApplicationInvalidConnectivity, "static"),
+		},
+		"InvalidEdgeIPsType": {
+			reason: "ValidateCreateEdgeIPs should return an error for an unsupported edge IPs type",
+			eips: &v1alpha1.SpectrumApplicationEdgeIPs{
+				Type: "anycast",
+			},
+			want: fmt.Errorf(errApplicationInvalidEdgeIPsType, "anycast"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateCreateEdgeIPs(tc.eips)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidateCreateEdgeIPs(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidateOriginDirect(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		od     []string
+		want   error
+	}{
+		"ValidNil": {
+			reason: "ValidateOriginDirect should return no error when no targets are set",
+		},
+		"ValidIP": {
+			reason: "ValidateOriginDirect should return no error for an IP target",
+			od:     []string{"tcp://192.0.2.1:22", "udp://[2001:db8::1]:53"},
+		},
+		"ValidHostname": {
+			reason: "ValidateOriginDirect should return no error for a hostname target",
+			od:     []string{"tcp://host.example.com:22"},
+		},
+		"InvalidNoScheme": {
+			reason: "ValidateOriginDirect should return an error for a target without a scheme",
+			od:     []string{"host.example.com:22"},
+			want:   fmt.Errorf(errApplicationInvalidOriginDirect, "host.example.com:22"),
+		},
+		"InvalidNoPort": {
+			reason: "ValidateOriginDirect should return an error for a target without a port",
+			od:     []string{"tcp://host.example.com"},
+			want:   fmt.Errorf(errApplicationInvalidOriginDirect, "tcp://host.example.com"),
+		},
+		"InvalidPath": {
+			reason: "ValidateOriginDirect should return an error for a target with a path",
+			od:     []string{"tcp://host.example.com:22/ssh"},
+			want:   fmt.Errorf(errApplicationInvalidOriginDirect, "tcp://host.example.com:22/ssh"),
+		},
+		"InvalidScheme": {
+			reason: "ValidateOriginDirect should return an error for an unsupported scheme",
+			od:     []string{"http://host.example.com:80"},
+			want:   fmt.Errorf(errApplicationInvalidOriginDirectScheme, "http"),
+		},
+		"InvalidPortTooHigh": {
+			reason: "ValidateOriginDirect should return an error for a port above the upper bound",
+			od:     []string{"tcp://192.0.2.1:22", "tcp://host.example.com:65536"},
+			want:   fmt.Errorf(errApplicationOriginDirectPortBounds, "65536", minOriginPort, maxOriginPort),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateOriginDirect(tc.od)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidateOriginDirect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidateZone(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		zone   string
+		want   error
+	}{
+		"Valid": {
+			reason: "ValidateZone should return no error for a zone ID",
+			zone:   zoneID,
+		},
+		"InvalidDomainName": {
+			reason: "ValidateZone should return an error for the domain name of a zone",
+			zone:   "foo.com",
+			want:   fmt.Errorf(errApplicationInvalidZone, "foo.com"),
+		},
+		"InvalidUpperCase": {
+			reason: "ValidateZone should return an error for a zone ID that is not lower case",
+			zone:   "023E105F4ECEF8AD9CA31A8372D0C353",
+			want:   fmt.Errorf(errApplicationInvalidZone, "023E105F4ECEF8AD9CA31A8372D0C353"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateZone(tc.zone)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidateZone(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidateProtocol(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		p      string
+		want   error
+	}{
+		"ValidTCPPort": {
+			reason: "ValidateProtocol should return no error for a tcp port",
+			p:      "tcp/22",
+		},
+		"ValidUDPPortRange": {
+			reason: "ValidateProtocol should return no error for a udp port range",
+			p:      "udp/1000-2000",
+		},
+		"InvalidEmpty": {
+			reason: "ValidateProtocol should return an error when no protocol is set",
+			p:      "",
+			want:   fmt.Errorf(errApplicationInvalidProtocol, ""),
+		},
+		"InvalidNoPort": {
+			reason: "ValidateProtocol should return an error when no port is set",
+			p:      "tcp",
+			want:   fmt.Errorf(errApplicationInvalidProtocol, "tcp"),
+		},
+		"InvalidScheme": {
+			reason: "ValidateProtocol should return an error for an unsupported scheme",
+			p:      "http/80",
+			want:   fmt.Errorf(errApplicationInvalidProtocolScheme, "http"),
+		},
+		"InvalidPortZero": {
+			reason: "ValidateProtocol should return an error for a port below the lower bound",
+			p:      "tcp/0",
+			want:   fmt.Errorf(errApplicationProtocolPortBounds, "0", minOriginPort, maxOriginPort),
+		},
+		"InvalidPortTooHigh": {
+			reason: "ValidateProtocol should return an error for a port above the upper bound",
+			p:      "udp/1000-65536",
+			want:   fmt.Errorf(errApplicationProtocolPortBounds, "65536", minOriginPort, maxOriginPort),
+		},
+		"InvalidPortNotNumber": {
+			reason: "ValidateProtocol should return an error for a port that is not a number",
+			p:      "tcp/ssh",
+			want:   fmt.Errorf(errApplicationProtocolPortBounds, "ssh", minOriginPort, maxOriginPort),
+		},
+		"InvalidPortRange": {
+			reason: "ValidateProtocol should return an error when the port range start is not lower than the end",
+			p:      "tcp/2000-1000",
+			want:   fmt.Errorf(errApplicationProtocolPortRange, 2000, 1000),
+		},
+		"InvalidPortRangeParts": {
+			reason: "ValidateProtocol should return an error for a port range with more than two ports",
+			p:      "tcp/1-2-3",
+			want:   fmt.Errorf(errApplicationInvalidProtocol, "tcp/1-2-3"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateProtocol(tc.p)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidateProtocol(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package fake
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/cloudflare/cloudflare-go"
 )
@@ -28,6 +29,7 @@ type MockClient struct {
 	MockSpectrumApplication       func(ctx context.Context, zoneID string, applicationID string) (cloudflare.SpectrumApplication, error)
 	MockUpdateSpectrumApplication func(ctx context.Context, zoneID, appID string, appDetails cloudflare.SpectrumApplication) (cloudflare.SpectrumApplication, error)
 	MockDeleteSpectrumApplication func(ctx context.Context, zoneID string, applicationID string) error
+	MockRawContext                func(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error)
 }
 
 // CreateSpectrumApplication mocks the CreateSpectrumApplication method of the Cloudflare API.
@@ -49,3 +51,8 @@ func (m MockClient) UpdateSpectrumApplication(ctx context.Context, zoneID, appID
 func (m MockClient) DeleteSpectrumApplication(ctx context.Context, zoneID string, applicationID string) error {
 	return m.MockDeleteSpectrumApplication(ctx, zoneID, applicationID)
 }
+
+// RawContext mocks the RawContext method of the Cloudflare API.
+func (m MockClient) RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+	return m.MockRawContext(ctx, method, endpoint, data)
+}
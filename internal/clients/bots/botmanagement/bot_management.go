@@ -0,0 +1,182 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package botmanagement
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/benagricola/provider-cloudflare/apis/bots/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+const (
+	errUpdateBotManagement = "error updating bot management"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with the bot protection of a Zone. Bot protection is managed using raw
+// API requests, as the cloudflare-go library does not support it.
+type Client interface {
+	RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error)
+}
+
+// NewClient returns a new Cloudflare API client for working with the bot
+// protection of a Zone.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// Settings are the bot protection settings of a Zone as represented by
+// the Cloudflare API. Cloudflare only returns the settings supported by
+// the plan of the Zone, so every field is optional.
+type Settings struct {
+	FightMode                *bool   `json:"fight_mode,omitempty"`
+	EnableJS                 *bool   `json:"enable_js,omitempty"`
+	DefinitelyAutomated      *string `json:"sbfm_definitely_automated,omitempty"`
+	LikelyAutomated          *string `json:"sbfm_likely_automated,omitempty"`
+	VerifiedBots             *string `json:"sbfm_verified_bots,omitempty"`
+	StaticResourceProtection *bool   `json:"sbfm_static_resource_protection,omitempty"`
+	OptimizeWordpress        *bool   `json:"optimize_wordpress,omitempty"`
+	AutoUpdateModel          *bool   `json:"auto_update_model,omitempty"`
+	SuppressSessionScore     *bool   `json:"suppress_session_score,omitempty"`
+	UsingLatestModel         *bool   `json:"using_latest_model,omitempty"`
+}
+
+func endpoint(zoneID string) string {
+	return "/zones/" + zoneID + "/bot_management"
+}
+
+// BotManagement returns the bot protection settings of the Zone with the
+// passed ID.
+func BotManagement(ctx context.Context, client Client, zoneID string) (*Settings, error) {
+	raw, err := client.RawContext(ctx, http.MethodGet, endpoint(zoneID), nil)
+	if err != nil {
+		return nil, err
+	}
+	s := &Settings{}
+	if err := json.Unmarshal(raw, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Product returns the product protecting a Zone from bots, based on the
+// settings Cloudflare returns for the plan of the Zone.
+func Product(s Settings) string {
+	switch {
+	case s.AutoUpdateModel != nil:
+		return v1alpha1.ProductBotManagement
+	case s.DefinitelyAutomated != nil:
+		return v1alpha1.ProductSuperBotFightMode
+	default:
+		return v1alpha1.ProductBotFightMode
+	}
+}
+
+// Capabilities returns the fields of a BotManagement that are supported
+// by the plan of a Zone, based on the settings Cloudflare returns.
+func Capabilities(s Settings) []string {
+	var c []string
+	for _, f := range []struct {
+		name      string
+		supported bool
+	}{
+		{"fightMode", s.FightMode != nil},
+		{"enableJS", s.EnableJS != nil},
+		{"definitelyAutomated", s.DefinitelyAutomated != nil},
+		{"likelyAutomated", s.LikelyAutomated != nil},
+		{"verifiedBots", s.VerifiedBots != nil},
+		{"staticResourceProtection", s.StaticResourceProtection != nil},
+		{"optimizeWordpress", s.OptimizeWordpress != nil},
+		{"autoUpdateModel", s.AutoUpdateModel != nil},
+		{"suppressSessionScore", s.SuppressSessionScore != nil},
+	} {
+		if f.supported {
+			c = append(c, f.name)
+		}
+	}
+	return c
+}
+
+// GenerateObservation creates an observation of the bot protection of a
+// Zone.
+func GenerateObservation(in Settings) v1alpha1.BotManagementObservation {
+	o := v1alpha1.BotManagementObservation{
+		Product:      Product(in),
+		Capabilities: Capabilities(in),
+	}
+	if in.UsingLatestModel != nil {
+		o.UsingLatestModel = *in.UsingLatestModel
+	}
+	return o
+}
+
+// NewSettings returns the bot protection settings of a Zone as
+// represented by the Cloudflare API, from the requested resource
+// parameters. Only the settings set in the parameters are included.
+func NewSettings(spec v1alpha1.BotManagementParameters) Settings {
+	return Settings{
+		FightMode:                spec.FightMode,
+		EnableJS:                 spec.EnableJS,
+		DefinitelyAutomated:      spec.DefinitelyAutomated,
+		LikelyAutomated:          spec.LikelyAutomated,
+		VerifiedBots:             spec.VerifiedBots,
+		StaticResourceProtection: spec.StaticResourceProtection,
+		OptimizeWordpress:        spec.OptimizeWordpress,
+		AutoUpdateModel:          spec.AutoUpdateModel,
+		SuppressSessionScore:     spec.SuppressSessionScore,
+	}
+}
+
+func boolUpToDate(want, got *bool) bool {
+	return want == nil || (got != nil && *want == *got)
+}
+
+func stringUpToDate(want, got *string) bool {
+	return want == nil || (got != nil && *want == *got)
+}
+
+// UpToDate checks if the remote bot protection settings are up to date
+// with the requested resource parameters. Settings that are not set in
+// the parameters are not managed.
+func UpToDate(spec *v1alpha1.BotManagementParameters, s Settings) bool {
+	if spec == nil {
+		return true
+	}
+
+	return boolUpToDate(spec.FightMode, s.FightMode) &&
+		boolUpToDate(spec.EnableJS, s.EnableJS) &&
+		stringUpToDate(spec.DefinitelyAutomated, s.DefinitelyAutomated) &&
+		stringUpToDate(spec.LikelyAutomated, s.LikelyAutomated) &&
+		stringUpToDate(spec.VerifiedBots, s.VerifiedBots) &&
+		boolUpToDate(spec.StaticResourceProtection, s.StaticResourceProtection) &&
+		boolUpToDate(spec.OptimizeWordpress, s.OptimizeWordpress) &&
+		boolUpToDate(spec.AutoUpdateModel, s.AutoUpdateModel) &&
+		boolUpToDate(spec.SuppressSessionScore, s.SuppressSessionScore)
+}
+
+// UpdateBotManagement updates the bot protection settings of the Zone
+// with the passed ID. Cloudflare rejects settings that are not supported
+// by the plan of the Zone.
+func UpdateBotManagement(ctx context.Context, client Client, zoneID string, spec v1alpha1.BotManagementParameters) error {
+	_, err := client.RawContext(ctx, http.MethodPut, endpoint(zoneID), NewSettings(spec))
+	return errors.Wrap(err, errUpdateBotManagement)
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package botmanagement
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	ptr "k8s.io/utils/pointer"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/bots/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/bots/botmanagement/fake"
+)
+
+const zoneID = "023e105f4ecef8ad9ca31a8372d0c353"
+
+func TestGenerateObservation(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		raw    string
+		want   v1alpha1.BotManagementObservation
+	}{
+		"BotFightMode": {
+			reason: "Free plan Zones should be observed as using Bot Fight Mode",
+			raw:    `{"enable_js":true,"fight_mode":false,"using_latest_model":true}`,
+			want: v1alpha1.BotManagementObservation{
+				Product:          v1alpha1.ProductBotFightMode,
+				Capabilities:     []string{"fightMode", "enableJS"},
+				UsingLatestModel: true,
+			},
+		},
+		"SuperBotFightMode": {
+			reason: "Business plan Zones should be observed as using Super Bot Fight Mode",
+			raw:    `{"enable_js":true,"sbfm_definitely_automated":"block","sbfm_likely_automated":"allow","sbfm_verified_bots":"allow","sbfm_static_resource_protection":false,"optimize_wordpress":true}`,
+			want: v1alpha1.BotManagementObservation{
+				Product:      v1alpha1.ProductSuperBotFightMode,
+				Capabilities: []string{"enableJS", "definitelyAutomated", "likelyAutomated", "verifiedBots", "staticResourceProtection", "optimizeWordpress"},
+			},
+		},
+		"BotManagement": {
+			reason: "Enterprise plan Zones should be observed as using Bot Management",
+			raw:    `{"enable_js":true,"auto_update_model":true,"suppress_session_score":false,"using_latest_model":true}`,
+			want: v1alpha1.BotManagementObservation{
+				Product:          v1alpha1.ProductBotManagement,
+				Capabilities:     []string{"enableJS", "autoUpdateModel", "suppressSessionScore"},
+				UsingLatestModel: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := Settings{}
+			if err := json.Unmarshal([]byte(tc.raw), &s); err != nil {
+				t.Fatalf("json.Unmarshal(...): %s", err)
+			}
+			got := GenerateObservation(s)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateObservation(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpToDate(t *testing.T) {
+	observed := Settings{
+		EnableJS:            ptr.BoolPtr(true),
+		DefinitelyAutomated: ptr.StringPtr("managed_challenge"),
+		VerifiedBots:        ptr.StringPtr("allow"),
+	}
+
+	cases := map[string]struct {
+		reason string
+		spec   *v1alpha1.BotManagementParameters
+		s      Settings
+		want   bool
+	}{
+		"NilSpec": {
+			reason: "A nil spec should always be up to date",
+			s:      observed,
+			want:   true,
+		},
+		"Unmanaged": {
+			reason: "Settings that are not requested should be ignored",
+			spec:   &v1alpha1.BotManagementParameters{EnableJS: ptr.BoolPtr(true)},
+			s:      observed,
+			want:   true,
+		},
+		"ActionChanged": {
+			reason: "A different action for definitely automated requests should not be up to date",
+			spec:   &v1alpha1.BotManagementParameters{DefinitelyAutomated: ptr.StringPtr("block")},
+			s:      observed,
+			want:   false,
+		},
+		"Unsupported": {
+			reason: "A requested setting that is not supported by the Zone should not be up to date",
+			spec:   &v1alpha1.BotManagementParameters{FightMode: ptr.BoolPtr(true)},
+			s:      observed,
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := UpToDate(tc.spec, tc.s)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdateBotManagement(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		err  error
+		data interface{}
+	}
+
+	cases := map[string]struct {
+		reason string
+		spec   v1alpha1.BotManagementParameters
+		err    error
+		want   want
+	}{
+		"UpdateFailed": {
+			reason: "Errors updating bot management should be wrapped",
+			spec:   v1alpha1.BotManagementParameters{FightMode: ptr.BoolPtr(true)},
+			err:    errBoom,
+			want: want{
+				err:  errors.Wrap(errBoom, errUpdateBotManagement),
+				data: Settings{FightMode: ptr.BoolPtr(true)},
+			},
+		},
+		"Success": {
+			reason: "Only the requested settings should be sent",
+			spec: v1alpha1.BotManagementParameters{
+				Zone:                     ptr.StringPtr(zoneID),
+				DefinitelyAutomated:      ptr.StringPtr("block"),
+				StaticResourceProtection: ptr.BoolPtr(false),
+			},
+			want: want{
+				data: Settings{
+					DefinitelyAutomated:      ptr.StringPtr("block"),
+					StaticResourceProtection: ptr.BoolPtr(false),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var data interface{}
+			client := fake.MockClient{
+				MockRawContext: func(_ context.Context, m, e string, d interface{}) (json.RawMessage, error) {
+					if m != http.MethodPut || e != "/zones/"+zoneID+"/bot_management" {
+						t.Errorf("\n%s\nUpdateBotManagement(...): unexpected request %s %s", tc.reason, m, e)
+					}
+					data = d
+					return json.RawMessage(`{}`), tc.err
+				},
+			}
+			err := UpdateBotManagement(context.Background(), client, zoneID, tc.spec)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdateBotManagement(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.data, data); diff != "" {
+				t.Errorf("\n%s\nUpdateBotManagement(...): -want data, +got data:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
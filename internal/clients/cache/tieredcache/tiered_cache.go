@@ -0,0 +1,153 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tieredcache
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/benagricola/provider-cloudflare/apis/cache/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+const (
+	// ValueOn is the value of an enabled setting.
+	ValueOn = "on"
+
+	// ValueOff is the value of a disabled setting.
+	ValueOff = "off"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with Tiered Cache. Tiered Cache is managed using raw API requests, as
+// the cloudflare-go library does not support it.
+type Client interface {
+	RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error)
+}
+
+// NewClient returns a new Cloudflare API client for working with Tiered
+// Cache.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// Setting is a Tiered Cache setting of a Zone as represented by the
+// Cloudflare API.
+type Setting struct {
+	ID    string `json:"id,omitempty"`
+	Value string `json:"value"`
+}
+
+func tieredCachingEndpoint(zoneID string) string {
+	return "/zones/" + zoneID + "/argo/tiered_caching"
+}
+
+func smartTopologyEndpoint(zoneID string) string {
+	return "/zones/" + zoneID + "/cache/tiered_cache_smart_topology_enable"
+}
+
+func getSetting(ctx context.Context, client Client, endpoint string) (*Setting, error) {
+	raw, err := client.RawContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	s := &Setting{}
+	if err := json.Unmarshal(raw, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func setSetting(ctx context.Context, client Client, endpoint string, on bool) error {
+	s := Setting{Value: ValueOff}
+	if on {
+		s.Value = ValueOn
+	}
+	_, err := client.RawContext(ctx, http.MethodPatch, endpoint, s)
+	return err
+}
+
+// TieredCaching returns the Tiered Cache setting of the Zone with the
+// passed ID.
+func TieredCaching(ctx context.Context, client Client, zoneID string) (*Setting, error) {
+	return getSetting(ctx, client, tieredCachingEndpoint(zoneID))
+}
+
+// SmartTopology returns the Smart Tiered Cache setting of the Zone with
+// the passed ID.
+func SmartTopology(ctx context.Context, client Client, zoneID string) (*Setting, error) {
+	return getSetting(ctx, client, smartTopologyEndpoint(zoneID))
+}
+
+// Topology returns the topology of the cache tiers of a Zone from its
+// Smart Tiered Cache setting.
+func Topology(smart Setting) string {
+	if smart.Value == ValueOn {
+		return v1alpha1.TopologySmart
+	}
+	return v1alpha1.TopologyGeneric
+}
+
+// GenerateObservation creates an observation of Tiered Cache on a Zone.
+func GenerateObservation(topology string) v1alpha1.TieredCacheObservation {
+	return v1alpha1.TieredCacheObservation{
+		Topology: topology,
+	}
+}
+
+// desiredTopology returns the requested topology, which defaults to
+// smart.
+func desiredTopology(spec v1alpha1.TieredCacheParameters) string {
+	if spec.Topology != nil {
+		return *spec.Topology
+	}
+	return v1alpha1.TopologySmart
+}
+
+// UpToDate checks if the remote topology of the cache tiers is up to date
+// with the requested resource parameters.
+func UpToDate(spec *v1alpha1.TieredCacheParameters, topology string) bool {
+	if spec == nil {
+		return true
+	}
+	return desiredTopology(*spec) == topology
+}
+
+// Enable enables Tiered Cache on the Zone with the passed ID, using the
+// requested topology.
+func Enable(ctx context.Context, client Client, zoneID string, spec v1alpha1.TieredCacheParameters) error {
+	if err := setSetting(ctx, client, tieredCachingEndpoint(zoneID), true); err != nil {
+		return err
+	}
+	return SetTopology(ctx, client, zoneID, spec)
+}
+
+// SetTopology enables or disables Smart Tiered Cache on the Zone with the
+// passed ID, according to the requested topology.
+func SetTopology(ctx context.Context, client Client, zoneID string, spec v1alpha1.TieredCacheParameters) error {
+	return setSetting(ctx, client, smartTopologyEndpoint(zoneID), desiredTopology(spec) == v1alpha1.TopologySmart)
+}
+
+// Disable disables Smart Tiered Cache and then Tiered Cache on the Zone
+// with the passed ID.
+func Disable(ctx context.Context, client Client, zoneID string) error {
+	if err := setSetting(ctx, client, smartTopologyEndpoint(zoneID), false); err != nil {
+		return err
+	}
+	return setSetting(ctx, client, tieredCachingEndpoint(zoneID), false)
+}
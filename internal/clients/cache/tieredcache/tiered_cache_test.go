@@ -0,0 +1,194 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tieredcache
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	ptr "k8s.io/utils/pointer"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/cache/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/cache/tieredcache/fake"
+)
+
+const zoneID = "023e105f4ecef8ad9ca31a8372d0c353"
+
+// request is a request sent to the mock Cloudflare API.
+type request struct {
+	Method   string
+	Endpoint string
+	Data     interface{}
+}
+
+func TestTopology(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		smart  Setting
+		want   string
+	}{
+		"Smart": {
+			reason: "Zones with Smart Tiered Cache enabled should use the smart topology",
+			smart:  Setting{ID: "tiered_cache_smart_topology_enable", Value: ValueOn},
+			want:   v1alpha1.TopologySmart,
+		},
+		"Generic": {
+			reason: "Zones with Smart Tiered Cache disabled should use the generic topology",
+			smart:  Setting{ID: "tiered_cache_smart_topology_enable", Value: ValueOff},
+			want:   v1alpha1.TopologyGeneric,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Topology(tc.smart)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nTopology(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		spec     *v1alpha1.TieredCacheParameters
+		topology string
+		want     bool
+	}{
+		"NilSpec": {
+			reason:   "A nil spec should always be up to date",
+			topology: v1alpha1.TopologyGeneric,
+			want:     true,
+		},
+		"DefaultTopology": {
+			reason:   "The smart topology should be up to date when no topology is requested",
+			spec:     &v1alpha1.TieredCacheParameters{},
+			topology: v1alpha1.TopologySmart,
+			want:     true,
+		},
+		"TopologyChanged": {
+			reason:   "A different topology should not be up to date",
+			spec:     &v1alpha1.TieredCacheParameters{Topology: ptr.StringPtr(v1alpha1.TopologyGeneric)},
+			topology: v1alpha1.TopologySmart,
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := UpToDate(tc.spec, tc.topology)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestEnable(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		err  error
+		reqs []request
+	}
+
+	cases := map[string]struct {
+		reason string
+		spec   v1alpha1.TieredCacheParameters
+		err    error
+		want   want
+	}{
+		"EnableFailed": {
+			reason: "Errors enabling Tiered Cache should be returned without changing the topology",
+			err:    errBoom,
+			want: want{
+				err: errBoom,
+				reqs: []request{
+					{Method: http.MethodPatch, Endpoint: "/zones/" + zoneID + "/argo/tiered_caching", Data: Setting{Value: ValueOn}},
+				},
+			},
+		},
+		"Smart": {
+			reason: "Tiered Cache should be enabled with Smart Tiered Cache by default",
+			want: want{
+				reqs: []request{
+					{Method: http.MethodPatch, Endpoint: "/zones/" + zoneID + "/argo/tiered_caching", Data: Setting{Value: ValueOn}},
+					{Method: http.MethodPatch, Endpoint: "/zones/" + zoneID + "/cache/tiered_cache_smart_topology_enable", Data: Setting{Value: ValueOn}},
+				},
+			},
+		},
+		"Generic": {
+			reason: "Tiered Cache should be enabled with Smart Tiered Cache disabled for the generic topology",
+			spec:   v1alpha1.TieredCacheParameters{Topology: ptr.StringPtr(v1alpha1.TopologyGeneric)},
+			want: want{
+				reqs: []request{
+					{Method: http.MethodPatch, Endpoint: "/zones/" + zoneID + "/argo/tiered_caching", Data: Setting{Value: ValueOn}},
+					{Method: http.MethodPatch, Endpoint: "/zones/" + zoneID + "/cache/tiered_cache_smart_topology_enable", Data: Setting{Value: ValueOff}},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var reqs []request
+			client := fake.MockClient{
+				MockRawContext: func(_ context.Context, m, e string, d interface{}) (json.RawMessage, error) {
+					reqs = append(reqs, request{Method: m, Endpoint: e, Data: d})
+					return json.RawMessage(`{}`), tc.err
+				},
+			}
+			err := Enable(context.Background(), client, zoneID, tc.spec)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nEnable(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.reqs, reqs); diff != "" {
+				t.Errorf("\n%s\nEnable(...): -want requests, +got requests:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDisable(t *testing.T) {
+	var reqs []request
+	client := fake.MockClient{
+		MockRawContext: func(_ context.Context, m, e string, d interface{}) (json.RawMessage, error) {
+			reqs = append(reqs, request{Method: m, Endpoint: e, Data: d})
+			return json.RawMessage(`{}`), nil
+		},
+	}
+
+	want := []request{
+		{Method: http.MethodPatch, Endpoint: "/zones/" + zoneID + "/cache/tiered_cache_smart_topology_enable", Data: Setting{Value: ValueOff}},
+		{Method: http.MethodPatch, Endpoint: "/zones/" + zoneID + "/argo/tiered_caching", Data: Setting{Value: ValueOff}},
+	}
+
+	if err := Disable(context.Background(), client, zoneID); err != nil {
+		t.Errorf("Disable(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(want, reqs); diff != "" {
+		t.Errorf("Disable(...): -want requests, +got requests:\n%s\n", diff)
+	}
+}
@@ -17,16 +17,22 @@ limitations under the License.
 package clients
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"io"
+	"io/ioutil"
 	"net/http"
 
 	"github.com/cloudflare/cloudflare-go"
 	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/benagricola/provider-cloudflare/apis/v1alpha1"
@@ -37,6 +43,13 @@ const (
 	errPCRef        = "providerConfigRef not set"
 	errTrackPCUsage = "cannot track ProviderConfig usage"
 	errNoAuth       = "auth details not valid"
+	errGetSecret    = "cannot get Secret"
+	errSecretKey    = "key not found in Secret"
+
+	errMarshalRequest    = "cannot marshal request"
+	errNewRequest        = "cannot create request"
+	errReadResponse      = "cannot read response"
+	errUnmarshalResponse = "cannot unmarshal response"
 )
 
 // AuthByAPIKey represents the details required to authenticate
@@ -58,23 +71,126 @@ type AuthByAPIToken struct {
 type Config struct {
 	*AuthByAPIKey   `json:",inline"`
 	*AuthByAPIToken `json:",inline"`
+
+	// AccountID scopes account-level API calls (such as Load
+	// Balancer Pools and Monitors) to the given account rather
+	// than the authenticated user.
+	AccountID *string `json:"accountId,omitempty"`
+
+	// RateLimit configures the request budget the client draws from. It
+	// is read from the ProviderConfig rather than its credentials, and
+	// the default budget is used if it is not set.
+	RateLimit *RateLimit `json:"-"`
+
+	// ProxyRecordsByDefault proxies DNS Records that can be proxied
+	// when they do not set proxied. It is read from the ProviderConfig.
+	ProxyRecordsByDefault bool `json:"-"`
+}
+
+// An API is a Cloudflare API client. It embeds the cloudflare-go client,
+// and adds requests to endpoints that cloudflare-go has no typed methods
+// for which, unlike cloudflare-go's Raw, honour the passed context.
+type API struct {
+	*cloudflare.API
+
+	hc *http.Client
 }
 
 // NewClient creates a new Cloudflare Client with provided Credentials.
-func NewClient(c Config, hc *http.Client) (*cloudflare.API, error) {
+func NewClient(c Config, hc *http.Client) (*API, error) {
 	if hc == nil {
 		hc = http.DefaultClient
 	}
-	ohc := cloudflare.HTTPClient(hc)
 
-	if c.AuthByAPIKey != nil && c.AuthByAPIKey.Key != nil &&
-		c.AuthByAPIKey.Email != nil {
-		return cloudflare.New(*c.AuthByAPIKey.Key, *c.AuthByAPIKey.Email, ohc)
+	rl := c.RateLimit
+	if rl == nil {
+		rl = NewRateLimit("", nil)
 	}
-	if c.AuthByAPIToken != nil && c.AuthByAPIToken.Token != nil {
-		return cloudflare.NewWithAPIToken(*c.AuthByAPIToken.Token, ohc)
+	rhc := RateLimitedHTTPClient(hc, *rl)
+
+	// Rate limited and failed requests are retried by the shared
+	// transport, so cloudflare-go must not retry them again. It waits for
+	// its own rate limiter without the context of the request, so that is
+	// disabled and requests only wait for the shared budget, using their
+	// context, in the transport.
+	opts := []cloudflare.Option{
+		cloudflare.HTTPClient(rhc),
+		cloudflare.UsingRateLimit(float64(rate.Inf)),
+		cloudflare.UsingRetryPolicy(0, 0, 0),
+	}
+	if c.AccountID != nil && *c.AccountID != "" {
+		opts = append(opts, cloudflare.UsingAccount(*c.AccountID))
 	}
-	return nil, errors.New(errNoAuth)
+
+	var api *cloudflare.API
+	var err error
+	switch {
+	case c.AuthByAPIKey != nil && c.AuthByAPIKey.Key != nil && c.AuthByAPIKey.Email != nil:
+		api, err = cloudflare.New(*c.AuthByAPIKey.Key, *c.AuthByAPIKey.Email, opts...)
+	case c.AuthByAPIToken != nil && c.AuthByAPIToken.Token != nil:
+		api, err = cloudflare.NewWithAPIToken(*c.AuthByAPIToken.Token, opts...)
+	default:
+		return nil, errors.New(errNoAuth)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &API{API: api, hc: rhc}, nil
+}
+
+// RawContext makes a request with the passed context to the passed
+// Cloudflare API endpoint, and returns its result as untouched JSON. The
+// request is cancelled, including while it waits for the shared request
+// budget or to be retried, when the context is done. Errors are returned
+// as they are by cloudflare-go.
+func (api *API) RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+	var body io.Reader
+	if data != nil {
+		b, err := json.Marshal(data)
+		if err != nil {
+			return nil, errors.Wrap(err, errMarshalRequest)
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, api.BaseURL+endpoint, body)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewRequest)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if api.UserAgent != "" {
+		req.Header.Set("User-Agent", api.UserAgent)
+	}
+	if api.APIToken != "" {
+		req.Header.Set("Authorization", "Bearer "+api.APIToken)
+	} else {
+		req.Header.Set("X-Auth-Key", api.APIKey)
+		req.Header.Set("X-Auth-Email", api.APIEmail)
+	}
+
+	rsp, err := api.hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close() //nolint:errcheck
+
+	b, err := ioutil.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, errReadResponse)
+	}
+
+	if rsp.StatusCode > http.StatusInternalServerError {
+		return nil, errors.Errorf("HTTP status %d: service failure", rsp.StatusCode)
+	}
+
+	r := cloudflare.RawResponse{}
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, errors.Wrap(err, errUnmarshalResponse)
+	}
+	if rsp.StatusCode >= http.StatusBadRequest {
+		return nil, &cloudflare.APIRequestError{StatusCode: rsp.StatusCode, Errors: r.Errors}
+	}
+	return r.Result, nil
 }
 
 // GetConfig returns a valid Cloudflare API configuration
@@ -105,7 +221,18 @@ func UseProviderConfig(ctx context.Context, c client.Client, mg resource.Managed
 	if err != nil {
 		return nil, errors.Wrap(err, errGetPC)
 	}
-	return UseProviderSecret(ctx, data)
+
+	config, err := UseProviderSecret(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every resource using this ProviderConfig shares its request budget.
+	config.RateLimit = NewRateLimit(pc.GetName(), pc.Spec.RateLimit)
+	if pc.Spec.ProxyRecordsByDefault != nil {
+		config.ProxyRecordsByDefault = *pc.Spec.ProxyRecordsByDefault
+	}
+	return config, nil
 }
 
 // UseProviderSecret extracts a JSON blob containing configuration
@@ -118,6 +245,21 @@ func UseProviderSecret(ctx context.Context, data []byte) (*Config, error) {
 	return config, nil
 }
 
+// GetSecretValue returns the value of the Secret key selected by the
+// passed selector.
+func GetSecretValue(ctx context.Context, c client.Reader, sel xpv1.SecretKeySelector) ([]byte, error) {
+	s := &corev1.Secret{}
+	nn := types.NamespacedName{Namespace: sel.Namespace, Name: sel.Name}
+	if err := c.Get(ctx, nn, s); err != nil {
+		return nil, errors.Wrap(err, errGetSecret)
+	}
+	v, ok := s.Data[sel.Key]
+	if !ok {
+		return nil, errors.Errorf("%s: %s", errSecretKey, sel.Key)
+	}
+	return v, nil
+}
+
 // ToNumber converts an interface from the Cloudflare API
 // into an int64 pointer, if it contains an existing int,
 // int64 or float64 value.
@@ -186,10 +328,33 @@ func ToBool(in interface{}) *bool {
 }
 
 // ToStringSlice converts an interface from the Cloudflare API
-// into a string slice.
+// into a string slice. Lists decoded from JSON are converted if
+// all of their items are strings.
 func ToStringSlice(in interface{}) []string {
-	if v, ok := in.([]string); ok {
+	switch v := in.(type) {
+	case []string:
 		return v
+	case []interface{}:
+		o := make([]string, len(v))
+		for i, iv := range v {
+			s, ok := iv.(string)
+			if !ok {
+				return nil
+			}
+			o[i] = s
+		}
+		return o
 	}
 	return nil
 }
+
+// ManagedCiphers returns the passed cipher suites, or nil if none are
+// passed. An empty list of ciphers means the same as an unset one: the
+// Cloudflare default ciphers are used. They are not managed, so they are
+// neither compared with the ciphers Cloudflare reports nor written.
+func ManagedCiphers(in []string) []string {
+	if len(in) == 0 {
+		return nil
+	}
+	return in
+}
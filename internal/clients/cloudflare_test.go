@@ -17,8 +17,11 @@ limitations under the License.
 package clients
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"io/ioutil"
+	"net/http"
 	"testing"
 
 	"github.com/cloudflare/cloudflare-go"
@@ -305,6 +308,24 @@ func TestNewClient(t *testing.T) {
 				}("beef"),
 			},
 		},
+		"ValidAccountScoped": {
+			reason: "A cloudflare client should be scoped to the account ID if one is configured",
+			args: args{
+				config: Config{
+					AuthByAPIToken: &AuthByAPIToken{
+						Token: ptr.StringPtr("beef"),
+					},
+					AccountID: ptr.StringPtr("1234"),
+				},
+			},
+			want: want{
+				err: nil,
+				o: func(token, account string) *cloudflare.API {
+					api, _ := cloudflare.NewWithAPIToken(token, cloudflare.UsingAccount(account))
+					return api
+				}("beef", "1234"),
+			},
+		},
 		"ValidAPIBothAuth": {
 			reason: "A cloudflare client should be returned configured with API key details if both Auth types are provided",
 			args: args{
@@ -335,10 +356,226 @@ func TestNewClient(t *testing.T) {
 				t.Errorf("\n%s\nNewClient(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
 
-			if diff := cmp.Diff(tc.want.o, got, cmpopts.IgnoreUnexported(cloudflare.API{})); diff != "" {
+			var api *cloudflare.API
+			if got != nil {
+				api = got.API
+			}
+			if diff := cmp.Diff(tc.want.o, api, cmpopts.IgnoreUnexported(cloudflare.API{})); diff != "" {
 				t.Errorf("\n%s\nNewClient(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 
 		})
 	}
 }
+
+func TestToStringSlice(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		in     interface{}
+		want   []string
+	}{
+		"Nil": {
+			reason: "ToStringSlice should return nil when passed nil",
+		},
+		"StringSlice": {
+			reason: "ToStringSlice should return a string slice unchanged",
+			in:     []string{"a", "b"},
+			want:   []string{"a", "b"},
+		},
+		"DecodedJSON": {
+			reason: "ToStringSlice should convert a list of strings decoded from JSON",
+			in:     []interface{}{"a", "b"},
+			want:   []string{"a", "b"},
+		},
+		"DecodedJSONEmpty": {
+			reason: "ToStringSlice should convert an empty list decoded from JSON",
+			in:     []interface{}{},
+			want:   []string{},
+		},
+		"DecodedJSONNotStrings": {
+			reason: "ToStringSlice should return nil when a list contains items that are not strings",
+			in:     []interface{}{"a", float64(1)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ToStringSlice(tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nToStringSlice(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestManagedCiphers(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		in     []string
+		want   []string
+	}{
+		"Unset": {
+			reason: "ManagedCiphers should return nil when no ciphers are set",
+		},
+		"Empty": {
+			reason: "ManagedCiphers should return nil for an empty list, as it means the same as an unset one",
+			in:     []string{},
+		},
+		"Ciphers": {
+			reason: "ManagedCiphers should return the ciphers that are set",
+			in:     []string{"AES128-SHA"},
+			want:   []string{"AES128-SHA"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ManagedCiphers(tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nManagedCiphers(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetSecretValue(t *testing.T) {
+	errBoom := errors.New("boom")
+	sel := xpv1.SecretKeySelector{
+		SecretReference: xpv1.SecretReference{Name: "secret", Namespace: "default"},
+		Key:             "value",
+	}
+
+	type want struct {
+		o   []byte
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Reader
+		want   want
+	}{
+		"GetError": {
+			reason: "GetSecretValue should return an error if the Secret cannot be read",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			want: want{
+				err: errors.Wrap(errBoom, errGetSecret),
+			},
+		},
+		"MissingKey": {
+			reason: "GetSecretValue should return an error if the key does not exist",
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+			want: want{
+				err: errors.Errorf("%s: %s", errSecretKey, "value"),
+			},
+		},
+		"Success": {
+			reason: "GetSecretValue should return the value of the selected key",
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.(*corev1.Secret).Data = map[string][]byte{"value": []byte("s3cr3t")}
+					return nil
+				}),
+			},
+			want: want{
+				o: []byte("s3cr3t"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := GetSecretValue(context.Background(), tc.kube, sel)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGetSecretValue(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nGetSecretValue(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRawContext(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		o   json.RawMessage
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		data   interface{}
+		fn     roundTripFn
+		want   want
+	}{
+		"Success": {
+			reason: "The result of a successful request should be returned",
+			data:   map[string]string{"name": "example"},
+			fn: func(req *http.Request, body string) (*http.Response, error) {
+				if req.Method != http.MethodPost || req.URL.Path != "/client/v4/zones/1234/example" || body != `{"name":"example"}` {
+					return nil, errBoom
+				}
+				if req.Header.Get("Authorization") != "Bearer beef" {
+					return nil, errBoom
+				}
+				rsp := response(http.StatusOK, nil)
+				rsp.Body = ioutil.NopCloser(bytes.NewReader([]byte(`{"success":true,"result":{"id":"5678"}}`)))
+				return rsp, nil
+			},
+			want: want{
+				o: json.RawMessage(`{"id":"5678"}`),
+			},
+		},
+		"ErrAPI": {
+			reason: "The errors of a failed request should be returned as an API request error",
+			fn: func(req *http.Request, body string) (*http.Response, error) {
+				rsp := response(http.StatusNotFound, nil)
+				rsp.Body = ioutil.NopCloser(bytes.NewReader([]byte(`{"success":false,"errors":[{"code":1001,"message":"not found"}]}`)))
+				return rsp, nil
+			},
+			want: want{
+				err: &cloudflare.APIRequestError{
+					StatusCode: http.StatusNotFound,
+					Errors:     []cloudflare.ResponseInfo{{Code: 1001, Message: "not found"}},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cf, _ := cloudflare.NewWithAPIToken("beef")
+			api := &API{API: cf, hc: &http.Client{Transport: &recordingTransport{fn: tc.fn}}}
+
+			got, err := api.RawContext(context.Background(), http.MethodPost, "/zones/1234/example", tc.data)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nRawContext(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(string(tc.want.o), string(got)); diff != "" {
+				t.Errorf("\n%s\nRawContext(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRawContextCancelled(t *testing.T) {
+	api, err := NewClient(Config{AuthByAPIToken: &AuthByAPIToken{Token: ptr.StringPtr("beef")}}, &http.Client{
+		Transport: &recordingTransport{fn: func(req *http.Request, body string) (*http.Response, error) {
+			return response(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"30"}}), nil
+		}},
+	})
+	if err != nil {
+		t.Fatalf("NewClient(...): %s", err)
+	}
+
+	// A request that is rate limited should stop being retried once its
+	// context is done, rather than waiting for the Retry-After.
+	ctx, cancel := context.WithCancel(context.Background())
+	go cancel()
+
+	if _, err := api.RawContext(ctx, http.MethodGet, "/zones", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("RawContext(...): want context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnssec
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cloudflare/cloudflare-go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/benagricola/provider-cloudflare/apis/dns/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+const (
+	// StatusActive is the status of DNSSEC once it is enabled and
+	// the DS record has been published at the registrar.
+	StatusActive = "active"
+
+	// StatusPending is the status of DNSSEC once it is enabled and
+	// before the DS record has been published at the registrar.
+	StatusPending = "pending"
+
+	// StatusDisabled is the status of DNSSEC when it is not enabled.
+	StatusDisabled = "disabled"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with DNSSEC.
+type Client interface {
+	ZoneDNSSECSetting(ctx context.Context, zoneID string) (cloudflare.ZoneDNSSEC, error)
+	UpdateZoneDNSSEC(ctx context.Context, zoneID string, options cloudflare.ZoneDNSSECUpdateOptions) (cloudflare.ZoneDNSSEC, error)
+}
+
+// NewClient returns a new Cloudflare API client for working with DNSSEC.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// IsEnabled returns true if the passed DNSSEC status indicates that
+// DNSSEC is enabled on a Zone, even if it is not yet active.
+func IsEnabled(status string) bool {
+	return status == StatusActive || status == StatusPending
+}
+
+// GenerateObservation creates an observation of DNSSEC on a Zone.
+func GenerateObservation(in cloudflare.ZoneDNSSEC) v1alpha1.DNSSECObservation {
+	o := v1alpha1.DNSSECObservation{
+		Status:          in.Status,
+		Flags:           in.Flags,
+		Algorithm:       in.Algorithm,
+		KeyType:         in.KeyType,
+		DigestType:      in.DigestType,
+		DigestAlgorithm: in.DigestAlgorithm,
+		Digest:          in.Digest,
+		DS:              in.DS,
+		KeyTag:          in.KeyTag,
+		PublicKey:       in.PublicKey,
+	}
+
+	if !in.ModifiedOn.IsZero() {
+		o.ModifiedOn = &metav1.Time{Time: in.ModifiedOn}
+	}
+
+	return o
+}
+
+// Enable enables DNSSEC on the Zone with the passed ID.
+func Enable(ctx context.Context, client Client, zoneID string) (cloudflare.ZoneDNSSEC, error) {
+	return client.UpdateZoneDNSSEC(ctx, zoneID, cloudflare.ZoneDNSSECUpdateOptions{Status: StatusActive})
+}
+
+// Disable disables DNSSEC on the Zone with the passed ID.
+func Disable(ctx context.Context, client Client, zoneID string) error {
+	_, err := client.UpdateZoneDNSSEC(ctx, zoneID, cloudflare.ZoneDNSSECUpdateOptions{Status: StatusDisabled})
+	return err
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnssec
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/benagricola/provider-cloudflare/apis/dns/v1alpha1"
+)
+
+func TestIsEnabled(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		status string
+		want   bool
+	}{
+		"Active": {
+			reason: "DNSSEC should be enabled when it is active",
+			status: StatusActive,
+			want:   true,
+		},
+		"Pending": {
+			reason: "DNSSEC should be enabled when it is waiting for the DS record",
+			status: StatusPending,
+			want:   true,
+		},
+		"Disabled": {
+			reason: "DNSSEC should not be enabled when it is disabled",
+			status: StatusDisabled,
+			want:   false,
+		},
+		"PendingDisabled": {
+			reason: "DNSSEC should not be enabled when it is being disabled",
+			status: "pending-disabled",
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsEnabled(tc.status)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsEnabled(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateObservation(t *testing.T) {
+	modified := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		reason string
+		in     cloudflare.ZoneDNSSEC
+		want   v1alpha1.DNSSECObservation
+	}{
+		"Disabled": {
+			reason: "An observation of disabled DNSSEC should only contain its status",
+			in:     cloudflare.ZoneDNSSEC{Status: StatusDisabled},
+			want:   v1alpha1.DNSSECObservation{Status: StatusDisabled},
+		},
+		"Active": {
+			reason: "An observation of active DNSSEC should contain the DS record and its parts",
+			in: cloudflare.ZoneDNSSEC{
+				Status:          StatusActive,
+				Flags:           257,
+				Algorithm:       "13",
+				KeyType:         "ECDSAP256SHA256",
+				DigestType:      "2",
+				DigestAlgorithm: "SHA256",
+				Digest:          "48E939042E82C22542CB377B580DFDC52A361CEFDC72E7F9107E2B6BD9306A45",
+				DS:              "example.com. 3600 IN DS 16953 13 2 48E939042E82C22542CB377B580DFDC52A361CEFDC72E7F9107E2B6BD9306A45",
+				KeyTag:          42,
+				PublicKey:       "oXiGYrSTO+LSCJ3mohc8EP+CzF9KxBj8/ydXJ22pKuZP3VAC3/Md/k7xZfz470CoRyZJ6gV6vml07IC3d8xqhA==",
+				ModifiedOn:      modified,
+			},
+			want: v1alpha1.DNSSECObservation{
+				Status:          StatusActive,
+				Flags:           257,
+				Algorithm:       "13",
+				KeyType:         "ECDSAP256SHA256",
+				DigestType:      "2",
+				DigestAlgorithm: "SHA256",
+				Digest:          "48E939042E82C22542CB377B580DFDC52A361CEFDC72E7F9107E2B6BD9306A45",
+				DS:              "example.com. 3600 IN DS 16953 13 2 48E939042E82C22542CB377B580DFDC52A361CEFDC72E7F9107E2B6BD9306A45",
+				KeyTag:          42,
+				PublicKey:       "oXiGYrSTO+LSCJ3mohc8EP+CzF9KxBj8/ydXJ22pKuZP3VAC3/Md/k7xZfz470CoRyZJ6gV6vml07IC3d8xqhA==",
+				ModifiedOn:      &metav1.Time{Time: modified},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateObservation(tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateObservation(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
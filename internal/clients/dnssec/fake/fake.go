@@ -0,0 +1,39 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// A MockClient acts as a testable representation of the Cloudflare API.
+type MockClient struct {
+	MockZoneDNSSECSetting func(ctx context.Context, zoneID string) (cloudflare.ZoneDNSSEC, error)
+	MockUpdateZoneDNSSEC  func(ctx context.Context, zoneID string, options cloudflare.ZoneDNSSECUpdateOptions) (cloudflare.ZoneDNSSEC, error)
+}
+
+// ZoneDNSSECSetting mocks the ZoneDNSSECSetting method of the Cloudflare API.
+func (m MockClient) ZoneDNSSECSetting(ctx context.Context, zoneID string) (cloudflare.ZoneDNSSEC, error) {
+	return m.MockZoneDNSSECSetting(ctx, zoneID)
+}
+
+// UpdateZoneDNSSEC mocks the UpdateZoneDNSSEC method of the Cloudflare API.
+func (m MockClient) UpdateZoneDNSSEC(ctx context.Context, zoneID string, options cloudflare.ZoneDNSSECUpdateOptions) (cloudflare.ZoneDNSSEC, error) {
+	return m.MockUpdateZoneDNSSEC(ctx, zoneID, options)
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emailrouting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/benagricola/provider-cloudflare/apis/emailrouting/v1alpha1"
+)
+
+const (
+	errCreateAddress = "error creating email routing address"
+)
+
+// Address is an Email Routing destination address as represented by the
+// Cloudflare API.
+type Address struct {
+	ID       string     `json:"id,omitempty"`
+	Email    string     `json:"email"`
+	Verified *time.Time `json:"verified,omitempty"`
+	Created  *time.Time `json:"created,omitempty"`
+}
+
+func addressesEndpoint(accountID string) string {
+	return "/accounts/" + accountID + "/email/routing/addresses"
+}
+
+func parseAddress(raw json.RawMessage) (*Address, error) {
+	a := &Address{}
+	if err := json.Unmarshal(raw, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// EmailRoutingAddress returns the destination address with the given ID.
+func EmailRoutingAddress(ctx context.Context, client Client, accountID, addressID string) (*Address, error) {
+	raw, err := client.RawContext(ctx, http.MethodGet, addressesEndpoint(accountID)+"/"+addressID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseAddress(raw)
+}
+
+// GenerateAddressObservation creates an observation of a destination
+// address.
+func GenerateAddressObservation(in Address) v1alpha1.EmailRoutingAddressObservation {
+	o := v1alpha1.EmailRoutingAddressObservation{
+		Verified: in.Verified != nil,
+	}
+	if in.Verified != nil {
+		t := metav1.NewTime(*in.Verified)
+		o.VerifiedOn = &t
+	}
+	if in.Created != nil {
+		t := metav1.NewTime(*in.Created)
+		o.CreatedOn = &t
+	}
+	return o
+}
+
+// CreateAddress creates a new destination address, which causes
+// Cloudflare to send a verification email to it.
+func CreateAddress(ctx context.Context, client Client, accountID, email string) (*Address, error) {
+	raw, err := client.RawContext(ctx, http.MethodPost, addressesEndpoint(accountID), Address{Email: email})
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateAddress)
+	}
+	return parseAddress(raw)
+}
+
+// DeleteAddress deletes the destination address with the given ID.
+func DeleteAddress(ctx context.Context, client Client, accountID, addressID string) error {
+	_, err := client.RawContext(ctx, http.MethodDelete, addressesEndpoint(accountID)+"/"+addressID, nil)
+	return err
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emailrouting
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/benagricola/provider-cloudflare/apis/emailrouting/v1alpha1"
+)
+
+func TestGenerateAddressObservation(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	mt := metav1.NewTime(now)
+
+	cases := map[string]struct {
+		reason string
+		raw    string
+		want   v1alpha1.EmailRoutingAddressObservation
+	}{
+		"Verified": {
+			reason: "A verified address should be observed as verified",
+			raw: `{
+				"id": "ea95132c15732412d22c1476fa83f27a",
+				"email": "team@example.org",
+				"verified": "2022-06-01T12:00:00Z",
+				"created": "2022-06-01T12:00:00Z"
+			}`,
+			want: v1alpha1.EmailRoutingAddressObservation{
+				Verified:   true,
+				VerifiedOn: &mt,
+				CreatedOn:  &mt,
+			},
+		},
+		"Unverified": {
+			reason: "An address that has not been verified should not be observed as verified",
+			raw: `{
+				"id": "ea95132c15732412d22c1476fa83f27a",
+				"email": "team@example.org",
+				"verified": null,
+				"created": "2022-06-01T12:00:00Z"
+			}`,
+			want: v1alpha1.EmailRoutingAddressObservation{
+				CreatedOn: &mt,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			a, err := parseAddress(json.RawMessage(tc.raw))
+			if err != nil {
+				t.Fatalf("\n%s\nparseAddress(...): %s", tc.reason, err)
+			}
+			got := GenerateAddressObservation(*a)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateAddressObservation(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
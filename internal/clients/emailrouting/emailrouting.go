@@ -0,0 +1,111 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emailrouting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/benagricola/provider-cloudflare/apis/emailrouting/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+const (
+	// StatusReady is the status of Email Routing once the DNS records it
+	// requires have been added to the Zone.
+	StatusReady = "ready"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with Email Routing. Email Routing is managed using raw API requests, as
+// the cloudflare-go library does not support it.
+type Client interface {
+	RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error)
+}
+
+// NewClient returns a new Cloudflare API client for working with Email
+// Routing.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// Settings are the Email Routing settings of a Zone as represented by the
+// Cloudflare API.
+type Settings struct {
+	Name     string     `json:"name,omitempty"`
+	Enabled  bool       `json:"enabled"`
+	Status   string     `json:"status,omitempty"`
+	Created  *time.Time `json:"created,omitempty"`
+	Modified *time.Time `json:"modified,omitempty"`
+}
+
+// IsNotFound returns true if the passed error indicates an Email Routing
+// resource was not found.
+func IsNotFound(err error) bool {
+	return clients.IsNotFound(err)
+}
+
+func endpoint(zoneID string) string {
+	return "/zones/" + zoneID + "/email/routing"
+}
+
+// EmailRouting returns the Email Routing settings of the Zone with the
+// passed ID.
+func EmailRouting(ctx context.Context, client Client, zoneID string) (*Settings, error) {
+	raw, err := client.RawContext(ctx, http.MethodGet, endpoint(zoneID), nil)
+	if err != nil {
+		return nil, err
+	}
+	s := &Settings{}
+	if err := json.Unmarshal(raw, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SetEnabled enables or disables Email Routing on the Zone with the passed
+// ID.
+func SetEnabled(ctx context.Context, client Client, zoneID string, enabled bool) error {
+	action := "/disable"
+	if enabled {
+		action = "/enable"
+	}
+	_, err := client.RawContext(ctx, http.MethodPost, endpoint(zoneID)+action, struct{}{})
+	return err
+}
+
+// GenerateSettingsObservation creates an observation of the Email Routing
+// settings of a Zone.
+func GenerateSettingsObservation(in Settings) v1alpha1.EmailRoutingSettingsObservation {
+	o := v1alpha1.EmailRoutingSettingsObservation{
+		Name:   in.Name,
+		Status: in.Status,
+	}
+	if in.Created != nil {
+		t := metav1.NewTime(*in.Created)
+		o.CreatedOn = &t
+	}
+	if in.Modified != nil {
+		t := metav1.NewTime(*in.Modified)
+		o.ModifiedOn = &t
+	}
+	return o
+}
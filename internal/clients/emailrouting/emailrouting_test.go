@@ -0,0 +1,122 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emailrouting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/emailrouting/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/emailrouting/fake"
+)
+
+const (
+	zoneID    = "023e105f4ecef8ad9ca31a8372d0c353"
+	accountID = "01a7362d577a6c3019a474fd6f485823"
+)
+
+func TestSetEnabled(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason   string
+		enabled  bool
+		err      error
+		endpoint string
+		want     error
+	}{
+		"Enable": {
+			reason:   "Email Routing should be enabled",
+			enabled:  true,
+			endpoint: "/zones/" + zoneID + "/email/routing/enable",
+		},
+		"Disable": {
+			reason:   "Email Routing should be disabled",
+			enabled:  false,
+			endpoint: "/zones/" + zoneID + "/email/routing/disable",
+		},
+		"Failed": {
+			reason:   "Errors enabling Email Routing should be returned",
+			enabled:  true,
+			err:      errBoom,
+			endpoint: "/zones/" + zoneID + "/email/routing/enable",
+			want:     errBoom,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := fake.MockClient{
+				MockRawContext: func(_ context.Context, m, e string, d interface{}) (json.RawMessage, error) {
+					if m != http.MethodPost || e != tc.endpoint {
+						t.Errorf("\n%s\nSetEnabled(...): unexpected request %s %s", tc.reason, m, e)
+					}
+					return json.RawMessage(`{}`), tc.err
+				},
+			}
+			err := SetEnabled(context.Background(), client, zoneID, tc.enabled)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nSetEnabled(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateSettingsObservation(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	mt := metav1.NewTime(now)
+
+	cases := map[string]struct {
+		reason string
+		in     Settings
+		want   v1alpha1.EmailRoutingSettingsObservation
+	}{
+		"Full": {
+			reason: "All fields should be observed",
+			in:     Settings{Name: "example.com", Enabled: true, Status: StatusReady, Created: &now, Modified: &now},
+			want: v1alpha1.EmailRoutingSettingsObservation{
+				Name:       "example.com",
+				Status:     StatusReady,
+				CreatedOn:  &mt,
+				ModifiedOn: &mt,
+			},
+		},
+		"Empty": {
+			reason: "Missing timestamps should not be observed",
+			in:     Settings{Name: "example.com", Status: "unconfigured"},
+			want:   v1alpha1.EmailRoutingSettingsObservation{Name: "example.com", Status: "unconfigured"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateSettingsObservation(tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateSettingsObservation(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
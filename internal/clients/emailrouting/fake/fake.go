@@ -0,0 +1,32 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// A MockClient acts as a testable representation of the Cloudflare API.
+type MockClient struct {
+	MockRawContext func(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error)
+}
+
+// RawContext mocks the RawContext method of the Cloudflare API.
+func (m MockClient) RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+	return m.MockRawContext(ctx, method, endpoint, data)
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emailrouting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/benagricola/provider-cloudflare/apis/emailrouting/v1alpha1"
+)
+
+const (
+	// MatcherAll is the type of matcher used by the catch-all rule.
+	MatcherAll = "all"
+
+	errCreateRule = "error creating email routing rule"
+	errUpdateRule = "error updating email routing rule"
+)
+
+// Matcher matches the emails a Rule applies to, as represented by the
+// Cloudflare API.
+type Matcher struct {
+	Type  string `json:"type"`
+	Field string `json:"field,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// Action is an action taken on emails matched by a Rule, as represented
+// by the Cloudflare API.
+type Action struct {
+	Type  string   `json:"type"`
+	Value []string `json:"value,omitempty"`
+}
+
+// Rule is an Email Routing Rule as represented by the Cloudflare API.
+type Rule struct {
+	Tag      string    `json:"tag,omitempty"`
+	Name     string    `json:"name,omitempty"`
+	Enabled  bool      `json:"enabled"`
+	Priority int       `json:"priority"`
+	Matchers []Matcher `json:"matchers"`
+	Actions  []Action  `json:"actions"`
+}
+
+func rulesEndpoint(zoneID string) string {
+	return endpoint(zoneID) + "/rules"
+}
+
+func ruleEndpoint(zoneID, tag string) string {
+	return rulesEndpoint(zoneID) + "/" + tag
+}
+
+func catchAllEndpoint(zoneID string) string {
+	return rulesEndpoint(zoneID) + "/catch_all"
+}
+
+func parseRule(raw json.RawMessage) (*Rule, error) {
+	r := &Rule{}
+	if err := json.Unmarshal(raw, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// IsCatchAll returns true if the passed parameters manage the catch-all
+// rule of a Zone.
+func IsCatchAll(spec *v1alpha1.EmailRoutingRuleParameters) bool {
+	return spec.CatchAll != nil && *spec.CatchAll
+}
+
+// ruleFromSpec converts the passed parameters into a Rule.
+func ruleFromSpec(spec *v1alpha1.EmailRoutingRuleParameters) Rule {
+	r := Rule{
+		Enabled:  true,
+		Matchers: []Matcher{},
+		Actions:  []Action{},
+	}
+	if spec.Name != nil {
+		r.Name = *spec.Name
+	}
+	if spec.Enabled != nil {
+		r.Enabled = *spec.Enabled
+	}
+	if spec.Priority != nil {
+		r.Priority = *spec.Priority
+	}
+
+	if IsCatchAll(spec) {
+		r.Matchers = append(r.Matchers, Matcher{Type: MatcherAll})
+	} else {
+		for _, m := range spec.Matchers {
+			rm := Matcher{Type: m.Type}
+			if m.Field != nil {
+				rm.Field = *m.Field
+			}
+			if m.Value != nil {
+				rm.Value = *m.Value
+			}
+			r.Matchers = append(r.Matchers, rm)
+		}
+	}
+
+	for _, a := range spec.Actions {
+		r.Actions = append(r.Actions, Action{Type: a.Type, Value: a.Value})
+	}
+	return r
+}
+
+// EmailRoutingRule returns the Email Routing Rule with the given tag.
+func EmailRoutingRule(ctx context.Context, client Client, zoneID, tag string) (*Rule, error) {
+	raw, err := client.RawContext(ctx, http.MethodGet, ruleEndpoint(zoneID, tag), nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseRule(raw)
+}
+
+// CatchAllRule returns the catch-all Email Routing Rule of a Zone.
+func CatchAllRule(ctx context.Context, client Client, zoneID string) (*Rule, error) {
+	raw, err := client.RawContext(ctx, http.MethodGet, catchAllEndpoint(zoneID), nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseRule(raw)
+}
+
+// LateInitializeRule initializes EmailRoutingRuleParameters based on the
+// remote resource.
+func LateInitializeRule(spec *v1alpha1.EmailRoutingRuleParameters, r Rule) bool {
+	if spec == nil {
+		return false
+	}
+
+	li := false
+	if spec.Priority == nil && !IsCatchAll(spec) {
+		spec.Priority = &r.Priority
+		li = true
+	}
+
+	return li
+}
+
+// RuleUpToDate checks if the remote Email Routing Rule is up to date with
+// the requested resource parameters.
+func RuleUpToDate(spec *v1alpha1.EmailRoutingRuleParameters, r Rule) bool {
+	if spec == nil {
+		return true
+	}
+
+	want := ruleFromSpec(spec)
+	if spec.Name != nil && want.Name != r.Name {
+		return false
+	}
+	if want.Enabled != r.Enabled {
+		return false
+	}
+	if spec.Priority != nil && want.Priority != r.Priority {
+		return false
+	}
+	if !IsCatchAll(spec) && !matchersEqual(want.Matchers, r.Matchers) {
+		return false
+	}
+	return actionsEqual(want.Actions, r.Actions)
+}
+
+func matchersEqual(a, b []Matcher) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func actionsEqual(a, b []Action) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Type != b[i].Type || len(a[i].Value) != len(b[i].Value) {
+			return false
+		}
+		for j := range a[i].Value {
+			if a[i].Value[j] != b[i].Value[j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// CreateRule creates a new Email Routing Rule.
+func CreateRule(ctx context.Context, client Client, zoneID string, spec *v1alpha1.EmailRoutingRuleParameters) (*Rule, error) {
+	raw, err := client.RawContext(ctx, http.MethodPost, rulesEndpoint(zoneID), ruleFromSpec(spec))
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateRule)
+	}
+	return parseRule(raw)
+}
+
+// UpdateRule updates the Email Routing Rule with the given tag.
+func UpdateRule(ctx context.Context, client Client, zoneID, tag string, spec *v1alpha1.EmailRoutingRuleParameters) error {
+	_, err := client.RawContext(ctx, http.MethodPut, ruleEndpoint(zoneID, tag), ruleFromSpec(spec))
+	return errors.Wrap(err, errUpdateRule)
+}
+
+// UpdateCatchAllRule updates the catch-all Email Routing Rule of a Zone.
+func UpdateCatchAllRule(ctx context.Context, client Client, zoneID string, spec *v1alpha1.EmailRoutingRuleParameters) (*Rule, error) {
+	raw, err := client.RawContext(ctx, http.MethodPut, catchAllEndpoint(zoneID), ruleFromSpec(spec))
+	if err != nil {
+		return nil, errors.Wrap(err, errUpdateRule)
+	}
+	return parseRule(raw)
+}
+
+// DeleteRule deletes the Email Routing Rule with the given tag.
+func DeleteRule(ctx context.Context, client Client, zoneID, tag string) error {
+	_, err := client.RawContext(ctx, http.MethodDelete, ruleEndpoint(zoneID, tag), nil)
+	return err
+}
+
+// DisableCatchAllRule disables the catch-all Email Routing Rule of a Zone,
+// as it cannot be deleted.
+func DisableCatchAllRule(ctx context.Context, client Client, zoneID string, spec *v1alpha1.EmailRoutingRuleParameters) error {
+	r := ruleFromSpec(spec)
+	r.Enabled = false
+	_, err := client.RawContext(ctx, http.MethodPut, catchAllEndpoint(zoneID), r)
+	return err
+}
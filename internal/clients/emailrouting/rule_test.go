@@ -0,0 +1,212 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emailrouting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	ptr "k8s.io/utils/pointer"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/emailrouting/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/emailrouting/fake"
+)
+
+const ruleTag = "a7e6fb77503c41d8a7f3113c6918f10c"
+
+func forward(to ...string) []v1alpha1.EmailRoutingRuleAction {
+	return []v1alpha1.EmailRoutingRuleAction{{Type: "forward", Value: to}}
+}
+
+func literalTo(address string) []v1alpha1.EmailRoutingRuleMatcher {
+	return []v1alpha1.EmailRoutingRuleMatcher{{Type: "literal", Field: ptr.StringPtr("to"), Value: ptr.StringPtr(address)}}
+}
+
+func TestRuleUpToDate(t *testing.T) {
+	priority := 10
+	remote := Rule{
+		Tag:      ruleTag,
+		Name:     "support",
+		Enabled:  true,
+		Priority: 10,
+		Matchers: []Matcher{{Type: "literal", Field: "to", Value: "support@example.com"}},
+		Actions:  []Action{{Type: "forward", Value: []string{"team@example.org"}}},
+	}
+
+	cases := map[string]struct {
+		reason string
+		spec   *v1alpha1.EmailRoutingRuleParameters
+		r      Rule
+		want   bool
+	}{
+		"NilSpec": {
+			reason: "A nil spec should always be up to date",
+			r:      remote,
+			want:   true,
+		},
+		"UpToDate": {
+			reason: "A Rule matching the spec should be up to date",
+			spec: &v1alpha1.EmailRoutingRuleParameters{
+				Name:     ptr.StringPtr("support"),
+				Priority: &priority,
+				Matchers: literalTo("support@example.com"),
+				Actions:  forward("team@example.org"),
+			},
+			r:    remote,
+			want: true,
+		},
+		"UnsetPriority": {
+			reason: "A spec without a priority should not be compared on priority",
+			spec: &v1alpha1.EmailRoutingRuleParameters{
+				Name:     ptr.StringPtr("support"),
+				Matchers: literalTo("support@example.com"),
+				Actions:  forward("team@example.org"),
+			},
+			r:    remote,
+			want: true,
+		},
+		"Disabled": {
+			reason: "A Rule should not be up to date when it is disabled in the spec",
+			spec: &v1alpha1.EmailRoutingRuleParameters{
+				Enabled:  ptr.BoolPtr(false),
+				Matchers: literalTo("support@example.com"),
+				Actions:  forward("team@example.org"),
+			},
+			r:    remote,
+			want: false,
+		},
+		"DifferentMatcher": {
+			reason: "A Rule matching a different address should not be up to date",
+			spec: &v1alpha1.EmailRoutingRuleParameters{
+				Matchers: literalTo("sales@example.com"),
+				Actions:  forward("team@example.org"),
+			},
+			r:    remote,
+			want: false,
+		},
+		"DifferentDestination": {
+			reason: "A Rule forwarding to a different address should not be up to date",
+			spec: &v1alpha1.EmailRoutingRuleParameters{
+				Matchers: literalTo("support@example.com"),
+				Actions:  forward("help@example.org"),
+			},
+			r:    remote,
+			want: false,
+		},
+		"CatchAll": {
+			reason: "Matchers should be ignored for the catch-all rule",
+			spec: &v1alpha1.EmailRoutingRuleParameters{
+				CatchAll: ptr.BoolPtr(true),
+				Actions:  []v1alpha1.EmailRoutingRuleAction{{Type: "drop"}},
+			},
+			r: Rule{
+				Enabled:  true,
+				Matchers: []Matcher{{Type: MatcherAll}},
+				Actions:  []Action{{Type: "drop"}},
+			},
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := RuleUpToDate(tc.spec, tc.r)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nRuleUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdateCatchAllRule(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		err  error
+		data interface{}
+	}
+
+	cases := map[string]struct {
+		reason string
+		spec   *v1alpha1.EmailRoutingRuleParameters
+		err    error
+		want   want
+	}{
+		"UpdateFailed": {
+			reason: "Errors updating the catch-all rule should be wrapped",
+			spec: &v1alpha1.EmailRoutingRuleParameters{
+				CatchAll: ptr.BoolPtr(true),
+				Actions:  forward("team@example.org"),
+			},
+			err: errBoom,
+			want: want{
+				err: errors.Wrap(errBoom, errUpdateRule),
+				data: Rule{
+					Enabled:  true,
+					Matchers: []Matcher{{Type: MatcherAll}},
+					Actions:  []Action{{Type: "forward", Value: []string{"team@example.org"}}},
+				},
+			},
+		},
+		"Success": {
+			reason: "The catch-all rule should match all emails regardless of the matchers in the spec",
+			spec: &v1alpha1.EmailRoutingRuleParameters{
+				CatchAll: ptr.BoolPtr(true),
+				Name:     ptr.StringPtr("catch-all"),
+				Matchers: literalTo("support@example.com"),
+				Actions:  forward("team@example.org"),
+			},
+			want: want{
+				data: Rule{
+					Name:     "catch-all",
+					Enabled:  true,
+					Matchers: []Matcher{{Type: MatcherAll}},
+					Actions:  []Action{{Type: "forward", Value: []string{"team@example.org"}}},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var data interface{}
+			client := fake.MockClient{
+				MockRawContext: func(_ context.Context, m, e string, d interface{}) (json.RawMessage, error) {
+					if m != http.MethodPut || e != "/zones/"+zoneID+"/email/routing/rules/catch_all" {
+						t.Errorf("\n%s\nUpdateCatchAllRule(...): unexpected request %s %s", tc.reason, m, e)
+					}
+					data = d
+					return json.RawMessage(`{}`), tc.err
+				},
+			}
+			_, err := UpdateCatchAllRule(context.Background(), client, zoneID, tc.spec)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdateCatchAllRule(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.data, data); diff != "" {
+				t.Errorf("\n%s\nUpdateCatchAllRule(...): -want data, +got data:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
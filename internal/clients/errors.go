@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Cloudflare returns this code when it rate limits a request.
+	codeRateLimited = 971
+
+	// Cloudflare returns these codes when the credentials in use are
+	// invalid, or are not permitted to make a request.
+	codeAuthenticationError = 10000
+	codeUnknownXAuthKey     = 9103
+	codeInvalidAccessToken  = 9109
+)
+
+// cloudflare-go returns untyped errors for some responses (e.g. 401, 403
+// and 5xx), which are prefixed with the HTTP status code of the response.
+var statusPrefix = regexp.MustCompile(`HTTP status (\d{3})`)
+
+// apiError returns the Cloudflare API error wrapped by the passed error,
+// if there is one.
+func apiError(err error) (*cloudflare.APIRequestError, bool) {
+	var e *cloudflare.APIRequestError
+	if errors.As(err, &e) && e != nil {
+		return e, true
+	}
+	return nil, false
+}
+
+// StatusCode returns the HTTP status code of the Cloudflare API response
+// that caused the passed error, or 0 if it cannot be determined.
+func StatusCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if e, ok := apiError(err); ok {
+		return e.StatusCode
+	}
+	m := statusPrefix.FindStringSubmatch(err.Error())
+	if m == nil {
+		return 0
+	}
+	s, _ := strconv.Atoi(m[1])
+	return s
+}
+
+// ErrorCodes returns the Cloudflare API error codes of the response that
+// caused the passed error.
+func ErrorCodes(err error) []int {
+	e, ok := apiError(err)
+	if !ok {
+		return nil
+	}
+	codes := make([]int, 0, len(e.Errors))
+	for _, i := range e.Errors {
+		codes = append(codes, i.Code)
+	}
+	return codes
+}
+
+// HasErrorCode returns true if the Cloudflare API response that caused
+// the passed error contained any of the passed error codes.
+func HasErrorCode(err error, codes ...int) bool {
+	for _, c := range ErrorCodes(err) {
+		for _, want := range codes {
+			if c == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsNotFound returns true if the passed error indicates that the
+// requested Cloudflare resource does not exist. Error codes are not
+// enough on their own: Cloudflare returns some of them, such as 7003,
+// both for resources that are gone and for resources the credentials in
+// use cannot access, so only a 404 response means a resource is gone.
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// IsRateLimited returns true if the passed error indicates that the
+// request was rate limited by the Cloudflare API.
+func IsRateLimited(err error) bool {
+	return StatusCode(err) == http.StatusTooManyRequests || HasErrorCode(err, codeRateLimited)
+}
+
+// IsAuthz returns true if the passed error indicates that the
+// credentials in use are invalid, or are not permitted to make the
+// request.
+func IsAuthz(err error) bool {
+	switch StatusCode(err) {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return true
+	}
+	return HasErrorCode(err, codeAuthenticationError, codeUnknownXAuthKey, codeInvalidAccessToken)
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func apiErr(status int, codes ...int) error {
+	e := &cloudflare.APIRequestError{StatusCode: status}
+	for _, c := range codes {
+		e.Errors = append(e.Errors, cloudflare.ResponseInfo{Code: c, Message: "boom"})
+	}
+	return e
+}
+
+func TestStatusCode(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   int
+	}{
+		"Nil": {
+			reason: "A nil error should have no status code",
+			want:   0,
+		},
+		"APIRequestError": {
+			reason: "The status code of an API error should be returned",
+			err:    apiErr(http.StatusNotFound),
+			want:   http.StatusNotFound,
+		},
+		"WrappedAPIRequestError": {
+			reason: "The status code of a wrapped API error should be returned",
+			err:    errors.Wrap(apiErr(http.StatusConflict), "wrapped"),
+			want:   http.StatusConflict,
+		},
+		"Untyped": {
+			reason: "The status code of an untyped error should be parsed from its message",
+			err:    errors.New("HTTP status 403: Authentication error"),
+			want:   http.StatusForbidden,
+		},
+		"Unknown": {
+			reason: "An error without a status code should return 0",
+			err:    errors.New("boom"),
+			want:   0,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := StatusCode(tc.err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nStatusCode(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestHasErrorCode(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		codes  []int
+		want   bool
+	}{
+		"Match": {
+			reason: "An error containing any of the codes should match",
+			err:    errors.Wrap(apiErr(http.StatusBadRequest, 1000, 81044), "wrapped"),
+			codes:  []int{81044, 81057},
+			want:   true,
+		},
+		"NoMatch": {
+			reason: "An error containing none of the codes should not match",
+			err:    apiErr(http.StatusBadRequest, 1000),
+			codes:  []int{81044},
+			want:   false,
+		},
+		"Untyped": {
+			reason: "Codes should not be matched in the message of an untyped error",
+			err:    errors.New("Record not found (81044)"),
+			codes:  []int{81044},
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := HasErrorCode(tc.err, tc.codes...)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nHasErrorCode(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   bool
+	}{
+		"Nil": {
+			reason: "A nil error should not be not found",
+			want:   false,
+		},
+		"NotFound": {
+			reason: "A 404 response should be not found",
+			err:    apiErr(http.StatusNotFound),
+			want:   true,
+		},
+		"NotFoundWithCode": {
+			reason: "A 404 response with a could not route error code should be not found",
+			err:    apiErr(http.StatusNotFound, 7003),
+			want:   true,
+		},
+		"NoAccessWithCode": {
+			reason: "A could not route error code should not be not found without a 404 response, as it is also returned when the credentials in use have no access",
+			err:    apiErr(http.StatusForbidden, 7003),
+			want:   false,
+		},
+		"Untyped": {
+			reason: "An untyped 404 error should be not found",
+			err:    errors.New("HTTP status 404: not found"),
+			want:   true,
+		},
+		"Other": {
+			reason: "Other errors should not be not found",
+			err:    apiErr(http.StatusBadRequest, 1000),
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsNotFound(tc.err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsNotFound(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsRateLimited(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   bool
+	}{
+		"TooManyRequests": {
+			reason: "A 429 response should be rate limited",
+			err:    apiErr(http.StatusTooManyRequests),
+			want:   true,
+		},
+		"RateLimitedCode": {
+			reason: "A rate limited error code should be rate limited",
+			err:    apiErr(http.StatusBadRequest, codeRateLimited),
+			want:   true,
+		},
+		"Other": {
+			reason: "Other errors should not be rate limited",
+			err:    apiErr(http.StatusNotFound),
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsRateLimited(tc.err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsRateLimited(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsAuthz(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   bool
+	}{
+		"Unauthorized": {
+			reason: "An untyped 401 error should be an authz error",
+			err:    errors.New("HTTP status 401: Invalid request headers"),
+			want:   true,
+		},
+		"Forbidden": {
+			reason: "A 403 response should be an authz error",
+			err:    apiErr(http.StatusForbidden),
+			want:   true,
+		},
+		"InvalidToken": {
+			reason: "An invalid access token error code should be an authz error",
+			err:    apiErr(http.StatusBadRequest, codeInvalidAccessToken),
+			want:   true,
+		},
+		"Other": {
+			reason: "Other errors should not be authz errors",
+			err:    apiErr(http.StatusNotFound),
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsAuthz(tc.err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsAuthz(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -55,7 +55,7 @@ func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
 // IsFilterNotFound returns true if the passed error indicates
 // a Filter was not found.
 func IsFilterNotFound(err error) bool {
-	return strings.Contains(err.Error(), "HTTP status 404")
+	return clients.IsNotFound(err)
 }
 
 // GenerateObservation creates an observation of a cloudflare Filter
@@ -0,0 +1,51 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// A MockClient acts as a testable representation of the Cloudflare API.
+type MockClient struct {
+	MockCreateRateLimit func(ctx context.Context, zoneID string, limit cloudflare.RateLimit) (cloudflare.RateLimit, error)
+	MockRateLimit       func(ctx context.Context, zoneID, limitID string) (cloudflare.RateLimit, error)
+	MockUpdateRateLimit func(ctx context.Context, zoneID, limitID string, limit cloudflare.RateLimit) (cloudflare.RateLimit, error)
+	MockDeleteRateLimit func(ctx context.Context, zoneID, limitID string) error
+}
+
+// CreateRateLimit mocks the CreateRateLimit method of the Cloudflare API.
+func (m MockClient) CreateRateLimit(ctx context.Context, zoneID string, limit cloudflare.RateLimit) (cloudflare.RateLimit, error) {
+	return m.MockCreateRateLimit(ctx, zoneID, limit)
+}
+
+// RateLimit mocks the RateLimit method of the Cloudflare API.
+func (m MockClient) RateLimit(ctx context.Context, zoneID, limitID string) (cloudflare.RateLimit, error) {
+	return m.MockRateLimit(ctx, zoneID, limitID)
+}
+
+// UpdateRateLimit mocks the UpdateRateLimit method of the Cloudflare API.
+func (m MockClient) UpdateRateLimit(ctx context.Context, zoneID, limitID string, limit cloudflare.RateLimit) (cloudflare.RateLimit, error) {
+	return m.MockUpdateRateLimit(ctx, zoneID, limitID, limit)
+}
+
+// DeleteRateLimit mocks the DeleteRateLimit method of the Cloudflare API.
+func (m MockClient) DeleteRateLimit(ctx context.Context, zoneID, limitID string) error {
+	return m.MockDeleteRateLimit(ctx, zoneID, limitID)
+}
@@ -0,0 +1,314 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+
+	"github.com/benagricola/provider-cloudflare/apis/firewall/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+const (
+	errCreateRateLimit = "error creating rate limit"
+	errUpdateRateLimit = "error updating rate limit"
+	errSpecNil         = "rate limit spec is empty"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with Rate Limits.
+type Client interface {
+	CreateRateLimit(ctx context.Context, zoneID string, limit cloudflare.RateLimit) (cloudflare.RateLimit, error)
+	RateLimit(ctx context.Context, zoneID, limitID string) (cloudflare.RateLimit, error)
+	UpdateRateLimit(ctx context.Context, zoneID, limitID string, limit cloudflare.RateLimit) (cloudflare.RateLimit, error)
+	DeleteRateLimit(ctx context.Context, zoneID, limitID string) error
+}
+
+// NewClient returns a new Cloudflare API client for working with Rate Limits.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// IsRateLimitNotFound returns true if the passed error indicates
+// a Rate Limit was not found.
+func IsRateLimitNotFound(err error) bool {
+	return clients.IsNotFound(err)
+}
+
+// GenerateObservation creates an observation of a cloudflare Rate Limit
+func GenerateObservation(in cloudflare.RateLimit) v1alpha1.RateLimitObservation {
+	return v1alpha1.RateLimitObservation{}
+}
+
+// LateInitialize initializes RateLimitParameters based on the remote resource
+func LateInitialize(spec *v1alpha1.RateLimitParameters, rl cloudflare.RateLimit) bool {
+	if spec == nil {
+		return false
+	}
+
+	li := false
+
+	if spec.Description == nil && len(rl.Description) > 0 {
+		spec.Description = &rl.Description
+		li = true
+	}
+
+	if spec.Disabled == nil {
+		spec.Disabled = &rl.Disabled
+		li = true
+	}
+
+	if spec.Action.Timeout == nil && rl.Action.Timeout > 0 {
+		t := int64(rl.Action.Timeout)
+		spec.Action.Timeout = &t
+		li = true
+	}
+
+	return li
+}
+
+// UpToDate checks if the remote resource is up to date with the
+// requested resource parameters.
+func UpToDate(spec *v1alpha1.RateLimitParameters, rl cloudflare.RateLimit) bool { //nolint:gocyclo
+	// NOTE: The complexity here is simply repeated if statements
+	// checking for updated fields. Optional fields that are not set
+	// are not compared, as Cloudflare defaults them.
+	if spec == nil {
+		return true
+	}
+
+	if spec.Description != nil && *spec.Description != rl.Description {
+		return false
+	}
+
+	if spec.Disabled != nil && *spec.Disabled != rl.Disabled {
+		return false
+	}
+
+	if spec.Threshold != int64(rl.Threshold) || spec.Period != int64(rl.Period) {
+		return false
+	}
+
+	if !matchUpToDate(spec.Match, rl.Match) {
+		return false
+	}
+
+	if !actionUpToDate(spec.Action, rl.Action) {
+		return false
+	}
+
+	if !cmp.Equal(bypass(spec.Bypass), rl.Bypass, cmpopts.EquateEmpty()) {
+		return false
+	}
+
+	if spec.CorrelateBy != nil && (rl.Correlate == nil || *spec.CorrelateBy != rl.Correlate.By) {
+		return false
+	}
+
+	return true
+}
+
+func matchUpToDate(spec *v1alpha1.RateLimitMatch, m cloudflare.RateLimitTrafficMatcher) bool { //nolint:gocyclo
+	if spec == nil {
+		return true
+	}
+
+	if rq := spec.Request; rq != nil {
+		if len(rq.Methods) > 0 && !cmp.Equal(methods(rq.Methods), m.Request.Methods) {
+			return false
+		}
+		if len(rq.Schemes) > 0 && !cmp.Equal(schemes(rq.Schemes), m.Request.Schemes) {
+			return false
+		}
+		if rq.URL != nil && *rq.URL != m.Request.URLPattern {
+			return false
+		}
+	}
+
+	if rs := spec.Response; rs != nil {
+		if len(rs.Statuses) > 0 && !cmp.Equal(statuses(rs.Statuses), m.Response.Statuses) {
+			return false
+		}
+		// Cloudflare matches origin traffic only unless told otherwise.
+		ot := m.Response.OriginTraffic == nil || *m.Response.OriginTraffic
+		if rs.OriginTraffic != nil && *rs.OriginTraffic != ot {
+			return false
+		}
+		if len(rs.Headers) > 0 && !cmp.Equal(headers(rs.Headers), m.Response.Headers) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func actionUpToDate(spec v1alpha1.RateLimitAction, a cloudflare.RateLimitAction) bool {
+	if spec.Mode != a.Mode {
+		return false
+	}
+
+	if spec.Timeout != nil && *spec.Timeout != int64(a.Timeout) {
+		return false
+	}
+
+	if spec.Response != nil {
+		if a.Response == nil {
+			return false
+		}
+		if spec.Response.ContentType != a.Response.ContentType || spec.Response.Body != a.Response.Body {
+			return false
+		}
+	}
+
+	return true
+}
+
+func methods(in []v1alpha1.RateLimitMethod) []string {
+	if in == nil {
+		return nil
+	}
+	out := make([]string, len(in))
+	for i, m := range in {
+		out[i] = string(m)
+	}
+	return out
+}
+
+func schemes(in []v1alpha1.RateLimitScheme) []string {
+	if in == nil {
+		return nil
+	}
+	out := make([]string, len(in))
+	for i, s := range in {
+		out[i] = string(s)
+	}
+	return out
+}
+
+func statuses(in []int64) []int {
+	if in == nil {
+		return nil
+	}
+	out := make([]int, len(in))
+	for i, s := range in {
+		out[i] = int(s)
+	}
+	return out
+}
+
+func headers(in []v1alpha1.RateLimitResponseHeader) []cloudflare.RateLimitResponseMatcherHeader {
+	if in == nil {
+		return nil
+	}
+	out := make([]cloudflare.RateLimitResponseMatcherHeader, len(in))
+	for i, h := range in {
+		out[i] = cloudflare.RateLimitResponseMatcherHeader{Name: h.Name, Op: h.Op, Value: h.Value}
+	}
+	return out
+}
+
+func bypass(in []v1alpha1.RateLimitBypass) []cloudflare.RateLimitKeyValue {
+	if in == nil {
+		return nil
+	}
+	out := make([]cloudflare.RateLimitKeyValue, len(in))
+	for i, b := range in {
+		out[i] = cloudflare.RateLimitKeyValue{Name: b.Name, Value: b.Value}
+	}
+	return out
+}
+
+// rateLimit converts RateLimitParameters into a cloudflare Rate Limit.
+func rateLimit(spec *v1alpha1.RateLimitParameters) cloudflare.RateLimit { //nolint:gocyclo
+	rl := cloudflare.RateLimit{
+		Threshold: int(spec.Threshold),
+		Period:    int(spec.Period),
+		Bypass:    bypass(spec.Bypass),
+		Action: cloudflare.RateLimitAction{
+			Mode: spec.Action.Mode,
+		},
+	}
+
+	if spec.Description != nil {
+		rl.Description = *spec.Description
+	}
+
+	if spec.Disabled != nil {
+		rl.Disabled = *spec.Disabled
+	}
+
+	if spec.Match != nil {
+		if rq := spec.Match.Request; rq != nil {
+			rl.Match.Request.Methods = methods(rq.Methods)
+			rl.Match.Request.Schemes = schemes(rq.Schemes)
+			if rq.URL != nil {
+				rl.Match.Request.URLPattern = *rq.URL
+			}
+		}
+		if rs := spec.Match.Response; rs != nil {
+			rl.Match.Response.Statuses = statuses(rs.Statuses)
+			rl.Match.Response.OriginTraffic = rs.OriginTraffic
+			rl.Match.Response.Headers = headers(rs.Headers)
+		}
+	}
+
+	if spec.Action.Timeout != nil {
+		rl.Action.Timeout = int(*spec.Action.Timeout)
+	}
+
+	if spec.Action.Response != nil {
+		rl.Action.Response = &cloudflare.RateLimitActionResponse{
+			ContentType: spec.Action.Response.ContentType,
+			Body:        spec.Action.Response.Body,
+		}
+	}
+
+	if spec.CorrelateBy != nil {
+		rl.Correlate = &cloudflare.RateLimitCorrelate{By: *spec.CorrelateBy}
+	}
+
+	return rl
+}
+
+// CreateRateLimit creates a new Rate Limit
+func CreateRateLimit(ctx context.Context, client Client, spec *v1alpha1.RateLimitParameters) (*cloudflare.RateLimit, error) {
+	if spec == nil {
+		return nil, errors.New(errSpecNil)
+	}
+
+	res, err := client.CreateRateLimit(ctx, *spec.Zone, rateLimit(spec))
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateRateLimit)
+	}
+
+	return &res, nil
+}
+
+// UpdateRateLimit updates mutable values on a Rate Limit
+func UpdateRateLimit(ctx context.Context, client Client, limitID string, spec *v1alpha1.RateLimitParameters) error {
+	rl := rateLimit(spec)
+	rl.ID = limitID
+
+	_, err := client.UpdateRateLimit(ctx, *spec.Zone, limitID, rl)
+	return errors.Wrap(err, errUpdateRateLimit)
+}
@@ -0,0 +1,422 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/benagricola/provider-cloudflare/apis/firewall/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/firewall/ratelimit/fake"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	ptr "k8s.io/utils/pointer"
+)
+
+func loginLimit() *v1alpha1.RateLimitParameters {
+	return &v1alpha1.RateLimitParameters{
+		Description: ptr.StringPtr("Protect login"),
+		Threshold:   5,
+		Period:      60,
+		Match: &v1alpha1.RateLimitMatch{
+			Request: &v1alpha1.RateLimitRequestMatch{
+				Methods: []v1alpha1.RateLimitMethod{"POST"},
+				Schemes: []v1alpha1.RateLimitScheme{"HTTPS"},
+				URL:     ptr.StringPtr("example.com/login*"),
+			},
+			Response: &v1alpha1.RateLimitResponseMatch{
+				Statuses:      []int64{401, 403},
+				OriginTraffic: ptr.BoolPtr(true),
+			},
+		},
+		Action: v1alpha1.RateLimitAction{
+			Mode:    "ban",
+			Timeout: ptr.Int64Ptr(600),
+			Response: &v1alpha1.RateLimitActionResponse{
+				ContentType: "text/plain",
+				Body:        "slow down",
+			},
+		},
+		Bypass: []v1alpha1.RateLimitBypass{
+			{Name: "url", Value: "example.com/login/health"},
+		},
+		Zone: ptr.StringPtr("Test Zone"),
+	}
+}
+
+func loginRateLimit() cloudflare.RateLimit {
+	return cloudflare.RateLimit{
+		ID:          "372e67954025e0ba6aaa6d586b9e0b59",
+		Description: "Protect login",
+		Threshold:   5,
+		Period:      60,
+		Match: cloudflare.RateLimitTrafficMatcher{
+			Request: cloudflare.RateLimitRequestMatcher{
+				Methods:    []string{"POST"},
+				Schemes:    []string{"HTTPS"},
+				URLPattern: "example.com/login*",
+			},
+			Response: cloudflare.RateLimitResponseMatcher{
+				Statuses:      []int{401, 403},
+				OriginTraffic: ptr.BoolPtr(true),
+			},
+		},
+		Action: cloudflare.RateLimitAction{
+			Mode:    "ban",
+			Timeout: 600,
+			Response: &cloudflare.RateLimitActionResponse{
+				ContentType: "text/plain",
+				Body:        "slow down",
+			},
+		},
+		Bypass: []cloudflare.RateLimitKeyValue{
+			{Name: "url", Value: "example.com/login/health"},
+		},
+	}
+}
+
+func TestLateInitialize(t *testing.T) {
+	type args struct {
+		rlp *v1alpha1.RateLimitParameters
+		rl  cloudflare.RateLimit
+	}
+
+	type want struct {
+		o   bool
+		rlp *v1alpha1.RateLimitParameters
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"LateInitSpecNil": {
+			reason: "LateInit should return false when not passed a spec",
+			args:   args{},
+			want: want{
+				o: false,
+			},
+		},
+		"LateInitDontUpdate": {
+			reason: "LateInit should not update already-set spec fields from a Rate Limit",
+			args: args{
+				rlp: &v1alpha1.RateLimitParameters{
+					Description: ptr.StringPtr("Protect login"),
+					Disabled:    ptr.BoolPtr(false),
+					Action: v1alpha1.RateLimitAction{
+						Mode:    "ban",
+						Timeout: ptr.Int64Ptr(600),
+					},
+				},
+				rl: cloudflare.RateLimit{
+					Description: "Something else",
+					Disabled:    true,
+					Action: cloudflare.RateLimitAction{
+						Mode:    "ban",
+						Timeout: 60,
+					},
+				},
+			},
+			want: want{
+				o: false,
+				rlp: &v1alpha1.RateLimitParameters{
+					Description: ptr.StringPtr("Protect login"),
+					Disabled:    ptr.BoolPtr(false),
+					Action: v1alpha1.RateLimitAction{
+						Mode:    "ban",
+						Timeout: ptr.Int64Ptr(600),
+					},
+				},
+			},
+		},
+		"LateInitUpdate": {
+			reason: "LateInit should update unset spec fields from a Rate Limit",
+			args: args{
+				rlp: &v1alpha1.RateLimitParameters{
+					Action: v1alpha1.RateLimitAction{
+						Mode: "ban",
+					},
+				},
+				rl: cloudflare.RateLimit{
+					Description: "Protect login",
+					Disabled:    true,
+					Action: cloudflare.RateLimitAction{
+						Mode:    "ban",
+						Timeout: 60,
+					},
+				},
+			},
+			want: want{
+				o: true,
+				rlp: &v1alpha1.RateLimitParameters{
+					Description: ptr.StringPtr("Protect login"),
+					Disabled:    ptr.BoolPtr(true),
+					Action: v1alpha1.RateLimitAction{
+						Mode:    "ban",
+						Timeout: ptr.Int64Ptr(60),
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := LateInitialize(tc.args.rlp, tc.args.rl)
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nLateInitialize(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.rlp, tc.args.rlp); diff != "" {
+				t.Errorf("\n%s\nLateInitialize(...): -want spec, +got spec:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpToDate(t *testing.T) {
+	type args struct {
+		rlp *v1alpha1.RateLimitParameters
+		rl  cloudflare.RateLimit
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   bool
+	}{
+		"UpToDateSpecNil": {
+			reason: "UpToDate should return true when not passed a spec",
+			args:   args{},
+			want:   true,
+		},
+		"UpToDate": {
+			reason: "UpToDate should return true when the Rate Limit matches the spec",
+			args: args{
+				rlp: loginLimit(),
+				rl:  loginRateLimit(),
+			},
+			want: true,
+		},
+		"UpToDateDefaults": {
+			reason: "UpToDate should ignore optional fields that are not set",
+			args: args{
+				rlp: &v1alpha1.RateLimitParameters{
+					Threshold: 5,
+					Period:    60,
+					Match: &v1alpha1.RateLimitMatch{
+						Request: &v1alpha1.RateLimitRequestMatch{
+							URL: ptr.StringPtr("example.com/login*"),
+						},
+						Response: &v1alpha1.RateLimitResponseMatch{
+							OriginTraffic: ptr.BoolPtr(true),
+						},
+					},
+					Action: v1alpha1.RateLimitAction{
+						Mode: "ban",
+					},
+				},
+				rl: cloudflare.RateLimit{
+					Description: "Protect login",
+					Threshold:   5,
+					Period:      60,
+					Match: cloudflare.RateLimitTrafficMatcher{
+						Request: cloudflare.RateLimitRequestMatcher{
+							Methods:    []string{"_ALL_"},
+							Schemes:    []string{"_ALL_"},
+							URLPattern: "example.com/login*",
+						},
+					},
+					Action: cloudflare.RateLimitAction{
+						Mode:    "ban",
+						Timeout: 60,
+					},
+				},
+			},
+			want: true,
+		},
+		"NotUpToDateThreshold": {
+			reason: "UpToDate should return false when the threshold differs",
+			args: args{
+				rlp: loginLimit(),
+				rl: func() cloudflare.RateLimit {
+					rl := loginRateLimit()
+					rl.Threshold = 10
+					return rl
+				}(),
+			},
+			want: false,
+		},
+		"NotUpToDateMethods": {
+			reason: "UpToDate should return false when the matched methods differ",
+			args: args{
+				rlp: loginLimit(),
+				rl: func() cloudflare.RateLimit {
+					rl := loginRateLimit()
+					rl.Match.Request.Methods = []string{"GET", "POST"}
+					return rl
+				}(),
+			},
+			want: false,
+		},
+		"NotUpToDateOriginTraffic": {
+			reason: "UpToDate should treat unset origin traffic as true",
+			args: args{
+				rlp: func() *v1alpha1.RateLimitParameters {
+					rlp := loginLimit()
+					rlp.Match.Response.OriginTraffic = ptr.BoolPtr(false)
+					return rlp
+				}(),
+				rl: func() cloudflare.RateLimit {
+					rl := loginRateLimit()
+					rl.Match.Response.OriginTraffic = nil
+					return rl
+				}(),
+			},
+			want: false,
+		},
+		"NotUpToDateActionResponse": {
+			reason: "UpToDate should return false when the action response differs",
+			args: args{
+				rlp: loginLimit(),
+				rl: func() cloudflare.RateLimit {
+					rl := loginRateLimit()
+					rl.Action.Response = nil
+					return rl
+				}(),
+			},
+			want: false,
+		},
+		"NotUpToDateBypass": {
+			reason: "UpToDate should return false when the bypass criteria differ",
+			args: args{
+				rlp: loginLimit(),
+				rl: func() cloudflare.RateLimit {
+					rl := loginRateLimit()
+					rl.Bypass = nil
+					return rl
+				}(),
+			},
+			want: false,
+		},
+		"NotUpToDateCorrelate": {
+			reason: "UpToDate should return false when the correlation differs",
+			args: args{
+				rlp: func() *v1alpha1.RateLimitParameters {
+					rlp := loginLimit()
+					rlp.CorrelateBy = ptr.StringPtr("nat")
+					return rlp
+				}(),
+				rl: loginRateLimit(),
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := UpToDate(tc.args.rlp, tc.args.rl)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreateRateLimit(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		client Client
+		rlp    *v1alpha1.RateLimitParameters
+	}
+
+	type want struct {
+		rl  *cloudflare.RateLimit
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ErrSpecNil": {
+			reason: "CreateRateLimit should return an error when not passed a spec",
+			args: args{
+				client: fake.MockClient{},
+			},
+			want: want{
+				err: errors.New(errSpecNil),
+			},
+		},
+		"ErrCreate": {
+			reason: "CreateRateLimit should wrap errors from the API",
+			args: args{
+				client: fake.MockClient{
+					MockCreateRateLimit: func(ctx context.Context, zoneID string, limit cloudflare.RateLimit) (cloudflare.RateLimit, error) {
+						return cloudflare.RateLimit{}, errBoom
+					},
+				},
+				rlp: loginLimit(),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errCreateRateLimit),
+			},
+		},
+		"Success": {
+			reason: "CreateRateLimit should send the Rate Limit built from the spec",
+			args: args{
+				client: fake.MockClient{
+					MockCreateRateLimit: func(ctx context.Context, zoneID string, limit cloudflare.RateLimit) (cloudflare.RateLimit, error) {
+						want := loginRateLimit()
+						want.ID = ""
+						if diff := cmp.Diff(want, limit); diff != "" {
+							return cloudflare.RateLimit{}, errors.New(diff)
+						}
+						limit.ID = "372e67954025e0ba6aaa6d586b9e0b59"
+						return limit, nil
+					},
+				},
+				rlp: loginLimit(),
+			},
+			want: want{
+				rl: func() *cloudflare.RateLimit {
+					rl := loginRateLimit()
+					return &rl
+				}(),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := CreateRateLimit(context.Background(), tc.args.client, tc.args.rlp)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreateRateLimit(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.rl, got); diff != "" {
+				t.Errorf("\n%s\nCreateRateLimit(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
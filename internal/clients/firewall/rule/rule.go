@@ -19,7 +19,6 @@ package rule
 import (
 	"context"
 	"net/http"
-	"strings"
 
 	"github.com/google/go-cmp/cmp"
 
@@ -55,7 +54,7 @@ func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
 // IsRuleNotFound returns true if the passed error indicates
 // a Rule was not found.
 func IsRuleNotFound(err error) bool {
-	return strings.Contains(err.Error(), "HTTP status 404")
+	return clients.IsNotFound(err)
 }
 
 // GenerateObservation creates an observation of a cloudflare Rule
@@ -0,0 +1,33 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// A MockClient acts as a testable representation of the Cloudflare API.
+type MockClient struct {
+	MockFirewallRule func(ctx context.Context, zoneID, ruleID string) (cloudflare.FirewallRule, error)
+}
+
+// FirewallRule mocks the FirewallRule method of the Cloudflare API.
+func (m MockClient) FirewallRule(ctx context.Context, zoneID, ruleID string) (cloudflare.FirewallRule, error) {
+	return m.MockFirewallRule(ctx, zoneID, ruleID)
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ruleorder
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sort"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/cloudflare/cloudflare-go"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/benagricola/provider-cloudflare/apis/firewall/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+const (
+	// maxPriority is the highest priority of a Firewall Rule.
+	maxPriority = math.MaxInt32
+
+	errPriorityOverflow = "priority of rule %d would exceed the maximum priority of %d"
+	errNoZone           = "rule %q has no zone"
+	errZoneMismatch     = "rule %q is on zone %q, not %q"
+
+	errRuleOrderPriorities = "cannot compute priorities of rule order %q"
+)
+
+// RuleRefsIndexKey is the field index of RuleOrders by the names of the
+// Rules they order.
+const RuleRefsIndexKey = "spec.forProvider.ruleRefs.name"
+
+// Client is a Cloudflare API client that implements methods for observing
+// the order of Firewall rules.
+type Client interface {
+	FirewallRule(ctx context.Context, zoneID, firewallRuleID string) (cloudflare.FirewallRule, error)
+}
+
+// NewClient returns a new Cloudflare API client for observing the order of
+// Firewall rules.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// Priorities returns the priority to assign to each referenced Rule, in
+// order. Priorities are computed rather than stored, so that inserting a
+// Rule reorders every Rule after it.
+func Priorities(spec *v1alpha1.RuleOrderParameters) ([]int32, error) {
+	start, step := int64(1), int64(1)
+	if spec.StartPriority != nil {
+		start = int64(*spec.StartPriority)
+	}
+	if spec.PriorityStep != nil {
+		step = int64(*spec.PriorityStep)
+	}
+
+	p := make([]int32, len(spec.RuleRefs))
+	for i := range p {
+		v := start + int64(i)*step
+		if v > maxPriority {
+			return nil, errors.Errorf(errPriorityOverflow, i+1, maxPriority)
+		}
+		p[i] = int32(v)
+	}
+	return p, nil
+}
+
+// Zone returns the ID of the Zone the passed Rules are on, or an error if
+// they are not all on the same Zone, as priorities only order Rules on
+// the same Zone.
+func Zone(rules []v1alpha1.Rule) (string, error) {
+	zone := ""
+	for i := range rules {
+		z := rules[i].Spec.ForProvider.Zone
+		if z == nil {
+			return "", errors.Errorf(errNoZone, rules[i].GetName())
+		}
+		if zone == "" {
+			zone = *z
+		}
+		if *z != zone {
+			return "", errors.Errorf(errZoneMismatch, rules[i].GetName(), *z, zone)
+		}
+	}
+	return zone, nil
+}
+
+// ObservedPriority returns the priority of the passed Firewall Rule, or
+// nil if it has no priority.
+func ObservedPriority(r cloudflare.FirewallRule) *int32 {
+	// Priority should be a whole number
+	p, ok := r.Priority.(float64)
+	if !ok {
+		return nil
+	}
+	v := int32(p)
+	return &v
+}
+
+// GenerateObservation creates an observation of the passed Rules, assigning
+// them the passed priorities. The priority of each Firewall Rule that has
+// been created is observed by the passed function.
+func GenerateObservation(zone string, rules []v1alpha1.Rule, priorities []int32, observe func(id string) (*int32, error)) (v1alpha1.RuleOrderObservation, error) {
+	o := v1alpha1.RuleOrderObservation{
+		Zone:  zone,
+		Rules: make([]v1alpha1.RuleOrderRuleObservation, len(rules)),
+	}
+	for i := range rules {
+		ro := v1alpha1.RuleOrderRuleObservation{
+			Name:     rules[i].GetName(),
+			ID:       meta.GetExternalName(&rules[i]),
+			Priority: priorities[i],
+		}
+		if ro.ID != "" {
+			p, err := observe(ro.ID)
+			if err != nil {
+				return v1alpha1.RuleOrderObservation{}, err
+			}
+			ro.ObservedPriority = p
+		}
+		o.Rules[i] = ro
+	}
+	return o, nil
+}
+
+// RemoteUpToDate returns true if the observed Firewall Rule has not been
+// created yet, or has the priority in the passed observation.
+func RemoteUpToDate(o v1alpha1.RuleOrderRuleObservation) bool {
+	return o.ID == "" || (o.ObservedPriority != nil && *o.ObservedPriority == o.Priority)
+}
+
+// UpToDate returns true if each Firewall Rule that has been created has
+// the priority in the passed observation in Cloudflare.
+func UpToDate(o v1alpha1.RuleOrderObservation) bool {
+	for i := range o.Rules {
+		if !RemoteUpToDate(o.Rules[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// position returns the position of the named Rule in the passed RuleOrder,
+// or -1 if it is not ordered by it.
+func position(spec *v1alpha1.RuleOrderParameters, rule string) int {
+	for i, ref := range spec.RuleRefs {
+		if ref.Name == rule {
+			return i
+		}
+	}
+	return -1
+}
+
+// IndexRuleRefs returns the names of the Rules ordered by the passed
+// RuleOrder, so that RuleOrders may be indexed by RuleRefsIndexKey.
+func IndexRuleRefs(o client.Object) []string {
+	ro, ok := o.(*v1alpha1.RuleOrder)
+	if !ok {
+		return nil
+	}
+	names := make([]string, len(ro.Spec.ForProvider.RuleRefs))
+	for i, ref := range ro.Spec.ForProvider.RuleRefs {
+		names[i] = ref.Name
+	}
+	return names
+}
+
+// References returns true if the passed RuleOrder orders the named Rule.
+func References(spec *v1alpha1.RuleOrderParameters, rule string) bool {
+	return position(spec, rule) >= 0
+}
+
+// AssignedPriority returns the name of the RuleOrder that orders the named
+// Rule and the priority it assigns to the Rule, or a nil priority if the
+// Rule is not ordered. A RuleOrder that is being deleted no longer orders
+// its Rules. If more than one RuleOrder orders the Rule, the first by name
+// is used.
+func AssignedPriority(ros []v1alpha1.RuleOrder, rule string) (string, *int32, error) {
+	sorted := make([]v1alpha1.RuleOrder, len(ros))
+	copy(sorted, ros)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].GetName() < sorted[j].GetName() })
+
+	for i := range sorted {
+		ro := &sorted[i]
+		pos := position(&ro.Spec.ForProvider, rule)
+		if pos < 0 || meta.WasDeleted(ro) {
+			continue
+		}
+		p, err := Priorities(&ro.Spec.ForProvider)
+		if err != nil {
+			return "", nil, errors.Wrapf(err, errRuleOrderPriorities, ro.GetName())
+		}
+		return ro.GetName(), &p[pos], nil
+	}
+	return "", nil, nil
+}
@@ -0,0 +1,332 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ruleorder
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ptr "k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/firewall/v1alpha1"
+)
+
+func refs(names ...string) []xpv1.Reference {
+	r := make([]xpv1.Reference, len(names))
+	for i, n := range names {
+		r[i] = xpv1.Reference{Name: n}
+	}
+	return r
+}
+
+func rule(name, zone, id string, priority *int32) v1alpha1.Rule {
+	r := v1alpha1.Rule{}
+	r.SetName(name)
+	if zone != "" {
+		r.Spec.ForProvider.Zone = &zone
+	}
+	if id != "" {
+		meta.SetExternalName(&r, id)
+	}
+	r.Spec.ForProvider.Priority = priority
+	return r
+}
+
+func TestPriorities(t *testing.T) {
+	type want struct {
+		p   []int32
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		spec   *v1alpha1.RuleOrderParameters
+		want   want
+	}{
+		"Defaults": {
+			reason: "Rules should be assigned consecutive priorities from 1 by default",
+			spec:   &v1alpha1.RuleOrderParameters{RuleRefs: refs("a", "b", "c")},
+			want:   want{p: []int32{1, 2, 3}},
+		},
+		"StartAndStep": {
+			reason: "Rules should be assigned priorities from the start priority, separated by the step",
+			spec: &v1alpha1.RuleOrderParameters{
+				RuleRefs:      refs("a", "b", "c"),
+				StartPriority: ptr.Int32Ptr(100),
+				PriorityStep:  ptr.Int32Ptr(10),
+			},
+			want: want{p: []int32{100, 110, 120}},
+		},
+		"Overflow": {
+			reason: "An error should be returned if a priority would exceed the maximum priority",
+			spec: &v1alpha1.RuleOrderParameters{
+				RuleRefs:      refs("a", "b"),
+				StartPriority: ptr.Int32Ptr(maxPriority),
+			},
+			want: want{err: errors.Errorf(errPriorityOverflow, 2, maxPriority)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Priorities(tc.spec)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nPriorities(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.p, got); diff != "" {
+				t.Errorf("\n%s\nPriorities(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestZone(t *testing.T) {
+	type want struct {
+		zone string
+		err  error
+	}
+
+	cases := map[string]struct {
+		reason string
+		rules  []v1alpha1.Rule
+		want   want
+	}{
+		"SameZone": {
+			reason: "The zone of the Rules should be returned if they share it",
+			rules:  []v1alpha1.Rule{rule("a", "z1", "", nil), rule("b", "z1", "", nil)},
+			want:   want{zone: "z1"},
+		},
+		"NoZone": {
+			reason: "An error should be returned if a Rule has no zone",
+			rules:  []v1alpha1.Rule{rule("a", "z1", "", nil), rule("b", "", "", nil)},
+			want:   want{err: errors.Errorf(errNoZone, "b")},
+		},
+		"DifferentZones": {
+			reason: "An error should be returned if the Rules are on different zones",
+			rules:  []v1alpha1.Rule{rule("a", "z1", "", nil), rule("b", "z2", "", nil)},
+			want:   want{err: errors.Errorf(errZoneMismatch, "b", "z2", "z1")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Zone(tc.rules)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nZone(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.zone, got); diff != "" {
+				t.Errorf("\n%s\nZone(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateObservation(t *testing.T) {
+	rules := []v1alpha1.Rule{
+		rule("a", "z1", "id-a", nil),
+		rule("b", "z1", "", nil),
+	}
+	observed := map[string]*int32{"id-a": ptr.Int32Ptr(5)}
+
+	got, err := GenerateObservation("z1", rules, []int32{1, 2}, func(id string) (*int32, error) {
+		return observed[id], nil
+	})
+	if err != nil {
+		t.Fatalf("GenerateObservation(...): unexpected error: %s", err)
+	}
+
+	want := v1alpha1.RuleOrderObservation{
+		Zone: "z1",
+		Rules: []v1alpha1.RuleOrderRuleObservation{
+			{Name: "a", ID: "id-a", Priority: 1, ObservedPriority: ptr.Int32Ptr(5)},
+			{Name: "b", Priority: 2},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GenerateObservation(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		o      v1alpha1.RuleOrderObservation
+		want   bool
+	}{
+		"UpToDate": {
+			reason: "Rules should be up to date if they have their priority",
+			o: v1alpha1.RuleOrderObservation{Rules: []v1alpha1.RuleOrderRuleObservation{
+				{Name: "a", ID: "id-a", Priority: 1, ObservedPriority: ptr.Int32Ptr(1)},
+			}},
+			want: true,
+		},
+		"NotCreated": {
+			reason: "Rules that have not been created should be up to date",
+			o: v1alpha1.RuleOrderObservation{Rules: []v1alpha1.RuleOrderRuleObservation{
+				{Name: "a", Priority: 1},
+			}},
+			want: true,
+		},
+		"Reordered": {
+			reason: "Rules should not be up to date if they were reordered outside of Crossplane",
+			o: v1alpha1.RuleOrderObservation{Rules: []v1alpha1.RuleOrderRuleObservation{
+				{Name: "a", ID: "id-a", Priority: 1, ObservedPriority: ptr.Int32Ptr(7)},
+			}},
+			want: false,
+		},
+		"NoRemotePriority": {
+			reason: "Rules should not be up to date if they have no priority in Cloudflare",
+			o: v1alpha1.RuleOrderObservation{Rules: []v1alpha1.RuleOrderRuleObservation{
+				{Name: "a", ID: "id-a", Priority: 1},
+			}},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := UpToDate(tc.o)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReferences(t *testing.T) {
+	spec := &v1alpha1.RuleOrderParameters{RuleRefs: refs("a", "b")}
+
+	if !References(spec, "b") {
+		t.Errorf("References(...): want true for an ordered Rule, got false")
+	}
+	if References(spec, "c") {
+		t.Errorf("References(...): want false for a Rule that is not ordered, got true")
+	}
+}
+
+func TestIndexRuleRefs(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		o      client.Object
+		want   []string
+	}{
+		"RuleOrder": {
+			reason: "The names of the ordered Rules should be indexed",
+			o:      &v1alpha1.RuleOrder{Spec: v1alpha1.RuleOrderSpec{ForProvider: v1alpha1.RuleOrderParameters{RuleRefs: refs("a", "b")}}},
+			want:   []string{"a", "b"},
+		},
+		"NotRuleOrder": {
+			reason: "Nothing should be indexed for an object that is not a RuleOrder",
+			o:      &v1alpha1.Rule{},
+			want:   nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IndexRuleRefs(tc.o)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIndexRuleRefs(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestAssignedPriority(t *testing.T) {
+	ruleOrder := func(name string, start int32, deleted bool, rules ...string) v1alpha1.RuleOrder {
+		ro := v1alpha1.RuleOrder{}
+		ro.SetName(name)
+		ro.Spec.ForProvider.RuleRefs = refs(rules...)
+		ro.Spec.ForProvider.StartPriority = &start
+		if deleted {
+			now := metav1.Now()
+			ro.SetDeletionTimestamp(&now)
+		}
+		return ro
+	}
+
+	type want struct {
+		name     string
+		priority *int32
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason string
+		ros    []v1alpha1.RuleOrder
+		rule   string
+		want   want
+	}{
+		"NotOrdered": {
+			reason: "A Rule that is not ordered by any RuleOrder should not be assigned a priority",
+			ros:    []v1alpha1.RuleOrder{ruleOrder("example", 1, false, "a")},
+			rule:   "b",
+			want:   want{},
+		},
+		"Ordered": {
+			reason: "A Rule should be assigned the priority of its position in the RuleOrder that orders it",
+			ros:    []v1alpha1.RuleOrder{ruleOrder("example", 10, false, "a", "b")},
+			rule:   "b",
+			want:   want{name: "example", priority: ptr.Int32Ptr(11)},
+		},
+		"FirstByName": {
+			reason: "A Rule ordered by more than one RuleOrder should be assigned its priority by the first by name",
+			ros: []v1alpha1.RuleOrder{
+				ruleOrder("second", 20, false, "a"),
+				ruleOrder("first", 10, false, "a"),
+			},
+			rule: "a",
+			want: want{name: "first", priority: ptr.Int32Ptr(10)},
+		},
+		"Deleted": {
+			reason: "A RuleOrder that is being deleted should no longer assign priorities",
+			ros:    []v1alpha1.RuleOrder{ruleOrder("example", 10, true, "a")},
+			rule:   "a",
+			want:   want{},
+		},
+		"Overflow": {
+			reason: "An error should be returned if the RuleOrder cannot compute its priorities",
+			ros:    []v1alpha1.RuleOrder{ruleOrder("example", maxPriority, false, "a", "b")},
+			rule:   "a",
+			want: want{
+				err: errors.Wrapf(errors.Errorf(errPriorityOverflow, 2, maxPriority), errRuleOrderPriorities, "example"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			n, p, err := AssignedPriority(tc.ros, tc.rule)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nAssignedPriority(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.name, n); diff != "" {
+				t.Errorf("\n%s\nAssignedPriority(...): -want name, +got name:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.priority, p); diff != "" {
+				t.Errorf("\n%s\nAssignedPriority(...): -want priority, +got priority:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
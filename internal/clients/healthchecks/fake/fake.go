@@ -0,0 +1,51 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// A MockClient acts as a testable representation of the Cloudflare API.
+type MockClient struct {
+	MockHealthcheck       func(ctx context.Context, zoneID, healthcheckID string) (cloudflare.Healthcheck, error)
+	MockCreateHealthcheck func(ctx context.Context, zoneID string, healthcheck cloudflare.Healthcheck) (cloudflare.Healthcheck, error)
+	MockUpdateHealthcheck func(ctx context.Context, zoneID string, healthcheckID string, healthcheck cloudflare.Healthcheck) (cloudflare.Healthcheck, error)
+	MockDeleteHealthcheck func(ctx context.Context, zoneID string, healthcheckID string) error
+}
+
+// Healthcheck mocks the Healthcheck method of the Cloudflare API.
+func (m MockClient) Healthcheck(ctx context.Context, zoneID, healthcheckID string) (cloudflare.Healthcheck, error) {
+	return m.MockHealthcheck(ctx, zoneID, healthcheckID)
+}
+
+// CreateHealthcheck mocks the CreateHealthcheck method of the Cloudflare API.
+func (m MockClient) CreateHealthcheck(ctx context.Context, zoneID string, healthcheck cloudflare.Healthcheck) (cloudflare.Healthcheck, error) {
+	return m.MockCreateHealthcheck(ctx, zoneID, healthcheck)
+}
+
+// UpdateHealthcheck mocks the UpdateHealthcheck method of the Cloudflare API.
+func (m MockClient) UpdateHealthcheck(ctx context.Context, zoneID string, healthcheckID string, healthcheck cloudflare.Healthcheck) (cloudflare.Healthcheck, error) {
+	return m.MockUpdateHealthcheck(ctx, zoneID, healthcheckID, healthcheck)
+}
+
+// DeleteHealthcheck mocks the DeleteHealthcheck method of the Cloudflare API.
+func (m MockClient) DeleteHealthcheck(ctx context.Context, zoneID string, healthcheckID string) error {
+	return m.MockDeleteHealthcheck(ctx, zoneID, healthcheckID)
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthchecks
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/benagricola/provider-cloudflare/apis/healthchecks/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+// Statuses of the most recent check of a HealthCheck.
+const (
+	StatusUnknown   = "unknown"
+	StatusHealthy   = "healthy"
+	StatusUnhealthy = "unhealthy"
+	StatusSuspended = "suspended"
+)
+
+const (
+	errCreateHealthCheck = "error creating health check"
+	errUpdateHealthCheck = "error updating health check"
+)
+
+// Defaults of Cloudflare for the checks that are always sent, as the
+// cloudflare-go library does not omit them when they are not set.
+const (
+	defaultHTTPMethod = "GET"
+	defaultHTTPPath   = "/"
+	defaultTCPMethod  = "connection_established"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with standalone Health Checks.
+type Client interface {
+	Healthcheck(ctx context.Context, zoneID, healthcheckID string) (cloudflare.Healthcheck, error)
+	CreateHealthcheck(ctx context.Context, zoneID string, healthcheck cloudflare.Healthcheck) (cloudflare.Healthcheck, error)
+	UpdateHealthcheck(ctx context.Context, zoneID string, healthcheckID string, healthcheck cloudflare.Healthcheck) (cloudflare.Healthcheck, error)
+	DeleteHealthcheck(ctx context.Context, zoneID string, healthcheckID string) error
+}
+
+// NewClient returns a new Cloudflare API client for working with Health
+// Checks.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// IsHealthCheckNotFound returns true if the passed error indicates
+// a Health Check was not found.
+func IsHealthCheckNotFound(err error) bool {
+	return clients.IsNotFound(err)
+}
+
+// GenerateObservation creates an observation of a Health Check.
+func GenerateObservation(in cloudflare.Healthcheck) v1alpha1.HealthCheckObservation {
+	o := v1alpha1.HealthCheckObservation{
+		Status:        in.Status,
+		FailureReason: in.FailureReason,
+	}
+	if in.CreatedOn != nil {
+		t := metav1.NewTime(*in.CreatedOn)
+		o.CreatedOn = &t
+	}
+	if in.ModifiedOn != nil {
+		t := metav1.NewTime(*in.ModifiedOn)
+		o.ModifiedOn = &t
+	}
+	return o
+}
+
+func newHTTPConfig(in *v1alpha1.HealthCheckHTTPConfig) *cloudflare.HealthcheckHTTPConfig {
+	if in == nil {
+		return nil
+	}
+
+	c := &cloudflare.HealthcheckHTTPConfig{
+		Method:        defaultHTTPMethod,
+		Path:          defaultHTTPPath,
+		ExpectedCodes: in.ExpectedCodes,
+		Header:        in.Header,
+	}
+	if in.Method != nil {
+		c.Method = *in.Method
+	}
+	if in.Port != nil {
+		c.Port = uint16(*in.Port)
+	}
+	if in.Path != nil {
+		c.Path = *in.Path
+	}
+	if in.ExpectedBody != nil {
+		c.ExpectedBody = *in.ExpectedBody
+	}
+	if in.FollowRedirects != nil {
+		c.FollowRedirects = *in.FollowRedirects
+	}
+	if in.AllowInsecure != nil {
+		c.AllowInsecure = *in.AllowInsecure
+	}
+	return c
+}
+
+func newTCPConfig(in *v1alpha1.HealthCheckTCPConfig) *cloudflare.HealthcheckTCPConfig {
+	if in == nil {
+		return nil
+	}
+
+	c := &cloudflare.HealthcheckTCPConfig{Method: defaultTCPMethod}
+	if in.Method != nil {
+		c.Method = *in.Method
+	}
+	if in.Port != nil {
+		c.Port = uint16(*in.Port)
+	}
+	return c
+}
+
+// NewHealthCheck returns a Health Check as represented by the Cloudflare
+// API, from the requested resource parameters. Cloudflare uses its own
+// defaults for fields that are not set.
+func NewHealthCheck(spec v1alpha1.HealthCheckParameters) cloudflare.Healthcheck {
+	hc := cloudflare.Healthcheck{
+		Name:       spec.Name,
+		Address:    spec.Address,
+		HTTPConfig: newHTTPConfig(spec.HTTPConfig),
+		TCPConfig:  newTCPConfig(spec.TCPConfig),
+	}
+	if spec.Description != nil {
+		hc.Description = *spec.Description
+	}
+	if spec.Type != nil {
+		hc.Type = *spec.Type
+	}
+	if spec.Suspended != nil {
+		hc.Suspended = *spec.Suspended
+	}
+	for _, r := range spec.CheckRegions {
+		hc.CheckRegions = append(hc.CheckRegions, string(r))
+	}
+	if spec.Retries != nil {
+		hc.Retries = *spec.Retries
+	}
+	if spec.Timeout != nil {
+		hc.Timeout = *spec.Timeout
+	}
+	if spec.Interval != nil {
+		hc.Interval = *spec.Interval
+	}
+	if spec.ConsecutiveSuccesses != nil {
+		hc.ConsecutiveSuccesses = *spec.ConsecutiveSuccesses
+	}
+	if spec.ConsecutiveFails != nil {
+		hc.ConsecutiveFails = *spec.ConsecutiveFails
+	}
+	return hc
+}
+
+// sortStrings compares slices of strings regardless of their order.
+var sortStrings = cmpopts.SortSlices(func(a, b string) bool { return a < b })
+
+func stringUpToDate(want *string, got string) bool {
+	return want == nil || *want == got
+}
+
+func intUpToDate(want *int, got int) bool {
+	return want == nil || *want == got
+}
+
+func boolUpToDate(want *bool, got bool) bool {
+	return want == nil || *want == got
+}
+
+func httpConfigUpToDate(spec *v1alpha1.HealthCheckHTTPConfig, c *cloudflare.HealthcheckHTTPConfig) bool {
+	if spec == nil {
+		return true
+	}
+	if c == nil {
+		return false
+	}
+
+	if spec.ExpectedCodes != nil && !cmp.Equal(spec.ExpectedCodes, c.ExpectedCodes, cmpopts.EquateEmpty(), sortStrings) {
+		return false
+	}
+	if spec.Header != nil && !cmp.Equal(spec.Header, c.Header, cmpopts.EquateEmpty()) {
+		return false
+	}
+
+	return stringUpToDate(spec.Method, c.Method) &&
+		intUpToDate(spec.Port, int(c.Port)) &&
+		stringUpToDate(spec.Path, c.Path) &&
+		stringUpToDate(spec.ExpectedBody, c.ExpectedBody) &&
+		boolUpToDate(spec.FollowRedirects, c.FollowRedirects) &&
+		boolUpToDate(spec.AllowInsecure, c.AllowInsecure)
+}
+
+func tcpConfigUpToDate(spec *v1alpha1.HealthCheckTCPConfig, c *cloudflare.HealthcheckTCPConfig) bool {
+	if spec == nil {
+		return true
+	}
+	if c == nil {
+		return false
+	}
+
+	return stringUpToDate(spec.Method, c.Method) &&
+		intUpToDate(spec.Port, int(c.Port))
+}
+
+// UpToDate checks if the remote Health Check is up to date with the
+// requested resource parameters. Fields that are not set in the
+// parameters are left to the defaults of Cloudflare, so are not compared.
+func UpToDate(spec *v1alpha1.HealthCheckParameters, hc cloudflare.Healthcheck) bool {
+	if spec == nil {
+		return true
+	}
+
+	if spec.Name != hc.Name || spec.Address != hc.Address {
+		return false
+	}
+
+	if spec.CheckRegions != nil &&
+		!cmp.Equal(NewHealthCheck(*spec).CheckRegions, hc.CheckRegions, cmpopts.EquateEmpty(), sortStrings) {
+		return false
+	}
+
+	return stringUpToDate(spec.Description, hc.Description) &&
+		stringUpToDate(spec.Type, hc.Type) &&
+		boolUpToDate(spec.Suspended, hc.Suspended) &&
+		intUpToDate(spec.Retries, hc.Retries) &&
+		intUpToDate(spec.Timeout, hc.Timeout) &&
+		intUpToDate(spec.Interval, hc.Interval) &&
+		intUpToDate(spec.ConsecutiveSuccesses, hc.ConsecutiveSuccesses) &&
+		intUpToDate(spec.ConsecutiveFails, hc.ConsecutiveFails) &&
+		httpConfigUpToDate(spec.HTTPConfig, hc.HTTPConfig) &&
+		tcpConfigUpToDate(spec.TCPConfig, hc.TCPConfig)
+}
+
+// CreateHealthCheck creates a new Health Check.
+func CreateHealthCheck(ctx context.Context, client Client, zoneID string, spec v1alpha1.HealthCheckParameters) (cloudflare.Healthcheck, error) {
+	hc, err := client.CreateHealthcheck(ctx, zoneID, NewHealthCheck(spec))
+	return hc, errors.Wrap(err, errCreateHealthCheck)
+}
+
+// UpdateHealthCheck replaces the Health Check with the given ID.
+func UpdateHealthCheck(ctx context.Context, client Client, zoneID, healthCheckID string, spec v1alpha1.HealthCheckParameters) error {
+	_, err := client.UpdateHealthcheck(ctx, zoneID, healthCheckID, NewHealthCheck(spec))
+	return errors.Wrap(err, errUpdateHealthCheck)
+}
@@ -0,0 +1,296 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthchecks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ptr "k8s.io/utils/pointer"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/healthchecks/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/healthchecks/fake"
+)
+
+const zoneID = "023e105f4ecef8ad9ca31a8372d0c353"
+
+func healthCheckSpec() v1alpha1.HealthCheckParameters {
+	interval := 30
+	return v1alpha1.HealthCheckParameters{
+		Name:         "origin",
+		Address:      "origin.example.com",
+		Type:         ptr.StringPtr("HTTPS"),
+		CheckRegions: []v1alpha1.HealthCheckRegion{"WEU", "ENAM"},
+		Interval:     &interval,
+		HTTPConfig: &v1alpha1.HealthCheckHTTPConfig{
+			Path:          ptr.StringPtr("/health"),
+			ExpectedCodes: []string{"200", "204"},
+		},
+	}
+}
+
+// observed returns a Health Check as returned by Cloudflare for the
+// parameters returned by healthCheckSpec, including its defaults.
+func observed(m ...func(*cloudflare.Healthcheck)) cloudflare.Healthcheck {
+	hc := cloudflare.Healthcheck{
+		ID:           "699d98642c564d2e855e9661899b7252",
+		Name:         "origin",
+		Address:      "origin.example.com",
+		Type:         "HTTPS",
+		CheckRegions: []string{"ENAM", "WEU"},
+		Retries:      2,
+		Timeout:      5,
+		Interval:     30,
+		HTTPConfig: &cloudflare.HealthcheckHTTPConfig{
+			Method:        "GET",
+			Port:          443,
+			Path:          "/health",
+			ExpectedCodes: []string{"204", "200"},
+		},
+		Status: StatusHealthy,
+	}
+	for _, f := range m {
+		f(&hc)
+	}
+	return hc
+}
+
+func TestGenerateObservation(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	mt := metav1.NewTime(now)
+
+	cases := map[string]struct {
+		reason string
+		hc     cloudflare.Healthcheck
+		want   v1alpha1.HealthCheckObservation
+	}{
+		"Healthy": {
+			reason: "The status and timestamps of a Health Check should be observed",
+			hc:     cloudflare.Healthcheck{Status: StatusHealthy, CreatedOn: &now, ModifiedOn: &now},
+			want:   v1alpha1.HealthCheckObservation{Status: StatusHealthy, CreatedOn: &mt, ModifiedOn: &mt},
+		},
+		"Unhealthy": {
+			reason: "The reason a Health Check failed should be observed",
+			hc:     cloudflare.Healthcheck{Status: StatusUnhealthy, FailureReason: "TCP connection failed"},
+			want:   v1alpha1.HealthCheckObservation{Status: StatusUnhealthy, FailureReason: "TCP connection failed"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateObservation(tc.hc)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateObservation(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		spec   *v1alpha1.HealthCheckParameters
+		hc     cloudflare.Healthcheck
+		want   bool
+	}{
+		"NilSpec": {
+			reason: "A nil spec should always be up to date",
+			hc:     observed(),
+			want:   true,
+		},
+		"UpToDate": {
+			reason: "Defaults set by Cloudflare and the order of lists should be ignored",
+			spec: func() *v1alpha1.HealthCheckParameters {
+				s := healthCheckSpec()
+				return &s
+			}(),
+			hc:   observed(),
+			want: true,
+		},
+		"AddressChanged": {
+			reason: "A Health Check with a different address should not be up to date",
+			spec: func() *v1alpha1.HealthCheckParameters {
+				s := healthCheckSpec()
+				s.Address = "backup.example.com"
+				return &s
+			}(),
+			hc:   observed(),
+			want: false,
+		},
+		"RegionsChanged": {
+			reason: "A Health Check sent from different regions should not be up to date",
+			spec: func() *v1alpha1.HealthCheckParameters {
+				s := healthCheckSpec()
+				s.CheckRegions = []v1alpha1.HealthCheckRegion{"WEU"}
+				return &s
+			}(),
+			hc:   observed(),
+			want: false,
+		},
+		"ExpectedCodesChanged": {
+			reason: "A Health Check expecting different response codes should not be up to date",
+			spec: func() *v1alpha1.HealthCheckParameters {
+				s := healthCheckSpec()
+				s.HTTPConfig.ExpectedCodes = []string{"2xx"}
+				return &s
+			}(),
+			hc:   observed(),
+			want: false,
+		},
+		"MissingTCPConfig": {
+			reason: "A Health Check without a requested TCP config should not be up to date",
+			spec: func() *v1alpha1.HealthCheckParameters {
+				s := healthCheckSpec()
+				s.TCPConfig = &v1alpha1.HealthCheckTCPConfig{Method: ptr.StringPtr("connection_established")}
+				return &s
+			}(),
+			hc:   observed(),
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := UpToDate(tc.spec, tc.hc)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreateHealthCheck(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	desired := cloudflare.Healthcheck{
+		Name:         "origin",
+		Address:      "origin.example.com",
+		Type:         "HTTPS",
+		CheckRegions: []string{"WEU", "ENAM"},
+		Interval:     30,
+		HTTPConfig: &cloudflare.HealthcheckHTTPConfig{
+			Method:        "GET",
+			Path:          "/health",
+			ExpectedCodes: []string{"200", "204"},
+		},
+	}
+
+	type want struct {
+		hc   cloudflare.Healthcheck
+		err  error
+		sent cloudflare.Healthcheck
+	}
+
+	cases := map[string]struct {
+		reason string
+		hc     cloudflare.Healthcheck
+		err    error
+		want   want
+	}{
+		"CreateFailed": {
+			reason: "Errors creating the Health Check should be wrapped",
+			err:    errBoom,
+			want: want{
+				err:  errors.Wrap(errBoom, errCreateHealthCheck),
+				sent: desired,
+			},
+		},
+		"Success": {
+			reason: "The created Health Check should be returned",
+			hc: cloudflare.Healthcheck{
+				ID:      "699d98642c564d2e855e9661899b7252",
+				Name:    "origin",
+				Address: "origin.example.com",
+				Type:    "HTTPS",
+				Status:  StatusUnknown,
+			},
+			want: want{
+				hc: cloudflare.Healthcheck{
+					ID:      "699d98642c564d2e855e9661899b7252",
+					Name:    "origin",
+					Address: "origin.example.com",
+					Type:    "HTTPS",
+					Status:  StatusUnknown,
+				},
+				sent: desired,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var sent cloudflare.Healthcheck
+			client := fake.MockClient{
+				MockCreateHealthcheck: func(ctx context.Context, z string, hc cloudflare.Healthcheck) (cloudflare.Healthcheck, error) {
+					if z != zoneID {
+						t.Errorf("\n%s\nCreateHealthCheck(...): unexpected zone %s", tc.reason, z)
+					}
+					sent = hc
+					return tc.hc, tc.err
+				},
+			}
+			got, err := CreateHealthCheck(context.Background(), client, zoneID, healthCheckSpec())
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreateHealthCheck(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.hc, got); diff != "" {
+				t.Errorf("\n%s\nCreateHealthCheck(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.sent, sent); diff != "" {
+				t.Errorf("\n%s\nCreateHealthCheck(...): -want sent, +got sent:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNewHealthCheckDefaults(t *testing.T) {
+	port := 8080
+
+	cases := map[string]struct {
+		reason string
+		spec   v1alpha1.HealthCheckParameters
+		want   cloudflare.Healthcheck
+	}{
+		"HTTP": {
+			reason: "The method and path of HTTP checks should default to those of Cloudflare",
+			spec:   v1alpha1.HealthCheckParameters{HTTPConfig: &v1alpha1.HealthCheckHTTPConfig{}},
+			want:   cloudflare.Healthcheck{HTTPConfig: &cloudflare.HealthcheckHTTPConfig{Method: "GET", Path: "/"}},
+		},
+		"TCP": {
+			reason: "The method of TCP checks should default to that of Cloudflare",
+			spec:   v1alpha1.HealthCheckParameters{TCPConfig: &v1alpha1.HealthCheckTCPConfig{Port: &port}},
+			want:   cloudflare.Healthcheck{TCPConfig: &cloudflare.HealthcheckTCPConfig{Method: "connection_established", Port: 8080}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := NewHealthCheck(tc.spec)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nNewHealthCheck(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// A MockClient acts as a testable representation of the Cloudflare API.
+type MockClient struct {
+	MockCreateLoadBalancer  func(ctx context.Context, zoneID string, lb cloudflare.LoadBalancer) (cloudflare.LoadBalancer, error)
+	MockLoadBalancerDetails func(ctx context.Context, zoneID, lbID string) (cloudflare.LoadBalancer, error)
+	MockModifyLoadBalancer  func(ctx context.Context, zoneID string, lb cloudflare.LoadBalancer) (cloudflare.LoadBalancer, error)
+	MockDeleteLoadBalancer  func(ctx context.Context, zoneID, lbID string) error
+}
+
+// CreateLoadBalancer mocks the CreateLoadBalancer method of the Cloudflare API.
+func (m MockClient) CreateLoadBalancer(ctx context.Context, zoneID string, lb cloudflare.LoadBalancer) (cloudflare.LoadBalancer, error) {
+	return m.MockCreateLoadBalancer(ctx, zoneID, lb)
+}
+
+// LoadBalancerDetails mocks the LoadBalancerDetails method of the Cloudflare API.
+func (m MockClient) LoadBalancerDetails(ctx context.Context, zoneID, lbID string) (cloudflare.LoadBalancer, error) {
+	return m.MockLoadBalancerDetails(ctx, zoneID, lbID)
+}
+
+// ModifyLoadBalancer mocks the ModifyLoadBalancer method of the Cloudflare API.
+func (m MockClient) ModifyLoadBalancer(ctx context.Context, zoneID string, lb cloudflare.LoadBalancer) (cloudflare.LoadBalancer, error) {
+	return m.MockModifyLoadBalancer(ctx, zoneID, lb)
+}
+
+// DeleteLoadBalancer mocks the DeleteLoadBalancer method of the Cloudflare API.
+func (m MockClient) DeleteLoadBalancer(ctx context.Context, zoneID, lbID string) error {
+	return m.MockDeleteLoadBalancer(ctx, zoneID, lbID)
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancer
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/benagricola/provider-cloudflare/apis/loadbalancing/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+const (
+	errUpdateLoadBalancer = "error updating load balancer"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with Load Balancers.
+type Client interface {
+	CreateLoadBalancer(ctx context.Context, zoneID string, lb cloudflare.LoadBalancer) (cloudflare.LoadBalancer, error)
+	LoadBalancerDetails(ctx context.Context, zoneID, lbID string) (cloudflare.LoadBalancer, error)
+	ModifyLoadBalancer(ctx context.Context, zoneID string, lb cloudflare.LoadBalancer) (cloudflare.LoadBalancer, error)
+	DeleteLoadBalancer(ctx context.Context, zoneID, lbID string) error
+}
+
+// NewClient returns a new Cloudflare API client for working with Load
+// Balancers.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// IsLoadBalancerNotFound returns true if the passed error indicates
+// a Load Balancer was not found.
+func IsLoadBalancerNotFound(err error) bool {
+	return clients.IsNotFound(err)
+}
+
+// GenerateObservation creates an observation of a cloudflare Load Balancer.
+func GenerateObservation(in cloudflare.LoadBalancer) v1alpha1.LoadBalancerObservation {
+	o := v1alpha1.LoadBalancerObservation{}
+	if in.CreatedOn != nil {
+		o.CreatedOn = &metav1.Time{Time: *in.CreatedOn}
+	}
+	if in.ModifiedOn != nil {
+		o.ModifiedOn = &metav1.Time{Time: *in.ModifiedOn}
+	}
+	return o
+}
+
+// LateInitialize initializes LoadBalancerParameters based on the remote
+// resource.
+func LateInitialize(spec *v1alpha1.LoadBalancerParameters, lb cloudflare.LoadBalancer) bool { //nolint:gocyclo
+	// NOTE: Gocyclo ignored here because this method has to check each field.
+
+	if spec == nil {
+		return false
+	}
+
+	li := false
+	if spec.Description == nil && lb.Description != "" {
+		spec.Description = &lb.Description
+		li = true
+	}
+	if spec.Enabled == nil && lb.Enabled != nil {
+		spec.Enabled = lb.Enabled
+		li = true
+	}
+	if spec.Proxied == nil {
+		spec.Proxied = &lb.Proxied
+		li = true
+	}
+	if spec.SessionAffinity == nil && lb.Persistence != "" {
+		spec.SessionAffinity = &lb.Persistence
+		li = true
+	}
+	if spec.SessionAffinityTTL == nil && lb.PersistenceTTL > 0 {
+		ttl := int64(lb.PersistenceTTL)
+		spec.SessionAffinityTTL = &ttl
+		li = true
+	}
+	if spec.SteeringPolicy == nil && lb.SteeringPolicy != "" {
+		spec.SteeringPolicy = &lb.SteeringPolicy
+		li = true
+	}
+	if spec.TTL == nil && lb.TTL > 0 {
+		ttl := int64(lb.TTL)
+		spec.TTL = &ttl
+		li = true
+	}
+	return li
+}
+
+// UpToDate checks if the remote resource is up to date with the
+// requested resource parameters.
+func UpToDate(spec *v1alpha1.LoadBalancerParameters, lb cloudflare.LoadBalancer) bool { //nolint:gocyclo
+	// NOTE: Gocyclo ignored here because this method has to check each field.
+
+	// If we don't have a spec, we _must_ be up to date.
+	if spec == nil {
+		return true
+	}
+
+	if !cmp.Equal(spec.DefaultPools, lb.DefaultPools, cmpopts.EquateEmpty()) {
+		return false
+	}
+	if spec.Description != nil && *spec.Description != lb.Description {
+		return false
+	}
+	if spec.Enabled != nil && (lb.Enabled == nil || *spec.Enabled != *lb.Enabled) {
+		return false
+	}
+	if spec.FallbackPool != nil && *spec.FallbackPool != lb.FallbackPool {
+		return false
+	}
+	if !cmp.Equal(spec.PopPools, lb.PopPools, cmpopts.EquateEmpty()) {
+		return false
+	}
+	if spec.Proxied != nil && *spec.Proxied != lb.Proxied {
+		return false
+	}
+	if !cmp.Equal(spec.RegionPools, lb.RegionPools, cmpopts.EquateEmpty()) {
+		return false
+	}
+	if spec.SessionAffinity != nil && *spec.SessionAffinity != lb.Persistence {
+		return false
+	}
+	if spec.SessionAffinityTTL != nil && *spec.SessionAffinityTTL != int64(lb.PersistenceTTL) {
+		return false
+	}
+	if spec.SteeringPolicy != nil && *spec.SteeringPolicy != lb.SteeringPolicy {
+		return false
+	}
+	if spec.TTL != nil && *spec.TTL != int64(lb.TTL) {
+		return false
+	}
+	return true
+}
+
+// ParametersToLoadBalancer converts LoadBalancerParameters into a
+// cloudflare Load Balancer.
+func ParametersToLoadBalancer(spec v1alpha1.LoadBalancerParameters) cloudflare.LoadBalancer {
+	lb := cloudflare.LoadBalancer{
+		Name:         spec.Name,
+		DefaultPools: spec.DefaultPools,
+		RegionPools:  spec.RegionPools,
+		PopPools:     spec.PopPools,
+		Enabled:      spec.Enabled,
+	}
+
+	if spec.Description != nil {
+		lb.Description = *spec.Description
+	}
+	if spec.FallbackPool != nil {
+		lb.FallbackPool = *spec.FallbackPool
+	}
+	if spec.Proxied != nil {
+		lb.Proxied = *spec.Proxied
+	}
+	if spec.SessionAffinity != nil {
+		lb.Persistence = *spec.SessionAffinity
+	}
+	if spec.SessionAffinityTTL != nil {
+		lb.PersistenceTTL = int(*spec.SessionAffinityTTL)
+	}
+	if spec.SteeringPolicy != nil {
+		lb.SteeringPolicy = *spec.SteeringPolicy
+	}
+	if spec.TTL != nil {
+		lb.TTL = int(*spec.TTL)
+	}
+	return lb
+}
+
+// UpdateLoadBalancer updates mutable values on a Load Balancer.
+func UpdateLoadBalancer(ctx context.Context, client Client, lbID string, spec v1alpha1.LoadBalancerParameters) error {
+	lb := ParametersToLoadBalancer(spec)
+	lb.ID = lbID
+
+	_, err := client.ModifyLoadBalancer(ctx, *spec.Zone, lb)
+	return errors.Wrap(err, errUpdateLoadBalancer)
+}
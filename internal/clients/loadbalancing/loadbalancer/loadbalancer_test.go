@@ -0,0 +1,306 @@
+package loadbalancer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/benagricola/provider-cloudflare/apis/loadbalancing/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/loadbalancing/loadbalancer/fake"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	ptr "k8s.io/utils/pointer"
+)
+
+func TestLateInitialize(t *testing.T) {
+	type args struct {
+		lp *v1alpha1.LoadBalancerParameters
+		lb cloudflare.LoadBalancer
+	}
+
+	type want struct {
+		o  bool
+		lp *v1alpha1.LoadBalancerParameters
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"LateInitSpecNil": {
+			reason: "LateInit should return false when not passed a spec",
+			args:   args{},
+			want: want{
+				o: false,
+			},
+		},
+		"LateInitDontUpdate": {
+			reason: "LateInit should not update already-set spec fields from a Load Balancer",
+			args: args{
+				lp: &v1alpha1.LoadBalancerParameters{
+					Name:           "lb.example.com",
+					Enabled:        ptr.BoolPtr(false),
+					Proxied:        ptr.BoolPtr(false),
+					SteeringPolicy: ptr.StringPtr("geo"),
+					TTL:            ptr.Int64Ptr(60),
+				},
+				lb: cloudflare.LoadBalancer{
+					Name:           "lb.example.com",
+					Enabled:        ptr.BoolPtr(true),
+					Proxied:        true,
+					SteeringPolicy: "off",
+					TTL:            30,
+				},
+			},
+			want: want{
+				o: false,
+				lp: &v1alpha1.LoadBalancerParameters{
+					Name:           "lb.example.com",
+					Enabled:        ptr.BoolPtr(false),
+					Proxied:        ptr.BoolPtr(false),
+					SteeringPolicy: ptr.StringPtr("geo"),
+					TTL:            ptr.Int64Ptr(60),
+				},
+			},
+		},
+		"LateInitUpdate": {
+			reason: "LateInit should update unset spec fields from a Load Balancer",
+			args: args{
+				lp: &v1alpha1.LoadBalancerParameters{
+					Name: "lb.example.com",
+				},
+				lb: cloudflare.LoadBalancer{
+					Name:           "lb.example.com",
+					Enabled:        ptr.BoolPtr(true),
+					Proxied:        true,
+					Persistence:    "none",
+					SteeringPolicy: "off",
+					TTL:            30,
+				},
+			},
+			want: want{
+				o: true,
+				lp: &v1alpha1.LoadBalancerParameters{
+					Name:            "lb.example.com",
+					Enabled:         ptr.BoolPtr(true),
+					Proxied:         ptr.BoolPtr(true),
+					SessionAffinity: ptr.StringPtr("none"),
+					SteeringPolicy:  ptr.StringPtr("off"),
+					TTL:             ptr.Int64Ptr(30),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := LateInitialize(tc.args.lp, tc.args.lb)
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nLateInit(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.lp, tc.args.lp); diff != "" {
+				t.Errorf("\n%s\nLateInit(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpToDate(t *testing.T) {
+	type args struct {
+		lp *v1alpha1.LoadBalancerParameters
+		lb cloudflare.LoadBalancer
+	}
+
+	type want struct {
+		o bool
+	}
+
+	lb := cloudflare.LoadBalancer{
+		Name:           "lb.example.com",
+		DefaultPools:   []string{"eu", "us"},
+		FallbackPool:   "us",
+		RegionPools:    map[string][]string{"WNAM": {"us"}, "WEU": {"eu"}},
+		SteeringPolicy: "geo",
+		Proxied:        true,
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"UpToDateSpecNil": {
+			reason: "UpToDate should return true when not passed a spec",
+			args:   args{},
+			want: want{
+				o: true,
+			},
+		},
+		"UpToDate": {
+			reason: "UpToDate should return true when the Load Balancer matches the spec",
+			args: args{
+				lp: &v1alpha1.LoadBalancerParameters{
+					Name:           "lb.example.com",
+					DefaultPools:   []string{"eu", "us"},
+					FallbackPool:   ptr.StringPtr("us"),
+					RegionPools:    map[string][]string{"WNAM": {"us"}, "WEU": {"eu"}},
+					SteeringPolicy: ptr.StringPtr("geo"),
+					Proxied:        ptr.BoolPtr(true),
+				},
+				lb: lb,
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"NotUpToDateDefaultPoolOrder": {
+			reason: "UpToDate should return false when the default pool failover order differs",
+			args: args{
+				lp: &v1alpha1.LoadBalancerParameters{
+					Name:         "lb.example.com",
+					DefaultPools: []string{"us", "eu"},
+					RegionPools:  map[string][]string{"WNAM": {"us"}, "WEU": {"eu"}},
+				},
+				lb: lb,
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"NotUpToDateRegionPools": {
+			reason: "UpToDate should return false when region pools have been removed",
+			args: args{
+				lp: &v1alpha1.LoadBalancerParameters{
+					Name:         "lb.example.com",
+					DefaultPools: []string{"eu", "us"},
+				},
+				lb: lb,
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"NotUpToDateEnabled": {
+			reason: "UpToDate should return false when enabled differs",
+			args: args{
+				lp: &v1alpha1.LoadBalancerParameters{
+					Name:         "lb.example.com",
+					DefaultPools: []string{"eu", "us"},
+					RegionPools:  map[string][]string{"WNAM": {"us"}, "WEU": {"eu"}},
+					Enabled:      ptr.BoolPtr(false),
+				},
+				lb: lb,
+			},
+			want: want{
+				o: false,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := UpToDate(tc.args.lp, tc.args.lb)
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdateLoadBalancer(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client Client
+	}
+
+	type args struct {
+		ctx context.Context
+		id  string
+		lp  v1alpha1.LoadBalancerParameters
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"UpdateLoadBalancerFailed": {
+			reason: "UpdateLoadBalancer should return errUpdateLoadBalancer if the update fails",
+			fields: fields{
+				client: fake.MockClient{
+					MockModifyLoadBalancer: func(ctx context.Context, zoneID string, lb cloudflare.LoadBalancer) (cloudflare.LoadBalancer, error) {
+						return cloudflare.LoadBalancer{}, errBoom
+					},
+				},
+			},
+			args: args{
+				id: "1234beef",
+				lp: v1alpha1.LoadBalancerParameters{
+					Name: "lb.example.com",
+					Zone: ptr.StringPtr("foo.com"),
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errUpdateLoadBalancer),
+			},
+		},
+		"UpdateLoadBalancer": {
+			reason: "UpdateLoadBalancer should send the Load Balancer ID and parameters to the zone",
+			fields: fields{
+				client: fake.MockClient{
+					MockModifyLoadBalancer: func(ctx context.Context, zoneID string, lb cloudflare.LoadBalancer) (cloudflare.LoadBalancer, error) {
+						want := cloudflare.LoadBalancer{
+							ID:             "1234beef",
+							Name:           "lb.example.com",
+							DefaultPools:   []string{"eu"},
+							FallbackPool:   "eu",
+							SteeringPolicy: "geo",
+						}
+						if zoneID != "foo.com" {
+							return cloudflare.LoadBalancer{}, errBoom
+						}
+						if diff := cmp.Diff(want, lb); diff != "" {
+							return cloudflare.LoadBalancer{}, errors.New(diff)
+						}
+						return lb, nil
+					},
+				},
+			},
+			args: args{
+				id: "1234beef",
+				lp: v1alpha1.LoadBalancerParameters{
+					Name:           "lb.example.com",
+					DefaultPools:   []string{"eu"},
+					FallbackPool:   ptr.StringPtr("eu"),
+					SteeringPolicy: ptr.StringPtr("geo"),
+					Zone:           ptr.StringPtr("foo.com"),
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := UpdateLoadBalancer(tc.args.ctx, tc.fields.client, tc.args.id, tc.args.lp)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdateLoadBalancer(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
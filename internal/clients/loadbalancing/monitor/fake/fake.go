@@ -0,0 +1,51 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// A MockClient acts as a testable representation of the Cloudflare API.
+type MockClient struct {
+	MockCreateLoadBalancerMonitor  func(ctx context.Context, monitor cloudflare.LoadBalancerMonitor) (cloudflare.LoadBalancerMonitor, error)
+	MockLoadBalancerMonitorDetails func(ctx context.Context, monitorID string) (cloudflare.LoadBalancerMonitor, error)
+	MockModifyLoadBalancerMonitor  func(ctx context.Context, monitor cloudflare.LoadBalancerMonitor) (cloudflare.LoadBalancerMonitor, error)
+	MockDeleteLoadBalancerMonitor  func(ctx context.Context, monitorID string) error
+}
+
+// CreateLoadBalancerMonitor mocks the CreateLoadBalancerMonitor method of the Cloudflare API.
+func (m MockClient) CreateLoadBalancerMonitor(ctx context.Context, monitor cloudflare.LoadBalancerMonitor) (cloudflare.LoadBalancerMonitor, error) {
+	return m.MockCreateLoadBalancerMonitor(ctx, monitor)
+}
+
+// LoadBalancerMonitorDetails mocks the LoadBalancerMonitorDetails method of the Cloudflare API.
+func (m MockClient) LoadBalancerMonitorDetails(ctx context.Context, monitorID string) (cloudflare.LoadBalancerMonitor, error) {
+	return m.MockLoadBalancerMonitorDetails(ctx, monitorID)
+}
+
+// ModifyLoadBalancerMonitor mocks the ModifyLoadBalancerMonitor method of the Cloudflare API.
+func (m MockClient) ModifyLoadBalancerMonitor(ctx context.Context, monitor cloudflare.LoadBalancerMonitor) (cloudflare.LoadBalancerMonitor, error) {
+	return m.MockModifyLoadBalancerMonitor(ctx, monitor)
+}
+
+// DeleteLoadBalancerMonitor mocks the DeleteLoadBalancerMonitor method of the Cloudflare API.
+func (m MockClient) DeleteLoadBalancerMonitor(ctx context.Context, monitorID string) error {
+	return m.MockDeleteLoadBalancerMonitor(ctx, monitorID)
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitor
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/benagricola/provider-cloudflare/apis/loadbalancing/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+const (
+	errUpdateMonitor = "error updating load balancer monitor"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with Load Balancer Monitors.
+type Client interface {
+	CreateLoadBalancerMonitor(ctx context.Context, monitor cloudflare.LoadBalancerMonitor) (cloudflare.LoadBalancerMonitor, error)
+	LoadBalancerMonitorDetails(ctx context.Context, monitorID string) (cloudflare.LoadBalancerMonitor, error)
+	ModifyLoadBalancerMonitor(ctx context.Context, monitor cloudflare.LoadBalancerMonitor) (cloudflare.LoadBalancerMonitor, error)
+	DeleteLoadBalancerMonitor(ctx context.Context, monitorID string) error
+}
+
+// NewClient returns a new Cloudflare API client for working with Load
+// Balancer Monitors.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// IsMonitorNotFound returns true if the passed error indicates
+// a Monitor was not found.
+func IsMonitorNotFound(err error) bool {
+	return clients.IsNotFound(err)
+}
+
+// GenerateObservation creates an observation of a cloudflare Monitor.
+func GenerateObservation(in cloudflare.LoadBalancerMonitor) v1alpha1.LoadBalancerMonitorObservation {
+	o := v1alpha1.LoadBalancerMonitorObservation{}
+	if in.CreatedOn != nil {
+		o.CreatedOn = &metav1.Time{Time: *in.CreatedOn}
+	}
+	if in.ModifiedOn != nil {
+		o.ModifiedOn = &metav1.Time{Time: *in.ModifiedOn}
+	}
+	return o
+}
+
+// LateInitialize initializes LoadBalancerMonitorParameters based on the
+// remote resource.
+func LateInitialize(spec *v1alpha1.LoadBalancerMonitorParameters, m cloudflare.LoadBalancerMonitor) bool { //nolint:gocyclo
+	// NOTE: Gocyclo ignored here because this method has to check each field.
+
+	if spec == nil {
+		return false
+	}
+
+	li := false
+	if spec.AllowInsecure == nil {
+		spec.AllowInsecure = &m.AllowInsecure
+		li = true
+	}
+	if spec.Description == nil && m.Description != "" {
+		spec.Description = &m.Description
+		li = true
+	}
+	if spec.ExpectedBody == nil && m.ExpectedBody != "" {
+		spec.ExpectedBody = &m.ExpectedBody
+		li = true
+	}
+	if spec.ExpectedCodes == nil && m.ExpectedCodes != "" {
+		spec.ExpectedCodes = &m.ExpectedCodes
+		li = true
+	}
+	if spec.FollowRedirects == nil {
+		spec.FollowRedirects = &m.FollowRedirects
+		li = true
+	}
+	if spec.Header == nil && len(m.Header) > 0 {
+		spec.Header = m.Header
+		li = true
+	}
+	if spec.Interval == nil && m.Interval > 0 {
+		i := int64(m.Interval)
+		spec.Interval = &i
+		li = true
+	}
+	if spec.Method == nil && m.Method != "" {
+		spec.Method = &m.Method
+		li = true
+	}
+	if spec.Path == nil && m.Path != "" {
+		spec.Path = &m.Path
+		li = true
+	}
+	if spec.Port == nil && m.Port > 0 {
+		p := int32(m.Port)
+		spec.Port = &p
+		li = true
+	}
+	if spec.ProbeZone == nil && m.ProbeZone != "" {
+		spec.ProbeZone = &m.ProbeZone
+		li = true
+	}
+	if spec.Retries == nil {
+		r := int64(m.Retries)
+		spec.Retries = &r
+		li = true
+	}
+	if spec.Timeout == nil && m.Timeout > 0 {
+		t := int64(m.Timeout)
+		spec.Timeout = &t
+		li = true
+	}
+	if spec.Type == nil && m.Type != "" {
+		spec.Type = &m.Type
+		li = true
+	}
+	return li
+}
+
+// UpToDate checks if the remote resource is up to date with the
+// requested resource parameters.
+func UpToDate(spec *v1alpha1.LoadBalancerMonitorParameters, m cloudflare.LoadBalancerMonitor) bool { //nolint:gocyclo
+	// NOTE: Gocyclo ignored here because this method has to check each field.
+
+	// If we don't have a spec, we _must_ be up to date.
+	if spec == nil {
+		return true
+	}
+
+	if spec.AllowInsecure != nil && *spec.AllowInsecure != m.AllowInsecure {
+		return false
+	}
+	if spec.Description != nil && *spec.Description != m.Description {
+		return false
+	}
+	if spec.ExpectedBody != nil && *spec.ExpectedBody != m.ExpectedBody {
+		return false
+	}
+	if spec.ExpectedCodes != nil && *spec.ExpectedCodes != m.ExpectedCodes {
+		return false
+	}
+	if spec.FollowRedirects != nil && *spec.FollowRedirects != m.FollowRedirects {
+		return false
+	}
+	if spec.Header != nil && !cmp.Equal(spec.Header, m.Header) {
+		return false
+	}
+	if spec.Interval != nil && *spec.Interval != int64(m.Interval) {
+		return false
+	}
+	if spec.Method != nil && *spec.Method != m.Method {
+		return false
+	}
+	if spec.Path != nil && *spec.Path != m.Path {
+		return false
+	}
+	if spec.Port != nil && *spec.Port != int32(m.Port) {
+		return false
+	}
+	if spec.ProbeZone != nil && *spec.ProbeZone != m.ProbeZone {
+		return false
+	}
+	if spec.Retries != nil && *spec.Retries != int64(m.Retries) {
+		return false
+	}
+	if spec.Timeout != nil && *spec.Timeout != int64(m.Timeout) {
+		return false
+	}
+	if spec.Type != nil && *spec.Type != m.Type {
+		return false
+	}
+	return true
+}
+
+// ParametersToMonitor converts LoadBalancerMonitorParameters into a
+// cloudflare Monitor.
+func ParametersToMonitor(spec v1alpha1.LoadBalancerMonitorParameters) cloudflare.LoadBalancerMonitor { //nolint:gocyclo
+	// NOTE: Gocyclo ignored here because this method has to check each field.
+
+	m := cloudflare.LoadBalancerMonitor{
+		Header: spec.Header,
+	}
+
+	if spec.AllowInsecure != nil {
+		m.AllowInsecure = *spec.AllowInsecure
+	}
+	if spec.Description != nil {
+		m.Description = *spec.Description
+	}
+	if spec.ExpectedBody != nil {
+		m.ExpectedBody = *spec.ExpectedBody
+	}
+	if spec.ExpectedCodes != nil {
+		m.ExpectedCodes = *spec.ExpectedCodes
+	}
+	if spec.FollowRedirects != nil {
+		m.FollowRedirects = *spec.FollowRedirects
+	}
+	if spec.Interval != nil {
+		m.Interval = int(*spec.Interval)
+	}
+	if spec.Method != nil {
+		m.Method = *spec.Method
+	}
+	if spec.Path != nil {
+		m.Path = *spec.Path
+	}
+	if spec.Port != nil {
+		m.Port = uint16(*spec.Port)
+	}
+	if spec.ProbeZone != nil {
+		m.ProbeZone = *spec.ProbeZone
+	}
+	if spec.Retries != nil {
+		m.Retries = int(*spec.Retries)
+	}
+	if spec.Timeout != nil {
+		m.Timeout = int(*spec.Timeout)
+	}
+	if spec.Type != nil {
+		m.Type = *spec.Type
+	}
+	return m
+}
+
+// UpdateMonitor updates mutable values on a Monitor.
+func UpdateMonitor(ctx context.Context, client Client, monitorID string, spec v1alpha1.LoadBalancerMonitorParameters) error {
+	m := ParametersToMonitor(spec)
+	m.ID = monitorID
+
+	_, err := client.ModifyLoadBalancerMonitor(ctx, m)
+	return errors.Wrap(err, errUpdateMonitor)
+}
@@ -0,0 +1,324 @@
+package monitor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/benagricola/provider-cloudflare/apis/loadbalancing/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/loadbalancing/monitor/fake"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	ptr "k8s.io/utils/pointer"
+)
+
+func TestLateInitialize(t *testing.T) {
+	type args struct {
+		mp *v1alpha1.LoadBalancerMonitorParameters
+		m  cloudflare.LoadBalancerMonitor
+	}
+
+	type want struct {
+		o  bool
+		mp *v1alpha1.LoadBalancerMonitorParameters
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"LateInitSpecNil": {
+			reason: "LateInit should return false when not passed a spec",
+			args:   args{},
+			want: want{
+				o: false,
+			},
+		},
+		"LateInitDontUpdate": {
+			reason: "LateInit should not update already-set spec fields from a Monitor",
+			args: args{
+				mp: &v1alpha1.LoadBalancerMonitorParameters{
+					AllowInsecure:   ptr.BoolPtr(false),
+					ExpectedCodes:   ptr.StringPtr("200"),
+					FollowRedirects: ptr.BoolPtr(true),
+					Interval:        ptr.Int64Ptr(30),
+					Method:          ptr.StringPtr("HEAD"),
+					Path:            ptr.StringPtr("/healthz"),
+					Retries:         ptr.Int64Ptr(1),
+					Timeout:         ptr.Int64Ptr(2),
+					Type:            ptr.StringPtr("https"),
+				},
+				m: cloudflare.LoadBalancerMonitor{
+					AllowInsecure:   true,
+					ExpectedCodes:   "2xx",
+					FollowRedirects: false,
+					Interval:        60,
+					Method:          "GET",
+					Path:            "/",
+					Retries:         2,
+					Timeout:         5,
+					Type:            "http",
+				},
+			},
+			want: want{
+				o: false,
+				mp: &v1alpha1.LoadBalancerMonitorParameters{
+					AllowInsecure:   ptr.BoolPtr(false),
+					ExpectedCodes:   ptr.StringPtr("200"),
+					FollowRedirects: ptr.BoolPtr(true),
+					Interval:        ptr.Int64Ptr(30),
+					Method:          ptr.StringPtr("HEAD"),
+					Path:            ptr.StringPtr("/healthz"),
+					Retries:         ptr.Int64Ptr(1),
+					Timeout:         ptr.Int64Ptr(2),
+					Type:            ptr.StringPtr("https"),
+				},
+			},
+		},
+		"LateInitUpdate": {
+			reason: "LateInit should update unset spec fields from a Monitor",
+			args: args{
+				mp: &v1alpha1.LoadBalancerMonitorParameters{},
+				m: cloudflare.LoadBalancerMonitor{
+					ExpectedCodes: "2xx",
+					Interval:      60,
+					Method:        "GET",
+					Path:          "/",
+					Retries:       2,
+					Timeout:       5,
+					Type:          "http",
+				},
+			},
+			want: want{
+				o: true,
+				mp: &v1alpha1.LoadBalancerMonitorParameters{
+					AllowInsecure:   ptr.BoolPtr(false),
+					ExpectedCodes:   ptr.StringPtr("2xx"),
+					FollowRedirects: ptr.BoolPtr(false),
+					Interval:        ptr.Int64Ptr(60),
+					Method:          ptr.StringPtr("GET"),
+					Path:            ptr.StringPtr("/"),
+					Retries:         ptr.Int64Ptr(2),
+					Timeout:         ptr.Int64Ptr(5),
+					Type:            ptr.StringPtr("http"),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := LateInitialize(tc.args.mp, tc.args.m)
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nLateInit(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mp, tc.args.mp); diff != "" {
+				t.Errorf("\n%s\nLateInit(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpToDate(t *testing.T) {
+	type args struct {
+		mp *v1alpha1.LoadBalancerMonitorParameters
+		m  cloudflare.LoadBalancerMonitor
+	}
+
+	type want struct {
+		o bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"UpToDateSpecNil": {
+			reason: "UpToDate should return true when not passed a spec",
+			args:   args{},
+			want: want{
+				o: true,
+			},
+		},
+		"UpToDateEmptyParams": {
+			reason: "UpToDate should return true when no parameters are set",
+			args: args{
+				mp: &v1alpha1.LoadBalancerMonitorParameters{},
+				m: cloudflare.LoadBalancerMonitor{
+					Type: "http",
+					Path: "/",
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"UpToDate": {
+			reason: "UpToDate should return true when the Monitor matches the spec",
+			args: args{
+				mp: &v1alpha1.LoadBalancerMonitorParameters{
+					Header:   map[string][]string{"Host": {"example.com"}},
+					Interval: ptr.Int64Ptr(60),
+					Path:     ptr.StringPtr("/healthz"),
+					Port:     ptr.Int32Ptr(8080),
+					Type:     ptr.StringPtr("https"),
+				},
+				m: cloudflare.LoadBalancerMonitor{
+					Header:   map[string][]string{"Host": {"example.com"}},
+					Interval: 60,
+					Path:     "/healthz",
+					Port:     8080,
+					Type:     "https",
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"NotUpToDatePath": {
+			reason: "UpToDate should return false when the path differs",
+			args: args{
+				mp: &v1alpha1.LoadBalancerMonitorParameters{
+					Path: ptr.StringPtr("/healthz"),
+				},
+				m: cloudflare.LoadBalancerMonitor{
+					Path: "/",
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"NotUpToDateHeader": {
+			reason: "UpToDate should return false when the headers differ",
+			args: args{
+				mp: &v1alpha1.LoadBalancerMonitorParameters{
+					Header: map[string][]string{"Host": {"example.com"}},
+				},
+				m: cloudflare.LoadBalancerMonitor{
+					Header: map[string][]string{"Host": {"example.org"}},
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"NotUpToDateRetries": {
+			reason: "UpToDate should return false when the retries differ",
+			args: args{
+				mp: &v1alpha1.LoadBalancerMonitorParameters{
+					Retries: ptr.Int64Ptr(0),
+				},
+				m: cloudflare.LoadBalancerMonitor{
+					Retries: 2,
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := UpToDate(tc.args.mp, tc.args.m)
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdateMonitor(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client Client
+	}
+
+	type args struct {
+		ctx context.Context
+		id  string
+		mp  v1alpha1.LoadBalancerMonitorParameters
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"UpdateMonitorFailed": {
+			reason: "UpdateMonitor should return errUpdateMonitor if the update fails",
+			fields: fields{
+				client: fake.MockClient{
+					MockModifyLoadBalancerMonitor: func(ctx context.Context, monitor cloudflare.LoadBalancerMonitor) (cloudflare.LoadBalancerMonitor, error) {
+						return cloudflare.LoadBalancerMonitor{}, errBoom
+					},
+				},
+			},
+			args: args{
+				id: "1234beef",
+				mp: v1alpha1.LoadBalancerMonitorParameters{
+					Type: ptr.StringPtr("http"),
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errUpdateMonitor),
+			},
+		},
+		"UpdateMonitor": {
+			reason: "UpdateMonitor should send the Monitor ID and parameters",
+			fields: fields{
+				client: fake.MockClient{
+					MockModifyLoadBalancerMonitor: func(ctx context.Context, monitor cloudflare.LoadBalancerMonitor) (cloudflare.LoadBalancerMonitor, error) {
+						want := cloudflare.LoadBalancerMonitor{
+							ID:            "1234beef",
+							Type:          "https",
+							Path:          "/healthz",
+							Port:          8443,
+							ExpectedCodes: "2xx",
+						}
+						if diff := cmp.Diff(want, monitor); diff != "" {
+							return cloudflare.LoadBalancerMonitor{}, errors.New(diff)
+						}
+						return monitor, nil
+					},
+				},
+			},
+			args: args{
+				id: "1234beef",
+				mp: v1alpha1.LoadBalancerMonitorParameters{
+					Type:          ptr.StringPtr("https"),
+					Path:          ptr.StringPtr("/healthz"),
+					Port:          ptr.Int32Ptr(8443),
+					ExpectedCodes: ptr.StringPtr("2xx"),
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := UpdateMonitor(tc.args.ctx, tc.fields.client, tc.args.id, tc.args.mp)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdateMonitor(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
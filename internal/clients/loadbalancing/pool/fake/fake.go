@@ -0,0 +1,51 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// A MockClient acts as a testable representation of the Cloudflare API.
+type MockClient struct {
+	MockCreateLoadBalancerPool  func(ctx context.Context, pool cloudflare.LoadBalancerPool) (cloudflare.LoadBalancerPool, error)
+	MockLoadBalancerPoolDetails func(ctx context.Context, poolID string) (cloudflare.LoadBalancerPool, error)
+	MockModifyLoadBalancerPool  func(ctx context.Context, pool cloudflare.LoadBalancerPool) (cloudflare.LoadBalancerPool, error)
+	MockDeleteLoadBalancerPool  func(ctx context.Context, poolID string) error
+}
+
+// CreateLoadBalancerPool mocks the CreateLoadBalancerPool method of the Cloudflare API.
+func (m MockClient) CreateLoadBalancerPool(ctx context.Context, pool cloudflare.LoadBalancerPool) (cloudflare.LoadBalancerPool, error) {
+	return m.MockCreateLoadBalancerPool(ctx, pool)
+}
+
+// LoadBalancerPoolDetails mocks the LoadBalancerPoolDetails method of the Cloudflare API.
+func (m MockClient) LoadBalancerPoolDetails(ctx context.Context, poolID string) (cloudflare.LoadBalancerPool, error) {
+	return m.MockLoadBalancerPoolDetails(ctx, poolID)
+}
+
+// ModifyLoadBalancerPool mocks the ModifyLoadBalancerPool method of the Cloudflare API.
+func (m MockClient) ModifyLoadBalancerPool(ctx context.Context, pool cloudflare.LoadBalancerPool) (cloudflare.LoadBalancerPool, error) {
+	return m.MockModifyLoadBalancerPool(ctx, pool)
+}
+
+// DeleteLoadBalancerPool mocks the DeleteLoadBalancerPool method of the Cloudflare API.
+func (m MockClient) DeleteLoadBalancerPool(ctx context.Context, poolID string) error {
+	return m.MockDeleteLoadBalancerPool(ctx, poolID)
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/benagricola/provider-cloudflare/apis/loadbalancing/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+const (
+	errUpdatePool    = "error updating load balancer pool"
+	errInvalidWeight = "invalid origin weight"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with Load Balancer Pools.
+type Client interface {
+	CreateLoadBalancerPool(ctx context.Context, pool cloudflare.LoadBalancerPool) (cloudflare.LoadBalancerPool, error)
+	LoadBalancerPoolDetails(ctx context.Context, poolID string) (cloudflare.LoadBalancerPool, error)
+	ModifyLoadBalancerPool(ctx context.Context, pool cloudflare.LoadBalancerPool) (cloudflare.LoadBalancerPool, error)
+	DeleteLoadBalancerPool(ctx context.Context, poolID string) error
+}
+
+// NewClient returns a new Cloudflare API client for working with Load
+// Balancer Pools.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// IsPoolNotFound returns true if the passed error indicates
+// a Pool was not found.
+func IsPoolNotFound(err error) bool {
+	return clients.IsNotFound(err)
+}
+
+// GenerateObservation creates an observation of a cloudflare Pool.
+func GenerateObservation(in cloudflare.LoadBalancerPool) v1alpha1.LoadBalancerPoolObservation {
+	o := v1alpha1.LoadBalancerPoolObservation{}
+	if in.CreatedOn != nil {
+		o.CreatedOn = &metav1.Time{Time: *in.CreatedOn}
+	}
+	if in.ModifiedOn != nil {
+		o.ModifiedOn = &metav1.Time{Time: *in.ModifiedOn}
+	}
+	return o
+}
+
+func regionsToCheckRegions(regions []string) []v1alpha1.LoadBalancerPoolCheckRegion {
+	cr := make([]v1alpha1.LoadBalancerPoolCheckRegion, len(regions))
+	for i, v := range regions {
+		cr[i] = v1alpha1.LoadBalancerPoolCheckRegion(v)
+	}
+	return cr
+}
+
+func checkRegionsToRegions(checkRegions []v1alpha1.LoadBalancerPoolCheckRegion) []string {
+	// Leave check regions unset so that Cloudflare applies its
+	// default regions.
+	if len(checkRegions) == 0 {
+		return nil
+	}
+	r := make([]string, len(checkRegions))
+	for i, v := range checkRegions {
+		r[i] = string(v)
+	}
+	return r
+}
+
+func formatWeight(w float64) string {
+	return strconv.FormatFloat(w, 'f', -1, 64)
+}
+
+// LateInitialize initializes LoadBalancerPoolParameters based on the
+// remote resource.
+func LateInitialize(spec *v1alpha1.LoadBalancerPoolParameters, p cloudflare.LoadBalancerPool) bool { //nolint:gocyclo
+	// NOTE: Gocyclo ignored here because this method has to check each field.
+
+	if spec == nil {
+		return false
+	}
+
+	li := false
+	if len(spec.CheckRegions) == 0 && len(p.CheckRegions) > 0 {
+		spec.CheckRegions = regionsToCheckRegions(p.CheckRegions)
+		li = true
+	}
+	if spec.Description == nil && p.Description != "" {
+		spec.Description = &p.Description
+		li = true
+	}
+	if spec.Enabled == nil {
+		spec.Enabled = &p.Enabled
+		li = true
+	}
+	if spec.MinimumOrigins == nil && p.MinimumOrigins > 0 {
+		mo := int64(p.MinimumOrigins)
+		spec.MinimumOrigins = &mo
+		li = true
+	}
+	if spec.NotificationEmail == nil && p.NotificationEmail != "" {
+		spec.NotificationEmail = &p.NotificationEmail
+		li = true
+	}
+
+	// Origins are matched by position, as that is how they are
+	// returned by the API.
+	for i := range spec.Origins {
+		if i >= len(p.Origins) {
+			break
+		}
+		o := &spec.Origins[i]
+		if o.Enabled == nil {
+			e := p.Origins[i].Enabled
+			o.Enabled = &e
+			li = true
+		}
+		if o.Weight == nil {
+			w := formatWeight(p.Origins[i].Weight)
+			o.Weight = &w
+			li = true
+		}
+	}
+	return li
+}
+
+// UpToDate checks if the remote resource is up to date with the
+// requested resource parameters.
+func UpToDate(spec *v1alpha1.LoadBalancerPoolParameters, p cloudflare.LoadBalancerPool) bool { //nolint:gocyclo
+	// NOTE: Gocyclo ignored here because this method has to check each field.
+
+	// If we don't have a spec, we _must_ be up to date.
+	if spec == nil {
+		return true
+	}
+
+	if spec.Name != p.Name {
+		return false
+	}
+	if spec.CheckRegions != nil && !cmp.Equal(spec.CheckRegions, regionsToCheckRegions(p.CheckRegions)) {
+		return false
+	}
+	if spec.Description != nil && *spec.Description != p.Description {
+		return false
+	}
+	if spec.Enabled != nil && *spec.Enabled != p.Enabled {
+		return false
+	}
+	if spec.MinimumOrigins != nil && *spec.MinimumOrigins != int64(p.MinimumOrigins) {
+		return false
+	}
+	if spec.Monitor != nil && *spec.Monitor != p.Monitor {
+		return false
+	}
+	if spec.NotificationEmail != nil && *spec.NotificationEmail != p.NotificationEmail {
+		return false
+	}
+	return originsUpToDate(spec.Origins, p.Origins)
+}
+
+func originsUpToDate(spec []v1alpha1.LoadBalancerOrigin, origins []cloudflare.LoadBalancerOrigin) bool {
+	if len(spec) != len(origins) {
+		return false
+	}
+	for i, o := range spec {
+		if o.Name != origins[i].Name || o.Address != origins[i].Address {
+			return false
+		}
+		if o.Enabled != nil && *o.Enabled != origins[i].Enabled {
+			return false
+		}
+		if o.Header != nil && !cmp.Equal(o.Header, origins[i].Header) {
+			return false
+		}
+		if o.Weight != nil {
+			w, err := strconv.ParseFloat(*o.Weight, 64)
+			if err != nil || w != origins[i].Weight {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ParametersToPool converts LoadBalancerPoolParameters into a
+// cloudflare Pool. Pools and origins are enabled, and origins
+// are equally weighted, unless specified otherwise.
+func ParametersToPool(spec v1alpha1.LoadBalancerPoolParameters) (cloudflare.LoadBalancerPool, error) {
+	p := cloudflare.LoadBalancerPool{
+		Name:         spec.Name,
+		Enabled:      true,
+		CheckRegions: checkRegionsToRegions(spec.CheckRegions),
+		Origins:      make([]cloudflare.LoadBalancerOrigin, len(spec.Origins)),
+	}
+
+	if spec.Description != nil {
+		p.Description = *spec.Description
+	}
+	if spec.Enabled != nil {
+		p.Enabled = *spec.Enabled
+	}
+	if spec.MinimumOrigins != nil {
+		p.MinimumOrigins = int(*spec.MinimumOrigins)
+	}
+	if spec.Monitor != nil {
+		p.Monitor = *spec.Monitor
+	}
+	if spec.NotificationEmail != nil {
+		p.NotificationEmail = *spec.NotificationEmail
+	}
+
+	for i, o := range spec.Origins {
+		co := cloudflare.LoadBalancerOrigin{
+			Name:    o.Name,
+			Address: o.Address,
+			Enabled: true,
+			Weight:  1,
+			Header:  o.Header,
+		}
+		if o.Enabled != nil {
+			co.Enabled = *o.Enabled
+		}
+		if o.Weight != nil {
+			w, err := strconv.ParseFloat(*o.Weight, 64)
+			if err != nil {
+				return cloudflare.LoadBalancerPool{}, errors.Wrap(err, errInvalidWeight)
+			}
+			co.Weight = w
+		}
+		p.Origins[i] = co
+	}
+	return p, nil
+}
+
+// UpdatePool updates mutable values on a Pool.
+func UpdatePool(ctx context.Context, client Client, poolID string, spec v1alpha1.LoadBalancerPoolParameters) error {
+	p, err := ParametersToPool(spec)
+	if err != nil {
+		return errors.Wrap(err, errUpdatePool)
+	}
+	p.ID = poolID
+
+	_, err = client.ModifyLoadBalancerPool(ctx, p)
+	return errors.Wrap(err, errUpdatePool)
+}
@@ -0,0 +1,397 @@
+package pool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/benagricola/provider-cloudflare/apis/loadbalancing/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/loadbalancing/pool/fake"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	ptr "k8s.io/utils/pointer"
+)
+
+func TestLateInitialize(t *testing.T) {
+	type args struct {
+		pp *v1alpha1.LoadBalancerPoolParameters
+		p  cloudflare.LoadBalancerPool
+	}
+
+	type want struct {
+		o  bool
+		pp *v1alpha1.LoadBalancerPoolParameters
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"LateInitSpecNil": {
+			reason: "LateInit should return false when not passed a spec",
+			args:   args{},
+			want: want{
+				o: false,
+			},
+		},
+		"LateInitDontUpdate": {
+			reason: "LateInit should not update already-set spec fields from a Pool",
+			args: args{
+				pp: &v1alpha1.LoadBalancerPoolParameters{
+					Name:         "primary",
+					CheckRegions: []v1alpha1.LoadBalancerPoolCheckRegion{"WEU"},
+					Enabled:      ptr.BoolPtr(false),
+					Origins: []v1alpha1.LoadBalancerOrigin{
+						{Name: "a", Address: "192.0.2.1", Enabled: ptr.BoolPtr(false), Weight: ptr.StringPtr("0.5")},
+					},
+				},
+				p: cloudflare.LoadBalancerPool{
+					Name:         "primary",
+					CheckRegions: []string{"WNAM"},
+					Enabled:      true,
+					Origins: []cloudflare.LoadBalancerOrigin{
+						{Name: "a", Address: "192.0.2.1", Enabled: true, Weight: 1},
+					},
+				},
+			},
+			want: want{
+				o: false,
+				pp: &v1alpha1.LoadBalancerPoolParameters{
+					Name:         "primary",
+					CheckRegions: []v1alpha1.LoadBalancerPoolCheckRegion{"WEU"},
+					Enabled:      ptr.BoolPtr(false),
+					Origins: []v1alpha1.LoadBalancerOrigin{
+						{Name: "a", Address: "192.0.2.1", Enabled: ptr.BoolPtr(false), Weight: ptr.StringPtr("0.5")},
+					},
+				},
+			},
+		},
+		"LateInitUpdate": {
+			reason: "LateInit should update unset spec and origin fields from a Pool",
+			args: args{
+				pp: &v1alpha1.LoadBalancerPoolParameters{
+					Name: "primary",
+					Origins: []v1alpha1.LoadBalancerOrigin{
+						{Name: "a", Address: "192.0.2.1"},
+					},
+				},
+				p: cloudflare.LoadBalancerPool{
+					Name:           "primary",
+					CheckRegions:   []string{"WNAM", "ENAM"},
+					Enabled:        true,
+					MinimumOrigins: 1,
+					Origins: []cloudflare.LoadBalancerOrigin{
+						{Name: "a", Address: "192.0.2.1", Enabled: true, Weight: 0.25},
+					},
+				},
+			},
+			want: want{
+				o: true,
+				pp: &v1alpha1.LoadBalancerPoolParameters{
+					Name:           "primary",
+					CheckRegions:   []v1alpha1.LoadBalancerPoolCheckRegion{"WNAM", "ENAM"},
+					Enabled:        ptr.BoolPtr(true),
+					MinimumOrigins: ptr.Int64Ptr(1),
+					Origins: []v1alpha1.LoadBalancerOrigin{
+						{Name: "a", Address: "192.0.2.1", Enabled: ptr.BoolPtr(true), Weight: ptr.StringPtr("0.25")},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := LateInitialize(tc.args.pp, tc.args.p)
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nLateInit(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.pp, tc.args.pp); diff != "" {
+				t.Errorf("\n%s\nLateInit(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpToDate(t *testing.T) {
+	type args struct {
+		pp *v1alpha1.LoadBalancerPoolParameters
+		p  cloudflare.LoadBalancerPool
+	}
+
+	type want struct {
+		o bool
+	}
+
+	pool := cloudflare.LoadBalancerPool{
+		Name:    "primary",
+		Enabled: true,
+		Monitor: "f1aba936b94213e5b8dca0c0dbf1f9cc",
+		Origins: []cloudflare.LoadBalancerOrigin{
+			{Name: "a", Address: "192.0.2.1", Enabled: true, Weight: 0.5},
+			{Name: "b", Address: "192.0.2.2", Enabled: true, Weight: 0.5},
+		},
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"UpToDateSpecNil": {
+			reason: "UpToDate should return true when not passed a spec",
+			args:   args{},
+			want: want{
+				o: true,
+			},
+		},
+		"UpToDate": {
+			reason: "UpToDate should return true when the Pool matches the spec",
+			args: args{
+				pp: &v1alpha1.LoadBalancerPoolParameters{
+					Name:    "primary",
+					Monitor: ptr.StringPtr("f1aba936b94213e5b8dca0c0dbf1f9cc"),
+					Origins: []v1alpha1.LoadBalancerOrigin{
+						{Name: "a", Address: "192.0.2.1", Weight: ptr.StringPtr("0.50")},
+						{Name: "b", Address: "192.0.2.2", Enabled: ptr.BoolPtr(true)},
+					},
+				},
+				p: pool,
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"NotUpToDateMonitor": {
+			reason: "UpToDate should return false when the monitor differs",
+			args: args{
+				pp: &v1alpha1.LoadBalancerPoolParameters{
+					Name:    "primary",
+					Monitor: ptr.StringPtr("4b4a6a1a06d0d8c4ad1f2b1e2a5bd6c8"),
+					Origins: []v1alpha1.LoadBalancerOrigin{
+						{Name: "a", Address: "192.0.2.1"},
+						{Name: "b", Address: "192.0.2.2"},
+					},
+				},
+				p: pool,
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"NotUpToDateOriginRemoved": {
+			reason: "UpToDate should return false when an origin has been removed",
+			args: args{
+				pp: &v1alpha1.LoadBalancerPoolParameters{
+					Name: "primary",
+					Origins: []v1alpha1.LoadBalancerOrigin{
+						{Name: "a", Address: "192.0.2.1"},
+					},
+				},
+				p: pool,
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"NotUpToDateOriginWeight": {
+			reason: "UpToDate should return false when an origin weight differs",
+			args: args{
+				pp: &v1alpha1.LoadBalancerPoolParameters{
+					Name: "primary",
+					Origins: []v1alpha1.LoadBalancerOrigin{
+						{Name: "a", Address: "192.0.2.1", Weight: ptr.StringPtr("0.75")},
+						{Name: "b", Address: "192.0.2.2"},
+					},
+				},
+				p: pool,
+			},
+			want: want{
+				o: false,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := UpToDate(tc.args.pp, tc.args.p)
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestParametersToPool(t *testing.T) {
+	type args struct {
+		pp v1alpha1.LoadBalancerPoolParameters
+	}
+
+	type want struct {
+		p   cloudflare.LoadBalancerPool
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Defaults": {
+			reason: "Pools and origins should be enabled and equally weighted by default",
+			args: args{
+				pp: v1alpha1.LoadBalancerPoolParameters{
+					Name: "primary",
+					Origins: []v1alpha1.LoadBalancerOrigin{
+						{Name: "a", Address: "192.0.2.1"},
+					},
+				},
+			},
+			want: want{
+				p: cloudflare.LoadBalancerPool{
+					Name:    "primary",
+					Enabled: true,
+					Origins: []cloudflare.LoadBalancerOrigin{
+						{Name: "a", Address: "192.0.2.1", Enabled: true, Weight: 1},
+					},
+				},
+			},
+		},
+		"AllFields": {
+			reason: "All set parameters should be converted",
+			args: args{
+				pp: v1alpha1.LoadBalancerPoolParameters{
+					Name:              "primary",
+					CheckRegions:      []v1alpha1.LoadBalancerPoolCheckRegion{"WEU", "EEU"},
+					Description:       ptr.StringPtr("Primary EU pool"),
+					Enabled:           ptr.BoolPtr(false),
+					MinimumOrigins:    ptr.Int64Ptr(2),
+					Monitor:           ptr.StringPtr("f1aba936b94213e5b8dca0c0dbf1f9cc"),
+					NotificationEmail: ptr.StringPtr("ops@example.com"),
+					Origins: []v1alpha1.LoadBalancerOrigin{
+						{Name: "a", Address: "192.0.2.1", Enabled: ptr.BoolPtr(false), Weight: ptr.StringPtr("0.3")},
+					},
+				},
+			},
+			want: want{
+				p: cloudflare.LoadBalancerPool{
+					Name:              "primary",
+					CheckRegions:      []string{"WEU", "EEU"},
+					Description:       "Primary EU pool",
+					Enabled:           false,
+					MinimumOrigins:    2,
+					Monitor:           "f1aba936b94213e5b8dca0c0dbf1f9cc",
+					NotificationEmail: "ops@example.com",
+					Origins: []cloudflare.LoadBalancerOrigin{
+						{Name: "a", Address: "192.0.2.1", Enabled: false, Weight: 0.3},
+					},
+				},
+			},
+		},
+		"InvalidWeight": {
+			reason: "An error should be returned if an origin weight is not a number",
+			args: args{
+				pp: v1alpha1.LoadBalancerPoolParameters{
+					Name: "primary",
+					Origins: []v1alpha1.LoadBalancerOrigin{
+						{Name: "a", Address: "192.0.2.1", Weight: ptr.StringPtr("heavy")},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errors.New(`strconv.ParseFloat: parsing "heavy": invalid syntax`), errInvalidWeight),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParametersToPool(tc.args.pp)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nParametersToPool(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.p, got); diff != "" {
+				t.Errorf("\n%s\nParametersToPool(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdatePool(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client Client
+	}
+
+	type args struct {
+		ctx context.Context
+		id  string
+		pp  v1alpha1.LoadBalancerPoolParameters
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"UpdatePoolFailed": {
+			reason: "UpdatePool should return errUpdatePool if the update fails",
+			fields: fields{
+				client: fake.MockClient{
+					MockModifyLoadBalancerPool: func(ctx context.Context, pool cloudflare.LoadBalancerPool) (cloudflare.LoadBalancerPool, error) {
+						return cloudflare.LoadBalancerPool{}, errBoom
+					},
+				},
+			},
+			args: args{
+				id: "1234beef",
+				pp: v1alpha1.LoadBalancerPoolParameters{Name: "primary"},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errUpdatePool),
+			},
+		},
+		"UpdatePool": {
+			reason: "UpdatePool should send the Pool ID with the update",
+			fields: fields{
+				client: fake.MockClient{
+					MockModifyLoadBalancerPool: func(ctx context.Context, pool cloudflare.LoadBalancerPool) (cloudflare.LoadBalancerPool, error) {
+						if pool.ID != "1234beef" {
+							return cloudflare.LoadBalancerPool{}, errBoom
+						}
+						return pool, nil
+					},
+				},
+			},
+			args: args{
+				id: "1234beef",
+				pp: v1alpha1.LoadBalancerPoolParameters{Name: "primary"},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := UpdatePool(tc.args.ctx, tc.fields.client, tc.args.id, tc.args.pp)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdatePool(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
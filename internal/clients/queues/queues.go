@@ -0,0 +1,206 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queues
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/benagricola/provider-cloudflare/apis/queues/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+const (
+	errCreateQueue = "error creating queue"
+	errUpdateQueue = "error updating queue"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with Queues. Queues are managed using raw API requests, as the
+// cloudflare-go library does not support them.
+type Client interface {
+	RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error)
+}
+
+// NewClient returns a new Cloudflare API client for working with Queues.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// Producer is a Worker that sends messages to a Queue, as represented by
+// the Cloudflare API.
+type Producer struct {
+	Script      string `json:"script,omitempty"`
+	Service     string `json:"service,omitempty"`
+	Environment string `json:"environment,omitempty"`
+}
+
+// ConsumerSettings configure how messages are delivered to a Consumer.
+type ConsumerSettings struct {
+	BatchSize     int `json:"batch_size,omitempty"`
+	MaxRetries    int `json:"max_retries,omitempty"`
+	MaxWaitTimeMs int `json:"max_wait_time_ms,omitempty"`
+}
+
+// Consumer receives messages from a Queue, as represented by the
+// Cloudflare API.
+type Consumer struct {
+	Script          string           `json:"script,omitempty"`
+	ScriptName      string           `json:"script_name,omitempty"`
+	Service         string           `json:"service,omitempty"`
+	Environment     string           `json:"environment,omitempty"`
+	Type            string           `json:"type,omitempty"`
+	DeadLetterQueue string           `json:"dead_letter_queue,omitempty"`
+	Settings        ConsumerSettings `json:"settings"`
+}
+
+// Queue is a Queue as represented by the Cloudflare API.
+type Queue struct {
+	ID         string     `json:"queue_id,omitempty"`
+	Name       string     `json:"queue_name"`
+	Producers  []Producer `json:"producers,omitempty"`
+	Consumers  []Consumer `json:"consumers,omitempty"`
+	CreatedOn  *time.Time `json:"created_on,omitempty"`
+	ModifiedOn *time.Time `json:"modified_on,omitempty"`
+}
+
+// IsQueueNotFound returns true if the passed error indicates a Queue was
+// not found.
+func IsQueueNotFound(err error) bool {
+	return clients.IsNotFound(err)
+}
+
+func endpoint(accountID string) string {
+	return "/accounts/" + accountID + "/queues"
+}
+
+func parseQueue(raw json.RawMessage) (*Queue, error) {
+	q := &Queue{}
+	if err := json.Unmarshal(raw, q); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// GetQueue returns the Queue with the given ID.
+func GetQueue(ctx context.Context, client Client, accountID, queueID string) (*Queue, error) {
+	raw, err := client.RawContext(ctx, http.MethodGet, endpoint(accountID)+"/"+queueID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseQueue(raw)
+}
+
+// firstOf returns the first non-empty string, as the Cloudflare API
+// reports the Worker bound to a Queue under different names depending on
+// how the binding was created.
+func firstOf(s ...string) string {
+	for _, v := range s {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// GenerateObservation creates an observation of a Queue.
+func GenerateObservation(in Queue) v1alpha1.QueueObservation {
+	o := v1alpha1.QueueObservation{}
+
+	for _, p := range in.Producers {
+		o.Producers = append(o.Producers, v1alpha1.QueueProducer{
+			Script:      firstOf(p.Script, p.Service),
+			Environment: p.Environment,
+		})
+	}
+
+	for _, c := range in.Consumers {
+		o.Consumers = append(o.Consumers, v1alpha1.QueueConsumer{
+			Script:          firstOf(c.Script, c.ScriptName, c.Service),
+			Environment:     c.Environment,
+			Type:            c.Type,
+			DeadLetterQueue: c.DeadLetterQueue,
+			BatchSize:       c.Settings.BatchSize,
+			MaxRetries:      c.Settings.MaxRetries,
+			MaxWaitTimeMs:   c.Settings.MaxWaitTimeMs,
+		})
+	}
+
+	if in.CreatedOn != nil {
+		t := metav1.NewTime(*in.CreatedOn)
+		o.CreatedOn = &t
+	}
+	if in.ModifiedOn != nil {
+		t := metav1.NewTime(*in.ModifiedOn)
+		o.ModifiedOn = &t
+	}
+	return o
+}
+
+// LateInitialize initializes QueueParameters based on the remote resource.
+func LateInitialize(spec *v1alpha1.QueueParameters, q Queue) bool {
+	if spec == nil {
+		return false
+	}
+
+	li := false
+	if spec.Name == nil {
+		spec.Name = &q.Name
+		li = true
+	}
+
+	return li
+}
+
+// UpToDate checks if the remote Queue is up to date with the requested
+// resource parameters.
+func UpToDate(spec *v1alpha1.QueueParameters, q Queue) bool {
+	if spec == nil || spec.Name == nil {
+		return true
+	}
+
+	return *spec.Name == q.Name
+}
+
+// CreateQueue creates a new Queue with the given name.
+func CreateQueue(ctx context.Context, client Client, accountID, name string) (*Queue, error) {
+	raw, err := client.RawContext(ctx, http.MethodPost, endpoint(accountID), Queue{Name: name})
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateQueue)
+	}
+	return parseQueue(raw)
+}
+
+// UpdateQueue renames the Queue with the given ID.
+func UpdateQueue(ctx context.Context, client Client, accountID, queueID string, spec v1alpha1.QueueParameters) error {
+	if spec.Name == nil {
+		return nil
+	}
+	_, err := client.RawContext(ctx, http.MethodPut, endpoint(accountID)+"/"+queueID, Queue{Name: *spec.Name})
+	return errors.Wrap(err, errUpdateQueue)
+}
+
+// DeleteQueue deletes the Queue with the given ID.
+func DeleteQueue(ctx context.Context, client Client, accountID, queueID string) error {
+	_, err := client.RawContext(ctx, http.MethodDelete, endpoint(accountID)+"/"+queueID, nil)
+	return err
+}
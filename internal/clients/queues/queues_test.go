@@ -0,0 +1,196 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queues
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ptr "k8s.io/utils/pointer"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/queues/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/queues/fake"
+)
+
+const (
+	accountID = "023e105f4ecef8ad9ca31a8372d0c353"
+	queueID   = "5e1b9969eb0a4c5a8d7ba7ad6b1d3a6e"
+)
+
+func TestGenerateObservation(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	mt := metav1.NewTime(now)
+
+	cases := map[string]struct {
+		reason string
+		raw    string
+		want   v1alpha1.QueueObservation
+	}{
+		"Bindings": {
+			reason: "The producers and consumers of a Queue should be observed",
+			raw: `{
+				"queue_id": "5e1b9969eb0a4c5a8d7ba7ad6b1d3a6e",
+				"queue_name": "orders",
+				"created_on": "2022-06-01T12:00:00Z",
+				"modified_on": "2022-06-01T12:00:00Z",
+				"producers": [{"script": "checkout", "environment": "production"}],
+				"consumers": [
+					{"script_name": "fulfilment", "type": "worker", "dead_letter_queue": "orders-dlq", "settings": {"batch_size": 10, "max_retries": 3, "max_wait_time_ms": 5000}},
+					{"type": "http_pull", "settings": {"batch_size": 50}}
+				]
+			}`,
+			want: v1alpha1.QueueObservation{
+				Producers: []v1alpha1.QueueProducer{{Script: "checkout", Environment: "production"}},
+				Consumers: []v1alpha1.QueueConsumer{
+					{Script: "fulfilment", Type: "worker", DeadLetterQueue: "orders-dlq", BatchSize: 10, MaxRetries: 3, MaxWaitTimeMs: 5000},
+					{Type: "http_pull", BatchSize: 50},
+				},
+				CreatedOn:  &mt,
+				ModifiedOn: &mt,
+			},
+		},
+		"Unbound": {
+			reason: "A Queue without producers or consumers should be observed",
+			raw:    `{"queue_id": "5e1b9969eb0a4c5a8d7ba7ad6b1d3a6e", "queue_name": "orders"}`,
+			want:   v1alpha1.QueueObservation{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q, err := parseQueue(json.RawMessage(tc.raw))
+			if err != nil {
+				t.Fatalf("\n%s\nparseQueue(...): %s", tc.reason, err)
+			}
+			got := GenerateObservation(*q)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateObservation(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		spec   *v1alpha1.QueueParameters
+		q      Queue
+		want   bool
+	}{
+		"NilSpec": {
+			reason: "A nil spec should always be up to date",
+			q:      Queue{Name: "orders"},
+			want:   true,
+		},
+		"NoName": {
+			reason: "A spec without a name should always be up to date",
+			spec:   &v1alpha1.QueueParameters{},
+			q:      Queue{Name: "orders"},
+			want:   true,
+		},
+		"SameName": {
+			reason: "A Queue with the requested name should be up to date",
+			spec:   &v1alpha1.QueueParameters{Name: ptr.StringPtr("orders")},
+			q:      Queue{Name: "orders"},
+			want:   true,
+		},
+		"Renamed": {
+			reason: "A Queue with a different name should not be up to date",
+			spec:   &v1alpha1.QueueParameters{Name: ptr.StringPtr("payments")},
+			q:      Queue{Name: "orders"},
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := UpToDate(tc.spec, tc.q)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdateQueue(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		err  error
+		data interface{}
+	}
+
+	cases := map[string]struct {
+		reason string
+		spec   v1alpha1.QueueParameters
+		err    error
+		want   want
+	}{
+		"NoName": {
+			reason: "No request should be sent when no name is set",
+			spec:   v1alpha1.QueueParameters{},
+			want:   want{},
+		},
+		"UpdateFailed": {
+			reason: "Errors renaming the Queue should be wrapped",
+			spec:   v1alpha1.QueueParameters{Name: ptr.StringPtr("payments")},
+			err:    errBoom,
+			want: want{
+				err:  errors.Wrap(errBoom, errUpdateQueue),
+				data: Queue{Name: "payments"},
+			},
+		},
+		"Success": {
+			reason: "The Queue should be renamed",
+			spec:   v1alpha1.QueueParameters{Name: ptr.StringPtr("payments")},
+			want: want{
+				data: Queue{Name: "payments"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var data interface{}
+			client := fake.MockClient{
+				MockRawContext: func(_ context.Context, m, e string, d interface{}) (json.RawMessage, error) {
+					if m != http.MethodPut || e != "/accounts/"+accountID+"/queues/"+queueID {
+						t.Errorf("\n%s\nUpdateQueue(...): unexpected request %s %s", tc.reason, m, e)
+					}
+					data = d
+					return json.RawMessage(`{}`), tc.err
+				},
+			}
+			err := UpdateQueue(context.Background(), client, accountID, queueID, tc.spec)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdateQueue(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.data, data); diff != "" {
+				t.Errorf("\n%s\nUpdateQueue(...): -want data, +got data:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/benagricola/provider-cloudflare/apis/r2/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+const (
+	errCreateBucket = "error creating r2 bucket"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with R2 Buckets. Buckets are managed using raw API requests, as the
+// cloudflare-go library does not support them.
+type Client interface {
+	RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error)
+}
+
+// NewClient returns a new Cloudflare API client for working with R2
+// Buckets.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// Bucket is an R2 Bucket as represented by the Cloudflare API.
+type Bucket struct {
+	Name         string     `json:"name"`
+	Location     string     `json:"location,omitempty"`
+	CreationDate *time.Time `json:"creation_date,omitempty"`
+}
+
+// createBucket is the request body used to create an R2 Bucket.
+type createBucket struct {
+	Name         string `json:"name"`
+	LocationHint string `json:"locationHint,omitempty"`
+}
+
+// IsBucketNotFound returns true if the passed error indicates an R2 Bucket
+// was not found.
+func IsBucketNotFound(err error) bool {
+	return clients.IsNotFound(err)
+}
+
+func endpoint(accountID string) string {
+	return "/accounts/" + accountID + "/r2/buckets"
+}
+
+func parseBucket(raw json.RawMessage) (*Bucket, error) {
+	b := &Bucket{}
+	if err := json.Unmarshal(raw, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// GetBucket returns the R2 Bucket with the given name.
+func GetBucket(ctx context.Context, client Client, accountID, name string) (*Bucket, error) {
+	raw, err := client.RawContext(ctx, http.MethodGet, endpoint(accountID)+"/"+name, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseBucket(raw)
+}
+
+// GenerateObservation creates an observation of an R2 Bucket.
+func GenerateObservation(in Bucket) v1alpha1.R2BucketObservation {
+	o := v1alpha1.R2BucketObservation{
+		Location: in.Location,
+	}
+	if in.CreationDate != nil {
+		t := metav1.NewTime(*in.CreationDate)
+		o.CreationDate = &t
+	}
+	return o
+}
+
+// CreateBucket creates a new R2 Bucket with the given name.
+func CreateBucket(ctx context.Context, client Client, accountID, name string, spec v1alpha1.R2BucketParameters) (*Bucket, error) {
+	req := createBucket{Name: name}
+	if spec.LocationHint != nil {
+		req.LocationHint = *spec.LocationHint
+	}
+
+	raw, err := client.RawContext(ctx, http.MethodPost, endpoint(accountID), req)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateBucket)
+	}
+	return parseBucket(raw)
+}
+
+// DeleteBucket deletes the R2 Bucket with the given name. The bucket must
+// be empty.
+func DeleteBucket(ctx context.Context, client Client, accountID, name string) error {
+	_, err := client.RawContext(ctx, http.MethodDelete, endpoint(accountID)+"/"+name, nil)
+	return err
+}
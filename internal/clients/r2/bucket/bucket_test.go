@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ptr "k8s.io/utils/pointer"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/r2/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/r2/bucket/fake"
+)
+
+const accountID = "023e105f4ecef8ad9ca31a8372d0c353"
+
+func TestGenerateObservation(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	mt := metav1.NewTime(now)
+
+	cases := map[string]struct {
+		reason string
+		b      Bucket
+		want   v1alpha1.R2BucketObservation
+	}{
+		"Full": {
+			reason: "The location and creation date of a Bucket should be observed",
+			b:      Bucket{Name: "assets", Location: "ENAM", CreationDate: &now},
+			want:   v1alpha1.R2BucketObservation{Location: "ENAM", CreationDate: &mt},
+		},
+		"Empty": {
+			reason: "A missing creation date should not be observed",
+			b:      Bucket{Name: "assets"},
+			want:   v1alpha1.R2BucketObservation{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateObservation(tc.b)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateObservation(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreateBucket(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		b    *Bucket
+		err  error
+		data interface{}
+	}
+
+	cases := map[string]struct {
+		reason string
+		spec   v1alpha1.R2BucketParameters
+		raw    json.RawMessage
+		err    error
+		want   want
+	}{
+		"CreateFailed": {
+			reason: "Errors creating the Bucket should be wrapped",
+			err:    errBoom,
+			want: want{
+				err:  errors.Wrap(errBoom, errCreateBucket),
+				data: createBucket{Name: "assets"},
+			},
+		},
+		"LocationHint": {
+			reason: "The location hint should be sent when it is set",
+			spec:   v1alpha1.R2BucketParameters{LocationHint: ptr.StringPtr("weur")},
+			raw:    json.RawMessage(`{"name":"assets","location":"WEUR"}`),
+			want: want{
+				b:    &Bucket{Name: "assets", Location: "WEUR"},
+				data: createBucket{Name: "assets", LocationHint: "weur"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var data interface{}
+			client := fake.MockClient{
+				MockRawContext: func(_ context.Context, m, e string, d interface{}) (json.RawMessage, error) {
+					if m != http.MethodPost || e != "/accounts/"+accountID+"/r2/buckets" {
+						t.Errorf("\n%s\nCreateBucket(...): unexpected request %s %s", tc.reason, m, e)
+					}
+					data = d
+					return tc.raw, tc.err
+				},
+			}
+			got, err := CreateBucket(context.Background(), client, accountID, "assets", tc.spec)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreateBucket(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.b, got); diff != "" {
+				t.Errorf("\n%s\nCreateBucket(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.data, data); diff != "" {
+				t.Errorf("\n%s\nCreateBucket(...): -want data, +got data:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+)
+
+const (
+	// DefaultRequestsPerSecond is the default sustained rate of requests
+	// made to the Cloudflare API, which matches the Cloudflare limit of
+	// 1200 requests per five minutes.
+	DefaultRequestsPerSecond = 4
+
+	// DefaultBurst is the default number of requests that may be made at
+	// once before the sustained rate applies.
+	DefaultBurst = 10
+
+	// DefaultMaxRetries is the default number of times a request rate
+	// limited by Cloudflare is retried.
+	DefaultMaxRetries = 5
+
+	minBackoff = 1 * time.Second
+	maxBackoff = 60 * time.Second
+)
+
+// RateLimit configures the request budget a client draws from.
+type RateLimit struct {
+	// Budget identifies the request budget. Clients configured with the
+	// same budget share a single rate limiter.
+	Budget string
+
+	RequestsPerSecond int
+	Burst             int
+	MaxRetries        int
+}
+
+// NewRateLimit returns the RateLimit of the budget with the given name,
+// applying defaults for any settings that are not configured.
+func NewRateLimit(budget string, in *v1alpha1.RateLimit) *RateLimit {
+	r := &RateLimit{
+		Budget:            budget,
+		RequestsPerSecond: DefaultRequestsPerSecond,
+		Burst:             DefaultBurst,
+		MaxRetries:        DefaultMaxRetries,
+	}
+	if in == nil {
+		return r
+	}
+	if in.RequestsPerSecond != nil {
+		r.RequestsPerSecond = *in.RequestsPerSecond
+	}
+	if in.Burst != nil {
+		r.Burst = *in.Burst
+	}
+	if in.MaxRetries != nil {
+		r.MaxRetries = *in.MaxRetries
+	}
+	return r
+}
+
+// budgets holds the rate limiter of each request budget, so that every
+// client created for a budget shares it regardless of which controller
+// created the client.
+var budgets = struct {
+	sync.Mutex
+	limiters map[string]*rate.Limiter
+}{limiters: map[string]*rate.Limiter{}}
+
+// limiterFor returns the shared rate limiter of the passed budget,
+// updating its rate if the budget has been reconfigured.
+func limiterFor(r RateLimit) *rate.Limiter {
+	budgets.Lock()
+	defer budgets.Unlock()
+
+	l, ok := budgets.limiters[r.Budget]
+	if !ok {
+		l = rate.NewLimiter(rate.Limit(r.RequestsPerSecond), r.Burst)
+		budgets.limiters[r.Budget] = l
+		return l
+	}
+	if l.Limit() != rate.Limit(r.RequestsPerSecond) {
+		l.SetLimit(rate.Limit(r.RequestsPerSecond))
+	}
+	if l.Burst() != r.Burst {
+		l.SetBurst(r.Burst)
+	}
+	return l
+}
+
+// RateLimitedHTTPClient returns a copy of the passed *http.Client whose
+// requests draw from the request budget of the passed RateLimit, and
+// which retries requests that were rate limited or failed transiently.
+func RateLimitedHTTPClient(hc *http.Client, r RateLimit) *http.Client {
+	base := hc.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	c := *hc
+	c.Transport = &retryTransport{
+		base:       base,
+		limiter:    limiterFor(r),
+		maxRetries: r.MaxRetries,
+		minBackoff: minBackoff,
+		maxBackoff: maxBackoff,
+	}
+	return &c
+}
+
+// A retryTransport is an http.RoundTripper that waits for its rate
+// limiter before making each request, and retries requests that were
+// rate limited by Cloudflare, failed with a server error or could not
+// be made.
+type retryTransport struct {
+	base       http.RoundTripper
+	limiter    *rate.Limiter
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+// RoundTrip makes the passed request, retrying it while it is rate
+// limited or fails transiently until the maximum number of retries has
+// been made.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	// A request can only be retried if its body can be read again.
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	for attempt := 0; ; attempt++ {
+		if err := t.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		r := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r = req.Clone(ctx)
+			r.Body = body
+		}
+
+		rsp, err := t.base.RoundTrip(r)
+		if !retryable(rsp, err) || attempt >= t.maxRetries || !replayable || ctx.Err() != nil {
+			return rsp, err
+		}
+
+		wait := t.backoff(attempt)
+		if err == nil {
+			wait = t.retryAfter(rsp, attempt)
+
+			// Drain the body so the connection can be reused.
+			_, _ = io.Copy(ioutil.Discard, rsp.Body)
+			_ = rsp.Body.Close()
+		}
+
+		tm := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			tm.Stop()
+			return nil, ctx.Err()
+		case <-tm.C:
+		}
+	}
+}
+
+// retryable returns true if a request that returned the passed response
+// and error may succeed if it is made again: it was rate limited, failed
+// with a server error, or could not be made at all.
+func retryable(rsp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return rsp.StatusCode == http.StatusTooManyRequests || rsp.StatusCode >= http.StatusInternalServerError
+}
+
+// retryAfter returns how long to wait before retrying a request. The
+// Retry-After header returned by Cloudflare is honoured if present,
+// otherwise the wait backs off exponentially.
+func (t *retryTransport) retryAfter(rsp *http.Response, attempt int) time.Duration {
+	if ra := rsp.Header.Get("Retry-After"); ra != "" {
+		if s, err := strconv.Atoi(ra); err == nil && s >= 0 {
+			return capDuration(time.Duration(s)*time.Second, t.maxBackoff)
+		}
+		if at, err := http.ParseTime(ra); err == nil {
+			d := time.Until(at)
+			if d < 0 {
+				d = 0
+			}
+			return capDuration(d, t.maxBackoff)
+		}
+	}
+	return t.backoff(attempt)
+}
+
+// backoff returns how long to wait before retrying a request that has
+// been made attempt times before. The wait backs off exponentially with
+// jitter so that clients that failed at the same time do not retry in
+// lockstep.
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	d := t.minBackoff
+	for i := 0; i < attempt && d < t.maxBackoff; i++ {
+		d *= 2
+	}
+	d = capDuration(d, t.maxBackoff)
+
+	// Wait for between half and all of the backoff.
+	half := d / 2
+	if half <= 0 {
+		return d
+	}
+	return half + time.Duration(rand.Int63n(int64(half)+1)) //nolint:gosec
+}
+
+func capDuration(d, max time.Duration) time.Duration {
+	if d > max {
+		return max
+	}
+	return d
+}
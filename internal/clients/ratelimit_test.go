@@ -0,0 +1,389 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	v1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+)
+
+// roundTripFn returns the response to a request with the passed body.
+type roundTripFn func(req *http.Request, body string) (*http.Response, error)
+
+func response(code int, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		StatusCode: code,
+		Header:     header,
+		Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+	}
+}
+
+// recordingTransport is an http.RoundTripper that records the bodies of
+// the requests it is passed.
+type recordingTransport struct {
+	fn     roundTripFn
+	bodies []string
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := ""
+	if req.Body != nil {
+		b, _ := ioutil.ReadAll(req.Body)
+		body = string(b)
+	}
+	rt.bodies = append(rt.bodies, body)
+	return rt.fn(req, body)
+}
+
+func TestNewRateLimit(t *testing.T) {
+	rps, burst, retries := 10, 20, 0
+
+	cases := map[string]struct {
+		reason string
+		in     *v1alpha1.RateLimit
+		want   *RateLimit
+	}{
+		"Defaults": {
+			reason: "Defaults should be used when no rate limit is configured",
+			want: &RateLimit{
+				Budget:            "example",
+				RequestsPerSecond: DefaultRequestsPerSecond,
+				Burst:             DefaultBurst,
+				MaxRetries:        DefaultMaxRetries,
+			},
+		},
+		"Configured": {
+			reason: "Configured settings should override the defaults",
+			in: &v1alpha1.RateLimit{
+				RequestsPerSecond: &rps,
+				Burst:             &burst,
+				MaxRetries:        &retries,
+			},
+			want: &RateLimit{
+				Budget:            "example",
+				RequestsPerSecond: 10,
+				Burst:             20,
+				MaxRetries:        0,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := NewRateLimit("example", tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nNewRateLimit(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLimiterFor(t *testing.T) {
+	a := limiterFor(RateLimit{Budget: "shared", RequestsPerSecond: 4, Burst: 10})
+	b := limiterFor(RateLimit{Budget: "shared", RequestsPerSecond: 8, Burst: 5})
+	if a != b {
+		t.Errorf("limiterFor(...): clients of the same budget should share a rate limiter")
+	}
+	if diff := cmp.Diff(rate.Limit(8), b.Limit()); diff != "" {
+		t.Errorf("limiterFor(...): -want limit, +got limit:\n%s\n", diff)
+	}
+	if diff := cmp.Diff(5, b.Burst()); diff != "" {
+		t.Errorf("limiterFor(...): -want burst, +got burst:\n%s\n", diff)
+	}
+
+	c := limiterFor(RateLimit{Budget: "other", RequestsPerSecond: 4, Burst: 10})
+	if a == c {
+		t.Errorf("limiterFor(...): clients of different budgets should not share a rate limiter")
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		code   int
+		err    error
+		bodies []string
+	}
+
+	cases := map[string]struct {
+		reason     string
+		maxRetries int
+		body       []byte
+		fn         roundTripFn
+		want       want
+	}{
+		"Success": {
+			reason:     "A request that is not rate limited should not be retried",
+			maxRetries: 3,
+			body:       []byte(`{"name":"example"}`),
+			fn: func(req *http.Request, body string) (*http.Response, error) {
+				return response(http.StatusOK, nil), nil
+			},
+			want: want{
+				code:   http.StatusOK,
+				bodies: []string{`{"name":"example"}`},
+			},
+		},
+		"Error": {
+			reason:     "Errors making a request should be returned once all retries have been made",
+			maxRetries: 2,
+			fn: func(req *http.Request, body string) (*http.Response, error) {
+				return nil, errBoom
+			},
+			want: want{
+				err:    errBoom,
+				bodies: []string{"", "", ""},
+			},
+		},
+		"ErrorThenSuccess": {
+			reason:     "A request that could not be made should be retried with its body",
+			maxRetries: 3,
+			body:       []byte(`{"name":"example"}`),
+			fn: func() roundTripFn {
+				calls := 0
+				return func(req *http.Request, body string) (*http.Response, error) {
+					calls++
+					if calls < 2 {
+						return nil, errBoom
+					}
+					return response(http.StatusOK, nil), nil
+				}
+			}(),
+			want: want{
+				code:   http.StatusOK,
+				bodies: []string{`{"name":"example"}`, `{"name":"example"}`},
+			},
+		},
+		"ServerError": {
+			reason:     "A request that failed with a server error should be retried",
+			maxRetries: 3,
+			fn: func() roundTripFn {
+				calls := 0
+				return func(req *http.Request, body string) (*http.Response, error) {
+					calls++
+					if calls < 3 {
+						return response(http.StatusServiceUnavailable, nil), nil
+					}
+					return response(http.StatusOK, nil), nil
+				}
+			}(),
+			want: want{
+				code:   http.StatusOK,
+				bodies: []string{"", "", ""},
+			},
+		},
+		"ServerErrorRetriesExhausted": {
+			reason:     "The server error response should be returned once all retries have been made",
+			maxRetries: 1,
+			fn: func(req *http.Request, body string) (*http.Response, error) {
+				return response(http.StatusBadGateway, nil), nil
+			},
+			want: want{
+				code:   http.StatusBadGateway,
+				bodies: []string{"", ""},
+			},
+		},
+		"RetryAfter": {
+			reason:     "A rate limited request should be retried with its body after the Retry-After period",
+			maxRetries: 3,
+			body:       []byte(`{"name":"example"}`),
+			fn: func() roundTripFn {
+				calls := 0
+				return func(req *http.Request, body string) (*http.Response, error) {
+					calls++
+					if calls < 3 {
+						return response(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"0"}}), nil
+					}
+					return response(http.StatusOK, nil), nil
+				}
+			}(),
+			want: want{
+				code:   http.StatusOK,
+				bodies: []string{`{"name":"example"}`, `{"name":"example"}`, `{"name":"example"}`},
+			},
+		},
+		"RetriesExhausted": {
+			reason:     "The rate limited response should be returned once all retries have been made",
+			maxRetries: 2,
+			fn: func(req *http.Request, body string) (*http.Response, error) {
+				return response(http.StatusTooManyRequests, nil), nil
+			},
+			want: want{
+				code:   http.StatusTooManyRequests,
+				bodies: []string{"", "", ""},
+			},
+		},
+		"ClientError": {
+			reason:     "Client error responses other than rate limiting should not be retried",
+			maxRetries: 3,
+			fn: func(req *http.Request, body string) (*http.Response, error) {
+				return response(http.StatusBadRequest, nil), nil
+			},
+			want: want{
+				code:   http.StatusBadRequest,
+				bodies: []string{""},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rt := &recordingTransport{fn: tc.fn}
+			tr := &retryTransport{
+				base:       rt,
+				limiter:    rate.NewLimiter(rate.Inf, 1),
+				maxRetries: tc.maxRetries,
+				minBackoff: time.Millisecond,
+				maxBackoff: 10 * time.Millisecond,
+			}
+
+			req, _ := http.NewRequest(http.MethodPost, "https://api.cloudflare.com/client/v4/zones", bytes.NewReader(tc.body))
+
+			rsp, err := tr.RoundTrip(req)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nRoundTrip(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			code := 0
+			if rsp != nil {
+				code = rsp.StatusCode
+			}
+			if diff := cmp.Diff(tc.want.code, code); diff != "" {
+				t.Errorf("\n%s\nRoundTrip(...): -want status, +got status:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.bodies, rt.bodies); diff != "" {
+				t.Errorf("\n%s\nRoundTrip(...): -want bodies, +got bodies:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRetryTransportCancelled(t *testing.T) {
+	rt := &recordingTransport{fn: func(req *http.Request, body string) (*http.Response, error) {
+		return response(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"30"}}), nil
+	}}
+	tr := &retryTransport{
+		base:       rt,
+		limiter:    rate.NewLimiter(rate.Inf, 1),
+		maxRetries: 3,
+		minBackoff: time.Millisecond,
+		maxBackoff: time.Minute,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.cloudflare.com/client/v4/zones", nil)
+	go cancel()
+
+	_, err := tr.RoundTrip(req)
+	if diff := cmp.Diff(context.Canceled, err, test.EquateErrors()); diff != "" {
+		t.Errorf("RoundTrip(...): -want error, +got error:\n%s\n", diff)
+	}
+}
+
+func TestRetryTransportNotReplayable(t *testing.T) {
+	rt := &recordingTransport{fn: func(req *http.Request, body string) (*http.Response, error) {
+		return response(http.StatusServiceUnavailable, nil), nil
+	}}
+	tr := &retryTransport{
+		base:       rt,
+		limiter:    rate.NewLimiter(rate.Inf, 1),
+		maxRetries: 3,
+		minBackoff: time.Millisecond,
+		maxBackoff: 10 * time.Millisecond,
+	}
+
+	// A body that cannot be read again means the request cannot be retried.
+	req, _ := http.NewRequest(http.MethodPost, "https://api.cloudflare.com/client/v4/zones", ioutil.NopCloser(bytes.NewReader([]byte(`{"name":"example"}`))))
+
+	rsp, err := tr.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(http.StatusServiceUnavailable, rsp.StatusCode); diff != "" {
+		t.Errorf("RoundTrip(...): -want status, +got status:\n%s\n", diff)
+	}
+	if diff := cmp.Diff([]string{`{"name":"example"}`}, rt.bodies); diff != "" {
+		t.Errorf("RoundTrip(...): a request that cannot be replayed should not be retried: -want bodies, +got bodies:\n%s\n", diff)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tr := &retryTransport{minBackoff: time.Second, maxBackoff: 60 * time.Second}
+
+	cases := map[string]struct {
+		reason  string
+		header  http.Header
+		attempt int
+		min     time.Duration
+		max     time.Duration
+	}{
+		"RetryAfterSeconds": {
+			reason: "A Retry-After header in seconds should be honoured",
+			header: http.Header{"Retry-After": []string{"7"}},
+			min:    7 * time.Second,
+			max:    7 * time.Second,
+		},
+		"RetryAfterCapped": {
+			reason: "A Retry-After header should not cause a wait longer than the maximum backoff",
+			header: http.Header{"Retry-After": []string{"3600"}},
+			min:    60 * time.Second,
+			max:    60 * time.Second,
+		},
+		"FirstBackoff": {
+			reason:  "The first retry should wait up to the minimum backoff",
+			attempt: 0,
+			min:     500 * time.Millisecond,
+			max:     time.Second,
+		},
+		"ExponentialBackoff": {
+			reason:  "Later retries should back off exponentially",
+			attempt: 3,
+			min:     4 * time.Second,
+			max:     8 * time.Second,
+		},
+		"MaxBackoff": {
+			reason:  "Backoff should not exceed the maximum backoff",
+			attempt: 20,
+			min:     30 * time.Second,
+			max:     60 * time.Second,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tr.retryAfter(response(http.StatusTooManyRequests, tc.header), tc.attempt)
+			if got < tc.min || got > tc.max {
+				t.Errorf("\n%s\nretryAfter(...): want between %s and %s, got %s", tc.reason, tc.min, tc.max, got)
+			}
+		})
+	}
+}
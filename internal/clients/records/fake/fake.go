@@ -18,6 +18,7 @@ package fake
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/cloudflare/cloudflare-go"
 )
@@ -27,7 +28,10 @@ type MockClient struct {
 	MockCreateDNSRecord func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) (*cloudflare.DNSRecordResponse, error)
 	MockUpdateDNSRecord func(ctx context.Context, zoneID, recordID string, rr cloudflare.DNSRecord) error
 	MockDNSRecord       func(ctx context.Context, zoneID, recordID string) (cloudflare.DNSRecord, error)
+	MockDNSRecords      func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error)
 	MockDeleteDNSRecord func(ctx context.Context, zoneID, recordID string) error
+	MockZoneDetails     func(ctx context.Context, zoneID string) (cloudflare.Zone, error)
+	MockRawContext      func(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error)
 }
 
 // CreateDNSRecord mocks the CreateDNSRecord method of the Cloudflare API.
@@ -45,7 +49,22 @@ func (m MockClient) DNSRecord(ctx context.Context, zoneID, recordID string) (clo
 	return m.MockDNSRecord(ctx, zoneID, recordID)
 }
 
+// DNSRecords mocks the DNSRecords method of the Cloudflare API.
+func (m MockClient) DNSRecords(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+	return m.MockDNSRecords(ctx, zoneID, rr)
+}
+
 // DeleteDNSRecord mocks the DeleteDNSRecord method of the Cloudflare API.
 func (m MockClient) DeleteDNSRecord(ctx context.Context, zoneID, recordID string) error {
 	return m.MockDeleteDNSRecord(ctx, zoneID, recordID)
 }
+
+// ZoneDetails mocks the ZoneDetails method of the Cloudflare API.
+func (m MockClient) ZoneDetails(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+	return m.MockZoneDetails(ctx, zoneID)
+}
+
+// RawContext mocks the RawContext method of the Cloudflare API.
+func (m MockClient) RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+	return m.MockRawContext(ctx, method, endpoint, data)
+}
@@ -18,10 +18,14 @@ package records
 
 import (
 	"context"
+	"encoding/json"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/cloudflare/cloudflare-go"
+	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/benagricola/provider-cloudflare/apis/dns/v1alpha1"
@@ -30,16 +34,61 @@ import (
 
 const (
 	// Cloudflare returns this code when a record isnt found.
-	errRecordNotFound = "81044"
+	errRecordNotFound = 81044
+
+	// Cloudflare returns this code when creating a record that
+	// already exists.
+	errRecordAlreadyExists = 81057
+
+	// Cloudflare uses a TTL of 1 to indicate an automatic TTL.
+	ttlAuto = 1
+
+	errInvalidData = "invalid record data %s"
 )
 
+// recordTypes are the DNS record types that may be managed by this provider.
+// These must be kept in sync with the enum on RecordParameters.Type.
+var recordTypes = map[string]bool{
+	"A":      true,
+	"AAAA":   true,
+	"CAA":    true,
+	"CNAME":  true,
+	"TXT":    true,
+	"SRV":    true,
+	"LOC":    true,
+	"MX":     true,
+	"NS":     true,
+	"SPF":    true,
+	"CERT":   true,
+	"DNSKEY": true,
+	"DS":     true,
+	"NAPTR":  true,
+	"SMIMEA": true,
+	"SSHFP":  true,
+	"TLSA":   true,
+	"URI":    true,
+}
+
 // Client is a Cloudflare API client that implements methods for working
 // with DNS Records.
 type Client interface {
 	CreateDNSRecord(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) (*cloudflare.DNSRecordResponse, error)
 	UpdateDNSRecord(ctx context.Context, zoneID, recordID string, rr cloudflare.DNSRecord) error
 	DNSRecord(ctx context.Context, zoneID, recordID string) (cloudflare.DNSRecord, error)
+	DNSRecords(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error)
 	DeleteDNSRecord(ctx context.Context, zoneID, recordID string) error
+	ZoneDetails(ctx context.Context, zoneID string) (cloudflare.Zone, error)
+	RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error)
+}
+
+// recordSettings represents the per-record settings of a DNS Record
+// that are in the API but not supported in the go library yet.
+type recordSettings struct {
+	Settings struct {
+		FlattenCNAME *bool `json:"flatten_cname,omitempty"`
+		IPv4Only     *bool `json:"ipv4_only,omitempty"`
+		IPv6Only     *bool `json:"ipv6_only,omitempty"`
+	} `json:"settings"`
 }
 
 // NewClient returns a new Cloudflare API client for working with DNS Records.
@@ -50,7 +99,115 @@ func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
 // IsRecordNotFound returns true if the passed error indicates
 // a Record was not found.
 func IsRecordNotFound(err error) bool {
-	return strings.Contains(err.Error(), errRecordNotFound)
+	return clients.IsNotFound(err) || clients.HasErrorCode(err, errRecordNotFound)
+}
+
+// IsRecordAlreadyExists returns true if the passed error indicates
+// a Record could not be created because it already exists.
+func IsRecordAlreadyExists(err error) bool {
+	return clients.HasErrorCode(err, errRecordAlreadyExists)
+}
+
+// IsValidType returns true if the passed type is a DNS record type
+// that may be managed by this provider.
+func IsValidType(t string) bool {
+	return recordTypes[t]
+}
+
+// proxiableTypes are the DNS record types whose traffic
+// may be proxied via Cloudflare.
+var proxiableTypes = map[string]bool{
+	"A":     true,
+	"AAAA":  true,
+	"CNAME": true,
+}
+
+// planEnterprise is the legacy ID of the Cloudflare Enterprise plan.
+const planEnterprise = "enterprise"
+
+// DefaultProxied returns whether a DNS Record of the passed type and name
+// is proxied when Proxied is not set on it, on a zone on the passed plan.
+// An explicitly set Proxied always takes precedence over this default.
+// Otherwise:
+//
+//  1. Records are not proxied unless byDefault is true.
+//  2. Records of a type that cannot be proxied are not proxied.
+//  3. Wildcard records are only proxied on zones on the Enterprise plan,
+//     as other plans cannot proxy them.
+//  4. All other records are proxied.
+func DefaultProxied(recordType, name string, plan cloudflare.ZonePlan, byDefault bool) bool {
+	if !byDefault || !proxiableTypes[recordType] {
+		return false
+	}
+	if strings.HasPrefix(name, "*") && plan.LegacyID != planEnterprise {
+		return false
+	}
+	return true
+}
+
+// IsSelfReferencing returns true if the passed DNS Record is a CNAME
+// that points at its own name on the passed zone, which Cloudflare would
+// reject as a CNAME loop.
+func IsSelfReferencing(spec *v1alpha1.RecordParameters, zone string) bool {
+	if spec.Type == nil || *spec.Type != "CNAME" {
+		return false
+	}
+	return NormalizeName(spec.Name, zone) == NormalizeName(spec.Content, zone)
+}
+
+// NormalizeTTL returns the TTL that Cloudflare uses for the passed TTL.
+// Any TTL lower than the automatic TTL is treated as automatic.
+func NormalizeTTL(ttl int64) int64 {
+	if ttl < ttlAuto {
+		return ttlAuto
+	}
+	return ttl
+}
+
+// EffectiveTTL returns the TTL that Cloudflare uses for a DNS Record with
+// the passed TTL and proxied state. Cloudflare always uses the automatic
+// TTL for proxied records, regardless of the TTL they are sent with.
+func EffectiveTTL(ttl int64, proxied *bool) int64 {
+	if proxied != nil && *proxied {
+		return ttlAuto
+	}
+	return NormalizeTTL(ttl)
+}
+
+// ContentEqual returns true if the passed contents of a DNS Record of the
+// passed type are equal. Cloudflare returns the canonical form of address
+// records, so an IPv6 address such as 2001:0DB8:0::1 is returned as
+// 2001:db8::1. Addresses are compared by value rather than by text.
+func ContentEqual(recordType, a, b string) bool {
+	if recordType == "A" || recordType == "AAAA" {
+		ipa, ipb := net.ParseIP(a), net.ParseIP(b)
+		if ipa != nil && ipb != nil {
+			return ipa.Equal(ipb)
+		}
+	}
+	return a == b
+}
+
+// NormalizeName returns the absolute form of a DNS Record name on the
+// passed zone, so that relative (www) and absolute (www.example.com)
+// names can be compared. The @ shorthand refers to the zone apex.
+func NormalizeName(name, zone string) string {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+
+	if zone == "" {
+		return name
+	}
+
+	if name == "@" {
+		return zone
+	}
+
+	if name == zone || strings.HasSuffix(name, "."+zone) {
+		return name
+	}
+
+	return name + "." + zone
 }
 
 // GenerateObservation creates an observation of a cloudflare Record.
@@ -60,11 +217,188 @@ func GenerateObservation(in cloudflare.DNSRecord) v1alpha1.RecordObservation {
 		FQDN:       in.Name,
 		Zone:       in.ZoneName,
 		Locked:     in.Locked,
+		TTL:        NormalizeTTL(int64(in.TTL)),
 		CreatedOn:  &metav1.Time{Time: in.CreatedOn},
 		ModifiedOn: &metav1.Time{Time: in.ModifiedOn},
 	}
 }
 
+// RecordSettings returns the observed settings of the DNS Record.
+func RecordSettings(ctx context.Context, client Client, zoneID, recordID string) (*v1alpha1.RecordSettings, error) {
+	raw, err := client.RawContext(ctx, http.MethodGet, "/zones/"+zoneID+"/dns_records/"+recordID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRecordSettings(raw)
+}
+
+// ParseRecordSettings returns the settings of a DNS Record from a raw
+// DNS Record response.
+func ParseRecordSettings(raw json.RawMessage) (*v1alpha1.RecordSettings, error) {
+	rs := recordSettings{}
+	if err := json.Unmarshal(raw, &rs); err != nil {
+		return nil, err
+	}
+	return &v1alpha1.RecordSettings{
+		FlattenCNAME: rs.Settings.FlattenCNAME,
+		IPv4Only:     rs.Settings.IPv4Only,
+		IPv6Only:     rs.Settings.IPv6Only,
+	}, nil
+}
+
+// UpdateRecordSettings updates the settings of a DNS Record. Only the
+// settings that are set in the passed settings are sent.
+func UpdateRecordSettings(ctx context.Context, client Client, zoneID, recordID string, spec *v1alpha1.RecordSettings) error {
+	if spec == nil {
+		return nil
+	}
+
+	rs := recordSettings{}
+	rs.Settings.FlattenCNAME = spec.FlattenCNAME
+	rs.Settings.IPv4Only = spec.IPv4Only
+	rs.Settings.IPv6Only = spec.IPv6Only
+
+	_, err := client.RawContext(ctx, http.MethodPatch, "/zones/"+zoneID+"/dns_records/"+recordID, rs)
+	return err
+}
+
+// SettingsUpToDate checks if the observed settings of a DNS Record are
+// up to date with the requested settings. Settings that are not set in
+// the requested settings are not managed, so are not compared.
+func SettingsUpToDate(spec, o *v1alpha1.RecordSettings) bool {
+	if spec == nil {
+		return true
+	}
+	if o == nil {
+		o = &v1alpha1.RecordSettings{}
+	}
+
+	// Cloudflare omits settings that are disabled.
+	for _, s := range []struct{ want, got *bool }{
+		{spec.FlattenCNAME, o.FlattenCNAME},
+		{spec.IPv4Only, o.IPv4Only},
+		{spec.IPv6Only, o.IPv6Only},
+	} {
+		if s.want != nil && *s.want != (s.got != nil && *s.got) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// LookupRecord returns the ID of the DNS Record on the Zone matching
+// the type, name and content of the passed parameters. An empty ID is
+// returned if no single matching DNS Record exists.
+func LookupRecord(ctx context.Context, client Client, spec *v1alpha1.RecordParameters) (string, error) {
+	filter := cloudflare.DNSRecord{Content: spec.Content}
+	if spec.Type != nil {
+		filter.Type = *spec.Type
+	}
+
+	rrs, err := client.DNSRecords(ctx, *spec.Zone, filter)
+	if err != nil {
+		return "", err
+	}
+
+	id := ""
+	for _, rr := range rrs {
+		if NormalizeName(spec.Name, rr.ZoneName) != NormalizeName(rr.Name, rr.ZoneName) {
+			continue
+		}
+
+		// Do not guess which record is ours if there is more than one.
+		if id != "" {
+			return "", nil
+		}
+		id = rr.ID
+	}
+
+	return id, nil
+}
+
+// Data returns the structured data of a DNS Record in the form that
+// Cloudflare sends and receives it, or nil if the passed data is unset.
+// Numbers are returned as float64, as they are when Cloudflare's
+// response is decoded.
+func Data(in *v1alpha1.RecordData) (map[string]interface{}, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	d := map[string]interface{}{}
+
+	for k, v := range map[string]*string{
+		"service":        in.Service,
+		"proto":          in.Proto,
+		"name":           in.Name,
+		"target":         in.Target,
+		"tag":            in.Tag,
+		"value":          in.Value,
+		"lat_direction":  in.LatDirection,
+		"long_direction": in.LongDirection,
+	} {
+		if v != nil {
+			d[k] = *v
+		}
+	}
+
+	for k, v := range map[string]*int32{
+		"priority":     in.Priority,
+		"weight":       in.Weight,
+		"port":         in.Port,
+		"flags":        in.Flags,
+		"lat_degrees":  in.LatDegrees,
+		"lat_minutes":  in.LatMinutes,
+		"long_degrees": in.LongDegrees,
+		"long_minutes": in.LongMinutes,
+	} {
+		if v != nil {
+			d[k] = float64(*v)
+		}
+	}
+
+	// Decimal values are strings in our spec to avoid floats in the CRD.
+	for k, v := range map[string]*string{
+		"lat_seconds":    in.LatSeconds,
+		"long_seconds":   in.LongSeconds,
+		"altitude":       in.Altitude,
+		"size":           in.Size,
+		"precision_horz": in.PrecisionHorz,
+		"precision_vert": in.PrecisionVert,
+	} {
+		if v == nil {
+			continue
+		}
+		f, err := strconv.ParseFloat(*v, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, errInvalidData, k)
+		}
+		d[k] = f
+	}
+
+	return d, nil
+}
+
+// DataUpToDate checks if the observed structured data of a DNS Record
+// is up to date with the requested data. Only the values that are set
+// in the requested data are compared.
+func DataUpToDate(spec *v1alpha1.RecordData, o interface{}) bool {
+	want, err := Data(spec)
+	if err != nil {
+		return false
+	}
+
+	got, _ := o.(map[string]interface{})
+	for k, v := range want {
+		if got[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
 // LateInitialize initializes RecordParameters based on the remote resource.
 func LateInitialize(spec *v1alpha1.RecordParameters, o cloudflare.DNSRecord) bool {
 	if spec == nil {
@@ -72,6 +406,12 @@ func LateInitialize(spec *v1alpha1.RecordParameters, o cloudflare.DNSRecord) boo
 	}
 
 	li := false
+	if spec.TTL == nil && o.TTL != 0 {
+		ttl := NormalizeTTL(int64(o.TTL))
+		spec.TTL = &ttl
+		li = true
+	}
+
 	if spec.Proxied == nil && o.Proxied != nil {
 		spec.Proxied = o.Proxied
 		li = true
@@ -99,26 +439,29 @@ func UpToDate(spec *v1alpha1.RecordParameters, o cloudflare.DNSRecord) bool { //
 
 	// Check if mutable fields are up to date with resource
 
-	// If the Spec Name doesn't have the zone name on the end of it
-	// Add it on the end when checking the result from the API
-	// As CF returns the name as the full DNS record (including zone name)
-	fn := spec.Name
-	if !strings.HasSuffix(fn, o.ZoneName) {
-		fn = fn + "." + o.ZoneName
-	}
-
-	if fn != o.Name {
+	// CF returns the name as the full DNS record (including zone name)
+	// so compare the absolute forms of both names.
+	if NormalizeName(spec.Name, o.ZoneName) != NormalizeName(o.Name, o.ZoneName) {
 		return false
 	}
 
-	if spec.Content != o.Content {
+	// Cloudflare generates the content of records with structured
+	// data, so we compare their data instead.
+	if spec.Data != nil {
+		if !DataUpToDate(spec.Data, o.Data) {
+			return false
+		}
+	} else if !ContentEqual(o.Type, spec.Content, o.Content) {
 		return false
 	}
 
-	if spec.TTL != nil && *spec.TTL != int64(o.TTL) {
+	if spec.TTL != nil && EffectiveTTL(*spec.TTL, spec.Proxied) != NormalizeTTL(int64(o.TTL)) {
 		return false
 	}
 
+	// Cloudflare keeps the proxied state of records in a paused zone,
+	// even though their traffic is not proxied while the zone is paused,
+	// so the record alone tells us whether it has drifted.
 	if spec.Proxied != nil && o.Proxied != nil && *spec.Proxied != *o.Proxied {
 		return false
 	}
@@ -130,21 +473,39 @@ func UpToDate(spec *v1alpha1.RecordParameters, o cloudflare.DNSRecord) bool { //
 	return true
 }
 
+// Priority returns the passed Priority of a DNS Record as the unsigned
+// 16 bit integer that Cloudflare expects for MX, SRV and URI records,
+// or nil if it is unset.
+func Priority(p *int32) *uint16 {
+	if p == nil {
+		return nil
+	}
+	v := uint16(*p)
+	return &v
+}
+
 // UpdateRecord updates mutable values on a DNS Record.
 func UpdateRecord(ctx context.Context, client Client, recordID string, spec *v1alpha1.RecordParameters) error {
-	// Cloudflare probably should not rely on the int type like this
-	ttl := int(*spec.TTL)
+	// Cloudflare probably should not rely on the int type like this.
+	// Proxying a record forces its TTL to automatic, so we send that
+	// rather than the TTL in our spec.
+	ttl := int(EffectiveTTL(*spec.TTL, spec.Proxied))
 
 	rr := cloudflare.DNSRecord{
-		Type:    *spec.Type,
-		Name:    spec.Name,
-		TTL:     ttl,
-		Content: spec.Content,
-		Proxied: spec.Proxied,
+		Type:     *spec.Type,
+		Name:     spec.Name,
+		TTL:      ttl,
+		Content:  spec.Content,
+		Proxied:  spec.Proxied,
+		Priority: Priority(spec.Priority),
 	}
 
-	if spec.Priority != nil {
-		*rr.Priority = uint16(*spec.Priority)
+	if spec.Data != nil {
+		d, err := Data(spec.Data)
+		if err != nil {
+			return err
+		}
+		rr.Data = d
 	}
 
 	return client.UpdateDNSRecord(ctx, *spec.Zone, recordID, rr)
@@ -17,14 +17,19 @@ limitations under the License.
 package records
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/cloudflare/cloudflare-go"
 
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/benagricola/provider-cloudflare/apis/dns/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/records/fake"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ptr "k8s.io/utils/pointer"
 )
 
@@ -92,6 +97,21 @@ func TestLateInitialize(t *testing.T) {
 				},
 			},
 		},
+		"LateInitTTL": {
+			reason: "LateInit should initialize an unset TTL from a Record",
+			args: args{
+				rp: &v1alpha1.RecordParameters{},
+				r: cloudflare.DNSRecord{
+					TTL: 600,
+				},
+			},
+			want: want{
+				o: true,
+				rp: &v1alpha1.RecordParameters{
+					TTL: ptr.Int64Ptr(600),
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -161,6 +181,260 @@ func TestUpToDate(t *testing.T) {
 				o: false,
 			},
 		},
+		"UpToDateRelativeName": {
+			reason: "UpToDate should return true if a relative spec name matches the record",
+			args: args{
+				rp: &v1alpha1.RecordParameters{
+					Name: "www",
+				},
+				r: cloudflare.DNSRecord{
+					Name:     "www.example.com",
+					ZoneName: "example.com",
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"UpToDateAbsoluteName": {
+			reason: "UpToDate should return true if an absolute spec name matches the record",
+			args: args{
+				rp: &v1alpha1.RecordParameters{
+					Name: "www.example.com",
+				},
+				r: cloudflare.DNSRecord{
+					Name:     "www.example.com",
+					ZoneName: "example.com",
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"UpToDateSuffixName": {
+			reason: "UpToDate should return false if a relative spec name only ends with the zone name",
+			args: args{
+				rp: &v1alpha1.RecordParameters{
+					Name: "myexample.com",
+				},
+				r: cloudflare.DNSRecord{
+					Name:     "myexample.com",
+					ZoneName: "example.com",
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"UpToDateTTLAuto": {
+			reason: "UpToDate should return true if the spec and record both use an automatic TTL",
+			args: args{
+				rp: &v1alpha1.RecordParameters{
+					Name: "foo",
+					TTL:  ptr.Int64Ptr(1),
+				},
+				r: cloudflare.DNSRecord{
+					Name: "foo",
+					TTL:  1,
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"UpToDateTTLZeroIsAuto": {
+			reason: "UpToDate should treat a TTL of 0 in the spec as automatic",
+			args: args{
+				rp: &v1alpha1.RecordParameters{
+					Name: "foo",
+					TTL:  ptr.Int64Ptr(0),
+				},
+				r: cloudflare.DNSRecord{
+					Name: "foo",
+					TTL:  1,
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"UpToDateTTLUnset": {
+			reason: "UpToDate should return true if the spec TTL is unset and the record uses an automatic TTL",
+			args: args{
+				rp: &v1alpha1.RecordParameters{
+					Name: "foo",
+				},
+				r: cloudflare.DNSRecord{
+					Name: "foo",
+					TTL:  1,
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"UpToDateTTLDifferent": {
+			reason: "UpToDate should return false if the spec uses an automatic TTL and the record does not",
+			args: args{
+				rp: &v1alpha1.RecordParameters{
+					Name: "foo",
+					TTL:  ptr.Int64Ptr(1),
+				},
+				r: cloudflare.DNSRecord{
+					Name: "foo",
+					TTL:  300,
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"UpToDateProxiedTTL": {
+			reason: "UpToDate should return true if a proxied record has an automatic TTL that differs from the spec",
+			args: args{
+				rp: &v1alpha1.RecordParameters{
+					Name:    "foo",
+					TTL:     ptr.Int64Ptr(600),
+					Proxied: ptr.BoolPtr(true),
+				},
+				r: cloudflare.DNSRecord{
+					Name:    "foo",
+					TTL:     1,
+					Proxied: ptr.BoolPtr(true),
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"UpToDateAAAACanonicalContent": {
+			reason: "UpToDate should return true if an AAAA record differs only by the form of its address",
+			args: args{
+				rp: &v1alpha1.RecordParameters{
+					Type:    ptr.StringPtr("AAAA"),
+					Name:    "foo",
+					Content: "2001:0DB8:0:0::1",
+					TTL:     ptr.Int64Ptr(600),
+					Proxied: ptr.BoolPtr(false),
+				},
+				r: cloudflare.DNSRecord{
+					Type:    "AAAA",
+					Name:    "foo",
+					Content: "2001:db8::1",
+					TTL:     600,
+					Proxied: ptr.BoolPtr(false),
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"UpToDateAAAADifferentContent": {
+			reason: "UpToDate should return false if an AAAA record has a different address",
+			args: args{
+				rp: &v1alpha1.RecordParameters{
+					Type:    ptr.StringPtr("AAAA"),
+					Name:    "foo",
+					Content: "2001:db8::2",
+				},
+				r: cloudflare.DNSRecord{
+					Type:    "AAAA",
+					Name:    "foo",
+					Content: "2001:db8::1",
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"UpToDateAAAAProxiedEnabled": {
+			reason: "UpToDate should return false if an AAAA record should be proxied but is not",
+			args: args{
+				rp: &v1alpha1.RecordParameters{
+					Type:    ptr.StringPtr("AAAA"),
+					Name:    "foo",
+					Content: "2001:db8::1",
+					TTL:     ptr.Int64Ptr(1),
+					Proxied: ptr.BoolPtr(true),
+				},
+				r: cloudflare.DNSRecord{
+					Type:    "AAAA",
+					Name:    "foo",
+					Content: "2001:db8::1",
+					TTL:     1,
+					Proxied: ptr.BoolPtr(false),
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"UpToDateAAAAProxiedDisabled": {
+			reason: "UpToDate should return false if an AAAA record should not be proxied but is",
+			args: args{
+				rp: &v1alpha1.RecordParameters{
+					Type:    ptr.StringPtr("AAAA"),
+					Name:    "foo",
+					Content: "2001:db8::1",
+					TTL:     ptr.Int64Ptr(600),
+					Proxied: ptr.BoolPtr(false),
+				},
+				r: cloudflare.DNSRecord{
+					Type:    "AAAA",
+					Name:    "foo",
+					Content: "2001:db8::1",
+					TTL:     1,
+					Proxied: ptr.BoolPtr(true),
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"UpToDateAAAAProxiedTTL": {
+			reason: "UpToDate should return true if a proxied AAAA record has the automatic TTL rather than its spec TTL",
+			args: args{
+				rp: &v1alpha1.RecordParameters{
+					Type:    ptr.StringPtr("AAAA"),
+					Name:    "foo",
+					Content: "2001:db8::1",
+					TTL:     ptr.Int64Ptr(600),
+					Proxied: ptr.BoolPtr(true),
+				},
+				r: cloudflare.DNSRecord{
+					Type:    "AAAA",
+					Name:    "foo",
+					Content: "2001:db8::1",
+					TTL:     1,
+					Proxied: ptr.BoolPtr(true),
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"UpToDateAAAATTLChanged": {
+			reason: "UpToDate should return false if an unproxied AAAA record has a different TTL",
+			args: args{
+				rp: &v1alpha1.RecordParameters{
+					Type:    ptr.StringPtr("AAAA"),
+					Name:    "foo",
+					Content: "2001:db8::1",
+					TTL:     ptr.Int64Ptr(600),
+					Proxied: ptr.BoolPtr(false),
+				},
+				r: cloudflare.DNSRecord{
+					Type:    "AAAA",
+					Name:    "foo",
+					Content: "2001:db8::1",
+					TTL:     300,
+					Proxied: ptr.BoolPtr(false),
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
 		"UpToDateIdentical": {
 			reason: "UpToDate should return true if the spec matches the record",
 			args: args{
@@ -183,6 +457,121 @@ func TestUpToDate(t *testing.T) {
 				o: true,
 			},
 		},
+		"UpToDatePriorityMX": {
+			reason: "UpToDate should return true if the priority of an MX record matches",
+			args: args{
+				rp: &v1alpha1.RecordParameters{
+					Type:     ptr.StringPtr("MX"),
+					Name:     "foo",
+					Content:  "mail.foo.com",
+					Priority: ptr.Int32Ptr(10),
+				},
+				r: cloudflare.DNSRecord{
+					Type:     "MX",
+					Name:     "foo",
+					Content:  "mail.foo.com",
+					Priority: uint16Ptr(10),
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"NotUpToDatePriorityMX": {
+			reason: "UpToDate should return false if the priority of an MX record has changed",
+			args: args{
+				rp: &v1alpha1.RecordParameters{
+					Type:     ptr.StringPtr("MX"),
+					Name:     "foo",
+					Content:  "mail.foo.com",
+					Priority: ptr.Int32Ptr(20),
+				},
+				r: cloudflare.DNSRecord{
+					Type:     "MX",
+					Name:     "foo",
+					Content:  "mail.foo.com",
+					Priority: uint16Ptr(10),
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"UpToDateDataSRV": {
+			reason: "UpToDate should compare the data rather than the content of an SRV record",
+			args: args{
+				rp: &v1alpha1.RecordParameters{
+					Type: ptr.StringPtr("SRV"),
+					Name: "_sip._tcp",
+					Data: &v1alpha1.RecordData{
+						Service:  ptr.StringPtr("_sip"),
+						Proto:    ptr.StringPtr("_tcp"),
+						Priority: ptr.Int32Ptr(10),
+						Weight:   ptr.Int32Ptr(5),
+						Port:     ptr.Int32Ptr(5060),
+						Target:   ptr.StringPtr("sip.foo.com"),
+					},
+				},
+				r: cloudflare.DNSRecord{
+					Type:    "SRV",
+					Name:    "_sip._tcp",
+					Content: "5\t5060\tsip.foo.com",
+					Data: map[string]interface{}{
+						"service":  "_sip",
+						"proto":    "_tcp",
+						"name":     "foo.com",
+						"priority": float64(10),
+						"weight":   float64(5),
+						"port":     float64(5060),
+						"target":   "sip.foo.com",
+					},
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"NotUpToDateDataSRV": {
+			reason: "UpToDate should return false if the data of an SRV record has changed",
+			args: args{
+				rp: &v1alpha1.RecordParameters{
+					Type: ptr.StringPtr("SRV"),
+					Name: "_sip._tcp",
+					Data: &v1alpha1.RecordData{
+						Port: ptr.Int32Ptr(5061),
+					},
+				},
+				r: cloudflare.DNSRecord{
+					Type: "SRV",
+					Name: "_sip._tcp",
+					Data: map[string]interface{}{
+						"port": float64(5060),
+					},
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"NotUpToDateDataMissing": {
+			reason: "UpToDate should return false if a record has no data but data is requested",
+			args: args{
+				rp: &v1alpha1.RecordParameters{
+					Type: ptr.StringPtr("CAA"),
+					Name: "foo",
+					Data: &v1alpha1.RecordData{
+						Tag: ptr.StringPtr("issue"),
+					},
+				},
+				r: cloudflare.DNSRecord{
+					Type: "CAA",
+					Name: "foo",
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -194,3 +583,666 @@ func TestUpToDate(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateRecord(t *testing.T) {
+	type want struct {
+		priority *uint16
+		json     string
+	}
+
+	cases := map[string]struct {
+		reason string
+		rp     *v1alpha1.RecordParameters
+		want   want
+	}{
+		"PriorityMX": {
+			reason: "UpdateRecord should send the priority of an MX record as an integer",
+			rp: &v1alpha1.RecordParameters{
+				Type:     ptr.StringPtr("MX"),
+				Name:     "foo",
+				Content:  "mail.foo.com",
+				TTL:      ptr.Int64Ptr(600),
+				Priority: ptr.Int32Ptr(65535),
+				Zone:     ptr.StringPtr("zone"),
+			},
+			want: want{
+				priority: uint16Ptr(65535),
+				json:     "65535",
+			},
+		},
+		"NoPriority": {
+			reason: "UpdateRecord should not send a priority when it is unset",
+			rp: &v1alpha1.RecordParameters{
+				Type:    ptr.StringPtr("A"),
+				Name:    "foo",
+				Content: "127.0.0.1",
+				TTL:     ptr.Int64Ptr(600),
+				Zone:    ptr.StringPtr("zone"),
+			},
+			want: want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got cloudflare.DNSRecord
+			client := fake.MockClient{
+				MockUpdateDNSRecord: func(ctx context.Context, zoneID, recordID string, rr cloudflare.DNSRecord) error {
+					got = rr
+					return nil
+				},
+			}
+			if err := UpdateRecord(context.Background(), client, "1234", tc.rp); err != nil {
+				t.Fatalf("\n%s\nUpdateRecord(...): unexpected error: %s\n", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.priority, got.Priority); diff != "" {
+				t.Errorf("\n%s\nUpdateRecord(...): -want priority, +got priority:\n%s\n", tc.reason, diff)
+			}
+			// The priority must be sent as an integer, as Cloudflare
+			// rejects priorities that are not.
+			body, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("\n%s\njson.Marshal(...): unexpected error: %s\n", tc.reason, err)
+			}
+			sent := struct {
+				Priority json.RawMessage `json:"priority"`
+			}{}
+			if err := json.Unmarshal(body, &sent); err != nil {
+				t.Fatalf("\n%s\njson.Unmarshal(...): unexpected error: %s\n", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.json, string(sent.Priority)); diff != "" {
+				t.Errorf("\n%s\nUpdateRecord(...): -want serialized priority, +got serialized priority:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestData(t *testing.T) {
+	type want struct {
+		d   map[string]interface{}
+		err bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		rd     *v1alpha1.RecordData
+		want   want
+	}{
+		"Nil": {
+			reason: "Data should return nil when no data is set",
+			want:   want{},
+		},
+		"CAA": {
+			reason: "Data should return the data of a CAA record",
+			rd: &v1alpha1.RecordData{
+				Flags: ptr.Int32Ptr(0),
+				Tag:   ptr.StringPtr("issue"),
+				Value: ptr.StringPtr("letsencrypt.org"),
+			},
+			want: want{
+				d: map[string]interface{}{
+					"flags": float64(0),
+					"tag":   "issue",
+					"value": "letsencrypt.org",
+				},
+			},
+		},
+		"LOC": {
+			reason: "Data should return decimal values of a LOC record as numbers",
+			rd: &v1alpha1.RecordData{
+				LatDegrees:   ptr.Int32Ptr(51),
+				LatMinutes:   ptr.Int32Ptr(30),
+				LatSeconds:   ptr.StringPtr("12.748"),
+				LatDirection: ptr.StringPtr("N"),
+				Altitude:     ptr.StringPtr("-10.5"),
+			},
+			want: want{
+				d: map[string]interface{}{
+					"lat_degrees":   float64(51),
+					"lat_minutes":   float64(30),
+					"lat_seconds":   12.748,
+					"lat_direction": "N",
+					"altitude":      -10.5,
+				},
+			},
+		},
+		"InvalidDecimal": {
+			reason: "Data should return an error if a decimal value is not a number",
+			rd: &v1alpha1.RecordData{
+				Size: ptr.StringPtr("large"),
+			},
+			want: want{
+				err: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Data(tc.rd)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nData(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.d, got); diff != "" {
+				t.Errorf("\n%s\nData(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNormalizeTTL(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		ttl    int64
+		want   int64
+	}{
+		"Zero": {
+			reason: "A TTL of 0 should be normalized to automatic",
+			ttl:    0,
+			want:   1,
+		},
+		"Auto": {
+			reason: "An automatic TTL should be left as automatic",
+			ttl:    1,
+			want:   1,
+		},
+		"Seconds": {
+			reason: "A TTL in seconds should be left as is",
+			ttl:    3600,
+			want:   3600,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := NormalizeTTL(tc.ttl)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nNormalizeTTL(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestEffectiveTTL(t *testing.T) {
+	type args struct {
+		ttl     int64
+		proxied *bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   int64
+	}{
+		"NotProxied": {
+			reason: "The TTL of a record that is not proxied should be used",
+			args: args{
+				ttl:     600,
+				proxied: ptr.BoolPtr(false),
+			},
+			want: 600,
+		},
+		"ProxiedUnset": {
+			reason: "The TTL of a record that is not known to be proxied should be used",
+			args: args{
+				ttl: 600,
+			},
+			want: 600,
+		},
+		"Proxied": {
+			reason: "A proxied record should always use the automatic TTL",
+			args: args{
+				ttl:     600,
+				proxied: ptr.BoolPtr(true),
+			},
+			want: 1,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := EffectiveTTL(tc.args.ttl, tc.args.proxied)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nEffectiveTTL(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestContentEqual(t *testing.T) {
+	type args struct {
+		recordType string
+		a          string
+		b          string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   bool
+	}{
+		"AEqual": {
+			reason: "A records with the same address should be equal",
+			args:   args{recordType: "A", a: "192.0.2.1", b: "192.0.2.1"},
+			want:   true,
+		},
+		"ADifferent": {
+			reason: "A records with different addresses should not be equal",
+			args:   args{recordType: "A", a: "192.0.2.1", b: "192.0.2.2"},
+			want:   false,
+		},
+		"AAAACanonical": {
+			reason: "AAAA records should be equal regardless of the form of their address",
+			args:   args{recordType: "AAAA", a: "2001:0DB8:0000:0000:0000:0000:0000:0001", b: "2001:db8::1"},
+			want:   true,
+		},
+		"AAAADifferent": {
+			reason: "AAAA records with different addresses should not be equal",
+			args:   args{recordType: "AAAA", a: "2001:db8::1", b: "2001:db8::2"},
+			want:   false,
+		},
+		"AAAAInvalid": {
+			reason: "AAAA records whose content is not an address should be compared as text",
+			args:   args{recordType: "AAAA", a: "not-an-address", b: "not-an-address"},
+			want:   true,
+		},
+		"TXTCaseSensitive": {
+			reason: "Records that are not address records should be compared as text",
+			args:   args{recordType: "TXT", a: "Hello", b: "hello"},
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ContentEqual(tc.args.recordType, tc.args.a, tc.args.b)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nContentEqual(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	type args struct {
+		name string
+		zone string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   string
+	}{
+		"Relative": {
+			reason: "A relative name should have the zone appended",
+			args:   args{name: "www", zone: "example.com"},
+			want:   "www.example.com",
+		},
+		"Absolute": {
+			reason: "An absolute name should be left as is",
+			args:   args{name: "www.example.com", zone: "example.com"},
+			want:   "www.example.com",
+		},
+		"AbsoluteTrailingDot": {
+			reason: "A fully qualified name should have its trailing dot removed",
+			args:   args{name: "www.example.com.", zone: "example.com"},
+			want:   "www.example.com",
+		},
+		"Apex": {
+			reason: "The zone name should be left as is",
+			args:   args{name: "example.com", zone: "example.com"},
+			want:   "example.com",
+		},
+		"ApexShorthand": {
+			reason: "The @ shorthand should refer to the zone apex",
+			args:   args{name: "@", zone: "example.com"},
+			want:   "example.com",
+		},
+		"MixedCase": {
+			reason: "Names should be compared case insensitively",
+			args:   args{name: "WWW.Example.com", zone: "example.com"},
+			want:   "www.example.com",
+		},
+		"RelativeWithZoneSuffix": {
+			reason: "A relative name ending with the zone name should have the zone appended",
+			args:   args{name: "myexample.com", zone: "example.com"},
+			want:   "myexample.com.example.com",
+		},
+		"NoZone": {
+			reason: "A name should be left as is when the zone is unknown",
+			args:   args{name: "www", zone: ""},
+			want:   "www",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := NormalizeName(tc.args.name, tc.args.zone)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nNormalizeName(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsSelfReferencing(t *testing.T) {
+	type args struct {
+		spec *v1alpha1.RecordParameters
+		zone string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   bool
+	}{
+		"NotCNAME": {
+			reason: "Only CNAME records can point to themselves",
+			args: args{
+				spec: &v1alpha1.RecordParameters{
+					Type:    ptr.StringPtr("TXT"),
+					Name:    "www.example.com",
+					Content: "www.example.com",
+				},
+			},
+			want: false,
+		},
+		"DifferentTarget": {
+			reason: "A CNAME record pointing to another name should not be self referencing",
+			args: args{
+				spec: &v1alpha1.RecordParameters{
+					Type:    ptr.StringPtr("CNAME"),
+					Name:    "www",
+					Content: "example.com",
+				},
+				zone: "example.com",
+			},
+			want: false,
+		},
+		"SameName": {
+			reason: "A CNAME record pointing to its own name should be self referencing",
+			args: args{
+				spec: &v1alpha1.RecordParameters{
+					Type:    ptr.StringPtr("CNAME"),
+					Name:    "www.example.com",
+					Content: "WWW.example.com.",
+				},
+			},
+			want: true,
+		},
+		"RelativeName": {
+			reason: "A CNAME record with a relative name pointing to its absolute name should be self referencing",
+			args: args{
+				spec: &v1alpha1.RecordParameters{
+					Type:    ptr.StringPtr("CNAME"),
+					Name:    "www",
+					Content: "www.example.com",
+				},
+				zone: "example.com",
+			},
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsSelfReferencing(tc.args.spec, tc.args.zone)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsSelfReferencing(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDefaultProxied(t *testing.T) {
+	free := cloudflare.ZonePlan{LegacyID: "free"}
+	enterprise := cloudflare.ZonePlan{LegacyID: "enterprise"}
+
+	type args struct {
+		recordType string
+		name       string
+		plan       cloudflare.ZonePlan
+		byDefault  bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   bool
+	}{
+		"A": {
+			reason: "A records should be proxied when records are proxied by default",
+			args:   args{recordType: "A", name: "www", plan: free, byDefault: true},
+			want:   true,
+		},
+		"AAAA": {
+			reason: "AAAA records should be proxied when records are proxied by default",
+			args:   args{recordType: "AAAA", name: "www", plan: free, byDefault: true},
+			want:   true,
+		},
+		"CNAME": {
+			reason: "CNAME records should be proxied when records are proxied by default",
+			args:   args{recordType: "CNAME", name: "www", plan: free, byDefault: true},
+			want:   true,
+		},
+		"TXT": {
+			reason: "Records of a type that cannot be proxied should not be proxied by default",
+			args:   args{recordType: "TXT", name: "www", plan: enterprise, byDefault: true},
+			want:   false,
+		},
+		"MX": {
+			reason: "Records of a type that cannot be proxied should not be proxied by default",
+			args:   args{recordType: "MX", name: "@", plan: free, byDefault: true},
+			want:   false,
+		},
+		"WildcardFree": {
+			reason: "Wildcard records should not be proxied by default on plans that cannot proxy them",
+			args:   args{recordType: "A", name: "*.example.com", plan: free, byDefault: true},
+			want:   false,
+		},
+		"WildcardEnterprise": {
+			reason: "Wildcard records should be proxied by default on the Enterprise plan",
+			args:   args{recordType: "A", name: "*.example.com", plan: enterprise, byDefault: true},
+			want:   true,
+		},
+		"NotByDefault": {
+			reason: "Records should not be proxied unless records are proxied by default",
+			args:   args{recordType: "A", name: "www", plan: enterprise, byDefault: false},
+			want:   false,
+		},
+		"WildcardEnterpriseNotByDefault": {
+			reason: "Wildcard records should not be proxied on the Enterprise plan unless records are proxied by default",
+			args:   args{recordType: "CNAME", name: "*", plan: enterprise, byDefault: false},
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := DefaultProxied(tc.args.recordType, tc.args.name, tc.args.plan, tc.args.byDefault)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nDefaultProxied(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSettingsUpToDate(t *testing.T) {
+	type args struct {
+		spec *v1alpha1.RecordSettings
+		o    *v1alpha1.RecordSettings
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   bool
+	}{
+		"NotManaged": {
+			reason: "Settings should be up to date when none are managed",
+			args: args{
+				o: &v1alpha1.RecordSettings{FlattenCNAME: ptr.BoolPtr(true)},
+			},
+			want: true,
+		},
+		"UpToDate": {
+			reason: "Settings should be up to date when the managed settings match",
+			args: args{
+				spec: &v1alpha1.RecordSettings{FlattenCNAME: ptr.BoolPtr(true)},
+				o:    &v1alpha1.RecordSettings{FlattenCNAME: ptr.BoolPtr(true), IPv6Only: ptr.BoolPtr(true)},
+			},
+			want: true,
+		},
+		"UpToDateOmitted": {
+			reason: "A disabled setting should be up to date when Cloudflare omits it",
+			args: args{
+				spec: &v1alpha1.RecordSettings{IPv4Only: ptr.BoolPtr(false)},
+				o:    &v1alpha1.RecordSettings{},
+			},
+			want: true,
+		},
+		"UpToDateNotObserved": {
+			reason: "Disabled settings should be up to date when no settings were observed",
+			args: args{
+				spec: &v1alpha1.RecordSettings{IPv6Only: ptr.BoolPtr(false)},
+			},
+			want: true,
+		},
+		"FlattenCNAMEChanged": {
+			reason: "Settings should not be up to date when FlattenCNAME has drifted",
+			args: args{
+				spec: &v1alpha1.RecordSettings{FlattenCNAME: ptr.BoolPtr(true)},
+				o:    &v1alpha1.RecordSettings{FlattenCNAME: ptr.BoolPtr(false)},
+			},
+			want: false,
+		},
+		"IPv4OnlyChanged": {
+			reason: "Settings should not be up to date when IPv4Only has drifted",
+			args: args{
+				spec: &v1alpha1.RecordSettings{IPv4Only: ptr.BoolPtr(false)},
+				o:    &v1alpha1.RecordSettings{IPv4Only: ptr.BoolPtr(true)},
+			},
+			want: false,
+		},
+		"IPv6OnlyChanged": {
+			reason: "Settings should not be up to date when IPv6Only is not observed",
+			args: args{
+				spec: &v1alpha1.RecordSettings{IPv6Only: ptr.BoolPtr(true)},
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := SettingsUpToDate(tc.args.spec, tc.args.o)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nSettingsUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestParseRecordSettings(t *testing.T) {
+	type want struct {
+		rs  *v1alpha1.RecordSettings
+		err bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		raw    string
+		want   want
+	}{
+		"Settings": {
+			reason: "The settings of a record should be parsed",
+			raw:    `{"id":"1234beef","settings":{"flatten_cname":true,"ipv4_only":false}}`,
+			want: want{
+				rs: &v1alpha1.RecordSettings{FlattenCNAME: ptr.BoolPtr(true), IPv4Only: ptr.BoolPtr(false)},
+			},
+		},
+		"NoSettings": {
+			reason: "A record without settings should have empty settings",
+			raw:    `{"id":"1234beef"}`,
+			want: want{
+				rs: &v1alpha1.RecordSettings{},
+			},
+		},
+		"Invalid": {
+			reason: "An error should be returned for an invalid response",
+			raw:    `{`,
+			want: want{
+				err: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseRecordSettings([]byte(tc.raw))
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nParseRecordSettings(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.rs, got); diff != "" {
+				t.Errorf("\n%s\nParseRecordSettings(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGenerateObservation(t *testing.T) {
+	created := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	modified := time.Date(2021, 6, 2, 8, 30, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		reason string
+		in     cloudflare.DNSRecord
+		want   v1alpha1.RecordObservation
+	}{
+		"Timestamps": {
+			reason: "GenerateObservation should map the creation and modification times of a record",
+			in: cloudflare.DNSRecord{
+				Name:       "www.example.com",
+				ZoneName:   "example.com",
+				Proxiable:  true,
+				Locked:     true,
+				TTL:        300,
+				CreatedOn:  created,
+				ModifiedOn: modified,
+			},
+			want: v1alpha1.RecordObservation{
+				Proxiable:  true,
+				FQDN:       "www.example.com",
+				Zone:       "example.com",
+				Locked:     true,
+				TTL:        300,
+				CreatedOn:  &metav1.Time{Time: created},
+				ModifiedOn: &metav1.Time{Time: modified},
+			},
+		},
+		"AutomaticTTL": {
+			reason: "GenerateObservation should normalize an unset TTL to automatic",
+			in: cloudflare.DNSRecord{
+				Name:       "www.example.com",
+				CreatedOn:  created,
+				ModifiedOn: created,
+			},
+			want: v1alpha1.RecordObservation{
+				FQDN:       "www.example.com",
+				TTL:        1,
+				CreatedOn:  &metav1.Time{Time: created},
+				ModifiedOn: &metav1.Time{Time: created},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateObservation(tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateObservation(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,424 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rulesets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/benagricola/provider-cloudflare/apis/rulesets/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+const (
+	// KindZone is the kind of the entry point Ruleset of a phase of a
+	// Zone.
+	KindZone = "zone"
+
+	errCreateRuleset = "error creating ruleset"
+	errUpdateRuleset = "error updating ruleset"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with Rulesets. Rulesets are managed using raw API requests, as the
+// cloudflare-go library does not support them.
+type Client interface {
+	RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error)
+}
+
+// NewClient returns a new Cloudflare API client for working with Rulesets.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// BlockResponse is a custom response sent when the block action is used.
+type BlockResponse struct {
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type,omitempty"`
+	Content     string `json:"content"`
+}
+
+// CategoryOverride overrides the rules of a managed ruleset that have a
+// given tag.
+type CategoryOverride struct {
+	Category string `json:"category"`
+	Enabled  *bool  `json:"enabled,omitempty"`
+	Action   string `json:"action,omitempty"`
+}
+
+// RuleOverride overrides a single rule of a managed ruleset.
+type RuleOverride struct {
+	ID               string `json:"id"`
+	Enabled          *bool  `json:"enabled,omitempty"`
+	Action           string `json:"action,omitempty"`
+	ScoreThreshold   int    `json:"score_threshold,omitempty"`
+	SensitivityLevel string `json:"sensitivity_level,omitempty"`
+}
+
+// Overrides override the behaviour of a managed ruleset.
+type Overrides struct {
+	Enabled          *bool              `json:"enabled,omitempty"`
+	Action           string             `json:"action,omitempty"`
+	SensitivityLevel string             `json:"sensitivity_level,omitempty"`
+	Categories       []CategoryOverride `json:"categories,omitempty"`
+	Rules            []RuleOverride     `json:"rules,omitempty"`
+}
+
+// RewriteValue rewrites a part of the URL of a request.
+type RewriteValue struct {
+	Value      *string `json:"value,omitempty"`
+	Expression *string `json:"expression,omitempty"`
+}
+
+// URIRewrite rewrites the URL of a request.
+type URIRewrite struct {
+	Path  *RewriteValue `json:"path,omitempty"`
+	Query *RewriteValue `json:"query,omitempty"`
+}
+
+// HeaderModification modifies a HTTP header.
+type HeaderModification struct {
+	Operation  string  `json:"operation"`
+	Value      *string `json:"value,omitempty"`
+	Expression *string `json:"expression,omitempty"`
+}
+
+// CacheTTL configures how long content is cached.
+type CacheTTL struct {
+	Mode    string `json:"mode"`
+	Default int    `json:"default,omitempty"`
+}
+
+// ActionParameters are the parameters of the action of a rule.
+type ActionParameters struct {
+	ID                      string                        `json:"id,omitempty"`
+	Overrides               *Overrides                    `json:"overrides,omitempty"`
+	Ruleset                 string                        `json:"ruleset,omitempty"`
+	Phases                  []string                      `json:"phases,omitempty"`
+	Products                []string                      `json:"products,omitempty"`
+	Response                *BlockResponse                `json:"response,omitempty"`
+	URI                     *URIRewrite                   `json:"uri,omitempty"`
+	Headers                 map[string]HeaderModification `json:"headers,omitempty"`
+	Cache                   *bool                         `json:"cache,omitempty"`
+	EdgeTTL                 *CacheTTL                     `json:"edge_ttl,omitempty"`
+	BrowserTTL              *CacheTTL                     `json:"browser_ttl,omitempty"`
+	RespectStrongETags      *bool                         `json:"respect_strong_etags,omitempty"`
+	OriginErrorPagePassthru *bool                         `json:"origin_error_page_passthru,omitempty"`
+}
+
+// RateLimit configures the rate limiting of a rule.
+type RateLimit struct {
+	Characteristics    []string `json:"characteristics"`
+	Period             int      `json:"period"`
+	RequestsPerPeriod  int      `json:"requests_per_period"`
+	MitigationTimeout  int      `json:"mitigation_timeout,omitempty"`
+	CountingExpression string   `json:"counting_expression,omitempty"`
+	RequestsToOrigin   bool     `json:"requests_to_origin,omitempty"`
+}
+
+// Rule is a rule of a Ruleset as represented by the Cloudflare API.
+type Rule struct {
+	ID               string            `json:"id,omitempty"`
+	Version          string            `json:"version,omitempty"`
+	LastUpdated      *time.Time        `json:"last_updated,omitempty"`
+	Action           string            `json:"action"`
+	ActionParameters *ActionParameters `json:"action_parameters,omitempty"`
+	Expression       string            `json:"expression"`
+	Description      string            `json:"description,omitempty"`
+	Enabled          bool              `json:"enabled"`
+	RateLimit        *RateLimit        `json:"ratelimit,omitempty"`
+}
+
+// Ruleset is a Ruleset as represented by the Cloudflare API.
+type Ruleset struct {
+	ID          string     `json:"id,omitempty"`
+	Name        string     `json:"name,omitempty"`
+	Description string     `json:"description"`
+	Kind        string     `json:"kind,omitempty"`
+	Phase       string     `json:"phase,omitempty"`
+	Version     string     `json:"version,omitempty"`
+	LastUpdated *time.Time `json:"last_updated,omitempty"`
+	Rules       []Rule     `json:"rules"`
+}
+
+// IsRulesetNotFound returns true if the passed error indicates
+// a Ruleset was not found.
+func IsRulesetNotFound(err error) bool {
+	return clients.IsNotFound(err)
+}
+
+func endpoint(zoneID string) string {
+	return "/zones/" + zoneID + "/rulesets"
+}
+
+func parseRuleset(raw json.RawMessage) (*Ruleset, error) {
+	rs := &Ruleset{}
+	if err := json.Unmarshal(raw, rs); err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+// GetRuleset returns the Ruleset with the given ID.
+func GetRuleset(ctx context.Context, client Client, zoneID, rulesetID string) (*Ruleset, error) {
+	raw, err := client.RawContext(ctx, http.MethodGet, endpoint(zoneID)+"/"+rulesetID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseRuleset(raw)
+}
+
+// GenerateObservation creates an observation of a Ruleset.
+func GenerateObservation(in Ruleset) v1alpha1.RulesetObservation {
+	o := v1alpha1.RulesetObservation{
+		Version: in.Version,
+	}
+	if in.LastUpdated != nil {
+		t := metav1.NewTime(*in.LastUpdated)
+		o.LastUpdated = &t
+	}
+	return o
+}
+
+func newOverrides(in *v1alpha1.RulesetOverrides) *Overrides {
+	if in == nil {
+		return nil
+	}
+
+	o := &Overrides{Enabled: in.Enabled}
+	if in.Action != nil {
+		o.Action = *in.Action
+	}
+	if in.SensitivityLevel != nil {
+		o.SensitivityLevel = *in.SensitivityLevel
+	}
+	for _, c := range in.Categories {
+		co := CategoryOverride{Category: c.Category, Enabled: c.Enabled}
+		if c.Action != nil {
+			co.Action = *c.Action
+		}
+		o.Categories = append(o.Categories, co)
+	}
+	for _, r := range in.Rules {
+		ro := RuleOverride{ID: r.ID, Enabled: r.Enabled}
+		if r.Action != nil {
+			ro.Action = *r.Action
+		}
+		if r.ScoreThreshold != nil {
+			ro.ScoreThreshold = *r.ScoreThreshold
+		}
+		if r.SensitivityLevel != nil {
+			ro.SensitivityLevel = *r.SensitivityLevel
+		}
+		o.Rules = append(o.Rules, ro)
+	}
+	return o
+}
+
+func newRewriteValue(in *v1alpha1.RulesetRewriteValue) *RewriteValue {
+	if in == nil {
+		return nil
+	}
+	return &RewriteValue{Value: in.Value, Expression: in.Expression}
+}
+
+func newURIRewrite(in *v1alpha1.RulesetURIRewrite) *URIRewrite {
+	if in == nil {
+		return nil
+	}
+	return &URIRewrite{Path: newRewriteValue(in.Path), Query: newRewriteValue(in.Query)}
+}
+
+// newHeaders returns the header modifications of a rule keyed by header
+// name, as they are represented by the Cloudflare API.
+func newHeaders(in []v1alpha1.RulesetHeader) map[string]HeaderModification {
+	if len(in) == 0 {
+		return nil
+	}
+	h := make(map[string]HeaderModification, len(in))
+	for _, hm := range in {
+		h[hm.Name] = HeaderModification{
+			Operation:  hm.Operation,
+			Value:      hm.Value,
+			Expression: hm.Expression,
+		}
+	}
+	return h
+}
+
+func newCacheTTL(in *v1alpha1.RulesetCacheTTL) *CacheTTL {
+	if in == nil {
+		return nil
+	}
+	t := &CacheTTL{Mode: in.Mode}
+	if in.Default != nil {
+		t.Default = *in.Default
+	}
+	return t
+}
+
+func newActionParameters(in *v1alpha1.RulesetRuleActionParameters) *ActionParameters {
+	if in == nil {
+		return nil
+	}
+
+	ap := &ActionParameters{
+		Overrides:               newOverrides(in.Overrides),
+		Phases:                  in.Phases,
+		URI:                     newURIRewrite(in.URI),
+		Headers:                 newHeaders(in.Headers),
+		Cache:                   in.Cache,
+		EdgeTTL:                 newCacheTTL(in.EdgeTTL),
+		BrowserTTL:              newCacheTTL(in.BrowserTTL),
+		RespectStrongETags:      in.RespectStrongETags,
+		OriginErrorPagePassthru: in.OriginErrorPagePassthru,
+	}
+	if in.ID != nil {
+		ap.ID = *in.ID
+	}
+	if in.Ruleset != nil {
+		ap.Ruleset = *in.Ruleset
+	}
+	for _, p := range in.Products {
+		ap.Products = append(ap.Products, string(p))
+	}
+	if in.Response != nil {
+		ap.Response = &BlockResponse{
+			StatusCode: in.Response.StatusCode,
+			Content:    in.Response.Content,
+		}
+		if in.Response.ContentType != nil {
+			ap.Response.ContentType = *in.Response.ContentType
+		}
+	}
+	return ap
+}
+
+func newRateLimit(in *v1alpha1.RulesetRuleRateLimit) *RateLimit {
+	if in == nil {
+		return nil
+	}
+
+	rl := &RateLimit{
+		Characteristics:   in.Characteristics,
+		Period:            in.Period,
+		RequestsPerPeriod: in.RequestsPerPeriod,
+	}
+	if in.MitigationTimeout != nil {
+		rl.MitigationTimeout = *in.MitigationTimeout
+	}
+	if in.CountingExpression != nil {
+		rl.CountingExpression = *in.CountingExpression
+	}
+	if in.RequestsToOrigin != nil {
+		rl.RequestsToOrigin = *in.RequestsToOrigin
+	}
+	return rl
+}
+
+// NewRules returns the rules of a Ruleset as represented by the
+// Cloudflare API, from the requested resource parameters. Rules are
+// enabled unless explicitly disabled.
+func NewRules(spec v1alpha1.RulesetParameters) []Rule {
+	rules := make([]Rule, 0, len(spec.Rules))
+	for _, r := range spec.Rules {
+		nr := Rule{
+			Action:           r.Action,
+			ActionParameters: newActionParameters(r.ActionParameters),
+			Expression:       r.Expression,
+			Enabled:          true,
+			RateLimit:        newRateLimit(r.RateLimit),
+		}
+		if r.Description != nil {
+			nr.Description = *r.Description
+		}
+		if r.Enabled != nil {
+			nr.Enabled = *r.Enabled
+		}
+		rules = append(rules, nr)
+	}
+	return rules
+}
+
+// NewRuleset returns a Ruleset as represented by the Cloudflare API,
+// from the requested resource parameters.
+func NewRuleset(spec v1alpha1.RulesetParameters) Ruleset {
+	rs := Ruleset{
+		Name:  spec.Name,
+		Kind:  KindZone,
+		Phase: spec.Phase,
+		Rules: NewRules(spec),
+	}
+	if spec.Description != nil {
+		rs.Description = *spec.Description
+	}
+	return rs
+}
+
+// UpToDate checks if the remote Ruleset is up to date with the requested
+// resource parameters. Rules are compared in order, ignoring the fields
+// Cloudflare sets on them.
+func UpToDate(spec *v1alpha1.RulesetParameters, rs Ruleset) bool {
+	if spec == nil {
+		return true
+	}
+
+	desired := NewRuleset(*spec)
+	if desired.Description != rs.Description {
+		return false
+	}
+
+	return cmp.Equal(desired.Rules, rs.Rules,
+		cmpopts.EquateEmpty(),
+		cmpopts.IgnoreFields(Rule{}, "ID", "Version", "LastUpdated"),
+	)
+}
+
+// CreateRuleset creates a new entry point Ruleset for a phase of a Zone.
+// Creation fails if the phase of the Zone already has an entry point
+// Ruleset, rather than replacing its rules.
+func CreateRuleset(ctx context.Context, client Client, zoneID string, spec v1alpha1.RulesetParameters) (*Ruleset, error) {
+	raw, err := client.RawContext(ctx, http.MethodPost, endpoint(zoneID), NewRuleset(spec))
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateRuleset)
+	}
+	return parseRuleset(raw)
+}
+
+// UpdateRuleset replaces the description and rules of the Ruleset with
+// the given ID.
+func UpdateRuleset(ctx context.Context, client Client, zoneID, rulesetID string, spec v1alpha1.RulesetParameters) error {
+	rs := NewRuleset(spec)
+	_, err := client.RawContext(ctx, http.MethodPut, endpoint(zoneID)+"/"+rulesetID, Ruleset{
+		Description: rs.Description,
+		Rules:       rs.Rules,
+	})
+	return errors.Wrap(err, errUpdateRuleset)
+}
+
+// DeleteRuleset deletes the Ruleset with the given ID.
+func DeleteRuleset(ctx context.Context, client Client, zoneID, rulesetID string) error {
+	_, err := client.RawContext(ctx, http.MethodDelete, endpoint(zoneID)+"/"+rulesetID, nil)
+	return err
+}
@@ -0,0 +1,426 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rulesets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	ptr "k8s.io/utils/pointer"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/rulesets/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/rulesets/fake"
+)
+
+const zoneID = "023e105f4ecef8ad9ca31a8372d0c353"
+
+func managedRulesetSpec() v1alpha1.RulesetParameters {
+	return v1alpha1.RulesetParameters{
+		Name:  "default",
+		Phase: "http_request_firewall_managed",
+		Rules: []v1alpha1.RulesetRule{
+			{
+				Action:     "execute",
+				Expression: "true",
+				ActionParameters: &v1alpha1.RulesetRuleActionParameters{
+					ID: ptr.StringPtr("efb7b8c949ac4650a09736fc376e9aee"),
+					Overrides: &v1alpha1.RulesetOverrides{
+						Categories: []v1alpha1.RulesetCategoryOverride{
+							{Category: "wordpress", Enabled: ptr.BoolPtr(false)},
+						},
+						Rules: []v1alpha1.RulesetRuleOverride{
+							{ID: "5de7edfa648c4d6891dc3e7f84534ffa", Action: ptr.StringPtr("log")},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func managedRules() []Rule {
+	return []Rule{
+		{
+			Action:     "execute",
+			Expression: "true",
+			Enabled:    true,
+			ActionParameters: &ActionParameters{
+				ID: "efb7b8c949ac4650a09736fc376e9aee",
+				Overrides: &Overrides{
+					Categories: []CategoryOverride{
+						{Category: "wordpress", Enabled: ptr.BoolPtr(false)},
+					},
+					Rules: []RuleOverride{
+						{ID: "5de7edfa648c4d6891dc3e7f84534ffa", Action: "log"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func transformRulesetSpec() v1alpha1.RulesetParameters {
+	return v1alpha1.RulesetParameters{
+		Name:  "default",
+		Phase: "http_request_late_transform",
+		Rules: []v1alpha1.RulesetRule{
+			{
+				Action:     "rewrite",
+				Expression: `http.host eq "example.com"`,
+				ActionParameters: &v1alpha1.RulesetRuleActionParameters{
+					URI: &v1alpha1.RulesetURIRewrite{
+						Path:  &v1alpha1.RulesetRewriteValue{Expression: ptr.StringPtr(`concat("/v2", http.request.uri.path)`)},
+						Query: &v1alpha1.RulesetRewriteValue{Value: ptr.StringPtr("")},
+					},
+					Headers: []v1alpha1.RulesetHeader{
+						{Name: "X-Source", Operation: "set", Value: ptr.StringPtr("cloudflare")},
+						{Name: "X-Forwarded-Host", Operation: "remove"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func transformRules() []Rule {
+	return []Rule{
+		{
+			Action:     "rewrite",
+			Expression: `http.host eq "example.com"`,
+			Enabled:    true,
+			ActionParameters: &ActionParameters{
+				URI: &URIRewrite{
+					Path:  &RewriteValue{Expression: ptr.StringPtr(`concat("/v2", http.request.uri.path)`)},
+					Query: &RewriteValue{Value: ptr.StringPtr("")},
+				},
+				Headers: map[string]HeaderModification{
+					"X-Source":         {Operation: "set", Value: ptr.StringPtr("cloudflare")},
+					"X-Forwarded-Host": {Operation: "remove"},
+				},
+			},
+		},
+	}
+}
+
+func cacheRulesetSpec() v1alpha1.RulesetParameters {
+	ttl := 3600
+	return v1alpha1.RulesetParameters{
+		Name:  "default",
+		Phase: "http_request_cache_settings",
+		Rules: []v1alpha1.RulesetRule{
+			{
+				Action:     "set_cache_settings",
+				Expression: `starts_with(http.request.uri.path, "/static/")`,
+				ActionParameters: &v1alpha1.RulesetRuleActionParameters{
+					Cache:              ptr.BoolPtr(true),
+					EdgeTTL:            &v1alpha1.RulesetCacheTTL{Mode: "override_origin", Default: &ttl},
+					BrowserTTL:         &v1alpha1.RulesetCacheTTL{Mode: "respect_origin"},
+					RespectStrongETags: ptr.BoolPtr(true),
+				},
+			},
+		},
+	}
+}
+
+func cacheRules() []Rule {
+	return []Rule{
+		{
+			Action:     "set_cache_settings",
+			Expression: `starts_with(http.request.uri.path, "/static/")`,
+			Enabled:    true,
+			ActionParameters: &ActionParameters{
+				Cache:              ptr.BoolPtr(true),
+				EdgeTTL:            &CacheTTL{Mode: "override_origin", Default: 3600},
+				BrowserTTL:         &CacheTTL{Mode: "respect_origin"},
+				RespectStrongETags: ptr.BoolPtr(true),
+			},
+		},
+	}
+}
+
+func TestNewRules(t *testing.T) {
+	timeout := 600
+
+	cases := map[string]struct {
+		reason string
+		spec   v1alpha1.RulesetParameters
+		want   []Rule
+	}{
+		"NoRules": {
+			reason: "A Ruleset without rules should have an empty list of rules",
+			spec:   v1alpha1.RulesetParameters{},
+			want:   []Rule{},
+		},
+		"Overrides": {
+			reason: "Overrides of a managed ruleset should be converted",
+			spec:   managedRulesetSpec(),
+			want:   managedRules(),
+		},
+		"Transform": {
+			reason: "URL rewrites and header modifications should be converted",
+			spec:   transformRulesetSpec(),
+			want:   transformRules(),
+		},
+		"CacheSettings": {
+			reason: "Cache settings should be converted",
+			spec:   cacheRulesetSpec(),
+			want:   cacheRules(),
+		},
+		"RateLimit": {
+			reason: "Disabled rate limiting rules should be converted",
+			spec: v1alpha1.RulesetParameters{
+				Rules: []v1alpha1.RulesetRule{
+					{
+						Action:      "block",
+						Expression:  `http.request.uri.path eq "/login"`,
+						Description: ptr.StringPtr("login"),
+						Enabled:     ptr.BoolPtr(false),
+						ActionParameters: &v1alpha1.RulesetRuleActionParameters{
+							Response: &v1alpha1.RulesetBlockResponse{StatusCode: 429, Content: "slow down"},
+						},
+						RateLimit: &v1alpha1.RulesetRuleRateLimit{
+							Characteristics:   []string{"ip.src", "cf.colo.id"},
+							Period:            60,
+							RequestsPerPeriod: 100,
+							MitigationTimeout: &timeout,
+						},
+					},
+				},
+			},
+			want: []Rule{
+				{
+					Action:      "block",
+					Expression:  `http.request.uri.path eq "/login"`,
+					Description: "login",
+					Enabled:     false,
+					ActionParameters: &ActionParameters{
+						Response: &BlockResponse{StatusCode: 429, Content: "slow down"},
+					},
+					RateLimit: &RateLimit{
+						Characteristics:   []string{"ip.src", "cf.colo.id"},
+						Period:            60,
+						RequestsPerPeriod: 100,
+						MitigationTimeout: 600,
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := NewRules(tc.spec)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nNewRules(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpToDate(t *testing.T) {
+	observed := func(m ...func(*Ruleset)) Ruleset {
+		rs := Ruleset{ID: "2f2feab2026849078ba485f918791bdc", Version: "3", Rules: managedRules()}
+		rs.Rules[0].ID = "fc3fa0fd8d5a4e21a9d8a1eb36e14e51"
+		rs.Rules[0].Version = "2"
+		for _, f := range m {
+			f(&rs)
+		}
+		return rs
+	}
+
+	cases := map[string]struct {
+		reason string
+		spec   *v1alpha1.RulesetParameters
+		rs     Ruleset
+		want   bool
+	}{
+		"NilSpec": {
+			reason: "A nil spec should always be up to date",
+			rs:     observed(),
+			want:   true,
+		},
+		"UpToDate": {
+			reason: "Rules set by Cloudflare should be ignored",
+			spec: func() *v1alpha1.RulesetParameters {
+				s := managedRulesetSpec()
+				return &s
+			}(),
+			rs:   observed(),
+			want: true,
+		},
+		"DescriptionChanged": {
+			reason: "A Ruleset with a different description should not be up to date",
+			spec: func() *v1alpha1.RulesetParameters {
+				s := managedRulesetSpec()
+				s.Description = ptr.StringPtr("managed")
+				return &s
+			}(),
+			rs:   observed(),
+			want: false,
+		},
+		"OverrideChanged": {
+			reason: "A Ruleset with a different override should not be up to date",
+			spec: func() *v1alpha1.RulesetParameters {
+				s := managedRulesetSpec()
+				s.Rules[0].ActionParameters.Overrides.Rules[0].Action = ptr.StringPtr("block")
+				return &s
+			}(),
+			rs:   observed(),
+			want: false,
+		},
+		"TransformUpToDate": {
+			reason: "Transform Rules matching the spec should be up to date",
+			spec: func() *v1alpha1.RulesetParameters {
+				s := transformRulesetSpec()
+				return &s
+			}(),
+			rs:   Ruleset{Rules: transformRules()},
+			want: true,
+		},
+		"HeaderChanged": {
+			reason: "A Ruleset with a different header modification should not be up to date",
+			spec: func() *v1alpha1.RulesetParameters {
+				s := transformRulesetSpec()
+				s.Rules[0].ActionParameters.Headers[0].Value = ptr.StringPtr("origin")
+				return &s
+			}(),
+			rs:   Ruleset{Rules: transformRules()},
+			want: false,
+		},
+		"RewriteChanged": {
+			reason: "A Ruleset with a different URL rewrite should not be up to date",
+			spec: func() *v1alpha1.RulesetParameters {
+				s := transformRulesetSpec()
+				s.Rules[0].ActionParameters.URI.Query = nil
+				return &s
+			}(),
+			rs:   Ruleset{Rules: transformRules()},
+			want: false,
+		},
+		"CacheTTLChanged": {
+			reason: "A Ruleset with a different edge TTL should not be up to date",
+			spec: func() *v1alpha1.RulesetParameters {
+				s := cacheRulesetSpec()
+				s.Rules[0].ActionParameters.EdgeTTL.Mode = "bypass"
+				return &s
+			}(),
+			rs:   Ruleset{Rules: cacheRules()},
+			want: false,
+		},
+		"RuleRemoved": {
+			reason: "A Ruleset with additional rules should not be up to date",
+			spec: func() *v1alpha1.RulesetParameters {
+				s := managedRulesetSpec()
+				s.Rules = nil
+				return &s
+			}(),
+			rs:   observed(),
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := UpToDate(tc.spec, tc.rs)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreateRuleset(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		rs   *Ruleset
+		err  error
+		data interface{}
+	}
+
+	cases := map[string]struct {
+		reason string
+		raw    json.RawMessage
+		err    error
+		want   want
+	}{
+		"CreateFailed": {
+			reason: "Errors creating the Ruleset should be wrapped",
+			err:    errBoom,
+			want: want{
+				err: errors.Wrap(errBoom, errCreateRuleset),
+				data: Ruleset{
+					Name:  "default",
+					Kind:  KindZone,
+					Phase: "http_request_firewall_managed",
+					Rules: managedRules(),
+				},
+			},
+		},
+		"Success": {
+			reason: "The created Ruleset should be returned",
+			raw:    json.RawMessage(`{"id":"2f2feab2026849078ba485f918791bdc","name":"default","kind":"zone","phase":"http_request_firewall_managed","version":"1"}`),
+			want: want{
+				rs: &Ruleset{
+					ID:      "2f2feab2026849078ba485f918791bdc",
+					Name:    "default",
+					Kind:    KindZone,
+					Phase:   "http_request_firewall_managed",
+					Version: "1",
+				},
+				data: Ruleset{
+					Name:  "default",
+					Kind:  KindZone,
+					Phase: "http_request_firewall_managed",
+					Rules: managedRules(),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var data interface{}
+			client := fake.MockClient{
+				MockRawContext: func(_ context.Context, m, e string, d interface{}) (json.RawMessage, error) {
+					if m != http.MethodPost || e != "/zones/"+zoneID+"/rulesets" {
+						t.Errorf("\n%s\nCreateRuleset(...): unexpected request %s %s", tc.reason, m, e)
+					}
+					data = d
+					return tc.raw, tc.err
+				},
+			}
+			got, err := CreateRuleset(context.Background(), client, zoneID, managedRulesetSpec())
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreateRuleset(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.rs, got); diff != "" {
+				t.Errorf("\n%s\nCreateRuleset(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.data, data); diff != "" {
+				t.Errorf("\n%s\nCreateRuleset(...): -want data, +got data:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
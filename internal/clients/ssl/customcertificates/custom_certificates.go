@@ -0,0 +1,230 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customcertificates
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/benagricola/provider-cloudflare/apis/ssl/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+const (
+	errNoCertificate      = "certificate or certificate secret ref is required"
+	errInvalidCertificate = "certificate is not a PEM encoded certificate"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with Custom Certificates. Custom Certificates are managed using raw API
+// requests, as the cloudflare-go library always sends geo restrictions
+// when uploading them, even when none are set.
+type Client interface {
+	RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error)
+}
+
+// NewClient returns a new Cloudflare API client for working with Custom
+// Certificates.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// GeoRestrictions restricts the data centers the private key of a Custom
+// Certificate is stored in.
+type GeoRestrictions struct {
+	Label string `json:"label"`
+}
+
+// Certificate is a Custom Certificate as represented by the Cloudflare
+// API.
+type Certificate struct {
+	ID              string           `json:"id,omitempty"`
+	Hosts           []string         `json:"hosts,omitempty"`
+	Issuer          string           `json:"issuer,omitempty"`
+	Signature       string           `json:"signature,omitempty"`
+	Status          string           `json:"status,omitempty"`
+	BundleMethod    string           `json:"bundle_method,omitempty"`
+	GeoRestrictions *GeoRestrictions `json:"geo_restrictions,omitempty"`
+	UploadedOn      *time.Time       `json:"uploaded_on,omitempty"`
+	ExpiresOn       *time.Time       `json:"expires_on,omitempty"`
+}
+
+// Options are the options used to upload a Custom Certificate.
+type Options struct {
+	Certificate     string           `json:"certificate"`
+	PrivateKey      string           `json:"private_key"`
+	BundleMethod    string           `json:"bundle_method,omitempty"`
+	GeoRestrictions *GeoRestrictions `json:"geo_restrictions,omitempty"`
+}
+
+// IsCustomCertificateNotFound returns true if the passed error indicates
+// a Custom Certificate was not found.
+func IsCustomCertificateNotFound(err error) bool {
+	return clients.IsNotFound(err)
+}
+
+func endpoint(zoneID string) string {
+	return "/zones/" + zoneID + "/custom_certificates"
+}
+
+// ResolveCertificate returns the desired PEM encoded certificate, read
+// from the referenced Secret if one is set.
+func ResolveCertificate(ctx context.Context, kube client.Reader, spec *v1alpha1.CustomCertificateParameters) (string, error) {
+	if spec.CertificateSecretRef != nil {
+		v, err := clients.GetSecretValue(ctx, kube, *spec.CertificateSecretRef)
+		return string(v), err
+	}
+	if spec.Certificate != nil {
+		return *spec.Certificate, nil
+	}
+	return "", errors.New(errNoCertificate)
+}
+
+// ResolvePrivateKey returns the PEM encoded private key read from the
+// referenced Secret.
+func ResolvePrivateKey(ctx context.Context, kube client.Reader, spec *v1alpha1.CustomCertificateParameters) (string, error) {
+	v, err := clients.GetSecretValue(ctx, kube, spec.PrivateKeySecretRef)
+	return string(v), err
+}
+
+// ExpiresOn returns the expiry time of the first certificate in the passed
+// PEM encoded certificate chain.
+func ExpiresOn(certificate string) (time.Time, error) {
+	b, _ := pem.Decode([]byte(certificate))
+	if b == nil {
+		return time.Time{}, errors.New(errInvalidCertificate)
+	}
+	c, err := x509.ParseCertificate(b.Bytes)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, errInvalidCertificate)
+	}
+	return c.NotAfter, nil
+}
+
+// ParseCertificate returns a Custom Certificate from a raw Custom
+// Certificate response.
+func ParseCertificate(raw json.RawMessage) (*Certificate, error) {
+	c := &Certificate{}
+	if err := json.Unmarshal(raw, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// CustomCertificate returns the Custom Certificate with the given ID.
+func CustomCertificate(ctx context.Context, client Client, zoneID, certificateID string) (*Certificate, error) {
+	raw, err := client.RawContext(ctx, http.MethodGet, endpoint(zoneID)+"/"+certificateID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCertificate(raw)
+}
+
+// GenerateObservation creates an observation of a Custom Certificate.
+func GenerateObservation(in Certificate) v1alpha1.CustomCertificateObservation {
+	o := v1alpha1.CustomCertificateObservation{
+		Hosts:     in.Hosts,
+		Issuer:    in.Issuer,
+		Signature: in.Signature,
+		Status:    in.Status,
+	}
+	if in.UploadedOn != nil {
+		t := metav1.NewTime(*in.UploadedOn)
+		o.UploadedOn = &t
+	}
+	if in.ExpiresOn != nil {
+		t := metav1.NewTime(*in.ExpiresOn)
+		o.ExpiresOn = &t
+	}
+	return o
+}
+
+// NewOptions returns the options used to upload the passed certificate
+// and private key with the requested resource parameters.
+func NewOptions(spec v1alpha1.CustomCertificateParameters, certificate, privateKey string) Options {
+	o := Options{
+		Certificate: certificate,
+		PrivateKey:  privateKey,
+	}
+	if spec.BundleMethod != nil {
+		o.BundleMethod = *spec.BundleMethod
+	}
+	if spec.GeoRestrictions != nil {
+		o.GeoRestrictions = &GeoRestrictions{Label: *spec.GeoRestrictions}
+	}
+	return o
+}
+
+// UpToDate checks if the remote Custom Certificate is up to date with the
+// requested resource parameters and the desired PEM encoded certificate.
+// The certificate itself cannot be read back from Cloudflare, so a
+// certificate with a different expiry is considered to be a renewal of
+// the uploaded certificate.
+func UpToDate(spec *v1alpha1.CustomCertificateParameters, certificate string, o Certificate) bool {
+	if spec == nil {
+		return true
+	}
+
+	if spec.BundleMethod != nil && *spec.BundleMethod != o.BundleMethod {
+		return false
+	}
+
+	if spec.GeoRestrictions != nil &&
+		(o.GeoRestrictions == nil || *spec.GeoRestrictions != o.GeoRestrictions.Label) {
+		return false
+	}
+
+	if o.ExpiresOn != nil {
+		e, err := ExpiresOn(certificate)
+		if err == nil && e.Unix() != o.ExpiresOn.Unix() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CreateCustomCertificate uploads a new Custom Certificate.
+func CreateCustomCertificate(ctx context.Context, client Client, zoneID string, o Options) (*Certificate, error) {
+	raw, err := client.RawContext(ctx, http.MethodPost, endpoint(zoneID), o)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCertificate(raw)
+}
+
+// UpdateCustomCertificate uploads a Custom Certificate again, replacing
+// its certificate, private key and settings.
+func UpdateCustomCertificate(ctx context.Context, client Client, zoneID, certificateID string, o Options) error {
+	_, err := client.RawContext(ctx, http.MethodPatch, endpoint(zoneID)+"/"+certificateID, o)
+	return err
+}
+
+// DeleteCustomCertificate deletes the Custom Certificate with the given
+// ID.
+func DeleteCustomCertificate(ctx context.Context, client Client, zoneID, certificateID string) error {
+	_, err := client.RawContext(ctx, http.MethodDelete, endpoint(zoneID)+"/"+certificateID, nil)
+	return err
+}
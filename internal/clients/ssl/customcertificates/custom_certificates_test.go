@@ -0,0 +1,339 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customcertificates
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	ptr "k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/ssl/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/ssl/customcertificates/fake"
+)
+
+// testCertificate is a self-signed certificate for example.com that
+// expires on 2036-10-13T07:34:05Z.
+const testCertificate = `-----BEGIN CERTIFICATE-----
+MIIBgDCCASegAwIBAgIUUvmmKu4QtoBZ79gg37bIVPgNAkAwCgYIKoZIzj0EAwIw
+FjEUMBIGA1UEAwwLZXhhbXBsZS5jb20wHhcNMjYxMDE2MDczNDA1WhcNMzYxMDEz
+MDczNDA1WjAWMRQwEgYDVQQDDAtleGFtcGxlLmNvbTBZMBMGByqGSM49AgEGCCqG
+SM49AwEHA0IABA1XJ0G5Bbb5XUV5l82PzeRROdCzNGegIbXmpVL/thKktyJ5uSYg
+sqDVn4TNg8R9D7ZrpfnUXRFXCXvSZSiHuPGjUzBRMB0GA1UdDgQWBBRnh9+8s802
+bUf4PaH0c1yV6FJk6DAfBgNVHSMEGDAWgBRnh9+8s802bUf4PaH0c1yV6FJk6DAP
+BgNVHRMBAf8EBTADAQH/MAoGCCqGSM49BAMCA0cAMEQCICePcdMus1H7P1qk07DF
+wnyIc30X5tvMU5hbAgRsjF4uAiAb6QtAiLXJWnDblpvkuwGjU7FMttDLTij2KrWC
+7yUDsQ==
+-----END CERTIFICATE-----
+`
+
+var testExpiresOn = time.Date(2036, time.October, 13, 7, 34, 5, 0, time.UTC)
+
+func TestResolveCertificate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	sel := &xpv1.SecretKeySelector{
+		SecretReference: xpv1.SecretReference{Name: "example-com-tls", Namespace: "default"},
+		Key:             "tls.crt",
+	}
+
+	type args struct {
+		kube client.Reader
+		spec *v1alpha1.CustomCertificateParameters
+	}
+
+	type want struct {
+		o   string
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Empty": {
+			reason: "ResolveCertificate should return an error when no certificate is set",
+			args: args{
+				spec: &v1alpha1.CustomCertificateParameters{},
+			},
+			want: want{
+				err: errors.New(errNoCertificate),
+			},
+		},
+		"Inline": {
+			reason: "ResolveCertificate should return the inline certificate",
+			args: args{
+				spec: &v1alpha1.CustomCertificateParameters{Certificate: ptr.StringPtr(testCertificate)},
+			},
+			want: want{
+				o: testCertificate,
+			},
+		},
+		"Secret": {
+			reason: "ResolveCertificate should prefer the certificate in the referenced Secret",
+			args: args{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						obj.(*corev1.Secret).Data = map[string][]byte{"tls.crt": []byte(testCertificate)}
+						return nil
+					}),
+				},
+				spec: &v1alpha1.CustomCertificateParameters{
+					Certificate:          ptr.StringPtr("inline"),
+					CertificateSecretRef: sel,
+				},
+			},
+			want: want{
+				o: testCertificate,
+			},
+		},
+		"SecretError": {
+			reason: "ResolveCertificate should return an error if the Secret cannot be read",
+			args: args{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+				spec: &v1alpha1.CustomCertificateParameters{CertificateSecretRef: sel},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, "cannot get Secret"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ResolveCertificate(context.Background(), tc.args.kube, tc.args.spec)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nResolveCertificate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nResolveCertificate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestExpiresOn(t *testing.T) {
+	type want struct {
+		o   time.Time
+		err error
+	}
+
+	cases := map[string]struct {
+		reason      string
+		certificate string
+		want        want
+	}{
+		"Valid": {
+			reason:      "ExpiresOn should return the expiry of the certificate",
+			certificate: testCertificate,
+			want: want{
+				o: testExpiresOn,
+			},
+		},
+		"NotPEM": {
+			reason:      "ExpiresOn should return an error if the certificate is not PEM encoded",
+			certificate: "not a certificate",
+			want: want{
+				err: errors.New(errInvalidCertificate),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ExpiresOn(tc.certificate)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nExpiresOn(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nExpiresOn(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpToDate(t *testing.T) {
+	expired := testExpiresOn.AddDate(-1, 0, 0)
+
+	cases := map[string]struct {
+		reason      string
+		spec        *v1alpha1.CustomCertificateParameters
+		certificate string
+		o           Certificate
+		want        bool
+	}{
+		"NilSpec": {
+			reason: "A nil spec should always be up to date",
+			o:      Certificate{BundleMethod: "ubiquitous"},
+			want:   true,
+		},
+		"UpToDate": {
+			reason: "A certificate matching the spec should be up to date",
+			spec: &v1alpha1.CustomCertificateParameters{
+				BundleMethod:    ptr.StringPtr("optimal"),
+				GeoRestrictions: ptr.StringPtr("eu"),
+			},
+			certificate: testCertificate,
+			o: Certificate{
+				BundleMethod:    "optimal",
+				GeoRestrictions: &GeoRestrictions{Label: "eu"},
+				ExpiresOn:       &testExpiresOn,
+			},
+			want: true,
+		},
+		"BundleMethodChanged": {
+			reason: "A certificate with a different bundle method should not be up to date",
+			spec:   &v1alpha1.CustomCertificateParameters{BundleMethod: ptr.StringPtr("force")},
+			o:      Certificate{BundleMethod: "ubiquitous"},
+			want:   false,
+		},
+		"GeoRestrictionsChanged": {
+			reason: "A certificate without the requested geo restrictions should not be up to date",
+			spec:   &v1alpha1.CustomCertificateParameters{GeoRestrictions: ptr.StringPtr("us")},
+			o:      Certificate{},
+			want:   false,
+		},
+		"CertificateRenewed": {
+			reason:      "A certificate that expires at a different time should not be up to date",
+			spec:        &v1alpha1.CustomCertificateParameters{},
+			certificate: testCertificate,
+			o:           Certificate{ExpiresOn: &expired},
+			want:        false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := UpToDate(tc.spec, tc.certificate, tc.o)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNewOptions(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		spec   v1alpha1.CustomCertificateParameters
+		want   Options
+	}{
+		"Minimal": {
+			reason: "Only the certificate and private key should be set when no settings are set",
+			spec:   v1alpha1.CustomCertificateParameters{},
+			want:   Options{Certificate: testCertificate, PrivateKey: "key"},
+		},
+		"Full": {
+			reason: "The bundle method and geo restrictions should be set when requested",
+			spec: v1alpha1.CustomCertificateParameters{
+				BundleMethod:    ptr.StringPtr("force"),
+				GeoRestrictions: ptr.StringPtr("highest_security"),
+			},
+			want: Options{
+				Certificate:     testCertificate,
+				PrivateKey:      "key",
+				BundleMethod:    "force",
+				GeoRestrictions: &GeoRestrictions{Label: "highest_security"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := NewOptions(tc.spec, testCertificate, "key")
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nNewOptions(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreateCustomCertificate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		c        *Certificate
+		err      error
+		method   string
+		endpoint string
+	}
+
+	cases := map[string]struct {
+		reason string
+		raw    json.RawMessage
+		err    error
+		want   want
+	}{
+		"CreateFailed": {
+			reason: "Errors uploading the certificate should be returned",
+			err:    errBoom,
+			want: want{
+				err:      errBoom,
+				method:   http.MethodPost,
+				endpoint: "/zones/023e105f4ecef8ad9ca31a8372d0c353/custom_certificates",
+			},
+		},
+		"Success": {
+			reason: "The uploaded certificate should be returned",
+			raw:    json.RawMessage(`{"id":"2458ce5a-0c35-4c7f-82c7-8e9487d3ff60","status":"pending"}`),
+			want: want{
+				c:        &Certificate{ID: "2458ce5a-0c35-4c7f-82c7-8e9487d3ff60", Status: "pending"},
+				method:   http.MethodPost,
+				endpoint: "/zones/023e105f4ecef8ad9ca31a8372d0c353/custom_certificates",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var method, endpoint string
+			client := fake.MockClient{
+				MockRawContext: func(_ context.Context, m, e string, data interface{}) (json.RawMessage, error) {
+					method, endpoint = m, e
+					return tc.raw, tc.err
+				},
+			}
+			got, err := CreateCustomCertificate(context.Background(), client, "023e105f4ecef8ad9ca31a8372d0c353", Options{})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreateCustomCertificate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.c, got); diff != "" {
+				t.Errorf("\n%s\nCreateCustomCertificate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.method, method); diff != "" {
+				t.Errorf("\n%s\nCreateCustomCertificate(...): -want method, +got method:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.endpoint, endpoint); diff != "" {
+				t.Errorf("\n%s\nCreateCustomCertificate(...): -want endpoint, +got endpoint:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package origincacertificates
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/benagricola/provider-cloudflare/apis/ssl/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+const (
+	// ConnectionKeyCertificate is the connection secret key holding the
+	// PEM encoded certificate. It matches the key used by Secrets of type
+	// kubernetes.io/tls.
+	ConnectionKeyCertificate = "tls.crt"
+
+	// ConnectionKeyPrivateKey is the connection secret key holding the
+	// PEM encoded private key of the certificate. It matches the key used
+	// by Secrets of type kubernetes.io/tls.
+	ConnectionKeyPrivateKey = "tls.key"
+
+	// RequestTypeRSA requests a certificate for an RSA private key.
+	RequestTypeRSA = "origin-rsa"
+
+	// RequestTypeECC requests a certificate for an ECDSA private key.
+	RequestTypeECC = "origin-ecc"
+
+	// The API returns expiry times in this format rather than RFC 3339.
+	expiresOnFormat = "2006-01-02 15:04:05 -0700 MST"
+
+	rsaKeySize = 2048
+
+	errNoHostnames       = "at least one hostname is required"
+	errGenerateKey       = "error generating private key"
+	errGenerateCSR       = "error generating certificate signing request"
+	errCreateCertificate = "error creating origin ca certificate"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with Origin CA Certificates. Origin CA Certificates are managed using raw
+// API requests, as the private key and signing request of a certificate
+// are generated by the provider.
+type Client interface {
+	RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error)
+}
+
+// NewClient returns a new Cloudflare API client for working with Origin CA
+// Certificates.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// Certificate is an Origin CA Certificate as represented by the Cloudflare
+// API.
+type Certificate struct {
+	ID                string   `json:"id,omitempty"`
+	Certificate       string   `json:"certificate,omitempty"`
+	Hostnames         []string `json:"hostnames,omitempty"`
+	ExpiresOn         string   `json:"expires_on,omitempty"`
+	RequestType       string   `json:"request_type,omitempty"`
+	RequestedValidity int64    `json:"requested_validity,omitempty"`
+	RevokedAt         string   `json:"revoked_at,omitempty"`
+	CSR               string   `json:"csr,omitempty"`
+}
+
+// IsOriginCACertificateNotFound returns true if the passed error indicates
+// an Origin CA Certificate was not found.
+func IsOriginCACertificateNotFound(err error) bool {
+	return clients.IsNotFound(err)
+}
+
+const endpoint = "/certificates"
+
+// ParseCertificate returns an Origin CA Certificate from a raw Origin CA
+// Certificate response.
+func ParseCertificate(raw json.RawMessage) (*Certificate, error) {
+	c := &Certificate{}
+	if err := json.Unmarshal(raw, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// OriginCACertificate returns the Origin CA Certificate with the given ID.
+func OriginCACertificate(ctx context.Context, client Client, certificateID string) (*Certificate, error) {
+	raw, err := client.RawContext(ctx, http.MethodGet, endpoint+"/"+certificateID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCertificate(raw)
+}
+
+// GenerateObservation creates an observation of an Origin CA Certificate.
+func GenerateObservation(in Certificate) v1alpha1.OriginCACertificateObservation {
+	o := v1alpha1.OriginCACertificateObservation{}
+	if t, err := time.Parse(expiresOnFormat, in.ExpiresOn); err == nil {
+		mt := metav1.NewTime(t.UTC())
+		o.ExpiresOn = &mt
+	}
+	return o
+}
+
+// ConnectionDetails returns the connection details of an issued Origin CA
+// Certificate. The private key is only known when the certificate is
+// created, so it is only included when passed.
+func ConnectionDetails(c Certificate, privateKey []byte) managed.ConnectionDetails {
+	cd := managed.ConnectionDetails{
+		ConnectionKeyCertificate: []byte(c.Certificate),
+	}
+	if privateKey != nil {
+		cd[ConnectionKeyPrivateKey] = privateKey
+	}
+	return cd
+}
+
+// generateKey returns a new private key of the passed request type, along
+// with its PEM encoding.
+func generateKey(requestType string) (crypto.Signer, []byte, error) {
+	var key crypto.Signer
+	var err error
+	switch requestType {
+	case RequestTypeECC:
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		key, err = rsa.GenerateKey(rand.Reader, rsaKeySize)
+	}
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errGenerateKey)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errGenerateKey)
+	}
+
+	return key, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// generateCSR returns a PEM encoded certificate signing request for the
+// passed hostnames, signed by the passed private key.
+func generateCSR(key crypto.Signer, hostnames []string) (string, error) {
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: hostnames[0]},
+		DNSNames: hostnames,
+	}, key)
+	if err != nil {
+		return "", errors.Wrap(err, errGenerateCSR)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})), nil
+}
+
+// CreateOriginCACertificate generates a new private key and requests an
+// Origin CA Certificate for it, returning the issued certificate along
+// with its connection details.
+func CreateOriginCACertificate(ctx context.Context, client Client, spec v1alpha1.OriginCACertificateParameters) (*Certificate, managed.ConnectionDetails, error) {
+	if len(spec.Hostnames) == 0 {
+		return nil, nil, errors.New(errNoHostnames)
+	}
+
+	req := Certificate{
+		Hostnames:   spec.Hostnames,
+		RequestType: RequestTypeRSA,
+	}
+	if spec.RequestType != nil {
+		req.RequestType = *spec.RequestType
+	}
+	if spec.RequestedValidity != nil {
+		req.RequestedValidity = *spec.RequestedValidity
+	}
+
+	key, pk, err := generateKey(req.RequestType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if req.CSR, err = generateCSR(key, spec.Hostnames); err != nil {
+		return nil, nil, err
+	}
+
+	raw, err := client.RawContext(ctx, http.MethodPost, endpoint, req)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errCreateCertificate)
+	}
+
+	c, err := ParseCertificate(raw)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errCreateCertificate)
+	}
+
+	return c, ConnectionDetails(*c, pk), nil
+}
+
+// RevokeOriginCACertificate revokes the Origin CA Certificate with the
+// given ID.
+func RevokeOriginCACertificate(ctx context.Context, client Client, certificateID string) error {
+	_, err := client.RawContext(ctx, http.MethodDelete, endpoint+"/"+certificateID, nil)
+	return err
+}
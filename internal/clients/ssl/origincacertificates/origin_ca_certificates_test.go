@@ -0,0 +1,231 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package origincacertificates
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ptr "k8s.io/utils/pointer"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/ssl/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/ssl/origincacertificates/fake"
+)
+
+func TestGenerateObservation(t *testing.T) {
+	expiresOn := metav1.NewTime(time.Date(2036, time.October, 13, 7, 34, 5, 0, time.UTC))
+
+	cases := map[string]struct {
+		reason string
+		in     Certificate
+		want   v1alpha1.OriginCACertificateObservation
+	}{
+		"ExpiresOn": {
+			reason: "The expiry time returned by the API should be parsed",
+			in:     Certificate{ExpiresOn: "2036-10-13 07:34:05 +0000 UTC"},
+			want:   v1alpha1.OriginCACertificateObservation{ExpiresOn: &expiresOn},
+		},
+		"InvalidExpiresOn": {
+			reason: "An expiry time that cannot be parsed should not be observed",
+			in:     Certificate{ExpiresOn: "soon"},
+			want:   v1alpha1.OriginCACertificateObservation{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateObservation(tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateObservation(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestConnectionDetails(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		c          Certificate
+		privateKey []byte
+		want       managed.ConnectionDetails
+	}{
+		"CertificateOnly": {
+			reason: "Only the certificate should be returned when no private key is passed",
+			c:      Certificate{Certificate: "certificate"},
+			want:   managed.ConnectionDetails{ConnectionKeyCertificate: []byte("certificate")},
+		},
+		"WithPrivateKey": {
+			reason:     "The private key should be returned when passed",
+			c:          Certificate{Certificate: "certificate"},
+			privateKey: []byte("key"),
+			want: managed.ConnectionDetails{
+				ConnectionKeyCertificate: []byte("certificate"),
+				ConnectionKeyPrivateKey:  []byte("key"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ConnectionDetails(tc.c, tc.privateKey)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nConnectionDetails(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreateOriginCACertificate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		c           *Certificate
+		err         error
+		requestType string
+		keyType     string
+	}
+
+	cases := map[string]struct {
+		reason string
+		spec   v1alpha1.OriginCACertificateParameters
+		err    error
+		want   want
+	}{
+		"NoHostnames": {
+			reason: "An error should be returned if no hostnames are set",
+			spec:   v1alpha1.OriginCACertificateParameters{},
+			want:   want{err: errors.New(errNoHostnames)},
+		},
+		"CreateFailed": {
+			reason: "Errors requesting the certificate should be returned",
+			spec:   v1alpha1.OriginCACertificateParameters{Hostnames: []string{"example.com"}},
+			err:    errBoom,
+			want: want{
+				err:         errors.Wrap(errBoom, errCreateCertificate),
+				requestType: RequestTypeRSA,
+			},
+		},
+		"SuccessRSA": {
+			reason: "An RSA private key should be generated by default",
+			spec:   v1alpha1.OriginCACertificateParameters{Hostnames: []string{"example.com", "*.example.com"}},
+			want: want{
+				c:           &Certificate{ID: "328578533902268680212849205732770752308931942346", Certificate: "certificate"},
+				requestType: RequestTypeRSA,
+				keyType:     "*rsa.PrivateKey",
+			},
+		},
+		"SuccessECC": {
+			reason: "An ECDSA private key should be generated for origin-ecc certificates",
+			spec: v1alpha1.OriginCACertificateParameters{
+				Hostnames:         []string{"example.com"},
+				RequestType:       ptr.StringPtr(RequestTypeECC),
+				RequestedValidity: ptr.Int64Ptr(90),
+			},
+			want: want{
+				c:           &Certificate{ID: "328578533902268680212849205732770752308931942346", Certificate: "certificate"},
+				requestType: RequestTypeECC,
+				keyType:     "*ecdsa.PrivateKey",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var req Certificate
+			client := fake.MockClient{
+				MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+					req = data.(Certificate)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return json.RawMessage(`{"id":"328578533902268680212849205732770752308931942346","certificate":"certificate"}`), nil
+				},
+			}
+
+			got, cd, err := CreateOriginCACertificate(context.Background(), client, tc.spec)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreateOriginCACertificate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.c, got); diff != "" {
+				t.Errorf("\n%s\nCreateOriginCACertificate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.requestType, req.RequestType); diff != "" {
+				t.Errorf("\n%s\nCreateOriginCACertificate(...): -want request type, +got request type:\n%s\n", tc.reason, diff)
+			}
+
+			if tc.want.c == nil {
+				return
+			}
+
+			b, _ := pem.Decode([]byte(req.CSR))
+			if b == nil {
+				t.Fatalf("\n%s\nCreateOriginCACertificate(...): CSR is not PEM encoded", tc.reason)
+			}
+			csr, err := x509.ParseCertificateRequest(b.Bytes)
+			if err != nil {
+				t.Fatalf("\n%s\nCreateOriginCACertificate(...): cannot parse CSR: %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.spec.Hostnames, csr.DNSNames); diff != "" {
+				t.Errorf("\n%s\nCreateOriginCACertificate(...): -want CSR hostnames, +got CSR hostnames:\n%s\n", tc.reason, diff)
+			}
+
+			b, _ = pem.Decode(cd[ConnectionKeyPrivateKey])
+			if b == nil {
+				t.Fatalf("\n%s\nCreateOriginCACertificate(...): private key is not PEM encoded", tc.reason)
+			}
+			key, err := x509.ParsePKCS8PrivateKey(b.Bytes)
+			if err != nil {
+				t.Fatalf("\n%s\nCreateOriginCACertificate(...): cannot parse private key: %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.keyType, fmt.Sprintf("%T", key)); diff != "" {
+				t.Errorf("\n%s\nCreateOriginCACertificate(...): -want key type, +got key type:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRevokeOriginCACertificate(t *testing.T) {
+	var method, endpoint string
+	client := fake.MockClient{
+		MockRawContext: func(_ context.Context, m, e string, data interface{}) (json.RawMessage, error) {
+			method, endpoint = m, e
+			return json.RawMessage(`{"id":"328578533902268680212849205732770752308931942346"}`), nil
+		},
+	}
+
+	if err := RevokeOriginCACertificate(context.Background(), client, "328578533902268680212849205732770752308931942346"); err != nil {
+		t.Errorf("RevokeOriginCACertificate(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(http.MethodDelete, method); diff != "" {
+		t.Errorf("RevokeOriginCACertificate(...): -want method, +got method:\n%s\n", diff)
+	}
+	if diff := cmp.Diff("/certificates/328578533902268680212849205732770752308931942346", endpoint); diff != "" {
+		t.Errorf("RevokeOriginCACertificate(...): -want endpoint, +got endpoint:\n%s\n", diff)
+	}
+}
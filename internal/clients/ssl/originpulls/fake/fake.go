@@ -0,0 +1,94 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// A MockClient acts as a testable representation of the Cloudflare API.
+type MockClient struct {
+	MockGetPerZoneAuthenticatedOriginPullsStatus             func(ctx context.Context, zoneID string) (cloudflare.PerZoneAuthenticatedOriginPullsSettings, error)
+	MockSetPerZoneAuthenticatedOriginPullsStatus             func(ctx context.Context, zoneID string, enable bool) (cloudflare.PerZoneAuthenticatedOriginPullsSettings, error)
+	MockUploadPerZoneAuthenticatedOriginPullsCertificate     func(ctx context.Context, zoneID string, params cloudflare.PerZoneAuthenticatedOriginPullsCertificateParams) (cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails, error)
+	MockGetPerZoneAuthenticatedOriginPullsCertificateDetails func(ctx context.Context, zoneID, certificateID string) (cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails, error)
+	MockDeletePerZoneAuthenticatedOriginPullsCertificate     func(ctx context.Context, zoneID, certificateID string) (cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails, error)
+	MockUploadPerHostnameAuthenticatedOriginPullsCertificate func(ctx context.Context, zoneID string, params cloudflare.PerHostnameAuthenticatedOriginPullsCertificateParams) (cloudflare.PerHostnameAuthenticatedOriginPullsCertificateDetails, error)
+	MockGetPerHostnameAuthenticatedOriginPullsCertificate    func(ctx context.Context, zoneID, certificateID string) (cloudflare.PerHostnameAuthenticatedOriginPullsCertificateDetails, error)
+	MockDeletePerHostnameAuthenticatedOriginPullsCertificate func(ctx context.Context, zoneID, certificateID string) (cloudflare.PerHostnameAuthenticatedOriginPullsCertificateDetails, error)
+	MockEditPerHostnameAuthenticatedOriginPullsConfig        func(ctx context.Context, zoneID string, config []cloudflare.PerHostnameAuthenticatedOriginPullsConfig) ([]cloudflare.PerHostnameAuthenticatedOriginPullsDetails, error)
+	MockGetPerHostnameAuthenticatedOriginPullsConfig         func(ctx context.Context, zoneID, hostname string) (cloudflare.PerHostnameAuthenticatedOriginPullsDetails, error)
+	MockRawContext                                           func(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error)
+}
+
+// GetPerZoneAuthenticatedOriginPullsStatus mocks the GetPerZoneAuthenticatedOriginPullsStatus method of the Cloudflare API.
+func (m MockClient) GetPerZoneAuthenticatedOriginPullsStatus(ctx context.Context, zoneID string) (cloudflare.PerZoneAuthenticatedOriginPullsSettings, error) {
+	return m.MockGetPerZoneAuthenticatedOriginPullsStatus(ctx, zoneID)
+}
+
+// SetPerZoneAuthenticatedOriginPullsStatus mocks the SetPerZoneAuthenticatedOriginPullsStatus method of the Cloudflare API.
+func (m MockClient) SetPerZoneAuthenticatedOriginPullsStatus(ctx context.Context, zoneID string, enable bool) (cloudflare.PerZoneAuthenticatedOriginPullsSettings, error) {
+	return m.MockSetPerZoneAuthenticatedOriginPullsStatus(ctx, zoneID, enable)
+}
+
+// UploadPerZoneAuthenticatedOriginPullsCertificate mocks the UploadPerZoneAuthenticatedOriginPullsCertificate method of the Cloudflare API.
+func (m MockClient) UploadPerZoneAuthenticatedOriginPullsCertificate(ctx context.Context, zoneID string, params cloudflare.PerZoneAuthenticatedOriginPullsCertificateParams) (cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails, error) {
+	return m.MockUploadPerZoneAuthenticatedOriginPullsCertificate(ctx, zoneID, params)
+}
+
+// GetPerZoneAuthenticatedOriginPullsCertificateDetails mocks the GetPerZoneAuthenticatedOriginPullsCertificateDetails method of the Cloudflare API.
+func (m MockClient) GetPerZoneAuthenticatedOriginPullsCertificateDetails(ctx context.Context, zoneID, certificateID string) (cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails, error) {
+	return m.MockGetPerZoneAuthenticatedOriginPullsCertificateDetails(ctx, zoneID, certificateID)
+}
+
+// DeletePerZoneAuthenticatedOriginPullsCertificate mocks the DeletePerZoneAuthenticatedOriginPullsCertificate method of the Cloudflare API.
+func (m MockClient) DeletePerZoneAuthenticatedOriginPullsCertificate(ctx context.Context, zoneID, certificateID string) (cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails, error) {
+	return m.MockDeletePerZoneAuthenticatedOriginPullsCertificate(ctx, zoneID, certificateID)
+}
+
+// UploadPerHostnameAuthenticatedOriginPullsCertificate mocks the UploadPerHostnameAuthenticatedOriginPullsCertificate method of the Cloudflare API.
+func (m MockClient) UploadPerHostnameAuthenticatedOriginPullsCertificate(ctx context.Context, zoneID string, params cloudflare.PerHostnameAuthenticatedOriginPullsCertificateParams) (cloudflare.PerHostnameAuthenticatedOriginPullsCertificateDetails, error) {
+	return m.MockUploadPerHostnameAuthenticatedOriginPullsCertificate(ctx, zoneID, params)
+}
+
+// GetPerHostnameAuthenticatedOriginPullsCertificate mocks the GetPerHostnameAuthenticatedOriginPullsCertificate method of the Cloudflare API.
+func (m MockClient) GetPerHostnameAuthenticatedOriginPullsCertificate(ctx context.Context, zoneID, certificateID string) (cloudflare.PerHostnameAuthenticatedOriginPullsCertificateDetails, error) {
+	return m.MockGetPerHostnameAuthenticatedOriginPullsCertificate(ctx, zoneID, certificateID)
+}
+
+// DeletePerHostnameAuthenticatedOriginPullsCertificate mocks the DeletePerHostnameAuthenticatedOriginPullsCertificate method of the Cloudflare API.
+func (m MockClient) DeletePerHostnameAuthenticatedOriginPullsCertificate(ctx context.Context, zoneID, certificateID string) (cloudflare.PerHostnameAuthenticatedOriginPullsCertificateDetails, error) {
+	return m.MockDeletePerHostnameAuthenticatedOriginPullsCertificate(ctx, zoneID, certificateID)
+}
+
+// EditPerHostnameAuthenticatedOriginPullsConfig mocks the EditPerHostnameAuthenticatedOriginPullsConfig method of the Cloudflare API.
+func (m MockClient) EditPerHostnameAuthenticatedOriginPullsConfig(ctx context.Context, zoneID string, config []cloudflare.PerHostnameAuthenticatedOriginPullsConfig) ([]cloudflare.PerHostnameAuthenticatedOriginPullsDetails, error) {
+	return m.MockEditPerHostnameAuthenticatedOriginPullsConfig(ctx, zoneID, config)
+}
+
+// GetPerHostnameAuthenticatedOriginPullsConfig mocks the GetPerHostnameAuthenticatedOriginPullsConfig method of the Cloudflare API.
+func (m MockClient) GetPerHostnameAuthenticatedOriginPullsConfig(ctx context.Context, zoneID, hostname string) (cloudflare.PerHostnameAuthenticatedOriginPullsDetails, error) {
+	return m.MockGetPerHostnameAuthenticatedOriginPullsConfig(ctx, zoneID, hostname)
+}
+
+// RawContext mocks the RawContext method of the Cloudflare API.
+func (m MockClient) RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+	return m.MockRawContext(ctx, method, endpoint, data)
+}
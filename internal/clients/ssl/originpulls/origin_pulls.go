@@ -0,0 +1,294 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package originpulls
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/benagricola/provider-cloudflare/apis/ssl/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/ssl/customcertificates"
+)
+
+const (
+	// StatusActive is the status of a certificate or hostname once it has
+	// been deployed to the Cloudflare edge.
+	StatusActive = "active"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with Authenticated Origin Pulls. The cloudflare-go library cannot remove
+// the configuration of a hostname, as that needs enabled to be sent as
+// null, so only that is done using a raw API request.
+type Client interface {
+	GetPerZoneAuthenticatedOriginPullsStatus(ctx context.Context, zoneID string) (cloudflare.PerZoneAuthenticatedOriginPullsSettings, error)
+	SetPerZoneAuthenticatedOriginPullsStatus(ctx context.Context, zoneID string, enable bool) (cloudflare.PerZoneAuthenticatedOriginPullsSettings, error)
+	UploadPerZoneAuthenticatedOriginPullsCertificate(ctx context.Context, zoneID string, params cloudflare.PerZoneAuthenticatedOriginPullsCertificateParams) (cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails, error)
+	GetPerZoneAuthenticatedOriginPullsCertificateDetails(ctx context.Context, zoneID, certificateID string) (cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails, error)
+	DeletePerZoneAuthenticatedOriginPullsCertificate(ctx context.Context, zoneID, certificateID string) (cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails, error)
+	UploadPerHostnameAuthenticatedOriginPullsCertificate(ctx context.Context, zoneID string, params cloudflare.PerHostnameAuthenticatedOriginPullsCertificateParams) (cloudflare.PerHostnameAuthenticatedOriginPullsCertificateDetails, error)
+	GetPerHostnameAuthenticatedOriginPullsCertificate(ctx context.Context, zoneID, certificateID string) (cloudflare.PerHostnameAuthenticatedOriginPullsCertificateDetails, error)
+	DeletePerHostnameAuthenticatedOriginPullsCertificate(ctx context.Context, zoneID, certificateID string) (cloudflare.PerHostnameAuthenticatedOriginPullsCertificateDetails, error)
+	EditPerHostnameAuthenticatedOriginPullsConfig(ctx context.Context, zoneID string, config []cloudflare.PerHostnameAuthenticatedOriginPullsConfig) ([]cloudflare.PerHostnameAuthenticatedOriginPullsDetails, error)
+	GetPerHostnameAuthenticatedOriginPullsConfig(ctx context.Context, zoneID, hostname string) (cloudflare.PerHostnameAuthenticatedOriginPullsDetails, error)
+	RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error)
+}
+
+// NewClient returns a new Cloudflare API client for working with
+// Authenticated Origin Pulls.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// Certificate is an Origin Pull Certificate, which may be used for either
+// zone-level or per-hostname Authenticated Origin Pulls.
+type Certificate struct {
+	ID           string
+	Issuer       string
+	Signature    string
+	SerialNumber string
+	Status       string
+	UploadedOn   *time.Time
+	ExpiresOn    *time.Time
+}
+
+// Options are the options used to upload an Origin Pull Certificate.
+type Options struct {
+	Certificate string
+	PrivateKey  string
+}
+
+// hostnameRemoval is the configuration of a hostname that removes it, so
+// that the zone-level configuration applies to it again.
+type hostnameRemoval struct {
+	Hostname string `json:"hostname"`
+	CertID   string `json:"cert_id,omitempty"`
+	Enabled  *bool  `json:"enabled"`
+}
+
+// hostnameRemovals is the body of a request to remove the Authenticated
+// Origin Pulls configuration of hostnames.
+type hostnameRemovals struct {
+	Config []hostnameRemoval `json:"config"`
+}
+
+// IsNotFound returns true if the passed error indicates an Origin Pull
+// Certificate or hostname configuration was not found.
+func IsNotFound(err error) bool {
+	return clients.IsNotFound(err)
+}
+
+// SetZoneEnabled enables or disables zone-level Authenticated Origin
+// Pulls on the Zone with the passed ID.
+func SetZoneEnabled(ctx context.Context, client Client, zoneID string, enabled bool) error {
+	_, err := client.SetPerZoneAuthenticatedOriginPullsStatus(ctx, zoneID, enabled)
+	return err
+}
+
+// ResolveOptions returns the options used to upload the certificate and
+// private key read from the Secrets referenced by the passed parameters.
+func ResolveOptions(ctx context.Context, kube client.Reader, spec *v1alpha1.OriginPullCertificateParameters) (Options, error) {
+	c, err := clients.GetSecretValue(ctx, kube, spec.CertificateSecretRef)
+	if err != nil {
+		return Options{}, err
+	}
+	k, err := clients.GetSecretValue(ctx, kube, spec.PrivateKeySecretRef)
+	if err != nil {
+		return Options{}, err
+	}
+	return Options{Certificate: string(c), PrivateKey: string(k)}, nil
+}
+
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func zoneCertificate(in cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails) *Certificate {
+	return &Certificate{
+		ID:         in.ID,
+		Issuer:     in.Issuer,
+		Signature:  in.Signature,
+		Status:     in.Status,
+		UploadedOn: timePtr(in.UploadedOn),
+		ExpiresOn:  timePtr(in.ExpiresOn),
+	}
+}
+
+func hostnameCertificate(in cloudflare.PerHostnameAuthenticatedOriginPullsCertificateDetails) *Certificate {
+	return &Certificate{
+		ID:           in.ID,
+		Issuer:       in.Issuer,
+		Signature:    in.Signature,
+		SerialNumber: in.SerialNumber,
+		Status:       in.Status,
+		UploadedOn:   timePtr(in.UploadedOn),
+		ExpiresOn:    timePtr(in.ExpiresOn),
+	}
+}
+
+// OriginPullCertificate returns the Origin Pull Certificate with the
+// given ID.
+func OriginPullCertificate(ctx context.Context, client Client, zoneID string, perHostname bool, certificateID string) (*Certificate, error) {
+	if perHostname {
+		c, err := client.GetPerHostnameAuthenticatedOriginPullsCertificate(ctx, zoneID, certificateID)
+		if err != nil {
+			return nil, err
+		}
+		return hostnameCertificate(c), nil
+	}
+
+	c, err := client.GetPerZoneAuthenticatedOriginPullsCertificateDetails(ctx, zoneID, certificateID)
+	if err != nil {
+		return nil, err
+	}
+	return zoneCertificate(c), nil
+}
+
+// GenerateCertificateObservation creates an observation of an Origin Pull
+// Certificate.
+func GenerateCertificateObservation(in Certificate) v1alpha1.OriginPullCertificateObservation {
+	o := v1alpha1.OriginPullCertificateObservation{
+		Issuer:       in.Issuer,
+		Signature:    in.Signature,
+		SerialNumber: in.SerialNumber,
+		Status:       in.Status,
+	}
+	if in.UploadedOn != nil {
+		t := metav1.NewTime(*in.UploadedOn)
+		o.UploadedOn = &t
+	}
+	if in.ExpiresOn != nil {
+		t := metav1.NewTime(*in.ExpiresOn)
+		o.ExpiresOn = &t
+	}
+	return o
+}
+
+// CertificateUpToDate checks if the remote Origin Pull Certificate is up
+// to date with the desired PEM encoded certificate. The certificate itself
+// cannot be read back from Cloudflare, so a certificate with a different
+// expiry is considered to be a renewal of the uploaded certificate.
+func CertificateUpToDate(certificate string, c Certificate) bool {
+	if c.ExpiresOn == nil {
+		return true
+	}
+	e, err := customcertificates.ExpiresOn(certificate)
+	if err != nil {
+		return true
+	}
+	return e.Unix() == c.ExpiresOn.Unix()
+}
+
+// CreateOriginPullCertificate uploads a new Origin Pull Certificate.
+func CreateOriginPullCertificate(ctx context.Context, client Client, zoneID string, perHostname bool, o Options) (*Certificate, error) {
+	if perHostname {
+		c, err := client.UploadPerHostnameAuthenticatedOriginPullsCertificate(ctx, zoneID, cloudflare.PerHostnameAuthenticatedOriginPullsCertificateParams{
+			Certificate: o.Certificate,
+			PrivateKey:  o.PrivateKey,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return hostnameCertificate(c), nil
+	}
+
+	c, err := client.UploadPerZoneAuthenticatedOriginPullsCertificate(ctx, zoneID, cloudflare.PerZoneAuthenticatedOriginPullsCertificateParams{
+		Certificate: o.Certificate,
+		PrivateKey:  o.PrivateKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return zoneCertificate(c), nil
+}
+
+// DeleteOriginPullCertificate deletes the Origin Pull Certificate with
+// the given ID.
+func DeleteOriginPullCertificate(ctx context.Context, client Client, zoneID string, perHostname bool, certificateID string) error {
+	if perHostname {
+		_, err := client.DeletePerHostnameAuthenticatedOriginPullsCertificate(ctx, zoneID, certificateID)
+		return err
+	}
+	_, err := client.DeletePerZoneAuthenticatedOriginPullsCertificate(ctx, zoneID, certificateID)
+	return err
+}
+
+// GenerateHostnameObservation creates an observation of the Authenticated
+// Origin Pulls configuration of a hostname.
+func GenerateHostnameObservation(in cloudflare.PerHostnameAuthenticatedOriginPullsDetails) v1alpha1.HostnameOriginPullObservation {
+	o := v1alpha1.HostnameOriginPullObservation{
+		Status:            in.Status,
+		CertificateStatus: in.CertStatus,
+	}
+	if !in.ExpiresOn.IsZero() {
+		t := metav1.NewTime(in.ExpiresOn)
+		o.ExpiresOn = &t
+	}
+	return o
+}
+
+// HostnameUpToDate checks if the remote hostname configuration is up to
+// date with the requested resource parameters.
+func HostnameUpToDate(spec *v1alpha1.HostnameOriginPullParameters, h cloudflare.PerHostnameAuthenticatedOriginPullsDetails) bool {
+	if spec == nil {
+		return true
+	}
+
+	if spec.Certificate != nil && *spec.Certificate != h.CertID {
+		return false
+	}
+
+	enabled := spec.Enabled == nil || *spec.Enabled
+	return enabled == h.Enabled
+}
+
+// UpdateHostnameConfig configures Authenticated Origin Pulls for a
+// hostname with the requested resource parameters.
+func UpdateHostnameConfig(ctx context.Context, client Client, zoneID string, spec v1alpha1.HostnameOriginPullParameters) error {
+	h := cloudflare.PerHostnameAuthenticatedOriginPullsConfig{
+		Hostname: spec.Hostname,
+		Enabled:  spec.Enabled == nil || *spec.Enabled,
+	}
+	if spec.Certificate != nil {
+		h.CertID = *spec.Certificate
+	}
+
+	_, err := client.EditPerHostnameAuthenticatedOriginPullsConfig(ctx, zoneID, []cloudflare.PerHostnameAuthenticatedOriginPullsConfig{h})
+	return err
+}
+
+// DeleteHostnameConfig removes the Authenticated Origin Pulls
+// configuration of a hostname, so that the zone-level configuration
+// applies to it again.
+func DeleteHostnameConfig(ctx context.Context, client Client, zoneID, hostname, certificateID string) error {
+	h := hostnameRemoval{
+		Hostname: hostname,
+		CertID:   certificateID,
+	}
+	_, err := client.RawContext(ctx, http.MethodPut, "/zones/"+zoneID+"/origin_tls_client_auth/hostnames", hostnameRemovals{Config: []hostnameRemoval{h}})
+	return err
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package originpulls
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	ptr "k8s.io/utils/pointer"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/ssl/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/ssl/originpulls/fake"
+)
+
+const zoneID = "023e105f4ecef8ad9ca31a8372d0c353"
+
+func TestOriginPullCertificate(t *testing.T) {
+	errBoom := errors.New("boom")
+	expires := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	client := fake.MockClient{
+		MockGetPerZoneAuthenticatedOriginPullsCertificateDetails: func(ctx context.Context, zoneID, certificateID string) (cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails, error) {
+			if certificateID != "zone-cert" {
+				return cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails{}, errBoom
+			}
+			return cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails{ID: certificateID, Status: StatusActive, ExpiresOn: expires}, nil
+		},
+		MockGetPerHostnameAuthenticatedOriginPullsCertificate: func(ctx context.Context, zoneID, certificateID string) (cloudflare.PerHostnameAuthenticatedOriginPullsCertificateDetails, error) {
+			if certificateID != "hostname-cert" {
+				return cloudflare.PerHostnameAuthenticatedOriginPullsCertificateDetails{}, errBoom
+			}
+			return cloudflare.PerHostnameAuthenticatedOriginPullsCertificateDetails{ID: certificateID, Status: StatusActive, SerialNumber: "1234"}, nil
+		},
+	}
+
+	type want struct {
+		c   *Certificate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason        string
+		perHostname   bool
+		certificateID string
+		want          want
+	}{
+		"Zone": {
+			reason:        "Zone-level certificates should be read from the zone-level API",
+			certificateID: "zone-cert",
+			want:          want{c: &Certificate{ID: "zone-cert", Status: StatusActive, ExpiresOn: &expires}},
+		},
+		"PerHostname": {
+			reason:        "Per-hostname certificates should be read from the per-hostname API",
+			perHostname:   true,
+			certificateID: "hostname-cert",
+			want:          want{c: &Certificate{ID: "hostname-cert", Status: StatusActive, SerialNumber: "1234"}},
+		},
+		"Error": {
+			reason:        "Errors reading the certificate should be returned",
+			perHostname:   true,
+			certificateID: "zone-cert",
+			want:          want{err: errBoom},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := OriginPullCertificate(context.Background(), client, zoneID, tc.perHostname, tc.certificateID)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nOriginPullCertificate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.c, got); diff != "" {
+				t.Errorf("\n%s\nOriginPullCertificate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestHostnameUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		spec   *v1alpha1.HostnameOriginPullParameters
+		h      cloudflare.PerHostnameAuthenticatedOriginPullsDetails
+		want   bool
+	}{
+		"NilSpec": {
+			reason: "A nil spec should always be up to date",
+			want:   true,
+		},
+		"UpToDate": {
+			reason: "A hostname presenting the requested certificate should be up to date",
+			spec:   &v1alpha1.HostnameOriginPullParameters{Hostname: "app.example.com", Certificate: ptr.StringPtr("cert-a")},
+			h:      cloudflare.PerHostnameAuthenticatedOriginPullsDetails{Hostname: "app.example.com", CertID: "cert-a", Enabled: true},
+			want:   true,
+		},
+		"CertificateDiffers": {
+			reason: "A hostname presenting a different certificate should not be up to date",
+			spec:   &v1alpha1.HostnameOriginPullParameters{Hostname: "app.example.com", Certificate: ptr.StringPtr("cert-b")},
+			h:      cloudflare.PerHostnameAuthenticatedOriginPullsDetails{Hostname: "app.example.com", CertID: "cert-a", Enabled: true},
+			want:   false,
+		},
+		"Disabled": {
+			reason: "A disabled hostname should not be up to date when enabled is not set",
+			spec:   &v1alpha1.HostnameOriginPullParameters{Hostname: "app.example.com", Certificate: ptr.StringPtr("cert-a")},
+			h:      cloudflare.PerHostnameAuthenticatedOriginPullsDetails{Hostname: "app.example.com", CertID: "cert-a", Enabled: false},
+			want:   false,
+		},
+		"DisabledRequested": {
+			reason: "A disabled hostname should be up to date when it is requested to be disabled",
+			spec:   &v1alpha1.HostnameOriginPullParameters{Hostname: "app.example.com", Certificate: ptr.StringPtr("cert-a"), Enabled: ptr.BoolPtr(false)},
+			h:      cloudflare.PerHostnameAuthenticatedOriginPullsDetails{Hostname: "app.example.com", CertID: "cert-a", Enabled: false},
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := HostnameUpToDate(tc.spec, tc.h)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nHostnameUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdateHostnameConfig(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		spec v1alpha1.HostnameOriginPullParameters
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   []cloudflare.PerHostnameAuthenticatedOriginPullsConfig
+		err    error
+	}{
+		"DefaultEnabled": {
+			reason: "The hostname should be enabled when enabled is not set",
+			args: args{
+				spec: v1alpha1.HostnameOriginPullParameters{Hostname: "app.example.com", Certificate: ptr.StringPtr("cert-a")},
+			},
+			want: []cloudflare.PerHostnameAuthenticatedOriginPullsConfig{{Hostname: "app.example.com", CertID: "cert-a", Enabled: true}},
+		},
+		"Disabled": {
+			reason: "The hostname should be disabled when requested",
+			args: args{
+				spec: v1alpha1.HostnameOriginPullParameters{Hostname: "app.example.com", Certificate: ptr.StringPtr("cert-a"), Enabled: ptr.BoolPtr(false)},
+			},
+			want: []cloudflare.PerHostnameAuthenticatedOriginPullsConfig{{Hostname: "app.example.com", CertID: "cert-a", Enabled: false}},
+		},
+		"Error": {
+			reason: "Errors configuring the hostname should be returned",
+			args: args{
+				spec: v1alpha1.HostnameOriginPullParameters{Hostname: "error.example.com"},
+			},
+			want: []cloudflare.PerHostnameAuthenticatedOriginPullsConfig{{Hostname: "error.example.com", Enabled: true}},
+			err:  errBoom,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []cloudflare.PerHostnameAuthenticatedOriginPullsConfig
+			client := fake.MockClient{
+				MockEditPerHostnameAuthenticatedOriginPullsConfig: func(ctx context.Context, zoneID string, config []cloudflare.PerHostnameAuthenticatedOriginPullsConfig) ([]cloudflare.PerHostnameAuthenticatedOriginPullsDetails, error) {
+					got = config
+					if config[0].Hostname == "error.example.com" {
+						return nil, errBoom
+					}
+					return nil, nil
+				},
+			}
+			err := UpdateHostnameConfig(context.Background(), client, zoneID, tc.args.spec)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdateHostnameConfig(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nUpdateHostnameConfig(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDeleteHostnameConfig(t *testing.T) {
+	var body []byte
+	client := fake.MockClient{
+		MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+			if method != http.MethodPut || endpoint != "/zones/"+zoneID+"/origin_tls_client_auth/hostnames" {
+				t.Errorf("unexpected request %s %s", method, endpoint)
+			}
+			b, err := json.Marshal(data)
+			body = b
+			return json.RawMessage(`[]`), err
+		},
+	}
+
+	if err := DeleteHostnameConfig(context.Background(), client, zoneID, "app.example.com", "cert-a"); err != nil {
+		t.Fatalf("DeleteHostnameConfig(...): unexpected error: %v", err)
+	}
+
+	// Enabled must be sent as null to remove the configuration of the
+	// hostname, rather than disable it.
+	want := `{"config":[{"hostname":"app.example.com","cert_id":"cert-a","enabled":null}]}`
+	if diff := cmp.Diff(want, string(body)); diff != "" {
+		t.Errorf("DeleteHostnameConfig(...): -want body, +got body:\n%s", diff)
+	}
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package totaltls
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/benagricola/provider-cloudflare/apis/ssl/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+const (
+	// StatusActive is the status of a certificate once it has been
+	// issued and is deployed to the Cloudflare edge.
+	StatusActive = "active"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with Total TLS. Total TLS is managed using raw API requests, as the
+// cloudflare-go library does not support it.
+type Client interface {
+	RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error)
+}
+
+// NewClient returns a new Cloudflare API client for working with Total
+// TLS.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// Settings are the Total TLS settings of a Zone as represented by the
+// Cloudflare API.
+type Settings struct {
+	Enabled              bool   `json:"enabled"`
+	CertificateAuthority string `json:"certificate_authority,omitempty"`
+	ValidityDays         int    `json:"validity_days,omitempty"`
+}
+
+// CertificatePack is a certificate pack of a Zone as represented by the
+// Cloudflare API.
+type CertificatePack struct {
+	ID                   string   `json:"id"`
+	Type                 string   `json:"type,omitempty"`
+	Hosts                []string `json:"hosts,omitempty"`
+	Status               string   `json:"status,omitempty"`
+	CertificateAuthority string   `json:"certificate_authority,omitempty"`
+}
+
+func endpoint(zoneID string) string {
+	return "/zones/" + zoneID + "/acm/total_tls"
+}
+
+// TotalTLS returns the Total TLS settings of the Zone with the passed ID.
+func TotalTLS(ctx context.Context, client Client, zoneID string) (*Settings, error) {
+	raw, err := client.RawContext(ctx, http.MethodGet, endpoint(zoneID), nil)
+	if err != nil {
+		return nil, err
+	}
+	s := &Settings{}
+	if err := json.Unmarshal(raw, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// CertificatePacks returns all certificate packs of the Zone with the
+// passed ID, including those that are not yet active.
+func CertificatePacks(ctx context.Context, client Client, zoneID string) ([]CertificatePack, error) {
+	raw, err := client.RawContext(ctx, http.MethodGet, "/zones/"+zoneID+"/ssl/certificate_packs?status=all", nil)
+	if err != nil {
+		return nil, err
+	}
+	var cps []CertificatePack
+	if err := json.Unmarshal(raw, &cps); err != nil {
+		return nil, err
+	}
+	return cps, nil
+}
+
+// GenerateObservation creates an observation of Total TLS on a Zone and
+// the certificates covering its hostnames.
+func GenerateObservation(in Settings, cps []CertificatePack) v1alpha1.TotalTLSObservation {
+	o := v1alpha1.TotalTLSObservation{
+		CertificateAuthority: in.CertificateAuthority,
+		ValidityDays:         in.ValidityDays,
+	}
+
+	for _, cp := range cps {
+		for _, h := range cp.Hosts {
+			o.Certificates = append(o.Certificates, v1alpha1.HostnameCertificate{
+				Hostname:             h,
+				CertificatePackID:    cp.ID,
+				Type:                 cp.Type,
+				CertificateAuthority: cp.CertificateAuthority,
+				Status:               cp.Status,
+			})
+		}
+	}
+
+	// Cloudflare does not order certificate packs, so sort them
+	// to avoid needlessly updating the status.
+	sort.SliceStable(o.Certificates, func(i, j int) bool {
+		if o.Certificates[i].Hostname != o.Certificates[j].Hostname {
+			return o.Certificates[i].Hostname < o.Certificates[j].Hostname
+		}
+		return o.Certificates[i].CertificatePackID < o.Certificates[j].CertificatePackID
+	})
+
+	return o
+}
+
+// CertificatesActive returns true if every certificate in the passed
+// observation has been issued and is active.
+func CertificatesActive(o v1alpha1.TotalTLSObservation) bool {
+	for _, c := range o.Certificates {
+		if c.Status != StatusActive {
+			return false
+		}
+	}
+	return true
+}
+
+// UpToDate checks if the remote Total TLS settings are up to date with
+// the requested resource parameters.
+func UpToDate(spec *v1alpha1.TotalTLSParameters, s Settings) bool {
+	if spec == nil {
+		return true
+	}
+
+	if spec.CertificateAuthority != nil && *spec.CertificateAuthority != s.CertificateAuthority {
+		return false
+	}
+
+	return true
+}
+
+// Enable enables Total TLS on the Zone with the passed ID, using the
+// requested certificate authority.
+func Enable(ctx context.Context, client Client, zoneID string, spec v1alpha1.TotalTLSParameters) (*Settings, error) {
+	s := Settings{Enabled: true}
+	if spec.CertificateAuthority != nil {
+		s.CertificateAuthority = *spec.CertificateAuthority
+	}
+
+	raw, err := client.RawContext(ctx, http.MethodPost, endpoint(zoneID), s)
+	if err != nil {
+		return nil, err
+	}
+	ns := &Settings{}
+	if err := json.Unmarshal(raw, ns); err != nil {
+		return nil, err
+	}
+	return ns, nil
+}
+
+// Disable disables Total TLS on the Zone with the passed ID.
+func Disable(ctx context.Context, client Client, zoneID string) error {
+	_, err := client.RawContext(ctx, http.MethodPost, endpoint(zoneID), Settings{Enabled: false})
+	return err
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package totaltls
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	ptr "k8s.io/utils/pointer"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/ssl/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/ssl/totaltls/fake"
+)
+
+const zoneID = "023e105f4ecef8ad9ca31a8372d0c353"
+
+func TestGenerateObservation(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		s      Settings
+		cps    []CertificatePack
+		want   v1alpha1.TotalTLSObservation
+	}{
+		"NoCertificates": {
+			reason: "Settings should be observed when there are no certificate packs",
+			s:      Settings{Enabled: true, CertificateAuthority: "google", ValidityDays: 90},
+			want:   v1alpha1.TotalTLSObservation{CertificateAuthority: "google", ValidityDays: 90},
+		},
+		"Certificates": {
+			reason: "Each hostname of each certificate pack should be observed, sorted by hostname",
+			s:      Settings{Enabled: true, CertificateAuthority: "lets_encrypt"},
+			cps: []CertificatePack{
+				{ID: "pack-b", Type: "advanced", Hosts: []string{"www.example.com"}, Status: "pending_validation", CertificateAuthority: "lets_encrypt"},
+				{ID: "pack-a", Type: "universal", Hosts: []string{"example.com", "*.example.com"}, Status: StatusActive, CertificateAuthority: "google"},
+			},
+			want: v1alpha1.TotalTLSObservation{
+				CertificateAuthority: "lets_encrypt",
+				Certificates: []v1alpha1.HostnameCertificate{
+					{Hostname: "*.example.com", CertificatePackID: "pack-a", Type: "universal", CertificateAuthority: "google", Status: StatusActive},
+					{Hostname: "example.com", CertificatePackID: "pack-a", Type: "universal", CertificateAuthority: "google", Status: StatusActive},
+					{Hostname: "www.example.com", CertificatePackID: "pack-b", Type: "advanced", CertificateAuthority: "lets_encrypt", Status: "pending_validation"},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateObservation(tc.s, tc.cps)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateObservation(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCertificatesActive(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		o      v1alpha1.TotalTLSObservation
+		want   bool
+	}{
+		"NoCertificates": {
+			reason: "An observation without certificates has no pending certificates",
+			want:   true,
+		},
+		"Active": {
+			reason: "An observation with only active certificates should be active",
+			o: v1alpha1.TotalTLSObservation{Certificates: []v1alpha1.HostnameCertificate{
+				{Hostname: "example.com", Status: StatusActive},
+			}},
+			want: true,
+		},
+		"Pending": {
+			reason: "An observation with a pending certificate should not be active",
+			o: v1alpha1.TotalTLSObservation{Certificates: []v1alpha1.HostnameCertificate{
+				{Hostname: "example.com", Status: StatusActive},
+				{Hostname: "www.example.com", Status: "pending_validation"},
+			}},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := CertificatesActive(tc.o)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nCertificatesActive(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		spec   *v1alpha1.TotalTLSParameters
+		s      Settings
+		want   bool
+	}{
+		"NilSpec": {
+			reason: "A nil spec should always be up to date",
+			s:      Settings{Enabled: true, CertificateAuthority: "google"},
+			want:   true,
+		},
+		"NoCertificateAuthority": {
+			reason: "Any certificate authority should be up to date when none is requested",
+			spec:   &v1alpha1.TotalTLSParameters{},
+			s:      Settings{Enabled: true, CertificateAuthority: "google"},
+			want:   true,
+		},
+		"CertificateAuthorityChanged": {
+			reason: "A different certificate authority should not be up to date",
+			spec:   &v1alpha1.TotalTLSParameters{CertificateAuthority: ptr.StringPtr("ssl_com")},
+			s:      Settings{Enabled: true, CertificateAuthority: "google"},
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := UpToDate(tc.spec, tc.s)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestEnable(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		s    *Settings
+		err  error
+		data interface{}
+	}
+
+	cases := map[string]struct {
+		reason string
+		spec   v1alpha1.TotalTLSParameters
+		raw    json.RawMessage
+		err    error
+		want   want
+	}{
+		"EnableFailed": {
+			reason: "Errors enabling Total TLS should be returned",
+			err:    errBoom,
+			want: want{
+				err:  errBoom,
+				data: Settings{Enabled: true},
+			},
+		},
+		"Success": {
+			reason: "The requested certificate authority should be sent and the new settings returned",
+			spec:   v1alpha1.TotalTLSParameters{CertificateAuthority: ptr.StringPtr("google")},
+			raw:    json.RawMessage(`{"enabled":true,"certificate_authority":"google","validity_days":90}`),
+			want: want{
+				s:    &Settings{Enabled: true, CertificateAuthority: "google", ValidityDays: 90},
+				data: Settings{Enabled: true, CertificateAuthority: "google"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var data interface{}
+			client := fake.MockClient{
+				MockRawContext: func(_ context.Context, m, e string, d interface{}) (json.RawMessage, error) {
+					if m != http.MethodPost || e != "/zones/"+zoneID+"/acm/total_tls" {
+						t.Errorf("\n%s\nEnable(...): unexpected request %s %s", tc.reason, m, e)
+					}
+					data = d
+					return tc.raw, tc.err
+				},
+			}
+			got, err := Enable(context.Background(), client, zoneID, tc.spec)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nEnable(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.s, got); diff != "" {
+				t.Errorf("\n%s\nEnable(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.data, data); diff != "" {
+				t.Errorf("\n%s\nEnable(...): -want data, +got data:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
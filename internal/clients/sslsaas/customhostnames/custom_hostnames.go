@@ -18,13 +18,25 @@ package customhostnames
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/cloudflare/cloudflare-go"
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/flowcontrol"
 
 	"github.com/benagricola/provider-cloudflare/apis/sslsaas/v1alpha1"
 	clients "github.com/benagricola/provider-cloudflare/internal/clients"
@@ -32,9 +44,63 @@ import (
 
 const (
 	// Cloudflare returns this code when a custom hostname isnt found
-	errCustomHostnameNotFound = "1436"
+	errCustomHostnameNotFound = 1436
+
+	// Cloudflare returns this code, with a 409 Conflict status, when a
+	// custom hostname cannot be deleted while its certificate is being
+	// deprovisioned.
+	errCertificateDeprovisioning = 1414
+)
+
+const (
+	// Returned when invalid custom metadata is supplied within spec
+	errCustomMetadataKeyEmpty    = "custom metadata keys must not be empty"
+	errCustomMetadataKeyFormat   = "custom metadata key %q may only contain letters, digits, '-', '_' and '.'"
+	errCustomMetadataKeyLength   = "custom metadata key %q must be at most %d characters"
+	errCustomMetadataValueFormat = "custom metadata value of key %q must be valid UTF-8 without control characters"
+	errCustomMetadataSize        = "custom metadata must be at most %d bytes when encoded, not %d"
+
+	// maxCustomMetadataKeyLength is the longest custom metadata key we
+	// accept, and maxCustomMetadataSize the largest custom metadata,
+	// encoded as JSON, that Cloudflare accepts for a custom hostname.
+	maxCustomMetadataKeyLength = 255
+	maxCustomMetadataSize      = 4096
 )
 
+const (
+	// validationRecordType is the type of DNS record created for TXT
+	// validation records.
+	validationRecordType = "TXT"
+
+	// validationRecordTTL is the TTL of DNS records created for TXT
+	// validation records, which is automatic.
+	validationRecordTTL = 1
+
+	// validationRecordComment tags the DNS records created for the TXT
+	// validation records of a custom hostname, so that they can be found
+	// again without the IDs kept in its status.
+	validationRecordComment = "crossplane: custom hostname %s validation"
+)
+
+// customMetadataKey matches the custom metadata keys we accept.
+var customMetadataKey = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// sslTypes are the levels of validation that may be used for a custom
+// hostname's certificate. These must be kept in sync with the enum on
+// CustomHostnameSSL.Type.
+var sslTypes = map[string]bool{
+	"dv": true,
+}
+
+// sslMethods are the domain control validation methods that may be used
+// for a custom hostname's certificate. These must be kept in sync with
+// the enum on CustomHostnameSSL.Method.
+var sslMethods = map[string]bool{
+	"http":  true,
+	"txt":   true,
+	"email": true,
+}
+
 // Client is a Cloudflare API client that implements methods for working
 // with Fallback Origins.
 type Client interface {
@@ -43,6 +109,47 @@ type Client interface {
 	DeleteCustomHostname(ctx context.Context, zoneID string, customHostnameID string) error
 	CreateCustomHostname(ctx context.Context, zoneID string, ch cloudflare.CustomHostname) (*cloudflare.CustomHostnameResponse, error)
 	CustomHostname(ctx context.Context, zoneID string, customHostnameID string) (cloudflare.CustomHostname, error)
+	RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error)
+	DNSRecords(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error)
+	DeleteDNSRecord(ctx context.Context, zoneID, recordID string) error
+}
+
+// customHostnameCertificates represents the certificate details of a
+// Custom Hostname that are in the API but not supported in the go
+// library yet.
+type customHostnameCertificates struct {
+	SSL struct {
+		ExpiresOn    *time.Time `json:"expires_on,omitempty"`
+		Certificates []struct {
+			ExpiresOn *time.Time `json:"expires_on,omitempty"`
+		} `json:"certificates,omitempty"`
+		TxtName           string             `json:"txt_name,omitempty"`
+		TxtValue          string             `json:"txt_value,omitempty"`
+		HTTPUrl           string             `json:"http_url,omitempty"`
+		HTTPBody          string             `json:"http_body,omitempty"`
+		ValidationRecords []validationRecord `json:"validation_records,omitempty"`
+	} `json:"ssl"`
+}
+
+// dnsRecord represents a DNS record with a comment, which is in the API
+// but not supported in the go library yet.
+type dnsRecord struct {
+	ID      string `json:"id,omitempty"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+	TTL     int    `json:"ttl,omitempty"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// validationRecord represents a domain control validation record of a
+// Custom Hostname certificate.
+type validationRecord struct {
+	TxtName  string   `json:"txt_name,omitempty"`
+	TxtValue string   `json:"txt_value,omitempty"`
+	HTTPUrl  string   `json:"http_url,omitempty"`
+	HTTPBody string   `json:"http_body,omitempty"`
+	Emails   []string `json:"emails,omitempty"`
 }
 
 // NewClient returns a new Cloudflare API client for working with Custom Hostnames.
@@ -50,10 +157,262 @@ func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
 	return clients.NewClient(cfg, hc)
 }
 
+// A ZoneRateLimiter limits the rate of calls made to each zone while
+// managing Custom Hostnames, as Custom Hostname operations are subject
+// to their own rate limits on Cloudflare.
+//
+// The limiter of a zone is forgotten once it has been idle for long
+// enough to refill its burst, as a new limiter would then allow the
+// same calls. Only the zones that were recently called are remembered.
+type ZoneRateLimiter struct {
+	qps   float32
+	burst int
+	idle  time.Duration
+	now   func() time.Time
+
+	mu       sync.Mutex
+	limiters map[string]*zoneLimiter
+}
+
+// zoneLimiter is the rate limiter of a zone, and when it was last used.
+type zoneLimiter struct {
+	flowcontrol.RateLimiter
+	waiting  int
+	lastUsed time.Time
+}
+
+// NewZoneRateLimiter returns a ZoneRateLimiter that allows qps calls per
+// second to each zone, with bursts of up to burst calls.
+func NewZoneRateLimiter(qps float32, burst int) *ZoneRateLimiter {
+	l := &ZoneRateLimiter{
+		qps:      qps,
+		burst:    burst,
+		now:      time.Now,
+		limiters: map[string]*zoneLimiter{},
+	}
+	if qps > 0 {
+		l.idle = time.Duration(float64(burst) / float64(qps) * float64(time.Second))
+	}
+	return l
+}
+
+// Wait blocks until a call may be made to the passed zone, or returns
+// an error if the passed context is done first.
+func (l *ZoneRateLimiter) Wait(ctx context.Context, zoneID string) error {
+	l.mu.Lock()
+	zl, ok := l.limiters[zoneID]
+	if !ok {
+		l.prune()
+		zl = &zoneLimiter{RateLimiter: flowcontrol.NewTokenBucketRateLimiter(l.qps, l.burst)}
+		l.limiters[zoneID] = zl
+	}
+	zl.waiting++
+	l.mu.Unlock()
+
+	err := zl.Wait(ctx)
+
+	l.mu.Lock()
+	zl.waiting--
+	zl.lastUsed = l.now()
+	l.mu.Unlock()
+
+	return err
+}
+
+// prune forgets the limiters of zones that nothing is waiting on, and
+// that have been idle for long enough to refill their burst. It must be
+// called with the lock held.
+func (l *ZoneRateLimiter) prune() {
+	// A limiter that never refills can never be forgotten.
+	if l.qps <= 0 {
+		return
+	}
+	now := l.now()
+	for id, zl := range l.limiters {
+		if zl.waiting == 0 && now.Sub(zl.lastUsed) > l.idle {
+			delete(l.limiters, id)
+		}
+	}
+}
+
+// rateLimitedClient is a Client that waits on a ZoneRateLimiter
+// before each call made to a zone.
+type rateLimitedClient struct {
+	Client
+	limiter *ZoneRateLimiter
+}
+
+// WithRateLimiter returns a Client that waits on the passed
+// ZoneRateLimiter before each call made to a zone.
+func WithRateLimiter(c Client, l *ZoneRateLimiter) Client {
+	return &rateLimitedClient{Client: c, limiter: l}
+}
+
+func (c *rateLimitedClient) UpdateCustomHostnameSSL(ctx context.Context, zoneID string, customHostnameID string, ssl cloudflare.CustomHostnameSSL) (*cloudflare.CustomHostnameResponse, error) {
+	if err := c.limiter.Wait(ctx, zoneID); err != nil {
+		return nil, err
+	}
+	return c.Client.UpdateCustomHostnameSSL(ctx, zoneID, customHostnameID, ssl)
+}
+
+func (c *rateLimitedClient) UpdateCustomHostname(ctx context.Context, zoneID string, customHostnameID string, ch cloudflare.CustomHostname) (*cloudflare.CustomHostnameResponse, error) {
+	if err := c.limiter.Wait(ctx, zoneID); err != nil {
+		return nil, err
+	}
+	return c.Client.UpdateCustomHostname(ctx, zoneID, customHostnameID, ch)
+}
+
+func (c *rateLimitedClient) DeleteCustomHostname(ctx context.Context, zoneID string, customHostnameID string) error {
+	if err := c.limiter.Wait(ctx, zoneID); err != nil {
+		return err
+	}
+	return c.Client.DeleteCustomHostname(ctx, zoneID, customHostnameID)
+}
+
+func (c *rateLimitedClient) CreateCustomHostname(ctx context.Context, zoneID string, ch cloudflare.CustomHostname) (*cloudflare.CustomHostnameResponse, error) {
+	if err := c.limiter.Wait(ctx, zoneID); err != nil {
+		return nil, err
+	}
+	return c.Client.CreateCustomHostname(ctx, zoneID, ch)
+}
+
+func (c *rateLimitedClient) CustomHostname(ctx context.Context, zoneID string, customHostnameID string) (cloudflare.CustomHostname, error) {
+	if err := c.limiter.Wait(ctx, zoneID); err != nil {
+		return cloudflare.CustomHostname{}, err
+	}
+	return c.Client.CustomHostname(ctx, zoneID, customHostnameID)
+}
+
+func (c *rateLimitedClient) RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+	if zoneID := zoneOf(endpoint); zoneID != "" {
+		if err := c.limiter.Wait(ctx, zoneID); err != nil {
+			return nil, err
+		}
+	}
+	return c.Client.RawContext(ctx, method, endpoint, data)
+}
+
+func (c *rateLimitedClient) DNSRecords(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+	if err := c.limiter.Wait(ctx, zoneID); err != nil {
+		return nil, err
+	}
+	return c.Client.DNSRecords(ctx, zoneID, rr)
+}
+
+func (c *rateLimitedClient) DeleteDNSRecord(ctx context.Context, zoneID, recordID string) error {
+	if err := c.limiter.Wait(ctx, zoneID); err != nil {
+		return err
+	}
+	return c.Client.DeleteDNSRecord(ctx, zoneID, recordID)
+}
+
+// zoneOf returns the ID of the zone that the passed API endpoint is
+// scoped to, or an empty string if it is not scoped to a zone.
+func zoneOf(endpoint string) string {
+	p := strings.Split(strings.TrimPrefix(endpoint, "/"), "/")
+	if len(p) < 2 || p[0] != "zones" {
+		return ""
+	}
+	return p[1]
+}
+
 // IsCustomHostnameNotFound returns true if the passed error indicates
 // that the CustomHostname is not found (been deleted or not set at all).
 func IsCustomHostnameNotFound(err error) bool {
-	return strings.Contains(err.Error(), errCustomHostnameNotFound)
+	return clients.IsNotFound(err) || clients.HasErrorCode(err, errCustomHostnameNotFound)
+}
+
+// IsCertificateDeprovisioning returns true if the passed error indicates
+// that the CustomHostname cannot be deleted yet because its certificate is
+// still being deprovisioned.
+func IsCertificateDeprovisioning(err error) bool {
+	var e *cloudflare.APIRequestError
+	if !errors.As(err, &e) || e == nil {
+		return false
+	}
+	return e.StatusCode == http.StatusConflict && clients.HasErrorCode(e, errCertificateDeprovisioning)
+}
+
+// IsValidSSLType returns true if the passed type is a level of
+// validation that may be used for a custom hostname's certificate.
+func IsValidSSLType(t string) bool {
+	return sslTypes[t]
+}
+
+// IsValidSSLMethod returns true if the passed method is a domain control
+// validation method that may be used for a custom hostname's certificate.
+func IsValidSSLMethod(m string) bool {
+	return sslMethods[m]
+}
+
+// ValidateCustomMetadata returns an error if the passed custom metadata
+// cannot be set on a custom hostname. Keys must be non-empty, short and
+// only contain letters, digits, '-', '_' and '.', values must be printable
+// UTF-8, and the encoded metadata must fit in Cloudflare's size limit.
+func ValidateCustomMetadata(md map[string]string) error {
+	if len(md) == 0 {
+		return nil
+	}
+
+	// Check keys in order so the same error is always returned.
+	keys := make([]string, 0, len(md))
+	for k := range md {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		switch {
+		case k == "":
+			return errors.New(errCustomMetadataKeyEmpty)
+		case len(k) > maxCustomMetadataKeyLength:
+			return errors.Errorf(errCustomMetadataKeyLength, k, maxCustomMetadataKeyLength)
+		case !customMetadataKey.MatchString(k):
+			return errors.Errorf(errCustomMetadataKeyFormat, k)
+		case !isPrintableUTF8(md[k]):
+			return errors.Errorf(errCustomMetadataValueFormat, k)
+		}
+	}
+
+	// A map of strings always marshals.
+	b, _ := json.Marshal(md)
+	if len(b) > maxCustomMetadataSize {
+		return errors.Errorf(errCustomMetadataSize, maxCustomMetadataSize, len(b))
+	}
+
+	return nil
+}
+
+// isPrintableUTF8 returns true if the passed string is valid UTF-8 and
+// contains no control characters.
+func isPrintableUTF8(s string) bool {
+	if !utf8.ValidString(s) {
+		return false
+	}
+	for _, r := range s {
+		if unicode.IsControl(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// customMetadataToStrings returns the passed custom metadata as a map of
+// strings. It returns false if any value is not a string, as these values
+// cannot be represented in our CustomHostnameParameters.
+func customMetadataToStrings(in cloudflare.CustomMetadata) (map[string]string, bool) {
+	if len(in) == 0 {
+		return nil, true
+	}
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		sv, ok := v.(string)
+		if !ok {
+			return nil, false
+		}
+		out[k] = sv
+	}
+	return out, true
 }
 
 // GenerateObservation creates an observation of a cloudflare Custom Hostname
@@ -61,6 +420,7 @@ func GenerateObservation(in cloudflare.CustomHostname) v1alpha1.CustomHostnameOb
 
 	ssl := v1alpha1.CustomHostnameSSLObserved{
 		Status:               in.SSL.Status,
+		Method:               in.SSL.Method,
 		HTTPUrl:              in.SSL.HTTPUrl,
 		HTTPBody:             in.SSL.HTTPBody,
 		CnameName:            in.SSL.CnameName,
@@ -92,11 +452,110 @@ func GenerateObservation(in cloudflare.CustomHostname) v1alpha1.CustomHostnameOb
 	}
 }
 
+// GetCustomHostname returns a Custom Hostname along with the raw API
+// response it was decoded from, so that the details of its certificate
+// that are not supported in the go library yet can be parsed without
+// fetching it again.
+func GetCustomHostname(ctx context.Context, client Client, zoneID, customHostnameID string) (cloudflare.CustomHostname, json.RawMessage, error) {
+	raw, err := client.RawContext(ctx, http.MethodGet, "/zones/"+zoneID+"/custom_hostnames/"+customHostnameID, nil)
+	if err != nil {
+		return cloudflare.CustomHostname{}, nil, err
+	}
+
+	ch := cloudflare.CustomHostname{}
+	if err := json.Unmarshal(raw, &ch); err != nil {
+		return cloudflare.CustomHostname{}, nil, err
+	}
+	return ch, raw, nil
+}
+
+// ParseCertificate fills in the details of the certificate for the
+// Custom Hostname that are not supported in the go library yet from a
+// raw Custom Hostname response.
+func ParseCertificate(raw json.RawMessage, o *v1alpha1.CustomHostnameSSLObserved) error {
+	exp, err := ParseCertificateExpiry(raw)
+	if err != nil {
+		return err
+	}
+	vr, err := ParseValidationRecords(raw)
+	if err != nil {
+		return err
+	}
+
+	o.ExpiresOn = exp
+	o.ValidationRecords = vr
+	return nil
+}
+
+// ParseCertificateExpiry returns the expiry of the active certificate
+// from a raw Custom Hostname response. When more than one certificate is
+// deployed the earliest expiry is returned, as that is the first one that
+// needs renewing.
+func ParseCertificateExpiry(raw json.RawMessage) (*metav1.Time, error) {
+	chc := customHostnameCertificates{}
+	if err := json.Unmarshal(raw, &chc); err != nil {
+		return nil, err
+	}
+
+	exp := chc.SSL.ExpiresOn
+	for _, c := range chc.SSL.Certificates {
+		if c.ExpiresOn != nil && (exp == nil || c.ExpiresOn.Before(*exp)) {
+			exp = c.ExpiresOn
+		}
+	}
+
+	if exp == nil {
+		return nil, nil
+	}
+	t := metav1.NewTime(*exp)
+	return &t, nil
+}
+
+// ParseValidationRecords returns the domain control validation records of
+// the certificate from a raw Custom Hostname response. Cloudflare may
+// only report a single record outside of the list of validation records,
+// in which case that record is returned.
+func ParseValidationRecords(raw json.RawMessage) ([]v1alpha1.CustomHostnameSSLValidationRecord, error) {
+	chc := customHostnameCertificates{}
+	if err := json.Unmarshal(raw, &chc); err != nil {
+		return nil, err
+	}
+
+	vrs := chc.SSL.ValidationRecords
+	if len(vrs) == 0 && (chc.SSL.TxtName != "" || chc.SSL.HTTPUrl != "") {
+		vrs = []validationRecord{{
+			TxtName:  chc.SSL.TxtName,
+			TxtValue: chc.SSL.TxtValue,
+			HTTPUrl:  chc.SSL.HTTPUrl,
+			HTTPBody: chc.SSL.HTTPBody,
+		}}
+	}
+
+	if len(vrs) == 0 {
+		return nil, nil
+	}
+
+	o := make([]v1alpha1.CustomHostnameSSLValidationRecord, len(vrs))
+	for i, vr := range vrs {
+		o[i] = v1alpha1.CustomHostnameSSLValidationRecord{
+			TXTName:  vr.TxtName,
+			TXTValue: vr.TxtValue,
+			HTTPURL:  vr.HTTPUrl,
+			HTTPBody: vr.HTTPBody,
+			Emails:   vr.Emails,
+		}
+	}
+	return o, nil
+}
+
 // CustomHostnameToParameters returns a CustomHostnameParameters representation of
 // a Cloudflare Custom Hostname.
 func CustomHostnameToParameters(in cloudflare.CustomHostname) v1alpha1.CustomHostnameParameters {
+	md, _ := customMetadataToStrings(in.CustomMetadata)
+
 	return v1alpha1.CustomHostnameParameters{
 		Hostname:           in.Hostname,
+		CustomMetadata:     md,
 		CustomOriginServer: clients.ToOptionalString(in.CustomOriginServer),
 		SSL: v1alpha1.CustomHostnameSSL{
 			// These fields are not optional in our API calls but are
@@ -119,8 +578,23 @@ func CustomHostnameToParameters(in cloudflare.CustomHostname) v1alpha1.CustomHos
 // ParametersToCustomHostname returns a Cloudflare API representation of a Custom
 // Hostname from our CustomHostnameParameters.
 func ParametersToCustomHostname(in v1alpha1.CustomHostnameParameters) cloudflare.CustomHostname {
+	var md cloudflare.CustomMetadata
+	if len(in.CustomMetadata) > 0 {
+		md = make(cloudflare.CustomMetadata, len(in.CustomMetadata))
+		for k, v := range in.CustomMetadata {
+			md[k] = v
+		}
+	}
+
+	var origin string
+	if in.CustomOriginServer != nil {
+		origin = *in.CustomOriginServer
+	}
+
 	return cloudflare.CustomHostname{
-		Hostname: in.Hostname,
+		Hostname:           in.Hostname,
+		CustomOriginServer: origin,
+		CustomMetadata:     md,
 		SSL: cloudflare.CustomHostnameSSL{
 			Method: *in.SSL.Method,
 			Type:   *in.SSL.Type,
@@ -128,7 +602,7 @@ func ParametersToCustomHostname(in v1alpha1.CustomHostnameParameters) cloudflare
 				HTTP2:         *clients.ToOptionalString(in.SSL.Settings.HTTP2),
 				TLS13:         *clients.ToOptionalString(in.SSL.Settings.TLS13),
 				MinTLSVersion: *clients.ToOptionalString(in.SSL.Settings.MinTLSVersion),
-				Ciphers:       in.SSL.Settings.Ciphers,
+				Ciphers:       clients.ManagedCiphers(in.SSL.Settings.Ciphers),
 			},
 			Wildcard:          in.SSL.Wildcard,
 			CustomCertificate: *clients.ToOptionalString(in.SSL.CustomCertificate),
@@ -144,14 +618,52 @@ func UpToDate(spec *v1alpha1.CustomHostnameParameters, o cloudflare.CustomHostna
 		return true
 	}
 
+	// Metadata with values that are not strings was not set by us, so
+	// it must be replaced if we manage the metadata.
+	if _, ok := customMetadataToStrings(o.CustomMetadata); !ok && len(spec.CustomMetadata) > 0 {
+		return false
+	}
+
+	// An empty cipher list means the default ciphers are used, which
+	// Cloudflare may report, so they are only compared if we manage them.
+	ochp := CustomHostnameToParameters(o)
+	if clients.ManagedCiphers(spec.SSL.Settings.Ciphers) == nil {
+		ochp.SSL.Settings.Ciphers = nil
+	}
+
+	// Cloudflare never reports the private key of a custom certificate,
+	// so it can only be compared when it is. Certificates are compared
+	// without surrounding whitespace, as Cloudflare may not preserve the
+	// trailing newline of a PEM block.
+	if ochp.SSL.CustomKey == nil {
+		ochp.SSL.CustomKey = spec.SSL.CustomKey
+	}
+	if !customCertificateUpToDate(spec.SSL.CustomCertificate, ochp.SSL.CustomCertificate) {
+		return false
+	}
+	ochp.SSL.CustomCertificate = spec.SSL.CustomCertificate
+
 	return cmp.Equal(*spec,
-		CustomHostnameToParameters(o),
+		ochp,
 		cmpopts.EquateEmpty(),
 		cmpopts.IgnoreTypes(&xpv1.Reference{}, &xpv1.Selector{}, []xpv1.Reference{}),
-		cmpopts.IgnoreFields(v1alpha1.CustomHostnameParameters{}, "Zone"),
+		cmpopts.IgnoreFields(v1alpha1.CustomHostnameParameters{}, "Zone", "ValidationRecordZone"),
 	)
 }
 
+// customCertificateUpToDate returns true if the passed custom certificates
+// are the same PEM data.
+func customCertificateUpToDate(spec, o *string) bool {
+	var sc, oc string
+	if spec != nil {
+		sc = *spec
+	}
+	if o != nil {
+		oc = *o
+	}
+	return strings.TrimSpace(sc) == strings.TrimSpace(oc)
+}
+
 // CreateCustomHostname creates a new Custom Hostname.
 func CreateCustomHostname(ctx context.Context, client Client, spec v1alpha1.CustomHostnameParameters) (*cloudflare.CustomHostnameResponse, error) {
 	return client.CreateCustomHostname(ctx, *spec.Zone, ParametersToCustomHostname(spec))
@@ -162,3 +674,197 @@ func UpdateCustomHostname(ctx context.Context, client Client, id string, spec v1
 	_, err := client.UpdateCustomHostname(ctx, *spec.Zone, id, ParametersToCustomHostname(spec))
 	return err
 }
+
+// txtValidationRecord returns a DNS record filter matching the passed
+// validation record, and false if it is not a TXT validation record.
+func txtValidationRecord(vr v1alpha1.CustomHostnameSSLValidationRecord) (cloudflare.DNSRecord, bool) {
+	if vr.TXTName == "" || vr.TXTValue == "" {
+		return cloudflare.DNSRecord{}, false
+	}
+	return cloudflare.DNSRecord{
+		Type:    validationRecordType,
+		Name:    vr.TXTName,
+		Content: vr.TXTValue,
+	}, true
+}
+
+// validationRecordTag returns the comment that the DNS records created for
+// the TXT validation records of the passed custom hostname are tagged with.
+func validationRecordTag(customHostnameID string) string {
+	return fmt.Sprintf(validationRecordComment, customHostnameID)
+}
+
+// CollectValidationRecords returns the passed published TXT records
+// together with those found on the passed zone by the tag of the passed
+// custom hostname, so that records whose IDs were lost from status are
+// not orphaned. Published records that do not know their zone were
+// published before it was recorded, on the passed zone.
+func CollectValidationRecords(ctx context.Context, client Client, zoneID, customHostnameID string, published []v1alpha1.CustomHostnameValidationDNSRecord) ([]v1alpha1.CustomHostnameValidationDNSRecord, error) {
+	out := make([]v1alpha1.CustomHostnameValidationDNSRecord, 0, len(published))
+	for _, p := range published {
+		if p.Zone == "" {
+			p.Zone = zoneID
+		}
+		out = append(out, p)
+	}
+	if zoneID == "" {
+		return out, nil
+	}
+
+	v := url.Values{}
+	v.Set("type", validationRecordType)
+	v.Set("comment", validationRecordTag(customHostnameID))
+	v.Set("per_page", "100")
+	raw, err := client.RawContext(ctx, http.MethodGet, "/zones/"+zoneID+"/dns_records?"+v.Encode(), nil)
+	if err != nil {
+		return out, err
+	}
+	var rrs []dnsRecord
+	if err := json.Unmarshal(raw, &rrs); err != nil {
+		return out, err
+	}
+
+	for _, rr := range rrs {
+		found := false
+		for _, p := range out {
+			if p.Zone == zoneID && p.ID == rr.ID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, v1alpha1.CustomHostnameValidationDNSRecord{Zone: zoneID, ID: rr.ID, Name: rr.Name, Value: rr.Content})
+		}
+	}
+	return out, nil
+}
+
+// partitionValidationDNSRecords splits the passed published TXT records
+// into those that are still current for the passed validation records on
+// the passed zone, and those that are stale. Records published on another
+// zone, or on any zone when zoneID is empty, are stale.
+func partitionValidationDNSRecords(zoneID string, published []v1alpha1.CustomHostnameValidationDNSRecord, vrs []v1alpha1.CustomHostnameSSLValidationRecord) (current, stale []v1alpha1.CustomHostnameValidationDNSRecord) {
+	for _, p := range published {
+		found := false
+		for _, vr := range vrs {
+			if zoneID != "" && (p.Zone == "" || p.Zone == zoneID) && vr.TXTName == p.Name && vr.TXTValue == p.Value {
+				found = true
+				break
+			}
+		}
+		if found {
+			current = append(current, p)
+			continue
+		}
+		stale = append(stale, p)
+	}
+	return current, stale
+}
+
+// ValidationRecordsUpToDate returns true if none of the passed published
+// TXT records are stale and, while validation is pending, a TXT record
+// exists on the passed zone for each of the passed TXT validation records.
+// Records are not looked up unless validation is pending, as Cloudflare
+// only needs them until the certificate is validated.
+func ValidationRecordsUpToDate(ctx context.Context, client Client, zoneID string, published []v1alpha1.CustomHostnameValidationDNSRecord, vrs []v1alpha1.CustomHostnameSSLValidationRecord, pending bool) (bool, error) {
+	if _, stale := partitionValidationDNSRecords(zoneID, published, vrs); len(stale) > 0 {
+		return false, nil
+	}
+	if zoneID == "" || !pending {
+		return true, nil
+	}
+	for _, vr := range vrs {
+		rr, ok := txtValidationRecord(vr)
+		if !ok {
+			continue
+		}
+		rrs, err := client.DNSRecords(ctx, zoneID, rr)
+		if err != nil {
+			return false, err
+		}
+		if len(rrs) == 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// PublishValidationRecords creates a TXT record on the passed zone for
+// each of the passed TXT validation records of the passed custom hostname
+// that does not have one yet, and deletes the passed published TXT records
+// that are stale. No records are created when zoneID is empty, so every
+// published record is deleted. Created records are tagged with the custom
+// hostname. It returns the TXT records that remain published, including
+// those it created, even if it returns an error, so that they can be
+// deleted later.
+func PublishValidationRecords(ctx context.Context, client Client, zoneID, customHostnameID string, published []v1alpha1.CustomHostnameValidationDNSRecord, vrs []v1alpha1.CustomHostnameSSLValidationRecord) ([]v1alpha1.CustomHostnameValidationDNSRecord, error) {
+	published, err := CollectValidationRecords(ctx, client, zoneID, customHostnameID, published)
+	if err != nil {
+		return published, err
+	}
+
+	current, stale := partitionValidationDNSRecords(zoneID, published, vrs)
+	for _, vr := range vrs {
+		if zoneID == "" {
+			break
+		}
+		rr, ok := txtValidationRecord(vr)
+		if !ok {
+			continue
+		}
+		rrs, err := client.DNSRecords(ctx, zoneID, rr)
+		if err != nil {
+			return append(current, stale...), err
+		}
+		if len(rrs) > 0 {
+			continue
+		}
+		raw, err := client.RawContext(ctx, http.MethodPost, "/zones/"+zoneID+"/dns_records", dnsRecord{
+			Type:    rr.Type,
+			Name:    rr.Name,
+			Content: rr.Content,
+			TTL:     validationRecordTTL,
+			Comment: validationRecordTag(customHostnameID),
+		})
+		if err != nil {
+			return append(current, stale...), err
+		}
+		res := dnsRecord{}
+		if err := json.Unmarshal(raw, &res); err != nil {
+			return append(current, stale...), err
+		}
+
+		// A published record that no longer exists is replaced by the
+		// record just created.
+		created := v1alpha1.CustomHostnameValidationDNSRecord{Zone: zoneID, ID: res.ID, Name: rr.Name, Value: rr.Content}
+		replaced := false
+		for i := range current {
+			if current[i].Name == created.Name && current[i].Value == created.Value {
+				current[i] = created
+				replaced = true
+			}
+		}
+		if !replaced {
+			current = append(current, created)
+		}
+	}
+
+	remaining, err := DeleteValidationRecords(ctx, client, stale)
+	return append(current, remaining...), err
+}
+
+// DeleteValidationRecords deletes the passed published TXT records from
+// the zones they were published on. It returns the TXT records that could
+// not be deleted. Records that do not know their zone cannot be found, so
+// they are not returned.
+func DeleteValidationRecords(ctx context.Context, client Client, published []v1alpha1.CustomHostnameValidationDNSRecord) ([]v1alpha1.CustomHostnameValidationDNSRecord, error) {
+	for i, p := range published {
+		if p.Zone == "" {
+			continue
+		}
+		if err := client.DeleteDNSRecord(ctx, p.Zone, p.ID); err != nil && !clients.IsNotFound(err) {
+			return published[i:], err
+		}
+	}
+	return nil, nil
+}
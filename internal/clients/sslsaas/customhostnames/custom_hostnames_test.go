@@ -17,13 +17,24 @@ limitations under the License.
 package customhostnames
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/cloudflare/cloudflare-go"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/benagricola/provider-cloudflare/apis/sslsaas/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/sslsaas/customhostnames/fake"
 
 	ptr "k8s.io/utils/pointer"
 )
@@ -138,6 +149,263 @@ func TestUpToDate(t *testing.T) {
 				o: true,
 			},
 		},
+		"UpToDateSSLMethodChanged": {
+			reason: "UpToDate should return false if only the SSL method has changed",
+			args: args{
+				chp: &v1alpha1.CustomHostnameParameters{
+					Hostname: hostname,
+					SSL: v1alpha1.CustomHostnameSSL{
+						Method: ptr.StringPtr("txt"),
+						Type:   ptr.StringPtr(sslType),
+					},
+				},
+				ch: cloudflare.CustomHostname{
+					Hostname: hostname,
+					SSL: cloudflare.CustomHostnameSSL{
+						Method: "http",
+						Type:   sslType,
+					},
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"UpToDateCustomOriginServerChanged": {
+			reason: "UpToDate should return false if only the custom origin server has changed",
+			args: args{
+				chp: &v1alpha1.CustomHostnameParameters{
+					Hostname:           hostname,
+					CustomOriginServer: ptr.StringPtr("new." + customOrigin),
+				},
+				ch: cloudflare.CustomHostname{
+					Hostname:           hostname,
+					CustomOriginServer: customOrigin,
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"UpToDateEmptyCiphers": {
+			reason: "UpToDate should return true if no ciphers are set, even when Cloudflare reports the default ciphers",
+			args: args{
+				chp: &v1alpha1.CustomHostnameParameters{
+					Hostname: hostname,
+				},
+				ch: cloudflare.CustomHostname{
+					Hostname: hostname,
+					SSL: cloudflare.CustomHostnameSSL{
+						Settings: cloudflare.CustomHostnameSSLSettings{
+							Ciphers: []string{"ECDHE-RSA-AES128-GCM-SHA256", "AES128-SHA"},
+						},
+					},
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"UpToDateEmptyCiphersSet": {
+			reason: "UpToDate should return true if an empty list of ciphers is set, as it means the same as no ciphers",
+			args: args{
+				chp: &v1alpha1.CustomHostnameParameters{
+					Hostname: hostname,
+					SSL: v1alpha1.CustomHostnameSSL{
+						Settings: v1alpha1.CustomHostnameSSLSettings{
+							Ciphers: []string{},
+						},
+					},
+				},
+				ch: cloudflare.CustomHostname{
+					Hostname: hostname,
+					SSL: cloudflare.CustomHostnameSSL{
+						Settings: cloudflare.CustomHostnameSSLSettings{
+							Ciphers: []string{"ECDHE-RSA-AES128-GCM-SHA256", "AES128-SHA"},
+						},
+					},
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"UpToDateCiphers": {
+			reason: "UpToDate should return true if the managed ciphers match",
+			args: args{
+				chp: &v1alpha1.CustomHostnameParameters{
+					Hostname: hostname,
+					SSL: v1alpha1.CustomHostnameSSL{
+						Settings: v1alpha1.CustomHostnameSSLSettings{
+							Ciphers: []string{"ECDHE-RSA-AES128-GCM-SHA256"},
+						},
+					},
+				},
+				ch: cloudflare.CustomHostname{
+					Hostname: hostname,
+					SSL: cloudflare.CustomHostnameSSL{
+						Settings: cloudflare.CustomHostnameSSLSettings{
+							Ciphers: []string{"ECDHE-RSA-AES128-GCM-SHA256"},
+						},
+					},
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"UpToDateCiphersChanged": {
+			reason: "UpToDate should return false if the managed ciphers have changed",
+			args: args{
+				chp: &v1alpha1.CustomHostnameParameters{
+					Hostname: hostname,
+					SSL: v1alpha1.CustomHostnameSSL{
+						Settings: v1alpha1.CustomHostnameSSLSettings{
+							Ciphers: []string{"ECDHE-RSA-AES128-GCM-SHA256"},
+						},
+					},
+				},
+				ch: cloudflare.CustomHostname{
+					Hostname: hostname,
+					SSL: cloudflare.CustomHostnameSSL{
+						Settings: cloudflare.CustomHostnameSSLSettings{
+							Ciphers: []string{"ECDHE-RSA-AES128-GCM-SHA256", "AES128-SHA"},
+						},
+					},
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"UpToDateCustomMetadata": {
+			reason: "UpToDate should return true if the custom metadata matches regardless of order",
+			args: args{
+				chp: &v1alpha1.CustomHostnameParameters{
+					Hostname:       hostname,
+					CustomMetadata: map[string]string{"tier": "gold", "region": "eu"},
+				},
+				ch: cloudflare.CustomHostname{
+					Hostname:       hostname,
+					CustomMetadata: cloudflare.CustomMetadata{"region": "eu", "tier": "gold"},
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"UpToDateCustomMetadataDrift": {
+			reason: "UpToDate should return false if a custom metadata value has changed",
+			args: args{
+				chp: &v1alpha1.CustomHostnameParameters{
+					Hostname:       hostname,
+					CustomMetadata: map[string]string{"tier": "gold"},
+				},
+				ch: cloudflare.CustomHostname{
+					Hostname:       hostname,
+					CustomMetadata: cloudflare.CustomMetadata{"tier": "silver"},
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"UpToDateCustomMetadataNotString": {
+			reason: "UpToDate should return false if a custom metadata value is not a string",
+			args: args{
+				chp: &v1alpha1.CustomHostnameParameters{
+					Hostname:       hostname,
+					CustomMetadata: map[string]string{"tier": "1"},
+				},
+				ch: cloudflare.CustomHostname{
+					Hostname:       hostname,
+					CustomMetadata: cloudflare.CustomMetadata{"tier": float64(1)},
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"UpToDateCustomKeyNotReported": {
+			reason: "UpToDate should return true if Cloudflare does not report the private key of a matching custom certificate",
+			args: args{
+				chp: &v1alpha1.CustomHostnameParameters{
+					Hostname: hostname,
+					SSL: v1alpha1.CustomHostnameSSL{
+						CustomCertificate: ptr.StringPtr(sslCustomCertificate),
+						CustomKey:         ptr.StringPtr(sslCustomKey),
+					},
+				},
+				ch: cloudflare.CustomHostname{
+					Hostname: hostname,
+					SSL: cloudflare.CustomHostnameSSL{
+						CustomCertificate: sslCustomCertificate,
+					},
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"UpToDateCustomCertificateWhitespace": {
+			reason: "UpToDate should return true if the custom certificate only differs by surrounding whitespace",
+			args: args{
+				chp: &v1alpha1.CustomHostnameParameters{
+					Hostname: hostname,
+					SSL: v1alpha1.CustomHostnameSSL{
+						CustomCertificate: ptr.StringPtr(sslCustomCertificate + "\n"),
+					},
+				},
+				ch: cloudflare.CustomHostname{
+					Hostname: hostname,
+					SSL: cloudflare.CustomHostnameSSL{
+						CustomCertificate: sslCustomCertificate,
+					},
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"UpToDateCustomCertificateChanged": {
+			reason: "UpToDate should return false if the custom certificate has changed",
+			args: args{
+				chp: &v1alpha1.CustomHostnameParameters{
+					Hostname: hostname,
+					SSL: v1alpha1.CustomHostnameSSL{
+						CustomCertificate: ptr.StringPtr("new cert"),
+						CustomKey:         ptr.StringPtr(sslCustomKey),
+					},
+				},
+				ch: cloudflare.CustomHostname{
+					Hostname: hostname,
+					SSL: cloudflare.CustomHostnameSSL{
+						CustomCertificate: sslCustomCertificate,
+					},
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"UpToDateCustomCertificateRemoved": {
+			reason: "UpToDate should return false if a custom certificate is set but Cloudflare issued the certificate",
+			args: args{
+				chp: &v1alpha1.CustomHostnameParameters{
+					Hostname: hostname,
+					SSL: v1alpha1.CustomHostnameSSL{
+						CustomCertificate: ptr.StringPtr(sslCustomCertificate),
+						CustomKey:         ptr.StringPtr(sslCustomKey),
+					},
+				},
+				ch: cloudflare.CustomHostname{
+					Hostname: hostname,
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -149,3 +417,1005 @@ func TestUpToDate(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomMetadataRoundTrip(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		md     map[string]string
+	}{
+		"NoMetadata": {
+			reason: "No custom metadata should be sent or observed when none is set",
+		},
+		"Metadata": {
+			reason: "Custom metadata should be observed as it was sent",
+			md:     map[string]string{"tier": "gold", "region": "eu"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ch := ParametersToCustomHostname(v1alpha1.CustomHostnameParameters{
+				CustomMetadata: tc.md,
+				SSL: v1alpha1.CustomHostnameSSL{
+					Method: ptr.StringPtr(sslMethod),
+					Type:   ptr.StringPtr(sslType),
+					Settings: v1alpha1.CustomHostnameSSLSettings{
+						HTTP2:         ptr.StringPtr("on"),
+						TLS13:         ptr.StringPtr("on"),
+						MinTLSVersion: ptr.StringPtr("1.2"),
+					},
+					CustomCertificate: ptr.StringPtr(sslCustomCertificate),
+					CustomKey:         ptr.StringPtr(sslCustomKey),
+				},
+			})
+			got := CustomHostnameToParameters(ch).CustomMetadata
+			if diff := cmp.Diff(tc.md, got); diff != "" {
+				t.Errorf("\n%s\nCustomHostnameToParameters(ParametersToCustomHostname(...)): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidateCustomMetadata(t *testing.T) {
+	long := strings.Repeat("a", maxCustomMetadataKeyLength+1)
+
+	cases := map[string]struct {
+		reason string
+		md     map[string]string
+		want   error
+	}{
+		"NoMetadata": {
+			reason: "No custom metadata should be valid",
+		},
+		"Valid": {
+			reason: "Custom metadata with valid keys and values should be valid",
+			md:     map[string]string{"tier": "gold", "origin.region-1_a": "eu west"},
+		},
+		"KeyEmpty": {
+			reason: "An empty key should be invalid",
+			md:     map[string]string{"": "gold"},
+			want:   errors.New(errCustomMetadataKeyEmpty),
+		},
+		"KeyTooLong": {
+			reason: "A key longer than the maximum length should be invalid",
+			md:     map[string]string{long: "gold"},
+			want:   errors.Errorf(errCustomMetadataKeyLength, long, maxCustomMetadataKeyLength),
+		},
+		"KeyFormat": {
+			reason: "A key with characters other than letters, digits, '-', '_' and '.' should be invalid",
+			md:     map[string]string{"tier/name": "gold"},
+			want:   errors.Errorf(errCustomMetadataKeyFormat, "tier/name"),
+		},
+		"ValueControlCharacter": {
+			reason: "A value containing a control character should be invalid",
+			md:     map[string]string{"tier": "gold\n"},
+			want:   errors.Errorf(errCustomMetadataValueFormat, "tier"),
+		},
+		"ValueInvalidUTF8": {
+			reason: "A value that is not valid UTF-8 should be invalid",
+			md:     map[string]string{"tier": "\xff"},
+			want:   errors.Errorf(errCustomMetadataValueFormat, "tier"),
+		},
+		"TooLarge": {
+			reason: "Custom metadata larger than the maximum size when encoded should be invalid",
+			md:     map[string]string{"tier": strings.Repeat("a", maxCustomMetadataSize)},
+			want:   errors.Errorf(errCustomMetadataSize, maxCustomMetadataSize, maxCustomMetadataSize+len(`{"tier":""}`)),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateCustomMetadata(tc.md)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidateCustomMetadata(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestParametersToCustomHostnameCiphers(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		ciphers []string
+		want    []string
+	}{
+		"Unset": {
+			reason: "No ciphers should be sent when none are set",
+		},
+		"Empty": {
+			reason:  "No ciphers should be sent when an empty list is set, so that the default ciphers are used",
+			ciphers: []string{},
+		},
+		"Ciphers": {
+			reason:  "The ciphers that are set should be sent",
+			ciphers: []string{"AES128-SHA"},
+			want:    []string{"AES128-SHA"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ch := ParametersToCustomHostname(v1alpha1.CustomHostnameParameters{
+				SSL: v1alpha1.CustomHostnameSSL{
+					Method: ptr.StringPtr(sslMethod),
+					Type:   ptr.StringPtr(sslType),
+					Settings: v1alpha1.CustomHostnameSSLSettings{
+						Ciphers: tc.ciphers,
+					},
+				},
+			})
+			if diff := cmp.Diff(tc.want, ch.SSL.Settings.Ciphers); diff != "" {
+				t.Errorf("\n%s\nParametersToCustomHostname(...): -want ciphers, +got ciphers:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsCertificateDeprovisioning(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   bool
+	}{
+		"Deprovisioning": {
+			reason: "A conflict with the deprovisioning error code should be reported as deprovisioning",
+			err: &cloudflare.APIRequestError{
+				StatusCode: http.StatusConflict,
+				Errors:     []cloudflare.ResponseInfo{{Code: errCertificateDeprovisioning}},
+			},
+			want: true,
+		},
+		"WrappedDeprovisioning": {
+			reason: "A wrapped conflict with the deprovisioning error code should be reported as deprovisioning",
+			err: errors.Wrap(&cloudflare.APIRequestError{
+				StatusCode: http.StatusConflict,
+				Errors:     []cloudflare.ResponseInfo{{Code: errCertificateDeprovisioning}},
+			}, "boom"),
+			want: true,
+		},
+		"OtherConflict": {
+			reason: "A conflict with another error code should not be reported as deprovisioning",
+			err: &cloudflare.APIRequestError{
+				StatusCode: http.StatusConflict,
+				Errors:     []cloudflare.ResponseInfo{{Code: 1406}},
+			},
+			want: false,
+		},
+		"OtherStatus": {
+			reason: "The deprovisioning error code without a conflict should not be reported as deprovisioning",
+			err: &cloudflare.APIRequestError{
+				StatusCode: http.StatusBadRequest,
+				Errors:     []cloudflare.ResponseInfo{{Code: errCertificateDeprovisioning}},
+			},
+			want: false,
+		},
+		"UntypedConflict": {
+			reason: "An untyped error should not be reported as deprovisioning",
+			err:    errors.New("HTTP status 409: conflict"),
+			want:   false,
+		},
+		"Nil": {
+			reason: "No error should not be reported as deprovisioning",
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsCertificateDeprovisioning(tc.err)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nIsCertificateDeprovisioning(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetCustomHostname(t *testing.T) {
+	raw := json.RawMessage(`{"id":"id","hostname":"myhostname.com","ssl":{"status":"active","expires_on":"2021-08-10T23:59:59Z"}}`)
+
+	calls := 0
+	client := fake.MockClient{
+		MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+			calls++
+			if method != http.MethodGet || endpoint != "/zones/zone/custom_hostnames/id" {
+				t.Errorf("RawContext(...): unexpected request %s %s", method, endpoint)
+			}
+			return raw, nil
+		},
+	}
+
+	ch, gotRaw, err := GetCustomHostname(context.Background(), client, "zone", "id")
+	if err != nil {
+		t.Fatalf("GetCustomHostname(...): unexpected error: %s", err)
+	}
+
+	want := cloudflare.CustomHostname{
+		ID:       "id",
+		Hostname: hostname,
+		SSL:      cloudflare.CustomHostnameSSL{Status: "active"},
+	}
+	if diff := cmp.Diff(want, ch); diff != "" {
+		t.Errorf("GetCustomHostname(...): -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(raw, gotRaw); diff != "" {
+		t.Errorf("GetCustomHostname(...): -want raw, +got raw:\n%s", diff)
+	}
+	if calls != 1 {
+		t.Errorf("GetCustomHostname(...): want 1 request, got %d", calls)
+	}
+}
+
+func TestParseCertificateExpiry(t *testing.T) {
+	earliest := metav1.NewTime(time.Date(2021, 7, 10, 23, 59, 59, 0, time.UTC))
+	latest := metav1.NewTime(time.Date(2021, 8, 10, 23, 59, 59, 0, time.UTC))
+
+	invalid := json.RawMessage(`{"ssl":{"expires_on":"never"}}`)
+	errInvalid := json.Unmarshal(invalid, &customHostnameCertificates{})
+
+	type want struct {
+		exp *metav1.Time
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		raw    json.RawMessage
+		want   want
+	}{
+		"NoCertificate": {
+			reason: "ParseCertificateExpiry should return no expiry when there is no certificate",
+			raw:    json.RawMessage(`{"ssl":{"status":"pending_validation"}}`),
+			want:   want{},
+		},
+		"SSLExpiry": {
+			reason: "ParseCertificateExpiry should return the expiry of the SSL section",
+			raw:    json.RawMessage(`{"ssl":{"expires_on":"2021-08-10T23:59:59Z"}}`),
+			want: want{
+				exp: &latest,
+			},
+		},
+		"EarliestCertificateExpiry": {
+			reason: "ParseCertificateExpiry should return the earliest expiry of the deployed certificates",
+			raw: json.RawMessage(`{"ssl":{"certificates":[
+				{"expires_on":"2021-08-10T23:59:59Z"},
+				{"expires_on":"2021-07-10T23:59:59Z"}
+			]}}`),
+			want: want{
+				exp: &earliest,
+			},
+		},
+		"ErrInvalidResponse": {
+			reason: "ParseCertificateExpiry should return an error when the response cannot be parsed",
+			raw:    invalid,
+			want: want{
+				err: errInvalid,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseCertificateExpiry(tc.raw)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nParseCertificateExpiry(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.exp, got); diff != "" {
+				t.Errorf("\n%s\nParseCertificateExpiry(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestParseValidationRecords(t *testing.T) {
+	invalid := json.RawMessage(`{"ssl":{"validation_records":{}}}`)
+	errInvalid := json.Unmarshal(invalid, &customHostnameCertificates{})
+
+	type want struct {
+		vr  []v1alpha1.CustomHostnameSSLValidationRecord
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		raw    json.RawMessage
+		want   want
+	}{
+		"NoValidationRecords": {
+			reason: "ParseValidationRecords should return no records when the certificate is not being validated",
+			raw:    json.RawMessage(`{"ssl":{"status":"active"}}`),
+			want:   want{},
+		},
+		"ValidationRecords": {
+			reason: "ParseValidationRecords should return the validation records of the certificate",
+			raw: json.RawMessage(`{"ssl":{"validation_records":[
+				{"txt_name":"_acme-challenge.myhostname.com","txt_value":"abc"},
+				{"emails":["admin@example.com"]}
+			]}}`),
+			want: want{
+				vr: []v1alpha1.CustomHostnameSSLValidationRecord{
+					{TXTName: "_acme-challenge.myhostname.com", TXTValue: "abc"},
+					{Emails: []string{"admin@example.com"}},
+				},
+			},
+		},
+		"SingleValidationRecord": {
+			reason: "ParseValidationRecords should return the record reported outside of the list of validation records",
+			raw:    json.RawMessage(`{"ssl":{"http_url":"http://app.example.com/.well-known/pki-validation/ca3-abc.txt","http_body":"ca3-def"}}`),
+			want: want{
+				vr: []v1alpha1.CustomHostnameSSLValidationRecord{
+					{HTTPURL: "http://app.example.com/.well-known/pki-validation/ca3-abc.txt", HTTPBody: "ca3-def"},
+				},
+			},
+		},
+		"ErrInvalidResponse": {
+			reason: "ParseValidationRecords should return an error when the response cannot be parsed",
+			raw:    invalid,
+			want: want{
+				err: errInvalid,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseValidationRecords(tc.raw)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nParseValidationRecords(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.vr, got); diff != "" {
+				t.Errorf("\n%s\nParseValidationRecords(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidationRecordsUpToDate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	txt := v1alpha1.CustomHostnameSSLValidationRecord{TXTName: "_acme-challenge." + hostname, TXTValue: "abc"}
+
+	type args struct {
+		zoneID    string
+		published []v1alpha1.CustomHostnameValidationDNSRecord
+		vrs       []v1alpha1.CustomHostnameSSLValidationRecord
+		pending   bool
+		existing  []cloudflare.DNSRecord
+		err       error
+	}
+
+	type want struct {
+		upToDate bool
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Published": {
+			reason: "Validation records should be up to date when each TXT validation record exists",
+			args: args{
+				zoneID:    "zone",
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{{Zone: "zone", ID: "1234", Name: txt.TXTName, Value: txt.TXTValue}},
+				vrs:       []v1alpha1.CustomHostnameSSLValidationRecord{txt},
+				pending:   true,
+				existing:  []cloudflare.DNSRecord{{ID: "1234"}},
+			},
+			want: want{upToDate: true},
+		},
+		"NotPublished": {
+			reason: "Validation records should not be up to date when a TXT validation record does not exist",
+			args: args{
+				zoneID:  "zone",
+				vrs:     []v1alpha1.CustomHostnameSSLValidationRecord{txt},
+				pending: true,
+			},
+			want: want{upToDate: false},
+		},
+		"NotPending": {
+			reason: "Existing records should not be looked up unless validation is pending",
+			args: args{
+				zoneID: "zone",
+				vrs:    []v1alpha1.CustomHostnameSSLValidationRecord{txt},
+				err:    errBoom,
+			},
+			want: want{upToDate: true},
+		},
+		"Stale": {
+			reason: "Validation records should not be up to date when a published TXT record is no longer needed",
+			args: args{
+				zoneID:    "zone",
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{{Zone: "zone", ID: "1234", Name: txt.TXTName, Value: txt.TXTValue}},
+			},
+			want: want{upToDate: false},
+		},
+		"StaleNoZone": {
+			reason: "Validation records should not be up to date when records are published but no zone is set",
+			args: args{
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{{Zone: "zone", ID: "1234", Name: txt.TXTName, Value: txt.TXTValue}},
+				vrs:       []v1alpha1.CustomHostnameSSLValidationRecord{txt},
+			},
+			want: want{upToDate: false},
+		},
+		"StaleOtherZone": {
+			reason: "Validation records should not be up to date when records are published on another zone",
+			args: args{
+				zoneID:    "zone",
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{{Zone: "other", ID: "1234", Name: txt.TXTName, Value: txt.TXTValue}},
+				vrs:       []v1alpha1.CustomHostnameSSLValidationRecord{txt},
+			},
+			want: want{upToDate: false},
+		},
+		"ErrLookup": {
+			reason: "An error should be returned if the existing records cannot be looked up",
+			args: args{
+				zoneID:  "zone",
+				vrs:     []v1alpha1.CustomHostnameSSLValidationRecord{txt},
+				pending: true,
+				err:     errBoom,
+			},
+			want: want{err: errBoom},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := fake.MockClient{
+				MockDNSRecords: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+					return tc.args.existing, tc.args.err
+				},
+			}
+
+			got, err := ValidationRecordsUpToDate(context.Background(), client, tc.args.zoneID, tc.args.published, tc.args.vrs, tc.args.pending)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidationRecordsUpToDate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.upToDate, got); diff != "" {
+				t.Errorf("\n%s\nValidationRecordsUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCollectValidationRecords(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	published := v1alpha1.CustomHostnameValidationDNSRecord{Zone: "zone", ID: "1234", Name: "_acme-challenge." + hostname, Value: "abc"}
+
+	type args struct {
+		zoneID    string
+		published []v1alpha1.CustomHostnameValidationDNSRecord
+		tagged    string
+		err       error
+	}
+
+	type want struct {
+		published []v1alpha1.CustomHostnameValidationDNSRecord
+		endpoint  string
+		err       error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoZone": {
+			reason: "CollectValidationRecords should not look up records when no zone is set",
+			args: args{
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{published},
+			},
+			want: want{
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{published},
+			},
+		},
+		"Tagged": {
+			reason: "CollectValidationRecords should add records found by their tag that status does not list",
+			args: args{
+				zoneID:    "zone",
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{published},
+				tagged:    `[{"id":"1234","type":"TXT","name":"_acme-challenge.myhostname.com","content":"abc"},{"id":"5678","type":"TXT","name":"_acme-challenge.myhostname.com","content":"def"}]`,
+			},
+			want: want{
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{
+					published,
+					{Zone: "zone", ID: "5678", Name: "_acme-challenge." + hostname, Value: "def"},
+				},
+				endpoint: "/zones/zone/dns_records?comment=crossplane%3A+custom+hostname+1234beef+validation&per_page=100&type=TXT",
+			},
+		},
+		"LegacyZone": {
+			reason: "CollectValidationRecords should record the zone of published records that do not know it",
+			args: args{
+				zoneID:    "zone",
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{{ID: "1234", Name: published.Name, Value: published.Value}},
+				tagged:    `[]`,
+			},
+			want: want{
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{published},
+				endpoint:  "/zones/zone/dns_records?comment=crossplane%3A+custom+hostname+1234beef+validation&per_page=100&type=TXT",
+			},
+		},
+		"ErrLookup": {
+			reason: "CollectValidationRecords should return an error, and the published records, if records cannot be looked up",
+			args: args{
+				zoneID:    "zone",
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{published},
+				err:       errBoom,
+			},
+			want: want{
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{published},
+				endpoint:  "/zones/zone/dns_records?comment=crossplane%3A+custom+hostname+1234beef+validation&per_page=100&type=TXT",
+				err:       errBoom,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			endpoint := ""
+			client := fake.MockClient{
+				MockRawContext: func(_ context.Context, method, e string, data interface{}) (json.RawMessage, error) {
+					endpoint = e
+					return json.RawMessage(tc.args.tagged), tc.args.err
+				},
+			}
+
+			got, err := CollectValidationRecords(context.Background(), client, tc.args.zoneID, "1234beef", tc.args.published)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCollectValidationRecords(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.published, got); diff != "" {
+				t.Errorf("\n%s\nCollectValidationRecords(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.endpoint, endpoint); diff != "" {
+				t.Errorf("\n%s\nCollectValidationRecords(...): -want endpoint, +got endpoint:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPublishValidationRecords(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	txt := v1alpha1.CustomHostnameSSLValidationRecord{TXTName: "_acme-challenge." + hostname, TXTValue: "abc"}
+	httpRecord := v1alpha1.CustomHostnameSSLValidationRecord{HTTPURL: "http://" + hostname + "/.well-known/pki-validation/ca3-abc.txt", HTTPBody: "ca3-def"}
+
+	current := v1alpha1.CustomHostnameValidationDNSRecord{Zone: "zone", ID: "1234", Name: txt.TXTName, Value: txt.TXTValue}
+	stale := v1alpha1.CustomHostnameValidationDNSRecord{Zone: "zone", ID: "5678", Name: txt.TXTName, Value: "old"}
+
+	type args struct {
+		zoneID    string
+		published []v1alpha1.CustomHostnameValidationDNSRecord
+		vrs       []v1alpha1.CustomHostnameSSLValidationRecord
+		tagged    string
+		existing  []cloudflare.DNSRecord
+		err       error
+		deleteErr error
+	}
+
+	type want struct {
+		published []v1alpha1.CustomHostnameValidationDNSRecord
+		created   []dnsRecord
+		deleted   []string
+		err       error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"CreateMissing": {
+			reason: "PublishValidationRecords should create tagged TXT validation records that do not exist, and return their IDs",
+			args: args{
+				zoneID: "zone",
+				vrs:    []v1alpha1.CustomHostnameSSLValidationRecord{txt, httpRecord},
+			},
+			want: want{
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{{Zone: "zone", ID: "new", Name: txt.TXTName, Value: txt.TXTValue}},
+				created: []dnsRecord{
+					{Type: "TXT", Name: txt.TXTName, Content: txt.TXTValue, TTL: 1, Comment: "crossplane: custom hostname 1234beef validation"},
+				},
+			},
+		},
+		"ReplaceMissing": {
+			reason: "PublishValidationRecords should replace a published TXT record that no longer exists",
+			args: args{
+				zoneID:    "zone",
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{current},
+				vrs:       []v1alpha1.CustomHostnameSSLValidationRecord{txt},
+			},
+			want: want{
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{{Zone: "zone", ID: "new", Name: txt.TXTName, Value: txt.TXTValue}},
+				created: []dnsRecord{
+					{Type: "TXT", Name: txt.TXTName, Content: txt.TXTValue, TTL: 1, Comment: "crossplane: custom hostname 1234beef validation"},
+				},
+			},
+		},
+		"SkipExisting": {
+			reason: "PublishValidationRecords should not create TXT validation records that already exist",
+			args: args{
+				zoneID:    "zone",
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{current},
+				vrs:       []v1alpha1.CustomHostnameSSLValidationRecord{txt},
+				existing:  []cloudflare.DNSRecord{{ID: "1234"}},
+			},
+			want: want{
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{current},
+			},
+		},
+		"AdoptTagged": {
+			reason: "PublishValidationRecords should track TXT records found by their tag when status has lost them",
+			args: args{
+				zoneID:   "zone",
+				vrs:      []v1alpha1.CustomHostnameSSLValidationRecord{txt},
+				tagged:   `[{"id":"1234","type":"TXT","name":"_acme-challenge.myhostname.com","content":"abc"}]`,
+				existing: []cloudflare.DNSRecord{{ID: "1234"}},
+			},
+			want: want{
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{current},
+			},
+		},
+		"DeleteStale": {
+			reason: "PublishValidationRecords should delete published TXT records that are no longer needed",
+			args: args{
+				zoneID:    "zone",
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{current, stale},
+				vrs:       []v1alpha1.CustomHostnameSSLValidationRecord{txt},
+				existing:  []cloudflare.DNSRecord{{ID: "1234"}},
+			},
+			want: want{
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{current},
+				deleted:   []string{"5678"},
+			},
+		},
+		"DeleteAllOnceActive": {
+			reason: "PublishValidationRecords should delete every published TXT record once there are no validation records",
+			args: args{
+				zoneID:    "zone",
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{current},
+			},
+			want: want{
+				deleted: []string{"1234"},
+			},
+		},
+		"DeleteAllNoZone": {
+			reason: "PublishValidationRecords should delete every published TXT record once no zone is set",
+			args: args{
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{current},
+				vrs:       []v1alpha1.CustomHostnameSSLValidationRecord{txt},
+			},
+			want: want{
+				deleted: []string{"1234"},
+			},
+		},
+		"ErrDelete": {
+			reason: "PublishValidationRecords should keep the published TXT records it could not delete",
+			args: args{
+				zoneID:    "zone",
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{stale},
+				deleteErr: errBoom,
+			},
+			want: want{
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{stale},
+				deleted:   []string{"5678"},
+				err:       errBoom,
+			},
+		},
+		"ErrLookup": {
+			reason: "PublishValidationRecords should return an error, and keep every published TXT record, if the existing records cannot be looked up",
+			args: args{
+				zoneID:    "zone",
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{stale},
+				vrs:       []v1alpha1.CustomHostnameSSLValidationRecord{txt},
+				err:       errBoom,
+			},
+			want: want{
+				published: []v1alpha1.CustomHostnameValidationDNSRecord{stale},
+				err:       errBoom,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var created []dnsRecord
+			var deleted []string
+			client := fake.MockClient{
+				MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+					if method == http.MethodGet {
+						if tc.args.tagged == "" {
+							return json.RawMessage(`[]`), nil
+						}
+						return json.RawMessage(tc.args.tagged), nil
+					}
+					rr := data.(dnsRecord)
+					created = append(created, rr)
+					rr.ID = "new"
+					b, err := json.Marshal(rr)
+					return json.RawMessage(b), err
+				},
+				MockDNSRecords: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+					return tc.args.existing, tc.args.err
+				},
+				MockDeleteDNSRecord: func(ctx context.Context, zoneID, recordID string) error {
+					deleted = append(deleted, recordID)
+					return tc.args.deleteErr
+				},
+			}
+
+			got, err := PublishValidationRecords(context.Background(), client, tc.args.zoneID, "1234beef", tc.args.published, tc.args.vrs)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nPublishValidationRecords(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.published, got); diff != "" {
+				t.Errorf("\n%s\nPublishValidationRecords(...): -want published, +got published:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.created, created); diff != "" {
+				t.Errorf("\n%s\nPublishValidationRecords(...): -want created, +got created:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("\n%s\nPublishValidationRecords(...): -want deleted, +got deleted:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDeleteValidationRecords(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	published := []v1alpha1.CustomHostnameValidationDNSRecord{
+		{Zone: "zone", ID: "1234", Name: "_acme-challenge." + hostname, Value: "abc"},
+		{Zone: "other", ID: "5678", Name: "_acme-challenge." + hostname, Value: "def"},
+	}
+
+	type want struct {
+		remaining []v1alpha1.CustomHostnameValidationDNSRecord
+		deleted   []string
+		err       error
+	}
+
+	cases := map[string]struct {
+		reason string
+		errs   map[string]error
+		want   want
+	}{
+		"Success": {
+			reason: "DeleteValidationRecords should delete each published TXT record by ID from the zone it was published on",
+			want: want{
+				deleted: []string{"zone/1234", "other/5678"},
+			},
+		},
+		"NotFound": {
+			reason: "DeleteValidationRecords should ignore published TXT records that were already deleted",
+			errs:   map[string]error{"1234": errors.New("HTTP status 404: not found")},
+			want: want{
+				deleted: []string{"zone/1234", "other/5678"},
+			},
+		},
+		"ErrDelete": {
+			reason: "DeleteValidationRecords should return the published TXT records it could not delete",
+			errs:   map[string]error{"5678": errBoom},
+			want: want{
+				remaining: published[1:],
+				deleted:   []string{"zone/1234", "other/5678"},
+				err:       errBoom,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var deleted []string
+			client := fake.MockClient{
+				MockDeleteDNSRecord: func(ctx context.Context, zoneID, recordID string) error {
+					deleted = append(deleted, zoneID+"/"+recordID)
+					return tc.errs[recordID]
+				},
+			}
+
+			got, err := DeleteValidationRecords(context.Background(), client, published)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDeleteValidationRecords(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.remaining, got); diff != "" {
+				t.Errorf("\n%s\nDeleteValidationRecords(...): -want remaining, +got remaining:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("\n%s\nDeleteValidationRecords(...): -want deleted, +got deleted:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWithRateLimiter(t *testing.T) {
+	type call struct {
+		zoneID  string
+		limited bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		calls  []call
+	}{
+		"BurstAllowed": {
+			reason: "Calls within the burst should not be limited",
+			calls: []call{
+				{zoneID: "zone-a"},
+				{zoneID: "zone-a"},
+			},
+		},
+		"SameZoneLimited": {
+			reason: "Calls beyond the burst to the same zone should be limited",
+			calls: []call{
+				{zoneID: "zone-a"},
+				{zoneID: "zone-a"},
+				{zoneID: "zone-a", limited: true},
+			},
+		},
+		"OtherZoneNotLimited": {
+			reason: "Calls to a zone should not be limited by calls made to another zone",
+			calls: []call{
+				{zoneID: "zone-a"},
+				{zoneID: "zone-a"},
+				{zoneID: "zone-b"},
+				{zoneID: "zone-b"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			made := 0
+			c := WithRateLimiter(fake.MockClient{
+				MockCustomHostname: func(ctx context.Context, zoneID string, customHostnameID string) (cloudflare.CustomHostname, error) {
+					made++
+					return cloudflare.CustomHostname{}, nil
+				},
+			}, NewZoneRateLimiter(0.1, 2))
+
+			want := 0
+			for i, call := range tc.calls {
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+				_, err := c.CustomHostname(ctx, call.zoneID, "1234beef")
+				cancel()
+
+				if limited := err != nil; limited != call.limited {
+					t.Errorf("\n%s\nCustomHostname(...) call %d: want limited %t, got error: %v\n", tc.reason, i, call.limited, err)
+				}
+				if !call.limited {
+					want++
+				}
+			}
+
+			if diff := cmp.Diff(want, made); diff != "" {
+				t.Errorf("\n%s\nCustomHostname(...) calls made: -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWithRateLimiterZoneCalls(t *testing.T) {
+	mc := fake.MockClient{
+		MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+			return json.RawMessage(`{}`), nil
+		},
+		MockDNSRecords: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+			return nil, nil
+		},
+		MockDeleteDNSRecord: func(ctx context.Context, zoneID, recordID string) error {
+			return nil
+		},
+	}
+
+	cases := map[string]struct {
+		reason string
+		call   func(ctx context.Context, c Client) error
+	}{
+		"RawContext": {
+			reason: "Raw calls to a zone should be limited",
+			call: func(ctx context.Context, c Client) error {
+				_, err := c.RawContext(ctx, http.MethodGet, "/zones/zone-a/custom_hostnames/1234beef", nil)
+				return err
+			},
+		},
+		"DNSRecords": {
+			reason: "DNSRecords calls to a zone should be limited",
+			call: func(ctx context.Context, c Client) error {
+				_, err := c.DNSRecords(ctx, "zone-a", cloudflare.DNSRecord{})
+				return err
+			},
+		},
+		"DeleteDNSRecord": {
+			reason: "DeleteDNSRecord calls to a zone should be limited",
+			call: func(ctx context.Context, c Client) error {
+				return c.DeleteDNSRecord(ctx, "zone-a", "1234beef")
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			l := NewZoneRateLimiter(0.1, 1)
+			if err := tc.call(context.Background(), WithRateLimiter(mc, l)); err != nil {
+				t.Fatalf("\n%s\nunexpected error: %v\n", tc.reason, err)
+			}
+
+			// The call should have used the only call in the zone's burst.
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			if err := l.Wait(ctx, "zone-a"); err == nil {
+				t.Errorf("\n%s\nWait(...): want the zone to be limited, got no error\n", tc.reason)
+			}
+		})
+	}
+}
+
+func TestZoneRateLimiterPrune(t *testing.T) {
+	start := time.Now()
+
+	cases := map[string]struct {
+		reason  string
+		elapsed time.Duration
+		want    []string
+	}{
+		"Recent": {
+			reason:  "Limiters of zones that were called recently should be remembered",
+			elapsed: 5 * time.Second,
+			want:    []string{"zone-a", "zone-b"},
+		},
+		"Idle": {
+			reason:  "Limiters of zones that have been idle long enough to refill their burst should be forgotten",
+			elapsed: 11 * time.Second,
+			want:    []string{"zone-b"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// A burst of 1 at 0.1 calls per second refills in 10 seconds.
+			l := NewZoneRateLimiter(0.1, 1)
+			now := start
+			l.now = func() time.Time { return now }
+
+			if err := l.Wait(context.Background(), "zone-a"); err != nil {
+				t.Fatalf("\n%s\nWait(...): unexpected error: %v\n", tc.reason, err)
+			}
+
+			now = start.Add(tc.elapsed)
+			if err := l.Wait(context.Background(), "zone-b"); err != nil {
+				t.Fatalf("\n%s\nWait(...): unexpected error: %v\n", tc.reason, err)
+			}
+
+			got := make([]string, 0, len(l.limiters))
+			for id := range l.limiters {
+				got = append(got, id)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("\n%s\nWait(...): -want zones, +got zones:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestZoneOf(t *testing.T) {
+	cases := map[string]struct {
+		endpoint string
+		want     string
+	}{
+		"Zone":         {endpoint: "/zones/1234beef/custom_hostnames/5678cafe", want: "1234beef"},
+		"ZoneNoSlash":  {endpoint: "zones/1234beef", want: "1234beef"},
+		"NotZone":      {endpoint: "/accounts/1234beef/workers/scripts", want: ""},
+		"ZonesListing": {endpoint: "/zones", want: ""},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, zoneOf(tc.endpoint)); diff != "" {
+				t.Errorf("zoneOf(%q): -want, +got:\n%s", tc.endpoint, diff)
+			}
+		})
+	}
+}
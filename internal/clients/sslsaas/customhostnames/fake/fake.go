@@ -18,6 +18,7 @@ package fake
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/cloudflare/cloudflare-go"
 )
@@ -29,6 +30,9 @@ type MockClient struct {
 	MockDeleteCustomHostname    func(ctx context.Context, zoneID string, customHostnameID string) error
 	MockCreateCustomHostname    func(ctx context.Context, zoneID string, ch cloudflare.CustomHostname) (*cloudflare.CustomHostnameResponse, error)
 	MockCustomHostname          func(ctx context.Context, zoneID string, customHostnameID string) (cloudflare.CustomHostname, error)
+	MockRawContext              func(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error)
+	MockDNSRecords              func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error)
+	MockDeleteDNSRecord         func(ctx context.Context, zoneID, recordID string) error
 }
 
 // UpdateCustomHostnameSSL mocks the UpdateCustomHostnameSSL method of the Cloudflare API.
@@ -55,3 +59,18 @@ func (m MockClient) CreateCustomHostname(ctx context.Context, zoneID string, ch
 func (m MockClient) CustomHostname(ctx context.Context, zoneID string, customHostnameID string) (cloudflare.CustomHostname, error) {
 	return m.MockCustomHostname(ctx, zoneID, customHostnameID)
 }
+
+// RawContext mocks the RawContext method of the Cloudflare API.
+func (m MockClient) RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+	return m.MockRawContext(ctx, method, endpoint, data)
+}
+
+// DNSRecords mocks the DNSRecords method of the Cloudflare API.
+func (m MockClient) DNSRecords(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+	return m.MockDNSRecords(ctx, zoneID, rr)
+}
+
+// DeleteDNSRecord mocks the DeleteDNSRecord method of the Cloudflare API.
+func (m MockClient) DeleteDNSRecord(ctx context.Context, zoneID, recordID string) error {
+	return m.MockDeleteDNSRecord(ctx, zoneID, recordID)
+}
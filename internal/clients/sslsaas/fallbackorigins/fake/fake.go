@@ -27,6 +27,7 @@ type MockClient struct {
 	MockUpdateCustomHostnameFallbackOrigin func(ctx context.Context, zoneID string, chfo cloudflare.CustomHostnameFallbackOrigin) (*cloudflare.CustomHostnameFallbackOriginResponse, error)
 	MockDeleteCustomHostnameFallbackOrigin func(ctx context.Context, zoneID string) error
 	MockCustomHostnameFallbackOrigin       func(ctx context.Context, zoneID string) (cloudflare.CustomHostnameFallbackOrigin, error)
+	MockDNSRecords                         func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error)
 }
 
 // UpdateCustomHostnameFallbackOrigin mocks the UpdateCustomHostnameFallbackOrigin method of the Cloudflare API.
@@ -43,3 +44,8 @@ func (m MockClient) DeleteCustomHostnameFallbackOrigin(ctx context.Context, zone
 func (m MockClient) CustomHostnameFallbackOrigin(ctx context.Context, zoneID string) (cloudflare.CustomHostnameFallbackOrigin, error) {
 	return m.MockCustomHostnameFallbackOrigin(ctx, zoneID)
 }
+
+// DNSRecords mocks the DNSRecords method of the Cloudflare API.
+func (m MockClient) DNSRecords(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+	return m.MockDNSRecords(ctx, zoneID, rr)
+}
@@ -19,7 +19,6 @@ package fallbackorigins
 import (
 	"context"
 	"net/http"
-	"strings"
 
 	"github.com/cloudflare/cloudflare-go"
 	"github.com/pkg/errors"
@@ -30,7 +29,7 @@ import (
 
 const (
 	// Cloudflare returns this code when a fallback origin isnt found
-	errFallbackOriginNotFound = "1551"
+	errFallbackOriginNotFound = 1551
 )
 
 // ErrNotFound is an error type so that we can return it in the controller test mocks
@@ -44,6 +43,7 @@ type Client interface {
 	UpdateCustomHostnameFallbackOrigin(ctx context.Context, zoneID string, chfo cloudflare.CustomHostnameFallbackOrigin) (*cloudflare.CustomHostnameFallbackOriginResponse, error)
 	DeleteCustomHostnameFallbackOrigin(ctx context.Context, zoneID string) error
 	CustomHostnameFallbackOrigin(ctx context.Context, zoneID string) (cloudflare.CustomHostnameFallbackOrigin, error)
+	DNSRecords(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error)
 }
 
 // NewClient returns a new Cloudflare API client for working with Fallback Origins.
@@ -62,8 +62,7 @@ func IsFallbackOriginNotFound(err error) bool {
 	}
 
 	// The actual Cloudflare API indicates a "not found" with this error code.
-	errStr := err.Error()
-	return strings.Contains(errStr, errFallbackOriginNotFound)
+	return clients.IsNotFound(err) || clients.HasErrorCode(err, errFallbackOriginNotFound)
 }
 
 // GenerateObservation creates an observation of a cloudflare Fallback Origin
@@ -93,6 +92,30 @@ func UpToDate(spec *v1alpha1.FallbackOriginParameters, o cloudflare.CustomHostna
 	return true
 }
 
+// originRecordTypes are the types of DNS Record that a Fallback Origin
+// may point to.
+var originRecordTypes = map[string]bool{
+	"A":     true,
+	"AAAA":  true,
+	"CNAME": true,
+}
+
+// OriginResolves returns true if the passed origin is the name of a
+// proxied A, AAAA or CNAME record on the passed zone, which Cloudflare
+// requires of a Fallback Origin.
+func OriginResolves(ctx context.Context, client Client, zoneID, origin string) (bool, error) {
+	rrs, err := client.DNSRecords(ctx, zoneID, cloudflare.DNSRecord{Name: origin})
+	if err != nil {
+		return false, err
+	}
+	for _, rr := range rrs {
+		if originRecordTypes[rr.Type] && rr.Proxied != nil && *rr.Proxied {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // UpdateFallbackOrigin updates mutable values on a Fallback Origin
 func UpdateFallbackOrigin(ctx context.Context, client Client, spec *v1alpha1.FallbackOriginParameters) error {
 
@@ -17,13 +17,18 @@ limitations under the License.
 package fallbackorigins
 
 import (
+	"context"
 	"testing"
 
 	"github.com/cloudflare/cloudflare-go"
+	"github.com/pkg/errors"
 
 	"github.com/google/go-cmp/cmp"
 
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
 	"github.com/benagricola/provider-cloudflare/apis/sslsaas/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/sslsaas/fallbackorigins/fake"
 
 	ptr "k8s.io/utils/pointer"
 )
@@ -103,3 +108,84 @@ func TestUpToDate(t *testing.T) {
 		})
 	}
 }
+
+func TestOriginResolves(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		rrs []cloudflare.DNSRecord
+		err error
+	}
+
+	type want struct {
+		o   bool
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoRecord": {
+			reason: "OriginResolves should return false if there is no record for the origin",
+			args:   args{},
+			want: want{
+				o: false,
+			},
+		},
+		"NotProxied": {
+			reason: "OriginResolves should return false if the record for the origin is not proxied",
+			args: args{
+				rrs: []cloudflare.DNSRecord{{Type: "A", Name: origin, Proxied: ptr.BoolPtr(false)}},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"WrongType": {
+			reason: "OriginResolves should return false if the record for the origin cannot be proxied",
+			args: args{
+				rrs: []cloudflare.DNSRecord{{Type: "TXT", Name: origin, Proxied: ptr.BoolPtr(true)}},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"Proxied": {
+			reason: "OriginResolves should return true if the origin is a proxied record",
+			args: args{
+				rrs: []cloudflare.DNSRecord{{Type: "AAAA", Name: origin, Proxied: ptr.BoolPtr(true)}},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"ErrLookup": {
+			reason: "OriginResolves should return any error looking up the records",
+			args: args{
+				err: errBoom,
+			},
+			want: want{
+				err: errBoom,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := fake.MockClient{
+				MockDNSRecords: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+					return tc.args.rrs, tc.args.err
+				},
+			}
+			got, err := OriginResolves(context.Background(), client, "zone", origin)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nOriginResolves(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nOriginResolves(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
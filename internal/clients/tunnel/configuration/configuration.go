@@ -0,0 +1,191 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/benagricola/provider-cloudflare/apis/tunnel/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+const (
+	errNoAccount = "account ID is required"
+	errNoTunnel  = "tunnel ID is required"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with Tunnel Configurations. The cloudflare-go library does not support
+// Tunnel Configurations, so they are managed using raw API requests.
+type Client interface {
+	RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error)
+}
+
+// NewClient returns a new Cloudflare API client for working with Tunnel
+// Configurations.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// Config is the configuration of a Tunnel as represented by the Cloudflare
+// API. The ingress rule and origin request fields of the API match those
+// of the managed resource, so those types are reused here.
+type Config struct {
+	Ingress       []v1alpha1.TunnelIngressRule  `json:"ingress,omitempty"`
+	OriginRequest *v1alpha1.TunnelOriginRequest `json:"originRequest,omitempty"`
+	WarpRouting   *WarpRouting                  `json:"warp-routing,omitempty"`
+}
+
+// WarpRouting configures routing of private network traffic from WARP
+// clients through a Tunnel.
+type WarpRouting struct {
+	Enabled bool `json:"enabled"`
+}
+
+// Configuration is a versioned Tunnel configuration as represented by the
+// Cloudflare API.
+type Configuration struct {
+	Version int64   `json:"version,omitempty"`
+	Config  *Config `json:"config"`
+}
+
+// IsTunnelConfigurationNotFound returns true if the passed error indicates
+// a Tunnel Configuration was not found.
+func IsTunnelConfigurationNotFound(err error) bool {
+	return clients.IsNotFound(err)
+}
+
+// Exists returns true if the passed Tunnel Configuration configures any
+// ingress rules. Tunnels that are not remotely managed, or whose
+// configuration has been deleted, have no ingress rules.
+func Exists(c *Configuration) bool {
+	return c != nil && c.Config != nil && len(c.Config.Ingress) > 0
+}
+
+func endpoint(accountID, tunnelID string) string {
+	return "/accounts/" + accountID + "/cfd_tunnel/" + tunnelID + "/configurations"
+}
+
+// ParseConfiguration returns a Tunnel Configuration from a raw Tunnel
+// Configuration response.
+func ParseConfiguration(raw json.RawMessage) (*Configuration, error) {
+	c := &Configuration{}
+	if err := json.Unmarshal(raw, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// TunnelConfiguration returns the configuration of the given Tunnel.
+func TunnelConfiguration(ctx context.Context, client Client, accountID, tunnelID string) (*Configuration, error) {
+	raw, err := client.RawContext(ctx, http.MethodGet, endpoint(accountID, tunnelID), nil)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfiguration(raw)
+}
+
+// GenerateObservation creates an observation of a Tunnel Configuration.
+func GenerateObservation(in Configuration) v1alpha1.TunnelConfigurationObservation {
+	return v1alpha1.TunnelConfigurationObservation{
+		Version: in.Version,
+	}
+}
+
+// ParametersToConfig returns a Tunnel configuration representing the
+// passed parameters.
+func ParametersToConfig(in v1alpha1.TunnelConfigurationParameters) Config {
+	c := Config{
+		Ingress:       in.Ingress,
+		OriginRequest: in.OriginRequest,
+	}
+	if in.WarpRouting != nil {
+		c.WarpRouting = &WarpRouting{Enabled: *in.WarpRouting}
+	}
+	return c
+}
+
+// normalizeOriginRequest returns nil for origin request settings that do
+// not set anything, as Cloudflare returns an empty object for these.
+func normalizeOriginRequest(in *v1alpha1.TunnelOriginRequest) *v1alpha1.TunnelOriginRequest {
+	if in == nil || cmp.Equal(*in, v1alpha1.TunnelOriginRequest{}) {
+		return nil
+	}
+	return in
+}
+
+// UpToDate checks if the remote Tunnel Configuration is up to date with
+// the requested resource parameters.
+func UpToDate(spec *v1alpha1.TunnelConfigurationParameters, o Configuration) bool {
+	if spec == nil {
+		return true
+	}
+
+	c := Config{}
+	if o.Config != nil {
+		c = *o.Config
+	}
+
+	if len(spec.Ingress) != len(c.Ingress) {
+		return false
+	}
+	for i := range spec.Ingress {
+		want, got := spec.Ingress[i], c.Ingress[i]
+		want.OriginRequest = normalizeOriginRequest(want.OriginRequest)
+		got.OriginRequest = normalizeOriginRequest(got.OriginRequest)
+		if !cmp.Equal(want, got) {
+			return false
+		}
+	}
+
+	if !cmp.Equal(normalizeOriginRequest(spec.OriginRequest), normalizeOriginRequest(c.OriginRequest)) {
+		return false
+	}
+
+	if spec.WarpRouting != nil && *spec.WarpRouting != (c.WarpRouting != nil && c.WarpRouting.Enabled) {
+		return false
+	}
+
+	return true
+}
+
+// UpdateTunnelConfiguration replaces the configuration of a Tunnel with
+// the requested resource parameters.
+func UpdateTunnelConfiguration(ctx context.Context, client Client, spec v1alpha1.TunnelConfigurationParameters) error {
+	if spec.Account == nil {
+		return errors.New(errNoAccount)
+	}
+	if spec.Tunnel == nil {
+		return errors.New(errNoTunnel)
+	}
+
+	c := ParametersToConfig(spec)
+	_, err := client.RawContext(ctx, http.MethodPut, endpoint(*spec.Account, *spec.Tunnel), Configuration{Config: &c})
+	return err
+}
+
+// DeleteTunnelConfiguration removes the configuration of a Tunnel by
+// replacing it with an empty configuration.
+func DeleteTunnelConfiguration(ctx context.Context, client Client, accountID, tunnelID string) error {
+	_, err := client.RawContext(ctx, http.MethodPut, endpoint(accountID, tunnelID), Configuration{Config: &Config{}})
+	return err
+}
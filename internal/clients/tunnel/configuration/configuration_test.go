@@ -0,0 +1,271 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	ptr "k8s.io/utils/pointer"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/tunnel/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/tunnel/configuration/fake"
+)
+
+func TestParseConfiguration(t *testing.T) {
+	type want struct {
+		c      *Configuration
+		exists bool
+		err    bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		raw    string
+		want   want
+	}{
+		"Configuration": {
+			reason: "A remotely managed configuration should be parsed",
+			raw: `{"tunnel_id":"f174e90a","version":3,"config":{` +
+				`"ingress":[{"hostname":"app.example.com","service":"http://localhost:8080","originRequest":{"noTLSVerify":true}},{"service":"http_status:404"}],` +
+				`"originRequest":{"connectTimeout":10},"warp-routing":{"enabled":true}}}`,
+			want: want{
+				c: &Configuration{
+					Version: 3,
+					Config: &Config{
+						Ingress: []v1alpha1.TunnelIngressRule{
+							{
+								Hostname:      ptr.StringPtr("app.example.com"),
+								Service:       "http://localhost:8080",
+								OriginRequest: &v1alpha1.TunnelOriginRequest{NoTLSVerify: ptr.BoolPtr(true)},
+							},
+							{Service: "http_status:404"},
+						},
+						OriginRequest: &v1alpha1.TunnelOriginRequest{ConnectTimeout: ptr.Int64Ptr(10)},
+						WarpRouting:   &WarpRouting{Enabled: true},
+					},
+				},
+				exists: true,
+			},
+		},
+		"NoConfiguration": {
+			reason: "A tunnel that is not remotely managed should have no configuration",
+			raw:    `{"tunnel_id":"f174e90a","version":0,"config":null}`,
+			want: want{
+				c: &Configuration{},
+			},
+		},
+		"Invalid": {
+			reason: "An error should be returned for an invalid response",
+			raw:    `{`,
+			want: want{
+				err: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseConfiguration([]byte(tc.raw))
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nParseConfiguration(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.c, got); diff != "" {
+				t.Errorf("\n%s\nParseConfiguration(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.exists, Exists(got)); diff != "" {
+				t.Errorf("\n%s\nExists(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpToDate(t *testing.T) {
+	ingress := []v1alpha1.TunnelIngressRule{
+		{Hostname: ptr.StringPtr("app.example.com"), Service: "http://localhost:8080"},
+		{Service: "http_status:404"},
+	}
+
+	cases := map[string]struct {
+		reason string
+		spec   *v1alpha1.TunnelConfigurationParameters
+		o      Configuration
+		want   bool
+	}{
+		"NilSpec": {
+			reason: "A nil spec should always be up to date",
+			want:   true,
+		},
+		"UpToDate": {
+			reason: "A configuration matching the spec should be up to date",
+			spec: &v1alpha1.TunnelConfigurationParameters{
+				Ingress:     ingress,
+				WarpRouting: ptr.BoolPtr(true),
+			},
+			o: Configuration{Config: &Config{
+				Ingress:     ingress,
+				WarpRouting: &WarpRouting{Enabled: true},
+			}},
+			want: true,
+		},
+		"EmptyOriginRequest": {
+			reason: "Empty origin request settings returned by Cloudflare should equal unset settings",
+			spec:   &v1alpha1.TunnelConfigurationParameters{Ingress: ingress},
+			o: Configuration{Config: &Config{
+				Ingress: []v1alpha1.TunnelIngressRule{
+					{Hostname: ptr.StringPtr("app.example.com"), Service: "http://localhost:8080", OriginRequest: &v1alpha1.TunnelOriginRequest{}},
+					{Service: "http_status:404", OriginRequest: &v1alpha1.TunnelOriginRequest{}},
+				},
+				OriginRequest: &v1alpha1.TunnelOriginRequest{},
+			}},
+			want: true,
+		},
+		"IngressChanged": {
+			reason: "A configuration with different ingress rules should not be up to date",
+			spec:   &v1alpha1.TunnelConfigurationParameters{Ingress: ingress},
+			o: Configuration{Config: &Config{
+				Ingress: []v1alpha1.TunnelIngressRule{{Service: "http_status:404"}},
+			}},
+			want: false,
+		},
+		"OriginRequestChanged": {
+			reason: "A configuration with different origin request settings should not be up to date",
+			spec: &v1alpha1.TunnelConfigurationParameters{
+				Ingress:       ingress,
+				OriginRequest: &v1alpha1.TunnelOriginRequest{ConnectTimeout: ptr.Int64Ptr(30)},
+			},
+			o: Configuration{Config: &Config{
+				Ingress:       ingress,
+				OriginRequest: &v1alpha1.TunnelOriginRequest{ConnectTimeout: ptr.Int64Ptr(10)},
+			}},
+			want: false,
+		},
+		"WarpRoutingChanged": {
+			reason: "A configuration with WARP routing disabled should not be up to date if it is enabled in the spec",
+			spec: &v1alpha1.TunnelConfigurationParameters{
+				Ingress:     ingress,
+				WarpRouting: ptr.BoolPtr(true),
+			},
+			o:    Configuration{Config: &Config{Ingress: ingress}},
+			want: false,
+		},
+		"NoConfig": {
+			reason: "A tunnel without configuration should not be up to date",
+			spec:   &v1alpha1.TunnelConfigurationParameters{Ingress: ingress},
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := UpToDate(tc.spec, tc.o)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdateTunnelConfiguration(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		err  error
+		body string
+	}
+
+	cases := map[string]struct {
+		reason string
+		spec   v1alpha1.TunnelConfigurationParameters
+		err    error
+		want   want
+	}{
+		"NoAccount": {
+			reason: "An error should be returned if no account is set",
+			spec:   v1alpha1.TunnelConfigurationParameters{Tunnel: ptr.StringPtr("f174e90a")},
+			want:   want{err: errors.New(errNoAccount)},
+		},
+		"NoTunnel": {
+			reason: "An error should be returned if no tunnel is set",
+			spec:   v1alpha1.TunnelConfigurationParameters{Account: ptr.StringPtr("372e6795")},
+			want:   want{err: errors.New(errNoTunnel)},
+		},
+		"UpdateFailed": {
+			reason: "Errors updating the configuration should be returned",
+			spec: v1alpha1.TunnelConfigurationParameters{
+				Account: ptr.StringPtr("372e6795"),
+				Tunnel:  ptr.StringPtr("f174e90a"),
+				Ingress: []v1alpha1.TunnelIngressRule{{Service: "http_status:404"}},
+			},
+			err: errBoom,
+			want: want{
+				err:  errBoom,
+				body: `{"config":{"ingress":[{"service":"http_status:404"}]}}`,
+			},
+		},
+		"Updated": {
+			reason: "The configuration should be sent in the format expected by cloudflared",
+			spec: v1alpha1.TunnelConfigurationParameters{
+				Account: ptr.StringPtr("372e6795"),
+				Tunnel:  ptr.StringPtr("f174e90a"),
+				Ingress: []v1alpha1.TunnelIngressRule{
+					{Hostname: ptr.StringPtr("app.example.com"), Service: "https://localhost:8443", OriginRequest: &v1alpha1.TunnelOriginRequest{NoTLSVerify: ptr.BoolPtr(true)}},
+					{Service: "http_status:404"},
+				},
+				OriginRequest: &v1alpha1.TunnelOriginRequest{ConnectTimeout: ptr.Int64Ptr(10)},
+				WarpRouting:   ptr.BoolPtr(false),
+			},
+			want: want{
+				body: `{"config":{"ingress":[{"hostname":"app.example.com","service":"https://localhost:8443","originRequest":{"noTLSVerify":true}},{"service":"http_status:404"}],` +
+					`"originRequest":{"connectTimeout":10},"warp-routing":{"enabled":false}}}`,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var body string
+			client := fake.MockClient{
+				MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+					if method != http.MethodPut || endpoint != "/accounts/372e6795/cfd_tunnel/f174e90a/configurations" {
+						t.Errorf("\n%s\nUpdateTunnelConfiguration(...): unexpected request %s %s\n", tc.reason, method, endpoint)
+					}
+					b, _ := json.Marshal(data)
+					body = string(b)
+					return nil, tc.err
+				},
+			}
+
+			err := UpdateTunnelConfiguration(context.Background(), client, tc.spec)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdateTunnelConfiguration(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.body, body); diff != "" {
+				t.Errorf("\n%s\nUpdateTunnelConfiguration(...): -want request body, +got request body:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/benagricola/provider-cloudflare/apis/tunnel/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+const (
+	errNoAccount = "account ID is required"
+	errNoTunnel  = "tunnel ID is required"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with Tunnel Routes. The cloudflare-go library does not support Tunnel
+// Routes, so they are managed using raw API requests.
+type Client interface {
+	RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error)
+}
+
+// NewClient returns a new Cloudflare API client for working with Tunnel
+// Routes.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// Route is a Tunnel Route as represented by the Cloudflare API.
+type Route struct {
+	ID               string  `json:"id,omitempty"`
+	Network          string  `json:"network,omitempty"`
+	TunnelID         string  `json:"tunnel_id,omitempty"`
+	Comment          *string `json:"comment,omitempty"`
+	VirtualNetworkID *string `json:"virtual_network_id,omitempty"`
+	DeletedAt        *string `json:"deleted_at,omitempty"`
+}
+
+// IsTunnelRouteNotFound returns true if the passed error indicates
+// a Tunnel Route was not found.
+func IsTunnelRouteNotFound(err error) bool {
+	return clients.IsNotFound(err)
+}
+
+func endpoint(accountID string) string {
+	return "/accounts/" + accountID + "/teamnet/routes"
+}
+
+// ParseRoute returns a Tunnel Route from a raw Tunnel Route response.
+func ParseRoute(raw json.RawMessage) (*Route, error) {
+	r := &Route{}
+	if err := json.Unmarshal(raw, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// TunnelRoute returns the Tunnel Route with the given ID.
+func TunnelRoute(ctx context.Context, client Client, accountID, routeID string) (*Route, error) {
+	raw, err := client.RawContext(ctx, http.MethodGet, endpoint(accountID)+"/"+routeID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRoute(raw)
+}
+
+// ParametersToRoute returns a Tunnel Route representing the passed
+// parameters.
+func ParametersToRoute(in v1alpha1.TunnelRouteParameters) Route {
+	r := Route{
+		Network:          in.Network,
+		Comment:          in.Comment,
+		VirtualNetworkID: in.VirtualNetworkID,
+	}
+	if in.Tunnel != nil {
+		r.TunnelID = *in.Tunnel
+	}
+	return r
+}
+
+// UpToDate checks if the remote Tunnel Route is up to date with the
+// requested resource parameters.
+func UpToDate(spec *v1alpha1.TunnelRouteParameters, r Route) bool {
+	if spec == nil {
+		return true
+	}
+
+	if spec.Network != r.Network {
+		return false
+	}
+
+	if spec.Tunnel != nil && *spec.Tunnel != r.TunnelID {
+		return false
+	}
+
+	if spec.Comment != nil && (r.Comment == nil || *spec.Comment != *r.Comment) {
+		return false
+	}
+
+	return true
+}
+
+// CreateTunnelRoute creates a new Tunnel Route.
+func CreateTunnelRoute(ctx context.Context, client Client, spec v1alpha1.TunnelRouteParameters) (*Route, error) {
+	if spec.Account == nil {
+		return nil, errors.New(errNoAccount)
+	}
+	if spec.Tunnel == nil {
+		return nil, errors.New(errNoTunnel)
+	}
+
+	raw, err := client.RawContext(ctx, http.MethodPost, endpoint(*spec.Account), ParametersToRoute(spec))
+	if err != nil {
+		return nil, err
+	}
+	return ParseRoute(raw)
+}
+
+// UpdateTunnelRoute updates the mutable fields of a Tunnel Route.
+func UpdateTunnelRoute(ctx context.Context, client Client, routeID string, spec v1alpha1.TunnelRouteParameters) error {
+	if spec.Account == nil {
+		return errors.New(errNoAccount)
+	}
+	if spec.Tunnel == nil {
+		return errors.New(errNoTunnel)
+	}
+
+	r := ParametersToRoute(spec)
+	// The virtual network of a route cannot be changed.
+	r.VirtualNetworkID = nil
+
+	_, err := client.RawContext(ctx, http.MethodPatch, endpoint(*spec.Account)+"/"+routeID, r)
+	return err
+}
+
+// DeleteTunnelRoute deletes the Tunnel Route with the given ID.
+func DeleteTunnelRoute(ctx context.Context, client Client, accountID, routeID string) error {
+	_, err := client.RawContext(ctx, http.MethodDelete, endpoint(accountID)+"/"+routeID, nil)
+	return err
+}
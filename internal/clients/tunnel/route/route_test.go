@@ -0,0 +1,211 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	ptr "k8s.io/utils/pointer"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/tunnel/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/tunnel/route/fake"
+)
+
+func TestUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		spec   *v1alpha1.TunnelRouteParameters
+		r      Route
+		want   bool
+	}{
+		"NilSpec": {
+			reason: "A nil spec should always be up to date",
+			r:      Route{Network: "10.0.0.0/8"},
+			want:   true,
+		},
+		"UpToDate": {
+			reason: "A route matching the spec should be up to date",
+			spec: &v1alpha1.TunnelRouteParameters{
+				Tunnel:  ptr.StringPtr("f174e90a"),
+				Network: "10.0.0.0/8",
+				Comment: ptr.StringPtr("office"),
+			},
+			r:    Route{TunnelID: "f174e90a", Network: "10.0.0.0/8", Comment: ptr.StringPtr("office")},
+			want: true,
+		},
+		"NetworkChanged": {
+			reason: "A route with a different network should not be up to date",
+			spec:   &v1alpha1.TunnelRouteParameters{Tunnel: ptr.StringPtr("f174e90a"), Network: "10.0.0.0/16"},
+			r:      Route{TunnelID: "f174e90a", Network: "10.0.0.0/8"},
+			want:   false,
+		},
+		"TunnelChanged": {
+			reason: "A route through a different tunnel should not be up to date",
+			spec:   &v1alpha1.TunnelRouteParameters{Tunnel: ptr.StringPtr("a0ed4fc8"), Network: "10.0.0.0/8"},
+			r:      Route{TunnelID: "f174e90a", Network: "10.0.0.0/8"},
+			want:   false,
+		},
+		"CommentChanged": {
+			reason: "A route with a different comment should not be up to date",
+			spec:   &v1alpha1.TunnelRouteParameters{Network: "10.0.0.0/8", Comment: ptr.StringPtr("office")},
+			r:      Route{Network: "10.0.0.0/8"},
+			want:   false,
+		},
+		"CommentNotManaged": {
+			reason: "A comment that is not set in the spec should not be compared",
+			spec:   &v1alpha1.TunnelRouteParameters{Network: "10.0.0.0/8"},
+			r:      Route{Network: "10.0.0.0/8", Comment: ptr.StringPtr("office")},
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := UpToDate(tc.spec, tc.r)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreateTunnelRoute(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		r    *Route
+		err  error
+		body string
+	}
+
+	cases := map[string]struct {
+		reason string
+		spec   v1alpha1.TunnelRouteParameters
+		err    error
+		want   want
+	}{
+		"NoAccount": {
+			reason: "An error should be returned if no account is set",
+			spec:   v1alpha1.TunnelRouteParameters{Tunnel: ptr.StringPtr("f174e90a"), Network: "10.0.0.0/8"},
+			want:   want{err: errors.New(errNoAccount)},
+		},
+		"NoTunnel": {
+			reason: "An error should be returned if no tunnel is set",
+			spec:   v1alpha1.TunnelRouteParameters{Account: ptr.StringPtr("372e6795"), Network: "10.0.0.0/8"},
+			want:   want{err: errors.New(errNoTunnel)},
+		},
+		"CreateFailed": {
+			reason: "Errors creating the route should be returned",
+			spec: v1alpha1.TunnelRouteParameters{
+				Account: ptr.StringPtr("372e6795"),
+				Tunnel:  ptr.StringPtr("f174e90a"),
+				Network: "10.0.0.0/8",
+			},
+			err: errBoom,
+			want: want{
+				err:  errBoom,
+				body: `{"network":"10.0.0.0/8","tunnel_id":"f174e90a"}`,
+			},
+		},
+		"Created": {
+			reason: "The created route should be returned",
+			spec: v1alpha1.TunnelRouteParameters{
+				Account:          ptr.StringPtr("372e6795"),
+				Tunnel:           ptr.StringPtr("f174e90a"),
+				Network:          "10.0.0.0/8",
+				Comment:          ptr.StringPtr("office"),
+				VirtualNetworkID: ptr.StringPtr("b2b1f1c4"),
+			},
+			want: want{
+				r: &Route{
+					ID:               "e6a0a5a5",
+					TunnelID:         "f174e90a",
+					Network:          "10.0.0.0/8",
+					Comment:          ptr.StringPtr("office"),
+					VirtualNetworkID: ptr.StringPtr("b2b1f1c4"),
+				},
+				body: `{"network":"10.0.0.0/8","tunnel_id":"f174e90a","comment":"office","virtual_network_id":"b2b1f1c4"}`,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var body string
+			client := fake.MockClient{
+				MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+					if method != http.MethodPost || endpoint != "/accounts/372e6795/teamnet/routes" {
+						t.Errorf("\n%s\nCreateTunnelRoute(...): unexpected request %s %s\n", tc.reason, method, endpoint)
+					}
+					b, _ := json.Marshal(data)
+					body = string(b)
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return json.RawMessage(`{"id":"e6a0a5a5","tunnel_id":"f174e90a","network":"10.0.0.0/8","comment":"office","virtual_network_id":"b2b1f1c4"}`), nil
+				},
+			}
+
+			got, err := CreateTunnelRoute(context.Background(), client, tc.spec)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreateTunnelRoute(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.r, got); diff != "" {
+				t.Errorf("\n%s\nCreateTunnelRoute(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.body, body); diff != "" {
+				t.Errorf("\n%s\nCreateTunnelRoute(...): -want request body, +got request body:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdateTunnelRoute(t *testing.T) {
+	var method, endpoint, body string
+	client := fake.MockClient{
+		MockRawContext: func(_ context.Context, m, e string, data interface{}) (json.RawMessage, error) {
+			method, endpoint = m, e
+			b, _ := json.Marshal(data)
+			body = string(b)
+			return nil, nil
+		},
+	}
+
+	err := UpdateTunnelRoute(context.Background(), client, "e6a0a5a5", v1alpha1.TunnelRouteParameters{
+		Account:          ptr.StringPtr("372e6795"),
+		Tunnel:           ptr.StringPtr("f174e90a"),
+		Network:          "10.0.0.0/8",
+		VirtualNetworkID: ptr.StringPtr("b2b1f1c4"),
+	})
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("UpdateTunnelRoute(...): -want error, +got error:\n%s\n", diff)
+	}
+	if diff := cmp.Diff(http.MethodPatch+" /accounts/372e6795/teamnet/routes/e6a0a5a5", method+" "+endpoint); diff != "" {
+		t.Errorf("UpdateTunnelRoute(...): -want request, +got request:\n%s\n", diff)
+	}
+	// The virtual network cannot be changed, so should not be sent.
+	if diff := cmp.Diff(`{"network":"10.0.0.0/8","tunnel_id":"f174e90a"}`, body); diff != "" {
+		t.Errorf("UpdateTunnelRoute(...): -want request body, +got request body:\n%s\n", diff)
+	}
+}
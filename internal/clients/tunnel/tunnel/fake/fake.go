@@ -0,0 +1,51 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// A MockClient acts as a testable representation of the Cloudflare API.
+type MockClient struct {
+	MockArgoTunnel                   func(ctx context.Context, accountID, tunnelUUID string) (cloudflare.ArgoTunnel, error)
+	MockCreateArgoTunnel             func(ctx context.Context, accountID, name, secret string) (cloudflare.ArgoTunnel, error)
+	MockCleanupArgoTunnelConnections func(ctx context.Context, accountID, tunnelUUID string) error
+	MockDeleteArgoTunnel             func(ctx context.Context, accountID, tunnelUUID string) error
+}
+
+// ArgoTunnel mocks the ArgoTunnel method of the Cloudflare API.
+func (m MockClient) ArgoTunnel(ctx context.Context, accountID, tunnelUUID string) (cloudflare.ArgoTunnel, error) {
+	return m.MockArgoTunnel(ctx, accountID, tunnelUUID)
+}
+
+// CreateArgoTunnel mocks the CreateArgoTunnel method of the Cloudflare API.
+func (m MockClient) CreateArgoTunnel(ctx context.Context, accountID, name, secret string) (cloudflare.ArgoTunnel, error) {
+	return m.MockCreateArgoTunnel(ctx, accountID, name, secret)
+}
+
+// CleanupArgoTunnelConnections mocks the CleanupArgoTunnelConnections method of the Cloudflare API.
+func (m MockClient) CleanupArgoTunnelConnections(ctx context.Context, accountID, tunnelUUID string) error {
+	return m.MockCleanupArgoTunnelConnections(ctx, accountID, tunnelUUID)
+}
+
+// DeleteArgoTunnel mocks the DeleteArgoTunnel method of the Cloudflare API.
+func (m MockClient) DeleteArgoTunnel(ctx context.Context, accountID, tunnelUUID string) error {
+	return m.MockDeleteArgoTunnel(ctx, accountID, tunnelUUID)
+}
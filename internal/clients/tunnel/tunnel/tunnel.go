@@ -0,0 +1,166 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/benagricola/provider-cloudflare/apis/tunnel/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+const (
+	// ConnectionKeyCredentials is the connection secret key holding a
+	// cloudflared credentials file for the Tunnel.
+	ConnectionKeyCredentials = "credentials.json"
+
+	// ConnectionKeyToken is the connection secret key holding a token
+	// that can be passed to cloudflared with --token.
+	ConnectionKeyToken = "token"
+
+	// ConnectionKeyTunnelID is the connection secret key holding the ID
+	// of the Tunnel.
+	ConnectionKeyTunnelID = "tunnelID"
+
+	// cloudflared requires a tunnel secret of at least 32 bytes.
+	secretLength = 32
+
+	errGenerateSecret = "error generating tunnel secret"
+	errCreateTunnel   = "error creating tunnel"
+	errConnDetails    = "error generating tunnel connection details"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with Tunnels.
+type Client interface {
+	ArgoTunnel(ctx context.Context, accountID, tunnelUUID string) (cloudflare.ArgoTunnel, error)
+	CreateArgoTunnel(ctx context.Context, accountID, name, secret string) (cloudflare.ArgoTunnel, error)
+	CleanupArgoTunnelConnections(ctx context.Context, accountID, tunnelUUID string) error
+	DeleteArgoTunnel(ctx context.Context, accountID, tunnelUUID string) error
+}
+
+// NewClient returns a new Cloudflare API client for working with Tunnels.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// IsTunnelNotFound returns true if the passed error indicates
+// a Tunnel was not found.
+func IsTunnelNotFound(err error) bool {
+	return clients.IsNotFound(err)
+}
+
+// GenerateObservation creates an observation of a Cloudflare Tunnel.
+func GenerateObservation(in cloudflare.ArgoTunnel) v1alpha1.TunnelObservation {
+	o := v1alpha1.TunnelObservation{
+		ID: in.ID,
+	}
+
+	for _, c := range in.Connections {
+		o.Connections = append(o.Connections, v1alpha1.TunnelConnection{
+			ColoName:           c.ColoName,
+			UUID:               c.UUID,
+			IsPendingReconnect: c.IsPendingReconnect,
+		})
+	}
+
+	return o
+}
+
+// credentials is the format of the credentials file read by cloudflared.
+type credentials struct {
+	AccountTag   string `json:"AccountTag"`
+	TunnelSecret string `json:"TunnelSecret"`
+	TunnelID     string `json:"TunnelID"`
+	TunnelName   string `json:"TunnelName,omitempty"`
+}
+
+// token is the format of the token accepted by cloudflared, before it is
+// base64 encoded.
+type token struct {
+	AccountTag   string `json:"a"`
+	TunnelID     string `json:"t"`
+	TunnelSecret string `json:"s"`
+}
+
+// ConnectionDetails returns the connection details cloudflared needs to
+// run the given Tunnel using the given base64 encoded secret.
+func ConnectionDetails(accountID, secret string, t cloudflare.ArgoTunnel) (managed.ConnectionDetails, error) {
+	c, err := json.Marshal(credentials{
+		AccountTag:   accountID,
+		TunnelSecret: secret,
+		TunnelID:     t.ID,
+		TunnelName:   t.Name,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, errConnDetails)
+	}
+
+	tk, err := json.Marshal(token{
+		AccountTag:   accountID,
+		TunnelID:     t.ID,
+		TunnelSecret: secret,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, errConnDetails)
+	}
+
+	return managed.ConnectionDetails{
+		ConnectionKeyCredentials: c,
+		ConnectionKeyToken:       []byte(base64.StdEncoding.EncodeToString(tk)),
+		ConnectionKeyTunnelID:    []byte(t.ID),
+	}, nil
+}
+
+// generateSecret returns a random base64 encoded tunnel secret.
+func generateSecret() (string, error) {
+	b := make([]byte, secretLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, errGenerateSecret)
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// CreateTunnel creates a new Tunnel with a randomly generated secret, and
+// returns the Tunnel along with its connection details.
+func CreateTunnel(ctx context.Context, client Client, accountID, name string) (*cloudflare.ArgoTunnel, managed.ConnectionDetails, error) {
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	t, err := client.CreateArgoTunnel(ctx, accountID, name, secret)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errCreateTunnel)
+	}
+
+	cd, err := ConnectionDetails(accountID, secret, t)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &t, cd, nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/internal/clients/tunnel/tunnel/fake"
+)
+
+func TestConnectionDetails(t *testing.T) {
+	tunnel := cloudflare.ArgoTunnel{
+		ID:   "f174e90a-fafe-4643-bbbc-4a0ed4fc8415",
+		Name: "example",
+	}
+
+	want := managed.ConnectionDetails{
+		ConnectionKeyCredentials: []byte(`{"AccountTag":"372e67954025e0ba6aaa6d586b9e0b59","TunnelSecret":"c2VjcmV0","TunnelID":"f174e90a-fafe-4643-bbbc-4a0ed4fc8415","TunnelName":"example"}`),
+		ConnectionKeyToken: []byte(base64.StdEncoding.EncodeToString(
+			[]byte(`{"a":"372e67954025e0ba6aaa6d586b9e0b59","t":"f174e90a-fafe-4643-bbbc-4a0ed4fc8415","s":"c2VjcmV0"}`),
+		)),
+		ConnectionKeyTunnelID: []byte("f174e90a-fafe-4643-bbbc-4a0ed4fc8415"),
+	}
+
+	got, err := ConnectionDetails("372e67954025e0ba6aaa6d586b9e0b59", "c2VjcmV0", tunnel)
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("ConnectionDetails(...): -want error, +got error:\n%s\n", diff)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ConnectionDetails(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestCreateTunnel(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		client Client
+	}
+
+	type want struct {
+		id  string
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"CreateError": {
+			reason: "CreateTunnel should return any error creating the Tunnel",
+			args: args{
+				client: fake.MockClient{
+					MockCreateArgoTunnel: func(ctx context.Context, accountID, name, secret string) (cloudflare.ArgoTunnel, error) {
+						return cloudflare.ArgoTunnel{}, errBoom
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errCreateTunnel),
+			},
+		},
+		"Success": {
+			reason: "CreateTunnel should return the Tunnel and connection details using the generated secret",
+			args: args{
+				client: fake.MockClient{
+					MockCreateArgoTunnel: func(ctx context.Context, accountID, name, secret string) (cloudflare.ArgoTunnel, error) {
+						if s, err := base64.StdEncoding.DecodeString(secret); err != nil || len(s) != secretLength {
+							return cloudflare.ArgoTunnel{}, errBoom
+						}
+						return cloudflare.ArgoTunnel{ID: "f174e90a-fafe-4643-bbbc-4a0ed4fc8415", Name: name, Secret: secret}, nil
+					},
+				},
+			},
+			want: want{
+				id: "f174e90a-fafe-4643-bbbc-4a0ed4fc8415",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, cd, err := CreateTunnel(context.Background(), tc.args.client, "372e67954025e0ba6aaa6d586b9e0b59", "example")
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreateTunnel(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if got == nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.id, got.ID); diff != "" {
+				t.Errorf("\n%s\nCreateTunnel(...): -want ID, +got ID:\n%s\n", tc.reason, diff)
+			}
+
+			// The secret in the credentials must be the one the Tunnel was created with.
+			c := credentials{}
+			if err := json.Unmarshal(cd[ConnectionKeyCredentials], &c); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(got.Secret, c.TunnelSecret); diff != "" {
+				t.Errorf("\n%s\nCreateTunnel(...): -want secret, +got secret:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
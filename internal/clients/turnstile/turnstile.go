@@ -0,0 +1,194 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package turnstile
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/benagricola/provider-cloudflare/apis/turnstile/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+const (
+	// ConnectionKeySitekey is the connection secret key holding the
+	// sitekey used to embed the Turnstile widget in a page.
+	ConnectionKeySitekey = "sitekey"
+
+	// ConnectionKeySecret is the connection secret key holding the
+	// secret used to validate Turnstile responses.
+	ConnectionKeySecret = "secret"
+
+	// regionWorld is the region Cloudflare uses when none is requested.
+	regionWorld = "world"
+
+	errCreateWidget = "error creating turnstile widget"
+	errUpdateWidget = "error updating turnstile widget"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with Turnstile widgets. Widgets are managed using raw API requests, as
+// the cloudflare-go library does not support them.
+type Client interface {
+	RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error)
+}
+
+// NewClient returns a new Cloudflare API client for working with
+// Turnstile widgets.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// Widget is a Turnstile widget as represented by the Cloudflare API.
+type Widget struct {
+	Sitekey      string     `json:"sitekey,omitempty"`
+	Secret       string     `json:"secret,omitempty"`
+	Name         string     `json:"name"`
+	Domains      []string   `json:"domains"`
+	Mode         string     `json:"mode"`
+	BotFightMode bool       `json:"bot_fight_mode"`
+	Region       string     `json:"region,omitempty"`
+	CreatedOn    *time.Time `json:"created_on,omitempty"`
+	ModifiedOn   *time.Time `json:"modified_on,omitempty"`
+}
+
+// IsWidgetNotFound returns true if the passed error indicates a Turnstile
+// widget was not found.
+func IsWidgetNotFound(err error) bool {
+	return clients.IsNotFound(err)
+}
+
+func endpoint(accountID string) string {
+	return "/accounts/" + accountID + "/challenges/widgets"
+}
+
+func parseWidget(raw json.RawMessage) (*Widget, error) {
+	w := &Widget{}
+	if err := json.Unmarshal(raw, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// GetWidget returns the Turnstile widget with the given sitekey.
+func GetWidget(ctx context.Context, client Client, accountID, sitekey string) (*Widget, error) {
+	raw, err := client.RawContext(ctx, http.MethodGet, endpoint(accountID)+"/"+sitekey, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parseWidget(raw)
+}
+
+// GenerateObservation creates an observation of a Turnstile widget.
+func GenerateObservation(in Widget) v1alpha1.TurnstileWidgetObservation {
+	o := v1alpha1.TurnstileWidgetObservation{
+		Sitekey: in.Sitekey,
+	}
+	if in.CreatedOn != nil {
+		t := metav1.NewTime(*in.CreatedOn)
+		o.CreatedOn = &t
+	}
+	if in.ModifiedOn != nil {
+		t := metav1.NewTime(*in.ModifiedOn)
+		o.ModifiedOn = &t
+	}
+	return o
+}
+
+// ConnectionDetails returns the connection details applications need to
+// embed the given Turnstile widget and validate its responses.
+func ConnectionDetails(w Widget) managed.ConnectionDetails {
+	cd := managed.ConnectionDetails{
+		ConnectionKeySitekey: []byte(w.Sitekey),
+	}
+	if w.Secret != "" {
+		cd[ConnectionKeySecret] = []byte(w.Secret)
+	}
+	return cd
+}
+
+// NewWidget returns a Turnstile widget as represented by the Cloudflare
+// API, from the requested resource parameters. The Cloudflare API requires
+// every field to be set, so defaults are used for those that are not.
+func NewWidget(spec v1alpha1.TurnstileWidgetParameters) Widget {
+	w := Widget{
+		Name:    spec.Name,
+		Domains: spec.Domains,
+		Mode:    v1alpha1.ModeManaged,
+		Region:  regionWorld,
+	}
+	if spec.Mode != nil {
+		w.Mode = *spec.Mode
+	}
+	if spec.BotFightMode != nil {
+		w.BotFightMode = *spec.BotFightMode
+	}
+	if spec.Region != nil {
+		w.Region = *spec.Region
+	}
+	return w
+}
+
+// sortStrings compares slices of strings regardless of their order.
+var sortStrings = cmpopts.SortSlices(func(a, b string) bool { return a < b })
+
+// UpToDate checks if the remote Turnstile widget is up to date with the
+// requested resource parameters.
+func UpToDate(spec *v1alpha1.TurnstileWidgetParameters, w Widget) bool {
+	if spec == nil {
+		return true
+	}
+
+	desired := NewWidget(*spec)
+
+	return desired.Name == w.Name &&
+		desired.Mode == w.Mode &&
+		desired.BotFightMode == w.BotFightMode &&
+		(w.Region == "" || desired.Region == w.Region) &&
+		cmp.Equal(desired.Domains, w.Domains, cmpopts.EquateEmpty(), sortStrings)
+}
+
+// CreateWidget creates a new Turnstile widget. The secret of the widget is
+// included in the returned Widget.
+func CreateWidget(ctx context.Context, client Client, accountID string, spec v1alpha1.TurnstileWidgetParameters) (*Widget, error) {
+	raw, err := client.RawContext(ctx, http.MethodPost, endpoint(accountID), NewWidget(spec))
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateWidget)
+	}
+	return parseWidget(raw)
+}
+
+// UpdateWidget replaces the Turnstile widget with the given sitekey.
+func UpdateWidget(ctx context.Context, client Client, accountID, sitekey string, spec v1alpha1.TurnstileWidgetParameters) error {
+	_, err := client.RawContext(ctx, http.MethodPut, endpoint(accountID)+"/"+sitekey, NewWidget(spec))
+	return errors.Wrap(err, errUpdateWidget)
+}
+
+// DeleteWidget deletes the Turnstile widget with the given sitekey.
+func DeleteWidget(ctx context.Context, client Client, accountID, sitekey string) error {
+	_, err := client.RawContext(ctx, http.MethodDelete, endpoint(accountID)+"/"+sitekey, nil)
+	return err
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package turnstile
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	ptr "k8s.io/utils/pointer"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/turnstile/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/turnstile/fake"
+)
+
+const (
+	accountID = "023e105f4ecef8ad9ca31a8372d0c353"
+	sitekey   = "0x4AAF00AAAABn0R22HWm-YUc"
+)
+
+func widgetSpec() v1alpha1.TurnstileWidgetParameters {
+	return v1alpha1.TurnstileWidgetParameters{
+		Name:    "login",
+		Domains: []string{"example.com", "www.example.com"},
+		Mode:    ptr.StringPtr(v1alpha1.ModeInvisible),
+	}
+}
+
+// observed returns a Turnstile widget as returned by Cloudflare for the
+// parameters returned by widgetSpec, including its defaults.
+func observed(m ...func(*Widget)) Widget {
+	w := Widget{
+		Sitekey: sitekey,
+		Secret:  "0x4AAF00AAAABn0R22HWm098HVBjhdsYUc",
+		Name:    "login",
+		Domains: []string{"www.example.com", "example.com"},
+		Mode:    v1alpha1.ModeInvisible,
+		Region:  "world",
+	}
+	for _, f := range m {
+		f(&w)
+	}
+	return w
+}
+
+func TestConnectionDetails(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		w      Widget
+		want   managed.ConnectionDetails
+	}{
+		"WithSecret": {
+			reason: "The sitekey and secret of a widget should be returned",
+			w:      observed(),
+			want: managed.ConnectionDetails{
+				ConnectionKeySitekey: []byte(sitekey),
+				ConnectionKeySecret:  []byte("0x4AAF00AAAABn0R22HWm098HVBjhdsYUc"),
+			},
+		},
+		"WithoutSecret": {
+			reason: "A missing secret should not overwrite a previously published secret",
+			w:      observed(func(w *Widget) { w.Secret = "" }),
+			want: managed.ConnectionDetails{
+				ConnectionKeySitekey: []byte(sitekey),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ConnectionDetails(tc.w)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nConnectionDetails(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		spec   *v1alpha1.TurnstileWidgetParameters
+		w      Widget
+		want   bool
+	}{
+		"NilSpec": {
+			reason: "A nil spec should always be up to date",
+			w:      observed(),
+			want:   true,
+		},
+		"UpToDate": {
+			reason: "Defaults set by Cloudflare and the order of domains should be ignored",
+			spec: func() *v1alpha1.TurnstileWidgetParameters {
+				s := widgetSpec()
+				return &s
+			}(),
+			w:    observed(),
+			want: true,
+		},
+		"DomainsChanged": {
+			reason: "A widget allowed on different domains should not be up to date",
+			spec: func() *v1alpha1.TurnstileWidgetParameters {
+				s := widgetSpec()
+				s.Domains = []string{"example.com"}
+				return &s
+			}(),
+			w:    observed(),
+			want: false,
+		},
+		"ModeDefaulted": {
+			reason: "A widget should be reverted to managed mode when no mode is requested",
+			spec: func() *v1alpha1.TurnstileWidgetParameters {
+				s := widgetSpec()
+				s.Mode = nil
+				return &s
+			}(),
+			w:    observed(),
+			want: false,
+		},
+		"BotFightModeChanged": {
+			reason: "A widget with a different bot fight mode should not be up to date",
+			spec: func() *v1alpha1.TurnstileWidgetParameters {
+				s := widgetSpec()
+				s.BotFightMode = ptr.BoolPtr(true)
+				return &s
+			}(),
+			w:    observed(),
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := UpToDate(tc.spec, tc.w)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreateWidget(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	desired := Widget{
+		Name:    "login",
+		Domains: []string{"example.com", "www.example.com"},
+		Mode:    v1alpha1.ModeInvisible,
+		Region:  "world",
+	}
+
+	type want struct {
+		w    *Widget
+		err  error
+		data interface{}
+	}
+
+	cases := map[string]struct {
+		reason string
+		raw    json.RawMessage
+		err    error
+		want   want
+	}{
+		"CreateFailed": {
+			reason: "Errors creating the widget should be wrapped",
+			err:    errBoom,
+			want: want{
+				err:  errors.Wrap(errBoom, errCreateWidget),
+				data: desired,
+			},
+		},
+		"Success": {
+			reason: "The created widget should be returned including its secret",
+			raw:    json.RawMessage(`{"sitekey":"0x4AAF00AAAABn0R22HWm-YUc","secret":"0x4AAF00AAAABn0R22HWm098HVBjhdsYUc","name":"login","domains":["example.com","www.example.com"],"mode":"invisible","bot_fight_mode":false,"region":"world"}`),
+			want: want{
+				w: &Widget{
+					Sitekey: sitekey,
+					Secret:  "0x4AAF00AAAABn0R22HWm098HVBjhdsYUc",
+					Name:    "login",
+					Domains: []string{"example.com", "www.example.com"},
+					Mode:    v1alpha1.ModeInvisible,
+					Region:  "world",
+				},
+				data: desired,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var data interface{}
+			client := fake.MockClient{
+				MockRawContext: func(_ context.Context, m, e string, d interface{}) (json.RawMessage, error) {
+					if m != http.MethodPost || e != "/accounts/"+accountID+"/challenges/widgets" {
+						t.Errorf("\n%s\nCreateWidget(...): unexpected request %s %s", tc.reason, m, e)
+					}
+					data = d
+					return tc.raw, tc.err
+				},
+			}
+			got, err := CreateWidget(context.Background(), client, accountID, widgetSpec())
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreateWidget(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.w, got); diff != "" {
+				t.Errorf("\n%s\nCreateWidget(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.data, data); diff != "" {
+				t.Errorf("\n%s\nCreateWidget(...): -want data, +got data:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// A MockClient acts as a testable representation of the Cloudflare API.
+type MockClient struct {
+	MockWaitingRoom       func(ctx context.Context, zoneID, waitingRoomID string) (cloudflare.WaitingRoom, error)
+	MockCreateWaitingRoom func(ctx context.Context, zoneID string, waitingRoom cloudflare.WaitingRoom) (*cloudflare.WaitingRoom, error)
+	MockUpdateWaitingRoom func(ctx context.Context, zoneID string, waitingRoom cloudflare.WaitingRoom) (cloudflare.WaitingRoom, error)
+	MockDeleteWaitingRoom func(ctx context.Context, zoneID, waitingRoomID string) error
+	MockRawContext        func(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error)
+}
+
+// WaitingRoom mocks the WaitingRoom method of the Cloudflare API.
+func (m MockClient) WaitingRoom(ctx context.Context, zoneID, waitingRoomID string) (cloudflare.WaitingRoom, error) {
+	return m.MockWaitingRoom(ctx, zoneID, waitingRoomID)
+}
+
+// CreateWaitingRoom mocks the CreateWaitingRoom method of the Cloudflare API.
+func (m MockClient) CreateWaitingRoom(ctx context.Context, zoneID string, waitingRoom cloudflare.WaitingRoom) (*cloudflare.WaitingRoom, error) {
+	return m.MockCreateWaitingRoom(ctx, zoneID, waitingRoom)
+}
+
+// UpdateWaitingRoom mocks the UpdateWaitingRoom method of the Cloudflare API.
+func (m MockClient) UpdateWaitingRoom(ctx context.Context, zoneID string, waitingRoom cloudflare.WaitingRoom) (cloudflare.WaitingRoom, error) {
+	return m.MockUpdateWaitingRoom(ctx, zoneID, waitingRoom)
+}
+
+// DeleteWaitingRoom mocks the DeleteWaitingRoom method of the Cloudflare API.
+func (m MockClient) DeleteWaitingRoom(ctx context.Context, zoneID, waitingRoomID string) error {
+	return m.MockDeleteWaitingRoom(ctx, zoneID, waitingRoomID)
+}
+
+// RawContext mocks the RawContext method of the Cloudflare API.
+func (m MockClient) RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+	return m.MockRawContext(ctx, method, endpoint, data)
+}
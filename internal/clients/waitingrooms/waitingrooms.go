@@ -0,0 +1,221 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package waitingrooms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/benagricola/provider-cloudflare/apis/waitingrooms/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+const (
+	errCreateWaitingRoom = "error creating waiting room"
+	errUpdateWaitingRoom = "error updating waiting room"
+	errSetQueueingMethod = "error setting waiting room queueing method"
+)
+
+// Defaults of Cloudflare for the fields that are always sent, as the
+// cloudflare-go library does not omit them when they are not set.
+const (
+	defaultPath            = "/"
+	defaultSessionDuration = 5
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with Waiting Rooms. The cloudflare-go library does not support the
+// queueing method of Waiting Rooms, so only the queueing method is read
+// and set using raw API requests.
+type Client interface {
+	WaitingRoom(ctx context.Context, zoneID, waitingRoomID string) (cloudflare.WaitingRoom, error)
+	CreateWaitingRoom(ctx context.Context, zoneID string, waitingRoom cloudflare.WaitingRoom) (*cloudflare.WaitingRoom, error)
+	UpdateWaitingRoom(ctx context.Context, zoneID string, waitingRoom cloudflare.WaitingRoom) (cloudflare.WaitingRoom, error)
+	DeleteWaitingRoom(ctx context.Context, zoneID, waitingRoomID string) error
+	RawContext(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error)
+}
+
+// NewClient returns a new Cloudflare API client for working with Waiting
+// Rooms.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// queueingMethod is the queueing method of a Waiting Room as represented
+// by the Cloudflare API.
+type queueingMethod struct {
+	QueueingMethod string `json:"queueing_method"`
+}
+
+// IsWaitingRoomNotFound returns true if the passed error indicates
+// a Waiting Room was not found.
+func IsWaitingRoomNotFound(err error) bool {
+	return clients.IsNotFound(err)
+}
+
+func endpoint(zoneID, waitingRoomID string) string {
+	return "/zones/" + zoneID + "/waiting_rooms/" + waitingRoomID
+}
+
+// GetWaitingRoom returns the Waiting Room with the given ID. When
+// withQueueingMethod is true the Waiting Room is fetched using a raw API
+// request, so that its queueing method can be returned too. Otherwise
+// the returned queueing method is empty.
+func GetWaitingRoom(ctx context.Context, client Client, zoneID, waitingRoomID string, withQueueingMethod bool) (cloudflare.WaitingRoom, string, error) {
+	if !withQueueingMethod {
+		wr, err := client.WaitingRoom(ctx, zoneID, waitingRoomID)
+		return wr, "", err
+	}
+
+	raw, err := client.RawContext(ctx, http.MethodGet, endpoint(zoneID, waitingRoomID), nil)
+	if err != nil {
+		return cloudflare.WaitingRoom{}, "", err
+	}
+	wr := cloudflare.WaitingRoom{}
+	if err := json.Unmarshal(raw, &wr); err != nil {
+		return cloudflare.WaitingRoom{}, "", err
+	}
+	qm := queueingMethod{}
+	if err := json.Unmarshal(raw, &qm); err != nil {
+		return cloudflare.WaitingRoom{}, "", err
+	}
+	return wr, qm.QueueingMethod, nil
+}
+
+// SetQueueingMethod sets the queueing method of the Waiting Room with
+// the given ID.
+func SetQueueingMethod(ctx context.Context, client Client, zoneID, waitingRoomID, method string) error {
+	_, err := client.RawContext(ctx, http.MethodPatch, endpoint(zoneID, waitingRoomID), queueingMethod{QueueingMethod: method})
+	return errors.Wrap(err, errSetQueueingMethod)
+}
+
+// GenerateObservation creates an observation of a Waiting Room.
+func GenerateObservation(in cloudflare.WaitingRoom) v1alpha1.WaitingRoomObservation {
+	o := v1alpha1.WaitingRoomObservation{}
+	if !in.CreatedOn.IsZero() {
+		t := metav1.NewTime(in.CreatedOn)
+		o.CreatedOn = &t
+	}
+	if !in.ModifiedOn.IsZero() {
+		t := metav1.NewTime(in.ModifiedOn)
+		o.ModifiedOn = &t
+	}
+	return o
+}
+
+// NewWaitingRoom returns a Waiting Room as represented by the Cloudflare
+// API, from the requested resource parameters. Cloudflare uses its own
+// defaults for fields that are not set. The queueing method is not part
+// of the returned Waiting Room, and must be set using SetQueueingMethod.
+func NewWaitingRoom(spec v1alpha1.WaitingRoomParameters) cloudflare.WaitingRoom {
+	wr := cloudflare.WaitingRoom{
+		Name:              spec.Name,
+		Host:              spec.Host,
+		Path:              defaultPath,
+		TotalActiveUsers:  spec.TotalActiveUsers,
+		NewUsersPerMinute: spec.NewUsersPerMinute,
+		SessionDuration:   defaultSessionDuration,
+	}
+	if spec.Description != nil {
+		wr.Description = *spec.Description
+	}
+	if spec.Path != nil {
+		wr.Path = *spec.Path
+	}
+	if spec.QueueAll != nil {
+		wr.QueueAll = *spec.QueueAll
+	}
+	if spec.CustomPageHTML != nil {
+		wr.CustomPageHTML = *spec.CustomPageHTML
+	}
+	if spec.SessionDuration != nil {
+		wr.SessionDuration = *spec.SessionDuration
+	}
+	if spec.DisableSessionRenewal != nil {
+		wr.DisableSessionRenewal = *spec.DisableSessionRenewal
+	}
+	if spec.Suspended != nil {
+		wr.Suspended = *spec.Suspended
+	}
+	return wr
+}
+
+func stringUpToDate(want *string, got string) bool {
+	return want == nil || *want == got
+}
+
+func intUpToDate(want *int, got int) bool {
+	return want == nil || *want == got
+}
+
+func boolUpToDate(want *bool, got bool) bool {
+	return want == nil || *want == got
+}
+
+// UpToDate checks if the remote Waiting Room and its queueing method are
+// up to date with the requested resource parameters. Fields that are not
+// set in the parameters are left to the defaults of Cloudflare, so are
+// not compared.
+func UpToDate(spec *v1alpha1.WaitingRoomParameters, wr cloudflare.WaitingRoom, qm string) bool {
+	if spec == nil {
+		return true
+	}
+
+	if spec.Name != wr.Name ||
+		spec.Host != wr.Host ||
+		spec.TotalActiveUsers != wr.TotalActiveUsers ||
+		spec.NewUsersPerMinute != wr.NewUsersPerMinute {
+		return false
+	}
+
+	return stringUpToDate(spec.Description, wr.Description) &&
+		stringUpToDate(spec.Path, wr.Path) &&
+		stringUpToDate(spec.QueueingMethod, qm) &&
+		boolUpToDate(spec.QueueAll, wr.QueueAll) &&
+		stringUpToDate(spec.CustomPageHTML, wr.CustomPageHTML) &&
+		intUpToDate(spec.SessionDuration, wr.SessionDuration) &&
+		boolUpToDate(spec.DisableSessionRenewal, wr.DisableSessionRenewal) &&
+		boolUpToDate(spec.Suspended, wr.Suspended)
+}
+
+// CreateWaitingRoom creates a new Waiting Room. The queueing method is
+// not set when creating a Waiting Room, as a failure to set it would
+// leave the Waiting Room unknown to us. It is set by the first update
+// instead.
+func CreateWaitingRoom(ctx context.Context, client Client, zoneID string, spec v1alpha1.WaitingRoomParameters) (*cloudflare.WaitingRoom, error) {
+	wr, err := client.CreateWaitingRoom(ctx, zoneID, NewWaitingRoom(spec))
+	return wr, errors.Wrap(err, errCreateWaitingRoom)
+}
+
+// UpdateWaitingRoom replaces the Waiting Room with the given ID, and sets
+// its queueing method if one is requested.
+func UpdateWaitingRoom(ctx context.Context, client Client, zoneID, waitingRoomID string, spec v1alpha1.WaitingRoomParameters) error {
+	wr := NewWaitingRoom(spec)
+	wr.ID = waitingRoomID
+	if _, err := client.UpdateWaitingRoom(ctx, zoneID, wr); err != nil {
+		return errors.Wrap(err, errUpdateWaitingRoom)
+	}
+	if spec.QueueingMethod == nil {
+		return nil
+	}
+	return SetQueueingMethod(ctx, client, zoneID, waitingRoomID, *spec.QueueingMethod)
+}
@@ -0,0 +1,406 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package waitingrooms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ptr "k8s.io/utils/pointer"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/waitingrooms/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/waitingrooms/fake"
+)
+
+const zoneID = "023e105f4ecef8ad9ca31a8372d0c353"
+
+func waitingRoomSpec() v1alpha1.WaitingRoomParameters {
+	duration := 10
+	return v1alpha1.WaitingRoomParameters{
+		Name:              "sale",
+		Host:              "shop.example.com",
+		Path:              ptr.StringPtr("/checkout"),
+		TotalActiveUsers:  1000,
+		NewUsersPerMinute: 200,
+		QueueingMethod:    ptr.StringPtr("fifo"),
+		SessionDuration:   &duration,
+	}
+}
+
+// observed returns a Waiting Room as returned by Cloudflare for the
+// parameters returned by waitingRoomSpec, including its defaults.
+func observed(m ...func(*cloudflare.WaitingRoom)) cloudflare.WaitingRoom {
+	wr := cloudflare.WaitingRoom{
+		ID:                "699d98642c564d2e855e9661899b7252",
+		Name:              "sale",
+		Host:              "shop.example.com",
+		Path:              "/checkout",
+		TotalActiveUsers:  1000,
+		NewUsersPerMinute: 200,
+		SessionDuration:   10,
+		CustomPageHTML:    "{{#waitTimeKnown}} {{waitTime}} mins {{/waitTimeKnown}}",
+	}
+	for _, f := range m {
+		f(&wr)
+	}
+	return wr
+}
+
+func TestGenerateObservation(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	mt := metav1.NewTime(now)
+
+	cases := map[string]struct {
+		reason string
+		wr     cloudflare.WaitingRoom
+		want   v1alpha1.WaitingRoomObservation
+	}{
+		"Timestamps": {
+			reason: "The timestamps of a Waiting Room should be observed",
+			wr:     cloudflare.WaitingRoom{CreatedOn: now, ModifiedOn: now},
+			want:   v1alpha1.WaitingRoomObservation{CreatedOn: &mt, ModifiedOn: &mt},
+		},
+		"Empty": {
+			reason: "Missing timestamps should not be observed",
+			wr:     cloudflare.WaitingRoom{},
+			want:   v1alpha1.WaitingRoomObservation{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateObservation(tc.wr)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGenerateObservation(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		spec   *v1alpha1.WaitingRoomParameters
+		wr     cloudflare.WaitingRoom
+		qm     string
+		want   bool
+	}{
+		"NilSpec": {
+			reason: "A nil spec should always be up to date",
+			wr:     observed(),
+			want:   true,
+		},
+		"UpToDate": {
+			reason: "Defaults set by Cloudflare should be ignored",
+			spec: func() *v1alpha1.WaitingRoomParameters {
+				s := waitingRoomSpec()
+				return &s
+			}(),
+			wr:   observed(),
+			qm:   "fifo",
+			want: true,
+		},
+		"TotalActiveUsersChanged": {
+			reason: "A Waiting Room allowing a different number of active users should not be up to date",
+			spec: func() *v1alpha1.WaitingRoomParameters {
+				s := waitingRoomSpec()
+				s.TotalActiveUsers = 500
+				return &s
+			}(),
+			wr:   observed(),
+			qm:   "fifo",
+			want: false,
+		},
+		"QueueingMethodChanged": {
+			reason: "A Waiting Room with a different queueing method should not be up to date",
+			spec: func() *v1alpha1.WaitingRoomParameters {
+				s := waitingRoomSpec()
+				s.QueueingMethod = ptr.StringPtr("random")
+				return &s
+			}(),
+			wr:   observed(),
+			qm:   "fifo",
+			want: false,
+		},
+		"CustomPageHTMLChanged": {
+			reason: "A Waiting Room with a different custom page should not be up to date",
+			spec: func() *v1alpha1.WaitingRoomParameters {
+				s := waitingRoomSpec()
+				s.CustomPageHTML = ptr.StringPtr("<html>Please wait</html>")
+				return &s
+			}(),
+			wr:   observed(),
+			qm:   "fifo",
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := UpToDate(tc.spec, tc.wr, tc.qm)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetWaitingRoom(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		wr  cloudflare.WaitingRoom
+		qm  string
+		err error
+	}
+
+	cases := map[string]struct {
+		reason             string
+		withQueueingMethod bool
+		client             fake.MockClient
+		want               want
+	}{
+		"Typed": {
+			reason: "The Waiting Room should be fetched using the typed API when its queueing method is not needed",
+			client: fake.MockClient{
+				MockWaitingRoom: func(ctx context.Context, zoneID, waitingRoomID string) (cloudflare.WaitingRoom, error) {
+					return observed(), nil
+				},
+			},
+			want: want{wr: observed()},
+		},
+		"RawFailed": {
+			reason:             "Errors fetching the Waiting Room should be returned",
+			withQueueingMethod: true,
+			client: fake.MockClient{
+				MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+					return nil, errBoom
+				},
+			},
+			want: want{err: errBoom},
+		},
+		"Raw": {
+			reason:             "The Waiting Room and its queueing method should be decoded from a single raw request",
+			withQueueingMethod: true,
+			client: fake.MockClient{
+				MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+					if method != http.MethodGet || endpoint != "/zones/"+zoneID+"/waiting_rooms/699d98642c564d2e855e9661899b7252" {
+						return nil, errBoom
+					}
+					return json.RawMessage(`{"id":"699d98642c564d2e855e9661899b7252","name":"sale","host":"shop.example.com","path":"/checkout","total_active_users":1000,"new_users_per_minute":200,"queueing_method":"random"}`), nil
+				},
+			},
+			want: want{
+				wr: cloudflare.WaitingRoom{
+					ID:                "699d98642c564d2e855e9661899b7252",
+					Name:              "sale",
+					Host:              "shop.example.com",
+					Path:              "/checkout",
+					TotalActiveUsers:  1000,
+					NewUsersPerMinute: 200,
+				},
+				qm: "random",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			wr, qm, err := GetWaitingRoom(context.Background(), tc.client, zoneID, "699d98642c564d2e855e9661899b7252", tc.withQueueingMethod)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGetWaitingRoom(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.wr, wr); diff != "" {
+				t.Errorf("\n%s\nGetWaitingRoom(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.qm, qm); diff != "" {
+				t.Errorf("\n%s\nGetWaitingRoom(...): -want queueing method, +got queueing method:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreateWaitingRoom(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	desired := cloudflare.WaitingRoom{
+		Name:              "sale",
+		Host:              "shop.example.com",
+		Path:              "/checkout",
+		TotalActiveUsers:  1000,
+		NewUsersPerMinute: 200,
+		SessionDuration:   10,
+	}
+
+	type want struct {
+		wr   *cloudflare.WaitingRoom
+		err  error
+		sent cloudflare.WaitingRoom
+	}
+
+	cases := map[string]struct {
+		reason string
+		wr     *cloudflare.WaitingRoom
+		err    error
+		want   want
+	}{
+		"CreateFailed": {
+			reason: "Errors creating the Waiting Room should be wrapped",
+			err:    errBoom,
+			want: want{
+				err:  errors.Wrap(errBoom, errCreateWaitingRoom),
+				sent: desired,
+			},
+		},
+		"Success": {
+			reason: "The created Waiting Room should be returned",
+			wr:     &cloudflare.WaitingRoom{ID: "699d98642c564d2e855e9661899b7252", Name: "sale"},
+			want: want{
+				wr:   &cloudflare.WaitingRoom{ID: "699d98642c564d2e855e9661899b7252", Name: "sale"},
+				sent: desired,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var sent cloudflare.WaitingRoom
+			client := fake.MockClient{
+				MockCreateWaitingRoom: func(ctx context.Context, z string, wr cloudflare.WaitingRoom) (*cloudflare.WaitingRoom, error) {
+					sent = wr
+					return tc.wr, tc.err
+				},
+			}
+			got, err := CreateWaitingRoom(context.Background(), client, zoneID, waitingRoomSpec())
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreateWaitingRoom(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.wr, got); diff != "" {
+				t.Errorf("\n%s\nCreateWaitingRoom(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.sent, sent); diff != "" {
+				t.Errorf("\n%s\nCreateWaitingRoom(...): -want sent, +got sent:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdateWaitingRoom(t *testing.T) {
+	errBoom := errors.New("boom")
+	wid := "699d98642c564d2e855e9661899b7252"
+
+	type want struct {
+		err  error
+		data interface{}
+	}
+
+	cases := map[string]struct {
+		reason string
+		spec   v1alpha1.WaitingRoomParameters
+		client func(data *interface{}) fake.MockClient
+		want   want
+	}{
+		"UpdateFailed": {
+			reason: "Errors updating the Waiting Room should be wrapped",
+			spec:   waitingRoomSpec(),
+			client: func(_ *interface{}) fake.MockClient {
+				return fake.MockClient{
+					MockUpdateWaitingRoom: func(ctx context.Context, zoneID string, wr cloudflare.WaitingRoom) (cloudflare.WaitingRoom, error) {
+						return cloudflare.WaitingRoom{}, errBoom
+					},
+				}
+			},
+			want: want{err: errors.Wrap(errBoom, errUpdateWaitingRoom)},
+		},
+		"SetQueueingMethodFailed": {
+			reason: "Errors setting the queueing method should be wrapped",
+			spec:   waitingRoomSpec(),
+			client: func(_ *interface{}) fake.MockClient {
+				return fake.MockClient{
+					MockUpdateWaitingRoom: func(ctx context.Context, zoneID string, wr cloudflare.WaitingRoom) (cloudflare.WaitingRoom, error) {
+						return wr, nil
+					},
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				}
+			},
+			want: want{err: errors.Wrap(errBoom, errSetQueueingMethod)},
+		},
+		"SetQueueingMethod": {
+			reason: "Only the queueing method should be set using a raw request",
+			spec:   waitingRoomSpec(),
+			client: func(sent *interface{}) fake.MockClient {
+				return fake.MockClient{
+					MockUpdateWaitingRoom: func(ctx context.Context, zoneID string, wr cloudflare.WaitingRoom) (cloudflare.WaitingRoom, error) {
+						if wr.ID != wid {
+							return cloudflare.WaitingRoom{}, errBoom
+						}
+						return wr, nil
+					},
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if method != http.MethodPatch || endpoint != "/zones/"+zoneID+"/waiting_rooms/"+wid {
+							return nil, errBoom
+						}
+						*sent = data
+						return json.RawMessage(`{}`), nil
+					},
+				}
+			},
+			want: want{data: queueingMethod{QueueingMethod: "fifo"}},
+		},
+		"NoQueueingMethod": {
+			reason: "No raw request should be made when no queueing method is requested",
+			spec: func() v1alpha1.WaitingRoomParameters {
+				s := waitingRoomSpec()
+				s.QueueingMethod = nil
+				return s
+			}(),
+			client: func(_ *interface{}) fake.MockClient {
+				return fake.MockClient{
+					MockUpdateWaitingRoom: func(ctx context.Context, zoneID string, wr cloudflare.WaitingRoom) (cloudflare.WaitingRoom, error) {
+						return wr, nil
+					},
+				}
+			},
+			want: want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var data interface{}
+			err := UpdateWaitingRoom(context.Background(), tc.client(&data), zoneID, wid, tc.spec)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdateWaitingRoom(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.data, data); diff != "" {
+				t.Errorf("\n%s\nUpdateWaitingRoom(...): -want data, +got data:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// A MockClient acts as a testable representation of the Cloudflare API.
+type MockClient struct {
+	MockCreateWorkersKVNamespace func(ctx context.Context, req *cloudflare.WorkersKVNamespaceRequest) (cloudflare.WorkersKVNamespaceResponse, error)
+	MockListWorkersKVNamespaces  func(ctx context.Context) ([]cloudflare.WorkersKVNamespace, error)
+	MockUpdateWorkersKVNamespace func(ctx context.Context, namespaceID string, req *cloudflare.WorkersKVNamespaceRequest) (cloudflare.Response, error)
+	MockDeleteWorkersKVNamespace func(ctx context.Context, namespaceID string) (cloudflare.Response, error)
+}
+
+// CreateWorkersKVNamespace mocks the CreateWorkersKVNamespace method of the Cloudflare API.
+func (m MockClient) CreateWorkersKVNamespace(ctx context.Context, req *cloudflare.WorkersKVNamespaceRequest) (cloudflare.WorkersKVNamespaceResponse, error) {
+	return m.MockCreateWorkersKVNamespace(ctx, req)
+}
+
+// ListWorkersKVNamespaces mocks the ListWorkersKVNamespaces method of the Cloudflare API.
+func (m MockClient) ListWorkersKVNamespaces(ctx context.Context) ([]cloudflare.WorkersKVNamespace, error) {
+	return m.MockListWorkersKVNamespaces(ctx)
+}
+
+// UpdateWorkersKVNamespace mocks the UpdateWorkersKVNamespace method of the Cloudflare API.
+func (m MockClient) UpdateWorkersKVNamespace(ctx context.Context, namespaceID string, req *cloudflare.WorkersKVNamespaceRequest) (cloudflare.Response, error) {
+	return m.MockUpdateWorkersKVNamespace(ctx, namespaceID, req)
+}
+
+// DeleteWorkersKVNamespace mocks the DeleteWorkersKVNamespace method of the Cloudflare API.
+func (m MockClient) DeleteWorkersKVNamespace(ctx context.Context, namespaceID string) (cloudflare.Response, error) {
+	return m.MockDeleteWorkersKVNamespace(ctx, namespaceID)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kvnamespace
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/pkg/errors"
+
+	"github.com/benagricola/provider-cloudflare/apis/workers/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+const (
+	errListNamespaces  = "error listing workers kv namespaces"
+	errUpdateNamespace = "error updating workers kv namespace"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with Workers KV Namespaces.
+type Client interface {
+	CreateWorkersKVNamespace(ctx context.Context, req *cloudflare.WorkersKVNamespaceRequest) (cloudflare.WorkersKVNamespaceResponse, error)
+	ListWorkersKVNamespaces(ctx context.Context) ([]cloudflare.WorkersKVNamespace, error)
+	UpdateWorkersKVNamespace(ctx context.Context, namespaceID string, req *cloudflare.WorkersKVNamespaceRequest) (cloudflare.Response, error)
+	DeleteWorkersKVNamespace(ctx context.Context, namespaceID string) (cloudflare.Response, error)
+}
+
+// NewClient returns a new Cloudflare API client for working with Workers
+// KV Namespaces.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// IsNamespaceNotFound returns true if the passed error indicates
+// a KV Namespace was not found.
+func IsNamespaceNotFound(err error) bool {
+	return clients.IsNotFound(err)
+}
+
+// LookupNamespace returns the KV Namespace with the given ID, or nil if
+// no such namespace exists on the account.
+func LookupNamespace(ctx context.Context, client Client, id string) (*cloudflare.WorkersKVNamespace, error) {
+	l, err := client.ListWorkersKVNamespaces(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errListNamespaces)
+	}
+	for i := range l {
+		if l[i].ID == id {
+			return &l[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// LateInitialize initializes WorkersKVNamespaceParameters based on the
+// remote resource.
+func LateInitialize(spec *v1alpha1.WorkersKVNamespaceParameters, o cloudflare.WorkersKVNamespace) bool {
+	if spec == nil {
+		return false
+	}
+
+	li := false
+	if spec.Title == nil {
+		spec.Title = &o.Title
+		li = true
+	}
+
+	return li
+}
+
+// UpToDate checks if the remote KV Namespace is up to date with the
+// requested resource parameters.
+func UpToDate(spec *v1alpha1.WorkersKVNamespaceParameters, o cloudflare.WorkersKVNamespace) bool {
+	if spec == nil || spec.Title == nil {
+		return true
+	}
+
+	return *spec.Title == o.Title
+}
+
+// UpdateNamespace updates the title of a KV Namespace.
+func UpdateNamespace(ctx context.Context, client Client, id string, spec *v1alpha1.WorkersKVNamespaceParameters) error {
+	if spec.Title == nil {
+		return nil
+	}
+	_, err := client.UpdateWorkersKVNamespace(ctx, id, &cloudflare.WorkersKVNamespaceRequest{Title: *spec.Title})
+	return errors.Wrap(err, errUpdateNamespace)
+}
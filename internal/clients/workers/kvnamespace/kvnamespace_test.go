@@ -0,0 +1,201 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kvnamespace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/workers/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/workers/kvnamespace/fake"
+
+	ptr "k8s.io/utils/pointer"
+)
+
+func TestLateInitialize(t *testing.T) {
+	type args struct {
+		spec *v1alpha1.WorkersKVNamespaceParameters
+		o    cloudflare.WorkersKVNamespace
+	}
+
+	type want struct {
+		spec *v1alpha1.WorkersKVNamespaceParameters
+		li   bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NilSpec": {
+			reason: "LateInitialize should return false when not passed a spec",
+			args:   args{},
+			want:   want{},
+		},
+		"LateInitTitle": {
+			reason: "LateInitialize should initialize the title from the remote namespace",
+			args: args{
+				spec: &v1alpha1.WorkersKVNamespaceParameters{},
+				o:    cloudflare.WorkersKVNamespace{ID: "ns", Title: "production"},
+			},
+			want: want{
+				spec: &v1alpha1.WorkersKVNamespaceParameters{Title: ptr.StringPtr("production")},
+				li:   true,
+			},
+		},
+		"TitleSet": {
+			reason: "LateInitialize should not overwrite a title set in the spec",
+			args: args{
+				spec: &v1alpha1.WorkersKVNamespaceParameters{Title: ptr.StringPtr("staging")},
+				o:    cloudflare.WorkersKVNamespace{ID: "ns", Title: "production"},
+			},
+			want: want{
+				spec: &v1alpha1.WorkersKVNamespaceParameters{Title: ptr.StringPtr("staging")},
+				li:   false,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			li := LateInitialize(tc.args.spec, tc.args.o)
+			if diff := cmp.Diff(tc.want.li, li); diff != "" {
+				t.Errorf("\n%s\nLateInitialize(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.spec, tc.args.spec); diff != "" {
+				t.Errorf("\n%s\nLateInitialize(...): -want spec, +got spec:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpToDate(t *testing.T) {
+	type args struct {
+		spec *v1alpha1.WorkersKVNamespaceParameters
+		o    cloudflare.WorkersKVNamespace
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   bool
+	}{
+		"NilSpec": {
+			reason: "UpToDate should return true when not passed a spec",
+			args:   args{},
+			want:   true,
+		},
+		"NilTitle": {
+			reason: "UpToDate should return true when no title is set",
+			args: args{
+				spec: &v1alpha1.WorkersKVNamespaceParameters{},
+				o:    cloudflare.WorkersKVNamespace{Title: "production"},
+			},
+			want: true,
+		},
+		"TitleDifferent": {
+			reason: "UpToDate should return false when the title differs",
+			args: args{
+				spec: &v1alpha1.WorkersKVNamespaceParameters{Title: ptr.StringPtr("staging")},
+				o:    cloudflare.WorkersKVNamespace{Title: "production"},
+			},
+			want: false,
+		},
+		"TitleSame": {
+			reason: "UpToDate should return true when the title matches",
+			args: args{
+				spec: &v1alpha1.WorkersKVNamespaceParameters{Title: ptr.StringPtr("production")},
+				o:    cloudflare.WorkersKVNamespace{Title: "production"},
+			},
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := UpToDate(tc.args.spec, tc.args.o)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdateNamespace(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		client Client
+		spec   *v1alpha1.WorkersKVNamespaceParameters
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   error
+	}{
+		"NoTitle": {
+			reason: "UpdateNamespace should do nothing when no title is set",
+			args: args{
+				client: fake.MockClient{},
+				spec:   &v1alpha1.WorkersKVNamespaceParameters{},
+			},
+		},
+		"UpdateError": {
+			reason: "UpdateNamespace should return errors from the API",
+			args: args{
+				client: fake.MockClient{
+					MockUpdateWorkersKVNamespace: func(ctx context.Context, id string, req *cloudflare.WorkersKVNamespaceRequest) (cloudflare.Response, error) {
+						return cloudflare.Response{}, errBoom
+					},
+				},
+				spec: &v1alpha1.WorkersKVNamespaceParameters{Title: ptr.StringPtr("staging")},
+			},
+			want: errors.Wrap(errBoom, errUpdateNamespace),
+		},
+		"Success": {
+			reason: "UpdateNamespace should send the title to the API",
+			args: args{
+				client: fake.MockClient{
+					MockUpdateWorkersKVNamespace: func(ctx context.Context, id string, req *cloudflare.WorkersKVNamespaceRequest) (cloudflare.Response, error) {
+						if id != "ns" || req.Title != "staging" {
+							return cloudflare.Response{}, errBoom
+						}
+						return cloudflare.Response{}, nil
+					},
+				},
+				spec: &v1alpha1.WorkersKVNamespaceParameters{Title: ptr.StringPtr("staging")},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := UpdateNamespace(context.Background(), tc.args.client, "ns", tc.args.spec)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdateNamespace(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// A MockClient acts as a testable representation of the Cloudflare API.
+type MockClient struct {
+	MockWriteWorkersKV  func(ctx context.Context, namespaceID, key string, value []byte) (cloudflare.Response, error)
+	MockReadWorkersKV   func(ctx context.Context, namespaceID, key string) ([]byte, error)
+	MockDeleteWorkersKV func(ctx context.Context, namespaceID, key string) (cloudflare.Response, error)
+}
+
+// WriteWorkersKV mocks the WriteWorkersKV method of the Cloudflare API.
+func (m MockClient) WriteWorkersKV(ctx context.Context, namespaceID, key string, value []byte) (cloudflare.Response, error) {
+	return m.MockWriteWorkersKV(ctx, namespaceID, key, value)
+}
+
+// ReadWorkersKV mocks the ReadWorkersKV method of the Cloudflare API.
+func (m MockClient) ReadWorkersKV(ctx context.Context, namespaceID, key string) ([]byte, error) {
+	return m.MockReadWorkersKV(ctx, namespaceID, key)
+}
+
+// DeleteWorkersKV mocks the DeleteWorkersKV method of the Cloudflare API.
+func (m MockClient) DeleteWorkersKV(ctx context.Context, namespaceID, key string) (cloudflare.Response, error) {
+	return m.MockDeleteWorkersKV(ctx, namespaceID, key)
+}
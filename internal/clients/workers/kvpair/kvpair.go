@@ -0,0 +1,67 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kvpair
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+
+	"github.com/cloudflare/cloudflare-go"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/benagricola/provider-cloudflare/apis/workers/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with Workers KV key/value pairs.
+type Client interface {
+	WriteWorkersKV(ctx context.Context, namespaceID, key string, value []byte) (cloudflare.Response, error)
+	ReadWorkersKV(ctx context.Context, namespaceID, key string) ([]byte, error)
+	DeleteWorkersKV(ctx context.Context, namespaceID, key string) (cloudflare.Response, error)
+}
+
+// NewClient returns a new Cloudflare API client for working with Workers
+// KV key/value pairs.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// IsPairNotFound returns true if the passed error indicates
+// a KV key was not found.
+func IsPairNotFound(err error) bool {
+	return clients.IsNotFound(err)
+}
+
+// ResolveValue returns the desired value of a KV pair, read from the
+// referenced Secret if one is set.
+func ResolveValue(ctx context.Context, kube client.Reader, spec *v1alpha1.WorkersKVPairParameters) ([]byte, error) {
+	if spec.ValueSecretRef != nil {
+		return clients.GetSecretValue(ctx, kube, *spec.ValueSecretRef)
+	}
+	if spec.Value != nil {
+		return []byte(*spec.Value), nil
+	}
+	return []byte{}, nil
+}
+
+// UpToDate checks if the remote value of a KV pair matches the desired
+// value.
+func UpToDate(value, o []byte) bool {
+	return bytes.Equal(value, o)
+}
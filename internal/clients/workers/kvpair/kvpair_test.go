@@ -0,0 +1,124 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kvpair
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/workers/v1alpha1"
+
+	ptr "k8s.io/utils/pointer"
+)
+
+func TestResolveValue(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		kube client.Reader
+		spec *v1alpha1.WorkersKVPairParameters
+	}
+
+	type want struct {
+		o   []byte
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Empty": {
+			reason: "ResolveValue should return an empty value when none is set",
+			args: args{
+				spec: &v1alpha1.WorkersKVPairParameters{},
+			},
+			want: want{
+				o: []byte{},
+			},
+		},
+		"Inline": {
+			reason: "ResolveValue should return the inline value",
+			args: args{
+				spec: &v1alpha1.WorkersKVPairParameters{Value: ptr.StringPtr("value")},
+			},
+			want: want{
+				o: []byte("value"),
+			},
+		},
+		"Secret": {
+			reason: "ResolveValue should prefer the value of the referenced Secret",
+			args: args{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						obj.(*corev1.Secret).Data = map[string][]byte{"value": []byte("s3cr3t")}
+						return nil
+					}),
+				},
+				spec: &v1alpha1.WorkersKVPairParameters{
+					Value: ptr.StringPtr("value"),
+					ValueSecretRef: &xpv1.SecretKeySelector{
+						SecretReference: xpv1.SecretReference{Name: "kv", Namespace: "default"},
+						Key:             "value",
+					},
+				},
+			},
+			want: want{
+				o: []byte("s3cr3t"),
+			},
+		},
+		"SecretError": {
+			reason: "ResolveValue should return an error if the Secret cannot be read",
+			args: args{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+				spec: &v1alpha1.WorkersKVPairParameters{
+					ValueSecretRef: &xpv1.SecretKeySelector{
+						SecretReference: xpv1.SecretReference{Name: "kv", Namespace: "default"},
+						Key:             "value",
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, "cannot get Secret"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ResolveValue(context.Background(), tc.args.kube, tc.args.spec)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nResolveValue(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nResolveValue(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -19,7 +19,6 @@ package route
 import (
 	"context"
 	"net/http"
-	"strings"
 
 	"github.com/cloudflare/cloudflare-go"
 
@@ -29,7 +28,7 @@ import (
 
 const (
 	// Cloudflare returns this code when a route isnt found.
-	errRouteNotFound = "10007"
+	errRouteNotFound = 10007
 )
 
 // Client is a Cloudflare API client that implements methods for working
@@ -49,7 +48,23 @@ func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
 // IsRouteNotFound returns true if the passed error indicates
 // a Worker Route was not found.
 func IsRouteNotFound(err error) bool {
-	return strings.Contains(err.Error(), errRouteNotFound)
+	return clients.IsNotFound(err) || clients.HasErrorCode(err, errRouteNotFound)
+}
+
+// LateInitialize initializes RouteParameters based on the remote resource.
+func LateInitialize(spec *v1alpha1.RouteParameters, o cloudflare.WorkerRoute) bool {
+	if spec == nil {
+		return false
+	}
+
+	li := false
+
+	if spec.Script == nil && o.Script != "" {
+		spec.Script = &o.Script
+		li = true
+	}
+
+	return li
 }
 
 // UpToDate checks if the remote Route is up to date with the
@@ -68,10 +83,9 @@ func UpToDate(spec *v1alpha1.RouteParameters, o cloudflare.WorkerRoute) bool { /
 		return false
 	}
 
-	if spec.Script == nil && o.Script != "" {
-		return false
-	}
-
+	// An unset script is late initialized from the route, so it is only
+	// compared once set. An empty script removes the script from the
+	// route.
 	if spec.Script != nil && *spec.Script != o.Script {
 		return false
 	}
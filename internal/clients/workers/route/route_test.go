@@ -17,6 +17,7 @@ limitations under the License.
 package route
 
 import (
+	"context"
 	"testing"
 
 	"github.com/cloudflare/cloudflare-go"
@@ -24,6 +25,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/benagricola/provider-cloudflare/apis/workers/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/workers/route/fake"
 
 	ptr "k8s.io/utils/pointer"
 )
@@ -76,8 +78,8 @@ func TestUpToDate(t *testing.T) {
 				o: false,
 			},
 		},
-		"UpToDateScriptDifferent": {
-			reason: "UpToDate should return false if the spec does not match the route script (nil)",
+		"UpToDateScriptUnset": {
+			reason: "UpToDate should return true if the spec script is unset, as it is late initialized from the route",
 			args: args{
 				rp: &v1alpha1.RouteParameters{
 					Script:  nil,
@@ -88,6 +90,22 @@ func TestUpToDate(t *testing.T) {
 					Pattern: "example.com/*",
 				},
 			},
+			want: want{
+				o: true,
+			},
+		},
+		"UpToDateScriptCleared": {
+			reason: "UpToDate should return false if the spec script is empty and the route has a script",
+			args: args{
+				rp: &v1alpha1.RouteParameters{
+					Script:  ptr.StringPtr(""),
+					Pattern: "example.com/*",
+				},
+				r: cloudflare.WorkerRoute{
+					Script:  "test-worker",
+					Pattern: "example.com/*",
+				},
+			},
 			want: want{
 				o: false,
 			},
@@ -167,3 +185,141 @@ func TestUpToDate(t *testing.T) {
 		})
 	}
 }
+
+func TestLateInitialize(t *testing.T) {
+	type args struct {
+		rp *v1alpha1.RouteParameters
+		r  cloudflare.WorkerRoute
+	}
+
+	type want struct {
+		o  bool
+		rp *v1alpha1.RouteParameters
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"LateInitSpecNil": {
+			reason: "LateInitialize should return false when not passed a spec",
+			args:   args{},
+			want: want{
+				o: false,
+			},
+		},
+		"LateInitScript": {
+			reason: "LateInitialize should initialize an unset script from the route",
+			args: args{
+				rp: &v1alpha1.RouteParameters{
+					Pattern: "example.com/*",
+				},
+				r: cloudflare.WorkerRoute{
+					Script:  "test-worker",
+					Pattern: "example.com/*",
+				},
+			},
+			want: want{
+				o: true,
+				rp: &v1alpha1.RouteParameters{
+					Script:  ptr.StringPtr("test-worker"),
+					Pattern: "example.com/*",
+				},
+			},
+		},
+		"LateInitDontClobber": {
+			reason: "LateInitialize should not overwrite a script that is already set",
+			args: args{
+				rp: &v1alpha1.RouteParameters{
+					Script:  ptr.StringPtr("new-worker"),
+					Pattern: "example.com/*",
+				},
+				r: cloudflare.WorkerRoute{
+					Script:  "test-worker",
+					Pattern: "example.com/*",
+				},
+			},
+			want: want{
+				o: false,
+				rp: &v1alpha1.RouteParameters{
+					Script:  ptr.StringPtr("new-worker"),
+					Pattern: "example.com/*",
+				},
+			},
+		},
+		"LateInitDontClobberEmpty": {
+			reason: "LateInitialize should not overwrite an empty script, which removes the script from the route",
+			args: args{
+				rp: &v1alpha1.RouteParameters{
+					Script:  ptr.StringPtr(""),
+					Pattern: "example.com/*",
+				},
+				r: cloudflare.WorkerRoute{
+					Script:  "test-worker",
+					Pattern: "example.com/*",
+				},
+			},
+			want: want{
+				o: false,
+				rp: &v1alpha1.RouteParameters{
+					Script:  ptr.StringPtr(""),
+					Pattern: "example.com/*",
+				},
+			},
+		},
+		"LateInitNoScript": {
+			reason: "LateInitialize should not initialize the script of a route without one",
+			args: args{
+				rp: &v1alpha1.RouteParameters{
+					Pattern: "example.com/*",
+				},
+				r: cloudflare.WorkerRoute{
+					Pattern: "example.com/*",
+				},
+			},
+			want: want{
+				o: false,
+				rp: &v1alpha1.RouteParameters{
+					Pattern: "example.com/*",
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := LateInitialize(tc.args.rp, tc.args.r)
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nLateInitialize(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.rp, tc.args.rp); diff != "" {
+				t.Errorf("\n%s\nLateInitialize(...): -want spec, +got spec:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdateRoute(t *testing.T) {
+	var got cloudflare.WorkerRoute
+	client := fake.MockClient{
+		MockUpdateWorkerRoute: func(ctx context.Context, zoneID string, routeID string, route cloudflare.WorkerRoute) (cloudflare.WorkerRouteResponse, error) {
+			got = route
+			return cloudflare.WorkerRouteResponse{}, nil
+		},
+	}
+
+	spec := &v1alpha1.RouteParameters{
+		Pattern: "example.com/*",
+		Script:  ptr.StringPtr(""),
+		Zone:    ptr.StringPtr("zone"),
+	}
+	if err := UpdateRoute(context.Background(), client, "route", spec); err != nil {
+		t.Fatalf("UpdateRoute(...): unexpected error: %s", err)
+	}
+
+	want := cloudflare.WorkerRoute{Pattern: "example.com/*"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("UpdateRoute(...): an empty script should remove the script from the route: -want, +got:\n%s\n", diff)
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go"
+)
+
+// A MockClient acts as a testable representation of the Cloudflare API.
+type MockClient struct {
+	MockUploadWorkerWithBindings func(ctx context.Context, requestParams *cloudflare.WorkerRequestParams, data *cloudflare.WorkerScriptParams) (cloudflare.WorkerScriptResponse, error)
+	MockDownloadWorker           func(ctx context.Context, requestParams *cloudflare.WorkerRequestParams) (cloudflare.WorkerScriptResponse, error)
+	MockListWorkerBindings       func(ctx context.Context, requestParams *cloudflare.WorkerRequestParams) (cloudflare.WorkerBindingListResponse, error)
+	MockListWorkerScripts        func(ctx context.Context) (cloudflare.WorkerListResponse, error)
+	MockDeleteWorker             func(ctx context.Context, requestParams *cloudflare.WorkerRequestParams) (cloudflare.WorkerScriptResponse, error)
+}
+
+// UploadWorkerWithBindings mocks the UploadWorkerWithBindings method of the Cloudflare API.
+func (m MockClient) UploadWorkerWithBindings(ctx context.Context, requestParams *cloudflare.WorkerRequestParams, data *cloudflare.WorkerScriptParams) (cloudflare.WorkerScriptResponse, error) {
+	return m.MockUploadWorkerWithBindings(ctx, requestParams, data)
+}
+
+// DownloadWorker mocks the DownloadWorker method of the Cloudflare API.
+func (m MockClient) DownloadWorker(ctx context.Context, requestParams *cloudflare.WorkerRequestParams) (cloudflare.WorkerScriptResponse, error) {
+	return m.MockDownloadWorker(ctx, requestParams)
+}
+
+// ListWorkerBindings mocks the ListWorkerBindings method of the Cloudflare API.
+func (m MockClient) ListWorkerBindings(ctx context.Context, requestParams *cloudflare.WorkerRequestParams) (cloudflare.WorkerBindingListResponse, error) {
+	return m.MockListWorkerBindings(ctx, requestParams)
+}
+
+// ListWorkerScripts mocks the ListWorkerScripts method of the Cloudflare API.
+func (m MockClient) ListWorkerScripts(ctx context.Context) (cloudflare.WorkerListResponse, error) {
+	return m.MockListWorkerScripts(ctx)
+}
+
+// DeleteWorker mocks the DeleteWorker method of the Cloudflare API.
+func (m MockClient) DeleteWorker(ctx context.Context, requestParams *cloudflare.WorkerRequestParams) (cloudflare.WorkerScriptResponse, error) {
+	return m.MockDeleteWorker(ctx, requestParams)
+}
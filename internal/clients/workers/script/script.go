@@ -0,0 +1,318 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package script
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/benagricola/provider-cloudflare/apis/workers/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+const (
+	// Cloudflare returns this code when a script isnt found.
+	errScriptNotFound = 10007
+
+	errNoScriptSource  = "no script source specified"
+	errGetConfigMap    = "cannot get script ConfigMap"
+	errMissingKey      = "key not found"
+	errDownloadScript  = "cannot download script"
+	errBadScriptStatus = "unexpected HTTP status downloading script"
+	errURLScheme       = "script URL must use https"
+	errURLHost         = "script URL host is not allowed"
+	errScriptTooLarge  = "downloaded script is larger than the maximum size"
+	errScriptChecksum  = "downloaded script does not match its sha256 checksum"
+	errTooManyRedirect = "too many redirects downloading script"
+	errBindingNoNS     = "kv_namespace binding has no namespace"
+	errBindingNoSecret = "secret_text binding has no secretRef"
+	errBindingType     = "unsupported binding type"
+	errUploadScript    = "error uploading worker script"
+	errBindingPrefix   = "binding"
+	errListScripts     = "error listing worker scripts"
+
+	// maxScriptSize is the largest Worker Script Cloudflare accepts, and
+	// so the most that is read when downloading a script.
+	maxScriptSize = 10 << 20
+
+	maxRedirects = 10
+)
+
+// Client is a Cloudflare API client that implements methods for working
+// with Worker Scripts.
+type Client interface {
+	UploadWorkerWithBindings(ctx context.Context, requestParams *cloudflare.WorkerRequestParams, data *cloudflare.WorkerScriptParams) (cloudflare.WorkerScriptResponse, error)
+	DownloadWorker(ctx context.Context, requestParams *cloudflare.WorkerRequestParams) (cloudflare.WorkerScriptResponse, error)
+	ListWorkerBindings(ctx context.Context, requestParams *cloudflare.WorkerRequestParams) (cloudflare.WorkerBindingListResponse, error)
+	ListWorkerScripts(ctx context.Context) (cloudflare.WorkerListResponse, error)
+	DeleteWorker(ctx context.Context, requestParams *cloudflare.WorkerRequestParams) (cloudflare.WorkerScriptResponse, error)
+}
+
+// NewClient returns a new Cloudflare API client for working with Worker
+// Scripts.
+func NewClient(cfg clients.Config, hc *http.Client) (Client, error) {
+	return clients.NewClient(cfg, hc)
+}
+
+// IsScriptNotFound returns true if the passed error indicates
+// a Worker Script was not found.
+func IsScriptNotFound(err error) bool {
+	return clients.IsNotFound(err) || clients.HasErrorCode(err, errScriptNotFound)
+}
+
+// LookupScript returns the metadata of the Worker Script with the given
+// name, or nil if no such script exists on the account.
+func LookupScript(ctx context.Context, client Client, name string) (*cloudflare.WorkerMetaData, error) {
+	l, err := client.ListWorkerScripts(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errListScripts)
+	}
+	for i := range l.WorkerList {
+		if l.WorkerList[i].ID == name {
+			return &l.WorkerList[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// GenerateObservation creates an observation of a cloudflare Worker Script.
+func GenerateObservation(in cloudflare.WorkerMetaData) v1alpha1.WorkerScriptObservation {
+	o := v1alpha1.WorkerScriptObservation{
+		ETag: in.ETAG,
+		Size: in.Size,
+	}
+	if !in.CreatedOn.IsZero() {
+		o.CreatedOn = &metav1.Time{Time: in.CreatedOn}
+	}
+	if !in.ModifiedOn.IsZero() {
+		o.ModifiedOn = &metav1.Time{Time: in.ModifiedOn}
+	}
+	return o
+}
+
+// A URLFetcher downloads the content of Worker Scripts from URLs. Only
+// https URLs whose host is explicitly allowed may be downloaded from, so
+// that a Worker Script cannot be used to make the provider request
+// arbitrary endpoints.
+type URLFetcher struct {
+	client *http.Client
+	hosts  []string
+}
+
+// NewURLFetcher returns a URLFetcher that downloads scripts with the
+// passed HTTP client from the passed hosts. Redirects are only followed
+// to allowed hosts.
+func NewURLFetcher(hc *http.Client, hosts []string) *URLFetcher {
+	f := &URLFetcher{hosts: hosts}
+	c := *hc
+	c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return errors.New(errTooManyRedirect)
+		}
+		return f.allowed(req.URL)
+	}
+	f.client = &c
+	return f
+}
+
+func (f *URLFetcher) allowed(u *url.URL) error {
+	if u.Scheme != "https" {
+		return errors.Errorf("%s: %s", errURLScheme, u.Scheme)
+	}
+	for _, h := range f.hosts {
+		if strings.EqualFold(h, u.Hostname()) {
+			return nil
+		}
+	}
+	return errors.Errorf("%s: %s", errURLHost, u.Hostname())
+}
+
+// Fetch downloads the content of a Worker Script from the passed URL.
+func (f *URLFetcher) Fetch(ctx context.Context, rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", errors.Wrap(err, errDownloadScript)
+	}
+	if err := f.allowed(u); err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", errors.Wrap(err, errDownloadScript)
+	}
+	rsp, err := f.client.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, errDownloadScript)
+	}
+	defer rsp.Body.Close() //nolint:errcheck
+	if rsp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("%s: %d", errBadScriptStatus, rsp.StatusCode)
+	}
+	b, err := ioutil.ReadAll(io.LimitReader(rsp.Body, maxScriptSize+1))
+	if err != nil {
+		return "", errors.Wrap(err, errDownloadScript)
+	}
+	if len(b) > maxScriptSize {
+		return "", errors.Errorf("%s: %d bytes", errScriptTooLarge, maxScriptSize)
+	}
+	return string(b), nil
+}
+
+// ResolveScript returns the content of a Worker Script, read from
+// whichever source is set on the passed parameters. Content downloaded
+// from a URL is checked against the source's checksum, if one is set.
+func ResolveScript(ctx context.Context, kube client.Reader, f *URLFetcher, src v1alpha1.WorkerScriptSource) (string, error) {
+	switch {
+	case src.Inline != nil:
+		return *src.Inline, nil
+	case src.ConfigMapRef != nil:
+		cm := &corev1.ConfigMap{}
+		nn := types.NamespacedName{Namespace: src.ConfigMapRef.Namespace, Name: src.ConfigMapRef.Name}
+		if err := kube.Get(ctx, nn, cm); err != nil {
+			return "", errors.Wrap(err, errGetConfigMap)
+		}
+		if v, ok := cm.Data[src.ConfigMapRef.Key]; ok {
+			return v, nil
+		}
+		if v, ok := cm.BinaryData[src.ConfigMapRef.Key]; ok {
+			return string(v), nil
+		}
+		return "", errors.Errorf("%s: %s", errMissingKey, src.ConfigMapRef.Key)
+	case src.SecretRef != nil:
+		v, err := clients.GetSecretValue(ctx, kube, *src.SecretRef)
+		return string(v), err
+	case src.URL != nil:
+		s, err := f.Fetch(ctx, *src.URL)
+		if err != nil {
+			return "", err
+		}
+		if src.SHA256 != nil && !strings.EqualFold(*src.SHA256, checksum(s)) {
+			return "", errors.New(errScriptChecksum)
+		}
+		return s, nil
+	}
+	return "", errors.New(errNoScriptSource)
+}
+
+func checksum(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// ScriptUpToDate checks if the content of the remote Worker Script o is up
+// to date with its source. Content is not downloaded from a URL source
+// again; it is compared against the source's checksum if one is set, and
+// is otherwise considered up to date.
+func ScriptUpToDate(ctx context.Context, kube client.Reader, src v1alpha1.WorkerScriptSource, o string) (bool, error) {
+	if src.URL != nil {
+		return src.SHA256 == nil || strings.EqualFold(*src.SHA256, checksum(o)), nil
+	}
+	s, err := ResolveScript(ctx, kube, nil, src)
+	if err != nil {
+		return false, err
+	}
+	return s == o, nil
+}
+
+// ResolveBindings converts the bindings of a Worker Script into their
+// cloudflare representation, reading the values of secret_text bindings
+// from their referenced Secrets.
+func ResolveBindings(ctx context.Context, kube client.Reader, bs []v1alpha1.WorkerScriptBinding) (map[string]cloudflare.WorkerBinding, error) {
+	if len(bs) == 0 {
+		return nil, nil
+	}
+	out := make(map[string]cloudflare.WorkerBinding, len(bs))
+	for _, b := range bs {
+		switch cloudflare.WorkerBindingType(b.Type) {
+		case cloudflare.WorkerKvNamespaceBindingType:
+			if b.Namespace == nil {
+				return nil, errors.Errorf("%s %s: %s", errBindingPrefix, b.Name, errBindingNoNS)
+			}
+			out[b.Name] = cloudflare.WorkerKvNamespaceBinding{NamespaceID: *b.Namespace}
+		case cloudflare.WorkerPlainTextBindingType:
+			t := ""
+			if b.Text != nil {
+				t = *b.Text
+			}
+			out[b.Name] = cloudflare.WorkerPlainTextBinding{Text: t}
+		case cloudflare.WorkerSecretTextBindingType:
+			if b.SecretRef == nil {
+				return nil, errors.Errorf("%s %s: %s", errBindingPrefix, b.Name, errBindingNoSecret)
+			}
+			t, err := clients.GetSecretValue(ctx, kube, *b.SecretRef)
+			if err != nil {
+				return nil, errors.Wrapf(err, "%s %s", errBindingPrefix, b.Name)
+			}
+			out[b.Name] = cloudflare.WorkerSecretTextBinding{Text: string(t)}
+		default:
+			return nil, errors.Errorf("%s %s: %s %q", errBindingPrefix, b.Name, errBindingType, b.Type)
+		}
+	}
+	return out, nil
+}
+
+// BindingsUpToDate checks if the remote Worker Script bindings are up to
+// date with the desired bindings. The values of secret_text bindings
+// cannot be read back from Cloudflare, so only their presence is compared.
+func BindingsUpToDate(bindings map[string]cloudflare.WorkerBinding, ob []cloudflare.WorkerBindingListItem) bool {
+	if len(bindings) != len(ob) {
+		return false
+	}
+
+	for _, rb := range ob {
+		b, ok := bindings[rb.Name]
+		if !ok || b.Type() != rb.Binding.Type() {
+			return false
+		}
+		switch v := b.(type) {
+		case cloudflare.WorkerKvNamespaceBinding:
+			if v.NamespaceID != rb.Binding.(cloudflare.WorkerKvNamespaceBinding).NamespaceID {
+				return false
+			}
+		case cloudflare.WorkerPlainTextBinding:
+			if v.Text != rb.Binding.(cloudflare.WorkerPlainTextBinding).Text {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// UploadScript uploads the content and bindings of a Worker Script,
+// creating or replacing the script with the given name.
+func UploadScript(ctx context.Context, client Client, name, script string, bindings map[string]cloudflare.WorkerBinding) (cloudflare.WorkerScriptResponse, error) {
+	r, err := client.UploadWorkerWithBindings(ctx,
+		&cloudflare.WorkerRequestParams{ScriptName: name},
+		&cloudflare.WorkerScriptParams{Script: script, Bindings: bindings},
+	)
+	return r, errors.Wrap(err, errUploadScript)
+}
@@ -0,0 +1,546 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package script
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/workers/v1alpha1"
+
+	ptr "k8s.io/utils/pointer"
+)
+
+func TestResolveScript(t *testing.T) {
+	errBoom := errors.New("boom")
+	content := "addEventListener('fetch', e => e.respondWith(new Response('ok')))"
+	contentSHA256 := "a3f8d02866aec050994d85dad1a2332e7cbe17afd686e0bc5e1e92f312dcdeaf"
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/worker.js":
+			fmt.Fprint(w, content)
+		case "/large.js":
+			fmt.Fprint(w, strings.Repeat("a", maxScriptSize+1))
+		case "/redirect.js":
+			http.Redirect(w, r, "https://example.com/worker.js", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	u, _ := url.Parse(srv.URL)
+	f := NewURLFetcher(srv.Client(), []string{u.Hostname()})
+
+	type args struct {
+		kube client.Reader
+		src  v1alpha1.WorkerScriptSource
+	}
+
+	type want struct {
+		o   string
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoSource": {
+			reason: "ResolveScript should return an error if no source is set",
+			args:   args{},
+			want: want{
+				err: errors.New(errNoScriptSource),
+			},
+		},
+		"Inline": {
+			reason: "ResolveScript should return inline content as is",
+			args: args{
+				src: v1alpha1.WorkerScriptSource{Inline: ptr.StringPtr(content)},
+			},
+			want: want{
+				o: content,
+			},
+		},
+		"ConfigMap": {
+			reason: "ResolveScript should return the selected ConfigMap key",
+			args: args{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						cm := obj.(*corev1.ConfigMap)
+						cm.Data = map[string]string{"worker.js": content}
+						return nil
+					}),
+				},
+				src: v1alpha1.WorkerScriptSource{
+					ConfigMapRef: &v1alpha1.ConfigMapKeySelector{
+						Name:      "worker",
+						Namespace: "default",
+						Key:       "worker.js",
+					},
+				},
+			},
+			want: want{
+				o: content,
+			},
+		},
+		"ConfigMapMissingKey": {
+			reason: "ResolveScript should return an error if the ConfigMap key does not exist",
+			args: args{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+				},
+				src: v1alpha1.WorkerScriptSource{
+					ConfigMapRef: &v1alpha1.ConfigMapKeySelector{
+						Name:      "worker",
+						Namespace: "default",
+						Key:       "worker.js",
+					},
+				},
+			},
+			want: want{
+				err: errors.Errorf("%s: %s", errMissingKey, "worker.js"),
+			},
+		},
+		"ConfigMapGetError": {
+			reason: "ResolveScript should return an error if the ConfigMap cannot be read",
+			args: args{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+				src: v1alpha1.WorkerScriptSource{
+					ConfigMapRef: &v1alpha1.ConfigMapKeySelector{
+						Name:      "worker",
+						Namespace: "default",
+						Key:       "worker.js",
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errGetConfigMap),
+			},
+		},
+		"Secret": {
+			reason: "ResolveScript should return the selected Secret key",
+			args: args{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						s := obj.(*corev1.Secret)
+						s.Data = map[string][]byte{"worker.js": []byte(content)}
+						return nil
+					}),
+				},
+				src: v1alpha1.WorkerScriptSource{
+					SecretRef: &xpv1.SecretKeySelector{
+						SecretReference: xpv1.SecretReference{
+							Name:      "worker",
+							Namespace: "default",
+						},
+						Key: "worker.js",
+					},
+				},
+			},
+			want: want{
+				o: content,
+			},
+		},
+		"URL": {
+			reason: "ResolveScript should return the content downloaded from a URL",
+			args: args{
+				src: v1alpha1.WorkerScriptSource{URL: ptr.StringPtr(srv.URL + "/worker.js")},
+			},
+			want: want{
+				o: content,
+			},
+		},
+		"URLNotFound": {
+			reason: "ResolveScript should return an error if the URL does not return OK",
+			args: args{
+				src: v1alpha1.WorkerScriptSource{URL: ptr.StringPtr(srv.URL + "/missing.js")},
+			},
+			want: want{
+				err: errors.Errorf("%s: %d", errBadScriptStatus, http.StatusNotFound),
+			},
+		},
+		"URLChecksum": {
+			reason: "ResolveScript should return downloaded content that matches its checksum",
+			args: args{
+				src: v1alpha1.WorkerScriptSource{
+					URL:    ptr.StringPtr(srv.URL + "/worker.js"),
+					SHA256: ptr.StringPtr(contentSHA256),
+				},
+			},
+			want: want{
+				o: content,
+			},
+		},
+		"URLChecksumMismatch": {
+			reason: "ResolveScript should return an error if downloaded content does not match its checksum",
+			args: args{
+				src: v1alpha1.WorkerScriptSource{
+					URL:    ptr.StringPtr(srv.URL + "/worker.js"),
+					SHA256: ptr.StringPtr(strings.Repeat("0", 64)),
+				},
+			},
+			want: want{
+				err: errors.New(errScriptChecksum),
+			},
+		},
+		"URLNotHTTPS": {
+			reason: "ResolveScript should return an error if the URL does not use https",
+			args: args{
+				src: v1alpha1.WorkerScriptSource{URL: ptr.StringPtr("http://" + u.Host + "/worker.js")},
+			},
+			want: want{
+				err: errors.Errorf("%s: %s", errURLScheme, "http"),
+			},
+		},
+		"URLHostNotAllowed": {
+			reason: "ResolveScript should return an error if the URL host is not allowed",
+			args: args{
+				src: v1alpha1.WorkerScriptSource{URL: ptr.StringPtr("https://169.254.169.254/latest/meta-data")},
+			},
+			want: want{
+				err: errors.Errorf("%s: %s", errURLHost, "169.254.169.254"),
+			},
+		},
+		"URLRedirectNotAllowed": {
+			reason: "ResolveScript should not follow redirects to hosts that are not allowed",
+			args: args{
+				src: v1alpha1.WorkerScriptSource{URL: ptr.StringPtr(srv.URL + "/redirect.js")},
+			},
+			want: want{
+				err: errors.Wrap(&url.Error{
+					Op:  "Get",
+					URL: "https://example.com/worker.js",
+					Err: errors.Errorf("%s: %s", errURLHost, "example.com"),
+				}, errDownloadScript),
+			},
+		},
+		"URLTooLarge": {
+			reason: "ResolveScript should return an error if the downloaded script is too large",
+			args: args{
+				src: v1alpha1.WorkerScriptSource{URL: ptr.StringPtr(srv.URL + "/large.js")},
+			},
+			want: want{
+				err: errors.Errorf("%s: %d bytes", errScriptTooLarge, maxScriptSize),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ResolveScript(context.Background(), tc.args.kube, f, tc.args.src)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nResolveScript(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nResolveScript(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestResolveBindings(t *testing.T) {
+	type args struct {
+		kube client.Reader
+		bs   []v1alpha1.WorkerScriptBinding
+	}
+
+	type want struct {
+		o   map[string]cloudflare.WorkerBinding
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoBindings": {
+			reason: "ResolveBindings should return nil when there are no bindings",
+			args:   args{},
+			want:   want{},
+		},
+		"AllTypes": {
+			reason: "ResolveBindings should convert every supported binding type",
+			args: args{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						s := obj.(*corev1.Secret)
+						s.Data = map[string][]byte{"token": []byte("s3cr3t")}
+						return nil
+					}),
+				},
+				bs: []v1alpha1.WorkerScriptBinding{
+					{Name: "KV", Type: "kv_namespace", Namespace: ptr.StringPtr("ns-id")},
+					{Name: "GREETING", Type: "plain_text", Text: ptr.StringPtr("hello")},
+					{Name: "TOKEN", Type: "secret_text", SecretRef: &xpv1.SecretKeySelector{
+						SecretReference: xpv1.SecretReference{Name: "worker", Namespace: "default"},
+						Key:             "token",
+					}},
+				},
+			},
+			want: want{
+				o: map[string]cloudflare.WorkerBinding{
+					"KV":       cloudflare.WorkerKvNamespaceBinding{NamespaceID: "ns-id"},
+					"GREETING": cloudflare.WorkerPlainTextBinding{Text: "hello"},
+					"TOKEN":    cloudflare.WorkerSecretTextBinding{Text: "s3cr3t"},
+				},
+			},
+		},
+		"KVNoNamespace": {
+			reason: "ResolveBindings should return an error for a kv_namespace binding without a namespace",
+			args: args{
+				bs: []v1alpha1.WorkerScriptBinding{
+					{Name: "KV", Type: "kv_namespace"},
+				},
+			},
+			want: want{
+				err: errors.Errorf("%s %s: %s", errBindingPrefix, "KV", errBindingNoNS),
+			},
+		},
+		"SecretNoRef": {
+			reason: "ResolveBindings should return an error for a secret_text binding without a secretRef",
+			args: args{
+				bs: []v1alpha1.WorkerScriptBinding{
+					{Name: "TOKEN", Type: "secret_text"},
+				},
+			},
+			want: want{
+				err: errors.Errorf("%s %s: %s", errBindingPrefix, "TOKEN", errBindingNoSecret),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ResolveBindings(context.Background(), tc.args.kube, tc.args.bs)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nResolveBindings(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nResolveBindings(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestScriptUpToDate(t *testing.T) {
+	content := "addEventListener('fetch', e => e.respondWith(new Response('ok')))"
+	contentSHA256 := "a3f8d02866aec050994d85dad1a2332e7cbe17afd686e0bc5e1e92f312dcdeaf"
+
+	type args struct {
+		src v1alpha1.WorkerScriptSource
+		o   string
+	}
+
+	type want struct {
+		o   bool
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoSource": {
+			reason: "ScriptUpToDate should return an error if no source is set",
+			args:   args{},
+			want: want{
+				err: errors.New(errNoScriptSource),
+			},
+		},
+		"InlineDifferent": {
+			reason: "ScriptUpToDate should return false if the script content differs",
+			args: args{
+				src: v1alpha1.WorkerScriptSource{Inline: ptr.StringPtr("new")},
+				o:   "old",
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"InlineIdentical": {
+			reason: "ScriptUpToDate should return true if the script content matches",
+			args: args{
+				src: v1alpha1.WorkerScriptSource{Inline: ptr.StringPtr(content)},
+				o:   content,
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"URLNoChecksum": {
+			reason: "ScriptUpToDate should return true for a URL source without a checksum, without downloading it",
+			args: args{
+				src: v1alpha1.WorkerScriptSource{URL: ptr.StringPtr("https://example.com/worker.js")},
+				o:   "old",
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"URLChecksumDifferent": {
+			reason: "ScriptUpToDate should return false if the remote content does not match the checksum",
+			args: args{
+				src: v1alpha1.WorkerScriptSource{
+					URL:    ptr.StringPtr("https://example.com/worker.js"),
+					SHA256: ptr.StringPtr(contentSHA256),
+				},
+				o: "old",
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"URLChecksumIdentical": {
+			reason: "ScriptUpToDate should return true if the remote content matches the checksum",
+			args: args{
+				src: v1alpha1.WorkerScriptSource{
+					URL:    ptr.StringPtr("https://example.com/worker.js"),
+					SHA256: ptr.StringPtr(contentSHA256),
+				},
+				o: content,
+			},
+			want: want{
+				o: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ScriptUpToDate(context.Background(), nil, tc.args.src, tc.args.o)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nScriptUpToDate(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nScriptUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestBindingsUpToDate(t *testing.T) {
+	type args struct {
+		bindings map[string]cloudflare.WorkerBinding
+		ob       []cloudflare.WorkerBindingListItem
+	}
+
+	type want struct {
+		o bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"UpToDateEmpty": {
+			reason: "BindingsUpToDate should return true and not panic with empty values",
+			args:   args{},
+			want: want{
+				o: true,
+			},
+		},
+		"UpToDateBindingMissing": {
+			reason: "BindingsUpToDate should return false if a binding is missing remotely",
+			args: args{
+				bindings: map[string]cloudflare.WorkerBinding{
+					"GREETING": cloudflare.WorkerPlainTextBinding{Text: "hello"},
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"UpToDateBindingDifferent": {
+			reason: "BindingsUpToDate should return false if a binding value differs",
+			args: args{
+				bindings: map[string]cloudflare.WorkerBinding{
+					"KV": cloudflare.WorkerKvNamespaceBinding{NamespaceID: "new"},
+				},
+				ob: []cloudflare.WorkerBindingListItem{
+					{Name: "KV", Binding: cloudflare.WorkerKvNamespaceBinding{NamespaceID: "old"}},
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"UpToDateBindingType": {
+			reason: "BindingsUpToDate should return false if a binding type differs",
+			args: args{
+				bindings: map[string]cloudflare.WorkerBinding{
+					"GREETING": cloudflare.WorkerPlainTextBinding{Text: "hello"},
+				},
+				ob: []cloudflare.WorkerBindingListItem{
+					{Name: "GREETING", Binding: cloudflare.WorkerSecretTextBinding{}},
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"UpToDateIdentical": {
+			reason: "BindingsUpToDate should return true if bindings match, ignoring secret values",
+			args: args{
+				bindings: map[string]cloudflare.WorkerBinding{
+					"KV":       cloudflare.WorkerKvNamespaceBinding{NamespaceID: "ns-id"},
+					"GREETING": cloudflare.WorkerPlainTextBinding{Text: "hello"},
+					"TOKEN":    cloudflare.WorkerSecretTextBinding{Text: "s3cr3t"},
+				},
+				ob: []cloudflare.WorkerBindingListItem{
+					{Name: "GREETING", Binding: cloudflare.WorkerPlainTextBinding{Text: "hello"}},
+					{Name: "KV", Binding: cloudflare.WorkerKvNamespaceBinding{NamespaceID: "ns-id"}},
+					{Name: "TOKEN", Binding: cloudflare.WorkerSecretTextBinding{}},
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := BindingsUpToDate(tc.args.bindings, tc.args.ob)
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nBindingsUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
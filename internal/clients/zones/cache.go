@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zones
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/benagricola/provider-cloudflare/apis/zone/v1alpha1"
+)
+
+// A SettingsCache caches the settings observed on Zones, so that the
+// settings of each Zone are looked up at most once per TTL rather than
+// every time the Zone is observed.
+type SettingsCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]settingsEntry
+}
+
+type settingsEntry struct {
+	settings    *v1alpha1.ZoneSettings
+	nonEditable []string
+	expires     time.Time
+}
+
+// NewSettingsCache returns a SettingsCache that caches the settings of
+// each Zone for the passed TTL.
+func NewSettingsCache(ttl time.Duration) *SettingsCache {
+	return &SettingsCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]settingsEntry{},
+	}
+}
+
+// Load loads the editable settings of a Zone into the passed
+// ZoneSettings, and returns the keys of the settings that cannot be
+// edited on the Zone. Settings are looked up from Cloudflare if they
+// are not cached, or were cached longer than the TTL ago. A nil
+// SettingsCache always looks them up.
+func (c *SettingsCache) Load(ctx context.Context, client Client, zoneID string, zs *v1alpha1.ZoneSettings) ([]string, error) {
+	if c == nil {
+		return LoadSettingsForZone(ctx, client, zoneID, zs)
+	}
+
+	c.mu.Lock()
+	e, ok := c.entries[zoneID]
+	c.mu.Unlock()
+
+	if ok && c.now().Before(e.expires) {
+		e.settings.DeepCopyInto(zs)
+		return append([]string{}, e.nonEditable...), nil
+	}
+
+	ne, err := LoadSettingsForZone(ctx, client, zoneID, zs)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[zoneID] = settingsEntry{
+		settings:    zs.DeepCopy(),
+		nonEditable: append([]string{}, ne...),
+		expires:     c.now().Add(c.ttl),
+	}
+	c.mu.Unlock()
+
+	return ne, nil
+}
+
+// Invalidate removes the cached settings of a Zone, so that they are
+// looked up the next time they are loaded. Settings should be
+// invalidated whenever they are changed.
+func (c *SettingsCache) Invalidate(zoneID string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	delete(c.entries, zoneID)
+	c.mu.Unlock()
+}
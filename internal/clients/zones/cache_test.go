@@ -0,0 +1,161 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zones
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/zone/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/internal/clients/zones/fake"
+)
+
+func TestSettingsCache(t *testing.T) {
+	errBoom := errors.New("boom")
+	on, off := "on", "off"
+	start := time.Now()
+
+	type step struct {
+		at         time.Duration
+		invalidate bool
+	}
+
+	type want struct {
+		settings *v1alpha1.ZoneSettings
+		ne       []string
+		calls    int
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason string
+		cache  func() *SettingsCache
+		fail   bool
+		steps  []step
+		want   want
+	}{
+		"Nil": {
+			reason: "A nil cache should look up settings every time they are loaded",
+			cache:  func() *SettingsCache { return nil },
+			steps:  []step{{}, {}},
+			want: want{
+				settings: &v1alpha1.ZoneSettings{AlwaysOnline: &on},
+				ne:       []string{"brotli"},
+				calls:    2,
+			},
+		},
+		"Cached": {
+			reason: "Settings should only be looked up once within the TTL",
+			cache:  func() *SettingsCache { return NewSettingsCache(time.Minute) },
+			steps:  []step{{}, {at: 30 * time.Second}},
+			want: want{
+				settings: &v1alpha1.ZoneSettings{AlwaysOnline: &on},
+				ne:       []string{"brotli"},
+				calls:    1,
+			},
+		},
+		"Expired": {
+			reason: "Settings should be looked up again once the TTL has passed",
+			cache:  func() *SettingsCache { return NewSettingsCache(time.Minute) },
+			steps:  []step{{}, {at: 2 * time.Minute}},
+			want: want{
+				settings: &v1alpha1.ZoneSettings{AlwaysOnline: &on},
+				ne:       []string{"brotli"},
+				calls:    2,
+			},
+		},
+		"Invalidated": {
+			reason: "Settings should be looked up again once they are invalidated",
+			cache:  func() *SettingsCache { return NewSettingsCache(time.Minute) },
+			steps:  []step{{}, {invalidate: true}},
+			want: want{
+				settings: &v1alpha1.ZoneSettings{AlwaysOnline: &on},
+				ne:       []string{"brotli"},
+				calls:    2,
+			},
+		},
+		"Error": {
+			reason: "Settings that could not be looked up should not be cached",
+			cache:  func() *SettingsCache { return NewSettingsCache(time.Minute) },
+			fail:   true,
+			steps:  []step{{}, {}},
+			want: want{
+				settings: &v1alpha1.ZoneSettings{},
+				calls:    2,
+				err:      errors.Wrap(errBoom, errLoadSettings),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			client := fake.MockClient{
+				MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
+					calls++
+					if tc.fail {
+						return nil, errBoom
+					}
+					return &cloudflare.ZoneSettingResponse{
+						Result: []cloudflare.ZoneSetting{
+							{ID: "always_online", Value: on, Editable: true},
+							{ID: "brotli", Value: off, Editable: false},
+						},
+					}, nil
+				},
+			}
+
+			c := tc.cache()
+			var (
+				zs  *v1alpha1.ZoneSettings
+				ne  []string
+				err error
+			)
+			for _, s := range tc.steps {
+				if c != nil {
+					at := start.Add(s.at)
+					c.now = func() time.Time { return at }
+				}
+				if s.invalidate {
+					c.Invalidate("abc")
+				}
+				zs = &v1alpha1.ZoneSettings{}
+				ne, err = c.Load(context.Background(), client, "abc", zs)
+			}
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nLoad(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.settings, zs); diff != "" {
+				t.Errorf("\n%s\nLoad(...): -want settings, +got settings:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ne, ne); diff != "" {
+				t.Errorf("\n%s\nLoad(...): -want non-editable, +got non-editable:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("\n%s\nLoad(...): -want calls, +got calls:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
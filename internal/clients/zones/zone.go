@@ -18,13 +18,18 @@ package zones
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/cloudflare/cloudflare-go"
 
@@ -38,6 +43,8 @@ const (
 	errSetPlan        = "error setting plan"
 	errUpdateSettings = "error updating settings"
 
+	errSettingsDeferred = "settings cannot be updated on a zone with status %q"
+
 	// Hardcoded string in cloudflare-go library.
 	// It is used to detect a 'not found' zone
 	// lookup vs. a failed lookup.
@@ -52,6 +59,14 @@ const (
 	// DO NOT CHANGE THIS
 	errZoneInvalidID = "Invalid zone identifier"
 
+	// String returned by Cloudflare API when creating a Zone
+	// for a domain that another account has placed a hold on.
+	errZoneHeld = "zone hold"
+
+	// String returned by Cloudflare API when changing a setting
+	// that the account is not entitled to use.
+	errNotEntitled = "not entitled"
+
 	cfsZeroRTT                                  = "0rtt"
 	cfsAdvancedDDOS                             = "advanced_ddos"
 	cfsAlwaysOnline                             = "always_online"
@@ -65,11 +80,14 @@ const (
 	cfsCiphers                                  = "ciphers"
 	cfsCnameFlattening                          = "cname_flattening"
 	cfsDevelopmentMode                          = "development_mode"
+	cfsEarlyHints                               = "early_hints"
 	cfsEdgeCacheTTL                             = "edge_cache_ttl"
 	cfsEmailObfuscation                         = "email_obfuscation"
+	cfsH2Prioritization                         = "h2_prioritization"
 	cfsHotlinkProtection                        = "hotlink_protection"
 	cfsHTTP2                                    = "http2"
 	cfsHTTP3                                    = "http3"
+	cfsImageResizing                            = "image_resizing"
 	cfsIPGeolocation                            = "ip_geolocation"
 	cfsIPv6                                     = "ipv6"
 	cfsLogToCloudflare                          = "log_to_cloudflare"
@@ -88,6 +106,7 @@ const (
 	cfsOpportunisticOnion                       = "opportunistic_onion"
 	cfsOrangeToOrange                           = "orange_to_orange"
 	cfsOriginErrorPagePassThru                  = "origin_error_page_pass_thru"
+	cfsOriginMaxHTTPVersion                     = "origin_max_http_version"
 	cfsPolish                                   = "polish"
 	cfsPrefetchPreload                          = "prefetch_preload"
 	cfsPrivacyPass                              = "privacy_pass"
@@ -113,6 +132,65 @@ const (
 	cfsWebSockets                               = "websockets"
 )
 
+// settingDependencies lists the settings that each setting depends on.
+// Cloudflare validates a setting against the current values of the
+// settings it depends on, so they must be updated first. For example
+// HTTPS cannot be enforced while SSL is off.
+var settingDependencies = map[string][]string{
+	cfsAlwaysUseHTTPS:         {cfsSSL},
+	cfsAutomaticHTTPSRewrites: {cfsSSL},
+	cfsSecurityHeader:         {cfsSSL},
+}
+
+// settingDefaults are the values Cloudflare uses for settings that have
+// not been changed on a Zone. A desired setting that matches its default
+// is not treated as drift when the setting is not observed on the Zone.
+var settingDefaults = ZoneSettingsMap{
+	cfsZeroRTT:                 "off",
+	cfsAlwaysUseHTTPS:          "off",
+	cfsBrotli:                  "on",
+	cfsBrowserCacheTTL:         int64(14400),
+	cfsBrowserCheck:            "on",
+	cfsCacheLevel:              "aggressive",
+	cfsChallengeTTL:            int64(1800),
+	cfsCnameFlattening:         "flatten_at_root",
+	cfsDevelopmentMode:         "off",
+	cfsEarlyHints:              "off",
+	cfsEdgeCacheTTL:            int64(7200),
+	cfsEmailObfuscation:        "on",
+	cfsH2Prioritization:        "off",
+	cfsHotlinkProtection:       "off",
+	cfsHTTP2:                   "on",
+	cfsImageResizing:           "off",
+	cfsIPGeolocation:           "on",
+	cfsMaxUpload:               int64(100),
+	cfsMinTLSVersion:           "1.0",
+	cfsMirage:                  "off",
+	cfsOriginErrorPagePassThru: "off",
+	cfsOriginMaxHTTPVersion:    "2",
+	cfsPolish:                  "off",
+	cfsPrefetchPreload:         "off",
+	cfsPseudoIPv4:              "off",
+	cfsResponseBuffering:       "off",
+	cfsRocketLoader:            "off",
+	cfsSecurityLevel:           "medium",
+	cfsServerSideExclude:       "on",
+	cfsSortQueryStringForCache: "off",
+	cfsTrueClientIPHeader:      "off",
+	cfsWAF:                     "off",
+	cfsWebP:                    "off",
+}
+
+// planFree is the legacy ID of the Cloudflare Free plan.
+const planFree = "free"
+
+// planGatedSettings are the settings that can only be enabled on Zones
+// on a paid plan.
+var planGatedSettings = map[string]bool{
+	cfsH2Prioritization: true,
+	cfsImageResizing:    true,
+}
+
 // toMinifySettings converts an interface from the Cloudflare API
 // into a MinifySettings type.
 func toMinifySettings(in interface{}) *v1alpha1.MinifySettings {
@@ -207,10 +285,97 @@ type ZoneSettingsMap map[string]interface{}
 // IsZoneNotFound returns true if the passed error indicates
 // a Zone was not found.
 func IsZoneNotFound(err error) bool {
+	if clients.IsNotFound(err) {
+		return true
+	}
 	errStr := err.Error()
 	return errStr == errZoneNotFound || strings.Contains(errStr, errZoneInvalidID)
 }
 
+// IsZoneHeld returns true if the passed error indicates a Zone
+// could not be created because a hold exists on the domain.
+func IsZoneHeld(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), errZoneHeld)
+}
+
+// SettingsError is returned when one or more settings could not be
+// updated on a Zone. Any other changed settings were updated.
+type SettingsError struct {
+	// Errors contains the error returned when updating each setting
+	// that could not be updated, keyed by the ID of the setting.
+	Errors map[string]error
+}
+
+// Keys returns the sorted IDs of the settings that could not be updated.
+func (e *SettingsError) Keys() []string {
+	keys := make([]string, 0, len(e.Errors))
+	for k := range e.Errors {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (e *SettingsError) Error() string {
+	keys := e.Keys()
+	msgs := make([]string, len(keys))
+	for i, k := range keys {
+		msgs[i] = fmt.Sprintf("%s: %s", k, e.Errors[k])
+	}
+	return errUpdateSettings + ": " + strings.Join(msgs, ", ")
+}
+
+// statusActive is the status of a Zone that has been activated.
+const statusActive = "active"
+
+// SettingsDeferredError is returned when the settings of a Zone were not
+// updated because the Zone is not active, as Cloudflare rejects changes
+// to the settings of pending or deactivated Zones. All other changes to
+// the Zone were made.
+type SettingsDeferredError struct {
+	// Status is the status of the Zone.
+	Status string
+}
+
+func (e *SettingsDeferredError) Error() string {
+	return fmt.Sprintf(errSettingsDeferred, e.Status)
+}
+
+// SettingsDeferred returns the status of the Zone and true if the passed
+// error is a SettingsDeferredError.
+func SettingsDeferred(err error) (string, bool) {
+	sd, ok := errors.Cause(err).(*SettingsDeferredError)
+	if !ok {
+		return "", false
+	}
+	return sd.Status, true
+}
+
+// FailedSettings returns the IDs of the settings that could not be
+// updated if the passed error is a SettingsError. Settings that the
+// account is not entitled to use are returned separately from those
+// that could not be updated for any other reason, such as being invalid.
+func FailedSettings(err error) (failed, notEntitled []string) {
+	se, ok := errors.Cause(err).(*SettingsError)
+	if !ok {
+		return nil, nil
+	}
+	for _, k := range se.Keys() {
+		if IsNotEntitled(se.Errors[k]) {
+			notEntitled = append(notEntitled, k)
+			continue
+		}
+		failed = append(failed, k)
+	}
+	return failed, notEntitled
+}
+
+// IsNotEntitled returns true if the passed error indicates a setting
+// could not be changed because the account is not entitled to use it.
+func IsNotEntitled(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), errNotEntitled)
+}
+
 // Client is a Cloudflare API client that implements methods for working
 // with Zones.
 type Client interface {
@@ -251,6 +416,15 @@ func GenerateObservation(in cloudflare.Zone) v1alpha1.ZoneObservation {
 	}
 }
 
+// SettingsManagementPolicy returns the policy that determines how the
+// settings of a Zone are managed.
+func SettingsManagementPolicy(spec *v1alpha1.ZoneParameters) string {
+	if spec == nil || spec.SettingsManagementPolicy == nil {
+		return v1alpha1.SettingsPolicyManaged
+	}
+	return *spec.SettingsManagementPolicy
+}
+
 // LateInitialize initializes ZoneParameters based on the remote resource
 func LateInitialize(spec *v1alpha1.ZoneParameters, z cloudflare.Zone,
 	ozs *v1alpha1.ZoneSettings) bool {
@@ -277,10 +451,21 @@ func LateInitialize(spec *v1alpha1.ZoneParameters, z cloudflare.Zone,
 		li = true
 	}
 
+	// Only settings that are enforced on every key are initialized,
+	// so that the spec only contains the settings the user set.
+	if SettingsManagementPolicy(spec) != v1alpha1.SettingsPolicyManaged {
+		return li
+	}
+
+	// Settings outside of the allowlist are left to other tools,
+	// so they are not initialized.
+	aozs := ozs.DeepCopy()
+	FilterSettings(aozs, spec.SettingsAllowlist)
+
 	// Create a settings map from our Desired and Observed
 	// Settings, so we can work out which fields need initialising.
 	desired := zoneToSettingsMap(&spec.Settings)
-	observed := zoneToSettingsMap(ozs)
+	observed := zoneToSettingsMap(aozs)
 
 	if LateInitializeSettings(observed, desired, &spec.Settings) {
 		li = true
@@ -409,29 +594,84 @@ func LateInitializeSettings(observed, desired ZoneSettingsMap, initOn *v1alpha1.
 	return li || nestedLateInit
 }
 
-// LoadSettingsForZone loads Zone settings from the cloudflare API
-// and returns a ZoneSettingsMap.
+// LoadSettingsForZone loads the editable Zone settings from the cloudflare
+// API into the passed ZoneSettings, and returns the keys of the settings
+// that cannot be edited on the Zone.
 func LoadSettingsForZone(ctx context.Context,
-	client Client, zoneID string, zs *v1alpha1.ZoneSettings) error {
+	client Client, zoneID string, zs *v1alpha1.ZoneSettings) ([]string, error) {
 
 	// Get settings
 	sr, err := client.ZoneSettings(ctx, zoneID)
 	if err != nil {
-		return errors.Wrap(err, errLoadSettings)
+		return nil, errors.Wrap(err, errLoadSettings)
 	}
 
 	// Parse the result into a map based on key
 	sbk := ZoneSettingsMap{}
+	ne := []string{}
 
 	for _, setting := range sr.Result {
 		// Ignore settings we cant edit
 		if !setting.Editable {
+			ne = append(ne, setting.ID)
 			continue
 		}
 		sbk[setting.ID] = setting.Value
 	}
 	settingsMapToZone(sbk, zs)
-	return nil
+	return ne, nil
+}
+
+// NonEditableSettings returns the keys of the passed settings that
+// are set but cannot be edited on the Zone, sorted by key. A setting
+// may stop being editable if the plan of the Zone is changed.
+func NonEditableSettings(zs *v1alpha1.ZoneSettings, nonEditable []string) []string {
+	sm := zoneToSettingsMap(zs)
+	out := []string{}
+	for _, k := range nonEditable {
+		if _, ok := sm[k]; ok {
+			out = append(out, k)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// RemoveSettings unsets the settings with the passed keys
+// on the passed ZoneSettings.
+func RemoveSettings(zs *v1alpha1.ZoneSettings, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	sm := zoneToSettingsMap(zs)
+	for _, k := range keys {
+		delete(sm, k)
+	}
+
+	*zs = v1alpha1.ZoneSettings{}
+	settingsMapToZone(sm, zs)
+}
+
+// FilterSettings unsets the settings that are not in the passed
+// allowlist of keys. All settings are kept when the allowlist is empty.
+func FilterSettings(zs *v1alpha1.ZoneSettings, allowlist []string) {
+	if zs == nil || len(allowlist) == 0 {
+		return
+	}
+
+	allowed := make(map[string]bool, len(allowlist))
+	for _, k := range allowlist {
+		allowed[k] = true
+	}
+
+	var keys []string
+	for k := range zoneToSettingsMap(zs) {
+		if !allowed[k] {
+			keys = append(keys, k)
+		}
+	}
+	RemoveSettings(zs, keys...)
 }
 
 // settingsMapToZone uses static definitions to map each setting
@@ -450,11 +690,14 @@ func settingsMapToZone(sm ZoneSettingsMap, zs *v1alpha1.ZoneSettings) {
 	zs.Ciphers = clients.ToStringSlice(sm[cfsCiphers])
 	zs.CnameFlattening = clients.ToString(sm[cfsCnameFlattening])
 	zs.DevelopmentMode = clients.ToString(sm[cfsDevelopmentMode])
+	zs.EarlyHints = clients.ToString(sm[cfsEarlyHints])
 	zs.EdgeCacheTTL = clients.ToNumber(sm[cfsEdgeCacheTTL])
 	zs.EmailObfuscation = clients.ToString(sm[cfsEmailObfuscation])
+	zs.H2Prioritization = clients.ToString(sm[cfsH2Prioritization])
 	zs.HotlinkProtection = clients.ToString(sm[cfsHotlinkProtection])
 	zs.HTTP2 = clients.ToString(sm[cfsHTTP2])
 	zs.HTTP3 = clients.ToString(sm[cfsHTTP3])
+	zs.ImageResizing = clients.ToString(sm[cfsImageResizing])
 	zs.IPGeolocation = clients.ToString(sm[cfsIPGeolocation])
 	zs.IPv6 = clients.ToString(sm[cfsIPv6])
 	zs.LogToCloudflare = clients.ToString(sm[cfsLogToCloudflare])
@@ -467,6 +710,7 @@ func settingsMapToZone(sm ZoneSettingsMap, zs *v1alpha1.ZoneSettings) {
 	zs.OpportunisticOnion = clients.ToString(sm[cfsOpportunisticOnion])
 	zs.OrangeToOrange = clients.ToString(sm[cfsOrangeToOrange])
 	zs.OriginErrorPagePassThru = clients.ToString(sm[cfsOriginErrorPagePassThru])
+	zs.OriginMaxHTTPVersion = clients.ToString(sm[cfsOriginMaxHTTPVersion])
 	zs.Polish = clients.ToString(sm[cfsPolish])
 	zs.PrefetchPreload = clients.ToString(sm[cfsPrefetchPreload])
 	zs.PrivacyPass = clients.ToString(sm[cfsPrivacyPass])
@@ -565,9 +809,11 @@ func mapSet(sm ZoneSettingsMap, key string, value interface{}) { //nolint:gocycl
 		if vt != nil {
 			sm[key] = *vt
 		}
+	// An empty list of ciphers means the Cloudflare defaults are used,
+	// so it is treated the same as an unset value.
 	case []string:
-		if vt != nil {
-			sm[key] = vt
+		if cs := clients.ManagedCiphers(vt); cs != nil {
+			sm[key] = cs
 		}
 	case *v1alpha1.MinifySettings:
 		if vt != nil {
@@ -604,11 +850,14 @@ func zoneToSettingsMap(zs *v1alpha1.ZoneSettings) ZoneSettingsMap {
 	mapSet(sm, cfsCiphers, zs.Ciphers)
 	mapSet(sm, cfsCnameFlattening, zs.CnameFlattening)
 	mapSet(sm, cfsDevelopmentMode, zs.DevelopmentMode)
+	mapSet(sm, cfsEarlyHints, zs.EarlyHints)
 	mapSet(sm, cfsEdgeCacheTTL, zs.EdgeCacheTTL)
 	mapSet(sm, cfsEmailObfuscation, zs.EmailObfuscation)
+	mapSet(sm, cfsH2Prioritization, zs.H2Prioritization)
 	mapSet(sm, cfsHotlinkProtection, zs.HotlinkProtection)
 	mapSet(sm, cfsHTTP2, zs.HTTP2)
 	mapSet(sm, cfsHTTP3, zs.HTTP3)
+	mapSet(sm, cfsImageResizing, zs.ImageResizing)
 	mapSet(sm, cfsIPGeolocation, zs.IPGeolocation)
 	mapSet(sm, cfsIPv6, zs.IPv6)
 	mapSet(sm, cfsLogToCloudflare, zs.LogToCloudflare)
@@ -621,6 +870,7 @@ func zoneToSettingsMap(zs *v1alpha1.ZoneSettings) ZoneSettingsMap {
 	mapSet(sm, cfsOpportunisticOnion, zs.OpportunisticOnion)
 	mapSet(sm, cfsOrangeToOrange, zs.OrangeToOrange)
 	mapSet(sm, cfsOriginErrorPagePassThru, zs.OriginErrorPagePassThru)
+	mapSet(sm, cfsOriginMaxHTTPVersion, zs.OriginMaxHTTPVersion)
 	mapSet(sm, cfsPolish, zs.Polish)
 	mapSet(sm, cfsPrefetchPreload, zs.PrefetchPreload)
 	mapSet(sm, cfsPrivacyPass, zs.PrivacyPass)
@@ -642,23 +892,67 @@ func zoneToSettingsMap(zs *v1alpha1.ZoneSettings) ZoneSettingsMap {
 	return sm
 }
 
+// mergeSetting returns the value of a setting to send to Cloudflare and
+// whether it differs from the current value. Nested settings are merged
+// over their current values, so that the full object is sent, but only
+// the nested fields that are specified are considered when comparing.
+func mergeSetting(cv, nv interface{}) (interface{}, bool) {
+	nm, ok := nv.(map[string]interface{})
+	if !ok {
+		return nv, !cmp.Equal(cv, nv)
+	}
+
+	cm, _ := cv.(map[string]interface{})
+
+	out := make(map[string]interface{}, len(cm)+len(nm))
+	for k, v := range cm {
+		out[k] = v
+	}
+
+	changed := false
+	for k, v := range nm {
+		mv, c := mergeSetting(cm[k], v)
+		out[k] = mv
+		changed = changed || c
+	}
+
+	return out, changed
+}
+
+// settingsWithDefaults returns a copy of the observed settings with the
+// default value filled in for any desired setting that was not observed.
+func settingsWithDefaults(ozs, dzs *v1alpha1.ZoneSettings) *v1alpha1.ZoneSettings {
+	observed := zoneToSettingsMap(ozs)
+	for k := range zoneToSettingsMap(dzs) {
+		if _, ok := observed[k]; ok {
+			continue
+		}
+		if dv, ok := settingDefaults[k]; ok {
+			observed[k] = dv
+		}
+	}
+
+	out := &v1alpha1.ZoneSettings{}
+	settingsMapToZone(observed, out)
+	return out
+}
+
 // GetChangedSettings builds a map of only the settings whose
 // values need to be updated.
 func GetChangedSettings(czs, dzs *v1alpha1.ZoneSettings) []cloudflare.ZoneSetting {
 	out := []cloudflare.ZoneSetting{}
 
-	current := zoneToSettingsMap(czs)
+	current := zoneToSettingsMap(settingsWithDefaults(czs, dzs))
 	desired := zoneToSettingsMap(dzs)
 
 	for k, nv := range desired {
-		cv := current[k]
 		// If the current value and new value are not the same,
 		// append a ZoneSetting entry to the output list, in
 		// preparation for updating.
-		if !cmp.Equal(cv, nv) {
+		if v, changed := mergeSetting(current[k], nv); changed {
 			zs := cloudflare.ZoneSetting{
 				ID:    k,
-				Value: nv,
+				Value: v,
 			}
 			out = append(out, zs)
 		}
@@ -666,6 +960,177 @@ func GetChangedSettings(czs, dzs *v1alpha1.ZoneSettings) []cloudflare.ZoneSettin
 	return out
 }
 
+// SettingsHash returns a hash of the passed settings, which changes
+// whenever the value of any setting changes.
+func SettingsHash(zs *v1alpha1.ZoneSettings) string {
+	// ZoneSettings only contains strings, numbers and slices or
+	// structs of these, so marshalling it cannot fail.
+	b, _ := json.Marshal(zs)
+	return fmt.Sprintf("%x", sha256.Sum256(b))
+}
+
+// SettingHashes returns a short hash of the value of each of the passed
+// settings that is set, keyed by the ID of the setting. The hashes are
+// only used to tell which settings changed, so a few bytes of each are
+// enough and keep the status of a Zone small.
+func SettingHashes(zs *v1alpha1.ZoneSettings) map[string]string {
+	sm := zoneToSettingsMap(zs)
+	if len(sm) == 0 {
+		return nil
+	}
+
+	hashes := make(map[string]string, len(sm))
+	for k, v := range sm {
+		// Settings only contain strings, numbers and slices or maps
+		// of these, so marshalling them cannot fail.
+		b, _ := json.Marshal(v)
+		h := sha256.Sum256(b)
+		hashes[k] = fmt.Sprintf("%x", h[:4])
+	}
+	return hashes
+}
+
+// changedHashKeys returns the sorted keys whose hash differs between the
+// passed setting hashes, including keys that are only in one of them.
+func changedHashKeys(prev, cur map[string]string) []string {
+	var keys []string
+	for k, h := range cur {
+		if prev[k] != h {
+			keys = append(keys, k)
+		}
+	}
+	for k := range prev {
+		if _, ok := cur[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ChangedSettingKeys returns the sorted keys of the desired settings
+// whose current value needs to be updated.
+func ChangedSettingKeys(czs, dzs *v1alpha1.ZoneSettings) []string {
+	cs := GetChangedSettings(czs, dzs)
+	if len(cs) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(cs))
+	for i, s := range cs {
+		keys[i] = s.ID
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// PlanGatedSettings returns the sorted keys of the passed settings that
+// are enabled but cannot be enabled on a Zone on the passed plan.
+func PlanGatedSettings(zs *v1alpha1.ZoneSettings, plan cloudflare.ZonePlan) []string {
+	if plan.LegacyID != planFree {
+		return nil
+	}
+
+	var keys []string
+	for k, v := range zoneToSettingsMap(zs) {
+		if planGatedSettings[k] && v == "on" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// TypePartial is the type of a Zone that uses a CNAME setup, where
+// DNS is hosted elsewhere and only some hostnames are proxied.
+const TypePartial = "partial"
+
+// verificationRecordName is the name, relative to a partial Zone, of the
+// TXT record that proves ownership of the Zone.
+const verificationRecordName = "cloudflare-verify"
+
+// cdnSuffix is the suffix of the target of the CNAME record that sends
+// the traffic of a hostname on a partial Zone to Cloudflare.
+const cdnSuffix = ".cdn.cloudflare.net"
+
+// ActivationRecords returns the DNS records that must be created with the
+// DNS provider of the passed partial Zone before it can be activated.
+// Each proxied hostname of the Zone also needs a CNAME record pointing
+// it at Cloudflare, which is described by the last record returned.
+// Nothing is returned for Zones that are not partial.
+func ActivationRecords(z cloudflare.Zone) []string {
+	if z.Type != TypePartial {
+		return nil
+	}
+
+	var rrs []string
+	if z.VerificationKey != "" {
+		rrs = append(rrs, "TXT "+verificationRecordName+"."+z.Name+" "+z.VerificationKey)
+	}
+	return append(rrs, "CNAME <hostname>."+z.Name+" <hostname>."+z.Name+cdnSuffix)
+}
+
+// ManagedSettingKeys returns the sorted keys of the settings that are
+// set on the passed ZoneSettings, and so are managed by Crossplane.
+func ManagedSettingKeys(zs *v1alpha1.ZoneSettings) []string {
+	sm := zoneToSettingsMap(zs)
+	if len(sm) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(sm))
+	for k := range sm {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SetSettingsSnapshot records a snapshot of the observed settings on the
+// passed observation, storing hashes of the settings rather than the
+// settings themselves. The time the settings last changed, and which of
+// them changed, are carried over from the previous observation unless
+// its hash differs. Which settings changed is only known if the previous
+// observation recorded the hash of each setting.
+func SetSettingsSnapshot(o *v1alpha1.ZoneObservation, prev v1alpha1.ZoneObservation,
+	ozs, dzs *v1alpha1.ZoneSettings, now metav1.Time) {
+
+	o.SettingsHash = SettingsHash(ozs)
+	o.SettingHashes = SettingHashes(ozs)
+	o.SettingsLastChanged = prev.SettingsLastChanged
+	o.LastChangedSettings = prev.LastChangedSettings
+	if prev.SettingsHash != "" && prev.SettingsHash != o.SettingsHash {
+		o.SettingsLastChanged = &now
+		o.LastChangedSettings = nil
+		if prev.SettingHashes != nil {
+			o.LastChangedSettings = changedHashKeys(prev.SettingHashes, o.SettingHashes)
+		}
+	}
+	o.ChangedSettings = ChangedSettingKeys(ozs, dzs)
+}
+
+// RefreshSettingsSnapshot records a snapshot of the settings observed
+// after an update on the passed observation. Only settings that needed
+// to be updated before the update can still need to be updated, so any
+// others, such as settings that cannot be edited, are not reported.
+func RefreshSettingsSnapshot(o *v1alpha1.ZoneObservation, ozs, dzs *v1alpha1.ZoneSettings, now metav1.Time) {
+	prev := *o.DeepCopy()
+	SetSettingsSnapshot(o, prev, ozs, dzs, now)
+
+	before := map[string]bool{}
+	for _, k := range prev.ChangedSettings {
+		before[k] = true
+	}
+
+	var changed []string
+	for _, k := range o.ChangedSettings {
+		if before[k] {
+			changed = append(changed, k)
+		}
+	}
+	o.ChangedSettings = changed
+}
+
 // UpToDate checks if the remote resource is up to date with the
 // requested resource parameters.
 func UpToDate(spec *v1alpha1.ZoneParameters, z cloudflare.Zone, ozs *v1alpha1.ZoneSettings) bool { //nolint:gocyclo
@@ -705,14 +1170,28 @@ func UpToDate(spec *v1alpha1.ZoneParameters, z cloudflare.Zone, ozs *v1alpha1.Zo
 	// Have a look at https://pkg.go.dev/github.com/google/go-cmp@v0.5.4/cmp/cmpopts
 	// to see if what you're looking for is supported by the cmp library
 	// before implementing here.
-	if !cmp.Equal(*ozs, spec.Settings) {
+	// Settings outside of the allowlist are never compared.
+	dzs, aozs := spec.Settings.DeepCopy(), ozs.DeepCopy()
+	FilterSettings(dzs, spec.SettingsAllowlist)
+	FilterSettings(aozs, spec.SettingsAllowlist)
+
+	// Only the settings, and nested fields of settings, that are set in
+	// spec are compared, as the others are never late initialized into
+	// spec.
+	if SettingsManagementPolicy(spec) == v1alpha1.SettingsPolicyManagedSubsetOnly {
+		return len(GetChangedSettings(aozs, dzs)) == 0
+	}
+
+	if !cmp.Equal(*settingsWithDefaults(aozs, dzs), *dzs, cmpopts.EquateEmpty()) {
 		return false
 	}
 	return true
 }
 
-// UpdateZone updates mutable values on a Zone
-func UpdateZone(ctx context.Context, client Client, zoneID string, spec v1alpha1.ZoneParameters) error { //nolint:gocyclo
+// UpdateZone updates mutable values on a Zone. The settings of the Zone
+// after the update, as returned by Cloudflare, are loaded into ozs so
+// that they can be observed without looking them up again.
+func UpdateZone(ctx context.Context, client Client, zoneID string, spec v1alpha1.ZoneParameters, ozs *v1alpha1.ZoneSettings, unapplied []string) error { //nolint:gocyclo
 	// Get current zone status
 	z, err := client.ZoneDetails(ctx, zoneID)
 	if err != nil {
@@ -753,21 +1232,157 @@ func UpdateZone(ctx context.Context, client Client, zoneID string, spec v1alpha1
 		}
 	}
 
+	// Settings cannot be changed until the Zone is active, so they
+	// are left until it is rather than failing to update them.
+	if z.Status != statusActive {
+		return &SettingsDeferredError{Status: z.Status}
+	}
+
 	// We don't store observed settings so look them up before changing.
-	curSettings := v1alpha1.ZoneSettings{}
-	err = LoadSettingsForZone(ctx, client, zoneID, &curSettings)
+	ne, err := LoadSettingsForZone(ctx, client, zoneID, ozs)
 	if err != nil {
 		return errors.Wrap(err, errUpdateSettings)
 	}
 
+	// Settings that cannot be edited would only fail to update,
+	// so we do not try to change them. Settings outside of the
+	// allowlist are never written.
+	desired := spec.Settings.DeepCopy()
+	FilterSettings(desired, spec.SettingsAllowlist)
+	RemoveSettings(desired, NonEditableSettings(desired, ne)...)
+
 	// See if any settings were updated, otherwise return
 	// update is complete.
-	cs := GetChangedSettings(&curSettings, &spec.Settings)
+	cs := GetChangedSettings(ozs, desired)
 	if len(cs) < 1 {
 		return nil
 	}
 
 	// One or more settings were changed, so update them and return.
-	_, err = client.UpdateZoneSettings(ctx, zoneID, cs)
-	return errors.Wrap(err, errUpdateSettings)
+	return updateSettings(ctx, client, zoneID, cs, ozs, unapplied)
+}
+
+// applySettings sets the values of the passed settings, as returned by
+// Cloudflare after updating them, on the passed ZoneSettings.
+func applySettings(zs *v1alpha1.ZoneSettings, updated []cloudflare.ZoneSetting) {
+	if len(updated) == 0 {
+		return
+	}
+
+	sm := zoneToSettingsMap(zs)
+	for _, s := range updated {
+		sm[s.ID] = s.Value
+	}
+
+	*zs = v1alpha1.ZoneSettings{}
+	settingsMapToZone(sm, zs)
+}
+
+// OrderSettings splits the passed settings into the fewest stages that
+// update each setting after the settings it depends on. The settings in
+// each stage are sorted by ID.
+func OrderSettings(cs []cloudflare.ZoneSetting) [][]cloudflare.ZoneSetting {
+	changed := make(map[string]bool, len(cs))
+	for _, s := range cs {
+		changed[s.ID] = true
+	}
+
+	// A setting is updated in the stage after the last of the changed
+	// settings it depends on.
+	stages := map[string]int{}
+	var stage func(id string) int
+	stage = func(id string) int {
+		if st, ok := stages[id]; ok {
+			return st
+		}
+		st := 0
+		for _, d := range settingDependencies[id] {
+			if !changed[d] {
+				continue
+			}
+			if ds := stage(d) + 1; ds > st {
+				st = ds
+			}
+		}
+		stages[id] = st
+		return st
+	}
+
+	sorted := make([]cloudflare.ZoneSetting, len(cs))
+	copy(sorted, cs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	var out [][]cloudflare.ZoneSetting
+	for _, s := range sorted {
+		st := stage(s.ID)
+		for len(out) <= st {
+			out = append(out, nil)
+		}
+		out[st] = append(out[st], s)
+	}
+	return out
+}
+
+// updateSettings updates the passed settings on a Zone, making one
+// update for each stage of settings returned by OrderSettings so that
+// settings are updated after the settings they depend on. If the
+// settings of a stage cannot all be updated together, each setting is
+// updated on its own so that a single invalid setting does not stop the
+// others from converging. Settings that could not be applied by a
+// previous update are updated on their own straight away, so that the
+// other settings are not updated one by one on every update until they
+// are applied. The updated values returned by Cloudflare are set on ozs.
+func updateSettings(ctx context.Context, client Client, zoneID string, cs []cloudflare.ZoneSetting, ozs *v1alpha1.ZoneSettings, unapplied []string) error {
+	isolated := make(map[string]bool, len(unapplied))
+	for _, k := range unapplied {
+		isolated[k] = true
+	}
+
+	failed := map[string]error{}
+	for _, st := range OrderSettings(cs) {
+		together := make([]cloudflare.ZoneSetting, 0, len(st))
+		for _, s := range st {
+			if !isolated[s.ID] {
+				together = append(together, s)
+				continue
+			}
+			updateSettingsStage(ctx, client, zoneID, []cloudflare.ZoneSetting{s}, ozs, failed)
+		}
+		if len(together) > 0 {
+			updateSettingsStage(ctx, client, zoneID, together, ozs, failed)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return &SettingsError{Errors: failed}
+}
+
+// updateSettingsStage updates the passed settings on a Zone together,
+// falling back to updating each setting on its own. The error returned
+// for each setting that could not be updated is added to failed.
+func updateSettingsStage(ctx context.Context, client Client, zoneID string, cs []cloudflare.ZoneSetting, ozs *v1alpha1.ZoneSettings, failed map[string]error) {
+	sr, err := client.UpdateZoneSettings(ctx, zoneID, cs)
+	if err == nil {
+		if sr != nil {
+			applySettings(ozs, sr.Result)
+		}
+		return
+	}
+
+	if len(cs) == 1 {
+		failed[cs[0].ID] = err
+		return
+	}
+
+	for _, s := range cs {
+		sr, err := client.UpdateZoneSettings(ctx, zoneID, []cloudflare.ZoneSetting{s})
+		if err != nil {
+			failed[s.ID] = err
+			continue
+		}
+		if sr != nil {
+			applySettings(ozs, sr.Result)
+		}
+	}
 }
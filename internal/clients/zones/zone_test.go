@@ -19,12 +19,14 @@ package zones
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/cloudflare/cloudflare-go"
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/pkg/errors"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ptr "k8s.io/utils/pointer"
 
 	"github.com/crossplane/crossplane-runtime/pkg/test"
@@ -288,6 +290,74 @@ func TestLateInitialize(t *testing.T) {
 				},
 			},
 		},
+		"SuccessSettingsAllowlist": {
+			reason: "LateInit should only update settings in the allowlist from a Zone",
+			args: args{
+				zp: &v1alpha1.ZoneParameters{
+					AccountID:         ptr.StringPtr("beef"),
+					Paused:            ptr.BoolPtr(false),
+					PlanID:            ptr.StringPtr("dead"),
+					SettingsAllowlist: []string{cfsWAF},
+				},
+				z: cloudflare.Zone{
+					Account: cloudflare.Account{
+						ID: "beef",
+					},
+					Plan: cloudflare.ZonePlan{
+						ZonePlanCommon: cloudflare.ZonePlanCommon{
+							ID: "dead",
+						},
+					},
+				},
+				// Only WAF should be late-inited here.
+				czs: &v1alpha1.ZoneSettings{
+					WAF:           ptr.StringPtr("on"),
+					SecurityLevel: ptr.StringPtr("high"),
+				},
+			},
+			want: want{
+				o: true,
+				zp: &v1alpha1.ZoneParameters{
+					AccountID:         ptr.StringPtr("beef"),
+					Paused:            ptr.BoolPtr(false),
+					PlanID:            ptr.StringPtr("dead"),
+					SettingsAllowlist: []string{cfsWAF},
+					Settings: v1alpha1.ZoneSettings{
+						WAF: ptr.StringPtr("on"),
+					},
+				},
+			},
+		},
+		"SettingsManagedSubsetOnly": {
+			reason: "LateInit should not initialize settings unless every setting is managed",
+			args: args{
+				zp: &v1alpha1.ZoneParameters{
+					AccountID:                ptr.StringPtr("beef"),
+					Paused:                   ptr.BoolPtr(false),
+					PlanID:                   ptr.StringPtr("dead"),
+					SettingsManagementPolicy: ptr.StringPtr(v1alpha1.SettingsPolicyManagedSubsetOnly),
+					Settings: v1alpha1.ZoneSettings{
+						SSL: ptr.StringPtr("strict"),
+					},
+				},
+				czs: &v1alpha1.ZoneSettings{
+					SSL:    ptr.StringPtr("full"),
+					Brotli: ptr.StringPtr("on"),
+				},
+			},
+			want: want{
+				o: false,
+				zp: &v1alpha1.ZoneParameters{
+					AccountID:                ptr.StringPtr("beef"),
+					Paused:                   ptr.BoolPtr(false),
+					PlanID:                   ptr.StringPtr("dead"),
+					SettingsManagementPolicy: ptr.StringPtr(v1alpha1.SettingsPolicyManagedSubsetOnly),
+					Settings: v1alpha1.ZoneSettings{
+						SSL: ptr.StringPtr("strict"),
+					},
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -302,6 +372,68 @@ func TestLateInitialize(t *testing.T) {
 		})
 	}
 }
+func TestFilterSettings(t *testing.T) {
+	type args struct {
+		zs        *v1alpha1.ZoneSettings
+		allowlist []string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   *v1alpha1.ZoneSettings
+	}{
+		"Nil": {
+			reason: "FilterSettings should not panic when passed nil settings",
+			args: args{
+				allowlist: []string{cfsWAF},
+			},
+		},
+		"EmptyAllowlist": {
+			reason: "FilterSettings should keep all settings when the allowlist is empty",
+			args: args{
+				zs: &v1alpha1.ZoneSettings{
+					ZeroRTT: ptr.StringPtr("on"),
+					WAF:     ptr.StringPtr("on"),
+				},
+			},
+			want: &v1alpha1.ZoneSettings{
+				ZeroRTT: ptr.StringPtr("on"),
+				WAF:     ptr.StringPtr("on"),
+			},
+		},
+		"Allowlist": {
+			reason: "FilterSettings should unset settings that are not in the allowlist",
+			args: args{
+				zs: &v1alpha1.ZoneSettings{
+					ZeroRTT:      ptr.StringPtr("on"),
+					WAF:          ptr.StringPtr("on"),
+					EdgeCacheTTL: ptr.Int64Ptr(7200),
+					Minify: &v1alpha1.MinifySettings{
+						CSS: ptr.StringPtr("on"),
+					},
+				},
+				allowlist: []string{cfsWAF, cfsMinify, cfsSecurityLevel},
+			},
+			want: &v1alpha1.ZoneSettings{
+				WAF: ptr.StringPtr("on"),
+				Minify: &v1alpha1.MinifySettings{
+					CSS: ptr.StringPtr("on"),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			FilterSettings(tc.args.zs, tc.args.allowlist)
+			if diff := cmp.Diff(tc.want, tc.args.zs); diff != "" {
+				t.Errorf("\n%s\nFilterSettings(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestUpToDate(t *testing.T) {
 	type args struct {
 		zp  *v1alpha1.ZoneParameters
@@ -451,13 +583,16 @@ func TestUpToDate(t *testing.T) {
 				o: false,
 			},
 		},
-		"VanityNSTrue": {
-			reason: "UpToDate should return true if VanityNS field matches in any order",
+		"SettingsDefaultNotObserved": {
+			reason: "UpToDate should return true if unobserved settings match their defaults",
 			args: args{
 				zp: &v1alpha1.ZoneParameters{
-					PlanID:            ptr.StringPtr("cake"),
-					Settings:          v1alpha1.ZoneSettings{},
-					VanityNameServers: []string{"ns2.woowoo.org", "ns1.lele.com"},
+					PlanID: ptr.StringPtr("cake"),
+					Settings: v1alpha1.ZoneSettings{
+						ZeroRTT:       ptr.StringPtr("on"),
+						SecurityLevel: ptr.StringPtr("medium"),
+						MaxUpload:     ptr.Int64Ptr(100),
+					},
 				},
 				z: cloudflare.Zone{
 					PlanPending: cloudflare.ZonePlan{
@@ -465,137 +600,402 @@ func TestUpToDate(t *testing.T) {
 							ID: "cake",
 						},
 					},
-					VanityNS: []string{"ns1.lele.com", "ns2.woowoo.org"},
 				},
-				ozs: &v1alpha1.ZoneSettings{},
+				ozs: &v1alpha1.ZoneSettings{
+					ZeroRTT: ptr.StringPtr("on"),
+				},
 			},
 			want: want{
 				o: true,
 			},
 		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			got := UpToDate(tc.args.zp, tc.args.z, tc.args.ozs)
-			if diff := cmp.Diff(tc.want.o, got); diff != "" {
-				t.Errorf("\n%s\nUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
-			}
-		})
-	}
-}
-
-func TestUpdateZone(t *testing.T) {
-	errBoom := errors.New("boom")
-
-	inputZoneID := "1234"
-	nsKey := cfsMinify
-
-	nsInputValue := v1alpha1.MinifySettings{
-		CSS:  ptr.StringPtr("on"),
-		HTML: ptr.StringPtr("off"),
-		JS:   ptr.StringPtr("bar"),
-	}
-
-	type fields struct {
-		client Client
-	}
-
-	type args struct {
-		ctx context.Context
-		id  string
-		zp  v1alpha1.ZoneParameters
-	}
-
-	type want struct {
-		err error
-	}
-
-	cases := map[string]struct {
-		reason string
-		fields fields
-		args   args
-		want   want
-	}{
-		"UpdateZoneNotFound": {
-			reason: "UpdateZone should return errUpdateZone if the zone is not found",
-			fields: fields{
-				client: fake.MockClient{
-					// When the ZoneDetails method is called on this fake client,
-					// return an error that we can compare.
-					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
-						return cloudflare.Zone{}, errBoom
+		"SettingsNonDefaultNotObserved": {
+			reason: "UpToDate should return false if unobserved settings do not match their defaults",
+			args: args{
+				zp: &v1alpha1.ZoneParameters{
+					PlanID: ptr.StringPtr("cake"),
+					Settings: v1alpha1.ZoneSettings{
+						SecurityLevel: ptr.StringPtr("under_attack"),
+					},
+				},
+				z: cloudflare.Zone{
+					PlanPending: cloudflare.ZonePlan{
+						ZonePlanCommon: cloudflare.ZonePlanCommon{
+							ID: "cake",
+						},
 					},
 				},
+				ozs: &v1alpha1.ZoneSettings{},
+			},
+			want: want{
+				o: false,
 			},
+		},
+		"SettingsAllowlistUnlistedChanged": {
+			reason: "UpToDate should return true if only settings outside of the allowlist have changed",
 			args: args{
-				id: inputZoneID,
+				zp: &v1alpha1.ZoneParameters{
+					PlanID: ptr.StringPtr("cake"),
+					Settings: v1alpha1.ZoneSettings{
+						WAF:           ptr.StringPtr("on"),
+						SecurityLevel: ptr.StringPtr("high"),
+					},
+					SettingsAllowlist: []string{cfsWAF},
+				},
+				z: cloudflare.Zone{
+					PlanPending: cloudflare.ZonePlan{
+						ZonePlanCommon: cloudflare.ZonePlanCommon{
+							ID: "cake",
+						},
+					},
+				},
+				ozs: &v1alpha1.ZoneSettings{
+					WAF:           ptr.StringPtr("on"),
+					SecurityLevel: ptr.StringPtr("low"),
+				},
 			},
 			want: want{
-				err: errors.Wrap(errBoom, errUpdateZone),
+				o: true,
 			},
 		},
-		"UpdateZoneOptions": {
-			reason: "UpdateZone should return no error when updating zone options",
-			fields: fields{
-				client: fake.MockClient{
-					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
-						return cloudflare.Zone{
-							ID:       zoneID,
-							Name:     "testzone.com",
-							Paused:   true,
-							VanityNS: []string{"ns1.lele.com"},
-						}, nil
-					},
-					// When EditZone is called, check it receives the expected arguments.
-					// If it doesn't we return an error which will cause the test to fail.
-					MockEditZone: func(ctx context.Context, zoneID string, zoneOpts cloudflare.ZoneOptions) (cloudflare.Zone, error) {
-						var err error
-						if zoneID != inputZoneID {
-							err = errors.New("zoneID value incorrect")
-						}
-						if *zoneOpts.Paused != false {
-							err = errors.New("zoneOpts.Paused value incorrect")
-						}
-
-						if !cmp.Equal(zoneOpts.VanityNS,
-							[]string{"ns1.lele.com", "ns2.woowoo.org"}) {
-							err = errors.New("zoneOpts.VanityNS does not match")
-						}
-						// Returned zone is discarded by UpdateZone
-						return cloudflare.Zone{}, err
+		"SettingsAllowlistListedChanged": {
+			reason: "UpToDate should return false if a setting in the allowlist has changed",
+			args: args{
+				zp: &v1alpha1.ZoneParameters{
+					PlanID: ptr.StringPtr("cake"),
+					Settings: v1alpha1.ZoneSettings{
+						WAF:           ptr.StringPtr("on"),
+						SecurityLevel: ptr.StringPtr("high"),
 					},
-
-					MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
-						return &cloudflare.ZoneSettingResponse{}, nil
+					SettingsAllowlist: []string{cfsWAF},
+				},
+				z: cloudflare.Zone{
+					PlanPending: cloudflare.ZonePlan{
+						ZonePlanCommon: cloudflare.ZonePlanCommon{
+							ID: "cake",
+						},
 					},
 				},
+				ozs: &v1alpha1.ZoneSettings{
+					WAF:           ptr.StringPtr("off"),
+					SecurityLevel: ptr.StringPtr("high"),
+				},
+			},
+			want: want{
+				o: false,
 			},
+		},
+		"SettingsWAFChanged": {
+			reason: "UpToDate should return false if only WAF has changed while SecurityLevel is also managed",
 			args: args{
-				id: inputZoneID,
-				zp: v1alpha1.ZoneParameters{
-					Paused:            ptr.BoolPtr(false),
-					VanityNameServers: []string{"ns1.lele.com", "ns2.woowoo.org"},
+				zp: &v1alpha1.ZoneParameters{
+					PlanID: ptr.StringPtr("cake"),
+					Settings: v1alpha1.ZoneSettings{
+						WAF:           ptr.StringPtr("on"),
+						SecurityLevel: ptr.StringPtr("high"),
+					},
+				},
+				z: cloudflare.Zone{
+					PlanPending: cloudflare.ZonePlan{
+						ZonePlanCommon: cloudflare.ZonePlanCommon{
+							ID: "cake",
+						},
+					},
+				},
+				ozs: &v1alpha1.ZoneSettings{
+					WAF:           ptr.StringPtr("off"),
+					SecurityLevel: ptr.StringPtr("high"),
 				},
 			},
 			want: want{
-				err: nil,
+				o: false,
 			},
 		},
-		"UpdateZoneSettings": {
-			reason: "UpdateZone should return no error when updating zone settings",
-			fields: fields{
-				client: fake.MockClient{
-					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
-						return cloudflare.Zone{
-							ID:       zoneID,
-							Name:     "testzone.com",
-							Paused:   true,
-							VanityNS: []string{"ns1.lele.com"},
-						}, nil
-					},
-					// When EditZone is called, check it receives the expected arguments.
-					// If it doesn't we return an error which will cause the test to fail.
+		"SettingsSecurityLevelChanged": {
+			reason: "UpToDate should return false if only SecurityLevel has changed while WAF is also managed",
+			args: args{
+				zp: &v1alpha1.ZoneParameters{
+					PlanID: ptr.StringPtr("cake"),
+					Settings: v1alpha1.ZoneSettings{
+						WAF:           ptr.StringPtr("on"),
+						SecurityLevel: ptr.StringPtr("high"),
+					},
+				},
+				z: cloudflare.Zone{
+					PlanPending: cloudflare.ZonePlan{
+						ZonePlanCommon: cloudflare.ZonePlanCommon{
+							ID: "cake",
+						},
+					},
+				},
+				ozs: &v1alpha1.ZoneSettings{
+					WAF:           ptr.StringPtr("on"),
+					SecurityLevel: ptr.StringPtr("medium"),
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"SettingsEmptyCiphers": {
+			reason: "UpToDate should return true if ciphers are empty and Cloudflare is using its default ciphers",
+			args: args{
+				zp: &v1alpha1.ZoneParameters{
+					PlanID: ptr.StringPtr("cake"),
+					Settings: v1alpha1.ZoneSettings{
+						Ciphers: []string{},
+					},
+				},
+				z: cloudflare.Zone{
+					PlanPending: cloudflare.ZonePlan{
+						ZonePlanCommon: cloudflare.ZonePlanCommon{
+							ID: "cake",
+						},
+					},
+				},
+				ozs: &v1alpha1.ZoneSettings{},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"SettingsEmptyCiphersObservedEmpty": {
+			reason: "UpToDate should return true if ciphers are empty and Cloudflare reports its default ciphers as an empty list",
+			args: args{
+				zp: &v1alpha1.ZoneParameters{
+					PlanID: ptr.StringPtr("cake"),
+					Settings: v1alpha1.ZoneSettings{
+						Ciphers: []string{},
+					},
+				},
+				z: cloudflare.Zone{
+					PlanPending: cloudflare.ZonePlan{
+						ZonePlanCommon: cloudflare.ZonePlanCommon{
+							ID: "cake",
+						},
+					},
+				},
+				ozs: &v1alpha1.ZoneSettings{
+					Ciphers: []string{},
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"VanityNSTrue": {
+			reason: "UpToDate should return true if VanityNS field matches in any order",
+			args: args{
+				zp: &v1alpha1.ZoneParameters{
+					PlanID:            ptr.StringPtr("cake"),
+					Settings:          v1alpha1.ZoneSettings{},
+					VanityNameServers: []string{"ns2.woowoo.org", "ns1.lele.com"},
+				},
+				z: cloudflare.Zone{
+					PlanPending: cloudflare.ZonePlan{
+						ZonePlanCommon: cloudflare.ZonePlanCommon{
+							ID: "cake",
+						},
+					},
+					VanityNS: []string{"ns1.lele.com", "ns2.woowoo.org"},
+				},
+				ozs: &v1alpha1.ZoneSettings{},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"SettingsManagedSubsetOnly": {
+			reason: "UpToDate should ignore settings that are not in spec when only the subset in spec is managed",
+			args: args{
+				zp: &v1alpha1.ZoneParameters{
+					SettingsManagementPolicy: ptr.StringPtr(v1alpha1.SettingsPolicyManagedSubsetOnly),
+					Settings: v1alpha1.ZoneSettings{
+						SSL: ptr.StringPtr("strict"),
+						Minify: &v1alpha1.MinifySettings{
+							CSS: ptr.StringPtr("on"),
+						},
+					},
+				},
+				ozs: &v1alpha1.ZoneSettings{
+					SSL:    ptr.StringPtr("strict"),
+					Brotli: ptr.StringPtr("off"),
+					Minify: &v1alpha1.MinifySettings{
+						CSS:  ptr.StringPtr("on"),
+						HTML: ptr.StringPtr("off"),
+						JS:   ptr.StringPtr("off"),
+					},
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+		"SettingsManagedSubsetOnlyChanged": {
+			reason: "UpToDate should return false if a setting in spec differs when only the subset in spec is managed",
+			args: args{
+				zp: &v1alpha1.ZoneParameters{
+					SettingsManagementPolicy: ptr.StringPtr(v1alpha1.SettingsPolicyManagedSubsetOnly),
+					Settings: v1alpha1.ZoneSettings{
+						SSL: ptr.StringPtr("strict"),
+					},
+				},
+				ozs: &v1alpha1.ZoneSettings{
+					SSL:    ptr.StringPtr("full"),
+					Brotli: ptr.StringPtr("off"),
+				},
+			},
+			want: want{
+				o: false,
+			},
+		},
+		"SettingsManagedSubsetOnlyAllowlist": {
+			reason: "UpToDate should ignore settings in spec outside of the allowlist when only the subset in spec is managed",
+			args: args{
+				zp: &v1alpha1.ZoneParameters{
+					SettingsManagementPolicy: ptr.StringPtr(v1alpha1.SettingsPolicyManagedSubsetOnly),
+					SettingsAllowlist:        []string{cfsBrotli},
+					Settings: v1alpha1.ZoneSettings{
+						SSL:    ptr.StringPtr("strict"),
+						Brotli: ptr.StringPtr("off"),
+					},
+				},
+				ozs: &v1alpha1.ZoneSettings{
+					SSL:    ptr.StringPtr("full"),
+					Brotli: ptr.StringPtr("off"),
+				},
+			},
+			want: want{
+				o: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := UpToDate(tc.args.zp, tc.args.z, tc.args.ozs)
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdateZone(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	inputZoneID := "1234"
+	nsKey := cfsMinify
+
+	nsInputValue := v1alpha1.MinifySettings{
+		CSS:  ptr.StringPtr("on"),
+		HTML: ptr.StringPtr("off"),
+		JS:   ptr.StringPtr("bar"),
+	}
+
+	type fields struct {
+		client Client
+	}
+
+	type args struct {
+		ctx context.Context
+		id  string
+		zp  v1alpha1.ZoneParameters
+	}
+
+	type want struct {
+		err      error
+		settings *v1alpha1.ZoneSettings
+		changed  []string
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"UpdateZoneNotFound": {
+			reason: "UpdateZone should return errUpdateZone if the zone is not found",
+			fields: fields{
+				client: fake.MockClient{
+					// When the ZoneDetails method is called on this fake client,
+					// return an error that we can compare.
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{}, errBoom
+					},
+				},
+			},
+			args: args{
+				id: inputZoneID,
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errUpdateZone),
+			},
+		},
+		"UpdateZoneOptions": {
+			reason: "UpdateZone should return no error when updating zone options",
+			fields: fields{
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{
+							ID:       zoneID,
+							Name:     "testzone.com",
+							Paused:   true,
+							VanityNS: []string{"ns1.lele.com"},
+							Status:   "active",
+						}, nil
+					},
+					// When EditZone is called, check it receives the expected arguments.
+					// If it doesn't we return an error which will cause the test to fail.
+					MockEditZone: func(ctx context.Context, zoneID string, zoneOpts cloudflare.ZoneOptions) (cloudflare.Zone, error) {
+						var err error
+						if zoneID != inputZoneID {
+							err = errors.New("zoneID value incorrect")
+						}
+						if *zoneOpts.Paused != false {
+							err = errors.New("zoneOpts.Paused value incorrect")
+						}
+
+						if !cmp.Equal(zoneOpts.VanityNS,
+							[]string{"ns1.lele.com", "ns2.woowoo.org"}) {
+							err = errors.New("zoneOpts.VanityNS does not match")
+						}
+						// Returned zone is discarded by UpdateZone
+						return cloudflare.Zone{}, err
+					},
+
+					MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
+						return &cloudflare.ZoneSettingResponse{}, nil
+					},
+				},
+			},
+			args: args{
+				id: inputZoneID,
+				zp: v1alpha1.ZoneParameters{
+					Paused:            ptr.BoolPtr(false),
+					VanityNameServers: []string{"ns1.lele.com", "ns2.woowoo.org"},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"UpdateZoneSettings": {
+			reason: "UpdateZone should return no error when updating zone settings",
+			fields: fields{
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{
+							ID:       zoneID,
+							Name:     "testzone.com",
+							Paused:   true,
+							VanityNS: []string{"ns1.lele.com"},
+							Status:   "active",
+						}, nil
+					},
+					// When EditZone is called, check it receives the expected arguments.
+					// If it doesn't we return an error which will cause the test to fail.
 					MockEditZone: func(ctx context.Context, zoneID string, zoneOpts cloudflare.ZoneOptions) (cloudflare.Zone, error) {
 						var err error
 						if zoneID != inputZoneID {
@@ -651,277 +1051,1493 @@ func TestUpdateZone(t *testing.T) {
 				},
 			},
 			args: args{
-				id: inputZoneID,
-				zp: v1alpha1.ZoneParameters{
-					Paused:            ptr.BoolPtr(false),
-					VanityNameServers: []string{"ns1.lele.com", "ns2.woowoo.org"},
-					Settings: v1alpha1.ZoneSettings{
-						Minify: &nsInputValue,
-					},
+				id: inputZoneID,
+				zp: v1alpha1.ZoneParameters{
+					Paused:            ptr.BoolPtr(false),
+					VanityNameServers: []string{"ns1.lele.com", "ns2.woowoo.org"},
+					Settings: v1alpha1.ZoneSettings{
+						Minify: &nsInputValue,
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"UpdateZoneSettingsPartial": {
+			reason: "UpdateZone should update each setting individually and return a SettingsError for settings that could not be updated",
+			fields: fields{
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{ID: zoneID, Status: "active"}, nil
+					},
+					MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
+						return &cloudflare.ZoneSettingResponse{
+							Result: []cloudflare.ZoneSetting{
+								{ID: cfsZeroRTT, Value: "off", Editable: true},
+								{ID: cfsBrotli, Value: "off", Editable: true},
+							},
+						}, nil
+					},
+					MockUpdateZoneSettings: func(ctx context.Context, zoneID string, cs []cloudflare.ZoneSetting) (*cloudflare.ZoneSettingResponse, error) {
+						for _, setting := range cs {
+							if setting.ID == cfsZeroRTT {
+								return nil, errBoom
+							}
+						}
+						return &cloudflare.ZoneSettingResponse{Result: cs}, nil
+					},
+				},
+			},
+			args: args{
+				id: inputZoneID,
+				zp: v1alpha1.ZoneParameters{
+					Settings: v1alpha1.ZoneSettings{
+						ZeroRTT: ptr.StringPtr("on"),
+						Brotli:  ptr.StringPtr("on"),
+					},
+				},
+			},
+			want: want{
+				err: &SettingsError{Errors: map[string]error{cfsZeroRTT: errBoom}},
+				settings: &v1alpha1.ZoneSettings{
+					ZeroRTT: ptr.StringPtr("off"),
+					Brotli:  ptr.StringPtr("on"),
+				},
+			},
+		},
+		"UpdateZoneSettingsObserved": {
+			reason: "UpdateZone should load the settings returned by Cloudflare after updating them",
+			fields: fields{
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{ID: zoneID, Status: "active"}, nil
+					},
+					MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
+						return &cloudflare.ZoneSettingResponse{
+							Result: []cloudflare.ZoneSetting{
+								{ID: cfsZeroRTT, Value: "off", Editable: true},
+								{ID: cfsBrotli, Value: "off", Editable: true},
+								{ID: cfsEdgeCacheTTL, Value: float64(7200), Editable: true},
+							},
+						}, nil
+					},
+					MockUpdateZoneSettings: func(ctx context.Context, zoneID string, cs []cloudflare.ZoneSetting) (*cloudflare.ZoneSettingResponse, error) {
+						// Cloudflare returns numbers in JSON, which are
+						// decoded as float64 values.
+						out := make([]cloudflare.ZoneSetting, len(cs))
+						for i, setting := range cs {
+							out[i] = setting
+							if n, ok := setting.Value.(int64); ok {
+								out[i].Value = float64(n)
+							}
+						}
+						return &cloudflare.ZoneSettingResponse{Result: out}, nil
+					},
+				},
+			},
+			args: args{
+				id: inputZoneID,
+				zp: v1alpha1.ZoneParameters{
+					Settings: v1alpha1.ZoneSettings{
+						ZeroRTT:      ptr.StringPtr("on"),
+						EdgeCacheTTL: ptr.Int64Ptr(900),
+					},
+				},
+			},
+			want: want{
+				settings: &v1alpha1.ZoneSettings{
+					ZeroRTT:      ptr.StringPtr("on"),
+					Brotli:       ptr.StringPtr("off"),
+					EdgeCacheTTL: ptr.Int64Ptr(900),
+				},
+			},
+		},
+		"UpdateZoneSettingNotEditable": {
+			reason: "UpdateZone should not try to change a desired setting that cannot be edited",
+			fields: fields{
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{ID: zoneID, Status: "active"}, nil
+					},
+					MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
+						return &cloudflare.ZoneSettingResponse{
+							Result: []cloudflare.ZoneSetting{
+								{ID: cfsZeroRTT, Value: "off", Editable: false},
+								{ID: cfsBrotli, Value: "off", Editable: true},
+							},
+						}, nil
+					},
+					MockUpdateZoneSettings: func(ctx context.Context, zoneID string, cs []cloudflare.ZoneSetting) (*cloudflare.ZoneSettingResponse, error) {
+						for _, setting := range cs {
+							if setting.ID == cfsZeroRTT {
+								return nil, errors.New("setting is not editable")
+							}
+						}
+						return &cloudflare.ZoneSettingResponse{Result: cs}, nil
+					},
+				},
+			},
+			args: args{
+				id: inputZoneID,
+				zp: v1alpha1.ZoneParameters{
+					Settings: v1alpha1.ZoneSettings{
+						ZeroRTT: ptr.StringPtr("on"),
+						Brotli:  ptr.StringPtr("on"),
+					},
+				},
+			},
+			want: want{
+				settings: &v1alpha1.ZoneSettings{
+					Brotli: ptr.StringPtr("on"),
+				},
+				// The non-editable setting still differs, which the
+				// Zone controller reports rather than updating.
+				changed: []string{cfsZeroRTT},
+			},
+		},
+		"UpdateZoneWAFOnly": {
+			reason: "UpdateZone should only update WAF when it is the only setting changed, even when SecurityLevel is also managed",
+			fields: fields{
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{ID: zoneID, Status: "active"}, nil
+					},
+					MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
+						return &cloudflare.ZoneSettingResponse{
+							Result: []cloudflare.ZoneSetting{
+								{ID: cfsWAF, Value: "off", Editable: true},
+								{ID: cfsSecurityLevel, Value: "high", Editable: true},
+							},
+						}, nil
+					},
+					MockUpdateZoneSettings: func(ctx context.Context, zoneID string, cs []cloudflare.ZoneSetting) (*cloudflare.ZoneSettingResponse, error) {
+						want := []cloudflare.ZoneSetting{{ID: cfsWAF, Value: "on"}}
+						if diff := cmp.Diff(want, cs); diff != "" {
+							return nil, errors.Errorf("unexpected settings: %s", diff)
+						}
+						return &cloudflare.ZoneSettingResponse{Result: cs}, nil
+					},
+				},
+			},
+			args: args{
+				id: inputZoneID,
+				zp: v1alpha1.ZoneParameters{
+					Settings: v1alpha1.ZoneSettings{
+						WAF:           ptr.StringPtr("on"),
+						SecurityLevel: ptr.StringPtr("high"),
+					},
+				},
+			},
+			want: want{
+				settings: &v1alpha1.ZoneSettings{
+					WAF:           ptr.StringPtr("on"),
+					SecurityLevel: ptr.StringPtr("high"),
+				},
+			},
+		},
+		"UpdateZoneSettingsAllowlist": {
+			reason: "UpdateZone should only update settings in the allowlist",
+			fields: fields{
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{ID: zoneID, Status: "active"}, nil
+					},
+					MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
+						return &cloudflare.ZoneSettingResponse{
+							Result: []cloudflare.ZoneSetting{
+								{ID: cfsWAF, Value: "off", Editable: true},
+								{ID: cfsSecurityLevel, Value: "low", Editable: true},
+							},
+						}, nil
+					},
+					MockUpdateZoneSettings: func(ctx context.Context, zoneID string, cs []cloudflare.ZoneSetting) (*cloudflare.ZoneSettingResponse, error) {
+						want := []cloudflare.ZoneSetting{{ID: cfsWAF, Value: "on"}}
+						if diff := cmp.Diff(want, cs); diff != "" {
+							return nil, errors.Errorf("unexpected settings: %s", diff)
+						}
+						return &cloudflare.ZoneSettingResponse{Result: cs}, nil
+					},
+				},
+			},
+			args: args{
+				id: inputZoneID,
+				zp: v1alpha1.ZoneParameters{
+					Settings: v1alpha1.ZoneSettings{
+						WAF:           ptr.StringPtr("on"),
+						SecurityLevel: ptr.StringPtr("high"),
+					},
+					SettingsAllowlist: []string{cfsWAF},
+				},
+			},
+			want: want{
+				settings: &v1alpha1.ZoneSettings{
+					WAF:           ptr.StringPtr("on"),
+					SecurityLevel: ptr.StringPtr("low"),
+				},
+				changed: []string{cfsSecurityLevel},
+			},
+		},
+		"UpdateZoneSettingsPending": {
+			reason: "UpdateZone should not update settings on a zone that is not active",
+			fields: fields{
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{ID: zoneID, Status: "pending"}, nil
+					},
+					MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
+						return nil, errors.New("settings should not be loaded on a pending zone")
+					},
+					MockUpdateZoneSettings: func(ctx context.Context, zoneID string, cs []cloudflare.ZoneSetting) (*cloudflare.ZoneSettingResponse, error) {
+						return nil, errors.New("settings should not be updated on a pending zone")
+					},
+				},
+			},
+			args: args{
+				id: inputZoneID,
+				zp: v1alpha1.ZoneParameters{
+					Settings: v1alpha1.ZoneSettings{
+						WAF: ptr.StringPtr("on"),
+					},
+				},
+			},
+			want: want{
+				err: &SettingsDeferredError{Status: "pending"},
+			},
+		},
+		// TODO: Test SetPlan
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &v1alpha1.ZoneSettings{}
+			err := UpdateZone(tc.args.ctx, tc.fields.client, tc.args.id, tc.args.zp, got, nil)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdateZone(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.settings == nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.settings, got); diff != "" {
+				t.Errorf("\n%s\nUpdateZone(...): -want settings, +got settings:\n%s\n", tc.reason, diff)
+			}
+			// Settings observed after the update should need no
+			// further changes, other than those that failed or
+			// cannot be edited.
+			if tc.want.err == nil {
+				if diff := cmp.Diff(tc.want.changed, ChangedSettingKeys(got, &tc.args.zp.Settings)); diff != "" {
+					t.Errorf("\n%s\nUpdateZone(...): -want changed settings, +got changed settings:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateZoneSettingsOrdered(t *testing.T) {
+	calls := [][]string{}
+	client := fake.MockClient{
+		MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+			return cloudflare.Zone{ID: zoneID, Status: "active"}, nil
+		},
+		MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
+			return &cloudflare.ZoneSettingResponse{
+				Result: []cloudflare.ZoneSetting{
+					{ID: cfsSSL, Value: "off", Editable: true},
+					{ID: cfsAlwaysUseHTTPS, Value: "off", Editable: true},
+					{ID: cfsAutomaticHTTPSRewrites, Value: "off", Editable: true},
+					{ID: cfsWAF, Value: "off", Editable: true},
+				},
+			}, nil
+		},
+		MockUpdateZoneSettings: func(ctx context.Context, zoneID string, cs []cloudflare.ZoneSetting) (*cloudflare.ZoneSettingResponse, error) {
+			ids := make([]string, len(cs))
+			for i, s := range cs {
+				ids[i] = s.ID
+			}
+			calls = append(calls, ids)
+			return &cloudflare.ZoneSettingResponse{Result: cs}, nil
+		},
+	}
+
+	zp := v1alpha1.ZoneParameters{
+		Settings: v1alpha1.ZoneSettings{
+			SSL:                    ptr.StringPtr("full"),
+			AlwaysUseHTTPS:         ptr.StringPtr("on"),
+			AutomaticHTTPSRewrites: ptr.StringPtr("on"),
+			WAF:                    ptr.StringPtr("on"),
+		},
+	}
+	if err := UpdateZone(context.Background(), client, "1234", zp, &v1alpha1.ZoneSettings{}, nil); err != nil {
+		t.Fatalf("UpdateZone(...): unexpected error: %s", err)
+	}
+
+	// Settings that depend on SSL are updated together once it has
+	// been updated, along with any other settings.
+	want := [][]string{
+		{cfsSSL, cfsWAF},
+		{cfsAlwaysUseHTTPS, cfsAutomaticHTTPSRewrites},
+	}
+	if diff := cmp.Diff(want, calls); diff != "" {
+		t.Errorf("UpdateZone(...): -want settings updated by each call, +got settings updated by each call:\n%s\n", diff)
+	}
+}
+
+func TestUpdateZoneSettingsUnapplied(t *testing.T) {
+	calls := [][]string{}
+	client := fake.MockClient{
+		MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+			return cloudflare.Zone{ID: zoneID, Status: "active"}, nil
+		},
+		MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
+			return &cloudflare.ZoneSettingResponse{
+				Result: []cloudflare.ZoneSetting{
+					{ID: cfsZeroRTT, Value: "off", Editable: true},
+					{ID: cfsBrotli, Value: "off", Editable: true},
+					{ID: cfsWAF, Value: "off", Editable: true},
+				},
+			}, nil
+		},
+		MockUpdateZoneSettings: func(ctx context.Context, zoneID string, cs []cloudflare.ZoneSetting) (*cloudflare.ZoneSettingResponse, error) {
+			ids := make([]string, len(cs))
+			for i, s := range cs {
+				ids[i] = s.ID
+			}
+			calls = append(calls, ids)
+			for _, s := range cs {
+				if s.ID == cfsZeroRTT {
+					return nil, errBoom
+				}
+			}
+			return &cloudflare.ZoneSettingResponse{Result: cs}, nil
+		},
+	}
+
+	zp := v1alpha1.ZoneParameters{
+		Settings: v1alpha1.ZoneSettings{
+			ZeroRTT: ptr.StringPtr("on"),
+			Brotli:  ptr.StringPtr("on"),
+			WAF:     ptr.StringPtr("on"),
+		},
+	}
+	err := UpdateZone(context.Background(), client, "1234", zp, &v1alpha1.ZoneSettings{}, []string{cfsZeroRTT})
+	if diff := cmp.Diff(&SettingsError{Errors: map[string]error{cfsZeroRTT: errBoom}}, err, test.EquateErrors()); diff != "" {
+		t.Errorf("UpdateZone(...): -want error, +got error:\n%s\n", diff)
+	}
+
+	// The setting that could not be applied before is updated on its
+	// own, so the others are updated together rather than one by one.
+	want := [][]string{
+		{cfsZeroRTT},
+		{cfsBrotli, cfsWAF},
+	}
+	if diff := cmp.Diff(want, calls); diff != "" {
+		t.Errorf("UpdateZone(...): -want settings updated by each call, +got settings updated by each call:\n%s\n", diff)
+	}
+}
+
+func TestOrderSettings(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		cs     []cloudflare.ZoneSetting
+		want   [][]cloudflare.ZoneSetting
+	}{
+		"NoSettings": {
+			reason: "OrderSettings should return no stages when passed no settings",
+		},
+		"NoDependencies": {
+			reason: "OrderSettings should return a single sorted stage when no settings depend on each other",
+			cs: []cloudflare.ZoneSetting{
+				{ID: cfsWAF, Value: "on"},
+				{ID: cfsAlwaysUseHTTPS, Value: "on"},
+			},
+			want: [][]cloudflare.ZoneSetting{
+				{
+					{ID: cfsAlwaysUseHTTPS, Value: "on"},
+					{ID: cfsWAF, Value: "on"},
+				},
+			},
+		},
+		"Dependencies": {
+			reason: "OrderSettings should update settings in a stage after the settings they depend on",
+			cs: []cloudflare.ZoneSetting{
+				{ID: cfsAlwaysUseHTTPS, Value: "on"},
+				{ID: cfsWAF, Value: "on"},
+				{ID: cfsSSL, Value: "full"},
+			},
+			want: [][]cloudflare.ZoneSetting{
+				{
+					{ID: cfsSSL, Value: "full"},
+					{ID: cfsWAF, Value: "on"},
+				},
+				{
+					{ID: cfsAlwaysUseHTTPS, Value: "on"},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := OrderSettings(tc.cs)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nOrderSettings(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLoadSettingsForZone(t *testing.T) {
+	errBoom := errors.New("boom")
+	type fields struct {
+		client Client
+	}
+
+	type args struct {
+		ctx context.Context
+		id  string
+		zs  v1alpha1.ZoneSettings
+	}
+
+	type want struct {
+		err error
+		o   v1alpha1.ZoneSettings
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrorLookupSettings": {
+			reason: "LoadSettingsForZone should return an error when the API call returns an error",
+			fields: fields{
+				client: fake.MockClient{
+					MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				id: "abcd",
+				zs: v1alpha1.ZoneSettings{ZeroRTT: ptr.StringPtr("yes")},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errLoadSettings),
+				o:   v1alpha1.ZoneSettings{ZeroRTT: ptr.StringPtr("yes")},
+			},
+		},
+		"LoadUnknownSetting": {
+			// Note: This is an academic test - all of the keys we use are static strings
+			// So we _cannot_ load a setting into a struct without knowing about it.
+			// We add this test to avoid regressions if the method used to load settings
+			// is changed, as it has caused problems in the past.
+			reason: "LoadSettingsForZone should not error when reading unknown settings",
+			fields: fields{
+				client: fake.MockClient{
+					MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
+						return &cloudflare.ZoneSettingResponse{
+							Result: []cloudflare.ZoneSetting{
+								{ID: "unknownKey", Value: "foo"},
+							},
+						}, nil
+					},
+				},
+			},
+			args: args{
+				id: "abcd",
+				zs: v1alpha1.ZoneSettings{
+					AdvancedDDOS: ptr.StringPtr("yes"),
+				},
+			},
+			want: want{
+				err: nil,
+				o: v1alpha1.ZoneSettings{
+					AdvancedDDOS: ptr.StringPtr("yes"),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.args.zs.DeepCopy()
+
+			_, err := LoadSettingsForZone(tc.args.ctx, tc.fields.client, tc.args.id, &tc.args.zs)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nLoadSettingsForZone(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, *got); diff != "" {
+				t.Errorf("\n%s\nLoadSettingsForZone(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSecurityHeaderSettingsToMap(t *testing.T) {
+	type args struct {
+		settings *v1alpha1.SecurityHeaderSettings
+	}
+
+	type want struct {
+		o map[string]interface{}
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Success": {
+			reason: "securityHeaderSettingsToMap should return a valid map type",
+			args: args{
+				settings: &v1alpha1.SecurityHeaderSettings{
+					StrictTransportSecurity: &v1alpha1.StrictTransportSecuritySettings{
+						Enabled:           ptr.BoolPtr(true),
+						MaxAge:            ptr.Int64Ptr(86400),
+						IncludeSubdomains: ptr.BoolPtr(true),
+						NoSniff:           ptr.BoolPtr(true),
+					},
+				},
+			},
+			want: want{
+				o: map[string]interface{}{
+					cfsStrictTransportSecurity: map[string]interface{}{
+						cfsStrictTransportSecurityEnabled:           true,
+						cfsStrictTransportSecurityIncludeSubdomains: true,
+						cfsStrictTransportSecurityMaxAge:            int64(86400),
+						cfsStrictTransportSecurityNoSniff:           true,
+					},
+				},
+			},
+		},
+		"SuccessEmpty": {
+			reason: "securityHeaderSettingsToMap should return an empty map when no settings are provided",
+			args: args{
+				settings: &v1alpha1.SecurityHeaderSettings{},
+			},
+			want: want{
+				o: map[string]interface{}{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := securityHeaderSettingsToMap(tc.args.settings)
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nsecurityHeaderSettingsToMap(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMobileRedirectSettingsToMap(t *testing.T) {
+	type args struct {
+		settings *v1alpha1.MobileRedirectSettings
+	}
+
+	type want struct {
+		o map[string]interface{}
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Success": {
+			reason: "mobileRedirectSettingsToMap should return a valid map type",
+			args: args{
+				settings: &v1alpha1.MobileRedirectSettings{
+					Status:    ptr.StringPtr("on"),
+					Subdomain: ptr.StringPtr("m"),
+					StripURI:  ptr.BoolPtr(false),
+				},
+			},
+			want: want{
+				o: map[string]interface{}{
+					cfsMobileRedirectStatus:    "on",
+					cfsMobileRedirectSubdomain: "m",
+					cfsMobileRedirectStripURI:  false,
+				},
+			},
+		},
+		"SuccessEmpty": {
+			reason: "mobileRedirectSettingsToMap should return an empty map when no settings are provided",
+			args: args{
+				settings: &v1alpha1.MobileRedirectSettings{},
+			},
+			want: want{
+				o: map[string]interface{}{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := mobileRedirectSettingsToMap(tc.args.settings)
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nmobileRedirectSettingsToMap(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMinifySettingsToMap(t *testing.T) {
+	type args struct {
+		settings *v1alpha1.MinifySettings
+	}
+
+	type want struct {
+		o map[string]interface{}
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Success": {
+			reason: "minifySettingsToMap should return a valid map type",
+			args: args{
+				settings: &v1alpha1.MinifySettings{
+					CSS:  ptr.StringPtr("on"),
+					HTML: ptr.StringPtr("on"),
+					JS:   ptr.StringPtr("on"),
+				},
+			},
+			want: want{
+				o: map[string]interface{}{
+					cfsMinifyCSS:  "on",
+					cfsMinifyHTML: "on",
+					cfsMinifyJS:   "on",
+				},
+			},
+		},
+		"SuccessEmpty": {
+			reason: "minifySettingsToMap should return an empty map when no settings are provided",
+			args: args{
+				settings: &v1alpha1.MinifySettings{},
+			},
+			want: want{
+				o: map[string]interface{}{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := minifySettingsToMap(tc.args.settings)
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nminifySettingsToMap(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetChangedSettings(t *testing.T) {
+	type args struct {
+		current *v1alpha1.ZoneSettings
+		desired *v1alpha1.ZoneSettings
+	}
+
+	type want struct {
+		o []cloudflare.ZoneSetting
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoChanges": {
+			reason: "GetChangedSettings should return no settings when nothing has changed",
+			args: args{
+				current: &v1alpha1.ZoneSettings{
+					ZeroRTT: ptr.StringPtr("on"),
+				},
+				desired: &v1alpha1.ZoneSettings{
+					ZeroRTT: ptr.StringPtr("on"),
+				},
+			},
+			want: want{
+				o: []cloudflare.ZoneSetting{},
+			},
+		},
+		"ChangedTLS13ZeroRTT": {
+			reason: "GetChangedSettings should return TLS 1.3 when it is changed to zero round-trip",
+			args: args{
+				current: &v1alpha1.ZoneSettings{
+					TLS13: ptr.StringPtr("on"),
+				},
+				desired: &v1alpha1.ZoneSettings{
+					TLS13: ptr.StringPtr("zrt"),
+				},
+			},
+			want: want{
+				o: []cloudflare.ZoneSetting{
+					{ID: cfsTLS13, Value: "zrt"},
+				},
+			},
+		},
+		"UnchangedTLS13ZeroRTT": {
+			reason: "GetChangedSettings should not return TLS 1.3 when it is already zero round-trip",
+			args: args{
+				current: &v1alpha1.ZoneSettings{
+					TLS13: ptr.StringPtr("zrt"),
+				},
+				desired: &v1alpha1.ZoneSettings{
+					TLS13: ptr.StringPtr("zrt"),
+				},
+			},
+			want: want{
+				o: []cloudflare.ZoneSetting{},
+			},
+		},
+		"EmptyCiphers": {
+			reason: "GetChangedSettings should not return empty ciphers, as they mean the Cloudflare defaults are used",
+			args: args{
+				current: &v1alpha1.ZoneSettings{
+					Ciphers: []string{"ECDHE-RSA-AES128-GCM-SHA256", "AES128-SHA"},
+				},
+				desired: &v1alpha1.ZoneSettings{
+					Ciphers: []string{},
+				},
+			},
+			want: want{
+				o: []cloudflare.ZoneSetting{},
+			},
+		},
+		"UnchangedCiphers": {
+			reason: "GetChangedSettings should not return ciphers that match the current ciphers",
+			args: args{
+				current: &v1alpha1.ZoneSettings{
+					Ciphers: []string{"ECDHE-RSA-AES128-GCM-SHA256", "AES128-SHA"},
+				},
+				desired: &v1alpha1.ZoneSettings{
+					Ciphers: []string{"ECDHE-RSA-AES128-GCM-SHA256", "AES128-SHA"},
+				},
+			},
+			want: want{
+				o: []cloudflare.ZoneSetting{},
+			},
+		},
+		"ChangedValue": {
+			reason: "GetChangedSettings should return a setting whose value has changed",
+			args: args{
+				current: &v1alpha1.ZoneSettings{
+					ZeroRTT: ptr.StringPtr("off"),
+				},
+				desired: &v1alpha1.ZoneSettings{
+					ZeroRTT: ptr.StringPtr("on"),
+				},
+			},
+			want: want{
+				o: []cloudflare.ZoneSetting{
+					{ID: cfsZeroRTT, Value: "on"},
+				},
+			},
+		},
+		"ChangedCiphers": {
+			reason: "GetChangedSettings should return ciphers in the format expected by Cloudflare",
+			args: args{
+				current: &v1alpha1.ZoneSettings{
+					Ciphers: []string{"AES128-SHA"},
+				},
+				desired: &v1alpha1.ZoneSettings{
+					Ciphers: []string{"ECDHE-RSA-AES128-GCM-SHA256"},
+				},
+			},
+			want: want{
+				o: []cloudflare.ZoneSetting{
+					{ID: cfsCiphers, Value: []string{"ECDHE-RSA-AES128-GCM-SHA256"}},
+				},
+			},
+		},
+		"ChangedOriginMaxHTTPVersion": {
+			reason: "GetChangedSettings should return an unobserved setting that does not match its default",
+			args: args{
+				current: &v1alpha1.ZoneSettings{},
+				desired: &v1alpha1.ZoneSettings{
+					OriginMaxHTTPVersion: ptr.StringPtr("1"),
+					EarlyHints:           ptr.StringPtr("off"),
+				},
+			},
+			want: want{
+				o: []cloudflare.ZoneSetting{
+					{ID: cfsOriginMaxHTTPVersion, Value: "1"},
+				},
+			},
+		},
+		"DefaultNotObserved": {
+			reason: "GetChangedSettings should not return an unobserved setting that matches its default",
+			args: args{
+				current: &v1alpha1.ZoneSettings{},
+				desired: &v1alpha1.ZoneSettings{
+					SecurityLevel:   ptr.StringPtr("medium"),
+					BrowserCacheTTL: ptr.Int64Ptr(14400),
+				},
+			},
+			want: want{
+				o: []cloudflare.ZoneSetting{},
+			},
+		},
+		"NonDefaultNotObserved": {
+			reason: "GetChangedSettings should return an unobserved setting that does not match its default",
+			args: args{
+				current: &v1alpha1.ZoneSettings{},
+				desired: &v1alpha1.ZoneSettings{
+					SecurityLevel: ptr.StringPtr("high"),
+				},
+			},
+			want: want{
+				o: []cloudflare.ZoneSetting{
+					{ID: cfsSecurityLevel, Value: "high"},
+				},
+			},
+		},
+		"DefaultObservedChanged": {
+			reason: "GetChangedSettings should return a default setting when the observed value differs",
+			args: args{
+				current: &v1alpha1.ZoneSettings{
+					SecurityLevel: ptr.StringPtr("high"),
+				},
+				desired: &v1alpha1.ZoneSettings{
+					SecurityLevel: ptr.StringPtr("medium"),
+				},
+			},
+			want: want{
+				o: []cloudflare.ZoneSetting{
+					{ID: cfsSecurityLevel, Value: "medium"},
+				},
+			},
+		},
+		"MinifyUnchanged": {
+			reason: "GetChangedSettings should not return an unchanged nested setting",
+			args: args{
+				current: &v1alpha1.ZoneSettings{
+					Minify: &v1alpha1.MinifySettings{
+						CSS:  ptr.StringPtr("on"),
+						HTML: ptr.StringPtr("off"),
+						JS:   ptr.StringPtr("off"),
+					},
+				},
+				desired: &v1alpha1.ZoneSettings{
+					Minify: &v1alpha1.MinifySettings{
+						CSS:  ptr.StringPtr("on"),
+						HTML: ptr.StringPtr("off"),
+						JS:   ptr.StringPtr("off"),
+					},
+				},
+			},
+			want: want{
+				o: []cloudflare.ZoneSetting{},
+			},
+		},
+		"MinifyPartiallySpecifiedUnchanged": {
+			reason: "GetChangedSettings should not return a nested setting when the specified fields are unchanged",
+			args: args{
+				current: &v1alpha1.ZoneSettings{
+					Minify: &v1alpha1.MinifySettings{
+						CSS:  ptr.StringPtr("on"),
+						HTML: ptr.StringPtr("off"),
+						JS:   ptr.StringPtr("off"),
+					},
+				},
+				desired: &v1alpha1.ZoneSettings{
+					Minify: &v1alpha1.MinifySettings{
+						CSS: ptr.StringPtr("on"),
+					},
+				},
+			},
+			want: want{
+				o: []cloudflare.ZoneSetting{},
+			},
+		},
+		"MinifyChanged": {
+			reason: "GetChangedSettings should return the full nested setting when one of its fields has changed",
+			args: args{
+				current: &v1alpha1.ZoneSettings{
+					Minify: &v1alpha1.MinifySettings{
+						CSS:  ptr.StringPtr("on"),
+						HTML: ptr.StringPtr("off"),
+						JS:   ptr.StringPtr("off"),
+					},
+				},
+				desired: &v1alpha1.ZoneSettings{
+					Minify: &v1alpha1.MinifySettings{
+						JS: ptr.StringPtr("on"),
+					},
+				},
+			},
+			want: want{
+				o: []cloudflare.ZoneSetting{
+					{
+						ID: cfsMinify,
+						Value: map[string]interface{}{
+							cfsMinifyCSS:  "on",
+							cfsMinifyHTML: "off",
+							cfsMinifyJS:   "on",
+						},
+					},
+				},
+			},
+		},
+		"SecurityHeaderChanged": {
+			reason: "GetChangedSettings should merge deeply nested settings when they have changed",
+			args: args{
+				current: &v1alpha1.ZoneSettings{
+					SecurityHeader: &v1alpha1.SecurityHeaderSettings{
+						StrictTransportSecurity: &v1alpha1.StrictTransportSecuritySettings{
+							Enabled: ptr.BoolPtr(false),
+							MaxAge:  ptr.Int64Ptr(0),
+						},
+					},
+				},
+				desired: &v1alpha1.ZoneSettings{
+					SecurityHeader: &v1alpha1.SecurityHeaderSettings{
+						StrictTransportSecurity: &v1alpha1.StrictTransportSecuritySettings{
+							Enabled: ptr.BoolPtr(true),
+						},
+					},
+				},
+			},
+			want: want{
+				o: []cloudflare.ZoneSetting{
+					{
+						ID: cfsSecurityHeader,
+						Value: map[string]interface{}{
+							cfsStrictTransportSecurity: map[string]interface{}{
+								cfsStrictTransportSecurityEnabled: true,
+								cfsStrictTransportSecurityMaxAge:  int64(0),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetChangedSettings(tc.args.current, tc.args.desired)
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nGetChangedSettings(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSettingsMapRoundTrip(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		zs     v1alpha1.ZoneSettings
+	}{
+		"TLS13On": {
+			reason: "TLS 1.3 should round-trip through a settings map when on",
+			zs:     v1alpha1.ZoneSettings{TLS13: ptr.StringPtr("on")},
+		},
+		"TLS13ZeroRTT": {
+			reason: "TLS 1.3 should round-trip through a settings map when using zero round-trip",
+			zs:     v1alpha1.ZoneSettings{TLS13: ptr.StringPtr("zrt")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := v1alpha1.ZoneSettings{}
+			settingsMapToZone(zoneToSettingsMap(&tc.zs), &got)
+			if diff := cmp.Diff(tc.zs, got); diff != "" {
+				t.Errorf("\n%s\nsettingsMapToZone(zoneToSettingsMap(...)): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNonEditableSettings(t *testing.T) {
+	type args struct {
+		zs          *v1alpha1.ZoneSettings
+		nonEditable []string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   []string
+	}{
+		"NoneNonEditable": {
+			reason: "NonEditableSettings should return no keys when all settings are editable",
+			args: args{
+				zs: &v1alpha1.ZoneSettings{
+					ZeroRTT: ptr.StringPtr("on"),
+				},
+			},
+			want: []string{},
+		},
+		"NonEditableNotSet": {
+			reason: "NonEditableSettings should ignore non-editable settings that are not set",
+			args: args{
+				zs: &v1alpha1.ZoneSettings{
+					ZeroRTT: ptr.StringPtr("on"),
 				},
+				nonEditable: []string{cfsWAF},
 			},
-			want: want{
-				err: nil,
+			want: []string{},
+		},
+		"NonEditableSet": {
+			reason: "NonEditableSettings should return the sorted keys of set settings that are not editable",
+			args: args{
+				zs: &v1alpha1.ZoneSettings{
+					ZeroRTT: ptr.StringPtr("on"),
+					WAF:     ptr.StringPtr("on"),
+					Minify: &v1alpha1.MinifySettings{
+						CSS: ptr.StringPtr("on"),
+					},
+				},
+				nonEditable: []string{cfsWAF, cfsMinify, cfsPolish},
 			},
+			want: []string{cfsMinify, cfsWAF},
 		},
-		// TODO: Test SetPlan
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			err := UpdateZone(tc.args.ctx, tc.fields.client, tc.args.id, tc.args.zp)
-			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nUpdateZone(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			got := NonEditableSettings(tc.args.zs, tc.args.nonEditable)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nNonEditableSettings(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
 
-func TestLoadSettingsForZone(t *testing.T) {
-	errBoom := errors.New("boom")
-	type fields struct {
-		client Client
-	}
-
+func TestRemoveSettings(t *testing.T) {
 	type args struct {
-		ctx context.Context
-		id  string
-		zs  v1alpha1.ZoneSettings
-	}
-
-	type want struct {
-		err error
-		o   v1alpha1.ZoneSettings
+		zs   *v1alpha1.ZoneSettings
+		keys []string
 	}
 
 	cases := map[string]struct {
 		reason string
-		fields fields
 		args   args
-		want   want
+		want   *v1alpha1.ZoneSettings
 	}{
-		"ErrorLookupSettings": {
-			reason: "LoadSettingsForZone should return an error when the API call returns an error",
-			fields: fields{
-				client: fake.MockClient{
-					MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
-						return nil, errBoom
-					},
+		"NoKeys": {
+			reason: "RemoveSettings should not change settings when no keys are passed",
+			args: args{
+				zs: &v1alpha1.ZoneSettings{
+					ZeroRTT: ptr.StringPtr("on"),
 				},
 			},
+			want: &v1alpha1.ZoneSettings{
+				ZeroRTT: ptr.StringPtr("on"),
+			},
+		},
+		"RemoveKeys": {
+			reason: "RemoveSettings should unset only the settings with the passed keys",
 			args: args{
-				id: "abcd",
-				zs: v1alpha1.ZoneSettings{ZeroRTT: ptr.StringPtr("yes")},
+				zs: &v1alpha1.ZoneSettings{
+					ZeroRTT:      ptr.StringPtr("on"),
+					WAF:          ptr.StringPtr("on"),
+					EdgeCacheTTL: ptr.Int64Ptr(7200),
+					Minify: &v1alpha1.MinifySettings{
+						CSS: ptr.StringPtr("on"),
+					},
+				},
+				keys: []string{cfsWAF, cfsMinify},
 			},
-			want: want{
-				err: errors.Wrap(errBoom, errLoadSettings),
-				o:   v1alpha1.ZoneSettings{ZeroRTT: ptr.StringPtr("yes")},
+			want: &v1alpha1.ZoneSettings{
+				ZeroRTT:      ptr.StringPtr("on"),
+				EdgeCacheTTL: ptr.Int64Ptr(7200),
 			},
 		},
-		"LoadUnknownSetting": {
-			// Note: This is an academic test - all of the keys we use are static strings
-			// So we _cannot_ load a setting into a struct without knowing about it.
-			// We add this test to avoid regressions if the method used to load settings
-			// is changed, as it has caused problems in the past.
-			reason: "LoadSettingsForZone should not error when reading unknown settings",
-			fields: fields{
-				client: fake.MockClient{
-					MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
-						return &cloudflare.ZoneSettingResponse{
-							Result: []cloudflare.ZoneSetting{
-								{ID: "unknownKey", Value: "foo"},
-							},
-						}, nil
-					},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			RemoveSettings(tc.args.zs, tc.args.keys...)
+			if diff := cmp.Diff(tc.want, tc.args.zs); diff != "" {
+				t.Errorf("\n%s\nRemoveSettings(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPlanGatedSettings(t *testing.T) {
+	free := cloudflare.ZonePlan{LegacyID: "free"}
+	pro := cloudflare.ZonePlan{LegacyID: "pro"}
+
+	type args struct {
+		zs   *v1alpha1.ZoneSettings
+		plan cloudflare.ZonePlan
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   []string
+	}{
+		"FreePlanEnabled": {
+			reason: "PlanGatedSettings should return enabled plan gated settings on the free plan",
+			args: args{
+				zs: &v1alpha1.ZoneSettings{
+					H2Prioritization: ptr.StringPtr("on"),
+					ImageResizing:    ptr.StringPtr("on"),
+					WAF:              ptr.StringPtr("on"),
 				},
+				plan: free,
 			},
+			want: []string{cfsH2Prioritization, cfsImageResizing},
+		},
+		"FreePlanDisabled": {
+			reason: "PlanGatedSettings should not return plan gated settings that are disabled",
 			args: args{
-				id: "abcd",
-				zs: v1alpha1.ZoneSettings{
-					AdvancedDDOS: ptr.StringPtr("yes"),
+				zs: &v1alpha1.ZoneSettings{
+					H2Prioritization: ptr.StringPtr("off"),
+					ImageResizing:    ptr.StringPtr("on"),
 				},
+				plan: free,
 			},
-			want: want{
-				err: nil,
-				o: v1alpha1.ZoneSettings{
-					AdvancedDDOS: ptr.StringPtr("yes"),
+			want: []string{cfsImageResizing},
+		},
+		"PaidPlan": {
+			reason: "PlanGatedSettings should return no settings on a paid plan",
+			args: args{
+				zs: &v1alpha1.ZoneSettings{
+					H2Prioritization: ptr.StringPtr("on"),
 				},
+				plan: pro,
 			},
+			want: nil,
 		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			got := tc.args.zs.DeepCopy()
-
-			err := LoadSettingsForZone(tc.args.ctx, tc.fields.client, tc.args.id, &tc.args.zs)
-			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nLoadSettingsForZone(...): -want error, +got error:\n%s\n", tc.reason, diff)
-			}
-			if diff := cmp.Diff(tc.want.o, *got); diff != "" {
-				t.Errorf("\n%s\nLoadSettingsForZone(...): -want, +got:\n%s\n", tc.reason, diff)
+			got := PlanGatedSettings(tc.args.zs, tc.args.plan)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nPlanGatedSettings(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
 
-func TestSecurityHeaderSettingsToMap(t *testing.T) {
-	type args struct {
-		settings *v1alpha1.SecurityHeaderSettings
+func TestActivationRecords(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		z      cloudflare.Zone
+		want   []string
+	}{
+		"Full": {
+			reason: "ActivationRecords should return no records for a full Zone",
+			z:      cloudflare.Zone{Name: "example.com", Type: "full", VerificationKey: "123-456"},
+			want:   nil,
+		},
+		"Partial": {
+			reason: "ActivationRecords should return the verification and CNAME records for a partial Zone",
+			z:      cloudflare.Zone{Name: "example.com", Type: "partial", VerificationKey: "123-456"},
+			want: []string{
+				"TXT cloudflare-verify.example.com 123-456",
+				"CNAME <hostname>.example.com <hostname>.example.com.cdn.cloudflare.net",
+			},
+		},
+		"PartialNoVerificationKey": {
+			reason: "ActivationRecords should only return the CNAME record when there is no verification key",
+			z:      cloudflare.Zone{Name: "example.com", Type: "partial"},
+			want: []string{
+				"CNAME <hostname>.example.com <hostname>.example.com.cdn.cloudflare.net",
+			},
+		},
 	}
 
-	type want struct {
-		o map[string]interface{}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ActivationRecords(tc.z)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nActivationRecords(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
 	}
+}
 
+func TestManagedSettingKeys(t *testing.T) {
 	cases := map[string]struct {
 		reason string
-		args   args
-		want   want
+		zs     *v1alpha1.ZoneSettings
+		want   []string
 	}{
-		"Success": {
-			reason: "securityHeaderSettingsToMap should return a valid map type",
-			args: args{
-				settings: &v1alpha1.SecurityHeaderSettings{
-					StrictTransportSecurity: &v1alpha1.StrictTransportSecuritySettings{
-						Enabled:           ptr.BoolPtr(true),
-						MaxAge:            ptr.Int64Ptr(86400),
-						IncludeSubdomains: ptr.BoolPtr(true),
-						NoSniff:           ptr.BoolPtr(true),
-					},
+		"NoSettings": {
+			reason: "ManagedSettingKeys should return no keys when no settings are set",
+			zs:     &v1alpha1.ZoneSettings{},
+			want:   nil,
+		},
+		"SomeSettings": {
+			reason: "ManagedSettingKeys should return the sorted keys of only the settings that are set",
+			zs: &v1alpha1.ZoneSettings{
+				ZeroRTT:      ptr.StringPtr("on"),
+				WAF:          ptr.StringPtr("off"),
+				EdgeCacheTTL: ptr.Int64Ptr(7200),
+				Minify: &v1alpha1.MinifySettings{
+					CSS: ptr.StringPtr("on"),
 				},
 			},
-			want: want{
-				o: map[string]interface{}{
-					cfsStrictTransportSecurity: map[string]interface{}{
-						cfsStrictTransportSecurityEnabled:           true,
-						cfsStrictTransportSecurityIncludeSubdomains: true,
-						cfsStrictTransportSecurityMaxAge:            int64(86400),
-						cfsStrictTransportSecurityNoSniff:           true,
-					},
-				},
+			want: []string{cfsZeroRTT, cfsEdgeCacheTTL, cfsMinify, cfsWAF},
+		},
+		"EmptyCiphers": {
+			reason: "ManagedSettingKeys should not return ciphers when they are left at their default",
+			zs: &v1alpha1.ZoneSettings{
+				Ciphers: []string{},
 			},
+			want: nil,
 		},
-		"SuccessEmpty": {
-			reason: "securityHeaderSettingsToMap should return an empty map when no settings are provided",
-			args: args{
-				settings: &v1alpha1.SecurityHeaderSettings{},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ManagedSettingKeys(tc.zs)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nManagedSettingKeys(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSettingHashes(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		a      *v1alpha1.ZoneSettings
+		b      *v1alpha1.ZoneSettings
+		want   []string
+	}{
+		"Equal": {
+			reason: "Equal settings should have equal hashes",
+			a:      &v1alpha1.ZoneSettings{ZeroRTT: ptr.StringPtr("on")},
+			b:      &v1alpha1.ZoneSettings{ZeroRTT: ptr.StringPtr("on")},
+		},
+		"Changed": {
+			reason: "Settings with different values should have different hashes",
+			a: &v1alpha1.ZoneSettings{
+				ZeroRTT:       ptr.StringPtr("on"),
+				SecurityLevel: ptr.StringPtr("medium"),
 			},
-			want: want{
-				o: map[string]interface{}{},
+			b: &v1alpha1.ZoneSettings{
+				ZeroRTT:       ptr.StringPtr("off"),
+				SecurityLevel: ptr.StringPtr("medium"),
 			},
+			want: []string{cfsZeroRTT},
+		},
+		"AddedAndRemoved": {
+			reason: "Settings that are only set on one side should be reported as changed",
+			a:      &v1alpha1.ZoneSettings{ZeroRTT: ptr.StringPtr("on")},
+			b:      &v1alpha1.ZoneSettings{SecurityLevel: ptr.StringPtr("medium")},
+			want:   []string{cfsZeroRTT, cfsSecurityLevel},
 		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			got := securityHeaderSettingsToMap(tc.args.settings)
-			if diff := cmp.Diff(tc.want.o, got); diff != "" {
-				t.Errorf("\n%s\nsecurityHeaderSettingsToMap(...): -want, +got:\n%s\n", tc.reason, diff)
+			got := changedHashKeys(SettingHashes(tc.a), SettingHashes(tc.b))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nchangedHashKeys(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
 
-func TestMobileRedirectSettingsToMap(t *testing.T) {
-	type args struct {
-		settings *v1alpha1.MobileRedirectSettings
+func TestSetSettingsSnapshot(t *testing.T) {
+	then := metav1.NewTime(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(time.Date(2021, 6, 2, 0, 0, 0, 0, time.UTC))
+
+	observed := &v1alpha1.ZoneSettings{
+		ZeroRTT:       ptr.StringPtr("off"),
+		SecurityLevel: ptr.StringPtr("medium"),
+	}
+	previous := &v1alpha1.ZoneSettings{
+		ZeroRTT:       ptr.StringPtr("on"),
+		SecurityLevel: ptr.StringPtr("medium"),
 	}
 
-	type want struct {
-		o map[string]interface{}
+	type args struct {
+		prev v1alpha1.ZoneObservation
+		ozs  *v1alpha1.ZoneSettings
+		dzs  *v1alpha1.ZoneSettings
 	}
 
 	cases := map[string]struct {
 		reason string
 		args   args
-		want   want
+		want   v1alpha1.ZoneObservation
 	}{
-		"Success": {
-			reason: "mobileRedirectSettingsToMap should return a valid map type",
+		"FirstSnapshot": {
+			reason: "The first snapshot should record a hash of the settings without a change time",
 			args: args{
-				settings: &v1alpha1.MobileRedirectSettings{
-					Status:    ptr.StringPtr("on"),
-					Subdomain: ptr.StringPtr("m"),
-					StripURI:  ptr.BoolPtr(false),
+				ozs: observed,
+				dzs: &v1alpha1.ZoneSettings{
+					ZeroRTT: ptr.StringPtr("off"),
 				},
 			},
-			want: want{
-				o: map[string]interface{}{
-					cfsMobileRedirectStatus:    "on",
-					cfsMobileRedirectSubdomain: "m",
-					cfsMobileRedirectStripURI:  false,
+			want: v1alpha1.ZoneObservation{
+				SettingsHash:  SettingsHash(observed),
+				SettingHashes: SettingHashes(observed),
+			},
+		},
+		"SettingsUnchanged": {
+			reason: "A snapshot of unchanged settings should keep the previous change time",
+			args: args{
+				prev: v1alpha1.ZoneObservation{
+					SettingsHash:        SettingsHash(observed),
+					SettingHashes:       SettingHashes(observed),
+					SettingsLastChanged: &then,
+					LastChangedSettings: []string{cfsSecurityLevel},
 				},
+				ozs: observed,
+				dzs: &v1alpha1.ZoneSettings{},
+			},
+			want: v1alpha1.ZoneObservation{
+				SettingsHash:        SettingsHash(observed),
+				SettingHashes:       SettingHashes(observed),
+				SettingsLastChanged: &then,
+				LastChangedSettings: []string{cfsSecurityLevel},
 			},
 		},
-		"SuccessEmpty": {
-			reason: "mobileRedirectSettingsToMap should return an empty map when no settings are provided",
+		"SettingsChanged": {
+			reason: "A snapshot of changed settings should record the change time and which settings changed",
 			args: args{
-				settings: &v1alpha1.MobileRedirectSettings{},
+				prev: v1alpha1.ZoneObservation{
+					SettingsHash:        SettingsHash(previous),
+					SettingHashes:       SettingHashes(previous),
+					SettingsLastChanged: &then,
+					LastChangedSettings: []string{cfsSecurityLevel},
+				},
+				ozs: observed,
+				dzs: &v1alpha1.ZoneSettings{},
 			},
-			want: want{
-				o: map[string]interface{}{},
+			want: v1alpha1.ZoneObservation{
+				SettingsHash:        SettingsHash(observed),
+				SettingHashes:       SettingHashes(observed),
+				SettingsLastChanged: &now,
+				LastChangedSettings: []string{cfsZeroRTT},
+			},
+		},
+		"SettingsChangedWithoutHashes": {
+			reason: "A snapshot of changed settings should not list which settings changed if the previous snapshot did not record their hashes",
+			args: args{
+				prev: v1alpha1.ZoneObservation{
+					SettingsHash:        SettingsHash(previous),
+					SettingsLastChanged: &then,
+				},
+				ozs: observed,
+				dzs: &v1alpha1.ZoneSettings{},
+			},
+			want: v1alpha1.ZoneObservation{
+				SettingsHash:        SettingsHash(observed),
+				SettingHashes:       SettingHashes(observed),
+				SettingsLastChanged: &now,
+			},
+		},
+		"SettingsRemoved": {
+			reason: "A snapshot should list settings that are no longer observed as changed",
+			args: args{
+				prev: v1alpha1.ZoneObservation{
+					SettingsHash:  SettingsHash(previous),
+					SettingHashes: SettingHashes(previous),
+				},
+				ozs: &v1alpha1.ZoneSettings{
+					ZeroRTT: ptr.StringPtr("on"),
+				},
+				dzs: &v1alpha1.ZoneSettings{},
+			},
+			want: v1alpha1.ZoneObservation{
+				SettingsHash: SettingsHash(&v1alpha1.ZoneSettings{
+					ZeroRTT: ptr.StringPtr("on"),
+				}),
+				SettingHashes: SettingHashes(&v1alpha1.ZoneSettings{
+					ZeroRTT: ptr.StringPtr("on"),
+				}),
+				SettingsLastChanged: &now,
+				LastChangedSettings: []string{cfsSecurityLevel},
+			},
+		},
+		"SettingsDrifted": {
+			reason: "A snapshot should list the settings that differ from their desired value",
+			args: args{
+				prev: v1alpha1.ZoneObservation{
+					SettingsHash: SettingsHash(observed),
+				},
+				ozs: observed,
+				dzs: &v1alpha1.ZoneSettings{
+					ZeroRTT:       ptr.StringPtr("on"),
+					SecurityLevel: ptr.StringPtr("high"),
+				},
+			},
+			want: v1alpha1.ZoneObservation{
+				SettingsHash:    SettingsHash(observed),
+				SettingHashes:   SettingHashes(observed),
+				ChangedSettings: []string{cfsZeroRTT, cfsSecurityLevel},
 			},
 		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			got := mobileRedirectSettingsToMap(tc.args.settings)
-			if diff := cmp.Diff(tc.want.o, got); diff != "" {
-				t.Errorf("\n%s\nmobileRedirectSettingsToMap(...): -want, +got:\n%s\n", tc.reason, diff)
+			got := v1alpha1.ZoneObservation{}
+			SetSettingsSnapshot(&got, tc.args.prev, tc.args.ozs, tc.args.dzs, now)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nSetSettingsSnapshot(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
 
-func TestMinifySettingsToMap(t *testing.T) {
-	type args struct {
-		settings *v1alpha1.MinifySettings
-	}
+func TestFailedSettings(t *testing.T) {
+	errInvalid := errors.New("invalid value")
+	errNotEntitled := errors.New("1015: setting is not entitled for this account")
 
 	type want struct {
-		o map[string]interface{}
+		failed      []string
+		notEntitled []string
 	}
 
 	cases := map[string]struct {
 		reason string
-		args   args
+		err    error
 		want   want
 	}{
-		"Success": {
-			reason: "minifySettingsToMap should return a valid map type",
-			args: args{
-				settings: &v1alpha1.MinifySettings{
-					CSS:  ptr.StringPtr("on"),
-					HTML: ptr.StringPtr("on"),
-					JS:   ptr.StringPtr("on"),
-				},
-			},
+		"NotSettingsError": {
+			reason: "FailedSettings should return no settings for other errors",
+			err:    errInvalid,
+		},
+		"Failed": {
+			reason: "FailedSettings should return settings that failed for reasons other than entitlement as failed",
+			err: errors.Wrap(&SettingsError{Errors: map[string]error{
+				cfsZeroRTT: errInvalid,
+			}}, "wrapped"),
 			want: want{
-				o: map[string]interface{}{
-					cfsMinifyCSS:  "on",
-					cfsMinifyHTML: "on",
-					cfsMinifyJS:   "on",
-				},
+				failed: []string{cfsZeroRTT},
 			},
 		},
-		"SuccessEmpty": {
-			reason: "minifySettingsToMap should return an empty map when no settings are provided",
-			args: args{
-				settings: &v1alpha1.MinifySettings{},
-			},
+		"NotEntitled": {
+			reason: "FailedSettings should return settings the account is not entitled to separately",
+			err: &SettingsError{Errors: map[string]error{
+				cfsZeroRTT:      errInvalid,
+				cfsWAF:          errNotEntitled,
+				cfsEdgeCacheTTL: errInvalid,
+			}},
 			want: want{
-				o: map[string]interface{}{},
+				failed:      []string{cfsEdgeCacheTTL, cfsZeroRTT},
+				notEntitled: []string{cfsWAF},
 			},
 		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			got := minifySettingsToMap(tc.args.settings)
-			if diff := cmp.Diff(tc.want.o, got); diff != "" {
-				t.Errorf("\n%s\nminifySettingsToMap(...): -want, +got:\n%s\n", tc.reason, diff)
+			failed, notEntitled := FailedSettings(tc.err)
+			if diff := cmp.Diff(tc.want.failed, failed); diff != "" {
+				t.Errorf("\n%s\nFailedSettings(...): -want failed, +got failed:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.notEntitled, notEntitled); diff != "" {
+				t.Errorf("\n%s\nFailedSettings(...): -want not entitled, +got not entitled:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
@@ -0,0 +1,217 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/access/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/access/application"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotApplication = "managed resource is not an AccessApplication custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errApplicationLookup   = "cannot lookup access application"
+	errApplicationCreation = "cannot create access application"
+	errApplicationUpdate   = "cannot update access application"
+	errApplicationDeletion = "cannot delete access application"
+	errNoAccount           = "no account found"
+)
+
+// Setup adds a controller that reconciles AccessApplication managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.AccessApplicationGroupKind)
+	o = o.For(v1alpha1.AccessApplicationGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.AccessApplicationGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (application.Client, error) {
+				return application.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.AccessApplication{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (application.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.AccessApplication)
+	if !ok {
+		return nil, errors.New(errNotApplication)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client application.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.AccessApplication)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotApplication)
+	}
+
+	// Application does not exist if we dont have an ID stored in external-name
+	aid := meta.GetExternalName(cr)
+	if aid == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalObservation{}, errors.New(errNoAccount)
+	}
+
+	a, err := e.client.AccessApplication(ctx, *cr.Spec.ForProvider.Account, aid)
+	if err != nil {
+		return managed.ExternalObservation{},
+			errors.Wrap(resource.Ignore(application.IsApplicationNotFound, err), errApplicationLookup)
+	}
+
+	cr.Status.AtProvider = application.GenerateObservation(a)
+
+	cr.Status.SetConditions(rtv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: application.LateInitialize(&cr.Spec.ForProvider, a),
+		ResourceUpToDate:        application.UpToDate(&cr.Spec.ForProvider, a),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.AccessApplication)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotApplication)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalCreation{}, errors.New(errNoAccount)
+	}
+
+	na, err := application.CreateApplication(ctx, e.client, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errApplicationCreation)
+	}
+
+	cr.Status.AtProvider = application.GenerateObservation(*na)
+
+	// Update the external name with the ID of the new Application
+	meta.SetExternalName(cr, na.ID)
+
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.AccessApplication)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotApplication)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalUpdate{}, errors.Wrap(errors.New(errNoAccount), errApplicationUpdate)
+	}
+
+	aid := meta.GetExternalName(cr)
+
+	// Update should never be called on a nonexistent resource
+	if aid == "" {
+		return managed.ExternalUpdate{}, errors.New(errApplicationUpdate)
+	}
+
+	return managed.ExternalUpdate{},
+		errors.Wrap(
+			application.UpdateApplication(ctx, e.client, aid, &cr.Spec.ForProvider),
+			errApplicationUpdate,
+		)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.AccessApplication)
+	if !ok {
+		return errors.New(errNotApplication)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return errors.Wrap(errors.New(errNoAccount), errApplicationDeletion)
+	}
+
+	aid := meta.GetExternalName(cr)
+
+	// Delete should never be called on a nonexistent resource
+	if aid == "" {
+		return errors.New(errApplicationDeletion)
+	}
+
+	return errors.Wrap(
+		resource.Ignore(application.IsApplicationNotFound,
+			e.client.DeleteAccessApplication(ctx, *cr.Spec.ForProvider.Account, aid)),
+		errApplicationDeletion)
+}
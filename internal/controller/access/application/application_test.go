@@ -0,0 +1,643 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package application
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/benagricola/provider-cloudflare/apis/access/v1alpha1"
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/access/application"
+	"github.com/benagricola/provider-cloudflare/internal/clients/access/application/fake"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+type applicationModifier func(*v1alpha1.AccessApplication)
+
+func withAccount(account string) applicationModifier {
+	return func(a *v1alpha1.AccessApplication) { a.Spec.ForProvider.Account = &account }
+}
+
+func withDomain(domain string) applicationModifier {
+	return func(a *v1alpha1.AccessApplication) { a.Spec.ForProvider.Domain = domain }
+}
+
+func withName(name string) applicationModifier {
+	return func(a *v1alpha1.AccessApplication) { a.Spec.ForProvider.Name = name }
+}
+
+func withExternalName(applicationID string) applicationModifier {
+	return func(a *v1alpha1.AccessApplication) { meta.SetExternalName(a, applicationID) }
+}
+
+func applicationBuild(m ...applicationModifier) *v1alpha1.AccessApplication {
+	cr := &v1alpha1.AccessApplication{}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (application.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotApplication": {
+			reason: "An error should be returned if the managed resource is not an *AccessApplication",
+			fields: fields{
+				newClient: application.NewClient,
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotApplication),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube:      mc,
+				newClient: application.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.AccessApplication{
+					Spec: v1alpha1.AccessApplicationSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when a client is created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *pcv1alpha1.ProviderConfig:
+							o.Spec.Credentials.Source = "Secret"
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								Key: "creds",
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{
+								"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+							}
+						}
+						return nil
+					}),
+				},
+				newClient: application.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.AccessApplication{
+					Spec: v1alpha1.AccessApplicationSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (application.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client application.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotApplication": {
+			reason: "An error should be returned if the managed resource is not an *AccessApplication",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotApplication),
+			},
+		},
+		"ErrNoApplication": {
+			reason: "We should return ResourceExists: false when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: &v1alpha1.AccessApplication{},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the Access Application has no account",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: applicationBuild(withExternalName("480f4f69-1a28-4fdd-9240-1ed29f0ac1db")),
+			},
+			want: want{
+				err: errors.New(errNoAccount),
+			},
+		},
+		"ErrApplicationLookup": {
+			reason: "We should return an empty observation and an error if the API returned an error",
+			fields: fields{
+				client: fake.MockClient{
+					MockAccessApplication: func(ctx context.Context, accountID, applicationID string) (cloudflare.AccessApplication, error) {
+						return cloudflare.AccessApplication{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: applicationBuild(
+					withAccount("Test Account"),
+					withExternalName("480f4f69-1a28-4fdd-9240-1ed29f0ac1db"),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errApplicationLookup),
+			},
+		},
+		"ErrApplicationNotFound": {
+			reason: "We should return ResourceExists: false if the Access Application was deleted on Cloudflare",
+			fields: fields{
+				client: fake.MockClient{
+					MockAccessApplication: func(ctx context.Context, accountID, applicationID string) (cloudflare.AccessApplication, error) {
+						return cloudflare.AccessApplication{}, errors.New("HTTP status 404: not found")
+					},
+				},
+			},
+			args: args{
+				mg: applicationBuild(
+					withAccount("Test Account"),
+					withExternalName("480f4f69-1a28-4fdd-9240-1ed29f0ac1db"),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Success": {
+			reason: "We should return ResourceExists: true and no error when an Access Application is found",
+			fields: fields{
+				client: fake.MockClient{
+					MockAccessApplication: func(ctx context.Context, accountID, applicationID string) (cloudflare.AccessApplication, error) {
+						return cloudflare.AccessApplication{
+							ID:              applicationID,
+							Name:            "Dashboard",
+							Domain:          "dashboard.example.com",
+							SessionDuration: "24h",
+						}, nil
+					},
+				},
+			},
+			args: args{
+				mg: applicationBuild(
+					withAccount("Test Account"),
+					withExternalName("480f4f69-1a28-4fdd-9240-1ed29f0ac1db"),
+					withName("Dashboard"),
+					withDomain("dashboard.example.com"),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client application.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotApplication": {
+			reason: "An error should be returned if the managed resource is not an *AccessApplication",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotApplication),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the Access Application has no account",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: applicationBuild(),
+			},
+			want: want{
+				err: errors.New(errNoAccount),
+			},
+		},
+		"ErrApplicationCreate": {
+			reason: "We should return any errors during the create process",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateAccessApplication: func(ctx context.Context, accountID string, a cloudflare.AccessApplication) (cloudflare.AccessApplication, error) {
+						return cloudflare.AccessApplication{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: applicationBuild(withAccount("Test Account"), withName("Dashboard"), withDomain("dashboard.example.com")),
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errBoom, "error creating access application"), errApplicationCreation),
+			},
+		},
+		"Success": {
+			reason: "We should return ExternalNameAssigned: true and no error when an Access Application is created",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateAccessApplication: func(ctx context.Context, accountID string, a cloudflare.AccessApplication) (cloudflare.AccessApplication, error) {
+						a.ID = "480f4f69-1a28-4fdd-9240-1ed29f0ac1db"
+						return a, nil
+					},
+				},
+			},
+			args: args{
+				mg: applicationBuild(withAccount("Test Account"), withName("Dashboard"), withDomain("dashboard.example.com")),
+			},
+			want: want{
+				o: managed.ExternalCreation{ExternalNameAssigned: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client application.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotApplication": {
+			reason: "An error should be returned if the managed resource is not an *AccessApplication",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotApplication),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the Access Application has no account",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: applicationBuild(withExternalName("480f4f69-1a28-4fdd-9240-1ed29f0ac1db")),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoAccount), errApplicationUpdate),
+			},
+		},
+		"ErrApplicationUpdate": {
+			reason: "We should return any errors during the update process",
+			fields: fields{
+				client: fake.MockClient{
+					MockUpdateAccessApplication: func(ctx context.Context, accountID string, a cloudflare.AccessApplication) (cloudflare.AccessApplication, error) {
+						return cloudflare.AccessApplication{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: applicationBuild(
+					withAccount("Test Account"),
+					withExternalName("480f4f69-1a28-4fdd-9240-1ed29f0ac1db"),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errBoom, "error updating access application"), errApplicationUpdate),
+			},
+		},
+		"Success": {
+			reason: "We should return no error when an Access Application is updated",
+			fields: fields{
+				client: fake.MockClient{
+					MockUpdateAccessApplication: func(ctx context.Context, accountID string, a cloudflare.AccessApplication) (cloudflare.AccessApplication, error) {
+						if a.ID != "480f4f69-1a28-4fdd-9240-1ed29f0ac1db" {
+							return cloudflare.AccessApplication{}, errBoom
+						}
+						return a, nil
+					},
+				},
+			},
+			args: args{
+				mg: applicationBuild(
+					withAccount("Test Account"),
+					withExternalName("480f4f69-1a28-4fdd-9240-1ed29f0ac1db"),
+				),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client application.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotApplication": {
+			reason: "An error should be returned if the managed resource is not an *AccessApplication",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotApplication),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the Access Application has no account",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: applicationBuild(withExternalName("480f4f69-1a28-4fdd-9240-1ed29f0ac1db")),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoAccount), errApplicationDeletion),
+			},
+		},
+		"ErrApplicationDelete": {
+			reason: "We should return any errors during the delete process",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteAccessApplication: func(ctx context.Context, accountID, applicationID string) error {
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: applicationBuild(
+					withAccount("Test Account"),
+					withExternalName("480f4f69-1a28-4fdd-9240-1ed29f0ac1db"),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errApplicationDeletion),
+			},
+		},
+		"SuccessNotFound": {
+			reason: "We should return no error when the Access Application was already deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteAccessApplication: func(ctx context.Context, accountID, applicationID string) error {
+						return errors.New("HTTP status 404: not found")
+					},
+				},
+			},
+			args: args{
+				mg: applicationBuild(
+					withAccount("Test Account"),
+					withExternalName("480f4f69-1a28-4fdd-9240-1ed29f0ac1db"),
+				),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"Success": {
+			reason: "We should return no error when an Access Application is deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteAccessApplication: func(ctx context.Context, accountID, applicationID string) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: applicationBuild(
+					withAccount("Test Account"),
+					withExternalName("480f4f69-1a28-4fdd-9240-1ed29f0ac1db"),
+				),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
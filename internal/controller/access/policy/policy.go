@@ -0,0 +1,230 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/access/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/access/policy"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotPolicy = "managed resource is not an AccessPolicy custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errPolicyLookup   = "cannot lookup access policy"
+	errPolicyCreation = "cannot create access policy"
+	errPolicyUpdate   = "cannot update access policy"
+	errPolicyDeletion = "cannot delete access policy"
+	errNoAccount      = "no account found"
+	errNoApplication  = "no access application found"
+)
+
+// Setup adds a controller that reconciles AccessPolicy managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.AccessPolicyGroupKind)
+	o = o.For(v1alpha1.AccessPolicyGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.AccessPolicyGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (policy.Client, error) {
+				return policy.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.AccessPolicy{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (policy.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.AccessPolicy)
+	if !ok {
+		return nil, errors.New(errNotPolicy)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client policy.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.AccessPolicy)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotPolicy)
+	}
+
+	// Policy does not exist if we dont have an ID stored in external-name
+	pid := meta.GetExternalName(cr)
+	if pid == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalObservation{}, errors.New(errNoAccount)
+	}
+
+	if cr.Spec.ForProvider.Application == nil {
+		return managed.ExternalObservation{}, errors.New(errNoApplication)
+	}
+
+	p, err := e.client.AccessPolicy(ctx, *cr.Spec.ForProvider.Account, *cr.Spec.ForProvider.Application, pid)
+	if err != nil {
+		return managed.ExternalObservation{},
+			errors.Wrap(resource.Ignore(policy.IsPolicyNotFound, err), errPolicyLookup)
+	}
+
+	cr.Status.SetConditions(rtv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: policy.LateInitialize(&cr.Spec.ForProvider, p),
+		ResourceUpToDate:        policy.UpToDate(&cr.Spec.ForProvider, p),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.AccessPolicy)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotPolicy)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalCreation{}, errors.New(errNoAccount)
+	}
+
+	if cr.Spec.ForProvider.Application == nil {
+		return managed.ExternalCreation{}, errors.New(errNoApplication)
+	}
+
+	np, err := policy.CreatePolicy(ctx, e.client, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errPolicyCreation)
+	}
+
+	// Update the external name with the ID of the new Policy
+	meta.SetExternalName(cr, np.ID)
+
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.AccessPolicy)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotPolicy)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalUpdate{}, errors.Wrap(errors.New(errNoAccount), errPolicyUpdate)
+	}
+
+	if cr.Spec.ForProvider.Application == nil {
+		return managed.ExternalUpdate{}, errors.Wrap(errors.New(errNoApplication), errPolicyUpdate)
+	}
+
+	pid := meta.GetExternalName(cr)
+
+	// Update should never be called on a nonexistent resource
+	if pid == "" {
+		return managed.ExternalUpdate{}, errors.New(errPolicyUpdate)
+	}
+
+	return managed.ExternalUpdate{},
+		errors.Wrap(
+			policy.UpdatePolicy(ctx, e.client, pid, &cr.Spec.ForProvider),
+			errPolicyUpdate,
+		)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.AccessPolicy)
+	if !ok {
+		return errors.New(errNotPolicy)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return errors.Wrap(errors.New(errNoAccount), errPolicyDeletion)
+	}
+
+	if cr.Spec.ForProvider.Application == nil {
+		return errors.Wrap(errors.New(errNoApplication), errPolicyDeletion)
+	}
+
+	pid := meta.GetExternalName(cr)
+
+	// Delete should never be called on a nonexistent resource
+	if pid == "" {
+		return errors.New(errPolicyDeletion)
+	}
+
+	return errors.Wrap(
+		resource.Ignore(policy.IsPolicyNotFound,
+			e.client.DeleteAccessPolicy(ctx, *cr.Spec.ForProvider.Account, *cr.Spec.ForProvider.Application, pid)),
+		errPolicyDeletion)
+}
@@ -0,0 +1,670 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/benagricola/provider-cloudflare/apis/access/v1alpha1"
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/access/policy"
+	"github.com/benagricola/provider-cloudflare/internal/clients/access/policy/fake"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+type policyModifier func(*v1alpha1.AccessPolicy)
+
+func withAccount(account string) policyModifier {
+	return func(p *v1alpha1.AccessPolicy) { p.Spec.ForProvider.Account = &account }
+}
+
+func withApplication(application string) policyModifier {
+	return func(p *v1alpha1.AccessPolicy) { p.Spec.ForProvider.Application = &application }
+}
+
+func withDecision(decision string) policyModifier {
+	return func(p *v1alpha1.AccessPolicy) { p.Spec.ForProvider.Decision = decision }
+}
+
+func withName(name string) policyModifier {
+	return func(p *v1alpha1.AccessPolicy) { p.Spec.ForProvider.Name = name }
+}
+
+func withExternalName(policyID string) policyModifier {
+	return func(p *v1alpha1.AccessPolicy) { meta.SetExternalName(p, policyID) }
+}
+
+func policyBuild(m ...policyModifier) *v1alpha1.AccessPolicy {
+	cr := &v1alpha1.AccessPolicy{}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (policy.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotPolicy": {
+			reason: "An error should be returned if the managed resource is not an *AccessPolicy",
+			fields: fields{
+				newClient: policy.NewClient,
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotPolicy),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube:      mc,
+				newClient: policy.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.AccessPolicy{
+					Spec: v1alpha1.AccessPolicySpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when a client is created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *pcv1alpha1.ProviderConfig:
+							o.Spec.Credentials.Source = "Secret"
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								Key: "creds",
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{
+								"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+							}
+						}
+						return nil
+					}),
+				},
+				newClient: policy.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.AccessPolicy{
+					Spec: v1alpha1.AccessPolicySpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (policy.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client policy.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotPolicy": {
+			reason: "An error should be returned if the managed resource is not an *AccessPolicy",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotPolicy),
+			},
+		},
+		"ErrNoPolicy": {
+			reason: "We should return ResourceExists: false when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: &v1alpha1.AccessPolicy{},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the Access Policy has no account",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: policyBuild(withExternalName("699d98642c564d2e855e9661899b7252")),
+			},
+			want: want{
+				err: errors.New(errNoAccount),
+			},
+		},
+		"ErrNoApplication": {
+			reason: "We should return an error if the Access Policy has no application",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: policyBuild(
+					withAccount("Test Account"),
+					withExternalName("699d98642c564d2e855e9661899b7252"),
+				),
+			},
+			want: want{
+				err: errors.New(errNoApplication),
+			},
+		},
+		"ErrPolicyLookup": {
+			reason: "We should return an empty observation and an error if the API returned an error",
+			fields: fields{
+				client: fake.MockClient{
+					MockAccessPolicy: func(ctx context.Context, accountID, applicationID, policyID string) (cloudflare.AccessPolicy, error) {
+						return cloudflare.AccessPolicy{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: policyBuild(
+					withAccount("Test Account"),
+					withApplication("480f4f69-1a28-4fdd-9240-1ed29f0ac1db"),
+					withExternalName("699d98642c564d2e855e9661899b7252"),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errPolicyLookup),
+			},
+		},
+		"ErrPolicyNotFound": {
+			reason: "We should return ResourceExists: false if the Access Policy was deleted on Cloudflare",
+			fields: fields{
+				client: fake.MockClient{
+					MockAccessPolicy: func(ctx context.Context, accountID, applicationID, policyID string) (cloudflare.AccessPolicy, error) {
+						return cloudflare.AccessPolicy{}, errors.New("HTTP status 404: not found")
+					},
+				},
+			},
+			args: args{
+				mg: policyBuild(
+					withAccount("Test Account"),
+					withApplication("480f4f69-1a28-4fdd-9240-1ed29f0ac1db"),
+					withExternalName("699d98642c564d2e855e9661899b7252"),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Success": {
+			reason: "We should return ResourceExists: true and no error when an Access Policy is found",
+			fields: fields{
+				client: fake.MockClient{
+					MockAccessPolicy: func(ctx context.Context, accountID, applicationID, policyID string) (cloudflare.AccessPolicy, error) {
+						return cloudflare.AccessPolicy{
+							ID:         policyID,
+							Name:       "Staff",
+							Decision:   "allow",
+							Precedence: 1,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				mg: policyBuild(
+					withAccount("Test Account"),
+					withApplication("480f4f69-1a28-4fdd-9240-1ed29f0ac1db"),
+					withExternalName("699d98642c564d2e855e9661899b7252"),
+					withName("Staff"),
+					withDecision("allow"),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client policy.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotPolicy": {
+			reason: "An error should be returned if the managed resource is not an *AccessPolicy",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotPolicy),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the Access Policy has no account",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: policyBuild(),
+			},
+			want: want{
+				err: errors.New(errNoAccount),
+			},
+		},
+		"ErrPolicyCreate": {
+			reason: "We should return any errors during the create process",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateAccessPolicy: func(ctx context.Context, accountID, applicationID string, p cloudflare.AccessPolicy) (cloudflare.AccessPolicy, error) {
+						return cloudflare.AccessPolicy{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: policyBuild(withAccount("Test Account"), withName("Staff"), withApplication("480f4f69-1a28-4fdd-9240-1ed29f0ac1db"), withDecision("allow")),
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errBoom, "error creating access policy"), errPolicyCreation),
+			},
+		},
+		"Success": {
+			reason: "We should return ExternalNameAssigned: true and no error when an Access Policy is created",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateAccessPolicy: func(ctx context.Context, accountID, applicationID string, p cloudflare.AccessPolicy) (cloudflare.AccessPolicy, error) {
+						p.ID = "699d98642c564d2e855e9661899b7252"
+						return p, nil
+					},
+				},
+			},
+			args: args{
+				mg: policyBuild(withAccount("Test Account"), withName("Staff"), withApplication("480f4f69-1a28-4fdd-9240-1ed29f0ac1db"), withDecision("allow")),
+			},
+			want: want{
+				o: managed.ExternalCreation{ExternalNameAssigned: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client policy.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotPolicy": {
+			reason: "An error should be returned if the managed resource is not an *AccessPolicy",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotPolicy),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the Access Policy has no account",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: policyBuild(withExternalName("699d98642c564d2e855e9661899b7252")),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoAccount), errPolicyUpdate),
+			},
+		},
+		"ErrPolicyUpdate": {
+			reason: "We should return any errors during the update process",
+			fields: fields{
+				client: fake.MockClient{
+					MockUpdateAccessPolicy: func(ctx context.Context, accountID, applicationID string, p cloudflare.AccessPolicy) (cloudflare.AccessPolicy, error) {
+						return cloudflare.AccessPolicy{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: policyBuild(
+					withAccount("Test Account"),
+					withApplication("480f4f69-1a28-4fdd-9240-1ed29f0ac1db"),
+					withExternalName("699d98642c564d2e855e9661899b7252"),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errBoom, "error updating access policy"), errPolicyUpdate),
+			},
+		},
+		"Success": {
+			reason: "We should return no error when an Access Policy is updated",
+			fields: fields{
+				client: fake.MockClient{
+					MockUpdateAccessPolicy: func(ctx context.Context, accountID, applicationID string, p cloudflare.AccessPolicy) (cloudflare.AccessPolicy, error) {
+						if p.ID != "699d98642c564d2e855e9661899b7252" {
+							return cloudflare.AccessPolicy{}, errBoom
+						}
+						return p, nil
+					},
+				},
+			},
+			args: args{
+				mg: policyBuild(
+					withAccount("Test Account"),
+					withApplication("480f4f69-1a28-4fdd-9240-1ed29f0ac1db"),
+					withExternalName("699d98642c564d2e855e9661899b7252"),
+				),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client policy.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotPolicy": {
+			reason: "An error should be returned if the managed resource is not an *AccessPolicy",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotPolicy),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the Access Policy has no account",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: policyBuild(withExternalName("699d98642c564d2e855e9661899b7252")),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoAccount), errPolicyDeletion),
+			},
+		},
+		"ErrPolicyDelete": {
+			reason: "We should return any errors during the delete process",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteAccessPolicy: func(ctx context.Context, accountID, applicationID, policyID string) error {
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: policyBuild(
+					withAccount("Test Account"),
+					withApplication("480f4f69-1a28-4fdd-9240-1ed29f0ac1db"),
+					withExternalName("699d98642c564d2e855e9661899b7252"),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errPolicyDeletion),
+			},
+		},
+		"SuccessNotFound": {
+			reason: "We should return no error when the Access Policy was already deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteAccessPolicy: func(ctx context.Context, accountID, applicationID, policyID string) error {
+						return errors.New("HTTP status 404: not found")
+					},
+				},
+			},
+			args: args{
+				mg: policyBuild(
+					withAccount("Test Account"),
+					withApplication("480f4f69-1a28-4fdd-9240-1ed29f0ac1db"),
+					withExternalName("699d98642c564d2e855e9661899b7252"),
+				),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"Success": {
+			reason: "We should return no error when an Access Policy is deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteAccessPolicy: func(ctx context.Context, accountID, applicationID, policyID string) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: policyBuild(
+					withAccount("Test Account"),
+					withApplication("480f4f69-1a28-4fdd-9240-1ed29f0ac1db"),
+					withExternalName("699d98642c564d2e855e9661899b7252"),
+				),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package account
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/account/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/accounts"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotAccount = "managed resource is not an Account custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errAccountLookup   = "cannot lookup Account"
+	errAccountCreation = "cannot create Account"
+	errAccountUpdate   = "cannot update Account"
+	errAccountDeletion = "cannot delete Account"
+)
+
+// Setup adds a controller that reconciles Account managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.AccountGroupKind)
+	o = o.For(v1alpha1.AccountGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.AccountGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (accounts.Client, error) {
+				return accounts.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.Account{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (accounts.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.Account)
+	if !ok {
+		return nil, errors.New(errNotAccount)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client accounts.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Account)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotAccount)
+	}
+
+	// Account does not exist if we dont have an ID stored in external-name
+	aid := meta.GetExternalName(cr)
+	if aid == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	a, _, err := e.client.Account(ctx, aid)
+	if err != nil {
+		return managed.ExternalObservation{},
+			errors.Wrap(resource.Ignore(accounts.IsAccountNotFound, err), errAccountLookup)
+	}
+
+	cr.Status.AtProvider = accounts.GenerateObservation(a)
+	cr.Status.SetConditions(rtv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: accounts.LateInitialize(&cr.Spec.ForProvider, a),
+		ResourceUpToDate:        accounts.UpToDate(&cr.Spec.ForProvider, a),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Account)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotAccount)
+	}
+
+	a, err := e.client.CreateAccount(ctx, accounts.ParametersToAccount(cr.Spec.ForProvider, nil))
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errAccountCreation)
+	}
+
+	cr.Status.AtProvider = accounts.GenerateObservation(a)
+
+	// Update the external name with the ID of the new Account
+	meta.SetExternalName(cr, a.ID)
+
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Account)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotAccount)
+	}
+
+	aid := meta.GetExternalName(cr)
+	// Update should never be called on a nonexistent resource
+	if aid == "" {
+		return managed.ExternalUpdate{}, errors.New(errAccountUpdate)
+	}
+
+	return managed.ExternalUpdate{},
+		errors.Wrap(
+			accounts.UpdateAccount(ctx, e.client, aid, cr.Spec.ForProvider),
+			errAccountUpdate,
+		)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Account)
+	if !ok {
+		return errors.New(errNotAccount)
+	}
+
+	aid := meta.GetExternalName(cr)
+	if aid == "" {
+		return errors.New(errAccountDeletion)
+	}
+
+	return errors.Wrap(
+		resource.Ignore(accounts.IsAccountNotFound, e.client.DeleteAccount(ctx, aid)),
+		errAccountDeletion,
+	)
+}
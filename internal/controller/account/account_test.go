@@ -0,0 +1,638 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package account
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/benagricola/provider-cloudflare/apis/account/v1alpha1"
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/accounts"
+	"github.com/benagricola/provider-cloudflare/internal/clients/accounts/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+type AccountModifier func(*v1alpha1.Account)
+
+func withName(name string) AccountModifier {
+	return func(a *v1alpha1.Account) { a.Spec.ForProvider.Name = name }
+}
+
+func withType(t string) AccountModifier {
+	return func(a *v1alpha1.Account) { a.Spec.ForProvider.Type = &t }
+}
+
+func withExternalName(accountID string) AccountModifier {
+	return func(a *v1alpha1.Account) { meta.SetExternalName(a, accountID) }
+}
+
+func Account(m ...AccountModifier) *v1alpha1.Account {
+	cr := &v1alpha1.Account{}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (accounts.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   error
+	}{
+		"ErrNotAccount": {
+			reason: "An error should be returned if the managed resource is not an *Account",
+			args: args{
+				mg: nil,
+			},
+			want: errors.New(errNotAccount),
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube: mc,
+			},
+			args: args{
+				mg: &v1alpha1.Account{
+					Spec: v1alpha1.AccountSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: errors.Wrap(errGetProviderConfig, errClientConfig),
+		},
+		"ConnectReturnOK": {
+			reason: "Connect should return no error when passed the correct values",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *pcv1alpha1.ProviderConfig:
+							o.Spec.Credentials.Source = "Secret"
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								Key: "creds",
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{
+								"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+							}
+						}
+						return nil
+					}),
+				},
+				newClient: accounts.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.Account{
+					Spec: v1alpha1.AccountSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (accounts.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	errAccountNoAccess := &cloudflare.APIRequestError{StatusCode: http.StatusForbidden, Errors: []cloudflare.ResponseInfo{{Code: 7003, Message: "Could not route to /accounts/abc, perhaps your object identifier is invalid?"}}}
+	errAccountGone := &cloudflare.APIRequestError{StatusCode: http.StatusNotFound, Errors: []cloudflare.ResponseInfo{{Code: 7003, Message: "Could not route to /accounts/abc, perhaps your object identifier is invalid?"}}}
+
+	type fields struct {
+		client accounts.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotAccount": {
+			reason: "An error should be returned if the managed resource is not an *Account",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotAccount),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return ResourceExists: false when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: Account(),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrAccountLookup": {
+			reason: "We should return an empty observation and an error if the API returned an error",
+			fields: fields{
+				client: fake.MockClient{
+					MockAccount: func(ctx context.Context, accountID string) (cloudflare.Account, cloudflare.ResultInfo, error) {
+						return cloudflare.Account{}, cloudflare.ResultInfo{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: Account(withExternalName("1234beef")),
+			},
+			want: want{
+				o:   managed.ExternalObservation{},
+				err: errors.Wrap(errBoom, errAccountLookup),
+			},
+		},
+		"ErrAccountNoAccess": {
+			reason: "We should return an error rather than ResourceExists: false if the Account cannot be accessed, even though Cloudflare returns the same error code as when it is gone",
+			fields: fields{
+				client: fake.MockClient{
+					MockAccount: func(ctx context.Context, accountID string) (cloudflare.Account, cloudflare.ResultInfo, error) {
+						return cloudflare.Account{}, cloudflare.ResultInfo{}, errAccountNoAccess
+					},
+				},
+			},
+			args: args{
+				mg: Account(withExternalName("1234beef")),
+			},
+			want: want{
+				o:   managed.ExternalObservation{},
+				err: errors.Wrap(errAccountNoAccess, errAccountLookup),
+			},
+		},
+		"ErrAccountNotFound": {
+			reason: "We should return ResourceExists: false if the Account is not found (deleted on CF side)",
+			fields: fields{
+				client: fake.MockClient{
+					MockAccount: func(ctx context.Context, accountID string) (cloudflare.Account, cloudflare.ResultInfo, error) {
+						return cloudflare.Account{}, cloudflare.ResultInfo{}, errAccountGone
+					},
+				},
+			},
+			args: args{
+				mg: Account(withExternalName("1234beef")),
+			},
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: false},
+				err: nil,
+			},
+		},
+		"Success": {
+			reason: "We should return ResourceExists: true and no error when an Account is found",
+			fields: fields{
+				client: fake.MockClient{
+					MockAccount: func(ctx context.Context, accountID string) (cloudflare.Account, cloudflare.ResultInfo, error) {
+						return cloudflare.Account{
+							ID:   accountID,
+							Name: "example",
+							Type: "standard",
+						}, cloudflare.ResultInfo{}, nil
+					},
+				},
+			},
+			args: args{
+				mg: Account(
+					withExternalName("1234beef"),
+					withName("example"),
+					withType("standard"),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				err: nil,
+			},
+		},
+		"SuccessLateInitialized": {
+			reason: "We should late initialize the Account type when it is not set",
+			fields: fields{
+				client: fake.MockClient{
+					MockAccount: func(ctx context.Context, accountID string) (cloudflare.Account, cloudflare.ResultInfo, error) {
+						return cloudflare.Account{
+							ID:   accountID,
+							Name: "example",
+							Type: "standard",
+						}, cloudflare.ResultInfo{}, nil
+					},
+				},
+			},
+			args: args{
+				mg: Account(
+					withExternalName("1234beef"),
+					withName("example"),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceLateInitialized: true,
+					ResourceUpToDate:        true,
+				},
+				err: nil,
+			},
+		},
+		"SuccessNotUpToDate": {
+			reason: "We should return ResourceUpToDate: false when the Account name differs",
+			fields: fields{
+				client: fake.MockClient{
+					MockAccount: func(ctx context.Context, accountID string) (cloudflare.Account, cloudflare.ResultInfo, error) {
+						return cloudflare.Account{
+							ID:   accountID,
+							Name: "old",
+							Type: "standard",
+						}, cloudflare.ResultInfo{}, nil
+					},
+				},
+			},
+			args: args{
+				mg: Account(
+					withExternalName("1234beef"),
+					withName("example"),
+					withType("standard"),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client accounts.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotAccount": {
+			reason: "An error should be returned if the managed resource is not an *Account",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotAccount),
+			},
+		},
+		"ErrAccountCreate": {
+			reason: "We should return any errors during the create process",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateAccount: func(ctx context.Context, account cloudflare.Account) (cloudflare.Account, error) {
+						return cloudflare.Account{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: Account(withName("example")),
+			},
+			want: want{
+				o:   managed.ExternalCreation{},
+				err: errors.Wrap(errBoom, errAccountCreation),
+			},
+		},
+		"Success": {
+			reason: "We should return ExternalNameAssigned: true and no error when an Account is created",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateAccount: func(ctx context.Context, account cloudflare.Account) (cloudflare.Account, error) {
+						account.ID = "1234beef"
+						return account, nil
+					},
+				},
+			},
+			args: args{
+				mg: Account(withName("example"), withType("standard")),
+			},
+			want: want{
+				o: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client accounts.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotAccount": {
+			reason: "An error should be returned if the managed resource is not an *Account",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotAccount),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: Account(withName("example")),
+			},
+			want: want{
+				o:   managed.ExternalUpdate{},
+				err: errors.New(errAccountUpdate),
+			},
+		},
+		"ErrAccountUpdate": {
+			reason: "We should return any errors during the update process",
+			fields: fields{
+				client: fake.MockClient{
+					MockAccount: func(ctx context.Context, accountID string) (cloudflare.Account, cloudflare.ResultInfo, error) {
+						return cloudflare.Account{ID: accountID}, cloudflare.ResultInfo{}, nil
+					},
+					MockUpdateAccount: func(ctx context.Context, accountID string, account cloudflare.Account) (cloudflare.Account, error) {
+						return cloudflare.Account{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: Account(withExternalName("1234beef"), withName("example")),
+			},
+			want: want{
+				o:   managed.ExternalUpdate{},
+				err: errors.Wrap(errBoom, errAccountUpdate),
+			},
+		},
+		"Success": {
+			reason: "We should return no error when an Account is updated",
+			fields: fields{
+				client: fake.MockClient{
+					MockAccount: func(ctx context.Context, accountID string) (cloudflare.Account, cloudflare.ResultInfo, error) {
+						return cloudflare.Account{ID: accountID}, cloudflare.ResultInfo{}, nil
+					},
+					MockUpdateAccount: func(ctx context.Context, accountID string, account cloudflare.Account) (cloudflare.Account, error) {
+						return account, nil
+					},
+				},
+			},
+			args: args{
+				mg: Account(withExternalName("1234beef"), withName("example")),
+			},
+			want: want{
+				o:   managed.ExternalUpdate{},
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client accounts.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotAccount": {
+			reason: "An error should be returned if the managed resource is not an *Account",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotAccount),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: Account(withName("example")),
+			},
+			want: want{
+				err: errors.New(errAccountDeletion),
+			},
+		},
+		"ErrAccountDelete": {
+			reason: "We should return any errors during the delete process",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteAccount: func(ctx context.Context, accountID string) error {
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: Account(withExternalName("1234beef")),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errAccountDeletion),
+			},
+		},
+		"Success": {
+			reason: "We should return no error when an Account is deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteAccount: func(ctx context.Context, accountID string) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: Account(withExternalName("1234beef")),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package botmanagement
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/bots/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/bots/botmanagement"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotBotManagement = "managed resource is not a BotManagement custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errBotManagementLookup   = "cannot lookup bot management"
+	errBotManagementCreation = "cannot configure bot management"
+	errBotManagementUpdate   = "cannot update bot management"
+	errBotManagementNoZone   = "no zone found"
+)
+
+// Setup adds a controller that reconciles BotManagement managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.BotManagementGroupKind)
+	o = o.For(v1alpha1.BotManagementGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.BotManagementGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (botmanagement.Client, error) {
+				return botmanagement.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.BotManagement{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (botmanagement.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.BotManagement)
+	if !ok {
+		return nil, errors.New(errNotBotManagement)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client botmanagement.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.BotManagement)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotBotManagement)
+	}
+
+	// Bot protection is a setting of the Zone that cannot be deleted, so
+	// it is left unchanged and no longer managed once deleted.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalObservation{}, errors.New(errBotManagementNoZone)
+	}
+
+	s, err := botmanagement.BotManagement(ctx, e.client, *cr.Spec.ForProvider.Zone)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errBotManagementLookup)
+	}
+
+	cr.Status.AtProvider = botmanagement.GenerateObservation(*s)
+	cr.Status.SetConditions(rtv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: botmanagement.UpToDate(&cr.Spec.ForProvider, *s),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.BotManagement)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotBotManagement)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalCreation{}, errors.Wrap(errors.New(errBotManagementNoZone), errBotManagementCreation)
+	}
+
+	cr.SetConditions(rtv1.Creating())
+
+	err := botmanagement.UpdateBotManagement(ctx, e.client, *cr.Spec.ForProvider.Zone, cr.Spec.ForProvider)
+	return managed.ExternalCreation{}, errors.Wrap(err, errBotManagementCreation)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.BotManagement)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotBotManagement)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalUpdate{}, errors.Wrap(errors.New(errBotManagementNoZone), errBotManagementUpdate)
+	}
+
+	err := botmanagement.UpdateBotManagement(ctx, e.client, *cr.Spec.ForProvider.Zone, cr.Spec.ForProvider)
+	return managed.ExternalUpdate{}, errors.Wrap(err, errBotManagementUpdate)
+}
+
+// Delete does nothing, as bot protection is a setting of the Zone that
+// cannot be deleted.
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	_, ok := mg.(*v1alpha1.BotManagement)
+	if !ok {
+		return errors.New(errNotBotManagement)
+	}
+	return nil
+}
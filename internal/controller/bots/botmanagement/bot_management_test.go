@@ -0,0 +1,564 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package botmanagement
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/benagricola/provider-cloudflare/apis/bots/v1alpha1"
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/bots/botmanagement"
+	"github.com/benagricola/provider-cloudflare/internal/clients/bots/botmanagement/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ptr "k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const testZone = "023e105f4ecef8ad9ca31a8372d0c353"
+
+type botManagementModifier func(*v1alpha1.BotManagement)
+
+func withZone(zoneID string) botManagementModifier {
+	return func(r *v1alpha1.BotManagement) { r.Spec.ForProvider.Zone = &zoneID }
+}
+
+func withFightMode(enabled bool) botManagementModifier {
+	return func(r *v1alpha1.BotManagement) { r.Spec.ForProvider.FightMode = &enabled }
+}
+
+func withDeletionTimestamp() botManagementModifier {
+	return func(r *v1alpha1.BotManagement) { r.SetDeletionTimestamp(&metav1.Time{}) }
+}
+
+func withObservation(o v1alpha1.BotManagementObservation) botManagementModifier {
+	return func(r *v1alpha1.BotManagement) { r.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) botManagementModifier {
+	return func(r *v1alpha1.BotManagement) { r.Status.SetConditions(c...) }
+}
+
+func botManagement(m ...botManagementModifier) *v1alpha1.BotManagement {
+	cr := &v1alpha1.BotManagement{}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// mockRaw returns a Raw function that responds to every request with
+// the passed response.
+func mockRaw(raw string, err error) func(method, endpoint string, data interface{}) (json.RawMessage, error) {
+	return func(method, endpoint string, data interface{}) (json.RawMessage, error) {
+		return json.RawMessage(raw), err
+	}
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (botmanagement.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotBotManagement": {
+			reason: "An error should be returned if the managed resource is not a *BotManagement",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotBotManagement),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube: mc,
+			},
+			args: args{
+				mg: &v1alpha1.BotManagement{
+					Spec: v1alpha1.BotManagementSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when a client is created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *pcv1alpha1.ProviderConfig:
+							o.Spec.Credentials.Source = "Secret"
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								Key: "creds",
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{
+								"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+							}
+						}
+						return nil
+					}),
+				},
+				newClient: botmanagement.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.BotManagement{
+					Spec: v1alpha1.BotManagementSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (botmanagement.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client botmanagement.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		cr  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotBotManagement": {
+			reason: "An error should be returned if the managed resource is not a *BotManagement",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotBotManagement),
+			},
+		},
+		"Deleted": {
+			reason: "We should return ResourceExists: false without looking up settings once the BotManagement is deleted",
+			fields: fields{
+				client: fake.MockClient{MockRawContext: mockRaw("", errBoom)},
+			},
+			args: args{
+				mg: botManagement(withZone(testZone), withDeletionTimestamp()),
+			},
+			want: want{
+				cr: botManagement(withZone(testZone), withDeletionTimestamp()),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the BotManagement has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: botManagement(),
+			},
+			want: want{
+				cr:  botManagement(),
+				err: errors.New(errBotManagementNoZone),
+			},
+		},
+		"ErrBotManagementLookup": {
+			reason: "We should return an empty observation and an error if the API returned an error",
+			fields: fields{
+				client: fake.MockClient{MockRawContext: mockRaw("", errBoom)},
+			},
+			args: args{
+				mg: botManagement(withZone(testZone)),
+			},
+			want: want{
+				cr:  botManagement(withZone(testZone)),
+				err: errors.Wrap(errBoom, errBotManagementLookup),
+			},
+		},
+		"NotUpToDate": {
+			reason: "We should observe the capabilities of the Zone and report the resource as not up to date if settings differ",
+			fields: fields{
+				client: fake.MockClient{MockRawContext: mockRaw(`{"enable_js":false,"fight_mode":false,"using_latest_model":true}`, nil)},
+			},
+			args: args{
+				mg: botManagement(withZone(testZone), withFightMode(true)),
+			},
+			want: want{
+				cr: botManagement(
+					withZone(testZone),
+					withFightMode(true),
+					withObservation(v1alpha1.BotManagementObservation{
+						Product:          v1alpha1.ProductBotFightMode,
+						Capabilities:     []string{"fightMode", "enableJS"},
+						UsingLatestModel: true,
+					}),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"UpToDate": {
+			reason: "We should report the resource as up to date when settings match",
+			fields: fields{
+				client: fake.MockClient{MockRawContext: mockRaw(`{"enable_js":false,"fight_mode":true}`, nil)},
+			},
+			args: args{
+				mg: botManagement(withZone(testZone), withFightMode(true)),
+			},
+			want: want{
+				cr: botManagement(
+					withZone(testZone),
+					withFightMode(true),
+					withObservation(v1alpha1.BotManagementObservation{
+						Product:      v1alpha1.ProductBotFightMode,
+						Capabilities: []string{"fightMode", "enableJS"},
+					}),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want cr, +got cr:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client botmanagement.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotBotManagement": {
+			reason: "An error should be returned if the managed resource is not a *BotManagement",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotBotManagement),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the BotManagement has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: botManagement(),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errBotManagementNoZone), errBotManagementCreation),
+			},
+		},
+		"ErrBotManagementCreate": {
+			reason: "We should return any errors while updating bot management",
+			fields: fields{
+				client: fake.MockClient{MockRawContext: mockRaw("", errBoom)},
+			},
+			args: args{
+				mg: botManagement(withZone(testZone), withFightMode(true)),
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errBoom, "error updating bot management"), errBotManagementCreation),
+			},
+		},
+		"Success": {
+			reason: "We should send the requested settings and return no error",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if diff := cmp.Diff(botmanagement.Settings{FightMode: ptr.BoolPtr(true)}, data); diff != "" {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{"fight_mode":true}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: botManagement(withZone(testZone), withFightMode(true)),
+			},
+			want: want{
+				o: managed.ExternalCreation{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client botmanagement.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotBotManagement": {
+			reason: "An error should be returned if the managed resource is not a *BotManagement",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotBotManagement),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the BotManagement has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: botManagement(),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errBotManagementNoZone), errBotManagementUpdate),
+			},
+		},
+		"ErrBotManagementUpdate": {
+			reason: "We should return any errors while updating bot management",
+			fields: fields{
+				client: fake.MockClient{MockRawContext: mockRaw("", errBoom)},
+			},
+			args: args{
+				mg: botManagement(withZone(testZone), withFightMode(true)),
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errBoom, "error updating bot management"), errBotManagementUpdate),
+			},
+		},
+		"Success": {
+			reason: "We should send the requested settings and return no error",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if diff := cmp.Diff(botmanagement.Settings{FightMode: ptr.BoolPtr(true)}, data); diff != "" {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{"fight_mode":true}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: botManagement(withZone(testZone), withFightMode(true)),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		client botmanagement.Client
+		args   args
+		want   want
+	}{
+		"ErrNotBotManagement": {
+			reason: "An error should be returned if the managed resource is not a *BotManagement",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotBotManagement),
+			},
+		},
+		"Success": {
+			reason: "We should leave the bot protection of the Zone unchanged and return no error",
+			client: fake.MockClient{
+				MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+					return nil, errors.New("unexpected request")
+				},
+			},
+			args: args{
+				mg: botManagement(withZone(testZone), withFightMode(true)),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tieredcache
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/cache/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/cache/tieredcache"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotTieredCache = "managed resource is not a TieredCache custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errTieredCacheLookup   = "cannot lookup tiered cache"
+	errSmartTopologyLookup = "cannot lookup smart tiered cache"
+	errTieredCacheCreation = "cannot enable tiered cache"
+	errTieredCacheUpdate   = "cannot update tiered cache"
+	errTieredCacheDeletion = "cannot disable tiered cache"
+	errTieredCacheNoZone   = "no zone found"
+)
+
+// Setup adds a controller that reconciles TieredCache managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.TieredCacheGroupKind)
+	o = o.For(v1alpha1.TieredCacheGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.TieredCacheGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (tieredcache.Client, error) {
+				return tieredcache.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.TieredCache{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (tieredcache.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.TieredCache)
+	if !ok {
+		return nil, errors.New(errNotTieredCache)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client tieredcache.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.TieredCache)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTieredCache)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalObservation{}, errors.New(errTieredCacheNoZone)
+	}
+
+	tc, err := tieredcache.TieredCaching(ctx, e.client, *cr.Spec.ForProvider.Zone)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errTieredCacheLookup)
+	}
+
+	// Tiered Cache is a setting of the Zone, so it exists only while
+	// enabled.
+	if tc.Value != tieredcache.ValueOn {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	smart, err := tieredcache.SmartTopology(ctx, e.client, *cr.Spec.ForProvider.Zone)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errSmartTopologyLookup)
+	}
+
+	topology := tieredcache.Topology(*smart)
+	cr.Status.AtProvider = tieredcache.GenerateObservation(topology)
+	cr.Status.SetConditions(rtv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: tieredcache.UpToDate(&cr.Spec.ForProvider, topology),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.TieredCache)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTieredCache)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalCreation{}, errors.Wrap(errors.New(errTieredCacheNoZone), errTieredCacheCreation)
+	}
+
+	cr.SetConditions(rtv1.Creating())
+
+	err := tieredcache.Enable(ctx, e.client, *cr.Spec.ForProvider.Zone, cr.Spec.ForProvider)
+	return managed.ExternalCreation{}, errors.Wrap(err, errTieredCacheCreation)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.TieredCache)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotTieredCache)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalUpdate{}, errors.Wrap(errors.New(errTieredCacheNoZone), errTieredCacheUpdate)
+	}
+
+	err := tieredcache.SetTopology(ctx, e.client, *cr.Spec.ForProvider.Zone, cr.Spec.ForProvider)
+	return managed.ExternalUpdate{}, errors.Wrap(err, errTieredCacheUpdate)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.TieredCache)
+	if !ok {
+		return errors.New(errNotTieredCache)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return errors.Wrap(errors.New(errTieredCacheNoZone), errTieredCacheDeletion)
+	}
+
+	return errors.Wrap(tieredcache.Disable(ctx, e.client, *cr.Spec.ForProvider.Zone), errTieredCacheDeletion)
+}
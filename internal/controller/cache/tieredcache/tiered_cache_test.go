@@ -0,0 +1,605 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tieredcache
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/benagricola/provider-cloudflare/apis/cache/v1alpha1"
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/cache/tieredcache"
+	"github.com/benagricola/provider-cloudflare/internal/clients/cache/tieredcache/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const testZone = "023e105f4ecef8ad9ca31a8372d0c353"
+
+type tieredCacheModifier func(*v1alpha1.TieredCache)
+
+func withZone(zoneID string) tieredCacheModifier {
+	return func(r *v1alpha1.TieredCache) { r.Spec.ForProvider.Zone = &zoneID }
+}
+
+func withTopology(topology string) tieredCacheModifier {
+	return func(r *v1alpha1.TieredCache) { r.Spec.ForProvider.Topology = &topology }
+}
+
+func withObservation(o v1alpha1.TieredCacheObservation) tieredCacheModifier {
+	return func(r *v1alpha1.TieredCache) { r.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) tieredCacheModifier {
+	return func(r *v1alpha1.TieredCache) { r.Status.SetConditions(c...) }
+}
+
+func tieredCache(m ...tieredCacheModifier) *v1alpha1.TieredCache {
+	cr := &v1alpha1.TieredCache{}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// mockRaw returns a Raw function that responds to Tiered Cache and Smart
+// Tiered Cache requests with the passed responses.
+func mockRaw(tiered, smart string, err error) func(method, endpoint string, data interface{}) (json.RawMessage, error) {
+	return func(method, endpoint string, data interface{}) (json.RawMessage, error) {
+		if strings.Contains(endpoint, "/tiered_cache_smart_topology_enable") {
+			return json.RawMessage(smart), err
+		}
+		return json.RawMessage(tiered), err
+	}
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (tieredcache.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTieredCache": {
+			reason: "An error should be returned if the managed resource is not a *TieredCache",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTieredCache),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube: mc,
+			},
+			args: args{
+				mg: &v1alpha1.TieredCache{
+					Spec: v1alpha1.TieredCacheSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when a client is created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *pcv1alpha1.ProviderConfig:
+							o.Spec.Credentials.Source = "Secret"
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								Key: "creds",
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{
+								"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+							}
+						}
+						return nil
+					}),
+				},
+				newClient: tieredcache.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.TieredCache{
+					Spec: v1alpha1.TieredCacheSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (tieredcache.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client tieredcache.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		cr  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTieredCache": {
+			reason: "An error should be returned if the managed resource is not a *TieredCache",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTieredCache),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the TieredCache has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: tieredCache(),
+			},
+			want: want{
+				cr:  tieredCache(),
+				err: errors.New(errTieredCacheNoZone),
+			},
+		},
+		"ErrTieredCacheLookup": {
+			reason: "We should return an empty observation and an error if the API returned an error",
+			fields: fields{
+				client: fake.MockClient{MockRawContext: mockRaw("", "", errBoom)},
+			},
+			args: args{
+				mg: tieredCache(withZone(testZone)),
+			},
+			want: want{
+				cr:  tieredCache(withZone(testZone)),
+				err: errors.Wrap(errBoom, errTieredCacheLookup),
+			},
+		},
+		"Disabled": {
+			reason: "We should return ResourceExists: false if Tiered Cache is disabled on the zone",
+			fields: fields{
+				client: fake.MockClient{MockRawContext: mockRaw(`{"id":"tiered_caching","value":"off"}`, "", nil)},
+			},
+			args: args{
+				mg: tieredCache(withZone(testZone)),
+			},
+			want: want{
+				cr: tieredCache(withZone(testZone)),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"TopologyChanged": {
+			reason: "We should observe the topology and report the resource as not up to date if it differs",
+			fields: fields{
+				client: fake.MockClient{MockRawContext: mockRaw(
+					`{"id":"tiered_caching","value":"on"}`,
+					`{"id":"tiered_cache_smart_topology_enable","value":"on"}`,
+					nil,
+				)},
+			},
+			args: args{
+				mg: tieredCache(withZone(testZone), withTopology(v1alpha1.TopologyGeneric)),
+			},
+			want: want{
+				cr: tieredCache(
+					withZone(testZone),
+					withTopology(v1alpha1.TopologyGeneric),
+					withObservation(v1alpha1.TieredCacheObservation{Topology: v1alpha1.TopologySmart}),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"UpToDate": {
+			reason: "We should set Available and report the resource as up to date when the topology matches",
+			fields: fields{
+				client: fake.MockClient{MockRawContext: mockRaw(
+					`{"id":"tiered_caching","value":"on"}`,
+					`{"id":"tiered_cache_smart_topology_enable","value":"off"}`,
+					nil,
+				)},
+			},
+			args: args{
+				mg: tieredCache(withZone(testZone), withTopology(v1alpha1.TopologyGeneric)),
+			},
+			want: want{
+				cr: tieredCache(
+					withZone(testZone),
+					withTopology(v1alpha1.TopologyGeneric),
+					withObservation(v1alpha1.TieredCacheObservation{Topology: v1alpha1.TopologyGeneric}),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want cr, +got cr:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client tieredcache.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTieredCache": {
+			reason: "An error should be returned if the managed resource is not a *TieredCache",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTieredCache),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the TieredCache has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: tieredCache(),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errTieredCacheNoZone), errTieredCacheCreation),
+			},
+		},
+		"ErrTieredCacheCreate": {
+			reason: "We should return any errors while enabling Tiered Cache",
+			fields: fields{
+				client: fake.MockClient{MockRawContext: mockRaw("", "", errBoom)},
+			},
+			args: args{
+				mg: tieredCache(withZone(testZone)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errTieredCacheCreation),
+			},
+		},
+		"Success": {
+			reason: "We should enable Tiered Cache with the requested topology and return no error",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						want := tieredcache.Setting{Value: tieredcache.ValueOn}
+						if strings.Contains(endpoint, "/tiered_cache_smart_topology_enable") {
+							want.Value = tieredcache.ValueOff
+						}
+						if diff := cmp.Diff(want, data); diff != "" {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: tieredCache(withZone(testZone), withTopology(v1alpha1.TopologyGeneric)),
+			},
+			want: want{
+				o: managed.ExternalCreation{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client tieredcache.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTieredCache": {
+			reason: "An error should be returned if the managed resource is not a *TieredCache",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTieredCache),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the TieredCache has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: tieredCache(),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errTieredCacheNoZone), errTieredCacheUpdate),
+			},
+		},
+		"ErrTieredCacheUpdate": {
+			reason: "We should return any errors while updating Tiered Cache",
+			fields: fields{
+				client: fake.MockClient{MockRawContext: mockRaw("", "", errBoom)},
+			},
+			args: args{
+				mg: tieredCache(withZone(testZone), withTopology(v1alpha1.TopologySmart)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errTieredCacheUpdate),
+			},
+		},
+		"Success": {
+			reason: "We should change only the topology and return no error",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if endpoint != "/zones/"+testZone+"/cache/tiered_cache_smart_topology_enable" {
+							return nil, errBoom
+						}
+						if diff := cmp.Diff(tieredcache.Setting{Value: tieredcache.ValueOn}, data); diff != "" {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: tieredCache(withZone(testZone), withTopology(v1alpha1.TopologySmart)),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client tieredcache.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTieredCache": {
+			reason: "An error should be returned if the managed resource is not a *TieredCache",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTieredCache),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the TieredCache has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: tieredCache(),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errTieredCacheNoZone), errTieredCacheDeletion),
+			},
+		},
+		"ErrTieredCacheDelete": {
+			reason: "We should return any errors while disabling Tiered Cache",
+			fields: fields{
+				client: fake.MockClient{MockRawContext: mockRaw("", "", errBoom)},
+			},
+			args: args{
+				mg: tieredCache(withZone(testZone)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errTieredCacheDeletion),
+			},
+		},
+		"Success": {
+			reason: "We should disable Tiered Cache and return no error",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if diff := cmp.Diff(tieredcache.Setting{Value: tieredcache.ValueOff}, data); diff != "" {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: tieredCache(withZone(testZone), withTopology(v1alpha1.TopologySmart)),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -17,37 +17,99 @@ limitations under the License.
 package controller
 
 import (
-	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 
-	"github.com/crossplane/crossplane-runtime/pkg/logging"
-
+	accessapplication "github.com/benagricola/provider-cloudflare/internal/controller/access/application"
+	accesspolicy "github.com/benagricola/provider-cloudflare/internal/controller/access/policy"
+	account "github.com/benagricola/provider-cloudflare/internal/controller/account"
+	botmanagement "github.com/benagricola/provider-cloudflare/internal/controller/bots/botmanagement"
+	tieredcache "github.com/benagricola/provider-cloudflare/internal/controller/cache/tieredcache"
 	"github.com/benagricola/provider-cloudflare/internal/controller/config"
 	record "github.com/benagricola/provider-cloudflare/internal/controller/dns"
+	dnssec "github.com/benagricola/provider-cloudflare/internal/controller/dns/dnssec"
+	emailroutingaddress "github.com/benagricola/provider-cloudflare/internal/controller/emailrouting/address"
+	emailroutingrule "github.com/benagricola/provider-cloudflare/internal/controller/emailrouting/rule"
+	emailroutingsettings "github.com/benagricola/provider-cloudflare/internal/controller/emailrouting/settings"
 	filter "github.com/benagricola/provider-cloudflare/internal/controller/firewall/filter"
+	ratelimit "github.com/benagricola/provider-cloudflare/internal/controller/firewall/ratelimit"
 	rule "github.com/benagricola/provider-cloudflare/internal/controller/firewall/rule"
+	ruleorder "github.com/benagricola/provider-cloudflare/internal/controller/firewall/ruleorder"
+	healthcheck "github.com/benagricola/provider-cloudflare/internal/controller/healthchecks/healthcheck"
+	loadbalancer "github.com/benagricola/provider-cloudflare/internal/controller/loadbalancing/loadbalancer"
+	monitor "github.com/benagricola/provider-cloudflare/internal/controller/loadbalancing/monitor"
+	pool "github.com/benagricola/provider-cloudflare/internal/controller/loadbalancing/pool"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	queue "github.com/benagricola/provider-cloudflare/internal/controller/queues/queue"
+	r2bucket "github.com/benagricola/provider-cloudflare/internal/controller/r2/bucket"
+	ruleset "github.com/benagricola/provider-cloudflare/internal/controller/rulesets/ruleset"
 	application "github.com/benagricola/provider-cloudflare/internal/controller/spectrum"
+	authenticatedoriginpulls "github.com/benagricola/provider-cloudflare/internal/controller/ssl/authenticatedoriginpulls"
+	customcertificate "github.com/benagricola/provider-cloudflare/internal/controller/ssl/customcertificate"
+	hostnameoriginpull "github.com/benagricola/provider-cloudflare/internal/controller/ssl/hostnameoriginpull"
+	origincacertificate "github.com/benagricola/provider-cloudflare/internal/controller/ssl/origincacertificate"
+	originpullcertificate "github.com/benagricola/provider-cloudflare/internal/controller/ssl/originpullcertificate"
+	totaltls "github.com/benagricola/provider-cloudflare/internal/controller/ssl/totaltls"
 	customhostname "github.com/benagricola/provider-cloudflare/internal/controller/sslsaas/customhostname"
 	fallbackorigin "github.com/benagricola/provider-cloudflare/internal/controller/sslsaas/fallbackorigin"
+	tunnelconfiguration "github.com/benagricola/provider-cloudflare/internal/controller/tunnel/configuration"
+	tunnelroute "github.com/benagricola/provider-cloudflare/internal/controller/tunnel/route"
+	tunnel "github.com/benagricola/provider-cloudflare/internal/controller/tunnel/tunnel"
+	turnstilewidget "github.com/benagricola/provider-cloudflare/internal/controller/turnstile/widget"
+	waitingroom "github.com/benagricola/provider-cloudflare/internal/controller/waitingrooms/waitingroom"
+	kvnamespace "github.com/benagricola/provider-cloudflare/internal/controller/workers/kvnamespace"
+	kvpair "github.com/benagricola/provider-cloudflare/internal/controller/workers/kvpair"
 	route "github.com/benagricola/provider-cloudflare/internal/controller/workers/route"
+	script "github.com/benagricola/provider-cloudflare/internal/controller/workers/script"
 	zone "github.com/benagricola/provider-cloudflare/internal/controller/zone"
 )
 
-// Setup creates all Template controllers with the supplied logger and adds them to
-// the supplied manager.
-func Setup(mgr ctrl.Manager, l logging.Logger, wl workqueue.RateLimiter) error {
-	for _, setup := range []func(ctrl.Manager, logging.Logger, workqueue.RateLimiter) error{
+// Setup creates all Template controllers with the supplied options and adds
+// them to the supplied manager.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	for _, setup := range []func(ctrl.Manager, options.Options) error{
 		application.Setup,
 		config.Setup,
 		rule.Setup,
 		filter.Setup,
+		ratelimit.Setup,
+		ruleorder.Setup,
 		customhostname.Setup,
 		zone.Setup,
 		record.Setup,
+		dnssec.Setup,
 		route.Setup,
 		fallbackorigin.Setup,
+		account.Setup,
+		loadbalancer.Setup,
+		monitor.Setup,
+		pool.Setup,
+		script.Setup,
+		kvnamespace.Setup,
+		kvpair.Setup,
+		accessapplication.Setup,
+		accesspolicy.Setup,
+		tunnel.Setup,
+		tunnelroute.Setup,
+		tunnelconfiguration.Setup,
+		customcertificate.Setup,
+		origincacertificate.Setup,
+		totaltls.Setup,
+		authenticatedoriginpulls.Setup,
+		originpullcertificate.Setup,
+		hostnameoriginpull.Setup,
+		ruleset.Setup,
+		tieredcache.Setup,
+		botmanagement.Setup,
+		healthcheck.Setup,
+		waitingroom.Setup,
+		turnstilewidget.Setup,
+		r2bucket.Setup,
+		queue.Setup,
+		emailroutingsettings.Setup,
+		emailroutingrule.Setup,
+		emailroutingaddress.Setup,
 	} {
-		if err := setup(mgr, l, wl); err != nil {
+		if err := setup(mgr, o); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,191 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnssec
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/dns/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/dnssec"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotDNSSEC = "managed resource is not a DNSSEC custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errDNSSECLookup   = "cannot lookup dnssec"
+	errDNSSECCreation = "cannot enable dnssec"
+	errDNSSECDeletion = "cannot disable dnssec"
+	errDNSSECNoZone   = "no zone found"
+)
+
+// Setup adds a controller that reconciles DNSSEC managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.DNSSECGroupKind)
+	o = o.For(v1alpha1.DNSSECGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.DNSSECGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (dnssec.Client, error) {
+				return dnssec.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.DNSSEC{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (dnssec.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.DNSSEC)
+	if !ok {
+		return nil, errors.New(errNotDNSSEC)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client dnssec.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.DNSSEC)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotDNSSEC)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalObservation{}, errors.New(errDNSSECNoZone)
+	}
+
+	d, err := e.client.ZoneDNSSECSetting(ctx, *cr.Spec.ForProvider.Zone)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errDNSSECLookup)
+	}
+
+	// DNSSEC is a setting of the Zone, so it exists only while enabled.
+	if !dnssec.IsEnabled(d.Status) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider = dnssec.GenerateObservation(d)
+
+	// DNSSEC only becomes active once the DS record has been
+	// published at the registrar of the Zone.
+	if d.Status == dnssec.StatusActive {
+		cr.Status.SetConditions(rtv1.Available())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.DNSSEC)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotDNSSEC)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalCreation{}, errors.Wrap(errors.New(errDNSSECNoZone), errDNSSECCreation)
+	}
+
+	cr.SetConditions(rtv1.Creating())
+
+	d, err := dnssec.Enable(ctx, e.client, *cr.Spec.ForProvider.Zone)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errDNSSECCreation)
+	}
+
+	cr.Status.AtProvider = dnssec.GenerateObservation(d)
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	_, ok := mg.(*v1alpha1.DNSSEC)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotDNSSEC)
+	}
+
+	// DNSSEC has no mutable fields, it is only enabled or disabled.
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.DNSSEC)
+	if !ok {
+		return errors.New(errNotDNSSEC)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return errors.Wrap(errors.New(errDNSSECNoZone), errDNSSECDeletion)
+	}
+
+	return errors.Wrap(dnssec.Disable(ctx, e.client, *cr.Spec.ForProvider.Zone), errDNSSECDeletion)
+}
@@ -18,19 +18,16 @@ package record
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
-	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 
 	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
-	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
-	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
@@ -39,6 +36,8 @@ import (
 	"github.com/benagricola/provider-cloudflare/apis/dns/v1alpha1"
 	clients "github.com/benagricola/provider-cloudflare/internal/clients"
 	records "github.com/benagricola/provider-cloudflare/internal/clients/records"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
 	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
 )
 
@@ -53,39 +52,71 @@ const (
 	errRecordDeletion = "cannot delete record"
 	errRecordNoZone   = "no zone found"
 
-	maxConcurrency = 5
+	errRecordSettingsLookup = "cannot lookup record settings"
+	errRecordSettingsUpdate = "cannot update record settings"
+
+	errRecordImport        = "cannot import record"
+	errRecordCreatePending = "cannot record pending create"
+	errRecordZoneLookup    = "cannot lookup zone of record"
+
+	errRecordInvalidType = "unsupported record type %q"
+
+	reasonCNAMELoop event.Reason = "CNAMELoop"
+	msgCNAMELoop                 = "CNAME record %q points to itself and will not resolve unless it is proxied"
+
+	// annotationKeyExternalCreatePending is set on a Record before it is
+	// created so that a record created by a controller that crashed before
+	// storing its external name can be found again rather than duplicated.
+	annotationKeyExternalCreatePending = "cloudflare.crossplane.io/external-create-pending"
+
+	// annotationKeyAdoptExisting may be set to "true" on a Record without
+	// an external name so that an existing record with the same type, name
+	// and content is adopted rather than a duplicate being created. It is
+	// also adopted if Cloudflare reports that the record already exists
+	// when creating it.
+	annotationKeyAdoptExisting = "cloudflare.crossplane.io/adopt-existing"
+
+	// annotationKeyImportID may be set to the ID of an existing record on
+	// a Record without an external name, so that the existing record is
+	// managed by the Record rather than a new record being created.
+	annotationKeyImportID = "cloudflare.crossplane.io/import-id"
 
 	// recordStatusActive = "active"
+
+	// zoneCacheTTL is how long the details of a zone looked up when
+	// creating a record are reused for. The name and plan of a zone
+	// rarely change.
+	zoneCacheTTL = 10 * time.Minute
 )
 
 // Setup adds a controller that reconciles Record managed resources.
-func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+func Setup(mgr ctrl.Manager, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.RecordGroupKind)
-
-	o := controller.Options{
-		RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
-		MaxConcurrentReconciles: maxConcurrency,
-	}
+	o = o.For(v1alpha1.RecordGroupKind)
 
 	hc := metrics.NewInstrumentedHTTPClient(name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.RecordGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
-			kube: mgr.GetClient(),
+		managed.WithExternalConnecter(metrics.InstrumentConnecter(policy.NewConnecter(&connector{
+			kube:     mgr.GetClient(),
+			recorder: recorder,
+			zones:    newZoneCache(zoneCacheTTL),
 			newCloudflareClientFn: func(cfg clients.Config) (records.Client, error) {
 				return records.NewClient(cfg, hc)
 			},
-		}),
-		managed.WithLogger(l.WithValues("controller", name)),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
-		managed.WithPollInterval(5*time.Minute),
+		}), name)),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(recorder),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
 		// Do not initialize external-name field.
 		managed.WithInitializers(),
 	)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		WithOptions(o).
+		WithOptions(o.ForControllerRuntime()).
 		For(&v1alpha1.Record{}).
 		Complete(r)
 }
@@ -94,6 +125,8 @@ func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
 // is called.
 type connector struct {
 	kube                  client.Client
+	recorder              event.Recorder
+	zones                 *zoneCache
 	newCloudflareClientFn func(cfg clients.Config) (records.Client, error)
 }
 
@@ -116,13 +149,78 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, err
 	}
 
-	return &external{client: client}, nil
+	return &external{
+		client:         client,
+		kube:           c.kube,
+		recorder:       c.recorder,
+		zones:          c.zones,
+		proxyByDefault: config.ProxyRecordsByDefault,
+	}, nil
+}
+
+// A zoneCache caches the details of the zones records are created on, so
+// that they are not looked up each time a record is created.
+type zoneCache struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	now   func() time.Time
+	zones map[string]cachedZone
+}
+
+type cachedZone struct {
+	zone    cloudflare.Zone
+	expires time.Time
+}
+
+func newZoneCache(ttl time.Duration) *zoneCache {
+	return &zoneCache{ttl: ttl, now: time.Now, zones: map[string]cachedZone{}}
+}
+
+// Get returns the details of the passed zone, looking them up using the
+// passed client when they are not cached or have expired.
+func (c *zoneCache) Get(ctx context.Context, client records.Client, zoneID string) (cloudflare.Zone, error) {
+	c.mu.Lock()
+	cz, ok := c.zones[zoneID]
+	c.mu.Unlock()
+
+	if ok && c.now().Before(cz.expires) {
+		return cz.zone, nil
+	}
+
+	z, err := client.ZoneDetails(ctx, zoneID)
+	if err != nil {
+		return cloudflare.Zone{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Drop expired zones so that zones records are no longer created on
+	// do not stay cached.
+	now := c.now()
+	for id, cz := range c.zones {
+		if !now.Before(cz.expires) {
+			delete(c.zones, id)
+		}
+	}
+	c.zones[zoneID] = cachedZone{zone: z, expires: now.Add(c.ttl)}
+
+	return z, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	client records.Client
+	client   records.Client
+	kube     client.Client
+	recorder event.Recorder
+
+	// zones caches the details of the zones records are created on.
+	zones *zoneCache
+
+	// proxyByDefault proxies records that can be proxied on their zone
+	// when they do not set Proxied.
+	proxyByDefault bool
 }
 
 func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -131,8 +229,15 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotRecord)
 	}
 
+	_, pending := cr.GetAnnotations()[annotationKeyExternalCreatePending]
+
+	rid, src, err := e.existing(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
 	// Record does not exist if we dont have an ID stored in external-name
-	rid := meta.GetExternalName(cr)
+	// and we did not find an existing record to import or adopt.
 	if rid == "" {
 		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
@@ -143,19 +248,50 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	record, err := e.client.DNSRecord(ctx, *cr.Spec.ForProvider.Zone, rid)
 
+	// A record that cannot be imported must not be created instead.
+	if err != nil && src == recordSourceImport {
+		return managed.ExternalObservation{}, errors.Wrap(err, errRecordImport)
+	}
+
 	if err != nil {
 		return managed.ExternalObservation{},
 			errors.Wrap(resource.Ignore(records.IsRecordNotFound, err), errRecordLookup)
 	}
 
+	// The external name of an imported or adopted record, and the removal
+	// of the pending annotation once we know the external name, are
+	// persisted by reporting the Record as late initialized.
+	li := false
+	if src != recordSourceExternalName {
+		meta.SetExternalName(cr, rid)
+		li = true
+	}
+	if pending {
+		meta.RemoveAnnotations(cr, annotationKeyExternalCreatePending)
+		li = true
+	}
+
 	cr.Status.AtProvider = records.GenerateObservation(record)
 
+	// Settings are only observed when we manage some of them, as they
+	// are not supported by the go library and need an extra API call.
+	if cr.Spec.ForProvider.Settings != nil {
+		rs, err := records.RecordSettings(ctx, e.client, *cr.Spec.ForProvider.Zone, rid)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errRecordSettingsLookup)
+		}
+		cr.Status.AtProvider.Settings = rs
+	}
+
 	cr.SetConditions(rtv1.Available())
 
+	li = records.LateInitialize(&cr.Spec.ForProvider, record) || li
+
 	return managed.ExternalObservation{
 		ResourceExists:          true,
-		ResourceLateInitialized: records.LateInitialize(&cr.Spec.ForProvider, record),
-		ResourceUpToDate:        records.UpToDate(&cr.Spec.ForProvider, record),
+		ResourceLateInitialized: li,
+		ResourceUpToDate: records.UpToDate(&cr.Spec.ForProvider, record) &&
+			records.SettingsUpToDate(cr.Spec.ForProvider.Settings, cr.Status.AtProvider.Settings),
 	}, nil
 }
 
@@ -178,37 +314,85 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errRecordCreation)
 	}
 
+	if !records.IsValidType(*cr.Spec.ForProvider.Type) {
+		return managed.ExternalCreation{},
+			errors.Wrap(errors.Errorf(errRecordInvalidType, *cr.Spec.ForProvider.Type), errRecordCreation)
+	}
+
+	// The zone has not been observed before the record is created, so we
+	// look up its plan when defaulting whether the record is proxied, and
+	// its name to resolve the relative names of an unproxied CNAME record.
+	// Proxied CNAME records are answered by Cloudflare rather than being
+	// resolved by clients, so they are not checked for loops.
+	proxied := cr.Spec.ForProvider.Proxied
+	defaulted := proxied == nil && e.proxyByDefault
+	cname := *cr.Spec.ForProvider.Type == "CNAME" && (proxied == nil || !*proxied)
+
+	z := cloudflare.Zone{}
+	if defaulted || cname {
+		var err error
+		z, err = e.zone(ctx, *cr.Spec.ForProvider.Zone)
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errRecordZoneLookup)
+		}
+	}
+
+	if proxied == nil {
+		p := records.DefaultProxied(*cr.Spec.ForProvider.Type, cr.Spec.ForProvider.Name, z.Plan, e.proxyByDefault)
+		proxied = &p
+	}
+
+	if !*proxied && records.IsSelfReferencing(&cr.Spec.ForProvider, z.Name) {
+		e.recorder.Event(cr, event.Warning(reasonCNAMELoop, errors.Errorf(msgCNAMELoop, cr.Spec.ForProvider.Name)))
+	}
+
+	// Records with structured data have their content generated from it.
+	data, err := records.Data(cr.Spec.ForProvider.Data)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errRecordCreation)
+	}
+
 	// Required for MX, SRV and URI records; unused by other record types.
-	if cr.Spec.ForProvider.Priority == nil {
+	// The priority of an SRV record may instead be set in its data.
+	if cr.Spec.ForProvider.Priority == nil && cr.Spec.ForProvider.Data == nil {
 		switch *cr.Spec.ForProvider.Type {
 		case "MX", "SRV", "URI":
 			return managed.ExternalCreation{}, errors.New(errRecordCreation)
 		}
 	}
 
+	// Persist that we are about to create the record, so that it can be
+	// found on restart if we crash before storing the external name.
+	meta.AddAnnotations(cr, map[string]string{
+		annotationKeyExternalCreatePending: time.Now().UTC().Format(time.RFC3339),
+	})
+	if err := e.kube.Update(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errRecordCreatePending)
+	}
+
 	cr.SetConditions(rtv1.Creating())
 
-	ttl := int(*cr.Spec.ForProvider.TTL)
-	var pri *uint16
-	if cr.Spec.ForProvider.Priority != nil {
-		val := uint16(*cr.Spec.ForProvider.Priority)
-		pri = &val
-	}
-
-	res, err := e.client.CreateDNSRecord(
-		ctx,
-		*cr.Spec.ForProvider.Zone,
-		cloudflare.DNSRecord{
-			Type:     *cr.Spec.ForProvider.Type,
-			Name:     cr.Spec.ForProvider.Name,
-			TTL:      ttl,
-			Content:  cr.Spec.ForProvider.Content,
-			Proxied:  cr.Spec.ForProvider.Proxied,
-			Priority: pri,
-		},
-	)
+	ttl := int(records.EffectiveTTL(*cr.Spec.ForProvider.TTL, proxied))
+	rr := cloudflare.DNSRecord{
+		Type:     *cr.Spec.ForProvider.Type,
+		Name:     cr.Spec.ForProvider.Name,
+		TTL:      ttl,
+		Content:  cr.Spec.ForProvider.Content,
+		Proxied:  proxied,
+		Priority: records.Priority(cr.Spec.ForProvider.Priority),
+	}
+	if data != nil {
+		rr.Data = data
+	}
+
+	res, err := e.client.CreateDNSRecord(ctx, *cr.Spec.ForProvider.Zone, rr)
 
 	if err != nil {
+		// The record may have been added by someone else since we last
+		// observed it, in which case we adopt it if we were asked to.
+		if records.IsRecordAlreadyExists(err) && cr.GetAnnotations()[annotationKeyAdoptExisting] == "true" {
+			return e.adopt(ctx, cr, err)
+		}
 		return managed.ExternalCreation{}, errors.Wrap(err, errRecordCreation)
 	}
 
@@ -220,6 +404,85 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
 }
 
+// A recordSource is how the ID of the record a Record manages was found.
+type recordSource int
+
+const (
+	recordSourceNone recordSource = iota
+	recordSourceExternalName
+	recordSourceImport
+	recordSourceLookup
+)
+
+// existing returns the ID of the existing record the passed Record
+// manages, and how it was found. In order of precedence it is:
+//
+//  1. The external name of the Record.
+//  2. The import ID annotation of the Record. An imported record is
+//     managed as if we had created it, so it is never created again.
+//  3. The single record with the type, name and content of the Record, if
+//     the Record has a pending create or may adopt an existing record.
+//     A pending create may have created the record without its ID being
+//     stored, and Cloudflare allows some duplicate records, such as
+//     multiple A records with the same name, so we do not guess which of
+//     several matching records is ours.
+//
+// An empty ID is returned if the Record manages no existing record.
+func (e *external) existing(ctx context.Context, cr *v1alpha1.Record) (string, recordSource, error) {
+	if rid := meta.GetExternalName(cr); rid != "" {
+		return rid, recordSourceExternalName, nil
+	}
+
+	if iid := cr.GetAnnotations()[annotationKeyImportID]; iid != "" {
+		return iid, recordSourceImport, nil
+	}
+
+	_, pending := cr.GetAnnotations()[annotationKeyExternalCreatePending]
+	if !pending && cr.GetAnnotations()[annotationKeyAdoptExisting] != "true" {
+		return "", recordSourceNone, nil
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return "", recordSourceNone, errors.New(errRecordNoZone)
+	}
+
+	id, err := records.LookupRecord(ctx, e.client, &cr.Spec.ForProvider)
+	if err != nil {
+		return "", recordSourceNone, errors.Wrap(err, errRecordLookup)
+	}
+	if id == "" {
+		return "", recordSourceNone, nil
+	}
+
+	return id, recordSourceLookup, nil
+}
+
+// adopt sets the external name of the passed Record to that of the
+// existing record matching it. The passed creation error is returned if
+// no single matching record exists.
+func (e *external) adopt(ctx context.Context, cr *v1alpha1.Record, cerr error) (managed.ExternalCreation, error) {
+	id, _, err := e.existing(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if id == "" {
+		return managed.ExternalCreation{}, errors.Wrap(cerr, errRecordCreation)
+	}
+
+	meta.SetExternalName(cr, id)
+
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+// zone returns the details of the passed zone, from the zone cache if
+// there is one.
+func (e *external) zone(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+	if e.zones == nil {
+		return e.client.ZoneDetails(ctx, zoneID)
+	}
+	return e.zones.Get(ctx, e.client, zoneID)
+}
+
 func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
 	cr, ok := mg.(*v1alpha1.Record)
 	if !ok {
@@ -237,10 +500,25 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errRecordUpdate)
 	}
 
+	// The zone of an existing record has been observed, so its name is
+	// used to resolve the relative names of a CNAME record.
+	proxied := cr.Spec.ForProvider.Proxied != nil && *cr.Spec.ForProvider.Proxied
+	if !proxied && records.IsSelfReferencing(&cr.Spec.ForProvider, cr.Status.AtProvider.Zone) {
+		e.recorder.Event(cr, event.Warning(reasonCNAMELoop, errors.Errorf(msgCNAMELoop, cr.Spec.ForProvider.Name)))
+	}
+
+	if err := records.UpdateRecord(ctx, e.client, rid, &cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errRecordUpdate)
+	}
+
+	if records.SettingsUpToDate(cr.Spec.ForProvider.Settings, cr.Status.AtProvider.Settings) {
+		return managed.ExternalUpdate{}, nil
+	}
+
 	return managed.ExternalUpdate{},
 		errors.Wrap(
-			records.UpdateRecord(ctx, e.client, rid, &cr.Spec.ForProvider),
-			errRecordUpdate,
+			records.UpdateRecordSettings(ctx, e.client, *cr.Spec.ForProvider.Zone, rid, cr.Spec.ForProvider.Settings),
+			errRecordSettingsUpdate,
 		)
 }
 
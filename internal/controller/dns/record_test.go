@@ -18,8 +18,10 @@ package record
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/cloudflare/cloudflare-go"
 	"github.com/google/go-cmp/cmp"
@@ -32,9 +34,12 @@ import (
 	"github.com/benagricola/provider-cloudflare/internal/clients/records/fake"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ptr "k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
@@ -64,6 +69,44 @@ func withExternalName(recordID string) recordModifier {
 	return func(r *v1alpha1.Record) { meta.SetExternalName(r, recordID) }
 }
 
+func withName(name string) recordModifier {
+	return func(r *v1alpha1.Record) { r.Spec.ForProvider.Name = name }
+}
+
+func withCreatePending() recordModifier {
+	return func(r *v1alpha1.Record) {
+		meta.AddAnnotations(r, map[string]string{annotationKeyExternalCreatePending: "2021-06-01T00:00:00Z"})
+	}
+}
+
+func withAdoptExisting() recordModifier {
+	return func(r *v1alpha1.Record) {
+		meta.AddAnnotations(r, map[string]string{annotationKeyAdoptExisting: "true"})
+	}
+}
+
+func withImportID(recordID string) recordModifier {
+	return func(r *v1alpha1.Record) {
+		meta.AddAnnotations(r, map[string]string{annotationKeyImportID: recordID})
+	}
+}
+
+func withContent(content string) recordModifier {
+	return func(r *v1alpha1.Record) { r.Spec.ForProvider.Content = content }
+}
+
+func withProxied(proxied bool) recordModifier {
+	return func(r *v1alpha1.Record) { r.Spec.ForProvider.Proxied = &proxied }
+}
+
+func withSettings(rs *v1alpha1.RecordSettings) recordModifier {
+	return func(r *v1alpha1.Record) { r.Spec.ForProvider.Settings = rs }
+}
+
+func withObservedSettings(rs *v1alpha1.RecordSettings) recordModifier {
+	return func(r *v1alpha1.Record) { r.Status.AtProvider.Settings = rs }
+}
+
 func withZone(zoneID string) recordModifier {
 	return func(r *v1alpha1.Record) { r.Spec.ForProvider.Zone = &zoneID }
 }
@@ -253,6 +296,228 @@ func TestObserve(t *testing.T) {
 				err: errors.New(errRecordNoZone),
 			},
 		},
+		"CreatePendingNotFound": {
+			reason: "We should return ResourceExists: false when a pending create did not create a record",
+			fields: fields{
+				client: fake.MockClient{
+					MockDNSRecords: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+						return []cloudflare.DNSRecord{}, nil
+					},
+				},
+			},
+			args: args{
+				mg: record(withCreatePending(), withZone("foo.com"), withType("A"), withName("www")),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrCreatePendingLookup": {
+			reason: "We should return an error if looking up a pending create fails",
+			fields: fields{
+				client: fake.MockClient{
+					MockDNSRecords: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: record(withCreatePending(), withZone("foo.com"), withType("A"), withName("www")),
+			},
+			want: want{
+				o:   managed.ExternalObservation{},
+				err: errors.Wrap(errBoom, errRecordLookup),
+			},
+		},
+		"SuccessCreatePendingRecovered": {
+			reason: "We should adopt a record created by a pending create rather than creating it again",
+			fields: fields{
+				client: fake.MockClient{
+					MockDNSRecords: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+						return []cloudflare.DNSRecord{
+							{ID: "other", Name: "mail.foo.com", ZoneName: "foo.com", Type: "A"},
+							{ID: "1234beef", Name: "www.foo.com", ZoneName: "foo.com", Type: "A"},
+						}, nil
+					},
+					MockDNSRecord: func(ctx context.Context, zoneID, recordID string) (cloudflare.DNSRecord, error) {
+						if recordID != "1234beef" {
+							return cloudflare.DNSRecord{}, errBoom
+						}
+						return cloudflare.DNSRecord{
+							ID:       recordID,
+							ZoneID:   zoneID,
+							Name:     "www.foo.com",
+							ZoneName: "foo.com",
+							Type:     "A",
+						}, nil
+					},
+				},
+			},
+			args: args{
+				mg: record(withCreatePending(), withZone("foo.com"), withType("A"), withName("www")),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+				err: nil,
+			},
+		},
+		"SuccessPausedZoneProxied": {
+			reason: "A proxied record in a paused zone should be up to date, as the zone's paused state does not change the record",
+			fields: fields{
+				client: fake.MockClient{
+					MockDNSRecord: func(ctx context.Context, zoneID, recordID string) (cloudflare.DNSRecord, error) {
+						return cloudflare.DNSRecord{
+							ID:      recordID,
+							ZoneID:  zoneID,
+							Type:    "A",
+							TTL:     1,
+							Proxied: ptr.BoolPtr(true),
+						}, nil
+					},
+					// The paused state of the zone must not be used to
+					// decide whether the record is up to date.
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{ID: zoneID, Paused: true}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withExternalName("1234beef"),
+					withZone("foo.com"),
+					withType("A"),
+					withTTL(300),
+					withProxied(true),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				err: nil,
+			},
+		},
+		"SuccessPausedZoneProxiedChanged": {
+			reason: "A change to Proxied on a record in a paused zone should still be detected",
+			fields: fields{
+				client: fake.MockClient{
+					MockDNSRecord: func(ctx context.Context, zoneID, recordID string) (cloudflare.DNSRecord, error) {
+						return cloudflare.DNSRecord{
+							ID:      recordID,
+							ZoneID:  zoneID,
+							Type:    "A",
+							TTL:     1,
+							Proxied: ptr.BoolPtr(true),
+						}, nil
+					},
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{ID: zoneID, Paused: true}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withExternalName("1234beef"),
+					withZone("foo.com"),
+					withType("A"),
+					withTTL(300),
+					withProxied(false),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				err: nil,
+			},
+		},
+		"ErrRecordSettingsLookup": {
+			reason: "We should return an error if the settings of a record cannot be looked up",
+			fields: fields{
+				client: fake.MockClient{
+					MockDNSRecord: func(ctx context.Context, zoneID, recordID string) (cloudflare.DNSRecord, error) {
+						return cloudflare.DNSRecord{ID: recordID, ZoneID: zoneID}, nil
+					},
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withExternalName("1234beef"),
+					withZone("foo.com"),
+					withSettings(&v1alpha1.RecordSettings{FlattenCNAME: ptr.BoolPtr(true)}),
+				),
+			},
+			want: want{
+				o:   managed.ExternalObservation{},
+				err: errors.Wrap(errBoom, errRecordSettingsLookup),
+			},
+		},
+		"SuccessSettingsUpToDate": {
+			reason: "A record should be up to date when its managed settings match",
+			fields: fields{
+				client: fake.MockClient{
+					MockDNSRecord: func(ctx context.Context, zoneID, recordID string) (cloudflare.DNSRecord, error) {
+						return cloudflare.DNSRecord{ID: recordID, ZoneID: zoneID}, nil
+					},
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if method != http.MethodGet || endpoint != "/zones/foo.com/dns_records/1234beef" {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{"id":"1234beef","settings":{"flatten_cname":true,"ipv6_only":true}}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withExternalName("1234beef"),
+					withZone("foo.com"),
+					withSettings(&v1alpha1.RecordSettings{FlattenCNAME: ptr.BoolPtr(true), IPv4Only: ptr.BoolPtr(false)}),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				err: nil,
+			},
+		},
+		"SuccessSettingsChanged": {
+			reason: "A record should not be up to date when a managed setting has drifted",
+			fields: fields{
+				client: fake.MockClient{
+					MockDNSRecord: func(ctx context.Context, zoneID, recordID string) (cloudflare.DNSRecord, error) {
+						return cloudflare.DNSRecord{ID: recordID, ZoneID: zoneID}, nil
+					},
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"id":"1234beef","settings":{}}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withExternalName("1234beef"),
+					withZone("foo.com"),
+					withSettings(&v1alpha1.RecordSettings{FlattenCNAME: ptr.BoolPtr(true)}),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				err: nil,
+			},
+		},
 		"Success": {
 			reason: "We should return ResourceExists: true and no error when a record is found",
 			fields: fields{
@@ -292,113 +557,939 @@ func TestObserve(t *testing.T) {
 	}
 }
 
-func TestCreate(t *testing.T) {
-	errBoom := errors.New("boom")
+func TestObserveImport(t *testing.T) {
+	errNotFound := &cloudflare.APIRequestError{
+		StatusCode: http.StatusNotFound,
+		Errors: []cloudflare.ResponseInfo{
+			{Code: 81044, Message: "Record not found"},
+		},
+	}
 
-	type fields struct {
+	type want struct {
+		o            managed.ExternalObservation
+		err          error
+		externalName string
+	}
+
+	cases := map[string]struct {
+		reason string
 		client records.Client
+		mg     *v1alpha1.Record
+		want   want
+	}{
+		"SuccessImport": {
+			reason: "A Record with an import ID should adopt the existing record rather than create one",
+			client: fake.MockClient{
+				MockDNSRecord: func(ctx context.Context, zoneID, recordID string) (cloudflare.DNSRecord, error) {
+					if recordID != "abcd1234" {
+						return cloudflare.DNSRecord{}, errNotFound
+					}
+					return cloudflare.DNSRecord{ID: recordID, ZoneID: zoneID, Name: "www.foo.com", ZoneName: "foo.com", Type: "A"}, nil
+				},
+			},
+			mg: record(withImportID("abcd1234"), withZone("foo.com"), withType("A"), withName("www")),
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+				externalName: "abcd1234",
+			},
+		},
+		"SuccessImportPendingCreate": {
+			reason: "A Record with an import ID should import the record rather than look up a pending create",
+			client: fake.MockClient{
+				MockDNSRecords: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+					return nil, errors.New("boom")
+				},
+				MockDNSRecord: func(ctx context.Context, zoneID, recordID string) (cloudflare.DNSRecord, error) {
+					if recordID != "abcd1234" {
+						return cloudflare.DNSRecord{}, errNotFound
+					}
+					return cloudflare.DNSRecord{ID: recordID, ZoneID: zoneID, Name: "www.foo.com", ZoneName: "foo.com", Type: "A"}, nil
+				},
+			},
+			mg: record(withImportID("abcd1234"), withCreatePending(), withAdoptExisting(), withZone("foo.com"), withType("A"), withName("www")),
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+				externalName: "abcd1234",
+			},
+		},
+		"ErrImportNotFound": {
+			reason: "A Record whose import ID does not exist should return an error rather than create a record",
+			client: fake.MockClient{
+				MockDNSRecord: func(ctx context.Context, zoneID, recordID string) (cloudflare.DNSRecord, error) {
+					return cloudflare.DNSRecord{}, errNotFound
+				},
+			},
+			mg: record(withImportID("abcd1234"), withZone("foo.com"), withType("A"), withName("www")),
+			want: want{
+				err: errors.Wrap(errNotFound, errRecordImport),
+			},
+		},
+		"ExternalNameSet": {
+			reason: "The import ID should be ignored once a Record has an external name",
+			client: fake.MockClient{
+				MockDNSRecord: func(ctx context.Context, zoneID, recordID string) (cloudflare.DNSRecord, error) {
+					if recordID != "1234beef" {
+						return cloudflare.DNSRecord{}, errNotFound
+					}
+					return cloudflare.DNSRecord{ID: recordID, ZoneID: zoneID}, nil
+				},
+			},
+			mg: record(withImportID("abcd1234"), withExternalName("1234beef"), withZone("foo.com")),
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				externalName: "1234beef",
+			},
+		},
 	}
 
-	type args struct {
-		ctx context.Context
-		mg  resource.Managed
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.client}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(tc.mg)); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want external name, +got external name:\n%s\n", tc.reason, diff)
+			}
+		})
 	}
+}
+
+func TestObserveAdopt(t *testing.T) {
+	errBoom := errors.New("boom")
 
 	type want struct {
-		o   managed.ExternalCreation
-		err error
+		o            managed.ExternalObservation
+		err          error
+		externalName string
 	}
 
 	cases := map[string]struct {
 		reason string
-		fields fields
-		args   args
+		client records.Client
+		mg     *v1alpha1.Record
 		want   want
 	}{
-		"ErrNotRecord": {
-			reason: "An error should be returned if the managed resource is not a *Record",
+		"SuccessAdopt": {
+			reason: "A Record that may adopt an existing record should adopt a matching record rather than create one",
+			client: fake.MockClient{
+				MockDNSRecords: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+					if rr.Type != "A" || rr.Content != "192.168.0.1" {
+						return nil, errBoom
+					}
+					return []cloudflare.DNSRecord{
+						{ID: "other", Name: "mail.foo.com", ZoneName: "foo.com", Type: "A", Content: "192.168.0.1"},
+						{ID: "1234beef", Name: "www.foo.com", ZoneName: "foo.com", Type: "A", Content: "192.168.0.1"},
+					}, nil
+				},
+				MockDNSRecord: func(ctx context.Context, zoneID, recordID string) (cloudflare.DNSRecord, error) {
+					if recordID != "1234beef" {
+						return cloudflare.DNSRecord{}, errBoom
+					}
+					return cloudflare.DNSRecord{ID: recordID, ZoneID: zoneID, Name: "www.foo.com", ZoneName: "foo.com", Type: "A", Content: "192.168.0.1"}, nil
+				},
+			},
+			mg: record(withAdoptExisting(), withZone("foo.com"), withType("A"), withName("www"), withContent("192.168.0.1")),
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+				externalName: "1234beef",
+			},
+		},
+		"AdoptNotFound": {
+			reason: "A Record that may adopt an existing record should be created if no matching record exists",
+			client: fake.MockClient{
+				MockDNSRecords: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+					return []cloudflare.DNSRecord{}, nil
+				},
+			},
+			mg: record(withAdoptExisting(), withZone("foo.com"), withType("A"), withName("www"), withContent("192.168.0.1")),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"AdoptAmbiguous": {
+			reason: "A Record that may adopt an existing record should not guess which of several matching records to adopt",
+			client: fake.MockClient{
+				MockDNSRecords: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+					return []cloudflare.DNSRecord{
+						{ID: "1234beef", Name: "www.foo.com", ZoneName: "foo.com", Type: "A", Content: "192.168.0.1"},
+						{ID: "5678beef", Name: "www.foo.com", ZoneName: "foo.com", Type: "A", Content: "192.168.0.1"},
+					}, nil
+				},
+			},
+			mg: record(withAdoptExisting(), withZone("foo.com"), withType("A"), withName("www"), withContent("192.168.0.1")),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrAdoptLookup": {
+			reason: "We should return an error if looking up a record to adopt fails",
+			client: fake.MockClient{
+				MockDNSRecords: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+					return nil, errBoom
+				},
+			},
+			mg: record(withAdoptExisting(), withZone("foo.com"), withType("A"), withName("www"), withContent("192.168.0.1")),
+			want: want{
+				err: errors.Wrap(errBoom, errRecordLookup),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.client}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(tc.mg)); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want external name, +got external name:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestExisting(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	// Looking up a record finds the single matching record 1234beef.
+	lookup := func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+		return []cloudflare.DNSRecord{
+			{ID: "1234beef", Name: "www.foo.com", ZoneName: "foo.com", Type: "A"},
+		}, nil
+	}
+
+	// Looking up a record must not happen.
+	noLookup := func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+		return nil, errBoom
+	}
+
+	type want struct {
+		id  string
+		src recordSource
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		client records.Client
+		mg     *v1alpha1.Record
+		want   want
+	}{
+		"None": {
+			reason: "A Record without an external name, import ID, pending create or adopt annotation manages no existing record",
+			client: fake.MockClient{MockDNSRecords: noLookup},
+			mg:     record(withZone("foo.com"), withType("A"), withName("www")),
+			want:   want{src: recordSourceNone},
+		},
+		"ExternalNameOverEverything": {
+			reason: "The external name of a Record should take precedence over its import ID, pending create and adopt annotation",
+			client: fake.MockClient{MockDNSRecords: noLookup},
+			mg: record(withExternalName("5678beef"), withImportID("abcd1234"), withCreatePending(), withAdoptExisting(),
+				withZone("foo.com"), withType("A"), withName("www")),
+			want: want{id: "5678beef", src: recordSourceExternalName},
+		},
+		"ImportOverPending": {
+			reason: "The import ID of a Record should take precedence over looking up a pending create",
+			client: fake.MockClient{MockDNSRecords: noLookup},
+			mg:     record(withImportID("abcd1234"), withCreatePending(), withZone("foo.com"), withType("A"), withName("www")),
+			want:   want{id: "abcd1234", src: recordSourceImport},
+		},
+		"ImportOverAdopt": {
+			reason: "The import ID of a Record should take precedence over adopting a matching record",
+			client: fake.MockClient{MockDNSRecords: noLookup},
+			mg:     record(withImportID("abcd1234"), withAdoptExisting(), withZone("foo.com"), withType("A"), withName("www")),
+			want:   want{id: "abcd1234", src: recordSourceImport},
+		},
+		"ImportWithoutZone": {
+			reason: "The import ID of a Record should be returned without looking up its zone",
+			client: fake.MockClient{MockDNSRecords: noLookup},
+			mg:     record(withImportID("abcd1234")),
+			want:   want{id: "abcd1234", src: recordSourceImport},
+		},
+		"Pending": {
+			reason: "A Record with a pending create should find the record it may have created",
+			client: fake.MockClient{MockDNSRecords: lookup},
+			mg:     record(withCreatePending(), withZone("foo.com"), withType("A"), withName("www")),
+			want:   want{id: "1234beef", src: recordSourceLookup},
+		},
+		"Adopt": {
+			reason: "A Record that may adopt an existing record should find a matching record",
+			client: fake.MockClient{MockDNSRecords: lookup},
+			mg:     record(withAdoptExisting(), withZone("foo.com"), withType("A"), withName("www")),
+			want:   want{id: "1234beef", src: recordSourceLookup},
+		},
+		"PendingAndAdopt": {
+			reason: "A Record with a pending create that may adopt an existing record should find a matching record once",
+			client: func() records.Client {
+				calls := 0
+				return fake.MockClient{
+					MockDNSRecords: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+						calls++
+						if calls > 1 {
+							return nil, errBoom
+						}
+						return lookup(ctx, zoneID, rr)
+					},
+				}
+			}(),
+			mg:   record(withCreatePending(), withAdoptExisting(), withZone("foo.com"), withType("A"), withName("www")),
+			want: want{id: "1234beef", src: recordSourceLookup},
+		},
+		"AdoptFalse": {
+			reason: "A Record whose adopt annotation is not true should not look up a matching record",
+			client: fake.MockClient{MockDNSRecords: noLookup},
+			mg: record(func(r *v1alpha1.Record) {
+				meta.AddAnnotations(r, map[string]string{annotationKeyAdoptExisting: "false"})
+			}, withZone("foo.com"), withType("A"), withName("www")),
+			want: want{src: recordSourceNone},
+		},
+		"LookupNotFound": {
+			reason: "A Record with a pending create should manage no existing record if none matches",
+			client: fake.MockClient{
+				MockDNSRecords: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+					return []cloudflare.DNSRecord{}, nil
+				},
+			},
+			mg:   record(withCreatePending(), withZone("foo.com"), withType("A"), withName("www")),
+			want: want{src: recordSourceNone},
+		},
+		"ErrLookupNoZone": {
+			reason: "We should return an error if we need to look up a record without a zone",
+			client: fake.MockClient{MockDNSRecords: noLookup},
+			mg:     record(withAdoptExisting(), withType("A"), withName("www")),
+			want:   want{src: recordSourceNone, err: errors.New(errRecordNoZone)},
+		},
+		"ErrLookup": {
+			reason: "We should return an error if looking up a record fails",
+			client: fake.MockClient{MockDNSRecords: noLookup},
+			mg:     record(withCreatePending(), withAdoptExisting(), withZone("foo.com"), withType("A"), withName("www")),
+			want:   want{src: recordSourceNone, err: errors.Wrap(errBoom, errRecordLookup)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.client}
+			id, src, err := e.existing(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.existing(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.id, id); diff != "" {
+				t.Errorf("\n%s\ne.existing(...): -want id, +got id:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.src, src); diff != "" {
+				t.Errorf("\n%s\ne.existing(...): -want source, +got source:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+	errAlreadyExists := &cloudflare.APIRequestError{
+		StatusCode: http.StatusBadRequest,
+		Errors: []cloudflare.ResponseInfo{
+			{Code: 81057, Message: "The record already exists."},
+		},
+	}
+
+	type fields struct {
+		client         records.Client
+		kube           client.Client
+		proxyByDefault bool
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o      managed.ExternalCreation
+		err    error
+		events []event.Event
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotRecord": {
+			reason: "An error should be returned if the managed resource is not a *Record",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotRecord),
+			},
+		},
+		"ErrRecordCreatePending": {
+			reason: "We should not create a record if we cannot persist that a create is pending",
+			fields: fields{
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{ID: zoneID}, nil
+					},
+					MockCreateDNSRecord: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) (*cloudflare.DNSRecordResponse, error) {
+						return nil, errBoom
+					},
+				},
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(errBoom),
+				},
+			},
+			args: args{
+				mg: record(
+					withZone("foo.com"),
+					withTTL(600),
+					withType("A"),
+				),
+			},
+			want: want{
+				o:   managed.ExternalCreation{},
+				err: errors.Wrap(errBoom, errRecordCreatePending),
+			},
+		},
+		"ErrRecordCreate": {
+			reason: "We should return any errors during the create process",
+			fields: fields{
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{ID: zoneID}, nil
+					},
+					MockCreateDNSRecord: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) (*cloudflare.DNSRecordResponse, error) {
+						return &cloudflare.DNSRecordResponse{
+							Result: cloudflare.DNSRecord{},
+						}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withExternalName("1234beef"),
+					withZone("foo.com"),
+					withTTL(600),
+					withType("A"),
+				),
+			},
+			want: want{
+				o:   managed.ExternalCreation{},
+				err: errors.Wrap(errBoom, errRecordCreation),
+			},
+		},
+		"WarnRecordCreateCNAMELoop": {
+			reason: "We should warn about, but still create, a CNAME record that points to itself",
+			fields: fields{
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{ID: zoneID}, nil
+					},
+					MockCreateDNSRecord: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) (*cloudflare.DNSRecordResponse, error) {
+						return &cloudflare.DNSRecordResponse{
+							Result: rr,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withZone("foo.com"),
+					withTTL(600),
+					withType("CNAME"),
+					withName("www.example.com"),
+					withContent("WWW.example.com."),
+				),
+			},
+			want: want{
+				o: managed.ExternalCreation{ExternalNameAssigned: true},
+				events: []event.Event{
+					event.Warning(reasonCNAMELoop, errors.New(`CNAME record "www.example.com" points to itself and will not resolve unless it is proxied`)),
+				},
+			},
+		},
+		"WarnRecordCreateRelativeCNAMELoop": {
+			reason: "We should warn about a CNAME record with a relative name that points to itself",
+			fields: fields{
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{ID: zoneID, Name: "foo.com"}, nil
+					},
+					MockCreateDNSRecord: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) (*cloudflare.DNSRecordResponse, error) {
+						return &cloudflare.DNSRecordResponse{
+							Result: rr,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withZone("foo.com"),
+					withTTL(600),
+					withType("CNAME"),
+					withName("www"),
+					withContent("www.foo.com"),
+					withProxied(false),
+				),
+			},
+			want: want{
+				o: managed.ExternalCreation{ExternalNameAssigned: true},
+				events: []event.Event{
+					event.Warning(reasonCNAMELoop, errors.New(`CNAME record "www" points to itself and will not resolve unless it is proxied`)),
+				},
+			},
+		},
+		"SuccessProxiedCNAMELoop": {
+			reason: "We should not lookup the zone of, or warn about, a proxied CNAME record that points to itself",
+			fields: fields{
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{}, errBoom
+					},
+					MockCreateDNSRecord: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) (*cloudflare.DNSRecordResponse, error) {
+						return &cloudflare.DNSRecordResponse{
+							Result: rr,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withZone("foo.com"),
+					withTTL(600),
+					withType("CNAME"),
+					withName("www"),
+					withContent("www.foo.com"),
+					withProxied(true),
+				),
+			},
+			want: want{
+				o: managed.ExternalCreation{ExternalNameAssigned: true},
+			},
+		},
+		"ErrRecordCreateCNAMEZoneLookup": {
+			reason: "We should return an error if we cannot lookup the zone to resolve the names of a CNAME record",
+			fields: fields{
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withZone("foo.com"),
+					withTTL(600),
+					withType("CNAME"),
+					withName("www"),
+					withContent("example.com"),
+					withProxied(false),
+				),
+			},
+			want: want{
+				o:   managed.ExternalCreation{},
+				err: errors.Wrap(errBoom, errRecordZoneLookup),
+			},
+		},
+		"ErrRecordCreateInvalidType": {
+			reason: "We should return an error if 'Type' is not a supported record type",
+			fields: fields{
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{ID: zoneID}, nil
+					},
+					MockCreateDNSRecord: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) (*cloudflare.DNSRecordResponse, error) {
+						return &cloudflare.DNSRecordResponse{
+							Result: rr,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withType("CNMAE"),
+					withTTL(600),
+					withZone("foo.com"),
+				),
+			},
+			want: want{
+				o:   managed.ExternalCreation{},
+				err: errors.Wrap(errors.Errorf(errRecordInvalidType, "CNMAE"), errRecordCreation),
+			},
+		},
+		"ErrRecordCreateLowercaseType": {
+			reason: "We should return an error if 'Type' is not an upper case record type",
+			fields: fields{
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{ID: zoneID}, nil
+					},
+					MockCreateDNSRecord: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) (*cloudflare.DNSRecordResponse, error) {
+						return &cloudflare.DNSRecordResponse{
+							Result: rr,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withType("aaaa"),
+					withTTL(600),
+					withZone("foo.com"),
+				),
+			},
+			want: want{
+				o:   managed.ExternalCreation{},
+				err: errors.Wrap(errors.Errorf(errRecordInvalidType, "aaaa"), errRecordCreation),
+			},
+		},
+		"ErrRecordCreatePriorityMX": {
+			reason: "We should return an error if 'Priority' is unset for MX records",
+			fields: fields{
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{ID: zoneID}, nil
+					},
+					MockCreateDNSRecord: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) (*cloudflare.DNSRecordResponse, error) {
+						return &cloudflare.DNSRecordResponse{
+							Result: rr,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withType("MX"),
+					withTTL(600),
+					withZone("foo.com"),
+				),
+			},
+			want: want{
+				o:   managed.ExternalCreation{},
+				err: errors.New(errRecordCreation),
+			},
+		},
+		"ErrRecordCreatePrioritySRV": {
+			reason: "We should return an error if 'Priority' is unset for SRV records",
+			fields: fields{
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{ID: zoneID}, nil
+					},
+					MockCreateDNSRecord: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) (*cloudflare.DNSRecordResponse, error) {
+						return &cloudflare.DNSRecordResponse{
+							Result: rr,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withType("SRV"),
+					withTTL(600),
+					withZone("foo.com"),
+				),
+			},
+			want: want{
+				o:   managed.ExternalCreation{},
+				err: errors.New(errRecordCreation),
+			},
+		},
+		"ErrRecordCreatePriorityURI": {
+			reason: "We should return an error if 'Priority' is unset for URI records",
+			fields: fields{
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{ID: zoneID}, nil
+					},
+					MockCreateDNSRecord: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) (*cloudflare.DNSRecordResponse, error) {
+						return &cloudflare.DNSRecordResponse{
+							Result: rr,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withType("URI"),
+					withTTL(600),
+					withZone("foo.com"),
+				),
+			},
+			want: want{
+				o:   managed.ExternalCreation{},
+				err: errors.New(errRecordCreation),
+			},
+		},
+		"SuccessDefaultProxied": {
+			reason: "We should create a proxiable record as proxied when Proxied is not set and records are proxied by default",
+			fields: fields{
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
+				proxyByDefault: true,
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{ID: zoneID}, nil
+					},
+					MockCreateDNSRecord: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) (*cloudflare.DNSRecordResponse, error) {
+						if rr.Proxied == nil || !*rr.Proxied {
+							return nil, errBoom
+						}
+						return &cloudflare.DNSRecordResponse{
+							Result: rr,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withType("CNAME"),
+					withName("www"),
+					withContent("example.com"),
+					withTTL(600),
+					withZone("foo.com"),
+				),
+			},
+			want: want{
+				o: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+				},
+			},
+		},
+		"ErrRecordZoneLookup": {
+			reason: "We should return an error if we cannot lookup the zone to default Proxied",
+			fields: fields{
+				proxyByDefault: true,
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withType("A"),
+					withName("www"),
+					withContent("192.168.0.1"),
+					withTTL(600),
+					withZone("foo.com"),
+				),
+			},
+			want: want{
+				o:   managed.ExternalCreation{},
+				err: errors.Wrap(errBoom, errRecordZoneLookup),
+			},
+		},
+		"SuccessDefaultWildcardNotProxied": {
+			reason: "We should create a wildcard record as not proxied when Proxied is not set and its zone cannot proxy wildcard records",
+			fields: fields{
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
+				proxyByDefault: true,
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{ID: zoneID, Plan: cloudflare.ZonePlan{LegacyID: "free"}}, nil
+					},
+					MockCreateDNSRecord: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) (*cloudflare.DNSRecordResponse, error) {
+						if rr.Proxied == nil || *rr.Proxied {
+							return nil, errBoom
+						}
+						return &cloudflare.DNSRecordResponse{
+							Result: rr,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withType("A"),
+					withName("*"),
+					withContent("192.168.0.1"),
+					withTTL(600),
+					withZone("foo.com"),
+				),
+			},
+			want: want{
+				o: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+				},
+			},
+		},
+		"SuccessDefaultNotProxied": {
+			reason: "We should create a proxiable record as not proxied when Proxied is not set and records are not proxied by default",
+			fields: fields{
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
+				client: fake.MockClient{
+					MockCreateDNSRecord: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) (*cloudflare.DNSRecordResponse, error) {
+						if rr.Proxied == nil || *rr.Proxied {
+							return nil, errBoom
+						}
+						return &cloudflare.DNSRecordResponse{
+							Result: rr,
+						}, nil
+					},
+				},
+			},
 			args: args{
-				mg: nil,
+				mg: record(
+					withType("A"),
+					withName("www"),
+					withContent("192.168.0.1"),
+					withTTL(600),
+					withZone("foo.com"),
+				),
 			},
 			want: want{
-				err: errors.New(errNotRecord),
+				o: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+				},
 			},
 		},
-		"ErrRecordCreate": {
-			reason: "We should return any errors during the create process",
+		"ErrRecordAlreadyExists": {
+			reason: "We should return an error if the record already exists and we were not asked to adopt it",
 			fields: fields{
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
 				client: fake.MockClient{
 					MockCreateDNSRecord: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) (*cloudflare.DNSRecordResponse, error) {
-						return &cloudflare.DNSRecordResponse{
-							Result: cloudflare.DNSRecord{},
-						}, errBoom
+						return nil, errAlreadyExists
+					},
+					MockDNSRecords: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+						return []cloudflare.DNSRecord{{ID: "1234beef", Name: "www.foo.com", ZoneName: "foo.com"}}, nil
 					},
 				},
 			},
 			args: args{
 				mg: record(
-					withExternalName("1234beef"),
-					withZone("foo.com"),
-					withTTL(600),
 					withType("A"),
+					withTTL(600),
+					withZone("foo.com"),
+					withName("www"),
+					withProxied(false),
 				),
 			},
 			want: want{
 				o:   managed.ExternalCreation{},
-				err: errors.Wrap(errBoom, errRecordCreation),
+				err: errors.Wrap(errAlreadyExists, errRecordCreation),
 			},
 		},
-		"ErrRecordCreatePriorityMX": {
-			reason: "We should return an error if 'Priority' is unset for MX records",
+		"ErrRecordAlreadyExistsNoMatch": {
+			reason: "We should return an error if the record already exists but no single matching record can be adopted",
 			fields: fields{
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
 				client: fake.MockClient{
 					MockCreateDNSRecord: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) (*cloudflare.DNSRecordResponse, error) {
-						return &cloudflare.DNSRecordResponse{
-							Result: rr,
-						}, nil
+						return nil, errAlreadyExists
+					},
+					MockDNSRecords: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+						return []cloudflare.DNSRecord{}, nil
 					},
 				},
 			},
 			args: args{
 				mg: record(
-					withType("MX"),
+					withType("A"),
 					withTTL(600),
 					withZone("foo.com"),
+					withName("www"),
+					withProxied(false),
+					withAdoptExisting(),
 				),
 			},
 			want: want{
 				o:   managed.ExternalCreation{},
-				err: errors.New(errRecordCreation),
+				err: errors.Wrap(errAlreadyExists, errRecordCreation),
 			},
 		},
-		"ErrRecordCreatePrioritySRV": {
-			reason: "We should return an error if 'Priority' is unset for SRV records",
+		"SuccessAdoptExisting": {
+			reason: "We should adopt a matching record if the record already exists and we were asked to adopt it",
 			fields: fields{
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
 				client: fake.MockClient{
 					MockCreateDNSRecord: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) (*cloudflare.DNSRecordResponse, error) {
-						return &cloudflare.DNSRecordResponse{
-							Result: rr,
-						}, nil
+						return nil, errAlreadyExists
+					},
+					MockDNSRecords: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+						return []cloudflare.DNSRecord{{ID: "1234beef", Name: "www.foo.com", ZoneName: "foo.com"}}, nil
 					},
 				},
 			},
 			args: args{
 				mg: record(
-					withType("SRV"),
+					withType("A"),
 					withTTL(600),
 					withZone("foo.com"),
+					withName("www"),
+					withProxied(false),
+					withAdoptExisting(),
 				),
 			},
 			want: want{
-				o:   managed.ExternalCreation{},
-				err: errors.New(errRecordCreation),
+				o: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+				},
 			},
 		},
-		"ErrRecordCreatePriorityURI": {
-			reason: "We should return an error if 'Priority' is unset for URI records",
+		"SuccessExplicitProxied": {
+			reason: "We should create a record with an explicitly set Proxied without looking up the zone",
 			fields: fields{
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
 				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{}, errBoom
+					},
 					MockCreateDNSRecord: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) (*cloudflare.DNSRecordResponse, error) {
+						if rr.Proxied == nil || *rr.Proxied {
+							return nil, errBoom
+						}
 						return &cloudflare.DNSRecordResponse{
 							Result: rr,
 						}, nil
@@ -407,20 +1498,28 @@ func TestCreate(t *testing.T) {
 			},
 			args: args{
 				mg: record(
-					withType("URI"),
+					withType("A"),
 					withTTL(600),
 					withZone("foo.com"),
+					withProxied(false),
 				),
 			},
 			want: want{
-				o:   managed.ExternalCreation{},
-				err: errors.New(errRecordCreation),
+				o: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+				},
 			},
 		},
 		"Success": {
 			reason: "We should return ExternalNameAssigned: true and no error when a record is created",
 			fields: fields{
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
 				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{ID: zoneID}, nil
+					},
 					MockCreateDNSRecord: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) (*cloudflare.DNSRecordResponse, error) {
 						return &cloudflare.DNSRecordResponse{
 							Result: rr,
@@ -446,11 +1545,15 @@ func TestCreate(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{client: tc.fields.client}
+			rec := &eventRecorder{}
+			e := external{client: tc.fields.client, kube: tc.fields.kube, recorder: rec, proxyByDefault: tc.fields.proxyByDefault}
 			got, err := e.Create(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
+			if diff := cmp.Diff(tc.want.events, rec.events); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want events, +got events:\n%s\n", tc.reason, diff)
+			}
 			if diff := cmp.Diff(tc.want.o, got); diff != "" {
 				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
@@ -471,8 +1574,9 @@ func TestUpdate(t *testing.T) {
 	}
 
 	type want struct {
-		o   managed.ExternalUpdate
-		err error
+		o      managed.ExternalUpdate
+		err    error
+		events []event.Event
 	}
 
 	cases := map[string]struct {
@@ -532,6 +1636,224 @@ func TestUpdate(t *testing.T) {
 				err: errors.Wrap(errBoom, errRecordUpdate),
 			},
 		},
+		"WarnRecordUpdateCNAMELoop": {
+			reason: "We should warn about, but still update, a CNAME record that points to itself",
+			fields: fields{
+				client: fake.MockClient{
+					MockUpdateDNSRecord: func(ctx context.Context, zoneID, recordID string, rr cloudflare.DNSRecord) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withExternalName("1234beef"),
+					withType("CNAME"),
+					withZone("foo.com"),
+					withTTL(600),
+					withName("www"),
+					withContent("www"),
+				),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+				events: []event.Event{
+					event.Warning(reasonCNAMELoop, errors.New(`CNAME record "www" points to itself and will not resolve unless it is proxied`)),
+				},
+			},
+		},
+		"SuccessProxiedCNAMELoop": {
+			reason: "We should not warn about a proxied CNAME record that points to itself",
+			fields: fields{
+				client: fake.MockClient{
+					MockUpdateDNSRecord: func(ctx context.Context, zoneID, recordID string, rr cloudflare.DNSRecord) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withExternalName("1234beef"),
+					withType("CNAME"),
+					withZone("foo.com"),
+					withTTL(600),
+					withName("www"),
+					withContent("www"),
+					withProxied(true),
+				),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+			},
+		},
+		"SuccessProxiedTTL": {
+			reason: "We should send an automatic TTL when a record is proxied",
+			fields: fields{
+				client: fake.MockClient{
+					MockUpdateDNSRecord: func(ctx context.Context, zoneID, recordID string, rr cloudflare.DNSRecord) error {
+						if rr.TTL != 1 {
+							return errors.Errorf("unexpected TTL %d", rr.TTL)
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withExternalName("1234beef"),
+					withType("A"),
+					withZone("foo.com"),
+					withTTL(900),
+					withProxied(true),
+				),
+			},
+			want: want{
+				o:   managed.ExternalUpdate{},
+				err: nil,
+			},
+		},
+		"SuccessAAAAProxiedTTL": {
+			reason: "We should send an automatic TTL when an AAAA record is proxied",
+			fields: fields{
+				client: fake.MockClient{
+					MockUpdateDNSRecord: func(ctx context.Context, zoneID, recordID string, rr cloudflare.DNSRecord) error {
+						if rr.Type != "AAAA" || rr.TTL != 1 || rr.Proxied == nil || !*rr.Proxied {
+							return errors.Errorf("unexpected %s record with TTL %d", rr.Type, rr.TTL)
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withExternalName("1234beef"),
+					withType("AAAA"),
+					withZone("foo.com"),
+					withTTL(900),
+					withProxied(true),
+				),
+			},
+			want: want{
+				o:   managed.ExternalUpdate{},
+				err: nil,
+			},
+		},
+		"SuccessAAAAUnproxiedTTL": {
+			reason: "We should send the spec TTL when an AAAA record is no longer proxied",
+			fields: fields{
+				client: fake.MockClient{
+					MockUpdateDNSRecord: func(ctx context.Context, zoneID, recordID string, rr cloudflare.DNSRecord) error {
+						if rr.Type != "AAAA" || rr.TTL != 900 || rr.Proxied == nil || *rr.Proxied {
+							return errors.Errorf("unexpected %s record with TTL %d", rr.Type, rr.TTL)
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withExternalName("1234beef"),
+					withType("AAAA"),
+					withZone("foo.com"),
+					withTTL(900),
+					withProxied(false),
+				),
+			},
+			want: want{
+				o:   managed.ExternalUpdate{},
+				err: nil,
+			},
+		},
+		"ErrRecordSettingsUpdate": {
+			reason: "We should return any errors updating the settings of a record",
+			fields: fields{
+				client: fake.MockClient{
+					MockUpdateDNSRecord: func(ctx context.Context, zoneID, recordID string, rr cloudflare.DNSRecord) error {
+						return nil
+					},
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withExternalName("1234beef"),
+					withType("CNAME"),
+					withZone("foo.com"),
+					withTTL(900),
+					withSettings(&v1alpha1.RecordSettings{FlattenCNAME: ptr.BoolPtr(true)}),
+				),
+			},
+			want: want{
+				o:   managed.ExternalUpdate{},
+				err: errors.Wrap(errBoom, errRecordSettingsUpdate),
+			},
+		},
+		"SuccessSettingsUpToDate": {
+			reason: "We should not update the settings of a record when they are up to date",
+			fields: fields{
+				client: fake.MockClient{
+					MockUpdateDNSRecord: func(ctx context.Context, zoneID, recordID string, rr cloudflare.DNSRecord) error {
+						return nil
+					},
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withExternalName("1234beef"),
+					withType("CNAME"),
+					withZone("foo.com"),
+					withTTL(900),
+					withSettings(&v1alpha1.RecordSettings{FlattenCNAME: ptr.BoolPtr(true)}),
+					withObservedSettings(&v1alpha1.RecordSettings{FlattenCNAME: ptr.BoolPtr(true)}),
+				),
+			},
+			want: want{
+				o:   managed.ExternalUpdate{},
+				err: nil,
+			},
+		},
+		"SuccessSettingsChanged": {
+			reason: "We should send only the managed settings of a record when they have drifted",
+			fields: fields{
+				client: fake.MockClient{
+					MockUpdateDNSRecord: func(ctx context.Context, zoneID, recordID string, rr cloudflare.DNSRecord) error {
+						return nil
+					},
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if method != http.MethodPatch || endpoint != "/zones/foo.com/dns_records/1234beef" {
+							return nil, errors.Errorf("unexpected %s %s", method, endpoint)
+						}
+						b, err := json.Marshal(data)
+						if err != nil {
+							return nil, err
+						}
+						if string(b) != `{"settings":{"flatten_cname":true}}` {
+							return nil, errors.Errorf("unexpected settings %s", b)
+						}
+						return json.RawMessage(`{}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: record(
+					withExternalName("1234beef"),
+					withType("CNAME"),
+					withZone("foo.com"),
+					withTTL(900),
+					withSettings(&v1alpha1.RecordSettings{FlattenCNAME: ptr.BoolPtr(true)}),
+					withObservedSettings(&v1alpha1.RecordSettings{}),
+				),
+			},
+			want: want{
+				o:   managed.ExternalUpdate{},
+				err: nil,
+			},
+		},
 		"Success": {
 			reason: "We should return no error when a zone is updated",
 			fields: fields{
@@ -563,11 +1885,15 @@ func TestUpdate(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{client: tc.fields.client}
+			rec := &eventRecorder{}
+			e := external{client: tc.fields.client, recorder: rec}
 			got, err := e.Update(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
+			if diff := cmp.Diff(tc.want.events, rec.events); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want events, +got events:\n%s\n", tc.reason, diff)
+			}
 			if diff := cmp.Diff(tc.want.o, got); diff != "" {
 				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
@@ -678,3 +2004,55 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+// eventRecorder records the events emitted during a test.
+type eventRecorder struct {
+	events []event.Event
+}
+
+func (r *eventRecorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *eventRecorder) WithAnnotations(_ ...string) event.Recorder {
+	return r
+}
+
+func TestZoneCache(t *testing.T) {
+	now := time.Now()
+	lookups := 0
+	cl := fake.MockClient{
+		MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+			lookups++
+			return cloudflare.Zone{ID: zoneID, Name: "foo.com"}, nil
+		},
+	}
+
+	c := newZoneCache(time.Minute)
+	c.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.Get(context.Background(), cl, "1234"); err != nil {
+			t.Fatalf("c.Get(...): %s", err)
+		}
+	}
+	if lookups != 1 {
+		t.Errorf("c.Get(...): want 1 lookup of a cached zone, got %d", lookups)
+	}
+
+	// Looking up another zone once the first has expired drops it.
+	now = now.Add(2 * time.Minute)
+	if _, err := c.Get(context.Background(), cl, "5678"); err != nil {
+		t.Fatalf("c.Get(...): %s", err)
+	}
+	if _, ok := c.zones["1234"]; ok {
+		t.Errorf("c.Get(...): want expired zone to be dropped")
+	}
+
+	if _, err := c.Get(context.Background(), cl, "1234"); err != nil {
+		t.Fatalf("c.Get(...): %s", err)
+	}
+	if lookups != 3 {
+		t.Errorf("c.Get(...): want 3 lookups once a zone has expired, got %d", lookups)
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package address
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/emailrouting/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/emailrouting"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotEmailRoutingAddress = "managed resource is not an EmailRoutingAddress custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errAddressLookup   = "cannot lookup email routing address"
+	errAddressCreation = "cannot create email routing address"
+	errAddressDeletion = "cannot delete email routing address"
+	errNoAccount       = "no account found"
+)
+
+// Setup adds a controller that reconciles EmailRoutingAddress managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.EmailRoutingAddressGroupKind)
+	o = o.For(v1alpha1.EmailRoutingAddressGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.EmailRoutingAddressGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (emailrouting.Client, error) {
+				return emailrouting.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.EmailRoutingAddress{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (emailrouting.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.EmailRoutingAddress)
+	if !ok {
+		return nil, errors.New(errNotEmailRoutingAddress)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client emailrouting.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.EmailRoutingAddress)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotEmailRoutingAddress)
+	}
+
+	// Address does not exist if we dont have an ID stored in external-name
+	aid := meta.GetExternalName(cr)
+	if aid == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalObservation{}, errors.New(errNoAccount)
+	}
+
+	a, err := emailrouting.EmailRoutingAddress(ctx, e.client, *cr.Spec.ForProvider.Account, aid)
+	if err != nil {
+		return managed.ExternalObservation{},
+			errors.Wrap(resource.Ignore(emailrouting.IsNotFound, err), errAddressLookup)
+	}
+
+	cr.Status.AtProvider = emailrouting.GenerateAddressObservation(*a)
+
+	// Emails are only forwarded to an address once its owner has followed
+	// the verification link sent to it.
+	if cr.Status.AtProvider.Verified {
+		cr.SetConditions(rtv1.Available())
+	} else {
+		cr.SetConditions(rtv1.Unavailable())
+	}
+
+	// The email of a destination address cannot be changed.
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.EmailRoutingAddress)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotEmailRoutingAddress)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalCreation{}, errors.Wrap(errors.New(errNoAccount), errAddressCreation)
+	}
+
+	a, err := emailrouting.CreateAddress(ctx, e.client, *cr.Spec.ForProvider.Account, cr.Spec.ForProvider.Email)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errAddressCreation)
+	}
+
+	cr.Status.AtProvider = emailrouting.GenerateAddressObservation(*a)
+
+	// Update the external name with the ID of the new Address
+	meta.SetExternalName(cr, a.ID)
+
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	// Destination addresses cannot be updated.
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.EmailRoutingAddress)
+	if !ok {
+		return errors.New(errNotEmailRoutingAddress)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return errors.Wrap(errors.New(errNoAccount), errAddressDeletion)
+	}
+
+	aid := meta.GetExternalName(cr)
+
+	// Delete should never be called on a nonexistent resource
+	if aid == "" {
+		return errors.New(errAddressDeletion)
+	}
+
+	return errors.Wrap(
+		resource.Ignore(emailrouting.IsNotFound,
+			emailrouting.DeleteAddress(ctx, e.client, *cr.Spec.ForProvider.Account, aid)),
+		errAddressDeletion)
+}
@@ -0,0 +1,560 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package address
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/emailrouting/v1alpha1"
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/emailrouting"
+	"github.com/benagricola/provider-cloudflare/internal/clients/emailrouting/fake"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const (
+	testAccount   = "01a7362d577a6c3019a474fd6f485823"
+	testAddressID = "ea95132c15732412d22c1476fa83f27a"
+	testEmail     = "team@example.org"
+)
+
+type addressModifier func(*v1alpha1.EmailRoutingAddress)
+
+func withAccount(account string) addressModifier {
+	return func(a *v1alpha1.EmailRoutingAddress) { a.Spec.ForProvider.Account = &account }
+}
+
+func withExternalName(id string) addressModifier {
+	return func(a *v1alpha1.EmailRoutingAddress) { meta.SetExternalName(a, id) }
+}
+
+func withObservation(o v1alpha1.EmailRoutingAddressObservation) addressModifier {
+	return func(a *v1alpha1.EmailRoutingAddress) { a.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) addressModifier {
+	return func(a *v1alpha1.EmailRoutingAddress) { a.Status.SetConditions(c...) }
+}
+
+func addressBuild(m ...addressModifier) *v1alpha1.EmailRoutingAddress {
+	cr := &v1alpha1.EmailRoutingAddress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "team",
+		},
+		Spec: v1alpha1.EmailRoutingAddressSpec{
+			ForProvider: v1alpha1.EmailRoutingAddressParameters{
+				Email: testEmail,
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (emailrouting.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotEmailRoutingAddress": {
+			reason: "An error should be returned if the managed resource is not an *EmailRoutingAddress",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotEmailRoutingAddress),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube: mc,
+			},
+			args: args{
+				mg: &v1alpha1.EmailRoutingAddress{
+					Spec: v1alpha1.EmailRoutingAddressSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when a client is created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *pcv1alpha1.ProviderConfig:
+							o.Spec.Credentials.Source = "Secret"
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								Key: "creds",
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{
+								"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+							}
+						}
+						return nil
+					}),
+				},
+				newClient: emailrouting.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.EmailRoutingAddress{
+					Spec: v1alpha1.EmailRoutingAddressSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (emailrouting.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	verifiedOn := metav1.NewTime(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC))
+	errNotFound := errors.New("HTTP status 404: not found")
+
+	type fields struct {
+		client emailrouting.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		cr  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotEmailRoutingAddress": {
+			reason: "An error should be returned if the managed resource is not an *EmailRoutingAddress",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotEmailRoutingAddress),
+			},
+		},
+		"ErrNoAddress": {
+			reason: "We should return ResourceExists: false when no external name is set",
+			args: args{
+				mg: addressBuild(withAccount(testAccount)),
+			},
+			want: want{
+				cr: addressBuild(withAccount(testAccount)),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the EmailRoutingAddress has no account",
+			args: args{
+				mg: addressBuild(withExternalName(testAddressID)),
+			},
+			want: want{
+				cr:  addressBuild(withExternalName(testAddressID)),
+				err: errors.New(errNoAccount),
+			},
+		},
+		"ErrAddressLookup": {
+			reason: "We should return an error if the EmailRoutingAddress could not be looked up",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: addressBuild(withAccount(testAccount), withExternalName(testAddressID)),
+			},
+			want: want{
+				cr:  addressBuild(withAccount(testAccount), withExternalName(testAddressID)),
+				err: errors.Wrap(errBoom, errAddressLookup),
+			},
+		},
+		"NotFound": {
+			reason: "We should return ResourceExists: false if the EmailRoutingAddress was deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errNotFound
+					},
+				},
+			},
+			args: args{
+				mg: addressBuild(withAccount(testAccount), withExternalName(testAddressID)),
+			},
+			want: want{
+				cr: addressBuild(withAccount(testAccount), withExternalName(testAddressID)),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Unverified": {
+			reason: "An EmailRoutingAddress should be unavailable until it has been verified",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if method != http.MethodGet || endpoint != "/accounts/"+testAccount+"/email/routing/addresses"+"/"+testAddressID {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{"id":"ea95132c15732412d22c1476fa83f27a","email":"team@example.org","verified":null}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: addressBuild(withAccount(testAccount), withExternalName(testAddressID)),
+			},
+			want: want{
+				cr: addressBuild(
+					withAccount(testAccount),
+					withExternalName(testAddressID),
+					withConditions(xpv1.Unavailable()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"Verified": {
+			reason: "An EmailRoutingAddress should be available once it has been verified",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"id":"ea95132c15732412d22c1476fa83f27a","email":"team@example.org","verified":"2022-06-01T12:00:00Z"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: addressBuild(withAccount(testAccount), withExternalName(testAddressID)),
+			},
+			want: want{
+				cr: addressBuild(
+					withAccount(testAccount),
+					withExternalName(testAddressID),
+					withObservation(v1alpha1.EmailRoutingAddressObservation{
+						Verified:   true,
+						VerifiedOn: &verifiedOn,
+					}),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want cr, +got cr:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client emailrouting.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		cr  resource.Managed
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotEmailRoutingAddress": {
+			reason: "An error should be returned if the managed resource is not an *EmailRoutingAddress",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotEmailRoutingAddress),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the EmailRoutingAddress has no account",
+			args: args{
+				mg: addressBuild(),
+			},
+			want: want{
+				cr:  addressBuild(),
+				err: errors.Wrap(errors.New(errNoAccount), errAddressCreation),
+			},
+		},
+		"ErrAddressCreate": {
+			reason: "We should return any errors creating the EmailRoutingAddress",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: addressBuild(withAccount(testAccount)),
+			},
+			want: want{
+				cr:  addressBuild(withAccount(testAccount)),
+				err: errors.Wrap(errors.Wrap(errBoom, "error creating email routing address"), errAddressCreation),
+			},
+		},
+		"Success": {
+			reason: "We should set the external name of a created EmailRoutingAddress to its ID",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if method != http.MethodPost || endpoint != "/accounts/"+testAccount+"/email/routing/addresses" {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{"id":"ea95132c15732412d22c1476fa83f27a","email":"team@example.org"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: addressBuild(withAccount(testAccount)),
+			},
+			want: want{
+				cr: addressBuild(
+					withAccount(testAccount),
+					withExternalName(testAddressID),
+				),
+				o: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want cr, +got cr:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := errors.New("HTTP status 404: not found")
+
+	type fields struct {
+		client emailrouting.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotEmailRoutingAddress": {
+			reason: "An error should be returned if the managed resource is not an *EmailRoutingAddress",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotEmailRoutingAddress),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the EmailRoutingAddress has no account",
+			args: args{
+				mg: addressBuild(withExternalName(testAddressID)),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoAccount), errAddressDeletion),
+			},
+		},
+		"ErrNoAddress": {
+			reason: "We should return an error when no external name is set",
+			args: args{
+				mg: addressBuild(withAccount(testAccount)),
+			},
+			want: want{
+				err: errors.New(errAddressDeletion),
+			},
+		},
+		"ErrAddressDelete": {
+			reason: "We should return any errors deleting the EmailRoutingAddress",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: addressBuild(withAccount(testAccount), withExternalName(testAddressID)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errAddressDeletion),
+			},
+		},
+		"NotFound": {
+			reason: "We should not return an error if the EmailRoutingAddress was already deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errNotFound
+					},
+				},
+			},
+			args: args{
+				mg: addressBuild(withAccount(testAccount), withExternalName(testAddressID)),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
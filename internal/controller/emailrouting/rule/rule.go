@@ -0,0 +1,236 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rule
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/emailrouting/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/emailrouting"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotEmailRoutingRule = "managed resource is not an EmailRoutingRule custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errRuleLookup   = "cannot lookup email routing rule"
+	errRuleCreation = "cannot create email routing rule"
+	errRuleUpdate   = "cannot update email routing rule"
+	errRuleDeletion = "cannot delete email routing rule"
+	errNoZone       = "no zone found"
+)
+
+// Setup adds a controller that reconciles EmailRoutingRule managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.EmailRoutingRuleGroupKind)
+	o = o.For(v1alpha1.EmailRoutingRuleGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.EmailRoutingRuleGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (emailrouting.Client, error) {
+				return emailrouting.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.EmailRoutingRule{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (emailrouting.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.EmailRoutingRule)
+	if !ok {
+		return nil, errors.New(errNotEmailRoutingRule)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client emailrouting.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.EmailRoutingRule)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotEmailRoutingRule)
+	}
+
+	// Rule does not exist if we dont have a tag stored in external-name
+	tag := meta.GetExternalName(cr)
+	if tag == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalObservation{}, errors.New(errNoZone)
+	}
+
+	var r *emailrouting.Rule
+	var err error
+	if emailrouting.IsCatchAll(&cr.Spec.ForProvider) {
+		r, err = emailrouting.CatchAllRule(ctx, e.client, *cr.Spec.ForProvider.Zone)
+	} else {
+		r, err = emailrouting.EmailRoutingRule(ctx, e.client, *cr.Spec.ForProvider.Zone, tag)
+	}
+	if err != nil {
+		return managed.ExternalObservation{},
+			errors.Wrap(resource.Ignore(emailrouting.IsNotFound, err), errRuleLookup)
+	}
+
+	cr.SetConditions(rtv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: emailrouting.LateInitializeRule(&cr.Spec.ForProvider, *r),
+		ResourceUpToDate:        emailrouting.RuleUpToDate(&cr.Spec.ForProvider, *r),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.EmailRoutingRule)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotEmailRoutingRule)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalCreation{}, errors.Wrap(errors.New(errNoZone), errRuleCreation)
+	}
+
+	var r *emailrouting.Rule
+	var err error
+	// The catch-all rule always exists, so it is configured rather than
+	// created.
+	if emailrouting.IsCatchAll(&cr.Spec.ForProvider) {
+		r, err = emailrouting.UpdateCatchAllRule(ctx, e.client, *cr.Spec.ForProvider.Zone, &cr.Spec.ForProvider)
+	} else {
+		r, err = emailrouting.CreateRule(ctx, e.client, *cr.Spec.ForProvider.Zone, &cr.Spec.ForProvider)
+	}
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errRuleCreation)
+	}
+
+	// Update the external name with the tag of the new Rule
+	meta.SetExternalName(cr, r.Tag)
+
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.EmailRoutingRule)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotEmailRoutingRule)
+	}
+
+	tag := meta.GetExternalName(cr)
+
+	// Update should never be called on a nonexistent resource
+	if tag == "" {
+		return managed.ExternalUpdate{}, errors.New(errRuleUpdate)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalUpdate{}, errors.Wrap(errors.New(errNoZone), errRuleUpdate)
+	}
+
+	if emailrouting.IsCatchAll(&cr.Spec.ForProvider) {
+		_, err := emailrouting.UpdateCatchAllRule(ctx, e.client, *cr.Spec.ForProvider.Zone, &cr.Spec.ForProvider)
+		return managed.ExternalUpdate{}, errors.Wrap(err, errRuleUpdate)
+	}
+
+	return managed.ExternalUpdate{},
+		errors.Wrap(emailrouting.UpdateRule(ctx, e.client, *cr.Spec.ForProvider.Zone, tag, &cr.Spec.ForProvider), errRuleUpdate)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.EmailRoutingRule)
+	if !ok {
+		return errors.New(errNotEmailRoutingRule)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return errors.Wrap(errors.New(errNoZone), errRuleDeletion)
+	}
+
+	tag := meta.GetExternalName(cr)
+
+	// Delete should never be called on a nonexistent resource
+	if tag == "" {
+		return errors.New(errRuleDeletion)
+	}
+
+	// The catch-all rule cannot be deleted, so it is disabled instead.
+	if emailrouting.IsCatchAll(&cr.Spec.ForProvider) {
+		return errors.Wrap(
+			emailrouting.DisableCatchAllRule(ctx, e.client, *cr.Spec.ForProvider.Zone, &cr.Spec.ForProvider),
+			errRuleDeletion)
+	}
+
+	return errors.Wrap(
+		resource.Ignore(emailrouting.IsNotFound,
+			emailrouting.DeleteRule(ctx, e.client, *cr.Spec.ForProvider.Zone, tag)),
+		errRuleDeletion)
+}
@@ -0,0 +1,771 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rule
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/emailrouting/v1alpha1"
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/emailrouting"
+	"github.com/benagricola/provider-cloudflare/internal/clients/emailrouting/fake"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const (
+	testZone        = "023e105f4ecef8ad9ca31a8372d0c353"
+	testRuleTag     = "a7e6fb77503c41d8a7f3113c6918f10c"
+	testCatchAllTag = "b8f7ac88614d52e9b8a4224d7a29a21d"
+)
+
+type ruleModifier func(*v1alpha1.EmailRoutingRule)
+
+func withZone(zone string) ruleModifier {
+	return func(r *v1alpha1.EmailRoutingRule) { r.Spec.ForProvider.Zone = &zone }
+}
+
+func withExternalName(tag string) ruleModifier {
+	return func(r *v1alpha1.EmailRoutingRule) { meta.SetExternalName(r, tag) }
+}
+
+func withPriority(priority int) ruleModifier {
+	return func(r *v1alpha1.EmailRoutingRule) { r.Spec.ForProvider.Priority = &priority }
+}
+
+func withForward(to string) ruleModifier {
+	return func(r *v1alpha1.EmailRoutingRule) {
+		r.Spec.ForProvider.Actions = []v1alpha1.EmailRoutingRuleAction{{Type: "forward", Value: []string{to}}}
+	}
+}
+
+func withCatchAll() ruleModifier {
+	return func(r *v1alpha1.EmailRoutingRule) {
+		catchAll := true
+		r.Spec.ForProvider.CatchAll = &catchAll
+		r.Spec.ForProvider.Matchers = nil
+		r.Spec.ForProvider.Actions = []v1alpha1.EmailRoutingRuleAction{{Type: "drop"}}
+	}
+}
+
+func withConditions(c ...xpv1.Condition) ruleModifier {
+	return func(r *v1alpha1.EmailRoutingRule) { r.Status.SetConditions(c...) }
+}
+
+func ruleBuild(m ...ruleModifier) *v1alpha1.EmailRoutingRule {
+	field, value := "to", "support@example.com"
+	cr := &v1alpha1.EmailRoutingRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "support",
+		},
+		Spec: v1alpha1.EmailRoutingRuleSpec{
+			ForProvider: v1alpha1.EmailRoutingRuleParameters{
+				Matchers: []v1alpha1.EmailRoutingRuleMatcher{{Type: "literal", Field: &field, Value: &value}},
+				Actions:  []v1alpha1.EmailRoutingRuleAction{{Type: "forward", Value: []string{"team@example.org"}}},
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (emailrouting.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotEmailRoutingRule": {
+			reason: "An error should be returned if the managed resource is not an *EmailRoutingRule",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotEmailRoutingRule),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube: mc,
+			},
+			args: args{
+				mg: &v1alpha1.EmailRoutingRule{
+					Spec: v1alpha1.EmailRoutingRuleSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when a client is created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *pcv1alpha1.ProviderConfig:
+							o.Spec.Credentials.Source = "Secret"
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								Key: "creds",
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{
+								"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+							}
+						}
+						return nil
+					}),
+				},
+				newClient: emailrouting.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.EmailRoutingRule{
+					Spec: v1alpha1.EmailRoutingRuleSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (emailrouting.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := errors.New("HTTP status 404: not found")
+
+	type fields struct {
+		client emailrouting.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		cr  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotEmailRoutingRule": {
+			reason: "An error should be returned if the managed resource is not an *EmailRoutingRule",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotEmailRoutingRule),
+			},
+		},
+		"ErrNoRule": {
+			reason: "We should return ResourceExists: false when no external name is set",
+			args: args{
+				mg: ruleBuild(withZone(testZone)),
+			},
+			want: want{
+				cr: ruleBuild(withZone(testZone)),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the EmailRoutingRule has no zone",
+			args: args{
+				mg: ruleBuild(withExternalName(testRuleTag)),
+			},
+			want: want{
+				cr:  ruleBuild(withExternalName(testRuleTag)),
+				err: errors.New(errNoZone),
+			},
+		},
+		"ErrRuleLookup": {
+			reason: "We should return an error if the EmailRoutingRule could not be looked up",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: ruleBuild(withZone(testZone), withExternalName(testRuleTag)),
+			},
+			want: want{
+				cr:  ruleBuild(withZone(testZone), withExternalName(testRuleTag)),
+				err: errors.Wrap(errBoom, errRuleLookup),
+			},
+		},
+		"NotFound": {
+			reason: "We should return ResourceExists: false if the EmailRoutingRule was deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errNotFound
+					},
+				},
+			},
+			args: args{
+				mg: ruleBuild(withZone(testZone), withExternalName(testRuleTag)),
+			},
+			want: want{
+				cr: ruleBuild(withZone(testZone), withExternalName(testRuleTag)),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"LateInitialize": {
+			reason: "We should late initialize the priority of an existing EmailRoutingRule",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if method != http.MethodGet || endpoint != "/zones/"+testZone+"/email/routing/rules"+"/"+testRuleTag {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{"tag":"a7e6fb77503c41d8a7f3113c6918f10c","name":"support","enabled":true,"priority":10,"matchers":[{"type":"literal","field":"to","value":"support@example.com"}],"actions":[{"type":"forward","value":["team@example.org"]}]}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: ruleBuild(withZone(testZone), withExternalName(testRuleTag)),
+			},
+			want: want{
+				cr: ruleBuild(
+					withZone(testZone),
+					withExternalName(testRuleTag),
+					withPriority(10),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceLateInitialized: true,
+					ResourceUpToDate:        true,
+				},
+			},
+		},
+		"NotUpToDate": {
+			reason: "We should return ResourceUpToDate: false when the destination differs",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"tag":"a7e6fb77503c41d8a7f3113c6918f10c","name":"support","enabled":true,"priority":10,"matchers":[{"type":"literal","field":"to","value":"support@example.com"}],"actions":[{"type":"forward","value":["team@example.org"]}]}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: ruleBuild(withZone(testZone), withExternalName(testRuleTag), withPriority(10), withForward("help@example.org")),
+			},
+			want: want{
+				cr: ruleBuild(
+					withZone(testZone),
+					withExternalName(testRuleTag),
+					withPriority(10),
+					withForward("help@example.org"),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"CatchAll": {
+			reason: "We should observe the catch-all rule of the Zone when CatchAll is set",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if method != http.MethodGet || endpoint != "/zones/"+testZone+"/email/routing/rules"+"/catch_all" {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{"tag":"b8f7ac88614d52e9b8a4224d7a29a21d","enabled":true,"matchers":[{"type":"all"}],"actions":[{"type":"drop"}]}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: ruleBuild(withZone(testZone), withExternalName(testCatchAllTag), withCatchAll()),
+			},
+			want: want{
+				cr: ruleBuild(
+					withZone(testZone),
+					withExternalName(testCatchAllTag),
+					withCatchAll(),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want cr, +got cr:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client emailrouting.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		cr  resource.Managed
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotEmailRoutingRule": {
+			reason: "An error should be returned if the managed resource is not an *EmailRoutingRule",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotEmailRoutingRule),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the EmailRoutingRule has no zone",
+			args: args{
+				mg: ruleBuild(),
+			},
+			want: want{
+				cr:  ruleBuild(),
+				err: errors.Wrap(errors.New(errNoZone), errRuleCreation),
+			},
+		},
+		"ErrRuleCreate": {
+			reason: "We should return any errors creating the EmailRoutingRule",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: ruleBuild(withZone(testZone)),
+			},
+			want: want{
+				cr:  ruleBuild(withZone(testZone)),
+				err: errors.Wrap(errors.Wrap(errBoom, "error creating email routing rule"), errRuleCreation),
+			},
+		},
+		"Success": {
+			reason: "We should set the external name of a created EmailRoutingRule to its tag",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if method != http.MethodPost || endpoint != "/zones/"+testZone+"/email/routing/rules" {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{"tag":"a7e6fb77503c41d8a7f3113c6918f10c","name":"support","enabled":true,"priority":10,"matchers":[{"type":"literal","field":"to","value":"support@example.com"}],"actions":[{"type":"forward","value":["team@example.org"]}]}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: ruleBuild(withZone(testZone)),
+			},
+			want: want{
+				cr: ruleBuild(
+					withZone(testZone),
+					withExternalName(testRuleTag),
+				),
+				o: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+				},
+			},
+		},
+		"CatchAll": {
+			reason: "We should configure the catch-all rule of the Zone rather than creating a new rule",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if method != http.MethodPut || endpoint != "/zones/"+testZone+"/email/routing/rules"+"/catch_all" {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{"tag":"b8f7ac88614d52e9b8a4224d7a29a21d","enabled":true,"matchers":[{"type":"all"}],"actions":[{"type":"drop"}]}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: ruleBuild(withZone(testZone), withCatchAll()),
+			},
+			want: want{
+				cr: ruleBuild(
+					withZone(testZone),
+					withCatchAll(),
+					withExternalName(testCatchAllTag),
+				),
+				o: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want cr, +got cr:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client emailrouting.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotEmailRoutingRule": {
+			reason: "An error should be returned if the managed resource is not an *EmailRoutingRule",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotEmailRoutingRule),
+			},
+		},
+		"ErrNoRule": {
+			reason: "We should return an error when no external name is set",
+			args: args{
+				mg: ruleBuild(withZone(testZone)),
+			},
+			want: want{
+				err: errors.New(errRuleUpdate),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the EmailRoutingRule has no zone",
+			args: args{
+				mg: ruleBuild(withExternalName(testRuleTag)),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoZone), errRuleUpdate),
+			},
+		},
+		"ErrRuleUpdate": {
+			reason: "We should return any errors updating the EmailRoutingRule",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: ruleBuild(withZone(testZone), withExternalName(testRuleTag)),
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errBoom, "error updating email routing rule"), errRuleUpdate),
+			},
+		},
+		"Success": {
+			reason: "We should update the EmailRoutingRule",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if method != http.MethodPut || endpoint != "/zones/"+testZone+"/email/routing/rules"+"/"+testRuleTag {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: ruleBuild(withZone(testZone), withExternalName(testRuleTag)),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+			},
+		},
+		"CatchAll": {
+			reason: "We should update the catch-all rule of the Zone when CatchAll is set",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if method != http.MethodPut || endpoint != "/zones/"+testZone+"/email/routing/rules"+"/catch_all" {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{"tag":"b8f7ac88614d52e9b8a4224d7a29a21d","enabled":true,"matchers":[{"type":"all"}],"actions":[{"type":"drop"}]}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: ruleBuild(withZone(testZone), withExternalName(testCatchAllTag), withCatchAll()),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := errors.New("HTTP status 404: not found")
+
+	type fields struct {
+		client emailrouting.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotEmailRoutingRule": {
+			reason: "An error should be returned if the managed resource is not an *EmailRoutingRule",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotEmailRoutingRule),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the EmailRoutingRule has no zone",
+			args: args{
+				mg: ruleBuild(withExternalName(testRuleTag)),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoZone), errRuleDeletion),
+			},
+		},
+		"ErrNoRule": {
+			reason: "We should return an error when no external name is set",
+			args: args{
+				mg: ruleBuild(withZone(testZone)),
+			},
+			want: want{
+				err: errors.New(errRuleDeletion),
+			},
+		},
+		"ErrRuleDelete": {
+			reason: "We should return any errors deleting the EmailRoutingRule",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: ruleBuild(withZone(testZone), withExternalName(testRuleTag)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errRuleDeletion),
+			},
+		},
+		"NotFound": {
+			reason: "We should not return an error if the EmailRoutingRule was already deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errNotFound
+					},
+				},
+			},
+			args: args{
+				mg: ruleBuild(withZone(testZone), withExternalName(testRuleTag)),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"CatchAll": {
+			reason: "We should disable the catch-all rule of the Zone rather than deleting it",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if method != http.MethodPut || endpoint != "/zones/"+testZone+"/email/routing/rules"+"/catch_all" {
+							return nil, errBoom
+						}
+						if r, ok := data.(emailrouting.Rule); !ok || r.Enabled {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: ruleBuild(withZone(testZone), withExternalName(testCatchAllTag), withCatchAll()),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
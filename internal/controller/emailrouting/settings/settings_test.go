@@ -0,0 +1,519 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/emailrouting/v1alpha1"
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/emailrouting"
+	"github.com/benagricola/provider-cloudflare/internal/clients/emailrouting/fake"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const (
+	testZone = "023e105f4ecef8ad9ca31a8372d0c353"
+)
+
+type settingsModifier func(*v1alpha1.EmailRoutingSettings)
+
+func withZone(zone string) settingsModifier {
+	return func(s *v1alpha1.EmailRoutingSettings) { s.Spec.ForProvider.Zone = &zone }
+}
+
+func withObservation(o v1alpha1.EmailRoutingSettingsObservation) settingsModifier {
+	return func(s *v1alpha1.EmailRoutingSettings) { s.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) settingsModifier {
+	return func(s *v1alpha1.EmailRoutingSettings) { s.Status.SetConditions(c...) }
+}
+
+func settingsBuild(m ...settingsModifier) *v1alpha1.EmailRoutingSettings {
+	cr := &v1alpha1.EmailRoutingSettings{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "example-com",
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (emailrouting.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotEmailRoutingSettings": {
+			reason: "An error should be returned if the managed resource is not an *EmailRoutingSettings",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotEmailRoutingSettings),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube: mc,
+			},
+			args: args{
+				mg: &v1alpha1.EmailRoutingSettings{
+					Spec: v1alpha1.EmailRoutingSettingsSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when a client is created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *pcv1alpha1.ProviderConfig:
+							o.Spec.Credentials.Source = "Secret"
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								Key: "creds",
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{
+								"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+							}
+						}
+						return nil
+					}),
+				},
+				newClient: emailrouting.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.EmailRoutingSettings{
+					Spec: v1alpha1.EmailRoutingSettingsSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (emailrouting.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client emailrouting.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		cr  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotEmailRoutingSettings": {
+			reason: "An error should be returned if the managed resource is not an *EmailRoutingSettings",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotEmailRoutingSettings),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the EmailRoutingSettings has no zone",
+			args: args{
+				mg: settingsBuild(),
+			},
+			want: want{
+				cr:  settingsBuild(),
+				err: errors.New(errNoZone),
+			},
+		},
+		"ErrSettingsLookup": {
+			reason: "We should return an error if the Email Routing settings could not be looked up",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: settingsBuild(withZone(testZone)),
+			},
+			want: want{
+				cr:  settingsBuild(withZone(testZone)),
+				err: errors.Wrap(errBoom, errSettingsLookup),
+			},
+		},
+		"Disabled": {
+			reason: "We should return ResourceExists: false when Email Routing is disabled",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"name":"example.com","enabled":false,"status":"unconfigured"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: settingsBuild(withZone(testZone)),
+			},
+			want: want{
+				cr: settingsBuild(withZone(testZone)),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Misconfigured": {
+			reason: "Email Routing should be unavailable until its DNS records have been added",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"name":"example.com","enabled":true,"status":"misconfigured"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: settingsBuild(withZone(testZone)),
+			},
+			want: want{
+				cr: settingsBuild(
+					withZone(testZone),
+					withObservation(v1alpha1.EmailRoutingSettingsObservation{Name: "example.com", Status: "misconfigured"}),
+					withConditions(xpv1.Unavailable()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"Ready": {
+			reason: "Email Routing should be available once it is ready",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if method != http.MethodGet || endpoint != "/zones/"+testZone+"/email/routing" {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{"name":"example.com","enabled":true,"status":"ready"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: settingsBuild(withZone(testZone)),
+			},
+			want: want{
+				cr: settingsBuild(
+					withZone(testZone),
+					withObservation(v1alpha1.EmailRoutingSettingsObservation{Name: "example.com", Status: "ready"}),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want cr, +got cr:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client emailrouting.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		cr  resource.Managed
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotEmailRoutingSettings": {
+			reason: "An error should be returned if the managed resource is not an *EmailRoutingSettings",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotEmailRoutingSettings),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the EmailRoutingSettings has no zone",
+			args: args{
+				mg: settingsBuild(),
+			},
+			want: want{
+				cr:  settingsBuild(),
+				err: errors.Wrap(errors.New(errNoZone), errSettingsCreation),
+			},
+		},
+		"ErrSettingsCreate": {
+			reason: "We should return any errors enabling Email Routing",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: settingsBuild(withZone(testZone)),
+			},
+			want: want{
+				cr:  settingsBuild(withZone(testZone), withConditions(xpv1.Creating())),
+				err: errors.Wrap(errBoom, errSettingsCreation),
+			},
+		},
+		"Success": {
+			reason: "We should enable Email Routing on the Zone",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if method != http.MethodPost || endpoint != "/zones/"+testZone+"/email/routing"+"/enable" {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: settingsBuild(withZone(testZone)),
+			},
+			want: want{
+				cr: settingsBuild(withZone(testZone), withConditions(xpv1.Creating())),
+				o:  managed.ExternalCreation{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want cr, +got cr:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client emailrouting.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotEmailRoutingSettings": {
+			reason: "An error should be returned if the managed resource is not an *EmailRoutingSettings",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotEmailRoutingSettings),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the EmailRoutingSettings has no zone",
+			args: args{
+				mg: settingsBuild(),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoZone), errSettingsDeletion),
+			},
+		},
+		"ErrSettingsDelete": {
+			reason: "We should return any errors disabling Email Routing",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: settingsBuild(withZone(testZone)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errSettingsDeletion),
+			},
+		},
+		"Success": {
+			reason: "We should disable Email Routing on the Zone",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if method != http.MethodPost || endpoint != "/zones/"+testZone+"/email/routing"+"/disable" {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: settingsBuild(withZone(testZone)),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/firewall/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	ratelimit "github.com/benagricola/provider-cloudflare/internal/clients/firewall/ratelimit"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotRateLimit = "managed resource is not a RateLimit custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errRateLimitLookup   = "cannot lookup rate limit"
+	errRateLimitCreation = "cannot create rate limit"
+	errRateLimitUpdate   = "cannot update rate limit"
+	errRateLimitDeletion = "cannot delete rate limit"
+	errNoZone            = "no zone found"
+)
+
+// Setup adds a controller that reconciles RateLimit managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.RateLimitGroupKind)
+	o = o.For(v1alpha1.RateLimitGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.RateLimitGroupVersionKind),
+		managed.WithExternalConnecter(metrics.InstrumentConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (ratelimit.Client, error) {
+				return ratelimit.NewClient(cfg, hc)
+			},
+		}), name)),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.RateLimit{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (ratelimit.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.RateLimit)
+	if !ok {
+		return nil, errors.New(errNotRateLimit)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client ratelimit.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RateLimit)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRateLimit)
+	}
+
+	// Rate Limit does not exist if we dont have an ID stored in external-name
+	rid := meta.GetExternalName(cr)
+	if rid == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalObservation{}, errors.New(errNoZone)
+	}
+
+	rl, err := e.client.RateLimit(ctx, *cr.Spec.ForProvider.Zone, rid)
+
+	if err != nil {
+		return managed.ExternalObservation{},
+			errors.Wrap(resource.Ignore(ratelimit.IsRateLimitNotFound, err), errRateLimitLookup)
+	}
+
+	cr.Status.AtProvider = ratelimit.GenerateObservation(rl)
+
+	cr.Status.SetConditions(rtv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: ratelimit.LateInitialize(&cr.Spec.ForProvider, rl),
+		ResourceUpToDate:        ratelimit.UpToDate(&cr.Spec.ForProvider, rl),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RateLimit)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRateLimit)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalCreation{}, errors.New(errNoZone)
+	}
+
+	nr, err := ratelimit.CreateRateLimit(ctx, e.client, &cr.Spec.ForProvider)
+
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errRateLimitCreation)
+	}
+
+	cr.Status.AtProvider = ratelimit.GenerateObservation(*nr)
+
+	// Update the external name with the ID of the new Rate Limit
+	meta.SetExternalName(cr, nr.ID)
+
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RateLimit)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRateLimit)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalUpdate{}, errors.Wrap(errors.New(errNoZone), errRateLimitUpdate)
+	}
+
+	rid := meta.GetExternalName(cr)
+
+	// Update should never be called on a nonexistent resource
+	if rid == "" {
+		return managed.ExternalUpdate{}, errors.New(errRateLimitUpdate)
+	}
+
+	return managed.ExternalUpdate{},
+		errors.Wrap(
+			ratelimit.UpdateRateLimit(ctx, e.client, meta.GetExternalName(cr), &cr.Spec.ForProvider),
+			errRateLimitUpdate,
+		)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.RateLimit)
+	if !ok {
+		return errors.New(errNotRateLimit)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return errors.Wrap(errors.New(errNoZone), errRateLimitDeletion)
+	}
+
+	rid := meta.GetExternalName(cr)
+
+	// Delete should never be called on a nonexistent resource
+	if rid == "" {
+		return errors.New(errRateLimitDeletion)
+	}
+
+	return errors.Wrap(
+		e.client.DeleteRateLimit(ctx, *cr.Spec.ForProvider.Zone, meta.GetExternalName(cr)),
+		errRateLimitDeletion)
+}
@@ -0,0 +1,676 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/benagricola/provider-cloudflare/apis/firewall/v1alpha1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/benagricola/provider-cloudflare/internal/clients/firewall/ratelimit"
+	"github.com/benagricola/provider-cloudflare/internal/clients/firewall/ratelimit/fake"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	corev1 "k8s.io/api/core/v1"
+
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+type rateLimitModifier func(*v1alpha1.RateLimit)
+
+func withThreshold(threshold int64) rateLimitModifier {
+	return func(r *v1alpha1.RateLimit) { r.Spec.ForProvider.Threshold = threshold }
+}
+
+func withPeriod(period int64) rateLimitModifier {
+	return func(r *v1alpha1.RateLimit) { r.Spec.ForProvider.Period = period }
+}
+
+func withMode(mode string) rateLimitModifier {
+	return func(r *v1alpha1.RateLimit) { r.Spec.ForProvider.Action.Mode = mode }
+}
+
+func withDisabled(disabled bool) rateLimitModifier {
+	return func(r *v1alpha1.RateLimit) { r.Spec.ForProvider.Disabled = &disabled }
+}
+
+func withZone(zone string) rateLimitModifier {
+	return func(r *v1alpha1.RateLimit) { r.Spec.ForProvider.Zone = &zone }
+}
+
+func withExternalName(limitID string) rateLimitModifier {
+	return func(r *v1alpha1.RateLimit) { meta.SetExternalName(r, limitID) }
+}
+
+func rateLimitBuild(m ...rateLimitModifier) *v1alpha1.RateLimit {
+	cr := &v1alpha1.RateLimit{}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client ratelimit.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotRateLimit": {
+			reason: "An error should be returned if the managed resource is not a *RateLimit",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotRateLimit),
+			},
+		},
+		"ErrNoRateLimit": {
+			reason: "We should return ResourceExists: false when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: &v1alpha1.RateLimit{},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the Rate Limit has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: rateLimitBuild(withExternalName("372e67954025e0ba6aaa6d586b9e0b59")),
+			},
+			want: want{
+				err: errors.New(errNoZone),
+			},
+		},
+		"ErrRateLimitLookup": {
+			reason: "We should return an empty observation and an error if the API returned an error",
+			fields: fields{
+				client: fake.MockClient{
+					MockRateLimit: func(ctx context.Context, zoneID, limitID string) (cloudflare.RateLimit, error) {
+						return cloudflare.RateLimit{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: rateLimitBuild(
+					withZone("Test Zone"),
+					withExternalName("372e67954025e0ba6aaa6d586b9e0b59"),
+				),
+			},
+			want: want{
+				o:   managed.ExternalObservation{},
+				err: errors.Wrap(errBoom, errRateLimitLookup),
+			},
+		},
+		"ErrRateLimitNotFound": {
+			reason: "We should return ResourceExists: false if the Rate Limit was deleted on Cloudflare",
+			fields: fields{
+				client: fake.MockClient{
+					MockRateLimit: func(ctx context.Context, zoneID, limitID string) (cloudflare.RateLimit, error) {
+						return cloudflare.RateLimit{}, errors.New("HTTP status 404: not found")
+					},
+				},
+			},
+			args: args{
+				mg: rateLimitBuild(
+					withZone("Test Zone"),
+					withExternalName("372e67954025e0ba6aaa6d586b9e0b59"),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Success": {
+			reason: "We should return ResourceExists: true and no error when a Rate Limit is found",
+			fields: fields{
+				client: fake.MockClient{
+					MockRateLimit: func(ctx context.Context, zoneID, limitID string) (cloudflare.RateLimit, error) {
+						return cloudflare.RateLimit{
+							ID:        limitID,
+							Threshold: 5,
+							Period:    60,
+							Action:    cloudflare.RateLimitAction{Mode: "ban", Timeout: 600},
+						}, nil
+					},
+				},
+			},
+			args: args{
+				mg: rateLimitBuild(
+					withZone("Test Zone"),
+					withExternalName("372e67954025e0ba6aaa6d586b9e0b59"),
+					withThreshold(5),
+					withPeriod(60),
+					withMode("ban"),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+			},
+		},
+		"NotUpToDate": {
+			reason: "We should return ResourceUpToDate: false when the Rate Limit differs",
+			fields: fields{
+				client: fake.MockClient{
+					MockRateLimit: func(ctx context.Context, zoneID, limitID string) (cloudflare.RateLimit, error) {
+						return cloudflare.RateLimit{
+							ID:        limitID,
+							Threshold: 10,
+							Period:    60,
+							Action:    cloudflare.RateLimitAction{Mode: "ban"},
+						}, nil
+					},
+				},
+			},
+			args: args{
+				mg: rateLimitBuild(
+					withZone("Test Zone"),
+					withExternalName("372e67954025e0ba6aaa6d586b9e0b59"),
+					withThreshold(5),
+					withPeriod(60),
+					withMode("ban"),
+					withDisabled(false),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client ratelimit.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotRateLimit": {
+			reason: "An error should be returned if the managed resource is not a *RateLimit",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotRateLimit),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the Rate Limit has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: rateLimitBuild(),
+			},
+			want: want{
+				err: errors.New(errNoZone),
+			},
+		},
+		"ErrRateLimitCreate": {
+			reason: "We should return any errors during the create process",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateRateLimit: func(ctx context.Context, zoneID string, limit cloudflare.RateLimit) (cloudflare.RateLimit, error) {
+						return cloudflare.RateLimit{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: rateLimitBuild(withZone("Test Zone"), withThreshold(5), withPeriod(60), withMode("ban")),
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errBoom, "error creating rate limit"), errRateLimitCreation),
+			},
+		},
+		"Success": {
+			reason: "We should return ExternalNameAssigned: true and no error when a Rate Limit is created",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateRateLimit: func(ctx context.Context, zoneID string, limit cloudflare.RateLimit) (cloudflare.RateLimit, error) {
+						limit.ID = "372e67954025e0ba6aaa6d586b9e0b59"
+						return limit, nil
+					},
+				},
+			},
+			args: args{
+				mg: rateLimitBuild(withZone("Test Zone"), withThreshold(5), withPeriod(60), withMode("ban")),
+			},
+			want: want{
+				o: managed.ExternalCreation{ExternalNameAssigned: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (ratelimit.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotRateLimit": {
+			reason: "An error should be returned if the managed resource is not a *RateLimit",
+			fields: fields{
+				newClient: ratelimit.NewClient,
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotRateLimit),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube:      mc,
+				newClient: ratelimit.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.RateLimit{
+					Spec: v1alpha1.RateLimitSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when a client is created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *pcv1alpha1.ProviderConfig:
+							o.Spec.Credentials.Source = "Secret"
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								Key: "creds",
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{
+								"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+							}
+						}
+						return nil
+					}),
+				},
+				newClient: ratelimit.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.RateLimit{
+					Spec: v1alpha1.RateLimitSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (ratelimit.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client ratelimit.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotRateLimit": {
+			reason: "An error should be returned if the managed resource is not a *RateLimit",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotRateLimit),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the Rate Limit has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: rateLimitBuild(withExternalName("372e67954025e0ba6aaa6d586b9e0b59")),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoZone), errRateLimitUpdate),
+			},
+		},
+		"ErrRateLimitUpdate": {
+			reason: "We should return any errors during the update process",
+			fields: fields{
+				client: fake.MockClient{
+					MockUpdateRateLimit: func(ctx context.Context, zoneID, limitID string, limit cloudflare.RateLimit) (cloudflare.RateLimit, error) {
+						return cloudflare.RateLimit{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: rateLimitBuild(
+					withZone("Test Zone"),
+					withExternalName("372e67954025e0ba6aaa6d586b9e0b59"),
+					withThreshold(5),
+					withPeriod(60),
+					withMode("ban"),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errBoom, "error updating rate limit"), errRateLimitUpdate),
+			},
+		},
+		"Success": {
+			reason: "We should return no error when a Rate Limit is updated",
+			fields: fields{
+				client: fake.MockClient{
+					MockUpdateRateLimit: func(ctx context.Context, zoneID, limitID string, limit cloudflare.RateLimit) (cloudflare.RateLimit, error) {
+						if limit.ID != limitID {
+							return cloudflare.RateLimit{}, errBoom
+						}
+						return limit, nil
+					},
+				},
+			},
+			args: args{
+				mg: rateLimitBuild(
+					withZone("Test Zone"),
+					withExternalName("372e67954025e0ba6aaa6d586b9e0b59"),
+					withThreshold(5),
+					withPeriod(60),
+					withMode("ban"),
+				),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client ratelimit.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotRateLimit": {
+			reason: "An error should be returned if the managed resource is not a *RateLimit",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotRateLimit),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the Rate Limit has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: rateLimitBuild(withExternalName("372e67954025e0ba6aaa6d586b9e0b59")),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoZone), errRateLimitDeletion),
+			},
+		},
+		"ErrRateLimitDelete": {
+			reason: "We should return any errors during the delete process",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteRateLimit: func(ctx context.Context, zoneID, limitID string) error {
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: rateLimitBuild(
+					withZone("Test Zone"),
+					withExternalName("372e67954025e0ba6aaa6d586b9e0b59"),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errRateLimitDeletion),
+			},
+		},
+		"Success": {
+			reason: "We should return no error when a Rate Limit is deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteRateLimit: func(ctx context.Context, zoneID, limitID string) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: rateLimitBuild(
+					withZone("Test Zone"),
+					withExternalName("372e67954025e0ba6aaa6d586b9e0b59"),
+				),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
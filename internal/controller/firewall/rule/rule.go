@@ -18,25 +18,28 @@ package rule
 
 import (
 	"context"
-	"time"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
+	ctrlevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
-	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
-	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/benagricola/provider-cloudflare/apis/firewall/v1alpha1"
 	clients "github.com/benagricola/provider-cloudflare/internal/clients"
 	rule "github.com/benagricola/provider-cloudflare/internal/clients/firewall/rule"
+	ruleorder "github.com/benagricola/provider-cloudflare/internal/clients/firewall/ruleorder"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
 	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
 )
 
@@ -52,41 +55,77 @@ const (
 	errNoZone       = "no zone found"
 	errNoFilter     = "no filter found"
 
-	maxConcurrency = 5
+	errRuleOrderLookup = "cannot lookup rule orders"
+	errRuleOrderIndex  = "cannot index rule orders"
 )
 
 // Setup adds a controller that reconciles Rule managed resources.
-func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+func Setup(mgr ctrl.Manager, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.RuleGroupKind)
+	o = o.For(v1alpha1.RuleGroupKind)
 
-	o := controller.Options{
-		RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
-		MaxConcurrentReconciles: maxConcurrency,
+	// RuleOrders are indexed by the Rules they order, so that a Rule can
+	// find the RuleOrder that assigns its priority without listing them
+	// all.
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.RuleOrder{}, ruleorder.RuleRefsIndexKey, ruleorder.IndexRuleRefs); err != nil {
+		return errors.Wrap(err, errRuleOrderIndex)
 	}
 
 	hc := metrics.NewInstrumentedHTTPClient(name)
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.RuleGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
+		managed.WithExternalConnecter(metrics.InstrumentConnecter(policy.NewConnecter(&connector{
 			kube: mgr.GetClient(),
 			newCloudflareClientFn: func(cfg clients.Config) (rule.Client, error) {
 				return rule.NewClient(cfg, hc)
 			},
-		}),
-		managed.WithLogger(l.WithValues("controller", name)),
+		}), name)),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
-		managed.WithPollInterval(5*time.Minute),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
 		// Do not initialize external-name field.
 		managed.WithInitializers(),
 	)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		WithOptions(o).
+		WithOptions(o.ForControllerRuntime()).
 		For(&v1alpha1.Rule{}).
+		Watches(&source.Kind{Type: &v1alpha1.RuleOrder{}}, &orderedRules{}).
 		Complete(r)
 }
 
+// orderedRules enqueues the Rules ordered by a RuleOrder, so that they are
+// assigned their new priorities without waiting for them to be polled.
+// A Rule that is no longer ordered by an updated RuleOrder is enqueued
+// too, so that it takes its own priority again.
+type orderedRules struct{}
+
+func (h *orderedRules) Create(e ctrlevent.CreateEvent, q workqueue.RateLimitingInterface) {
+	enqueueRuleRefs(e.Object, q)
+}
+
+func (h *orderedRules) Update(e ctrlevent.UpdateEvent, q workqueue.RateLimitingInterface) {
+	enqueueRuleRefs(e.ObjectOld, q)
+	enqueueRuleRefs(e.ObjectNew, q)
+}
+
+func (h *orderedRules) Delete(e ctrlevent.DeleteEvent, q workqueue.RateLimitingInterface) {
+	enqueueRuleRefs(e.Object, q)
+}
+
+func (h *orderedRules) Generic(e ctrlevent.GenericEvent, q workqueue.RateLimitingInterface) {
+	enqueueRuleRefs(e.Object, q)
+}
+
+// enqueueRuleRefs enqueues the Rules ordered by the passed RuleOrder.
+func enqueueRuleRefs(o client.Object, q workqueue.RateLimitingInterface) {
+	for _, name := range ruleorder.IndexRuleRefs(o) {
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: name}})
+	}
+}
+
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
@@ -113,13 +152,38 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, err
 	}
 
-	return &external{client: client}, nil
+	return &external{client: client, kube: c.kube}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
 	client rule.Client
+	kube   client.Client
+}
+
+// desired returns the parameters the Firewall Rule of the passed Rule
+// should have, along with the name of the RuleOrder that orders it, if
+// any. The priority of a Rule ordered by a RuleOrder is assigned by the
+// RuleOrder rather than set on the Rule. The Rule controller is the only
+// controller that sets the priority of a Firewall Rule.
+func (e *external) desired(ctx context.Context, cr *v1alpha1.Rule) (*v1alpha1.RuleParameters, string, error) {
+	l := &v1alpha1.RuleOrderList{}
+	if err := e.kube.List(ctx, l, client.MatchingFields{ruleorder.RuleRefsIndexKey: cr.GetName()}); err != nil {
+		return nil, "", errors.Wrap(err, errRuleOrderLookup)
+	}
+
+	name, p, err := ruleorder.AssignedPriority(l.Items, cr.GetName())
+	if err != nil {
+		return nil, "", errors.Wrap(err, errRuleOrderLookup)
+	}
+	if p == nil {
+		return &cr.Spec.ForProvider, "", nil
+	}
+
+	spec := cr.Spec.ForProvider.DeepCopy()
+	spec.Priority = p
+	return spec, name, nil
 }
 
 func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -138,6 +202,11 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNoZone)
 	}
 
+	spec, orderedBy, err := e.desired(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
 	r, err := e.client.FirewallRule(ctx, *cr.Spec.ForProvider.Zone, rid)
 
 	if err != nil {
@@ -146,13 +215,22 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}
 
 	cr.Status.AtProvider = rule.GenerateObservation(r)
+	cr.Status.AtProvider.OrderedBy = orderedBy
 
 	cr.Status.SetConditions(rtv1.Available())
 
+	// The priority of a Rule ordered by a RuleOrder is not late
+	// initialized, so that the Rule takes the priority it was last
+	// assigned once the RuleOrder is deleted.
+	li := r
+	if orderedBy != "" {
+		li.Priority = nil
+	}
+
 	return managed.ExternalObservation{
 		ResourceExists:          true,
-		ResourceLateInitialized: rule.LateInitialize(&cr.Spec.ForProvider, r),
-		ResourceUpToDate:        rule.UpToDate(&cr.Spec.ForProvider, r),
+		ResourceLateInitialized: rule.LateInitialize(&cr.Spec.ForProvider, li),
+		ResourceUpToDate:        rule.UpToDate(spec, r),
 	}, nil
 }
 
@@ -170,7 +248,12 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNoFilter)
 	}
 
-	nr, err := rule.CreateRule(ctx, e.client, &cr.Spec.ForProvider)
+	spec, _, err := e.desired(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	nr, err := rule.CreateRule(ctx, e.client, spec)
 
 	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errRuleCreation)
@@ -201,9 +284,14 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errRuleUpdate)
 	}
 
+	spec, _, err := e.desired(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
 	return managed.ExternalUpdate{},
 		errors.Wrap(
-			rule.UpdateRule(ctx, e.client, meta.GetExternalName(cr), &cr.Spec.ForProvider),
+			rule.UpdateRule(ctx, e.client, meta.GetExternalName(cr), spec),
 			errRuleUpdate,
 		)
 }
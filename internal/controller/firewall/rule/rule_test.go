@@ -37,7 +37,11 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
@@ -46,6 +50,7 @@ import (
 
 	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
 	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	ruleorder "github.com/benagricola/provider-cloudflare/internal/clients/firewall/ruleorder"
 )
 
 // Unlike many Kubernetes projects Crossplane does not use third party testing
@@ -86,6 +91,34 @@ func withFilter(filter string) ruleModifer {
 	return func(r *v1alpha1.Rule) { r.Spec.ForProvider.Filter = ptr.String(filter) }
 }
 
+func withName(name string) ruleModifer {
+	return func(r *v1alpha1.Rule) { r.SetName(name) }
+}
+
+func withPriority(priority int32) ruleModifer {
+	return func(r *v1alpha1.Rule) { r.Spec.ForProvider.Priority = &priority }
+}
+
+// ruleOrders returns a MockListFn that lists a RuleOrder assigning the
+// passed Rule the passed priority, when RuleOrders are listed by the
+// Rules they order.
+func ruleOrders(rule string, priority int32) test.MockListFn {
+	return func(_ context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+		lo := &client.ListOptions{}
+		lo.ApplyOptions(opts)
+		if lo.FieldSelector == nil || !lo.FieldSelector.Matches(fields.Set{ruleorder.RuleRefsIndexKey: rule}) {
+			return errors.New("rule orders were not listed by the rules they order")
+		}
+
+		ro := v1alpha1.RuleOrder{}
+		ro.SetName("example")
+		ro.Spec.ForProvider.RuleRefs = []xpv1.Reference{{Name: rule}}
+		ro.Spec.ForProvider.StartPriority = &priority
+		obj.(*v1alpha1.RuleOrderList).Items = []v1alpha1.RuleOrder{ro}
+		return nil
+	}
+}
+
 func ruleBuild(m ...ruleModifer) *v1alpha1.Rule {
 	cr := &v1alpha1.Rule{}
 	for _, f := range m {
@@ -98,6 +131,7 @@ func TestObserve(t *testing.T) {
 
 	type fields struct {
 		client rule.Client
+		kube   client.Client
 	}
 
 	type args struct {
@@ -143,6 +177,7 @@ func TestObserve(t *testing.T) {
 		"ErrRuleLookup": {
 			reason: "We should return an empty observation and an error if the API returned an error",
 			fields: fields{
+				kube: &test.MockClient{MockList: test.NewMockListFn(nil)},
 				client: fake.MockClient{
 					MockFirewallRule: func(ctx context.Context, zoneID string, ruleID string) (cloudflare.FirewallRule, error) {
 						return cloudflare.FirewallRule{}, errBoom
@@ -175,9 +210,86 @@ func TestObserve(t *testing.T) {
 				err: errors.New(errNoZone),
 			},
 		},
+		"ErrRuleOrderLookup": {
+			reason: "We should return an error if we cannot lookup the RuleOrders that may order the rule",
+			fields: fields{
+				client: fake.MockClient{},
+				kube:   &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			},
+			args: args{
+				mg: ruleBuild(
+					withExternalName("372e67954025e0ba6aaa6d586b9e0b61"),
+					withZone("Test Zone"),
+				),
+			},
+			want: want{
+				o:   managed.ExternalObservation{},
+				err: errors.Wrap(errBoom, errRuleOrderLookup),
+			},
+		},
+		"OrderedPriority": {
+			reason: "We should ignore the priority of a rule ordered by a RuleOrder, and not late initialize it",
+			fields: fields{
+				client: fake.MockClient{
+					MockFirewallRule: func(ctx context.Context, zoneID string, ruleID string) (cloudflare.FirewallRule, error) {
+						return cloudflare.FirewallRule{
+							ID:       "372e67954025e0ba6aaa6d586b9e0b61",
+							Action:   "allow",
+							Priority: float64(10),
+						}, nil
+					},
+				},
+				kube: &test.MockClient{MockList: ruleOrders("a", 10)},
+			},
+			args: args{
+				mg: ruleBuild(
+					withName("a"),
+					withExternalName("372e67954025e0ba6aaa6d586b9e0b61"),
+					withZone("Test Zone"),
+					withAction("allow"),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"OrderedPriorityOutOfDate": {
+			reason: "We should compare a rule ordered by a RuleOrder to the priority assigned by the RuleOrder",
+			fields: fields{
+				client: fake.MockClient{
+					MockFirewallRule: func(ctx context.Context, zoneID string, ruleID string) (cloudflare.FirewallRule, error) {
+						return cloudflare.FirewallRule{
+							ID:       "372e67954025e0ba6aaa6d586b9e0b61",
+							Action:   "allow",
+							Priority: float64(5),
+						}, nil
+					},
+				},
+				kube: &test.MockClient{MockList: ruleOrders("a", 10)},
+			},
+			args: args{
+				mg: ruleBuild(
+					withName("a"),
+					withExternalName("372e67954025e0ba6aaa6d586b9e0b61"),
+					withZone("Test Zone"),
+					withAction("allow"),
+					withPriority(5),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
 		"Success": {
 			reason: "We should return ResourceExists: true and no error when a rule is found",
 			fields: fields{
+				kube: &test.MockClient{MockList: test.NewMockListFn(nil)},
 				client: fake.MockClient{
 					MockFirewallRule: func(ctx context.Context, zoneID string, ruleID string) (cloudflare.FirewallRule, error) {
 						return cloudflare.FirewallRule{
@@ -214,7 +326,7 @@ func TestObserve(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{client: tc.fields.client}
+			e := external{client: tc.fields.client, kube: tc.fields.kube}
 			got, err := e.Observe(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
@@ -231,6 +343,7 @@ func TestCreate(t *testing.T) {
 
 	type fields struct {
 		client rule.Client
+		kube   client.Client
 	}
 
 	type args struct {
@@ -261,6 +374,7 @@ func TestCreate(t *testing.T) {
 		"ErrRuleCreate": {
 			reason: "We should return any errors during the create process",
 			fields: fields{
+				kube: &test.MockClient{MockList: test.NewMockListFn(nil)},
 				client: fake.MockClient{
 					MockCreateFirewallRules: func(ctx context.Context, zoneID string, rr []cloudflare.FirewallRule) ([]cloudflare.FirewallRule, error) {
 						return []cloudflare.FirewallRule{}, errBoom
@@ -286,6 +400,7 @@ func TestCreate(t *testing.T) {
 		"Success": {
 			reason: "We should return ExternalNameAssigned: true and no error when a rule is created",
 			fields: fields{
+				kube: &test.MockClient{MockList: test.NewMockListFn(nil)},
 				client: fake.MockClient{
 					MockCreateFirewallRules: func(ctx context.Context, zoneID string, rr []cloudflare.FirewallRule) ([]cloudflare.FirewallRule, error) {
 						return []cloudflare.FirewallRule{{
@@ -322,7 +437,7 @@ func TestCreate(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{client: tc.fields.client}
+			e := external{client: tc.fields.client, kube: tc.fields.kube}
 			got, err := e.Create(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
@@ -433,6 +548,7 @@ func TestUpdate(t *testing.T) {
 
 	type fields struct {
 		client rule.Client
+		kube   client.Client
 	}
 
 	type args struct {
@@ -500,6 +616,7 @@ func TestUpdate(t *testing.T) {
 		}, "ErrRuleUpdate": {
 			reason: "We should return any errors during the update process",
 			fields: fields{
+				kube: &test.MockClient{MockList: test.NewMockListFn(nil)},
 				client: fake.MockClient{
 					MockUpdateFirewallRule: func(ctx context.Context, zoneID string, rr cloudflare.FirewallRule) (cloudflare.FirewallRule, error) {
 						return cloudflare.FirewallRule{}, errBoom
@@ -533,9 +650,44 @@ func TestUpdate(t *testing.T) {
 				err: errors.Wrap(errors.Wrap(errBoom, "error updating firewall rule"), errRuleUpdate),
 			},
 		},
+		"OrderedPriority": {
+			reason: "We should update a rule ordered by a RuleOrder with the priority assigned by the RuleOrder",
+			fields: fields{
+				client: fake.MockClient{
+					MockFirewallRule: func(ctx context.Context, zoneID string, ruleID string) (cloudflare.FirewallRule, error) {
+						return cloudflare.FirewallRule{
+							ID:       "372e67954025e0ba6aaa6d586b9e0b61",
+							Action:   "allow",
+							Priority: float64(5),
+						}, nil
+					},
+					MockUpdateFirewallRule: func(ctx context.Context, zoneID string, rr cloudflare.FirewallRule) (cloudflare.FirewallRule, error) {
+						if rr.Priority != int32(10) {
+							return cloudflare.FirewallRule{}, errBoom
+						}
+						return rr, nil
+					},
+				},
+				kube: &test.MockClient{MockList: ruleOrders("a", 10)},
+			},
+			args: args{
+				mg: ruleBuild(
+					withName("a"),
+					withExternalName("372e67954025e0ba6aaa6d586b9e0b61"),
+					withZone("Test Zone"),
+					withAction("allow"),
+					withPriority(5),
+					withFilter("372e67954025e0ba6aaa6d586b9e0b61"),
+				),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+			},
+		},
 		"Success": {
 			reason: "We should return no error when a rule is updated successfully",
 			fields: fields{
+				kube: &test.MockClient{MockList: test.NewMockListFn(nil)},
 				client: fake.MockClient{
 					MockFirewallRule: func(ctx context.Context, zoneID string, ruleID string) (cloudflare.FirewallRule, error) {
 						return cloudflare.FirewallRule{
@@ -581,7 +733,7 @@ func TestUpdate(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{client: tc.fields.client}
+			e := external{client: tc.fields.client, kube: tc.fields.kube}
 			got, err := e.Update(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
@@ -703,3 +855,60 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestOrderedRules(t *testing.T) {
+	ro := func(names ...string) *v1alpha1.RuleOrder {
+		r := &v1alpha1.RuleOrder{}
+		for _, n := range names {
+			r.Spec.ForProvider.RuleRefs = append(r.Spec.ForProvider.RuleRefs, xpv1.Reference{Name: n})
+		}
+		return r
+	}
+
+	cases := map[string]struct {
+		reason string
+		event  func(h *orderedRules, q workqueue.RateLimitingInterface)
+		want   []string
+	}{
+		"Create": {
+			reason: "The Rules ordered by a new RuleOrder should be enqueued",
+			event: func(h *orderedRules, q workqueue.RateLimitingInterface) {
+				h.Create(ctrlevent.CreateEvent{Object: ro("a", "b")}, q)
+			},
+			want: []string{"a", "b"},
+		},
+		"Update": {
+			reason: "The Rules ordered by a RuleOrder before and after it was updated should be enqueued",
+			event: func(h *orderedRules, q workqueue.RateLimitingInterface) {
+				h.Update(ctrlevent.UpdateEvent{ObjectOld: ro("a", "b"), ObjectNew: ro("b", "c")}, q)
+			},
+			want: []string{"a", "b", "c"},
+		},
+		"Delete": {
+			reason: "The Rules ordered by a deleted RuleOrder should be enqueued",
+			event: func(h *orderedRules, q workqueue.RateLimitingInterface) {
+				h.Delete(ctrlevent.DeleteEvent{Object: ro("a")}, q)
+			},
+			want: []string{"a"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			defer q.ShutDown()
+
+			tc.event(&orderedRules{}, q)
+
+			got := []string{}
+			for q.Len() > 0 {
+				item, _ := q.Get()
+				got = append(got, item.(reconcile.Request).Name)
+				q.Done(item)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\norderedRules: -want enqueued Rules, +got enqueued Rules:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ruleorder
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/firewall/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	ruleorder "github.com/benagricola/provider-cloudflare/internal/clients/firewall/ruleorder"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotRuleOrder = "managed resource is not a RuleOrder custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errGetRule         = "cannot get rule %q"
+	errRuleLookup      = "cannot lookup firewall rule %q"
+	errRuleOrderLookup = "cannot lookup rule order"
+)
+
+// Setup adds a controller that reconciles RuleOrder managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.RuleOrderGroupKind)
+	o = o.For(v1alpha1.RuleOrderGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.RuleOrderGroupVersionKind),
+		managed.WithExternalConnecter(metrics.InstrumentConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (ruleorder.Client, error) {
+				return ruleorder.NewClient(cfg, hc)
+			},
+		}), name)),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.RuleOrder{}).
+		Watches(&source.Kind{Type: &v1alpha1.Rule{}}, handler.EnqueueRequestsFromMapFunc(orderedBy)).
+		Complete(r)
+}
+
+// orderedBy maps a Rule to the RuleOrder that orders it, so that the order
+// of a Rule that is created or changed is observed without waiting for its
+// RuleOrder to be polled.
+func orderedBy(o client.Object) []reconcile.Request {
+	r, ok := o.(*v1alpha1.Rule)
+	if !ok || r.Status.AtProvider.OrderedBy == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: r.Status.AtProvider.OrderedBy}}}
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (ruleorder.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.RuleOrder)
+	if !ok {
+		return nil, errors.New(errNotRuleOrder)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client, kube: c.kube}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+// A RuleOrder has no external resource of its own. The Rule controller
+// assigns each Rule it orders the priority the RuleOrder computes, and is
+// the only controller that sets the priority of a Firewall Rule, so a
+// RuleOrder only observes the priorities of its Rules.
+type external struct {
+	client ruleorder.Client
+	kube   client.Client
+}
+
+// observe returns an observation of the ordering of the Rules referenced
+// by the passed RuleOrder.
+func (e *external) observe(ctx context.Context, cr *v1alpha1.RuleOrder) (v1alpha1.RuleOrderObservation, error) {
+	rules := make([]v1alpha1.Rule, len(cr.Spec.ForProvider.RuleRefs))
+	for i, ref := range cr.Spec.ForProvider.RuleRefs {
+		if err := e.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, &rules[i]); err != nil {
+			return v1alpha1.RuleOrderObservation{}, errors.Wrapf(err, errGetRule, ref.Name)
+		}
+	}
+
+	zone, err := ruleorder.Zone(rules)
+	if err != nil {
+		return v1alpha1.RuleOrderObservation{}, err
+	}
+
+	p, err := ruleorder.Priorities(&cr.Spec.ForProvider)
+	if err != nil {
+		return v1alpha1.RuleOrderObservation{}, err
+	}
+
+	return ruleorder.GenerateObservation(zone, rules, p, func(id string) (*int32, error) {
+		r, err := e.client.FirewallRule(ctx, zone, id)
+		if err != nil {
+			return nil, errors.Wrapf(err, errRuleLookup, id)
+		}
+		return ruleorder.ObservedPriority(r), nil
+	})
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RuleOrder)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRuleOrder)
+	}
+
+	// RuleOrder has not been applied if we dont have a name stored in
+	// external-name. Once it is deleted, the Rules keep their priorities.
+	if meta.GetExternalName(cr) == "" || meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	o, err := e.observe(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errRuleOrderLookup)
+	}
+
+	cr.Status.AtProvider = o
+
+	// Rules that were reordered outside of Crossplane are restored by
+	// the Rule controller, so there is nothing for us to update.
+	if ruleorder.UpToDate(o) {
+		cr.SetConditions(rtv1.Available())
+	} else {
+		cr.SetConditions(rtv1.Unavailable())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RuleOrder)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRuleOrder)
+	}
+
+	o, err := e.observe(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errRuleOrderLookup)
+	}
+
+	cr.Status.AtProvider = o
+
+	meta.SetExternalName(cr, cr.GetName())
+
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	_, ok := mg.(*v1alpha1.RuleOrder)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRuleOrder)
+	}
+
+	// The priorities of the ordered Rules are only set by the Rule
+	// controller, so there is nothing to update.
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	_, ok := mg.(*v1alpha1.RuleOrder)
+	if !ok {
+		return errors.New(errNotRuleOrder)
+	}
+
+	// Firewall Rules keep the priorities they were assigned, so there is
+	// nothing to delete.
+	return nil
+}
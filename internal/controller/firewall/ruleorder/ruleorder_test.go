@@ -0,0 +1,286 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ruleorder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ptr "k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/firewall/v1alpha1"
+	ruleorder "github.com/benagricola/provider-cloudflare/internal/clients/firewall/ruleorder"
+	"github.com/benagricola/provider-cloudflare/internal/clients/firewall/ruleorder/fake"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const zoneID = "023e105f4ecef8ad9ca31a8372d0c353"
+
+type ruleOrderModifier func(*v1alpha1.RuleOrder)
+
+func withExternalName(name string) ruleOrderModifier {
+	return func(r *v1alpha1.RuleOrder) { meta.SetExternalName(r, name) }
+}
+
+func withRules(names ...string) ruleOrderModifier {
+	return func(r *v1alpha1.RuleOrder) {
+		for _, n := range names {
+			r.Spec.ForProvider.RuleRefs = append(r.Spec.ForProvider.RuleRefs, xpv1.Reference{Name: n})
+		}
+	}
+}
+
+func ruleOrder(m ...ruleOrderModifier) *v1alpha1.RuleOrder {
+	cr := &v1alpha1.RuleOrder{}
+	cr.SetName("example")
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// rules returns a MockGetFn that gets the passed Rules by name.
+func rules(rs ...v1alpha1.Rule) test.MockGetFn {
+	return func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		for _, r := range rs {
+			if r.GetName() == key.Name {
+				r.DeepCopyInto(obj.(*v1alpha1.Rule))
+				return nil
+			}
+		}
+		return errors.New("not found")
+	}
+}
+
+func rule(name, id string, priority *int32) v1alpha1.Rule {
+	r := v1alpha1.Rule{}
+	r.SetName(name)
+	r.Spec.ForProvider.Zone = ptr.StringPtr(zoneID)
+	r.Spec.ForProvider.Priority = priority
+	if id != "" {
+		meta.SetExternalName(&r, id)
+	}
+	return r
+}
+
+// firewallRules returns a Cloudflare client mock that observes the passed
+// priorities by Firewall Rule ID.
+func firewallRules(priorities map[string]float64) fake.MockClient {
+	return fake.MockClient{
+		MockFirewallRule: func(ctx context.Context, zoneID, ruleID string) (cloudflare.FirewallRule, error) {
+			return cloudflare.FirewallRule{ID: ruleID, Priority: priorities[ruleID]}, nil
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client ruleorder.Client
+		kube   client.Client
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		mg     resource.Managed
+		want   want
+	}{
+		"ErrNotRuleOrder": {
+			reason: "An error should be returned if the managed resource is not a *RuleOrder",
+			mg:     nil,
+			want:   want{err: errors.New(errNotRuleOrder)},
+		},
+		"NotApplied": {
+			reason: "We should return ResourceExists: false when no external name is set",
+			mg:     ruleOrder(withRules("a")),
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"ErrGetRule": {
+			reason: "We should return an error if a Rule cannot be found",
+			fields: fields{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			},
+			mg: ruleOrder(withExternalName("example"), withRules("a")),
+			want: want{
+				err: errors.Wrap(errors.Wrapf(errBoom, errGetRule, "a"), errRuleOrderLookup),
+			},
+		},
+		"UpToDate": {
+			reason: "We should return ResourceUpToDate: true when all Rules have their priorities",
+			fields: fields{
+				client: firewallRules(map[string]float64{"id-a": 1, "id-b": 2}),
+				kube: &test.MockClient{MockGet: rules(
+					rule("a", "id-a", ptr.Int32Ptr(1)),
+					rule("b", "id-b", ptr.Int32Ptr(2)),
+				)},
+			},
+			mg: ruleOrder(withExternalName("example"), withRules("a", "b")),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"Reordered": {
+			reason: "We should return ResourceUpToDate: true when a Firewall Rule was reordered outside of Crossplane, as the Rule controller restores its priority",
+			fields: fields{
+				client: firewallRules(map[string]float64{"id-a": 3, "id-b": 2}),
+				kube: &test.MockClient{MockGet: rules(
+					rule("a", "id-a", ptr.Int32Ptr(1)),
+					rule("b", "id-b", ptr.Int32Ptr(2)),
+				)},
+			},
+			mg: ruleOrder(withExternalName("example"), withRules("a", "b")),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"NewRule": {
+			reason: "We should return ResourceUpToDate: true when a Rule has not been created yet, as it is created with its assigned priority",
+			fields: fields{
+				client: firewallRules(map[string]float64{"id-a": 1}),
+				kube: &test.MockClient{MockGet: rules(
+					rule("a", "id-a", ptr.Int32Ptr(1)),
+					rule("b", "", nil),
+				)},
+			},
+			mg: ruleOrder(withExternalName("example"), withRules("a", "b")),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client, kube: tc.fields.kube}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	e := external{
+		client: firewallRules(map[string]float64{"id-a": 2, "id-b": 1}),
+		kube: &test.MockClient{
+			MockGet: rules(
+				rule("a", "id-a", ptr.Int32Ptr(2)),
+				rule("b", "id-b", ptr.Int32Ptr(1)),
+				rule("c", "", nil),
+			),
+			MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+				t.Errorf("e.Create(...): unexpected update of %q: a RuleOrder must not update the Rules it orders", obj.GetName())
+				return nil
+			},
+		},
+	}
+
+	cr := ruleOrder(withRules("a", "b", "c"), func(r *v1alpha1.RuleOrder) {
+		r.Spec.ForProvider.StartPriority = ptr.Int32Ptr(10)
+		r.Spec.ForProvider.PriorityStep = ptr.Int32Ptr(10)
+	})
+
+	got, err := e.Create(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Create(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(managed.ExternalCreation{ExternalNameAssigned: true}, got); diff != "" {
+		t.Errorf("e.Create(...): -want, +got:\n%s\n", diff)
+	}
+	if diff := cmp.Diff("example", meta.GetExternalName(cr)); diff != "" {
+		t.Errorf("e.Create(...): -want external name, +got external name:\n%s\n", diff)
+	}
+
+	// The Firewall Rules are not reordered by the RuleOrder, so their
+	// observed priorities are those they had before it was created.
+	want := v1alpha1.RuleOrderObservation{
+		Zone: zoneID,
+		Rules: []v1alpha1.RuleOrderRuleObservation{
+			{Name: "a", ID: "id-a", Priority: 10, ObservedPriority: ptr.Int32Ptr(2)},
+			{Name: "b", ID: "id-b", Priority: 20, ObservedPriority: ptr.Int32Ptr(1)},
+			{Name: "c", Priority: 30},
+		},
+	}
+	if diff := cmp.Diff(want, cr.Status.AtProvider); diff != "" {
+		t.Errorf("e.Create(...): -want observation, +got observation:\n%s\n", diff)
+	}
+}
+
+func TestOrderedBy(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		o      client.Object
+		want   []reconcile.Request
+	}{
+		"Ordered": {
+			reason: "A Rule should map to the RuleOrder that orders it",
+			o: func() client.Object {
+				r := rule("a", "id-a", nil)
+				r.Status.AtProvider.OrderedBy = "example"
+				return &r
+			}(),
+			want: []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "example"}}},
+		},
+		"NotOrdered": {
+			reason: "A Rule that is not ordered should not map to a RuleOrder",
+			o: func() client.Object {
+				r := rule("a", "id-a", nil)
+				return &r
+			}(),
+			want: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := orderedBy(tc.o)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\norderedBy(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthcheck
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/healthchecks/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/healthchecks"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotHealthCheck = "managed resource is not a HealthCheck custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errHealthCheckLookup   = "cannot lookup health check"
+	errHealthCheckCreation = "cannot create health check"
+	errHealthCheckUpdate   = "cannot update health check"
+	errHealthCheckDeletion = "cannot delete health check"
+	errNoZone              = "no zone found"
+)
+
+// Setup adds a controller that reconciles HealthCheck managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.HealthCheckGroupKind)
+	o = o.For(v1alpha1.HealthCheckGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.HealthCheckGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (healthchecks.Client, error) {
+				return healthchecks.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.HealthCheck{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (healthchecks.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.HealthCheck)
+	if !ok {
+		return nil, errors.New(errNotHealthCheck)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client healthchecks.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.HealthCheck)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotHealthCheck)
+	}
+
+	// HealthCheck does not exist if we dont have an ID stored in external-name
+	hid := meta.GetExternalName(cr)
+	if hid == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalObservation{}, errors.New(errNoZone)
+	}
+
+	hc, err := e.client.Healthcheck(ctx, *cr.Spec.ForProvider.Zone, hid)
+	if err != nil {
+		return managed.ExternalObservation{},
+			errors.Wrap(resource.Ignore(healthchecks.IsHealthCheckNotFound, err), errHealthCheckLookup)
+	}
+
+	cr.Status.AtProvider = healthchecks.GenerateObservation(hc)
+
+	cr.SetConditions(rtv1.Available())
+
+	// Report the result of the most recent check separately, as an
+	// unhealthy origin does not make the HealthCheck itself unavailable.
+	switch hc.Status {
+	case healthchecks.StatusHealthy:
+		cr.SetConditions(v1alpha1.Healthy())
+	case healthchecks.StatusUnhealthy:
+		cr.SetConditions(v1alpha1.Unhealthy(hc.FailureReason))
+	default:
+		cr.SetConditions(v1alpha1.HealthUnknown())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: healthchecks.UpToDate(&cr.Spec.ForProvider, hc),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.HealthCheck)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotHealthCheck)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalCreation{}, errors.Wrap(errors.New(errNoZone), errHealthCheckCreation)
+	}
+
+	hc, err := healthchecks.CreateHealthCheck(ctx, e.client, *cr.Spec.ForProvider.Zone, cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errHealthCheckCreation)
+	}
+
+	cr.Status.AtProvider = healthchecks.GenerateObservation(hc)
+
+	// Update the external name with the ID of the new HealthCheck
+	meta.SetExternalName(cr, hc.ID)
+
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.HealthCheck)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotHealthCheck)
+	}
+
+	hid := meta.GetExternalName(cr)
+
+	// Update should never be called on a nonexistent resource
+	if hid == "" {
+		return managed.ExternalUpdate{}, errors.New(errHealthCheckUpdate)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalUpdate{}, errors.Wrap(errors.New(errNoZone), errHealthCheckUpdate)
+	}
+
+	return managed.ExternalUpdate{},
+		errors.Wrap(healthchecks.UpdateHealthCheck(ctx, e.client, *cr.Spec.ForProvider.Zone, hid, cr.Spec.ForProvider), errHealthCheckUpdate)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.HealthCheck)
+	if !ok {
+		return errors.New(errNotHealthCheck)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return errors.Wrap(errors.New(errNoZone), errHealthCheckDeletion)
+	}
+
+	hid := meta.GetExternalName(cr)
+
+	// Delete should never be called on a nonexistent resource
+	if hid == "" {
+		return errors.New(errHealthCheckDeletion)
+	}
+
+	return errors.Wrap(
+		resource.Ignore(healthchecks.IsHealthCheckNotFound,
+			e.client.DeleteHealthcheck(ctx, *cr.Spec.ForProvider.Zone, hid)),
+		errHealthCheckDeletion)
+}
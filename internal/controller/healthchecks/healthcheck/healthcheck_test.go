@@ -0,0 +1,672 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthcheck
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/healthchecks/v1alpha1"
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/healthchecks"
+	"github.com/benagricola/provider-cloudflare/internal/clients/healthchecks/fake"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const (
+	testZone          = "023e105f4ecef8ad9ca31a8372d0c353"
+	testHealthCheckID = "699d98642c564d2e855e9661899b7252"
+)
+
+type healthCheckModifier func(*v1alpha1.HealthCheck)
+
+func withZone(zone string) healthCheckModifier {
+	return func(h *v1alpha1.HealthCheck) { h.Spec.ForProvider.Zone = &zone }
+}
+
+func withInterval(i int) healthCheckModifier {
+	return func(h *v1alpha1.HealthCheck) { h.Spec.ForProvider.Interval = &i }
+}
+
+func withExternalName(healthCheckID string) healthCheckModifier {
+	return func(h *v1alpha1.HealthCheck) { meta.SetExternalName(h, healthCheckID) }
+}
+
+func withObservation(o v1alpha1.HealthCheckObservation) healthCheckModifier {
+	return func(h *v1alpha1.HealthCheck) { h.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) healthCheckModifier {
+	return func(h *v1alpha1.HealthCheck) { h.Status.SetConditions(c...) }
+}
+
+func healthCheckBuild(m ...healthCheckModifier) *v1alpha1.HealthCheck {
+	cr := &v1alpha1.HealthCheck{
+		Spec: v1alpha1.HealthCheckSpec{
+			ForProvider: v1alpha1.HealthCheckParameters{
+				Name:    "origin",
+				Address: "origin.example.com",
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (healthchecks.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotHealthCheck": {
+			reason: "An error should be returned if the managed resource is not a *HealthCheck",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotHealthCheck),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube: mc,
+			},
+			args: args{
+				mg: &v1alpha1.HealthCheck{
+					Spec: v1alpha1.HealthCheckSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when a client is created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *pcv1alpha1.ProviderConfig:
+							o.Spec.Credentials.Source = "Secret"
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								Key: "creds",
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{
+								"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+							}
+						}
+						return nil
+					}),
+				},
+				newClient: healthchecks.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.HealthCheck{
+					Spec: v1alpha1.HealthCheckSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (healthchecks.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := errors.New("HTTP status 404: not found")
+
+	type fields struct {
+		client healthchecks.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		cr  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotHealthCheck": {
+			reason: "An error should be returned if the managed resource is not a *HealthCheck",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotHealthCheck),
+			},
+		},
+		"ErrNoHealthCheck": {
+			reason: "We should return ResourceExists: false when no external name is set",
+			args: args{
+				mg: healthCheckBuild(withZone(testZone)),
+			},
+			want: want{
+				cr: healthCheckBuild(withZone(testZone)),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the HealthCheck has no zone",
+			args: args{
+				mg: healthCheckBuild(withExternalName(testHealthCheckID)),
+			},
+			want: want{
+				cr:  healthCheckBuild(withExternalName(testHealthCheckID)),
+				err: errors.New(errNoZone),
+			},
+		},
+		"ErrHealthCheckLookup": {
+			reason: "We should return an error if the HealthCheck could not be looked up",
+			fields: fields{
+				client: fake.MockClient{
+					MockHealthcheck: func(ctx context.Context, zoneID, healthcheckID string) (cloudflare.Healthcheck, error) {
+						return cloudflare.Healthcheck{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: healthCheckBuild(withZone(testZone), withExternalName(testHealthCheckID)),
+			},
+			want: want{
+				cr:  healthCheckBuild(withZone(testZone), withExternalName(testHealthCheckID)),
+				err: errors.Wrap(errBoom, errHealthCheckLookup),
+			},
+		},
+		"NotFound": {
+			reason: "We should return ResourceExists: false if the HealthCheck was deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockHealthcheck: func(ctx context.Context, zoneID, healthcheckID string) (cloudflare.Healthcheck, error) {
+						return cloudflare.Healthcheck{}, errNotFound
+					},
+				},
+			},
+			args: args{
+				mg: healthCheckBuild(withZone(testZone), withExternalName(testHealthCheckID)),
+			},
+			want: want{
+				cr: healthCheckBuild(withZone(testZone), withExternalName(testHealthCheckID)),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "We should return ResourceUpToDate: true and report a healthy origin when the HealthCheck matches",
+			fields: fields{
+				client: fake.MockClient{
+					MockHealthcheck: func(ctx context.Context, zoneID, healthcheckID string) (cloudflare.Healthcheck, error) {
+						return cloudflare.Healthcheck{ID: testHealthCheckID, Name: "origin", Address: "origin.example.com", Interval: 60, Status: "healthy"}, nil
+					},
+				},
+			},
+			args: args{
+				mg: healthCheckBuild(withZone(testZone), withExternalName(testHealthCheckID), withInterval(60)),
+			},
+			want: want{
+				cr: healthCheckBuild(
+					withZone(testZone),
+					withExternalName(testHealthCheckID),
+					withInterval(60),
+					withObservation(v1alpha1.HealthCheckObservation{Status: "healthy"}),
+					withConditions(xpv1.Available(), v1alpha1.Healthy()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"NotUpToDate": {
+			reason: "We should return ResourceUpToDate: false when the interval differs",
+			fields: fields{
+				client: fake.MockClient{
+					MockHealthcheck: func(ctx context.Context, zoneID, healthcheckID string) (cloudflare.Healthcheck, error) {
+						return cloudflare.Healthcheck{ID: testHealthCheckID, Name: "origin", Address: "origin.example.com", Interval: 30, Status: "unknown"}, nil
+					},
+				},
+			},
+			args: args{
+				mg: healthCheckBuild(withZone(testZone), withExternalName(testHealthCheckID), withInterval(60)),
+			},
+			want: want{
+				cr: healthCheckBuild(
+					withZone(testZone),
+					withExternalName(testHealthCheckID),
+					withInterval(60),
+					withObservation(v1alpha1.HealthCheckObservation{Status: "unknown"}),
+					withConditions(xpv1.Available(), v1alpha1.HealthUnknown()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"Unhealthy": {
+			reason: "We should report the failure reason when the origin is unhealthy",
+			fields: fields{
+				client: fake.MockClient{
+					MockHealthcheck: func(ctx context.Context, zoneID, healthcheckID string) (cloudflare.Healthcheck, error) {
+						return cloudflare.Healthcheck{ID: testHealthCheckID, Name: "origin", Address: "origin.example.com", Status: "unhealthy", FailureReason: "TCP connection failed"}, nil
+					},
+				},
+			},
+			args: args{
+				mg: healthCheckBuild(withZone(testZone), withExternalName(testHealthCheckID)),
+			},
+			want: want{
+				cr: healthCheckBuild(
+					withZone(testZone),
+					withExternalName(testHealthCheckID),
+					withObservation(v1alpha1.HealthCheckObservation{
+						Status:        "unhealthy",
+						FailureReason: "TCP connection failed",
+					}),
+					withConditions(xpv1.Available(), v1alpha1.Unhealthy("TCP connection failed")),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want cr, +got cr:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client healthchecks.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		cr  resource.Managed
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotHealthCheck": {
+			reason: "An error should be returned if the managed resource is not a *HealthCheck",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotHealthCheck),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the HealthCheck has no zone",
+			args: args{
+				mg: healthCheckBuild(),
+			},
+			want: want{
+				cr:  healthCheckBuild(),
+				err: errors.Wrap(errors.New(errNoZone), errHealthCheckCreation),
+			},
+		},
+		"ErrHealthCheckCreate": {
+			reason: "We should return any errors creating the HealthCheck",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateHealthcheck: func(ctx context.Context, zoneID string, healthcheck cloudflare.Healthcheck) (cloudflare.Healthcheck, error) {
+						return cloudflare.Healthcheck{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: healthCheckBuild(withZone(testZone)),
+			},
+			want: want{
+				cr:  healthCheckBuild(withZone(testZone)),
+				err: errors.Wrap(errors.Wrap(errBoom, "error creating health check"), errHealthCheckCreation),
+			},
+		},
+		"Success": {
+			reason: "We should set the external name of a created HealthCheck",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateHealthcheck: func(ctx context.Context, zoneID string, healthcheck cloudflare.Healthcheck) (cloudflare.Healthcheck, error) {
+						return cloudflare.Healthcheck{ID: testHealthCheckID, Name: "origin", Address: "origin.example.com", Status: "unknown"}, nil
+					},
+				},
+			},
+			args: args{
+				mg: healthCheckBuild(withZone(testZone), withInterval(60)),
+			},
+			want: want{
+				cr: healthCheckBuild(
+					withZone(testZone),
+					withInterval(60),
+					withExternalName(testHealthCheckID),
+					withObservation(v1alpha1.HealthCheckObservation{Status: "unknown"}),
+				),
+				o: managed.ExternalCreation{ExternalNameAssigned: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want cr, +got cr:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client healthchecks.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotHealthCheck": {
+			reason: "An error should be returned if the managed resource is not a *HealthCheck",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotHealthCheck),
+			},
+		},
+		"ErrNoHealthCheck": {
+			reason: "We should return an error when no external name is set",
+			args: args{
+				mg: healthCheckBuild(withZone(testZone)),
+			},
+			want: want{
+				err: errors.New(errHealthCheckUpdate),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the HealthCheck has no zone",
+			args: args{
+				mg: healthCheckBuild(withExternalName(testHealthCheckID)),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoZone), errHealthCheckUpdate),
+			},
+		},
+		"ErrHealthCheckUpdate": {
+			reason: "We should return any errors updating the HealthCheck",
+			fields: fields{
+				client: fake.MockClient{
+					MockUpdateHealthcheck: func(ctx context.Context, zoneID string, healthcheckID string, healthcheck cloudflare.Healthcheck) (cloudflare.Healthcheck, error) {
+						return cloudflare.Healthcheck{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: healthCheckBuild(withZone(testZone), withExternalName(testHealthCheckID)),
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errBoom, "error updating health check"), errHealthCheckUpdate),
+			},
+		},
+		"Success": {
+			reason: "We should replace the HealthCheck",
+			fields: fields{
+				client: fake.MockClient{
+					MockUpdateHealthcheck: func(ctx context.Context, zoneID string, healthcheckID string, healthcheck cloudflare.Healthcheck) (cloudflare.Healthcheck, error) {
+						if zoneID != testZone || healthcheckID != testHealthCheckID || healthcheck.Interval != 60 {
+							return cloudflare.Healthcheck{}, errBoom
+						}
+						return healthcheck, nil
+					},
+				},
+			},
+			args: args{
+				mg: healthCheckBuild(withZone(testZone), withExternalName(testHealthCheckID), withInterval(60)),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := errors.New("HTTP status 404: not found")
+
+	type fields struct {
+		client healthchecks.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotHealthCheck": {
+			reason: "An error should be returned if the managed resource is not a *HealthCheck",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotHealthCheck),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the HealthCheck has no zone",
+			args: args{
+				mg: healthCheckBuild(withExternalName(testHealthCheckID)),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoZone), errHealthCheckDeletion),
+			},
+		},
+		"ErrNoHealthCheck": {
+			reason: "We should return an error when no external name is set",
+			args: args{
+				mg: healthCheckBuild(withZone(testZone)),
+			},
+			want: want{
+				err: errors.New(errHealthCheckDeletion),
+			},
+		},
+		"ErrHealthCheckDelete": {
+			reason: "We should return any errors deleting the HealthCheck",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteHealthcheck: func(ctx context.Context, zoneID string, healthcheckID string) error {
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: healthCheckBuild(withZone(testZone), withExternalName(testHealthCheckID)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errHealthCheckDeletion),
+			},
+		},
+		"NotFound": {
+			reason: "We should not return an error if the HealthCheck was already deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteHealthcheck: func(ctx context.Context, zoneID string, healthcheckID string) error {
+						return errNotFound
+					},
+				},
+			},
+			args: args{
+				mg: healthCheckBuild(withZone(testZone), withExternalName(testHealthCheckID)),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancer
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/loadbalancing/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	loadbalancer "github.com/benagricola/provider-cloudflare/internal/clients/loadbalancing/loadbalancer"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotLoadBalancer = "managed resource is not a LoadBalancer custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errLoadBalancerLookup   = "cannot lookup load balancer"
+	errLoadBalancerCreation = "cannot create load balancer"
+	errLoadBalancerUpdate   = "cannot update load balancer"
+	errLoadBalancerDeletion = "cannot delete load balancer"
+	errLoadBalancerNoZone   = "no zone found"
+)
+
+// Setup adds a controller that reconciles LoadBalancer managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.LoadBalancerGroupKind)
+	o = o.For(v1alpha1.LoadBalancerGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.LoadBalancerGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (loadbalancer.Client, error) {
+				return loadbalancer.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.LoadBalancer{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (loadbalancer.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.LoadBalancer)
+	if !ok {
+		return nil, errors.New(errNotLoadBalancer)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client loadbalancer.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.LoadBalancer)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotLoadBalancer)
+	}
+
+	// Load Balancer does not exist if we dont have an ID stored in external-name
+	lid := meta.GetExternalName(cr)
+	if lid == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalObservation{}, errors.New(errLoadBalancerNoZone)
+	}
+
+	lb, err := e.client.LoadBalancerDetails(ctx, *cr.Spec.ForProvider.Zone, lid)
+	if err != nil {
+		return managed.ExternalObservation{},
+			errors.Wrap(resource.Ignore(loadbalancer.IsLoadBalancerNotFound, err), errLoadBalancerLookup)
+	}
+
+	cr.Status.AtProvider = loadbalancer.GenerateObservation(lb)
+	cr.Status.SetConditions(rtv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: loadbalancer.LateInitialize(&cr.Spec.ForProvider, lb),
+		ResourceUpToDate:        loadbalancer.UpToDate(&cr.Spec.ForProvider, lb),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.LoadBalancer)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotLoadBalancer)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalCreation{}, errors.Wrap(errors.New(errLoadBalancerNoZone), errLoadBalancerCreation)
+	}
+
+	lb, err := e.client.CreateLoadBalancer(ctx, *cr.Spec.ForProvider.Zone, loadbalancer.ParametersToLoadBalancer(cr.Spec.ForProvider))
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errLoadBalancerCreation)
+	}
+
+	cr.Status.AtProvider = loadbalancer.GenerateObservation(lb)
+
+	// Update the external name with the ID of the new Load Balancer
+	meta.SetExternalName(cr, lb.ID)
+
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.LoadBalancer)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotLoadBalancer)
+	}
+
+	lid := meta.GetExternalName(cr)
+
+	// Update should never be called on a nonexistent resource
+	if lid == "" {
+		return managed.ExternalUpdate{}, errors.New(errLoadBalancerUpdate)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalUpdate{}, errors.Wrap(errors.New(errLoadBalancerNoZone), errLoadBalancerUpdate)
+	}
+
+	return managed.ExternalUpdate{},
+		errors.Wrap(
+			loadbalancer.UpdateLoadBalancer(ctx, e.client, lid, cr.Spec.ForProvider),
+			errLoadBalancerUpdate,
+		)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.LoadBalancer)
+	if !ok {
+		return errors.New(errNotLoadBalancer)
+	}
+
+	lid := meta.GetExternalName(cr)
+
+	// Delete should never be called on a nonexistent resource
+	if lid == "" {
+		return errors.New(errLoadBalancerDeletion)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return errors.Wrap(errors.New(errLoadBalancerNoZone), errLoadBalancerDeletion)
+	}
+
+	return errors.Wrap(
+		resource.Ignore(loadbalancer.IsLoadBalancerNotFound, e.client.DeleteLoadBalancer(ctx, *cr.Spec.ForProvider.Zone, lid)),
+		errLoadBalancerDeletion,
+	)
+}
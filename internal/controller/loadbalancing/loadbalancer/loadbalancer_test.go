@@ -0,0 +1,613 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancer
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/benagricola/provider-cloudflare/apis/loadbalancing/v1alpha1"
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	loadbalancer "github.com/benagricola/provider-cloudflare/internal/clients/loadbalancing/loadbalancer"
+	"github.com/benagricola/provider-cloudflare/internal/clients/loadbalancing/loadbalancer/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	ptr "k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+type LoadBalancerModifier func(*v1alpha1.LoadBalancer)
+
+func withZone(zoneID string) LoadBalancerModifier {
+	return func(r *v1alpha1.LoadBalancer) { r.Spec.ForProvider.Zone = &zoneID }
+}
+
+func withExternalName(id string) LoadBalancerModifier {
+	return func(r *v1alpha1.LoadBalancer) { meta.SetExternalName(r, id) }
+}
+
+func LoadBalancer(m ...LoadBalancerModifier) *v1alpha1.LoadBalancer {
+	cr := &v1alpha1.LoadBalancer{
+		Spec: v1alpha1.LoadBalancerSpec{
+			ForProvider: v1alpha1.LoadBalancerParameters{
+				Name:         "lb.example.com",
+				DefaultPools: []string{"17b5962d775c646f3f9725cbc7a53df4"},
+				FallbackPool: ptr.StringPtr("17b5962d775c646f3f9725cbc7a53df4"),
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	kube := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			switch o := obj.(type) {
+			case *pcv1alpha1.ProviderConfig:
+				o.Spec.Credentials.Source = "Secret"
+				o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+					Key: "creds",
+				}
+			case *corev1.Secret:
+				o.Data = map[string][]byte{
+					"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+				}
+			}
+			return nil
+		}),
+		MockCreate: test.NewMockCreateFn(nil),
+	}
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (loadbalancer.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotLoadBalancer": {
+			reason: "An error should be returned if the managed resource is not a *LoadBalancer",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotLoadBalancer),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube: mc,
+			},
+			args: args{
+				mg: &v1alpha1.LoadBalancer{
+					Spec: v1alpha1.LoadBalancerSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"ConnectReturnOK": {
+			reason: "Connect should return no error when passed the correct values",
+			fields: fields{
+				kube:      kube,
+				newClient: loadbalancer.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.LoadBalancer{
+					Spec: v1alpha1.LoadBalancerSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (loadbalancer.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client loadbalancer.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotLoadBalancer": {
+			reason: "An error should be returned if the managed resource is not a *LoadBalancer",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotLoadBalancer),
+			},
+		},
+		"ErrNoLoadBalancer": {
+			reason: "We should return ResourceExists: false when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: LoadBalancer(),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrLoadBalancerNoZone": {
+			reason: "We should return an error if the LoadBalancer does not have a zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: LoadBalancer(withExternalName("1234beef")),
+			},
+			want: want{
+				o:   managed.ExternalObservation{},
+				err: errors.New(errLoadBalancerNoZone),
+			},
+		},
+		"ErrLoadBalancerLookup": {
+			reason: "We should return an empty observation and an error if the API returned an error",
+			fields: fields{
+				client: fake.MockClient{
+					MockLoadBalancerDetails: func(ctx context.Context, zoneID, id string) (cloudflare.LoadBalancer, error) {
+						return cloudflare.LoadBalancer{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: LoadBalancer(withExternalName("1234beef"), withZone("foo.com")),
+			},
+			want: want{
+				o:   managed.ExternalObservation{},
+				err: errors.Wrap(errBoom, errLoadBalancerLookup),
+			},
+		},
+		"ErrLoadBalancerNotFound": {
+			reason: "We should return ResourceExists: false if the LoadBalancer is not found (deleted on CF side)",
+			fields: fields{
+				client: fake.MockClient{
+					MockLoadBalancerDetails: func(ctx context.Context, zoneID, id string) (cloudflare.LoadBalancer, error) {
+						return cloudflare.LoadBalancer{}, errors.New("HTTP status 404: not found")
+					},
+				},
+			},
+			args: args{
+				mg: LoadBalancer(withExternalName("1234beef"), withZone("foo.com")),
+			},
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: false},
+				err: nil,
+			},
+		},
+		"Success": {
+			reason: "We should return ResourceExists: true and no error when a LoadBalancer is found",
+			fields: fields{
+				client: fake.MockClient{
+					MockLoadBalancerDetails: func(ctx context.Context, zoneID, id string) (cloudflare.LoadBalancer, error) {
+						o := cloudflare.LoadBalancer{
+							Name:         "lb.example.com",
+							DefaultPools: []string{"17b5962d775c646f3f9725cbc7a53df4"},
+							FallbackPool: "17b5962d775c646f3f9725cbc7a53df4",
+							Proxied:      true,
+						}
+						o.ID = id
+						return o, nil
+					},
+				},
+			},
+			args: args{
+				mg: LoadBalancer(withExternalName("1234beef"), withZone("foo.com")),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceLateInitialized: true,
+					ResourceUpToDate:        true,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client loadbalancer.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotLoadBalancer": {
+			reason: "An error should be returned if the managed resource is not a *LoadBalancer",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotLoadBalancer),
+			},
+		},
+		"ErrLoadBalancerNoZone": {
+			reason: "We should return an error if the LoadBalancer does not have a zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: LoadBalancer(),
+			},
+			want: want{
+				o:   managed.ExternalCreation{},
+				err: errors.Wrap(errors.New(errLoadBalancerNoZone), errLoadBalancerCreation),
+			},
+		},
+		"ErrLoadBalancerCreate": {
+			reason: "We should return any errors during the create process",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateLoadBalancer: func(ctx context.Context, zoneID string, o cloudflare.LoadBalancer) (cloudflare.LoadBalancer, error) {
+						return cloudflare.LoadBalancer{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: LoadBalancer(withZone("foo.com")),
+			},
+			want: want{
+				o:   managed.ExternalCreation{},
+				err: errors.Wrap(errBoom, errLoadBalancerCreation),
+			},
+		},
+		"Success": {
+			reason: "We should return ExternalNameAssigned: true and no error when a LoadBalancer is created",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateLoadBalancer: func(ctx context.Context, zoneID string, o cloudflare.LoadBalancer) (cloudflare.LoadBalancer, error) {
+						o.ID = "1234beef"
+						return o, nil
+					},
+				},
+			},
+			args: args{
+				mg: LoadBalancer(withZone("foo.com")),
+			},
+			want: want{
+				o: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client loadbalancer.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotLoadBalancer": {
+			reason: "An error should be returned if the managed resource is not a *LoadBalancer",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotLoadBalancer),
+			},
+		},
+		"ErrNoLoadBalancer": {
+			reason: "We should return an error when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: LoadBalancer(),
+			},
+			want: want{
+				o:   managed.ExternalUpdate{},
+				err: errors.New(errLoadBalancerUpdate),
+			},
+		},
+		"ErrLoadBalancerUpdate": {
+			reason: "We should return any errors during the update process",
+			fields: fields{
+				client: fake.MockClient{
+					MockModifyLoadBalancer: func(ctx context.Context, zoneID string, o cloudflare.LoadBalancer) (cloudflare.LoadBalancer, error) {
+						return cloudflare.LoadBalancer{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: LoadBalancer(withExternalName("1234beef"), withZone("foo.com")),
+			},
+			want: want{
+				o:   managed.ExternalUpdate{},
+				err: errors.Wrap(errors.Wrap(errBoom, "error updating load balancer"), errLoadBalancerUpdate),
+			},
+		},
+		"Success": {
+			reason: "We should return no error when a LoadBalancer is updated",
+			fields: fields{
+				client: fake.MockClient{
+					MockModifyLoadBalancer: func(ctx context.Context, zoneID string, o cloudflare.LoadBalancer) (cloudflare.LoadBalancer, error) {
+						return o, nil
+					},
+				},
+			},
+			args: args{
+				mg: LoadBalancer(withExternalName("1234beef"), withZone("foo.com")),
+			},
+			want: want{
+				o:   managed.ExternalUpdate{},
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client loadbalancer.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotLoadBalancer": {
+			reason: "An error should be returned if the managed resource is not a *LoadBalancer",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotLoadBalancer),
+			},
+		},
+		"ErrNoLoadBalancer": {
+			reason: "We should return an error when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: LoadBalancer(),
+			},
+			want: want{
+				err: errors.New(errLoadBalancerDeletion),
+			},
+		},
+		"ErrLoadBalancerNoZone": {
+			reason: "We should return an error if the LoadBalancer does not have a zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: LoadBalancer(withExternalName("1234beef")),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errLoadBalancerNoZone), errLoadBalancerDeletion),
+			},
+		},
+		"ErrLoadBalancerDelete": {
+			reason: "We should return any errors during the delete process",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteLoadBalancer: func(ctx context.Context, zoneID, id string) error {
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: LoadBalancer(withExternalName("1234beef"), withZone("foo.com")),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errLoadBalancerDeletion),
+			},
+		},
+		"Success": {
+			reason: "We should return no error when a LoadBalancer is deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteLoadBalancer: func(ctx context.Context, zoneID, id string) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: LoadBalancer(withExternalName("1234beef"), withZone("foo.com")),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
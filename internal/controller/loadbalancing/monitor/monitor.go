@@ -0,0 +1,204 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitor
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/loadbalancing/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	monitor "github.com/benagricola/provider-cloudflare/internal/clients/loadbalancing/monitor"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotMonitor = "managed resource is not a LoadBalancerMonitor custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errMonitorLookup   = "cannot lookup load balancer monitor"
+	errMonitorCreation = "cannot create load balancer monitor"
+	errMonitorUpdate   = "cannot update load balancer monitor"
+	errMonitorDeletion = "cannot delete load balancer monitor"
+)
+
+// Setup adds a controller that reconciles LoadBalancerMonitor managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.LoadBalancerMonitorGroupKind)
+	o = o.For(v1alpha1.LoadBalancerMonitorGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.LoadBalancerMonitorGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (monitor.Client, error) {
+				return monitor.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.LoadBalancerMonitor{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (monitor.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.LoadBalancerMonitor)
+	if !ok {
+		return nil, errors.New(errNotMonitor)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	// Monitors are managed on an account rather than a zone.
+	if cr.Spec.ForProvider.Account != nil {
+		config.AccountID = cr.Spec.ForProvider.Account
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client monitor.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.LoadBalancerMonitor)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotMonitor)
+	}
+
+	// Monitor does not exist if we dont have an ID stored in external-name
+	mid := meta.GetExternalName(cr)
+	if mid == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	m, err := e.client.LoadBalancerMonitorDetails(ctx, mid)
+	if err != nil {
+		return managed.ExternalObservation{},
+			errors.Wrap(resource.Ignore(monitor.IsMonitorNotFound, err), errMonitorLookup)
+	}
+
+	cr.Status.AtProvider = monitor.GenerateObservation(m)
+	cr.Status.SetConditions(rtv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: monitor.LateInitialize(&cr.Spec.ForProvider, m),
+		ResourceUpToDate:        monitor.UpToDate(&cr.Spec.ForProvider, m),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.LoadBalancerMonitor)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotMonitor)
+	}
+
+	m, err := e.client.CreateLoadBalancerMonitor(ctx, monitor.ParametersToMonitor(cr.Spec.ForProvider))
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errMonitorCreation)
+	}
+
+	cr.Status.AtProvider = monitor.GenerateObservation(m)
+
+	// Update the external name with the ID of the new Monitor
+	meta.SetExternalName(cr, m.ID)
+
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.LoadBalancerMonitor)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotMonitor)
+	}
+
+	mid := meta.GetExternalName(cr)
+
+	// Update should never be called on a nonexistent resource
+	if mid == "" {
+		return managed.ExternalUpdate{}, errors.New(errMonitorUpdate)
+	}
+
+	return managed.ExternalUpdate{},
+		errors.Wrap(
+			monitor.UpdateMonitor(ctx, e.client, mid, cr.Spec.ForProvider),
+			errMonitorUpdate,
+		)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.LoadBalancerMonitor)
+	if !ok {
+		return errors.New(errNotMonitor)
+	}
+
+	mid := meta.GetExternalName(cr)
+
+	// Delete should never be called on a nonexistent resource
+	if mid == "" {
+		return errors.New(errMonitorDeletion)
+	}
+
+	return errors.Wrap(
+		resource.Ignore(monitor.IsMonitorNotFound, e.client.DeleteLoadBalancerMonitor(ctx, mid)),
+		errMonitorDeletion,
+	)
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/loadbalancing/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	pool "github.com/benagricola/provider-cloudflare/internal/clients/loadbalancing/pool"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotPool = "managed resource is not a LoadBalancerPool custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errPoolLookup   = "cannot lookup load balancer pool"
+	errPoolCreation = "cannot create load balancer pool"
+	errPoolUpdate   = "cannot update load balancer pool"
+	errPoolDeletion = "cannot delete load balancer pool"
+)
+
+// Setup adds a controller that reconciles LoadBalancerPool managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.LoadBalancerPoolGroupKind)
+	o = o.For(v1alpha1.LoadBalancerPoolGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.LoadBalancerPoolGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (pool.Client, error) {
+				return pool.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.LoadBalancerPool{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (pool.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.LoadBalancerPool)
+	if !ok {
+		return nil, errors.New(errNotPool)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	// Pools are managed on an account rather than a zone.
+	if cr.Spec.ForProvider.Account != nil {
+		config.AccountID = cr.Spec.ForProvider.Account
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client pool.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.LoadBalancerPool)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotPool)
+	}
+
+	// Pool does not exist if we dont have an ID stored in external-name
+	pid := meta.GetExternalName(cr)
+	if pid == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	p, err := e.client.LoadBalancerPoolDetails(ctx, pid)
+	if err != nil {
+		return managed.ExternalObservation{},
+			errors.Wrap(resource.Ignore(pool.IsPoolNotFound, err), errPoolLookup)
+	}
+
+	cr.Status.AtProvider = pool.GenerateObservation(p)
+	cr.Status.SetConditions(rtv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: pool.LateInitialize(&cr.Spec.ForProvider, p),
+		ResourceUpToDate:        pool.UpToDate(&cr.Spec.ForProvider, p),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.LoadBalancerPool)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotPool)
+	}
+
+	p, err := pool.ParametersToPool(cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errPoolCreation)
+	}
+
+	p, err = e.client.CreateLoadBalancerPool(ctx, p)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errPoolCreation)
+	}
+
+	cr.Status.AtProvider = pool.GenerateObservation(p)
+
+	// Update the external name with the ID of the new Pool
+	meta.SetExternalName(cr, p.ID)
+
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.LoadBalancerPool)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotPool)
+	}
+
+	pid := meta.GetExternalName(cr)
+
+	// Update should never be called on a nonexistent resource
+	if pid == "" {
+		return managed.ExternalUpdate{}, errors.New(errPoolUpdate)
+	}
+
+	return managed.ExternalUpdate{},
+		errors.Wrap(
+			pool.UpdatePool(ctx, e.client, pid, cr.Spec.ForProvider),
+			errPoolUpdate,
+		)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.LoadBalancerPool)
+	if !ok {
+		return errors.New(errNotPool)
+	}
+
+	pid := meta.GetExternalName(cr)
+
+	// Delete should never be called on a nonexistent resource
+	if pid == "" {
+		return errors.New(errPoolDeletion)
+	}
+
+	return errors.Wrap(
+		resource.Ignore(pool.IsPoolNotFound, e.client.DeleteLoadBalancerPool(ctx, pid)),
+		errPoolDeletion,
+	)
+}
@@ -0,0 +1,608 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/benagricola/provider-cloudflare/apis/loadbalancing/v1alpha1"
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	pool "github.com/benagricola/provider-cloudflare/internal/clients/loadbalancing/pool"
+	"github.com/benagricola/provider-cloudflare/internal/clients/loadbalancing/pool/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	ptr "k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+type PoolModifier func(*v1alpha1.LoadBalancerPool)
+
+func withAccount(accountID string) PoolModifier {
+	return func(r *v1alpha1.LoadBalancerPool) { r.Spec.ForProvider.Account = &accountID }
+}
+
+func withExternalName(id string) PoolModifier {
+	return func(r *v1alpha1.LoadBalancerPool) { meta.SetExternalName(r, id) }
+}
+
+func Pool(m ...PoolModifier) *v1alpha1.LoadBalancerPool {
+	cr := &v1alpha1.LoadBalancerPool{
+		Spec: v1alpha1.LoadBalancerPoolSpec{
+			ForProvider: v1alpha1.LoadBalancerPoolParameters{
+				Name: "primary",
+				Origins: []v1alpha1.LoadBalancerOrigin{
+					{Name: "a", Address: "192.0.2.1"},
+				},
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	kube := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			switch o := obj.(type) {
+			case *pcv1alpha1.ProviderConfig:
+				o.Spec.Credentials.Source = "Secret"
+				o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+					Key: "creds",
+				}
+			case *corev1.Secret:
+				o.Data = map[string][]byte{
+					"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+				}
+			}
+			return nil
+		}),
+		MockCreate: test.NewMockCreateFn(nil),
+	}
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (pool.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err     error
+		account *string
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotPool": {
+			reason: "An error should be returned if the managed resource is not a *LoadBalancerPool",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotPool),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube: mc,
+			},
+			args: args{
+				mg: &v1alpha1.LoadBalancerPool{
+					Spec: v1alpha1.LoadBalancerPoolSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"ConnectReturnOK": {
+			reason: "Connect should return no error when passed the correct values",
+			fields: fields{
+				kube:      kube,
+				newClient: pool.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.LoadBalancerPool{
+					Spec: v1alpha1.LoadBalancerPoolSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"ConnectAccountScoped": {
+			reason: "Connect should scope the client to the account of the LoadBalancerPool",
+			fields: fields{
+				kube:      kube,
+				newClient: pool.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.LoadBalancerPool{
+					Spec: v1alpha1.LoadBalancerPoolSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+						ForProvider: v1alpha1.LoadBalancerPoolParameters{
+							Account: ptr.StringPtr("01a7362d577a6c3019a474fd6f485823"),
+						},
+					},
+				},
+			},
+			want: want{
+				err:     nil,
+				account: ptr.StringPtr("01a7362d577a6c3019a474fd6f485823"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var account *string
+			nc := func(cfg clients.Config) (pool.Client, error) {
+				account = cfg.AccountID
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.account, account); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want account, +got account:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client pool.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotPool": {
+			reason: "An error should be returned if the managed resource is not a *LoadBalancerPool",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotPool),
+			},
+		},
+		"ErrNoPool": {
+			reason: "We should return ResourceExists: false when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: Pool(),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrPoolLookup": {
+			reason: "We should return an empty observation and an error if the API returned an error",
+			fields: fields{
+				client: fake.MockClient{
+					MockLoadBalancerPoolDetails: func(ctx context.Context, id string) (cloudflare.LoadBalancerPool, error) {
+						return cloudflare.LoadBalancerPool{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: Pool(withExternalName("1234beef")),
+			},
+			want: want{
+				o:   managed.ExternalObservation{},
+				err: errors.Wrap(errBoom, errPoolLookup),
+			},
+		},
+		"ErrPoolNotFound": {
+			reason: "We should return ResourceExists: false if the LoadBalancerPool is not found (deleted on CF side)",
+			fields: fields{
+				client: fake.MockClient{
+					MockLoadBalancerPoolDetails: func(ctx context.Context, id string) (cloudflare.LoadBalancerPool, error) {
+						return cloudflare.LoadBalancerPool{}, errors.New("HTTP status 404: not found")
+					},
+				},
+			},
+			args: args{
+				mg: Pool(withExternalName("1234beef")),
+			},
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: false},
+				err: nil,
+			},
+		},
+		"Success": {
+			reason: "We should return ResourceExists: true and no error when a LoadBalancerPool is found",
+			fields: fields{
+				client: fake.MockClient{
+					MockLoadBalancerPoolDetails: func(ctx context.Context, id string) (cloudflare.LoadBalancerPool, error) {
+						o := cloudflare.LoadBalancerPool{
+							Name:    "primary",
+							Enabled: true,
+							Origins: []cloudflare.LoadBalancerOrigin{
+								{Name: "a", Address: "192.0.2.1", Enabled: true, Weight: 1},
+							},
+						}
+						o.ID = id
+						return o, nil
+					},
+				},
+			},
+			args: args{
+				mg: Pool(withExternalName("1234beef"), withAccount("01a7362d577a6c3019a474fd6f485823")),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceLateInitialized: true,
+					ResourceUpToDate:        true,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client pool.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotPool": {
+			reason: "An error should be returned if the managed resource is not a *LoadBalancerPool",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotPool),
+			},
+		},
+		"ErrPoolCreate": {
+			reason: "We should return any errors during the create process",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateLoadBalancerPool: func(ctx context.Context, o cloudflare.LoadBalancerPool) (cloudflare.LoadBalancerPool, error) {
+						return cloudflare.LoadBalancerPool{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: Pool(),
+			},
+			want: want{
+				o:   managed.ExternalCreation{},
+				err: errors.Wrap(errBoom, errPoolCreation),
+			},
+		},
+		"Success": {
+			reason: "We should return ExternalNameAssigned: true and no error when a LoadBalancerPool is created",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateLoadBalancerPool: func(ctx context.Context, o cloudflare.LoadBalancerPool) (cloudflare.LoadBalancerPool, error) {
+						o.ID = "1234beef"
+						return o, nil
+					},
+				},
+			},
+			args: args{
+				mg: Pool(),
+			},
+			want: want{
+				o: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client pool.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotPool": {
+			reason: "An error should be returned if the managed resource is not a *LoadBalancerPool",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotPool),
+			},
+		},
+		"ErrNoPool": {
+			reason: "We should return an error when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: Pool(),
+			},
+			want: want{
+				o:   managed.ExternalUpdate{},
+				err: errors.New(errPoolUpdate),
+			},
+		},
+		"ErrPoolUpdate": {
+			reason: "We should return any errors during the update process",
+			fields: fields{
+				client: fake.MockClient{
+					MockModifyLoadBalancerPool: func(ctx context.Context, o cloudflare.LoadBalancerPool) (cloudflare.LoadBalancerPool, error) {
+						return cloudflare.LoadBalancerPool{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: Pool(withExternalName("1234beef")),
+			},
+			want: want{
+				o:   managed.ExternalUpdate{},
+				err: errors.Wrap(errors.Wrap(errBoom, "error updating load balancer pool"), errPoolUpdate),
+			},
+		},
+		"Success": {
+			reason: "We should return no error when a LoadBalancerPool is updated",
+			fields: fields{
+				client: fake.MockClient{
+					MockModifyLoadBalancerPool: func(ctx context.Context, o cloudflare.LoadBalancerPool) (cloudflare.LoadBalancerPool, error) {
+						return o, nil
+					},
+				},
+			},
+			args: args{
+				mg: Pool(withExternalName("1234beef")),
+			},
+			want: want{
+				o:   managed.ExternalUpdate{},
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client pool.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotPool": {
+			reason: "An error should be returned if the managed resource is not a *LoadBalancerPool",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotPool),
+			},
+		},
+		"ErrNoPool": {
+			reason: "We should return an error when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: Pool(),
+			},
+			want: want{
+				err: errors.New(errPoolDeletion),
+			},
+		},
+		"ErrPoolDelete": {
+			reason: "We should return any errors during the delete process",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteLoadBalancerPool: func(ctx context.Context, id string) error {
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: Pool(withExternalName("1234beef")),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errPoolDeletion),
+			},
+		},
+		"Success": {
+			reason: "We should return no error when a LoadBalancerPool is deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteLoadBalancerPool: func(ctx context.Context, id string) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: Pool(withExternalName("1234beef")),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package options contains options that configure the controllers of
+// this provider.
+package options
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+)
+
+const (
+	// DefaultPollInterval is the default interval at which a managed
+	// resource is checked for drift from its desired state.
+	DefaultPollInterval = 5 * time.Minute
+
+	// DefaultMaxConcurrentReconciles is the default number of managed
+	// resources of each kind that may be reconciled at once.
+	DefaultMaxConcurrentReconciles = 5
+
+	// DefaultTimeout is the default time allowed for all the calls made
+	// to Cloudflare while reconciling a managed resource.
+	DefaultTimeout = time.Minute
+
+	// DefaultCustomHostnameZoneQPS is the default rate of calls per
+	// second that the Custom Hostname controller may make to each zone.
+	DefaultCustomHostnameZoneQPS = 2
+
+	// DefaultCustomHostnameZoneBurst is the default number of calls that
+	// the Custom Hostname controller may make to each zone in a burst.
+	DefaultCustomHostnameZoneBurst = 10
+
+	errInvalidOverride = "invalid override %q for %s"
+)
+
+// Options configure a controller.
+type Options struct {
+	// Logger used by the controller.
+	Logger logging.Logger
+
+	// GlobalRateLimiter limits the rate at which all controllers may
+	// reconcile resources.
+	GlobalRateLimiter workqueue.RateLimiter
+
+	// PollInterval at which each managed resource is checked for drift.
+	PollInterval time.Duration
+
+	// MaxConcurrentReconciles of each kind of managed resource.
+	MaxConcurrentReconciles int
+
+	// Timeout of each reconcile of a managed resource.
+	Timeout time.Duration
+
+	// CustomHostnameZoneQPS is the rate of calls per second that the
+	// Custom Hostname controller may make to each zone.
+	CustomHostnameZoneQPS float32
+
+	// CustomHostnameZoneBurst is the number of calls that the Custom
+	// Hostname controller may make to each zone in a burst.
+	CustomHostnameZoneBurst int
+
+	// WorkerScriptHosts are the hosts that the content of Worker Scripts
+	// may be downloaded from.
+	WorkerScriptHosts []string
+
+	// Overrides of these options for specific controllers, keyed by the
+	// lower case group kind of the managed resource they reconcile, e.g.
+	// zone.zone.cloudflare.crossplane.io.
+	Overrides map[string]Override
+}
+
+// An Override replaces options for a specific controller. Zero values
+// are not overridden.
+type Override struct {
+	PollInterval            time.Duration
+	MaxConcurrentReconciles int
+	Timeout                 time.Duration
+}
+
+// For returns the options of the controller that reconciles the passed
+// group kind, with any overrides applied.
+func (o Options) For(groupKind string) Options {
+	ov, ok := o.Overrides[strings.ToLower(groupKind)]
+	if !ok {
+		return o
+	}
+	if ov.PollInterval > 0 {
+		o.PollInterval = ov.PollInterval
+	}
+	if ov.MaxConcurrentReconciles > 0 {
+		o.MaxConcurrentReconciles = ov.MaxConcurrentReconciles
+	}
+	if ov.Timeout > 0 {
+		o.Timeout = ov.Timeout
+	}
+	return o
+}
+
+// ForControllerRuntime returns the controller-runtime options of a
+// controller.
+func (o Options) ForControllerRuntime() controller.Options {
+	return controller.Options{
+		RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(o.GlobalRateLimiter),
+		MaxConcurrentReconciles: o.MaxConcurrentReconciles,
+	}
+}
+
+// ParseOverrides parses per controller overrides of the poll interval,
+// max concurrent reconciles and timeout, each keyed by the group kind
+// of the managed resource reconciled by the controller.
+func ParseOverrides(poll, concurrency, timeout map[string]string) (map[string]Override, error) {
+	ov := map[string]Override{}
+
+	for gk, v := range poll {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return nil, errors.Errorf(errInvalidOverride, v, gk)
+		}
+		o := ov[strings.ToLower(gk)]
+		o.PollInterval = d
+		ov[strings.ToLower(gk)] = o
+	}
+
+	for gk, v := range concurrency {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return nil, errors.Errorf(errInvalidOverride, v, gk)
+		}
+		o := ov[strings.ToLower(gk)]
+		o.MaxConcurrentReconciles = n
+		ov[strings.ToLower(gk)] = o
+	}
+
+	for gk, v := range timeout {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return nil, errors.Errorf(errInvalidOverride, v, gk)
+		}
+		o := ov[strings.ToLower(gk)]
+		o.Timeout = d
+		ov[strings.ToLower(gk)] = o
+	}
+
+	return ov, nil
+}
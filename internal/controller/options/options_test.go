@@ -0,0 +1,136 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestFor(t *testing.T) {
+	base := Options{
+		PollInterval:            DefaultPollInterval,
+		MaxConcurrentReconciles: DefaultMaxConcurrentReconciles,
+		Timeout:                 DefaultTimeout,
+		Overrides: map[string]Override{
+			"zone.zone.cloudflare.crossplane.io": {PollInterval: 30 * time.Minute},
+		},
+	}
+
+	cases := map[string]struct {
+		reason    string
+		groupKind string
+		want      Options
+	}{
+		"NoOverride": {
+			reason:    "Options should be unchanged for a controller without overrides",
+			groupKind: "Record.dns.cloudflare.crossplane.io",
+			want:      base,
+		},
+		"Override": {
+			reason:    "Overridden options should replace the defaults, regardless of case",
+			groupKind: "Zone.zone.cloudflare.crossplane.io",
+			want: Options{
+				PollInterval:            30 * time.Minute,
+				MaxConcurrentReconciles: DefaultMaxConcurrentReconciles,
+				Timeout:                 DefaultTimeout,
+				Overrides:               base.Overrides,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := base.For(tc.groupKind)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nFor(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestParseOverrides(t *testing.T) {
+	type args struct {
+		poll        map[string]string
+		concurrency map[string]string
+		timeout     map[string]string
+	}
+
+	type want struct {
+		o   map[string]Override
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Empty": {
+			reason: "No overrides should be returned when none are passed",
+			want:   want{o: map[string]Override{}},
+		},
+		"Merged": {
+			reason: "Overrides for the same controller should be merged",
+			args: args{
+				poll:        map[string]string{"Zone.zone.cloudflare.crossplane.io": "30m"},
+				concurrency: map[string]string{"zone.zone.cloudflare.crossplane.io": "1"},
+				timeout:     map[string]string{"Record.dns.cloudflare.crossplane.io": "2m"},
+			},
+			want: want{o: map[string]Override{
+				"zone.zone.cloudflare.crossplane.io": {
+					PollInterval:            30 * time.Minute,
+					MaxConcurrentReconciles: 1,
+				},
+				"record.dns.cloudflare.crossplane.io": {
+					Timeout: 2 * time.Minute,
+				},
+			}},
+		},
+		"InvalidPoll": {
+			reason: "An error should be returned for an invalid poll interval",
+			args: args{
+				poll: map[string]string{"zone": "soon"},
+			},
+			want: want{err: errors.Errorf(errInvalidOverride, "soon", "zone")},
+		},
+		"InvalidConcurrency": {
+			reason: "An error should be returned for a non-positive concurrency",
+			args: args{
+				concurrency: map[string]string{"zone": "0"},
+			},
+			want: want{err: errors.Errorf(errInvalidOverride, "0", "zone")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseOverrides(tc.args.poll, tc.args.concurrency, tc.args.timeout)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nParseOverrides(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\nParseOverrides(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
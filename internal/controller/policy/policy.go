@@ -0,0 +1,204 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package policy restricts the operations the provider may perform on the
+// external resource of a managed resource.
+package policy
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+const (
+	// AnnotationKeyManagementPolicy may be set on any managed resource to
+	// restrict the operations the provider performs on its external
+	// resource. Its value is either a named policy or a comma separated
+	// list of the actions that are allowed, such as "Observe,Create".
+	// Resources without the annotation are fully controlled.
+	AnnotationKeyManagementPolicy = "cloudflare.crossplane.io/management-policy"
+
+	// FullControl allows the provider to create, update and delete the
+	// external resource.
+	FullControl = "FullControl"
+
+	// ObserveOnly only allows the provider to observe the external
+	// resource. It is never created, updated or deleted, making it safe to
+	// import existing objects such as a production zone.
+	ObserveOnly = "ObserveOnly"
+
+	// ActionObserve allows the provider to observe the external resource.
+	// Every policy must allow it.
+	ActionObserve = "Observe"
+
+	// ActionCreate allows the provider to create the external resource.
+	ActionCreate = "Create"
+
+	// ActionUpdate allows the provider to update the external resource.
+	ActionUpdate = "Update"
+
+	// ActionDelete allows the provider to delete the external resource.
+	ActionDelete = "Delete"
+
+	errUnknownPolicy   = "unknown management policy %q"
+	errPolicyNoObserve = "management policy %q does not allow the " + ActionObserve + " action"
+	errObserveNotFound = "external resource does not exist and cannot be created with management policy %q"
+	errCreateDenied    = "cannot create external resource with management policy %q"
+)
+
+// A Policy is the set of operations the provider may perform on an
+// external resource. The external resource may always be observed.
+type Policy struct {
+	name string
+
+	Create bool
+	Update bool
+	Delete bool
+}
+
+// Parse returns the Policy named by the supplied management policy, which
+// is either a named policy or a comma separated list of actions. Unknown
+// policies and actions are rejected rather than ignored.
+func Parse(s string) (Policy, error) {
+	switch s {
+	case "", FullControl:
+		return Policy{name: FullControl, Create: true, Update: true, Delete: true}, nil
+	case ObserveOnly:
+		return Policy{name: ObserveOnly}, nil
+	}
+
+	p := Policy{name: s}
+	observe := false
+	for _, a := range strings.Split(s, ",") {
+		switch strings.TrimSpace(a) {
+		case ActionObserve:
+			observe = true
+		case ActionCreate:
+			p.Create = true
+		case ActionUpdate:
+			p.Update = true
+		case ActionDelete:
+			p.Delete = true
+		default:
+			return Policy{}, errors.Errorf(errUnknownPolicy, s)
+		}
+	}
+
+	// The managed reconciler observes every resource before it decides
+	// whether to create, update or delete it.
+	if !observe {
+		return Policy{}, errors.Errorf(errPolicyNoObserve, s)
+	}
+
+	return p, nil
+}
+
+// Get returns the management policy of the supplied managed resource.
+func Get(mg resource.Managed) (Policy, error) {
+	return Parse(mg.GetAnnotations()[AnnotationKeyManagementPolicy])
+}
+
+// NewConnecter returns a managed.ExternalConnecter that wraps the clients
+// returned by the supplied managed.ExternalConnecter, so that they only
+// perform the operations allowed by the management policy of each managed
+// resource.
+func NewConnecter(c managed.ExternalConnecter) managed.ExternalConnecter {
+	return &connecter{ExternalConnecter: c}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{ExternalClient: ec}, nil
+}
+
+type external struct {
+	managed.ExternalClient
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	p, err := Get(mg)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// The managed reconciler keeps calling Delete until the external
+	// resource no longer exists. Report it as gone so that the finalizer is
+	// removed without touching the external resource.
+	if !p.Delete && meta.WasDeleted(mg) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	obs, err := e.ExternalClient.Observe(ctx, mg)
+	if err != nil {
+		return obs, err
+	}
+
+	if !obs.ResourceExists && !p.Create && !meta.WasDeleted(mg) {
+		return managed.ExternalObservation{}, errors.Errorf(errObserveNotFound, p.name)
+	}
+
+	// Never report drift, so that Update is not called.
+	if !p.Update {
+		obs.ResourceUpToDate = true
+	}
+	return obs, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	p, err := Get(mg)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if !p.Create {
+		return managed.ExternalCreation{}, errors.Errorf(errCreateDenied, p.name)
+	}
+	return e.ExternalClient.Create(ctx, mg)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	p, err := Get(mg)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if !p.Update {
+		return managed.ExternalUpdate{}, nil
+	}
+	return e.ExternalClient.Update(ctx, mg)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	p, err := Get(mg)
+	if err != nil {
+		return err
+	}
+	if !p.Delete {
+		return nil
+	}
+	return e.ExternalClient.Delete(ctx, mg)
+}
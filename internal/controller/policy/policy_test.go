@@ -0,0 +1,309 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+var errBoom = errors.New("boom")
+
+func withPolicy(p string) *fake.Managed {
+	mg := &fake.Managed{}
+	mg.SetAnnotations(map[string]string{AnnotationKeyManagementPolicy: p})
+	return mg
+}
+
+func deleted(mg *fake.Managed) *fake.Managed {
+	now := metav1.Now()
+	mg.SetDeletionTimestamp(&now)
+	return mg
+}
+
+func TestParse(t *testing.T) {
+	type want struct {
+		p   Policy
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		policy string
+		want   want
+	}{
+		"Unset": {
+			reason: "A resource without a policy should be fully controlled",
+			policy: "",
+			want:   want{p: Policy{name: FullControl, Create: true, Update: true, Delete: true}},
+		},
+		"FullControl": {
+			reason: "FullControl should allow every action",
+			policy: FullControl,
+			want:   want{p: Policy{name: FullControl, Create: true, Update: true, Delete: true}},
+		},
+		"ObserveOnly": {
+			reason: "ObserveOnly should only allow observing",
+			policy: ObserveOnly,
+			want:   want{p: Policy{name: ObserveOnly}},
+		},
+		"Observe": {
+			reason: "The Observe action alone should only allow observing",
+			policy: "Observe",
+			want:   want{p: Policy{name: "Observe"}},
+		},
+		"Subset": {
+			reason: "A list of actions should only allow the listed actions",
+			policy: "Observe, Create,Update",
+			want:   want{p: Policy{name: "Observe, Create,Update", Create: true, Update: true}},
+		},
+		"NoObserve": {
+			reason: "A list of actions without Observe should return an error",
+			policy: "Create,Update,Delete",
+			want:   want{err: errors.Errorf(errPolicyNoObserve, "Create,Update,Delete")},
+		},
+		"UnknownAction": {
+			reason: "A list of actions with an unknown action should return an error",
+			policy: "Observe,Orphan",
+			want:   want{err: errors.Errorf(errUnknownPolicy, "Observe,Orphan")},
+		},
+		"Empty": {
+			reason: "A list of actions with an empty action should return an error",
+			policy: "Observe,,Create",
+			want:   want{err: errors.Errorf(errUnknownPolicy, "Observe,,Create")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Parse(tc.policy)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nParse(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.p, got, cmp.AllowUnexported(Policy{})); diff != "" {
+				t.Errorf("\n%s\nParse(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	exists := func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}, nil
+	}
+	missing := func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+		return managed.ExternalObservation{}, nil
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		observe func(context.Context, resource.Managed) (managed.ExternalObservation, error)
+		mg      resource.Managed
+		want    want
+	}{
+		"FullControl": {
+			reason:  "Observations should be passed through without a policy",
+			observe: exists,
+			mg:      &fake.Managed{},
+			want:    want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+		"UnknownPolicy": {
+			reason:  "An unknown policy should return an error",
+			observe: exists,
+			mg:      withPolicy("Sometimes"),
+			want:    want{err: errors.Errorf(errUnknownPolicy, "Sometimes")},
+		},
+		"OrphanOnDelete": {
+			reason:  "Orphaning is configured with the deletion policy rather than a management policy",
+			observe: exists,
+			mg:      withPolicy("OrphanOnDelete"),
+			want:    want{err: errors.Errorf(errUnknownPolicy, "OrphanOnDelete")},
+		},
+		"ObserveOnlyUpToDate": {
+			reason:  "An existing resource should always be up to date with ObserveOnly",
+			observe: exists,
+			mg:      withPolicy(ObserveOnly),
+			want:    want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"ObserveOnlyNotFound": {
+			reason:  "A missing resource should return an error with ObserveOnly",
+			observe: missing,
+			mg:      withPolicy(ObserveOnly),
+			want:    want{err: errors.Errorf(errObserveNotFound, ObserveOnly)},
+		},
+		"ObserveOnlyError": {
+			reason: "Errors observing the resource should be returned with ObserveOnly",
+			observe: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+				return managed.ExternalObservation{}, errBoom
+			},
+			mg:   withPolicy(ObserveOnly),
+			want: want{err: errBoom},
+		},
+		"ObserveOnlyDeleted": {
+			reason:  "A deleted resource should be reported as gone with ObserveOnly",
+			observe: exists,
+			mg:      deleted(withPolicy(ObserveOnly)),
+			want:    want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"NoUpdateNotUpToDate": {
+			reason:  "An existing resource should always be up to date when it may not be updated",
+			observe: exists,
+			mg:      withPolicy("Observe,Create,Delete"),
+			want:    want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"UpdateNotUpToDate": {
+			reason:  "Drift should be reported when the resource may be updated",
+			observe: exists,
+			mg:      withPolicy("Observe,Update"),
+			want:    want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+		"NoCreateNotFound": {
+			reason:  "A missing resource should return an error when it may not be created",
+			observe: missing,
+			mg:      withPolicy("Observe,Update,Delete"),
+			want:    want{err: errors.Errorf(errObserveNotFound, "Observe,Update,Delete")},
+		},
+		"CreateNotFound": {
+			reason:  "A missing resource should be reported as missing when it may be created",
+			observe: missing,
+			mg:      withPolicy("Observe, Create"),
+			want:    want{o: managed.ExternalObservation{}},
+		},
+		"NoDeleteDeleted": {
+			reason:  "A deleted resource should be reported as gone when it may not be deleted",
+			observe: exists,
+			mg:      deleted(withPolicy("Observe,Create,Update")),
+			want:    want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"NoCreateDeletedNotFound": {
+			reason:  "A deleted resource that is missing should be reported as gone when it may not be created",
+			observe: missing,
+			mg:      deleted(withPolicy("Observe,Delete")),
+			want:    want{o: managed.ExternalObservation{}},
+		},
+		"FullControlDeleted": {
+			reason:  "A deleted resource should be observed as usual without a policy",
+			observe: exists,
+			mg:      deleted(&fake.Managed{}),
+			want:    want{o: managed.ExternalObservation{ResourceExists: true}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{ExternalClient: &managed.ExternalClientFns{ObserveFn: tc.observe}}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreateUpdateDelete(t *testing.T) {
+	type want struct {
+		createErr error
+		created   bool
+		updated   bool
+		deleted   bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		want   want
+	}{
+		"FullControl": {
+			reason: "All operations should be allowed without a policy",
+			mg:     &fake.Managed{},
+			want:   want{created: true, updated: true, deleted: true},
+		},
+		"ObserveOnly": {
+			reason: "No operations should be allowed with ObserveOnly",
+			mg:     withPolicy(ObserveOnly),
+			want:   want{createErr: errors.Errorf(errCreateDenied, ObserveOnly)},
+		},
+		"FullControlExplicit": {
+			reason: "All operations should be allowed with FullControl",
+			mg:     withPolicy(FullControl),
+			want:   want{created: true, updated: true, deleted: true},
+		},
+		"OrphanOnDelete": {
+			reason: "All operations but Delete should be allowed without the Delete action",
+			mg:     withPolicy("Observe,Create,Update"),
+			want:   want{created: true, updated: true},
+		},
+		"NoCreate": {
+			reason: "All operations but Create should be allowed without the Create action",
+			mg:     withPolicy("Observe,Update,Delete"),
+			want:   want{createErr: errors.Errorf(errCreateDenied, "Observe,Update,Delete"), updated: true, deleted: true},
+		},
+		"NoUpdate": {
+			reason: "All operations but Update should be allowed without the Update action",
+			mg:     withPolicy("Observe,Create,Delete"),
+			want:   want{created: true, deleted: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := want{}
+			e := &external{ExternalClient: &managed.ExternalClientFns{
+				CreateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+					got.created = true
+					return managed.ExternalCreation{}, nil
+				},
+				UpdateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+					got.updated = true
+					return managed.ExternalUpdate{}, nil
+				},
+				DeleteFn: func(_ context.Context, _ resource.Managed) error {
+					got.deleted = true
+					return nil
+				},
+			}}
+
+			_, got.createErr = e.Create(context.Background(), tc.mg)
+			if _, err := e.Update(context.Background(), tc.mg); err != nil {
+				t.Errorf("\n%s\ne.Update(...): unexpected error: %s", tc.reason, err)
+			}
+			if err := e.Delete(context.Background(), tc.mg); err != nil {
+				t.Errorf("\n%s\ne.Delete(...): unexpected error: %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors(), cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nCreate, Update and Delete: -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/queues/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/queues"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotQueue = "managed resource is not a Queue custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errQueueLookup   = "cannot lookup queue"
+	errQueueCreation = "cannot create queue"
+	errQueueUpdate   = "cannot update queue"
+	errQueueDeletion = "cannot delete queue"
+	errNoAccount     = "no account found"
+)
+
+// Setup adds a controller that reconciles Queue managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.QueueGroupKind)
+	o = o.For(v1alpha1.QueueGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.QueueGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (queues.Client, error) {
+				return queues.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.Queue{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (queues.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.Queue)
+	if !ok {
+		return nil, errors.New(errNotQueue)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client queues.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Queue)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotQueue)
+	}
+
+	// Queue does not exist if we dont have an ID stored in external-name
+	qid := meta.GetExternalName(cr)
+	if qid == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalObservation{}, errors.New(errNoAccount)
+	}
+
+	q, err := queues.GetQueue(ctx, e.client, *cr.Spec.ForProvider.Account, qid)
+	if err != nil {
+		return managed.ExternalObservation{},
+			errors.Wrap(resource.Ignore(queues.IsQueueNotFound, err), errQueueLookup)
+	}
+
+	cr.Status.AtProvider = queues.GenerateObservation(*q)
+
+	cr.SetConditions(rtv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: queues.LateInitialize(&cr.Spec.ForProvider, *q),
+		ResourceUpToDate:        queues.UpToDate(&cr.Spec.ForProvider, *q),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Queue)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotQueue)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalCreation{}, errors.Wrap(errors.New(errNoAccount), errQueueCreation)
+	}
+
+	name := cr.GetName()
+	if cr.Spec.ForProvider.Name != nil {
+		name = *cr.Spec.ForProvider.Name
+	}
+
+	q, err := queues.CreateQueue(ctx, e.client, *cr.Spec.ForProvider.Account, name)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errQueueCreation)
+	}
+
+	cr.Status.AtProvider = queues.GenerateObservation(*q)
+
+	// Update the external name with the ID of the new Queue
+	meta.SetExternalName(cr, q.ID)
+
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Queue)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotQueue)
+	}
+
+	qid := meta.GetExternalName(cr)
+
+	// Update should never be called on a nonexistent resource
+	if qid == "" {
+		return managed.ExternalUpdate{}, errors.New(errQueueUpdate)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalUpdate{}, errors.Wrap(errors.New(errNoAccount), errQueueUpdate)
+	}
+
+	return managed.ExternalUpdate{},
+		errors.Wrap(queues.UpdateQueue(ctx, e.client, *cr.Spec.ForProvider.Account, qid, cr.Spec.ForProvider), errQueueUpdate)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Queue)
+	if !ok {
+		return errors.New(errNotQueue)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return errors.Wrap(errors.New(errNoAccount), errQueueDeletion)
+	}
+
+	qid := meta.GetExternalName(cr)
+
+	// Delete should never be called on a nonexistent resource
+	if qid == "" {
+		return errors.New(errQueueDeletion)
+	}
+
+	return errors.Wrap(
+		resource.Ignore(queues.IsQueueNotFound,
+			queues.DeleteQueue(ctx, e.client, *cr.Spec.ForProvider.Account, qid)),
+		errQueueDeletion)
+}
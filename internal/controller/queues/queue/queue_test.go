@@ -0,0 +1,664 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/queues/v1alpha1"
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/queues"
+	"github.com/benagricola/provider-cloudflare/internal/clients/queues/fake"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const (
+	testAccount = "023e105f4ecef8ad9ca31a8372d0c353"
+	testQueueID = "df6a2b1c7b6e4d4fb5e1a8b1e0c3d2f1"
+)
+
+type queueModifier func(*v1alpha1.Queue)
+
+func withAccount(account string) queueModifier {
+	return func(q *v1alpha1.Queue) { q.Spec.ForProvider.Account = &account }
+}
+
+func withName(name string) queueModifier {
+	return func(q *v1alpha1.Queue) { q.Spec.ForProvider.Name = &name }
+}
+
+func withExternalName(id string) queueModifier {
+	return func(q *v1alpha1.Queue) { meta.SetExternalName(q, id) }
+}
+
+func withObservation(o v1alpha1.QueueObservation) queueModifier {
+	return func(q *v1alpha1.Queue) { q.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) queueModifier {
+	return func(q *v1alpha1.Queue) { q.Status.SetConditions(c...) }
+}
+
+func queueBuild(m ...queueModifier) *v1alpha1.Queue {
+	cr := &v1alpha1.Queue{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "orders",
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+var testObservation = v1alpha1.QueueObservation{
+	Producers: []v1alpha1.QueueProducer{
+		{Script: "checkout"},
+	},
+	Consumers: []v1alpha1.QueueConsumer{
+		{Script: "fulfilment", Type: "worker", BatchSize: 10, MaxRetries: 3},
+	},
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (queues.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotQueue": {
+			reason: "An error should be returned if the managed resource is not a *Queue",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotQueue),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube: mc,
+			},
+			args: args{
+				mg: &v1alpha1.Queue{
+					Spec: v1alpha1.QueueSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when a client is created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *pcv1alpha1.ProviderConfig:
+							o.Spec.Credentials.Source = "Secret"
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								Key: "creds",
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{
+								"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+							}
+						}
+						return nil
+					}),
+				},
+				newClient: queues.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.Queue{
+					Spec: v1alpha1.QueueSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (queues.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := errors.New("HTTP status 404: not found")
+
+	type fields struct {
+		client queues.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		cr  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotQueue": {
+			reason: "An error should be returned if the managed resource is not a *Queue",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotQueue),
+			},
+		},
+		"ErrNoQueue": {
+			reason: "We should return ResourceExists: false when no external name is set",
+			args: args{
+				mg: queueBuild(withAccount(testAccount)),
+			},
+			want: want{
+				cr: queueBuild(withAccount(testAccount)),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the Queue has no account",
+			args: args{
+				mg: queueBuild(withExternalName(testQueueID)),
+			},
+			want: want{
+				cr:  queueBuild(withExternalName(testQueueID)),
+				err: errors.New(errNoAccount),
+			},
+		},
+		"ErrQueueLookup": {
+			reason: "We should return an error if the Queue could not be looked up",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: queueBuild(withAccount(testAccount), withExternalName(testQueueID)),
+			},
+			want: want{
+				cr:  queueBuild(withAccount(testAccount), withExternalName(testQueueID)),
+				err: errors.Wrap(errBoom, errQueueLookup),
+			},
+		},
+		"NotFound": {
+			reason: "We should return ResourceExists: false if the Queue was deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errNotFound
+					},
+				},
+			},
+			args: args{
+				mg: queueBuild(withAccount(testAccount), withExternalName(testQueueID)),
+			},
+			want: want{
+				cr: queueBuild(withAccount(testAccount), withExternalName(testQueueID)),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"LateInitialize": {
+			reason: "We should late initialize the name of an existing Queue",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"queue_id":"df6a2b1c7b6e4d4fb5e1a8b1e0c3d2f1","queue_name":"orders","producers":[{"type":"worker","script":"checkout"}],"consumers":[{"type":"worker","script_name":"fulfilment","settings":{"batch_size":10,"max_retries":3}}]}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: queueBuild(withAccount(testAccount), withExternalName(testQueueID)),
+			},
+			want: want{
+				cr: queueBuild(
+					withAccount(testAccount),
+					withExternalName(testQueueID),
+					withName("orders"),
+					withObservation(testObservation),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceLateInitialized: true,
+					ResourceUpToDate:        true,
+				},
+			},
+		},
+		"NotUpToDate": {
+			reason: "We should return ResourceUpToDate: false when the name differs",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"queue_id":"df6a2b1c7b6e4d4fb5e1a8b1e0c3d2f1","queue_name":"orders","producers":[{"type":"worker","script":"checkout"}],"consumers":[{"type":"worker","script_name":"fulfilment","settings":{"batch_size":10,"max_retries":3}}]}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: queueBuild(withAccount(testAccount), withExternalName(testQueueID), withName("payments")),
+			},
+			want: want{
+				cr: queueBuild(
+					withAccount(testAccount),
+					withExternalName(testQueueID),
+					withName("payments"),
+					withObservation(testObservation),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want cr, +got cr:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client queues.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		cr  resource.Managed
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotQueue": {
+			reason: "An error should be returned if the managed resource is not a *Queue",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotQueue),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the Queue has no account",
+			args: args{
+				mg: queueBuild(),
+			},
+			want: want{
+				cr:  queueBuild(),
+				err: errors.Wrap(errors.New(errNoAccount), errQueueCreation),
+			},
+		},
+		"ErrQueueCreate": {
+			reason: "We should return any errors creating the Queue",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: queueBuild(withAccount(testAccount)),
+			},
+			want: want{
+				cr:  queueBuild(withAccount(testAccount)),
+				err: errors.Wrap(errors.Wrap(errBoom, "error creating queue"), errQueueCreation),
+			},
+		},
+		"Success": {
+			reason: "We should set the external name of a created Queue to its ID",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if method != http.MethodPost || endpoint != "/accounts/"+testAccount+"/queues" {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{"queue_id":"df6a2b1c7b6e4d4fb5e1a8b1e0c3d2f1","queue_name":"orders"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: queueBuild(withAccount(testAccount)),
+			},
+			want: want{
+				cr: queueBuild(
+					withAccount(testAccount),
+					withExternalName(testQueueID),
+				),
+				o: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want cr, +got cr:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client queues.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotQueue": {
+			reason: "An error should be returned if the managed resource is not a *Queue",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotQueue),
+			},
+		},
+		"ErrNoQueue": {
+			reason: "We should return an error when no external name is set",
+			args: args{
+				mg: queueBuild(withAccount(testAccount)),
+			},
+			want: want{
+				err: errors.New(errQueueUpdate),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the Queue has no account",
+			args: args{
+				mg: queueBuild(withExternalName(testQueueID)),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoAccount), errQueueUpdate),
+			},
+		},
+		"ErrQueueUpdate": {
+			reason: "We should return any errors updating the Queue",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: queueBuild(withAccount(testAccount), withExternalName(testQueueID), withName("payments")),
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errBoom, "error updating queue"), errQueueUpdate),
+			},
+		},
+		"Success": {
+			reason: "We should rename the Queue",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if method != http.MethodPut || endpoint != "/accounts/"+testAccount+"/queues/"+testQueueID {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: queueBuild(withAccount(testAccount), withExternalName(testQueueID), withName("payments")),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := errors.New("HTTP status 404: not found")
+
+	type fields struct {
+		client queues.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotQueue": {
+			reason: "An error should be returned if the managed resource is not a *Queue",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotQueue),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the Queue has no account",
+			args: args{
+				mg: queueBuild(withExternalName(testQueueID)),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoAccount), errQueueDeletion),
+			},
+		},
+		"ErrNoQueue": {
+			reason: "We should return an error when no external name is set",
+			args: args{
+				mg: queueBuild(withAccount(testAccount)),
+			},
+			want: want{
+				err: errors.New(errQueueDeletion),
+			},
+		},
+		"ErrQueueDelete": {
+			reason: "We should return any errors deleting the Queue",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: queueBuild(withAccount(testAccount), withExternalName(testQueueID)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errQueueDeletion),
+			},
+		},
+		"NotFound": {
+			reason: "We should not return an error if the Queue was already deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errNotFound
+					},
+				},
+			},
+			args: args{
+				mg: queueBuild(withAccount(testAccount), withExternalName(testQueueID)),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/r2/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/r2/bucket"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotBucket = "managed resource is not an R2Bucket custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errBucketLookup   = "cannot lookup r2 bucket"
+	errBucketCreation = "cannot create r2 bucket"
+	errBucketDeletion = "cannot delete r2 bucket"
+	errNoAccount      = "no account found"
+)
+
+// Setup adds a controller that reconciles R2Bucket managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.R2BucketGroupKind)
+	o = o.For(v1alpha1.R2BucketGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.R2BucketGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (bucket.Client, error) {
+				return bucket.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.R2Bucket{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (bucket.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.R2Bucket)
+	if !ok {
+		return nil, errors.New(errNotBucket)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client bucket.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.R2Bucket)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotBucket)
+	}
+
+	// R2Bucket does not exist if we dont have a name stored in external-name
+	name := meta.GetExternalName(cr)
+	if name == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalObservation{}, errors.New(errNoAccount)
+	}
+
+	b, err := bucket.GetBucket(ctx, e.client, *cr.Spec.ForProvider.Account, name)
+	if err != nil {
+		return managed.ExternalObservation{},
+			errors.Wrap(resource.Ignore(bucket.IsBucketNotFound, err), errBucketLookup)
+	}
+
+	cr.Status.AtProvider = bucket.GenerateObservation(*b)
+
+	cr.SetConditions(rtv1.Available())
+
+	// The name and location of an R2 Bucket cannot be changed, so it is
+	// always up to date.
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.R2Bucket)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotBucket)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalCreation{}, errors.Wrap(errors.New(errNoAccount), errBucketCreation)
+	}
+
+	name := cr.GetName()
+	if cr.Spec.ForProvider.Name != nil {
+		name = *cr.Spec.ForProvider.Name
+	}
+
+	b, err := bucket.CreateBucket(ctx, e.client, *cr.Spec.ForProvider.Account, name, cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errBucketCreation)
+	}
+
+	cr.Status.AtProvider = bucket.GenerateObservation(*b)
+
+	// R2 Buckets are identified by their name
+	meta.SetExternalName(cr, name)
+
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	_, ok := mg.(*v1alpha1.R2Bucket)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotBucket)
+	}
+
+	// R2 Buckets have no mutable fields.
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.R2Bucket)
+	if !ok {
+		return errors.New(errNotBucket)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return errors.Wrap(errors.New(errNoAccount), errBucketDeletion)
+	}
+
+	name := meta.GetExternalName(cr)
+
+	// Delete should never be called on a nonexistent resource
+	if name == "" {
+		return errors.New(errBucketDeletion)
+	}
+
+	return errors.Wrap(
+		resource.Ignore(bucket.IsBucketNotFound,
+			bucket.DeleteBucket(ctx, e.client, *cr.Spec.ForProvider.Account, name)),
+		errBucketDeletion)
+}
@@ -0,0 +1,594 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/r2/v1alpha1"
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/r2/bucket"
+	"github.com/benagricola/provider-cloudflare/internal/clients/r2/bucket/fake"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const (
+	testAccount = "023e105f4ecef8ad9ca31a8372d0c353"
+	testBucket  = "assets"
+)
+
+type bucketModifier func(*v1alpha1.R2Bucket)
+
+func withAccount(account string) bucketModifier {
+	return func(b *v1alpha1.R2Bucket) { b.Spec.ForProvider.Account = &account }
+}
+
+func withName(name string) bucketModifier {
+	return func(b *v1alpha1.R2Bucket) { b.Spec.ForProvider.Name = &name }
+}
+
+func withExternalName(name string) bucketModifier {
+	return func(b *v1alpha1.R2Bucket) { meta.SetExternalName(b, name) }
+}
+
+func withObservation(o v1alpha1.R2BucketObservation) bucketModifier {
+	return func(b *v1alpha1.R2Bucket) { b.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) bucketModifier {
+	return func(b *v1alpha1.R2Bucket) { b.Status.SetConditions(c...) }
+}
+
+func bucketBuild(m ...bucketModifier) *v1alpha1.R2Bucket {
+	cr := &v1alpha1.R2Bucket{
+		ObjectMeta: metav1.ObjectMeta{Name: testBucket},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (bucket.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotBucket": {
+			reason: "An error should be returned if the managed resource is not a *R2Bucket",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotBucket),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube: mc,
+			},
+			args: args{
+				mg: &v1alpha1.R2Bucket{
+					Spec: v1alpha1.R2BucketSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when a client is created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *pcv1alpha1.ProviderConfig:
+							o.Spec.Credentials.Source = "Secret"
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								Key: "creds",
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{
+								"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+							}
+						}
+						return nil
+					}),
+				},
+				newClient: bucket.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.R2Bucket{
+					Spec: v1alpha1.R2BucketSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (bucket.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := errors.New("HTTP status 404: not found")
+	created := metav1.NewTime(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	type fields struct {
+		client bucket.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		cr  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotBucket": {
+			reason: "An error should be returned if the managed resource is not a *R2Bucket",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotBucket),
+			},
+		},
+		"ErrNoBucket": {
+			reason: "We should return ResourceExists: false when no external name is set",
+			args: args{
+				mg: bucketBuild(withAccount(testAccount)),
+			},
+			want: want{
+				cr: bucketBuild(withAccount(testAccount)),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the R2Bucket has no account",
+			args: args{
+				mg: bucketBuild(withExternalName(testBucket)),
+			},
+			want: want{
+				cr:  bucketBuild(withExternalName(testBucket)),
+				err: errors.New(errNoAccount),
+			},
+		},
+		"ErrBucketLookup": {
+			reason: "We should return an error if the R2Bucket could not be looked up",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: bucketBuild(withAccount(testAccount), withExternalName(testBucket)),
+			},
+			want: want{
+				cr:  bucketBuild(withAccount(testAccount), withExternalName(testBucket)),
+				err: errors.Wrap(errBoom, errBucketLookup),
+			},
+		},
+		"NotFound": {
+			reason: "We should return ResourceExists: false if the R2Bucket was deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errNotFound
+					},
+				},
+			},
+			args: args{
+				mg: bucketBuild(withAccount(testAccount), withExternalName(testBucket)),
+			},
+			want: want{
+				cr: bucketBuild(withAccount(testAccount), withExternalName(testBucket)),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Exists": {
+			reason: "We should report the location of an existing R2Bucket",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if method != http.MethodGet || endpoint != "/accounts/"+testAccount+"/r2/buckets/"+testBucket {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{"name":"assets","location":"WEUR","creation_date":"2022-06-01T12:00:00Z"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: bucketBuild(withAccount(testAccount), withExternalName(testBucket)),
+			},
+			want: want{
+				cr: bucketBuild(
+					withAccount(testAccount),
+					withExternalName(testBucket),
+					withObservation(v1alpha1.R2BucketObservation{Location: "WEUR", CreationDate: &created}),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want cr, +got cr:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client bucket.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		cr  resource.Managed
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotBucket": {
+			reason: "An error should be returned if the managed resource is not a *R2Bucket",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotBucket),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the R2Bucket has no account",
+			args: args{
+				mg: bucketBuild(),
+			},
+			want: want{
+				cr:  bucketBuild(),
+				err: errors.Wrap(errors.New(errNoAccount), errBucketCreation),
+			},
+		},
+		"ErrBucketCreate": {
+			reason: "We should return any errors creating the R2Bucket",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: bucketBuild(withAccount(testAccount)),
+			},
+			want: want{
+				cr:  bucketBuild(withAccount(testAccount)),
+				err: errors.Wrap(errors.Wrap(errBoom, "error creating r2 bucket"), errBucketCreation),
+			},
+		},
+		"Success": {
+			reason: "We should create an R2Bucket named after the managed resource by default",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"name":"assets","location":"ENAM"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: bucketBuild(withAccount(testAccount)),
+			},
+			want: want{
+				cr: bucketBuild(
+					withAccount(testAccount),
+					withExternalName(testBucket),
+					withObservation(v1alpha1.R2BucketObservation{Location: "ENAM"}),
+				),
+				o: managed.ExternalCreation{ExternalNameAssigned: true},
+			},
+		},
+		"SuccessWithName": {
+			reason: "We should create an R2Bucket with the requested name",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"name":"media","location":"ENAM"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: bucketBuild(withAccount(testAccount), withName("media")),
+			},
+			want: want{
+				cr: bucketBuild(
+					withAccount(testAccount),
+					withName("media"),
+					withExternalName("media"),
+					withObservation(v1alpha1.R2BucketObservation{Location: "ENAM"}),
+				),
+				o: managed.ExternalCreation{ExternalNameAssigned: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want cr, +got cr:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ErrNotBucket": {
+			reason: "An error should be returned if the managed resource is not a *R2Bucket",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotBucket),
+			},
+		},
+		"Success": {
+			reason: "Updating an R2Bucket should be a no-op",
+			args: args{
+				mg: bucketBuild(withAccount(testAccount), withExternalName(testBucket)),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := errors.New("HTTP status 404: not found")
+
+	type fields struct {
+		client bucket.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotBucket": {
+			reason: "An error should be returned if the managed resource is not a *R2Bucket",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotBucket),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the R2Bucket has no account",
+			args: args{
+				mg: bucketBuild(withExternalName(testBucket)),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoAccount), errBucketDeletion),
+			},
+		},
+		"ErrNoBucket": {
+			reason: "We should return an error when no external name is set",
+			args: args{
+				mg: bucketBuild(withAccount(testAccount)),
+			},
+			want: want{
+				err: errors.New(errBucketDeletion),
+			},
+		},
+		"ErrBucketDelete": {
+			reason: "We should return any errors deleting the R2Bucket",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: bucketBuild(withAccount(testAccount), withExternalName(testBucket)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errBucketDeletion),
+			},
+		},
+		"NotFound": {
+			reason: "We should not return an error if the R2Bucket was already deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errNotFound
+					},
+				},
+			},
+			args: args{
+				mg: bucketBuild(withAccount(testAccount), withExternalName(testBucket)),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,652 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ruleset
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/rulesets/v1alpha1"
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/rulesets"
+	"github.com/benagricola/provider-cloudflare/internal/clients/rulesets/fake"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const (
+	testZone      = "023e105f4ecef8ad9ca31a8372d0c353"
+	testRulesetID = "2f2feab2026849078ba485f918791bdc"
+)
+
+type rulesetModifier func(*v1alpha1.Ruleset)
+
+func withZone(zone string) rulesetModifier {
+	return func(r *v1alpha1.Ruleset) { r.Spec.ForProvider.Zone = &zone }
+}
+
+func withRule(rule v1alpha1.RulesetRule) rulesetModifier {
+	return func(r *v1alpha1.Ruleset) { r.Spec.ForProvider.Rules = append(r.Spec.ForProvider.Rules, rule) }
+}
+
+func withExternalName(rulesetID string) rulesetModifier {
+	return func(r *v1alpha1.Ruleset) { meta.SetExternalName(r, rulesetID) }
+}
+
+func withObservation(o v1alpha1.RulesetObservation) rulesetModifier {
+	return func(r *v1alpha1.Ruleset) { r.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) rulesetModifier {
+	return func(r *v1alpha1.Ruleset) { r.Status.SetConditions(c...) }
+}
+
+func rulesetBuild(m ...rulesetModifier) *v1alpha1.Ruleset {
+	cr := &v1alpha1.Ruleset{
+		Spec: v1alpha1.RulesetSpec{
+			ForProvider: v1alpha1.RulesetParameters{
+				Name:  "default",
+				Phase: "http_request_firewall_custom",
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+var blockRule = v1alpha1.RulesetRule{
+	Action:     "block",
+	Expression: `ip.src eq 192.0.2.1`,
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (rulesets.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotRuleset": {
+			reason: "An error should be returned if the managed resource is not a *Ruleset",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotRuleset),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube: mc,
+			},
+			args: args{
+				mg: &v1alpha1.Ruleset{
+					Spec: v1alpha1.RulesetSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when a client is created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *pcv1alpha1.ProviderConfig:
+							o.Spec.Credentials.Source = "Secret"
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								Key: "creds",
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{
+								"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+							}
+						}
+						return nil
+					}),
+				},
+				newClient: rulesets.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.Ruleset{
+					Spec: v1alpha1.RulesetSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (rulesets.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := errors.New("HTTP status 404: not found")
+
+	type fields struct {
+		client rulesets.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		cr  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotRuleset": {
+			reason: "An error should be returned if the managed resource is not a *Ruleset",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotRuleset),
+			},
+		},
+		"ErrNoRuleset": {
+			reason: "We should return ResourceExists: false when no external name is set",
+			args: args{
+				mg: rulesetBuild(withZone(testZone)),
+			},
+			want: want{
+				cr: rulesetBuild(withZone(testZone)),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the Ruleset has no zone",
+			args: args{
+				mg: rulesetBuild(withExternalName(testRulesetID)),
+			},
+			want: want{
+				cr:  rulesetBuild(withExternalName(testRulesetID)),
+				err: errors.New(errNoZone),
+			},
+		},
+		"ErrRulesetLookup": {
+			reason: "We should return an error if the Ruleset could not be looked up",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: rulesetBuild(withZone(testZone), withExternalName(testRulesetID)),
+			},
+			want: want{
+				cr:  rulesetBuild(withZone(testZone), withExternalName(testRulesetID)),
+				err: errors.Wrap(errBoom, errRulesetLookup),
+			},
+		},
+		"NotFound": {
+			reason: "We should return ResourceExists: false if the Ruleset was deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errNotFound
+					},
+				},
+			},
+			args: args{
+				mg: rulesetBuild(withZone(testZone), withExternalName(testRulesetID)),
+			},
+			want: want{
+				cr: rulesetBuild(withZone(testZone), withExternalName(testRulesetID)),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "We should return ResourceUpToDate: true when the rules match",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"id":"2f2feab2026849078ba485f918791bdc","version":"2","rules":[{"id":"a","version":"1","action":"block","expression":"ip.src eq 192.0.2.1","enabled":true}]}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: rulesetBuild(withZone(testZone), withExternalName(testRulesetID), withRule(blockRule)),
+			},
+			want: want{
+				cr: rulesetBuild(
+					withZone(testZone),
+					withExternalName(testRulesetID),
+					withRule(blockRule),
+					withObservation(v1alpha1.RulesetObservation{Version: "2"}),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"NotUpToDate": {
+			reason: "We should return ResourceUpToDate: false when the rules differ",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"id":"2f2feab2026849078ba485f918791bdc","version":"2","rules":[{"id":"a","version":"1","action":"log","expression":"ip.src eq 192.0.2.1","enabled":true}]}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: rulesetBuild(withZone(testZone), withExternalName(testRulesetID), withRule(blockRule)),
+			},
+			want: want{
+				cr: rulesetBuild(
+					withZone(testZone),
+					withExternalName(testRulesetID),
+					withRule(blockRule),
+					withObservation(v1alpha1.RulesetObservation{Version: "2"}),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want cr, +got cr:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client rulesets.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		cr  resource.Managed
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotRuleset": {
+			reason: "An error should be returned if the managed resource is not a *Ruleset",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotRuleset),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the Ruleset has no zone",
+			args: args{
+				mg: rulesetBuild(),
+			},
+			want: want{
+				cr:  rulesetBuild(),
+				err: errors.Wrap(errors.New(errNoZone), errRulesetCreation),
+			},
+		},
+		"ErrRulesetCreate": {
+			reason: "We should return any errors creating the Ruleset",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: rulesetBuild(withZone(testZone)),
+			},
+			want: want{
+				cr:  rulesetBuild(withZone(testZone)),
+				err: errors.Wrap(errors.Wrap(errBoom, "error creating ruleset"), errRulesetCreation),
+			},
+		},
+		"Success": {
+			reason: "We should set the external name of a created Ruleset",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"id":"2f2feab2026849078ba485f918791bdc","version":"1"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: rulesetBuild(withZone(testZone), withRule(blockRule)),
+			},
+			want: want{
+				cr: rulesetBuild(
+					withZone(testZone),
+					withRule(blockRule),
+					withExternalName(testRulesetID),
+					withObservation(v1alpha1.RulesetObservation{Version: "1"}),
+				),
+				o: managed.ExternalCreation{ExternalNameAssigned: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want cr, +got cr:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client rulesets.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotRuleset": {
+			reason: "An error should be returned if the managed resource is not a *Ruleset",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotRuleset),
+			},
+		},
+		"ErrNoRuleset": {
+			reason: "We should return an error when no external name is set",
+			args: args{
+				mg: rulesetBuild(withZone(testZone)),
+			},
+			want: want{
+				err: errors.New(errRulesetUpdate),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the Ruleset has no zone",
+			args: args{
+				mg: rulesetBuild(withExternalName(testRulesetID)),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoZone), errRulesetUpdate),
+			},
+		},
+		"ErrRulesetUpdate": {
+			reason: "We should return any errors updating the Ruleset",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: rulesetBuild(withZone(testZone), withExternalName(testRulesetID)),
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errBoom, "error updating ruleset"), errRulesetUpdate),
+			},
+		},
+		"Success": {
+			reason: "We should replace the rules of the Ruleset",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if method != http.MethodPut || endpoint != "/zones/"+testZone+"/rulesets/"+testRulesetID {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: rulesetBuild(withZone(testZone), withExternalName(testRulesetID), withRule(blockRule)),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := errors.New("HTTP status 404: not found")
+
+	type fields struct {
+		client rulesets.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotRuleset": {
+			reason: "An error should be returned if the managed resource is not a *Ruleset",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotRuleset),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the Ruleset has no zone",
+			args: args{
+				mg: rulesetBuild(withExternalName(testRulesetID)),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoZone), errRulesetDeletion),
+			},
+		},
+		"ErrNoRuleset": {
+			reason: "We should return an error when no external name is set",
+			args: args{
+				mg: rulesetBuild(withZone(testZone)),
+			},
+			want: want{
+				err: errors.New(errRulesetDeletion),
+			},
+		},
+		"ErrRulesetDelete": {
+			reason: "We should return any errors deleting the Ruleset",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: rulesetBuild(withZone(testZone), withExternalName(testRulesetID)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errRulesetDeletion),
+			},
+		},
+		"NotFound": {
+			reason: "We should not return an error if the Ruleset was already deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errNotFound
+					},
+				},
+			},
+			args: args{
+				mg: rulesetBuild(withZone(testZone), withExternalName(testRulesetID)),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
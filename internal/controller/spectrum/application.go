@@ -18,19 +18,14 @@ package spectrum
 
 import (
 	"context"
-	"time"
 
 	"github.com/pkg/errors"
-	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 
 	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
-	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
-	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
@@ -39,6 +34,8 @@ import (
 	"github.com/benagricola/provider-cloudflare/apis/spectrum/v1alpha1"
 	clients "github.com/benagricola/provider-cloudflare/internal/clients"
 	applications "github.com/benagricola/provider-cloudflare/internal/clients/applications"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
 	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
 )
 
@@ -52,38 +49,33 @@ const (
 	errApplicationUpdate   = "cannot update application"
 	errApplicationDeletion = "cannot delete application"
 	errApplicationNoZone   = "no zone found"
-
-	maxConcurrency = 5
 )
 
 // Setup adds a controller that reconciles Spectrum managed resources.
-func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+func Setup(mgr ctrl.Manager, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.ApplicationGroupKind)
-
-	o := controller.Options{
-		RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
-		MaxConcurrentReconciles: maxConcurrency,
-	}
+	o = o.For(v1alpha1.ApplicationGroupKind)
 
 	hc := metrics.NewInstrumentedHTTPClient(name)
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.ApplicationGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
 			kube: mgr.GetClient(),
 			newCloudflareClientFn: func(cfg clients.Config) (applications.Client, error) {
 				return applications.NewClient(cfg, hc)
 			},
-		}),
-		managed.WithLogger(l.WithValues("controller", name)),
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
-		managed.WithPollInterval(5*time.Minute),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
 		// Do not initialize external-name field.
 		managed.WithInitializers(),
 	)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		WithOptions(o).
+		WithOptions(o.ForControllerRuntime()).
 		For(&v1alpha1.Application{}).
 		Complete(r)
 }
@@ -139,8 +131,7 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errApplicationNoZone)
 	}
 
-	application, err := e.client.SpectrumApplication(ctx, *cr.Spec.ForProvider.Zone, aid)
-
+	application, ttl, err := applications.GetSpectrumApplication(ctx, e.client, *cr.Spec.ForProvider.Zone, aid, &cr.Spec.ForProvider)
 	if err != nil {
 		if applications.IsApplicationNotFound(err) {
 			return managed.ExternalObservation{ResourceExists: false}, nil
@@ -149,13 +140,27 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}
 
 	cr.Status.AtProvider = applications.GenerateObservation(application)
+	cr.Status.AtProvider.OriginDNSTTL = ttl
+
+	// Dynamic edge IPs are assigned by Cloudflare after the application
+	// is created, and it cannot be reached until they are.
+	if applications.EdgeIPsAssigned(application) {
+		cr.SetConditions(rtv1.Available())
+	} else {
+		cr.SetConditions(rtv1.Unavailable())
+	}
 
-	cr.SetConditions(rtv1.Available())
+	// Late initialization must happen first, so that late initialized
+	// fields are compared with what they were initialized to.
+	li := applications.LateInitialize(&cr.Spec.ForProvider, application)
+	upToDate := applications.UpToDate(&cr.Spec.ForProvider, application) &&
+		applications.OriginDNSTTLUpToDate(&cr.Spec.ForProvider, ttl)
 
 	return managed.ExternalObservation{
 		ResourceExists:          true,
-		ResourceLateInitialized: applications.LateInitialize(&cr.Spec.ForProvider, application),
-		ResourceUpToDate:        applications.UpToDate(&cr.Spec.ForProvider, application),
+		ResourceLateInitialized: li,
+		ResourceUpToDate:        upToDate,
+		ConnectionDetails:       applications.ConnectionDetails(cr.Status.AtProvider),
 	}, nil
 }
 
@@ -170,6 +175,10 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 			errors.Wrap(errors.New(errApplicationNoZone), errApplicationCreation)
 	}
 
+	if err := applications.ValidateCreate(&cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errApplicationCreation)
+	}
+
 	cr.SetConditions(rtv1.Creating())
 
 	dns := cloudflare.SpectrumApplicationDNS{
@@ -249,10 +258,12 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		ap.ArgoSmartRouting = *cr.Spec.ForProvider.ArgoSmartRouting
 	}
 
-	res, err := e.client.CreateSpectrumApplication(
+	res, err := applications.CreateSpectrumApplication(
 		ctx,
+		e.client,
 		*cr.Spec.ForProvider.Zone,
 		ap,
+		cr.Spec.ForProvider.OriginDNS,
 	)
 
 	if err != nil {
@@ -284,11 +295,18 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errApplicationUpdate)
 	}
 
-	return managed.ExternalUpdate{},
-		errors.Wrap(
-			applications.UpdateSpectrumApplication(ctx, e.client, meta.GetExternalName(cr), &cr.Spec.ForProvider),
-			errApplicationUpdate,
-		)
+	res, err := applications.UpdateSpectrumApplication(ctx, e.client, meta.GetExternalName(cr), &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errApplicationUpdate)
+	}
+
+	// Edge IPs may be assigned differently after an update, such as when
+	// migrating between static and dynamic edge IPs, so refresh them.
+	cr.Status.AtProvider = applications.GenerateObservation(res)
+
+	return managed.ExternalUpdate{
+		ConnectionDetails: applications.ConnectionDetails(cr.Status.AtProvider),
+	}, nil
 }
 
 func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
@@ -18,6 +18,7 @@ package spectrum
 
 import (
 	"context"
+	"encoding/json"
 	"net"
 	"net/http"
 	"testing"
@@ -52,6 +53,8 @@ import (
 // https://github.com/golang/go/wiki/TestComments
 // https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
 
+const zoneID = "023e105f4ecef8ad9ca31a8372d0c353"
+
 type ApplicationModifier func(*v1alpha1.Application)
 
 func withEdgeIPs(edgeIPs v1alpha1.SpectrumApplicationEdgeIPs) ApplicationModifier {
@@ -265,7 +268,7 @@ func TestObserve(t *testing.T) {
 			args: args{
 				mg: Application(
 					withExternalName("1234beef"),
-					withZone("foo.com"),
+					withZone(zoneID),
 				),
 			},
 			want: want{
@@ -297,14 +300,14 @@ func TestObserve(t *testing.T) {
 			fields: fields{
 				client: fake.MockClient{
 					MockSpectrumApplication: func(ctx context.Context, zoneID string, ApplicationID string) (cloudflare.SpectrumApplication, error) {
-						return cloudflare.SpectrumApplication{}, errors.New("10006")
+						return cloudflare.SpectrumApplication{}, &cloudflare.APIRequestError{StatusCode: http.StatusBadRequest, Errors: []cloudflare.ResponseInfo{{Code: 10006, Message: "Application not found"}}}
 					},
 				},
 			},
 			args: args{
 				mg: Application(
 					withExternalName("1234beef"),
-					withZone("foo.com"),
+					withZone(zoneID),
 				),
 			},
 			want: want{
@@ -324,12 +327,13 @@ func TestObserve(t *testing.T) {
 				},
 			},
 			args: args{
-				mg: Application(withExternalName("1234beef"), withZone("foo.com")),
+				mg: Application(withExternalName("1234beef"), withZone(zoneID)),
 			},
 			want: want{
 				o: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: true,
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{"edgeIPs": []byte(""), "dnsName": []byte("")},
 				},
 				err: nil,
 			},
@@ -352,13 +356,14 @@ func TestObserve(t *testing.T) {
 				},
 			},
 			args: args{
-				mg: Application(withExternalName("1234beef"), withZone("foo.com")),
+				mg: Application(withExternalName("1234beef"), withZone(zoneID)),
 			},
 			want: want{
 				o: managed.ExternalObservation{
 					ResourceExists:          true,
 					ResourceLateInitialized: true,
 					ResourceUpToDate:        true,
+					ConnectionDetails:       managed.ConnectionDetails{"edgeIPs": []byte("1.2.3.4"), "dnsName": []byte("")},
 				},
 				err: nil,
 			},
@@ -379,6 +384,173 @@ func TestObserve(t *testing.T) {
 	}
 }
 
+func TestObserveEdgeIPsReadiness(t *testing.T) {
+	netIP := net.ParseIP("1.2.3.4")
+
+	type want struct {
+		eips      []string
+		condition xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		reason string
+		eips   *cloudflare.SpectrumApplicationEdgeIPs
+		want   want
+	}{
+		"DynamicUnassigned": {
+			reason: "An application with dynamic edge IPs should not be ready until IPs are assigned",
+			eips: &cloudflare.SpectrumApplicationEdgeIPs{
+				Type: cloudflare.SpectrumEdgeTypeDynamic,
+			},
+			want: want{
+				condition: xpv1.Unavailable(),
+			},
+		},
+		"DynamicAssigned": {
+			reason: "An application with dynamic edge IPs should be ready once IPs are assigned",
+			eips: &cloudflare.SpectrumApplicationEdgeIPs{
+				Type: cloudflare.SpectrumEdgeTypeDynamic,
+				IPs:  []net.IP{netIP},
+			},
+			want: want{
+				eips:      []string{"1.2.3.4"},
+				condition: xpv1.Available(),
+			},
+		},
+		"Static": {
+			reason: "An application with static edge IPs should be ready",
+			eips: &cloudflare.SpectrumApplicationEdgeIPs{
+				Type: cloudflare.SpectrumEdgeTypeStatic,
+				IPs:  []net.IP{netIP},
+			},
+			want: want{
+				eips:      []string{"1.2.3.4"},
+				condition: xpv1.Available(),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: fake.MockClient{
+				MockSpectrumApplication: func(ctx context.Context, zoneID, ApplicationID string) (cloudflare.SpectrumApplication, error) {
+					return cloudflare.SpectrumApplication{
+						ID:      ApplicationID,
+						EdgeIPs: tc.eips,
+					}, nil
+				},
+			}}
+
+			cr := Application(withExternalName("1234beef"), withZone(zoneID))
+			if _, err := e.Observe(context.Background(), cr); err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): unexpected error: %s\n", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.eips, cr.Status.AtProvider.EdgeIPs); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want edge IPs, +got edge IPs:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.condition, cr.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveUpToDate(t *testing.T) {
+	e := external{client: fake.MockClient{
+		MockSpectrumApplication: func(ctx context.Context, zoneID, ApplicationID string) (cloudflare.SpectrumApplication, error) {
+			return cloudflare.SpectrumApplication{
+				ID:       ApplicationID,
+				Protocol: "tcp/22",
+				EdgeIPs: &cloudflare.SpectrumApplicationEdgeIPs{
+					Type: cloudflare.SpectrumEdgeTypeStatic,
+					IPs:  []net.IP{net.ParseIP("192.0.2.2")},
+				},
+			}, nil
+		},
+	}}
+
+	cr := Application(
+		withExternalName("1234beef"),
+		withZone(zoneID),
+		withProtocol("tcp/22"),
+		withEdgeIPs(v1alpha1.SpectrumApplicationEdgeIPs{
+			Type: "static",
+			IPs:  []string{"192.0.2.2"},
+		}),
+	)
+	got, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): unexpected error: %s", err)
+	}
+	if !got.ResourceUpToDate {
+		t.Fatalf("e.Observe(...): expected the application to be up to date")
+	}
+
+	want := v1alpha1.ApplicationObservation{
+		EdgeIPs:  []string{"192.0.2.2"},
+		Protocol: "tcp/22",
+	}
+	if diff := cmp.Diff(want, cr.Status.AtProvider); diff != "" {
+		t.Errorf("e.Observe(...): an up to date application should still be observed: -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestObserveOriginDNSTTL(t *testing.T) {
+	e := external{client: fake.MockClient{
+		MockSpectrumApplication: func(ctx context.Context, zoneID, ApplicationID string) (cloudflare.SpectrumApplication, error) {
+			return cloudflare.SpectrumApplication{}, errors.New("the application should only be looked up once, including its origin DNS TTL")
+		},
+		MockRawContext: func(ctx context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+			return json.RawMessage(`{"id":"1234beef","origin_dns":{"name":"origin.example.com","ttl":900}}`), nil
+		},
+	}}
+
+	ttl := int64(600)
+	cr := Application(
+		withExternalName("1234beef"),
+		withZone(zoneID),
+		withOriginDNS(v1alpha1.SpectrumApplicationOriginDNS{Name: "origin.example.com", TTL: &ttl}),
+	)
+	got, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): unexpected error: %s", err)
+	}
+	if got.ResourceUpToDate {
+		t.Errorf("e.Observe(...): expected the application not to be up to date when its origin DNS TTL differs")
+	}
+	if diff := cmp.Diff(ptr.Int64Ptr(900), cr.Status.AtProvider.OriginDNSTTL); diff != "" {
+		t.Errorf("e.Observe(...): -want origin DNS TTL, +got origin DNS TTL:\n%s\n", diff)
+	}
+}
+
+func TestObserveDNSName(t *testing.T) {
+	dnsName := "ssh.foo.com"
+
+	e := external{client: fake.MockClient{
+		MockSpectrumApplication: func(ctx context.Context, zoneID, ApplicationID string) (cloudflare.SpectrumApplication, error) {
+			return cloudflare.SpectrumApplication{
+				ID: ApplicationID,
+				DNS: cloudflare.SpectrumApplicationDNS{
+					Type: "CNAME",
+					Name: dnsName,
+				},
+			}, nil
+		},
+	}}
+
+	cr := Application(withExternalName("1234beef"), withZone(zoneID))
+	got, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(dnsName, cr.Status.AtProvider.DNSName); diff != "" {
+		t.Errorf("e.Observe(...): -want DNS name, +got DNS name:\n%s\n", diff)
+	}
+	if diff := cmp.Diff([]byte(dnsName), got.ConnectionDetails["dnsName"]); diff != "" {
+		t.Errorf("e.Observe(...): -want DNS name connection detail, +got DNS name connection detail:\n%s\n", diff)
+	}
+}
+
 func TestCreate(t *testing.T) {
 	errBoom := errors.New("boom")
 	port := uint32(2022)
@@ -425,12 +597,14 @@ func TestCreate(t *testing.T) {
 			},
 			args: args{
 				mg: Application(
+					withProtocol("tcp/22"),
 					withExternalName("1234beef"),
-					withZone("foo.com"),
+					withZone(zoneID),
 					withTLS("full"),
 					withTrafficType("https"),
 					withEdgeIPs(v1alpha1.SpectrumApplicationEdgeIPs{
-						IPs: []string{"192.0.2.2", "2001:db8::1"},
+						Type: "static",
+						IPs:  []string{"192.0.2.2", "2001:db8::1"},
 					}),
 				),
 			},
@@ -439,6 +613,128 @@ func TestCreate(t *testing.T) {
 				err: errors.Wrap(errBoom, errApplicationCreation),
 			},
 		},
+		"ErrApplicationInvalidTrafficType": {
+			reason: "We should return an error if the traffic type is not supported",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateSpectrumApplication: func(ctx context.Context, zoneID string, appDetails cloudflare.SpectrumApplication) (cloudflare.SpectrumApplication, error) {
+						return appDetails, nil
+					},
+				},
+			},
+			args: args{
+				mg: Application(
+					withProtocol("tcp/22"),
+					withZone(zoneID),
+					withTLS("full"),
+					withTrafficType("tcp"),
+				),
+			},
+			want: want{
+				o:   managed.ExternalCreation{},
+				err: errors.Wrap(errors.New(`unsupported traffic type "tcp"`), errApplicationCreation),
+			},
+		},
+		"ErrApplicationDynamicNoConnectivity": {
+			reason: "We should return an error if dynamic edge IPs are created without connectivity",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateSpectrumApplication: func(ctx context.Context, zoneID string, appDetails cloudflare.SpectrumApplication) (cloudflare.SpectrumApplication, error) {
+						return appDetails, nil
+					},
+				},
+			},
+			args: args{
+				mg: Application(
+					withProtocol("tcp/22"),
+					withZone(zoneID),
+					withEdgeIPs(v1alpha1.SpectrumApplicationEdgeIPs{
+						Type: "dynamic",
+					}),
+				),
+			},
+			want: want{
+				o:   managed.ExternalCreation{},
+				err: errors.Wrap(errors.New("dynamic edge IPs require connectivity"), errApplicationCreation),
+			},
+		},
+		"SuccessDynamicNoIPs": {
+			reason: "We should create dynamic edge IPs without IPs, as Cloudflare assigns them",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateSpectrumApplication: func(ctx context.Context, zoneID string, appDetails cloudflare.SpectrumApplication) (cloudflare.SpectrumApplication, error) {
+						if appDetails.EdgeIPs == nil || len(appDetails.EdgeIPs.IPs) != 0 {
+							return cloudflare.SpectrumApplication{}, errBoom
+						}
+						appDetails.ID = "1234beef"
+						return appDetails, nil
+					},
+				},
+			},
+			args: args{
+				mg: Application(
+					withProtocol("tcp/22"),
+					withZone(zoneID),
+					withEdgeIPs(v1alpha1.SpectrumApplicationEdgeIPs{
+						Type:         "dynamic",
+						Connectivity: ptr.StringPtr("ipv4"),
+					}),
+				),
+			},
+			want: want{
+				o: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+				},
+				err: nil,
+			},
+		},
+		"ErrApplicationInvalidProtocol": {
+			reason: "We should return an error if the protocol scheme is not supported",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateSpectrumApplication: func(ctx context.Context, zoneID string, appDetails cloudflare.SpectrumApplication) (cloudflare.SpectrumApplication, error) {
+						return appDetails, nil
+					},
+				},
+			},
+			args: args{
+				mg: Application(
+					withProtocol("sctp/22"),
+					withZone(zoneID),
+					withTLS("full"),
+					withTrafficType("direct"),
+				),
+			},
+			want: want{
+				o:   managed.ExternalCreation{},
+				err: errors.Wrap(errors.New(`unsupported protocol scheme "sctp"`), errApplicationCreation),
+			},
+		},
+		"ErrApplicationInvalidEdgeIPsType": {
+			reason: "We should return an error if the edge IPs type is not supported",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateSpectrumApplication: func(ctx context.Context, zoneID string, appDetails cloudflare.SpectrumApplication) (cloudflare.SpectrumApplication, error) {
+						return appDetails, nil
+					},
+				},
+			},
+			args: args{
+				mg: Application(
+					withProtocol("tcp/22"),
+					withZone(zoneID),
+					withTLS("full"),
+					withTrafficType("https"),
+					withEdgeIPs(v1alpha1.SpectrumApplicationEdgeIPs{
+						Type: "anycast",
+					}),
+				),
+			},
+			want: want{
+				o:   managed.ExternalCreation{},
+				err: errors.Wrap(errors.New(`unsupported edge IPs type "anycast"`), errApplicationCreation),
+			},
+		},
 		"ErrApplicationNoZone": {
 			reason: "We should return an error if the Application does not have a zone",
 			fields: fields{
@@ -450,11 +746,13 @@ func TestCreate(t *testing.T) {
 			},
 			args: args{
 				mg: Application(
+					withProtocol("tcp/22"),
 					withExternalName("1234beef"),
 					withTLS("full"),
 					withTrafficType("https"),
 					withEdgeIPs(v1alpha1.SpectrumApplicationEdgeIPs{
-						IPs: []string{"192.0.2.2", "2001:db8::1"},
+						Type: "static",
+						IPs:  []string{"192.0.2.2", "2001:db8::1"},
 					}),
 				),
 			},
@@ -474,12 +772,14 @@ func TestCreate(t *testing.T) {
 			},
 			args: args{
 				mg: Application(
+					withProtocol("tcp/22"),
 					withExternalName("1234beef"),
-					withZone("foo.com"),
+					withZone(zoneID),
 					withTLS("full"),
 					withTrafficType("https"),
 					withEdgeIPs(v1alpha1.SpectrumApplicationEdgeIPs{
-						IPs: []string{"ImNotAnIP", "2001:db8::1"},
+						Type: "static",
+						IPs:  []string{"ImNotAnIP", "2001:db8::1"},
 					}),
 				),
 			},
@@ -500,7 +800,7 @@ func TestCreate(t *testing.T) {
 			args: args{
 				mg: Application(
 					withExternalName("1234beef"),
-					withZone("foo.com"),
+					withZone(zoneID),
 					withProtocol("tcp/22"),
 					withDNS(v1alpha1.SpectrumApplicationDNS{
 						Type: "CNAME",
@@ -536,7 +836,7 @@ func TestCreate(t *testing.T) {
 			args: args{
 				mg: Application(
 					withExternalName("1234beef"),
-					withZone("foo.com"),
+					withZone(zoneID),
 					withProtocol("tcp/22"),
 					withDNS(v1alpha1.SpectrumApplicationDNS{
 						Type: "CNAME",
@@ -573,7 +873,7 @@ func TestCreate(t *testing.T) {
 			args: args{
 				mg: Application(
 					withExternalName("1234beef"),
-					withZone("foo.com"),
+					withZone(zoneID),
 					withProtocol("tcp/22"),
 					withDNS(v1alpha1.SpectrumApplicationDNS{
 						Type: "CNAME",
@@ -608,7 +908,7 @@ func TestCreate(t *testing.T) {
 			args: args{
 				mg: Application(
 					withExternalName("1234beef"),
-					withZone("foo.com"),
+					withZone(zoneID),
 					withProtocol("tcp/22"),
 					withDNS(v1alpha1.SpectrumApplicationDNS{
 						Type: "CNAME",
@@ -642,8 +942,9 @@ func TestCreate(t *testing.T) {
 			},
 			args: args{
 				mg: Application(
+					withProtocol("tcp/22"),
 					withExternalName("1234beef"),
-					withZone("foo.com"),
+					withZone(zoneID),
 					withTLS("full"),
 					withTrafficType("https"),
 					withArgoSmartRouting(true),
@@ -719,11 +1020,13 @@ func TestUpdate(t *testing.T) {
 			},
 			args: args{
 				mg: Application(
-					withZone("foo.com"),
+					withProtocol("tcp/22"),
+					withZone(zoneID),
 					withTLS("full"),
 					withTrafficType("https"),
 					withEdgeIPs(v1alpha1.SpectrumApplicationEdgeIPs{
-						IPs: []string{"192.0.2.2", "2001:db8::1"},
+						Type: "static",
+						IPs:  []string{"192.0.2.2", "2001:db8::1"},
 					}),
 				),
 			},
@@ -743,11 +1046,13 @@ func TestUpdate(t *testing.T) {
 			},
 			args: args{
 				mg: Application(
+					withProtocol("tcp/22"),
 					withExternalName("1234beef"),
 					withTLS("full"),
 					withTrafficType("https"),
 					withEdgeIPs(v1alpha1.SpectrumApplicationEdgeIPs{
-						IPs: []string{"192.0.2.2", "2001:db8::1"},
+						Type: "static",
+						IPs:  []string{"192.0.2.2", "2001:db8::1"},
 					}),
 				),
 			},
@@ -767,12 +1072,14 @@ func TestUpdate(t *testing.T) {
 			},
 			args: args{
 				mg: Application(
+					withProtocol("tcp/22"),
 					withExternalName("1234beef"),
-					withZone("foo.com"),
+					withZone(zoneID),
 					withTLS("full"),
 					withTrafficType("https"),
 					withEdgeIPs(v1alpha1.SpectrumApplicationEdgeIPs{
-						IPs: []string{"ImNotAnIP", "2001:db8::1"},
+						Type: "static",
+						IPs:  []string{"ImNotAnIP", "2001:db8::1"},
 					}),
 				),
 			},
@@ -792,12 +1099,14 @@ func TestUpdate(t *testing.T) {
 			},
 			args: args{
 				mg: Application(
+					withProtocol("tcp/22"),
 					withExternalName("1234beef"),
-					withZone("foo.com"),
+					withZone(zoneID),
 					withTLS("full"),
 					withTrafficType("https"),
 					withEdgeIPs(v1alpha1.SpectrumApplicationEdgeIPs{
-						IPs: []string{"192.0.2.2", "2001:db8::1"},
+						Type: "static",
+						IPs:  []string{"192.0.2.2", "2001:db8::1"},
 					}),
 				),
 			},
@@ -822,17 +1131,21 @@ func TestUpdate(t *testing.T) {
 			},
 			args: args{
 				mg: Application(
+					withProtocol("tcp/22"),
 					withExternalName("1234beef"),
-					withZone("foo.com"),
+					withZone(zoneID),
 					withTLS("full"),
 					withTrafficType("https"),
 					withEdgeIPs(v1alpha1.SpectrumApplicationEdgeIPs{
-						IPs: []string{"192.0.2.2", "2001:db8::1"},
+						Type: "static",
+						IPs:  []string{"192.0.2.2", "2001:db8::1"},
 					}),
 				),
 			},
 			want: want{
-				o:   managed.ExternalUpdate{},
+				o: managed.ExternalUpdate{
+					ConnectionDetails: managed.ConnectionDetails{"edgeIPs": []byte("192.0.2.2,2001:db8::1"), "dnsName": []byte("")},
+				},
 				err: nil,
 			},
 		},
@@ -852,6 +1165,83 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestUpdateEdgeIPsMigration(t *testing.T) {
+	e := external{client: fake.MockClient{
+		MockUpdateSpectrumApplication: func(ctx context.Context, zoneID, ApplicationID string, appDetails cloudflare.SpectrumApplication) (cloudflare.SpectrumApplication, error) {
+			// Cloudflare assigns new edge IPs when migrating to dynamic
+			// edge IPs.
+			return cloudflare.SpectrumApplication{
+				ID: ApplicationID,
+				EdgeIPs: &cloudflare.SpectrumApplicationEdgeIPs{
+					Type: cloudflare.SpectrumEdgeTypeDynamic,
+					IPs:  []net.IP{net.ParseIP("198.51.100.1")},
+				},
+			}, nil
+		},
+	}}
+
+	cr := Application(
+		withProtocol("tcp/22"),
+		withExternalName("1234beef"),
+		withZone(zoneID),
+		withEdgeIPs(v1alpha1.SpectrumApplicationEdgeIPs{
+			Type: "dynamic",
+		}),
+	)
+	cr.Status.AtProvider.EdgeIPs = []string{"192.0.2.2"}
+
+	got, err := e.Update(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Update(...): unexpected error: %s", err)
+	}
+
+	want := managed.ExternalUpdate{
+		ConnectionDetails: managed.ConnectionDetails{"edgeIPs": []byte("198.51.100.1"), "dnsName": []byte("")},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("e.Update(...): -want, +got:\n%s\n", diff)
+	}
+	if diff := cmp.Diff([]string{"198.51.100.1"}, cr.Status.AtProvider.EdgeIPs); diff != "" {
+		t.Errorf("e.Update(...): -want edge IPs, +got edge IPs:\n%s\n", diff)
+	}
+}
+
+func TestUpdateTrafficTypeHTTPToHTTPS(t *testing.T) {
+	e := external{client: fake.MockClient{
+		MockUpdateSpectrumApplication: func(ctx context.Context, zoneID, ApplicationID string, appDetails cloudflare.SpectrumApplication) (cloudflare.SpectrumApplication, error) {
+			if appDetails.TrafficType != "https" || appDetails.TLS != "full" {
+				return cloudflare.SpectrumApplication{}, errors.Errorf("unexpected traffic type %q with TLS %q", appDetails.TrafficType, appDetails.TLS)
+			}
+			appDetails.ID = ApplicationID
+			return appDetails, nil
+		},
+	}}
+
+	cr := Application(
+		withProtocol("tcp/443"),
+		withExternalName("1234beef"),
+		withZone(zoneID),
+		withTrafficType("https"),
+		withTLS("full"),
+	)
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Errorf("e.Update(...): switching from http to https should be applied: %s", err)
+	}
+
+	cr = Application(
+		withProtocol("tcp/443"),
+		withExternalName("1234beef"),
+		withZone(zoneID),
+		withTrafficType("https"),
+		withTLS("off"),
+	)
+	want := errors.Wrap(errors.New("traffic type https requires TLS, but TLS is off"), errApplicationUpdate)
+	_, err := e.Update(context.Background(), cr)
+	if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Update(...): switching to https without TLS should be rejected: -want error, +got error:\n%s\n", diff)
+	}
+}
+
 func TestDelete(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -894,11 +1284,12 @@ func TestDelete(t *testing.T) {
 			},
 			args: args{
 				mg: Application(
-					withZone("foo.com"),
+					withZone(zoneID),
 					withTLS("full"),
 					withTrafficType("https"),
 					withEdgeIPs(v1alpha1.SpectrumApplicationEdgeIPs{
-						IPs: []string{"192.0.2.2", "2001:db8::1"},
+						Type: "static",
+						IPs:  []string{"192.0.2.2", "2001:db8::1"},
 					}),
 				),
 			},
@@ -918,11 +1309,12 @@ func TestDelete(t *testing.T) {
 			args: args{
 				mg: Application(
 					withExternalName("1234beef"),
-					withZone("foo.com"),
+					withZone(zoneID),
 					withTLS("full"),
 					withTrafficType("https"),
 					withEdgeIPs(v1alpha1.SpectrumApplicationEdgeIPs{
-						IPs: []string{"192.0.2.2", "2001:db8::1"},
+						Type: "static",
+						IPs:  []string{"192.0.2.2", "2001:db8::1"},
 					}),
 				),
 			},
@@ -945,7 +1337,8 @@ func TestDelete(t *testing.T) {
 					withTLS("full"),
 					withTrafficType("https"),
 					withEdgeIPs(v1alpha1.SpectrumApplicationEdgeIPs{
-						IPs: []string{"192.0.2.2", "2001:db8::1"},
+						Type: "static",
+						IPs:  []string{"192.0.2.2", "2001:db8::1"},
 					}),
 				),
 			},
@@ -965,11 +1358,12 @@ func TestDelete(t *testing.T) {
 			args: args{
 				mg: Application(
 					withExternalName("1234beef"),
-					withZone("foo.com"),
+					withZone(zoneID),
 					withTLS("full"),
 					withTrafficType("https"),
 					withEdgeIPs(v1alpha1.SpectrumApplicationEdgeIPs{
-						IPs: []string{"192.0.2.2", "2001:db8::1"},
+						Type: "static",
+						IPs:  []string{"192.0.2.2", "2001:db8::1"},
 					}),
 				),
 			},
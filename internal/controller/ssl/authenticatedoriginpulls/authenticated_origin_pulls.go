@@ -0,0 +1,178 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authenticatedoriginpulls
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/ssl/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/ssl/originpulls"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotAuthenticatedOriginPulls = "managed resource is not an AuthenticatedOriginPulls custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errAuthenticatedOriginPullsLookup   = "cannot lookup authenticated origin pulls"
+	errAuthenticatedOriginPullsCreation = "cannot enable authenticated origin pulls"
+	errAuthenticatedOriginPullsDeletion = "cannot disable authenticated origin pulls"
+	errNoZone                           = "no zone found"
+)
+
+// Setup adds a controller that reconciles AuthenticatedOriginPulls managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.AuthenticatedOriginPullsGroupKind)
+	o = o.For(v1alpha1.AuthenticatedOriginPullsGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.AuthenticatedOriginPullsGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (originpulls.Client, error) {
+				return originpulls.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.AuthenticatedOriginPulls{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (originpulls.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.AuthenticatedOriginPulls)
+	if !ok {
+		return nil, errors.New(errNotAuthenticatedOriginPulls)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client originpulls.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.AuthenticatedOriginPulls)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotAuthenticatedOriginPulls)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalObservation{}, errors.New(errNoZone)
+	}
+
+	s, err := e.client.GetPerZoneAuthenticatedOriginPullsStatus(ctx, *cr.Spec.ForProvider.Zone)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errAuthenticatedOriginPullsLookup)
+	}
+
+	// Authenticated Origin Pulls are a setting of the Zone, so they exist
+	// only while enabled.
+	if !s.Enabled {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider.Enabled = s.Enabled
+
+	cr.Status.SetConditions(rtv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.AuthenticatedOriginPulls)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotAuthenticatedOriginPulls)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalCreation{}, errors.Wrap(errors.New(errNoZone), errAuthenticatedOriginPullsCreation)
+	}
+
+	cr.SetConditions(rtv1.Creating())
+
+	return managed.ExternalCreation{},
+		errors.Wrap(originpulls.SetZoneEnabled(ctx, e.client, *cr.Spec.ForProvider.Zone, true), errAuthenticatedOriginPullsCreation)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	// Zone-level Authenticated Origin Pulls have no settings other than
+	// whether they are enabled, so there is nothing to update.
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.AuthenticatedOriginPulls)
+	if !ok {
+		return errors.New(errNotAuthenticatedOriginPulls)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return errors.Wrap(errors.New(errNoZone), errAuthenticatedOriginPullsDeletion)
+	}
+
+	return errors.Wrap(originpulls.SetZoneEnabled(ctx, e.client, *cr.Spec.ForProvider.Zone, false), errAuthenticatedOriginPullsDeletion)
+}
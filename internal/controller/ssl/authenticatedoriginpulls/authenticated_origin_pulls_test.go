@@ -0,0 +1,528 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authenticatedoriginpulls
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/benagricola/provider-cloudflare/apis/ssl/v1alpha1"
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/ssl/originpulls"
+	"github.com/benagricola/provider-cloudflare/internal/clients/ssl/originpulls/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const testZone = "023e105f4ecef8ad9ca31a8372d0c353"
+
+type aopModifier func(*v1alpha1.AuthenticatedOriginPulls)
+
+func withZone(zoneID string) aopModifier {
+	return func(r *v1alpha1.AuthenticatedOriginPulls) { r.Spec.ForProvider.Zone = &zoneID }
+}
+
+func withObservation(o v1alpha1.AuthenticatedOriginPullsObservation) aopModifier {
+	return func(r *v1alpha1.AuthenticatedOriginPulls) { r.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) aopModifier {
+	return func(r *v1alpha1.AuthenticatedOriginPulls) { r.Status.SetConditions(c...) }
+}
+
+func authenticatedOriginPulls(m ...aopModifier) *v1alpha1.AuthenticatedOriginPulls {
+	cr := &v1alpha1.AuthenticatedOriginPulls{}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (originpulls.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotAuthenticatedOriginPulls": {
+			reason: "An error should be returned if the managed resource is not an *AuthenticatedOriginPulls",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotAuthenticatedOriginPulls),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube: mc,
+			},
+			args: args{
+				mg: &v1alpha1.AuthenticatedOriginPulls{
+					Spec: v1alpha1.AuthenticatedOriginPullsSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when a client is created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *pcv1alpha1.ProviderConfig:
+							o.Spec.Credentials.Source = "Secret"
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								Key: "creds",
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{
+								"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+							}
+						}
+						return nil
+					}),
+				},
+				newClient: originpulls.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.AuthenticatedOriginPulls{
+					Spec: v1alpha1.AuthenticatedOriginPullsSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (originpulls.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client originpulls.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		cr  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotAuthenticatedOriginPulls": {
+			reason: "An error should be returned if the managed resource is not an *AuthenticatedOriginPulls",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotAuthenticatedOriginPulls),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the AuthenticatedOriginPulls has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: authenticatedOriginPulls(),
+			},
+			want: want{
+				cr:  authenticatedOriginPulls(),
+				err: errors.New(errNoZone),
+			},
+		},
+		"ErrAuthenticatedOriginPullsLookup": {
+			reason: "We should return an empty observation and an error if the API returned an error",
+			fields: fields{
+				client: fake.MockClient{
+					MockGetPerZoneAuthenticatedOriginPullsStatus: func(ctx context.Context, zoneID string) (cloudflare.PerZoneAuthenticatedOriginPullsSettings, error) {
+						return cloudflare.PerZoneAuthenticatedOriginPullsSettings{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: authenticatedOriginPulls(withZone(testZone)),
+			},
+			want: want{
+				cr:  authenticatedOriginPulls(withZone(testZone)),
+				err: errors.Wrap(errBoom, errAuthenticatedOriginPullsLookup),
+			},
+		},
+		"Disabled": {
+			reason: "We should return ResourceExists: false if Authenticated Origin Pulls are disabled on the zone",
+			fields: fields{
+				client: fake.MockClient{
+					MockGetPerZoneAuthenticatedOriginPullsStatus: func(ctx context.Context, zoneID string) (cloudflare.PerZoneAuthenticatedOriginPullsSettings, error) {
+						return cloudflare.PerZoneAuthenticatedOriginPullsSettings{Enabled: false}, nil
+					},
+				},
+			},
+			args: args{
+				mg: authenticatedOriginPulls(withZone(testZone)),
+			},
+			want: want{
+				cr: authenticatedOriginPulls(withZone(testZone)),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Enabled": {
+			reason: "We should set Available when Authenticated Origin Pulls are enabled on the zone",
+			fields: fields{
+				client: fake.MockClient{
+					MockGetPerZoneAuthenticatedOriginPullsStatus: func(ctx context.Context, zoneID string) (cloudflare.PerZoneAuthenticatedOriginPullsSettings, error) {
+						if zoneID != testZone {
+							return cloudflare.PerZoneAuthenticatedOriginPullsSettings{}, errBoom
+						}
+						return cloudflare.PerZoneAuthenticatedOriginPullsSettings{Enabled: true}, nil
+					},
+				},
+			},
+			args: args{
+				mg: authenticatedOriginPulls(withZone(testZone)),
+			},
+			want: want{
+				cr: authenticatedOriginPulls(
+					withZone(testZone),
+					withObservation(v1alpha1.AuthenticatedOriginPullsObservation{Enabled: true}),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want cr, +got cr:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client originpulls.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotAuthenticatedOriginPulls": {
+			reason: "An error should be returned if the managed resource is not an *AuthenticatedOriginPulls",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotAuthenticatedOriginPulls),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the AuthenticatedOriginPulls has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: authenticatedOriginPulls(),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoZone), errAuthenticatedOriginPullsCreation),
+			},
+		},
+		"ErrAuthenticatedOriginPullsCreate": {
+			reason: "We should return any errors while enabling Authenticated Origin Pulls",
+			fields: fields{
+				client: fake.MockClient{
+					MockSetPerZoneAuthenticatedOriginPullsStatus: func(ctx context.Context, zoneID string, enable bool) (cloudflare.PerZoneAuthenticatedOriginPullsSettings, error) {
+						return cloudflare.PerZoneAuthenticatedOriginPullsSettings{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: authenticatedOriginPulls(withZone(testZone)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errAuthenticatedOriginPullsCreation),
+			},
+		},
+		"Success": {
+			reason: "We should enable Authenticated Origin Pulls and return no error",
+			fields: fields{
+				client: fake.MockClient{
+					MockSetPerZoneAuthenticatedOriginPullsStatus: func(ctx context.Context, zoneID string, enable bool) (cloudflare.PerZoneAuthenticatedOriginPullsSettings, error) {
+						if !enable {
+							return cloudflare.PerZoneAuthenticatedOriginPullsSettings{}, errBoom
+						}
+						return cloudflare.PerZoneAuthenticatedOriginPullsSettings{Enabled: true}, nil
+					},
+				},
+			},
+			args: args{
+				mg: authenticatedOriginPulls(withZone(testZone)),
+			},
+			want: want{
+				o: managed.ExternalCreation{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Success": {
+			reason: "Updating zone-level Authenticated Origin Pulls should do nothing and return no error",
+			args: args{
+				mg: authenticatedOriginPulls(withZone(testZone)),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: fake.MockClient{}}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client originpulls.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotAuthenticatedOriginPulls": {
+			reason: "An error should be returned if the managed resource is not an *AuthenticatedOriginPulls",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotAuthenticatedOriginPulls),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the AuthenticatedOriginPulls has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: authenticatedOriginPulls(),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoZone), errAuthenticatedOriginPullsDeletion),
+			},
+		},
+		"ErrAuthenticatedOriginPullsDelete": {
+			reason: "We should return any errors while disabling Authenticated Origin Pulls",
+			fields: fields{
+				client: fake.MockClient{
+					MockSetPerZoneAuthenticatedOriginPullsStatus: func(ctx context.Context, zoneID string, enable bool) (cloudflare.PerZoneAuthenticatedOriginPullsSettings, error) {
+						return cloudflare.PerZoneAuthenticatedOriginPullsSettings{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: authenticatedOriginPulls(withZone(testZone)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errAuthenticatedOriginPullsDeletion),
+			},
+		},
+		"Success": {
+			reason: "We should disable Authenticated Origin Pulls and return no error",
+			fields: fields{
+				client: fake.MockClient{
+					MockSetPerZoneAuthenticatedOriginPullsStatus: func(ctx context.Context, zoneID string, enable bool) (cloudflare.PerZoneAuthenticatedOriginPullsSettings, error) {
+						if enable {
+							return cloudflare.PerZoneAuthenticatedOriginPullsSettings{}, errBoom
+						}
+						return cloudflare.PerZoneAuthenticatedOriginPullsSettings{Enabled: false}, nil
+					},
+				},
+			},
+			args: args{
+				mg: authenticatedOriginPulls(withZone(testZone)),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customcertificate
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/ssl/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/ssl/customcertificates"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotCustomCertificate = "managed resource is not a CustomCertificate custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errCustomCertificateLookup   = "cannot lookup custom certificate"
+	errCustomCertificateCreation = "cannot create custom certificate"
+	errCustomCertificateUpdate   = "cannot update custom certificate"
+	errCustomCertificateDeletion = "cannot delete custom certificate"
+	errCustomCertificateResolve  = "cannot resolve custom certificate"
+	errCustomCertificateKey      = "cannot resolve custom certificate private key"
+	errNoZone                    = "no zone found"
+)
+
+// Setup adds a controller that reconciles CustomCertificate managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.CustomCertificateGroupKind)
+	o = o.For(v1alpha1.CustomCertificateGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.CustomCertificateGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (customcertificates.Client, error) {
+				return customcertificates.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.CustomCertificate{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (customcertificates.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.CustomCertificate)
+	if !ok {
+		return nil, errors.New(errNotCustomCertificate)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client, kube: c.kube}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client customcertificates.Client
+	kube   client.Client
+}
+
+// options returns the options used to upload the certificate and private
+// key requested by the passed Custom Certificate.
+func (e *external) options(ctx context.Context, cr *v1alpha1.CustomCertificate) (customcertificates.Options, error) {
+	c, err := customcertificates.ResolveCertificate(ctx, e.kube, &cr.Spec.ForProvider)
+	if err != nil {
+		return customcertificates.Options{}, errors.Wrap(err, errCustomCertificateResolve)
+	}
+
+	k, err := customcertificates.ResolvePrivateKey(ctx, e.kube, &cr.Spec.ForProvider)
+	if err != nil {
+		return customcertificates.Options{}, errors.Wrap(err, errCustomCertificateKey)
+	}
+
+	return customcertificates.NewOptions(cr.Spec.ForProvider, c, k), nil
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.CustomCertificate)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotCustomCertificate)
+	}
+
+	// Custom Certificate does not exist if we dont have an ID stored in external-name
+	cid := meta.GetExternalName(cr)
+	if cid == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalObservation{}, errors.New(errNoZone)
+	}
+
+	c, err := customcertificates.CustomCertificate(ctx, e.client, *cr.Spec.ForProvider.Zone, cid)
+	if err != nil {
+		return managed.ExternalObservation{},
+			errors.Wrap(resource.Ignore(customcertificates.IsCustomCertificateNotFound, err), errCustomCertificateLookup)
+	}
+
+	// The certificate is read on every observation so that a renewed
+	// certificate is uploaded when the Secret it is read from changes.
+	certificate, err := customcertificates.ResolveCertificate(ctx, e.kube, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errCustomCertificateResolve)
+	}
+
+	cr.Status.AtProvider = customcertificates.GenerateObservation(*c)
+
+	cr.SetConditions(rtv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: customcertificates.UpToDate(&cr.Spec.ForProvider, certificate, *c),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.CustomCertificate)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotCustomCertificate)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalCreation{}, errors.Wrap(errors.New(errNoZone), errCustomCertificateCreation)
+	}
+
+	o, err := e.options(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCustomCertificateCreation)
+	}
+
+	c, err := customcertificates.CreateCustomCertificate(ctx, e.client, *cr.Spec.ForProvider.Zone, o)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCustomCertificateCreation)
+	}
+
+	// Update the external name with the ID of the new Custom Certificate
+	meta.SetExternalName(cr, c.ID)
+
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.CustomCertificate)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotCustomCertificate)
+	}
+
+	cid := meta.GetExternalName(cr)
+
+	// Update should never be called on a nonexistent resource
+	if cid == "" {
+		return managed.ExternalUpdate{}, errors.New(errCustomCertificateUpdate)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalUpdate{}, errors.Wrap(errors.New(errNoZone), errCustomCertificateUpdate)
+	}
+
+	o, err := e.options(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errCustomCertificateUpdate)
+	}
+
+	return managed.ExternalUpdate{},
+		errors.Wrap(customcertificates.UpdateCustomCertificate(ctx, e.client, *cr.Spec.ForProvider.Zone, cid, o), errCustomCertificateUpdate)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.CustomCertificate)
+	if !ok {
+		return errors.New(errNotCustomCertificate)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return errors.Wrap(errors.New(errNoZone), errCustomCertificateDeletion)
+	}
+
+	cid := meta.GetExternalName(cr)
+
+	// Delete should never be called on a nonexistent resource
+	if cid == "" {
+		return errors.New(errCustomCertificateDeletion)
+	}
+
+	return errors.Wrap(
+		resource.Ignore(customcertificates.IsCustomCertificateNotFound,
+			customcertificates.DeleteCustomCertificate(ctx, e.client, *cr.Spec.ForProvider.Zone, cid)),
+		errCustomCertificateDeletion)
+}
@@ -0,0 +1,698 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customcertificate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/benagricola/provider-cloudflare/apis/ssl/v1alpha1"
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/ssl/customcertificates"
+	"github.com/benagricola/provider-cloudflare/internal/clients/ssl/customcertificates/fake"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const (
+	testZone          = "023e105f4ecef8ad9ca31a8372d0c353"
+	testCertificateID = "2458ce5a-0c35-4c7f-82c7-8e9487d3ff60"
+)
+
+type certificateModifier func(*v1alpha1.CustomCertificate)
+
+func withZone(zone string) certificateModifier {
+	return func(c *v1alpha1.CustomCertificate) { c.Spec.ForProvider.Zone = &zone }
+}
+
+func withCertificate(certificate string) certificateModifier {
+	return func(c *v1alpha1.CustomCertificate) { c.Spec.ForProvider.Certificate = &certificate }
+}
+
+func withPrivateKeySecretRef(key string) certificateModifier {
+	return func(c *v1alpha1.CustomCertificate) {
+		c.Spec.ForProvider.PrivateKeySecretRef = xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Name: "example-com-tls", Namespace: "default"},
+			Key:             key,
+		}
+	}
+}
+
+func withExternalName(certificateID string) certificateModifier {
+	return func(c *v1alpha1.CustomCertificate) { meta.SetExternalName(c, certificateID) }
+}
+
+func certificateBuild(m ...certificateModifier) *v1alpha1.CustomCertificate {
+	cr := &v1alpha1.CustomCertificate{}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// secretKube returns a client that reads a Secret containing a private
+// key from the tls.key key.
+func secretKube() client.Client {
+	return &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.(*corev1.Secret).Data = map[string][]byte{"tls.key": []byte("key")}
+			return nil
+		}),
+	}
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (customcertificates.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotCustomCertificate": {
+			reason: "An error should be returned if the managed resource is not a *CustomCertificate",
+			fields: fields{
+				newClient: customcertificates.NewClient,
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotCustomCertificate),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube:      mc,
+				newClient: customcertificates.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.CustomCertificate{
+					Spec: v1alpha1.CustomCertificateSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when a client is created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *pcv1alpha1.ProviderConfig:
+							o.Spec.Credentials.Source = "Secret"
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								Key: "creds",
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{
+								"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+							}
+						}
+						return nil
+					}),
+				},
+				newClient: customcertificates.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.CustomCertificate{
+					Spec: v1alpha1.CustomCertificateSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (customcertificates.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := errors.New("HTTP status 404: Not Found")
+
+	type fields struct {
+		client customcertificates.Client
+		kube   client.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotCustomCertificate": {
+			reason: "An error should be returned if the managed resource is not a *CustomCertificate",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotCustomCertificate),
+			},
+		},
+		"ErrNoCustomCertificate": {
+			reason: "We should return ResourceExists: false when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: &v1alpha1.CustomCertificate{},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the Custom Certificate has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: certificateBuild(withExternalName(testCertificateID)),
+			},
+			want: want{
+				err: errors.New(errNoZone),
+			},
+		},
+		"ErrCustomCertificateLookup": {
+			reason: "We should return an empty observation and an error if the API returned an error",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: certificateBuild(withZone(testZone), withExternalName(testCertificateID)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errCustomCertificateLookup),
+			},
+		},
+		"CustomCertificateNotFound": {
+			reason: "We should return ResourceExists: false if the Custom Certificate was not found",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errNotFound
+					},
+				},
+			},
+			args: args{
+				mg: certificateBuild(withZone(testZone), withExternalName(testCertificateID)),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrCustomCertificateResolve": {
+			reason: "We should return an error if the desired certificate cannot be resolved",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"id":"2458ce5a-0c35-4c7f-82c7-8e9487d3ff60"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: certificateBuild(withZone(testZone), withExternalName(testCertificateID)),
+			},
+			want: want{
+				err: errors.Wrap(errors.New("certificate or certificate secret ref is required"), errCustomCertificateResolve),
+			},
+		},
+		"Success": {
+			reason: "We should return ResourceExists: true and no error when a Custom Certificate is found",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"id":"2458ce5a-0c35-4c7f-82c7-8e9487d3ff60","status":"active","bundle_method":"ubiquitous"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: certificateBuild(
+					withZone(testZone),
+					withCertificate("certificate"),
+					withExternalName(testCertificateID),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client, kube: tc.fields.kube}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client customcertificates.Client
+		kube   client.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotCustomCertificate": {
+			reason: "An error should be returned if the managed resource is not a *CustomCertificate",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotCustomCertificate),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the Custom Certificate has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: certificateBuild(withCertificate("certificate")),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoZone), errCustomCertificateCreation),
+			},
+		},
+		"ErrCustomCertificateKey": {
+			reason: "We should return an error if the private key cannot be read",
+			fields: fields{
+				client: fake.MockClient{},
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+			},
+			args: args{
+				mg: certificateBuild(
+					withZone(testZone),
+					withCertificate("certificate"),
+					withPrivateKeySecretRef("tls.key"),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errors.Wrap(errBoom, "cannot get Secret"), errCustomCertificateKey), errCustomCertificateCreation),
+			},
+		},
+		"ErrCustomCertificateCreate": {
+			reason: "We should return any errors during the create process",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+				kube: secretKube(),
+			},
+			args: args{
+				mg: certificateBuild(
+					withZone(testZone),
+					withCertificate("certificate"),
+					withPrivateKeySecretRef("tls.key"),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errCustomCertificateCreation),
+			},
+		},
+		"Success": {
+			reason: "We should return ExternalNameAssigned: true and no error when a Custom Certificate is created",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"id":"2458ce5a-0c35-4c7f-82c7-8e9487d3ff60","status":"pending"}`), nil
+					},
+				},
+				kube: secretKube(),
+			},
+			args: args{
+				mg: certificateBuild(
+					withZone(testZone),
+					withCertificate("certificate"),
+					withPrivateKeySecretRef("tls.key"),
+				),
+			},
+			want: want{
+				o: managed.ExternalCreation{ExternalNameAssigned: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client, kube: tc.fields.kube}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client customcertificates.Client
+		kube   client.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotCustomCertificate": {
+			reason: "An error should be returned if the managed resource is not a *CustomCertificate",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotCustomCertificate),
+			},
+		},
+		"ErrNoCustomCertificate": {
+			reason: "We should return an error when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: certificateBuild(withZone(testZone)),
+			},
+			want: want{
+				err: errors.New(errCustomCertificateUpdate),
+			},
+		},
+		"ErrCustomCertificateUpdate": {
+			reason: "We should return any errors during the update process",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+				kube: secretKube(),
+			},
+			args: args{
+				mg: certificateBuild(
+					withZone(testZone),
+					withCertificate("certificate"),
+					withPrivateKeySecretRef("tls.key"),
+					withExternalName(testCertificateID),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errCustomCertificateUpdate),
+			},
+		},
+		"Success": {
+			reason: "We should upload the certificate again and return no error when a Custom Certificate is updated",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						want := customcertificates.Options{Certificate: "certificate", PrivateKey: "key"}
+						if diff := cmp.Diff(want, data); diff != "" {
+							t.Errorf("MockRawContext(...): -want, +got:\n%s\n", diff)
+						}
+						return json.RawMessage(`{}`), nil
+					},
+				},
+				kube: secretKube(),
+			},
+			args: args{
+				mg: certificateBuild(
+					withZone(testZone),
+					withCertificate("certificate"),
+					withPrivateKeySecretRef("tls.key"),
+					withExternalName(testCertificateID),
+				),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client, kube: tc.fields.kube}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := errors.New("HTTP status 404: Not Found")
+
+	type fields struct {
+		client customcertificates.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotCustomCertificate": {
+			reason: "An error should be returned if the managed resource is not a *CustomCertificate",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotCustomCertificate),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the Custom Certificate has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: certificateBuild(withExternalName(testCertificateID)),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoZone), errCustomCertificateDeletion),
+			},
+		},
+		"ErrCustomCertificateDelete": {
+			reason: "We should return any errors during the delete process",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: certificateBuild(withZone(testZone), withExternalName(testCertificateID)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errCustomCertificateDeletion),
+			},
+		},
+		"CustomCertificateNotFound": {
+			reason: "We should return no error if the Custom Certificate was already deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errNotFound
+					},
+				},
+			},
+			args: args{
+				mg: certificateBuild(withZone(testZone), withExternalName(testCertificateID)),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"Success": {
+			reason: "We should return no error when a Custom Certificate is deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: certificateBuild(withZone(testZone), withExternalName(testCertificateID)),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostnameoriginpull
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/ssl/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/ssl/originpulls"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotHostnameOriginPull = "managed resource is not a HostnameOriginPull custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errHostnameOriginPullLookup   = "cannot lookup hostname origin pull"
+	errHostnameOriginPullCreation = "cannot create hostname origin pull"
+	errHostnameOriginPullUpdate   = "cannot update hostname origin pull"
+	errHostnameOriginPullDeletion = "cannot delete hostname origin pull"
+	errNoZone                     = "no zone found"
+	errNoCertificate              = "no certificate found"
+)
+
+// Setup adds a controller that reconciles HostnameOriginPull managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.HostnameOriginPullGroupKind)
+	o = o.For(v1alpha1.HostnameOriginPullGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.HostnameOriginPullGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (originpulls.Client, error) {
+				return originpulls.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.HostnameOriginPull{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (originpulls.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.HostnameOriginPull)
+	if !ok {
+		return nil, errors.New(errNotHostnameOriginPull)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client originpulls.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.HostnameOriginPull)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotHostnameOriginPull)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalObservation{}, errors.New(errNoZone)
+	}
+
+	h, err := e.client.GetPerHostnameAuthenticatedOriginPullsConfig(ctx, *cr.Spec.ForProvider.Zone, cr.Spec.ForProvider.Hostname)
+	if err != nil {
+		return managed.ExternalObservation{},
+			errors.Wrap(resource.Ignore(originpulls.IsNotFound, err), errHostnameOriginPullLookup)
+	}
+
+	// A hostname without a certificate uses the zone-level configuration.
+	if h.CertID == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider = originpulls.GenerateHostnameObservation(h)
+
+	// Hostname configuration is deployed asynchronously, so it is only
+	// available once it is active on the Cloudflare edge.
+	if cr.Status.AtProvider.Status == originpulls.StatusActive {
+		cr.Status.SetConditions(rtv1.Available())
+	} else {
+		cr.Status.SetConditions(rtv1.Unavailable())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: originpulls.HostnameUpToDate(&cr.Spec.ForProvider, h),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.HostnameOriginPull)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotHostnameOriginPull)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalCreation{}, errors.Wrap(errors.New(errNoZone), errHostnameOriginPullCreation)
+	}
+
+	if cr.Spec.ForProvider.Certificate == nil {
+		return managed.ExternalCreation{}, errors.Wrap(errors.New(errNoCertificate), errHostnameOriginPullCreation)
+	}
+
+	cr.SetConditions(rtv1.Creating())
+
+	return managed.ExternalCreation{},
+		errors.Wrap(originpulls.UpdateHostnameConfig(ctx, e.client, *cr.Spec.ForProvider.Zone, cr.Spec.ForProvider), errHostnameOriginPullCreation)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.HostnameOriginPull)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotHostnameOriginPull)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalUpdate{}, errors.Wrap(errors.New(errNoZone), errHostnameOriginPullUpdate)
+	}
+
+	return managed.ExternalUpdate{},
+		errors.Wrap(originpulls.UpdateHostnameConfig(ctx, e.client, *cr.Spec.ForProvider.Zone, cr.Spec.ForProvider), errHostnameOriginPullUpdate)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.HostnameOriginPull)
+	if !ok {
+		return errors.New(errNotHostnameOriginPull)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return errors.Wrap(errors.New(errNoZone), errHostnameOriginPullDeletion)
+	}
+
+	cid := ""
+	if cr.Spec.ForProvider.Certificate != nil {
+		cid = *cr.Spec.ForProvider.Certificate
+	}
+
+	return errors.Wrap(
+		resource.Ignore(originpulls.IsNotFound,
+			originpulls.DeleteHostnameConfig(ctx, e.client, *cr.Spec.ForProvider.Zone, cr.Spec.ForProvider.Hostname, cid)),
+		errHostnameOriginPullDeletion)
+}
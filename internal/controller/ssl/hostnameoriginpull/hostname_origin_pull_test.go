@@ -0,0 +1,648 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostnameoriginpull
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/benagricola/provider-cloudflare/apis/ssl/v1alpha1"
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/ssl/originpulls"
+	"github.com/benagricola/provider-cloudflare/internal/clients/ssl/originpulls/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const (
+	testZone          = "023e105f4ecef8ad9ca31a8372d0c353"
+	testHostname      = "app.example.com"
+	testCertificateID = "2458ce5a-0c35-4c7f-82c7-8e9487d3ff60"
+)
+
+type hostnameModifier func(*v1alpha1.HostnameOriginPull)
+
+func withZone(zoneID string) hostnameModifier {
+	return func(r *v1alpha1.HostnameOriginPull) { r.Spec.ForProvider.Zone = &zoneID }
+}
+
+func withCertificate(certificateID string) hostnameModifier {
+	return func(r *v1alpha1.HostnameOriginPull) { r.Spec.ForProvider.Certificate = &certificateID }
+}
+
+func withObservation(o v1alpha1.HostnameOriginPullObservation) hostnameModifier {
+	return func(r *v1alpha1.HostnameOriginPull) { r.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) hostnameModifier {
+	return func(r *v1alpha1.HostnameOriginPull) { r.Status.SetConditions(c...) }
+}
+
+func hostnameOriginPull(m ...hostnameModifier) *v1alpha1.HostnameOriginPull {
+	cr := &v1alpha1.HostnameOriginPull{
+		Spec: v1alpha1.HostnameOriginPullSpec{
+			ForProvider: v1alpha1.HostnameOriginPullParameters{
+				Hostname: testHostname,
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (originpulls.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotHostnameOriginPull": {
+			reason: "An error should be returned if the managed resource is not a *HostnameOriginPull",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotHostnameOriginPull),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube: mc,
+			},
+			args: args{
+				mg: &v1alpha1.HostnameOriginPull{
+					Spec: v1alpha1.HostnameOriginPullSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when a client is created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *pcv1alpha1.ProviderConfig:
+							o.Spec.Credentials.Source = "Secret"
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								Key: "creds",
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{
+								"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+							}
+						}
+						return nil
+					}),
+				},
+				newClient: originpulls.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.HostnameOriginPull{
+					Spec: v1alpha1.HostnameOriginPullSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (originpulls.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client originpulls.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		cr  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotHostnameOriginPull": {
+			reason: "An error should be returned if the managed resource is not a *HostnameOriginPull",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotHostnameOriginPull),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the HostnameOriginPull has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: hostnameOriginPull(),
+			},
+			want: want{
+				cr:  hostnameOriginPull(),
+				err: errors.New(errNoZone),
+			},
+		},
+		"ErrHostnameOriginPullLookup": {
+			reason: "We should return an empty observation and an error if the API returned an error",
+			fields: fields{
+				client: fake.MockClient{
+					MockGetPerHostnameAuthenticatedOriginPullsConfig: func(ctx context.Context, zoneID, hostname string) (cloudflare.PerHostnameAuthenticatedOriginPullsDetails, error) {
+						return cloudflare.PerHostnameAuthenticatedOriginPullsDetails{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: hostnameOriginPull(withZone(testZone)),
+			},
+			want: want{
+				cr:  hostnameOriginPull(withZone(testZone)),
+				err: errors.Wrap(errBoom, errHostnameOriginPullLookup),
+			},
+		},
+		"NotFound": {
+			reason: "We should return ResourceExists: false if the hostname has no configuration",
+			fields: fields{
+				client: fake.MockClient{
+					MockGetPerHostnameAuthenticatedOriginPullsConfig: func(ctx context.Context, zoneID, hostname string) (cloudflare.PerHostnameAuthenticatedOriginPullsDetails, error) {
+						return cloudflare.PerHostnameAuthenticatedOriginPullsDetails{}, errors.New("HTTP status 404: Not Found")
+					},
+				},
+			},
+			args: args{
+				mg: hostnameOriginPull(withZone(testZone)),
+			},
+			want: want{
+				cr: hostnameOriginPull(withZone(testZone)),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"NoCertificate": {
+			reason: "We should return ResourceExists: false if no certificate is presented for the hostname",
+			fields: fields{
+				client: fake.MockClient{
+					MockGetPerHostnameAuthenticatedOriginPullsConfig: func(ctx context.Context, zoneID, hostname string) (cloudflare.PerHostnameAuthenticatedOriginPullsDetails, error) {
+						return cloudflare.PerHostnameAuthenticatedOriginPullsDetails{Hostname: "app.example.com"}, nil
+					},
+				},
+			},
+			args: args{
+				mg: hostnameOriginPull(withZone(testZone)),
+			},
+			want: want{
+				cr: hostnameOriginPull(withZone(testZone)),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Pending": {
+			reason: "We should not set Available while the hostname configuration is being deployed",
+			fields: fields{
+				client: fake.MockClient{
+					MockGetPerHostnameAuthenticatedOriginPullsConfig: func(ctx context.Context, zoneID, hostname string) (cloudflare.PerHostnameAuthenticatedOriginPullsDetails, error) {
+						return cloudflare.PerHostnameAuthenticatedOriginPullsDetails{Hostname: "app.example.com", CertID: testCertificateID, Enabled: true, Status: "pending_deployment", CertStatus: "active"}, nil
+					},
+				},
+			},
+			args: args{
+				mg: hostnameOriginPull(withZone(testZone), withCertificate(testCertificateID)),
+			},
+			want: want{
+				cr: hostnameOriginPull(
+					withZone(testZone),
+					withCertificate(testCertificateID),
+					withObservation(v1alpha1.HostnameOriginPullObservation{Status: "pending_deployment", CertificateStatus: "active"}),
+					withConditions(xpv1.Unavailable()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"Active": {
+			reason: "We should set Available once the hostname configuration is active",
+			fields: fields{
+				client: fake.MockClient{
+					MockGetPerHostnameAuthenticatedOriginPullsConfig: func(ctx context.Context, zoneID, hostname string) (cloudflare.PerHostnameAuthenticatedOriginPullsDetails, error) {
+						if zoneID != testZone || hostname != testHostname {
+							return cloudflare.PerHostnameAuthenticatedOriginPullsDetails{}, errBoom
+						}
+						return cloudflare.PerHostnameAuthenticatedOriginPullsDetails{Hostname: "app.example.com", CertID: testCertificateID, Enabled: true, Status: "active", CertStatus: "active"}, nil
+					},
+				},
+			},
+			args: args{
+				mg: hostnameOriginPull(withZone(testZone), withCertificate("5a7805061c76ada191ed06f989cc3dac")),
+			},
+			want: want{
+				cr: hostnameOriginPull(
+					withZone(testZone),
+					withCertificate("5a7805061c76ada191ed06f989cc3dac"),
+					withObservation(v1alpha1.HostnameOriginPullObservation{Status: "active", CertificateStatus: "active"}),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want cr, +got cr:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client originpulls.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotHostnameOriginPull": {
+			reason: "An error should be returned if the managed resource is not a *HostnameOriginPull",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotHostnameOriginPull),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the HostnameOriginPull has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: hostnameOriginPull(),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoZone), errHostnameOriginPullCreation),
+			},
+		},
+		"ErrHostnameOriginPullCreate": {
+			reason: "We should return any errors while configuring the hostname",
+			fields: fields{
+				client: fake.MockClient{
+					MockEditPerHostnameAuthenticatedOriginPullsConfig: func(ctx context.Context, zoneID string, config []cloudflare.PerHostnameAuthenticatedOriginPullsConfig) ([]cloudflare.PerHostnameAuthenticatedOriginPullsDetails, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: hostnameOriginPull(withZone(testZone), withCertificate(testCertificateID)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errHostnameOriginPullCreation),
+			},
+		},
+		"ErrNoCertificate": {
+			reason: "We should return an error if the HostnameOriginPull has no certificate",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: hostnameOriginPull(withZone(testZone)),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoCertificate), errHostnameOriginPullCreation),
+			},
+		},
+		"Success": {
+			reason: "We should present the requested certificate for the hostname and return no error",
+			fields: fields{
+				client: fake.MockClient{
+					MockEditPerHostnameAuthenticatedOriginPullsConfig: func(ctx context.Context, zoneID string, config []cloudflare.PerHostnameAuthenticatedOriginPullsConfig) ([]cloudflare.PerHostnameAuthenticatedOriginPullsDetails, error) {
+						if zoneID != testZone || len(config) != 1 || config[0].CertID != testCertificateID {
+							return nil, errBoom
+						}
+						return nil, nil
+					},
+				},
+			},
+			args: args{
+				mg: hostnameOriginPull(withZone(testZone), withCertificate(testCertificateID)),
+			},
+			want: want{
+				o: managed.ExternalCreation{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client originpulls.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotHostnameOriginPull": {
+			reason: "An error should be returned if the managed resource is not a *HostnameOriginPull",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotHostnameOriginPull),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the HostnameOriginPull has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: hostnameOriginPull(),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoZone), errHostnameOriginPullUpdate),
+			},
+		},
+		"ErrHostnameOriginPullUpdate": {
+			reason: "We should return any errors while configuring the hostname",
+			fields: fields{
+				client: fake.MockClient{
+					MockEditPerHostnameAuthenticatedOriginPullsConfig: func(ctx context.Context, zoneID string, config []cloudflare.PerHostnameAuthenticatedOriginPullsConfig) ([]cloudflare.PerHostnameAuthenticatedOriginPullsDetails, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: hostnameOriginPull(withZone(testZone), withCertificate(testCertificateID)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errHostnameOriginPullUpdate),
+			},
+		},
+		"Success": {
+			reason: "We should present the requested certificate for the hostname and return no error",
+			fields: fields{
+				client: fake.MockClient{
+					MockEditPerHostnameAuthenticatedOriginPullsConfig: func(ctx context.Context, zoneID string, config []cloudflare.PerHostnameAuthenticatedOriginPullsConfig) ([]cloudflare.PerHostnameAuthenticatedOriginPullsDetails, error) {
+						return nil, nil
+					},
+				},
+			},
+			args: args{
+				mg: hostnameOriginPull(withZone(testZone), withCertificate(testCertificateID)),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client originpulls.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotHostnameOriginPull": {
+			reason: "An error should be returned if the managed resource is not a *HostnameOriginPull",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotHostnameOriginPull),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the HostnameOriginPull has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: hostnameOriginPull(),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoZone), errHostnameOriginPullDeletion),
+			},
+		},
+		"ErrHostnameOriginPullDelete": {
+			reason: "We should return any errors while removing the hostname configuration",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: hostnameOriginPull(withZone(testZone)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errHostnameOriginPullDeletion),
+			},
+		},
+		"Success": {
+			reason: "We should remove the hostname configuration and return no error",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						want := `{"config":[{"hostname":"` + testHostname + `","cert_id":"` + testCertificateID + `","enabled":null}]}`
+						b, _ := json.Marshal(data)
+						if method != http.MethodPut || endpoint != "/zones/"+testZone+"/origin_tls_client_auth/hostnames" || string(b) != want {
+							return nil, errBoom
+						}
+						return json.RawMessage(`[]`), nil
+					},
+				},
+			},
+			args: args{
+				mg: hostnameOriginPull(withZone(testZone), withCertificate(testCertificateID)),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
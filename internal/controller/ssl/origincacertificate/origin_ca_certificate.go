@@ -0,0 +1,200 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package origincacertificate
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/ssl/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/ssl/origincacertificates"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotOriginCACertificate = "managed resource is not an OriginCACertificate custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errOriginCACertificateLookup   = "cannot lookup origin ca certificate"
+	errOriginCACertificateCreation = "cannot create origin ca certificate"
+	errOriginCACertificateDeletion = "cannot delete origin ca certificate"
+)
+
+// Setup adds a controller that reconciles OriginCACertificate managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.OriginCACertificateGroupKind)
+	o = o.For(v1alpha1.OriginCACertificateGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.OriginCACertificateGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (origincacertificates.Client, error) {
+				return origincacertificates.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.OriginCACertificate{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (origincacertificates.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.OriginCACertificate)
+	if !ok {
+		return nil, errors.New(errNotOriginCACertificate)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client origincacertificates.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.OriginCACertificate)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotOriginCACertificate)
+	}
+
+	// Origin CA Certificate does not exist if we dont have an ID stored in external-name
+	cid := meta.GetExternalName(cr)
+	if cid == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	c, err := origincacertificates.OriginCACertificate(ctx, e.client, cid)
+	if err != nil {
+		return managed.ExternalObservation{},
+			errors.Wrap(resource.Ignore(origincacertificates.IsOriginCACertificateNotFound, err), errOriginCACertificateLookup)
+	}
+
+	// Revoked certificates are still returned by the API, and are issued
+	// again.
+	if c.RevokedAt != "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider = origincacertificates.GenerateObservation(*c)
+
+	cr.SetConditions(rtv1.Available())
+
+	// The settings of an Origin CA Certificate cannot be changed, so it
+	// is always up to date.
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  true,
+		ConnectionDetails: origincacertificates.ConnectionDetails(*c, nil),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.OriginCACertificate)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotOriginCACertificate)
+	}
+
+	c, cd, err := origincacertificates.CreateOriginCACertificate(ctx, e.client, cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errOriginCACertificateCreation)
+	}
+
+	cr.Status.AtProvider = origincacertificates.GenerateObservation(*c)
+
+	// Update the external name with the ID of the new Origin CA Certificate
+	meta.SetExternalName(cr, c.ID)
+
+	return managed.ExternalCreation{
+		ExternalNameAssigned: true,
+		ConnectionDetails:    cd,
+	}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	_, ok := mg.(*v1alpha1.OriginCACertificate)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotOriginCACertificate)
+	}
+
+	// Origin CA Certificates have no mutable fields.
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.OriginCACertificate)
+	if !ok {
+		return errors.New(errNotOriginCACertificate)
+	}
+
+	cid := meta.GetExternalName(cr)
+
+	// Delete should never be called on a nonexistent resource
+	if cid == "" {
+		return errors.New(errOriginCACertificateDeletion)
+	}
+
+	return errors.Wrap(
+		resource.Ignore(origincacertificates.IsOriginCACertificateNotFound,
+			origincacertificates.RevokeOriginCACertificate(ctx, e.client, cid)),
+		errOriginCACertificateDeletion)
+}
@@ -0,0 +1,516 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package origincacertificate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/benagricola/provider-cloudflare/apis/ssl/v1alpha1"
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/ssl/origincacertificates"
+	"github.com/benagricola/provider-cloudflare/internal/clients/ssl/origincacertificates/fake"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const testCertificateID = "328578533902268680212849205732770752308931942346"
+
+type certificateModifier func(*v1alpha1.OriginCACertificate)
+
+func withHostnames(hostnames ...string) certificateModifier {
+	return func(c *v1alpha1.OriginCACertificate) { c.Spec.ForProvider.Hostnames = hostnames }
+}
+
+func withExternalName(certificateID string) certificateModifier {
+	return func(c *v1alpha1.OriginCACertificate) { meta.SetExternalName(c, certificateID) }
+}
+
+func certificateBuild(m ...certificateModifier) *v1alpha1.OriginCACertificate {
+	cr := &v1alpha1.OriginCACertificate{}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (origincacertificates.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotOriginCACertificate": {
+			reason: "An error should be returned if the managed resource is not an *OriginCACertificate",
+			fields: fields{
+				newClient: origincacertificates.NewClient,
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotOriginCACertificate),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube:      mc,
+				newClient: origincacertificates.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.OriginCACertificate{
+					Spec: v1alpha1.OriginCACertificateSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when a client is created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *pcv1alpha1.ProviderConfig:
+							o.Spec.Credentials.Source = "Secret"
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								Key: "creds",
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{
+								"creds": []byte("{\"Token\":\"foo\"}"),
+							}
+						}
+						return nil
+					}),
+				},
+				newClient: origincacertificates.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.OriginCACertificate{
+					Spec: v1alpha1.OriginCACertificateSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (origincacertificates.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := errors.New("HTTP status 404: Not Found")
+
+	type fields struct {
+		client origincacertificates.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotOriginCACertificate": {
+			reason: "An error should be returned if the managed resource is not an *OriginCACertificate",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotOriginCACertificate),
+			},
+		},
+		"ErrNoOriginCACertificate": {
+			reason: "We should return ResourceExists: false when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: &v1alpha1.OriginCACertificate{},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrOriginCACertificateLookup": {
+			reason: "We should return an empty observation and an error if the API returned an error",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: certificateBuild(withExternalName(testCertificateID)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errOriginCACertificateLookup),
+			},
+		},
+		"OriginCACertificateNotFound": {
+			reason: "We should return ResourceExists: false if the Origin CA Certificate was not found",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errNotFound
+					},
+				},
+			},
+			args: args{
+				mg: certificateBuild(withExternalName(testCertificateID)),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"OriginCACertificateRevoked": {
+			reason: "We should return ResourceExists: false if the Origin CA Certificate was revoked",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"id":"328578533902268680212849205732770752308931942346","revoked_at":"2024-09-06 18:43:47 +0000 UTC"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: certificateBuild(withExternalName(testCertificateID)),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Success": {
+			reason: "We should return the certificate as a connection detail when an Origin CA Certificate is found",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"id":"328578533902268680212849205732770752308931942346","certificate":"certificate"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: certificateBuild(withHostnames("example.com"), withExternalName(testCertificateID)),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						origincacertificates.ConnectionKeyCertificate: []byte("certificate"),
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client origincacertificates.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		externalName string
+		keys         []string
+		err          error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotOriginCACertificate": {
+			reason: "An error should be returned if the managed resource is not an *OriginCACertificate",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotOriginCACertificate),
+			},
+		},
+		"ErrOriginCACertificateCreate": {
+			reason: "We should return any errors during the create process",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: certificateBuild(withHostnames("example.com")),
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errBoom, "error creating origin ca certificate"), errOriginCACertificateCreation),
+			},
+		},
+		"Success": {
+			reason: "We should write the certificate and private key to the connection secret when an Origin CA Certificate is created",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"id":"328578533902268680212849205732770752308931942346","certificate":"certificate"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: certificateBuild(withHostnames("example.com")),
+			},
+			want: want{
+				externalName: testCertificateID,
+				keys: []string{
+					origincacertificates.ConnectionKeyCertificate,
+					origincacertificates.ConnectionKeyPrivateKey,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.externalName == "" {
+				return
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(tc.args.mg)); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want external name, +got external name:\n%s\n", tc.reason, diff)
+			}
+			for _, k := range tc.want.keys {
+				if len(got.ConnectionDetails[k]) == 0 {
+					t.Errorf("\n%s\ne.Create(...): missing connection detail %q", tc.reason, k)
+				}
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := errors.New("HTTP status 404: Not Found")
+
+	type fields struct {
+		client origincacertificates.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotOriginCACertificate": {
+			reason: "An error should be returned if the managed resource is not an *OriginCACertificate",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotOriginCACertificate),
+			},
+		},
+		"ErrNoOriginCACertificate": {
+			reason: "We should return an error when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: certificateBuild(),
+			},
+			want: want{
+				err: errors.New(errOriginCACertificateDeletion),
+			},
+		},
+		"ErrOriginCACertificateDelete": {
+			reason: "We should return any errors during the delete process",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: certificateBuild(withExternalName(testCertificateID)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errOriginCACertificateDeletion),
+			},
+		},
+		"OriginCACertificateNotFound": {
+			reason: "We should return no error if the Origin CA Certificate no longer exists",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errNotFound
+					},
+				},
+			},
+			args: args{
+				mg: certificateBuild(withExternalName(testCertificateID)),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"Success": {
+			reason: "We should return no error when an Origin CA Certificate is revoked",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"id":"328578533902268680212849205732770752308931942346"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: certificateBuild(withExternalName(testCertificateID)),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package originpullcertificate
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/ssl/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/ssl/originpulls"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotOriginPullCertificate = "managed resource is not an OriginPullCertificate custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errOriginPullCertificateLookup   = "cannot lookup origin pull certificate"
+	errOriginPullCertificateCreation = "cannot create origin pull certificate"
+	errOriginPullCertificateUpdate   = "cannot update origin pull certificate"
+	errOriginPullCertificateDeletion = "cannot delete origin pull certificate"
+	errOriginPullCertificateResolve  = "cannot resolve origin pull certificate"
+	errOriginPullCertificatePersist  = "cannot persist replaced origin pull certificate"
+	errNoZone                        = "no zone found"
+)
+
+// Setup adds a controller that reconciles OriginPullCertificate managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.OriginPullCertificateGroupKind)
+	o = o.For(v1alpha1.OriginPullCertificateGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.OriginPullCertificateGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (originpulls.Client, error) {
+				return originpulls.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.OriginPullCertificate{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (originpulls.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.OriginPullCertificate)
+	if !ok {
+		return nil, errors.New(errNotOriginPullCertificate)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client, kube: c.kube}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client originpulls.Client
+	kube   client.Client
+}
+
+// perHostname returns true if the passed Origin Pull Certificate is used
+// for per-hostname Authenticated Origin Pulls.
+func perHostname(cr *v1alpha1.OriginPullCertificate) bool {
+	return cr.Spec.ForProvider.PerHostname != nil && *cr.Spec.ForProvider.PerHostname
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.OriginPullCertificate)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotOriginPullCertificate)
+	}
+
+	// Origin Pull Certificate does not exist if we dont have an ID stored in external-name
+	cid := meta.GetExternalName(cr)
+	if cid == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalObservation{}, errors.New(errNoZone)
+	}
+
+	c, err := originpulls.OriginPullCertificate(ctx, e.client, *cr.Spec.ForProvider.Zone, perHostname(cr), cid)
+	if err != nil {
+		return managed.ExternalObservation{},
+			errors.Wrap(resource.Ignore(originpulls.IsNotFound, err), errOriginPullCertificateLookup)
+	}
+
+	// The certificate is read on every observation so that a renewed
+	// certificate is uploaded when the Secret it is read from changes.
+	o, err := originpulls.ResolveOptions(ctx, e.kube, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errOriginPullCertificateResolve)
+	}
+
+	cr.Status.AtProvider = originpulls.GenerateCertificateObservation(*c)
+
+	cr.SetConditions(rtv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: originpulls.CertificateUpToDate(o.Certificate, *c),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.OriginPullCertificate)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotOriginPullCertificate)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalCreation{}, errors.Wrap(errors.New(errNoZone), errOriginPullCertificateCreation)
+	}
+
+	o, err := originpulls.ResolveOptions(ctx, e.kube, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(errors.Wrap(err, errOriginPullCertificateResolve), errOriginPullCertificateCreation)
+	}
+
+	c, err := originpulls.CreateOriginPullCertificate(ctx, e.client, *cr.Spec.ForProvider.Zone, perHostname(cr), o)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errOriginPullCertificateCreation)
+	}
+
+	// Update the external name with the ID of the new Origin Pull Certificate
+	meta.SetExternalName(cr, c.ID)
+
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.OriginPullCertificate)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotOriginPullCertificate)
+	}
+
+	cid := meta.GetExternalName(cr)
+
+	// Update should never be called on a nonexistent resource
+	if cid == "" {
+		return managed.ExternalUpdate{}, errors.New(errOriginPullCertificateUpdate)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalUpdate{}, errors.Wrap(errors.New(errNoZone), errOriginPullCertificateUpdate)
+	}
+
+	o, err := originpulls.ResolveOptions(ctx, e.kube, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(errors.Wrap(err, errOriginPullCertificateResolve), errOriginPullCertificateUpdate)
+	}
+
+	// Uploaded certificates cannot be modified, so the renewed certificate
+	// is uploaded alongside the existing one, which is deleted once the ID
+	// of the renewed certificate has been persisted.
+	c, err := originpulls.CreateOriginPullCertificate(ctx, e.client, *cr.Spec.ForProvider.Zone, perHostname(cr), o)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errOriginPullCertificateUpdate)
+	}
+
+	meta.SetExternalName(cr, c.ID)
+	if err := e.kube.Update(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errOriginPullCertificatePersist)
+	}
+
+	return managed.ExternalUpdate{},
+		errors.Wrap(
+			resource.Ignore(originpulls.IsNotFound,
+				originpulls.DeleteOriginPullCertificate(ctx, e.client, *cr.Spec.ForProvider.Zone, perHostname(cr), cid)),
+			errOriginPullCertificateUpdate)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.OriginPullCertificate)
+	if !ok {
+		return errors.New(errNotOriginPullCertificate)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return errors.Wrap(errors.New(errNoZone), errOriginPullCertificateDeletion)
+	}
+
+	cid := meta.GetExternalName(cr)
+
+	// Delete should never be called on a nonexistent resource
+	if cid == "" {
+		return errors.New(errOriginPullCertificateDeletion)
+	}
+
+	return errors.Wrap(
+		resource.Ignore(originpulls.IsNotFound,
+			originpulls.DeleteOriginPullCertificate(ctx, e.client, *cr.Spec.ForProvider.Zone, perHostname(cr), cid)),
+		errOriginPullCertificateDeletion)
+}
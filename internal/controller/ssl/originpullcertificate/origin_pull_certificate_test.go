@@ -0,0 +1,726 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package originpullcertificate
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/benagricola/provider-cloudflare/apis/ssl/v1alpha1"
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/ssl/originpulls"
+	"github.com/benagricola/provider-cloudflare/internal/clients/ssl/originpulls/fake"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const (
+	testZone          = "023e105f4ecef8ad9ca31a8372d0c353"
+	testCertificateID = "2458ce5a-0c35-4c7f-82c7-8e9487d3ff60"
+)
+
+type certificateModifier func(*v1alpha1.OriginPullCertificate)
+
+func withZone(zone string) certificateModifier {
+	return func(c *v1alpha1.OriginPullCertificate) { c.Spec.ForProvider.Zone = &zone }
+}
+
+func withSecretRefs() certificateModifier {
+	return func(c *v1alpha1.OriginPullCertificate) {
+		ref := xpv1.SecretReference{Name: "origin-pull-tls", Namespace: "default"}
+		c.Spec.ForProvider.CertificateSecretRef = xpv1.SecretKeySelector{SecretReference: ref, Key: "tls.crt"}
+		c.Spec.ForProvider.PrivateKeySecretRef = xpv1.SecretKeySelector{SecretReference: ref, Key: "tls.key"}
+	}
+}
+
+func withExternalName(certificateID string) certificateModifier {
+	return func(c *v1alpha1.OriginPullCertificate) { meta.SetExternalName(c, certificateID) }
+}
+
+func certificateBuild(m ...certificateModifier) *v1alpha1.OriginPullCertificate {
+	cr := &v1alpha1.OriginPullCertificate{}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// secretKube returns a client that reads a Secret containing a
+// certificate from the tls.crt key and a private key from the tls.key key.
+func secretKube() *test.MockClient {
+	return &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.(*corev1.Secret).Data = map[string][]byte{
+				"tls.crt": []byte("certificate"),
+				"tls.key": []byte("key"),
+			}
+			return nil
+		}),
+		MockUpdate: test.NewMockUpdateFn(nil),
+	}
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (originpulls.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotOriginPullCertificate": {
+			reason: "An error should be returned if the managed resource is not an *OriginPullCertificate",
+			fields: fields{
+				newClient: originpulls.NewClient,
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotOriginPullCertificate),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube:      mc,
+				newClient: originpulls.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.OriginPullCertificate{
+					Spec: v1alpha1.OriginPullCertificateSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when a client is created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *pcv1alpha1.ProviderConfig:
+							o.Spec.Credentials.Source = "Secret"
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								Key: "creds",
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{
+								"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+							}
+						}
+						return nil
+					}),
+				},
+				newClient: originpulls.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.OriginPullCertificate{
+					Spec: v1alpha1.OriginPullCertificateSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (originpulls.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := errors.New("HTTP status 404: Not Found")
+
+	type fields struct {
+		client originpulls.Client
+		kube   client.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotOriginPullCertificate": {
+			reason: "An error should be returned if the managed resource is not an *OriginPullCertificate",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotOriginPullCertificate),
+			},
+		},
+		"ErrNoOriginPullCertificate": {
+			reason: "We should return ResourceExists: false when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: &v1alpha1.OriginPullCertificate{},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the Origin Pull Certificate has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: certificateBuild(withExternalName(testCertificateID)),
+			},
+			want: want{
+				err: errors.New(errNoZone),
+			},
+		},
+		"ErrOriginPullCertificateLookup": {
+			reason: "We should return an empty observation and an error if the API returned an error",
+			fields: fields{
+				client: fake.MockClient{
+					MockGetPerZoneAuthenticatedOriginPullsCertificateDetails: func(ctx context.Context, zoneID, certificateID string) (cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails, error) {
+						return cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: certificateBuild(withZone(testZone), withExternalName(testCertificateID)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errOriginPullCertificateLookup),
+			},
+		},
+		"OriginPullCertificateNotFound": {
+			reason: "We should return ResourceExists: false if the Origin Pull Certificate was not found",
+			fields: fields{
+				client: fake.MockClient{
+					MockGetPerZoneAuthenticatedOriginPullsCertificateDetails: func(ctx context.Context, zoneID, certificateID string) (cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails, error) {
+						return cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails{}, errNotFound
+					},
+				},
+			},
+			args: args{
+				mg: certificateBuild(withZone(testZone), withExternalName(testCertificateID)),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrOriginPullCertificateResolve": {
+			reason: "We should return an error if the desired certificate cannot be resolved",
+			fields: fields{
+				client: fake.MockClient{
+					MockGetPerZoneAuthenticatedOriginPullsCertificateDetails: func(ctx context.Context, zoneID, certificateID string) (cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails, error) {
+						return cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails{ID: testCertificateID}, nil
+					},
+				},
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+			},
+			args: args{
+				mg: certificateBuild(withZone(testZone), withSecretRefs(), withExternalName(testCertificateID)),
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errBoom, "cannot get Secret"), errOriginPullCertificateResolve),
+			},
+		},
+		"Success": {
+			reason: "We should return ResourceExists: true and no error when an Origin Pull Certificate is found",
+			fields: fields{
+				client: fake.MockClient{
+					MockGetPerZoneAuthenticatedOriginPullsCertificateDetails: func(ctx context.Context, zoneID, certificateID string) (cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails, error) {
+						return cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails{ID: testCertificateID, Status: "active"}, nil
+					},
+				},
+				kube: secretKube(),
+			},
+			args: args{
+				mg: certificateBuild(
+					withZone(testZone),
+					withSecretRefs(),
+					withExternalName(testCertificateID),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client, kube: tc.fields.kube}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client originpulls.Client
+		kube   client.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotOriginPullCertificate": {
+			reason: "An error should be returned if the managed resource is not an *OriginPullCertificate",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotOriginPullCertificate),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the Origin Pull Certificate has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: certificateBuild(withSecretRefs()),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoZone), errOriginPullCertificateCreation),
+			},
+		},
+		"ErrOriginPullCertificateResolve": {
+			reason: "We should return an error if the certificate cannot be read",
+			fields: fields{
+				client: fake.MockClient{},
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+			},
+			args: args{
+				mg: certificateBuild(
+					withZone(testZone),
+					withSecretRefs(),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errors.Wrap(errBoom, "cannot get Secret"), errOriginPullCertificateResolve), errOriginPullCertificateCreation),
+			},
+		},
+		"ErrOriginPullCertificateCreate": {
+			reason: "We should return any errors during the create process",
+			fields: fields{
+				client: fake.MockClient{
+					MockUploadPerZoneAuthenticatedOriginPullsCertificate: func(ctx context.Context, zoneID string, params cloudflare.PerZoneAuthenticatedOriginPullsCertificateParams) (cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails, error) {
+						return cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails{}, errBoom
+					},
+				},
+				kube: secretKube(),
+			},
+			args: args{
+				mg: certificateBuild(
+					withZone(testZone),
+					withSecretRefs(),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errOriginPullCertificateCreation),
+			},
+		},
+		"Success": {
+			reason: "We should return ExternalNameAssigned: true and no error when an Origin Pull Certificate is created",
+			fields: fields{
+				client: fake.MockClient{
+					MockUploadPerZoneAuthenticatedOriginPullsCertificate: func(ctx context.Context, zoneID string, params cloudflare.PerZoneAuthenticatedOriginPullsCertificateParams) (cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails, error) {
+						return cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails{ID: testCertificateID, Status: "pending"}, nil
+					},
+				},
+				kube: secretKube(),
+			},
+			args: args{
+				mg: certificateBuild(
+					withZone(testZone),
+					withSecretRefs(),
+				),
+			},
+			want: want{
+				o: managed.ExternalCreation{ExternalNameAssigned: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client, kube: tc.fields.kube}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client originpulls.Client
+		kube   client.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotOriginPullCertificate": {
+			reason: "An error should be returned if the managed resource is not an *OriginPullCertificate",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotOriginPullCertificate),
+			},
+		},
+		"ErrNoOriginPullCertificate": {
+			reason: "We should return an error when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: certificateBuild(withZone(testZone)),
+			},
+			want: want{
+				err: errors.New(errOriginPullCertificateUpdate),
+			},
+		},
+		"ErrOriginPullCertificateUpdate": {
+			reason: "We should return any errors during the update process",
+			fields: fields{
+				client: fake.MockClient{
+					MockUploadPerZoneAuthenticatedOriginPullsCertificate: func(ctx context.Context, zoneID string, params cloudflare.PerZoneAuthenticatedOriginPullsCertificateParams) (cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails, error) {
+						return cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails{}, errBoom
+					},
+				},
+				kube: secretKube(),
+			},
+			args: args{
+				mg: certificateBuild(
+					withZone(testZone),
+					withSecretRefs(),
+					withExternalName(testCertificateID),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errOriginPullCertificateUpdate),
+			},
+		},
+		"ErrOriginPullCertificatePersist": {
+			reason: "We should return an error if the ID of the replacement certificate cannot be persisted",
+			fields: fields{
+				client: fake.MockClient{
+					MockUploadPerZoneAuthenticatedOriginPullsCertificate: func(ctx context.Context, zoneID string, params cloudflare.PerZoneAuthenticatedOriginPullsCertificateParams) (cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails, error) {
+						return cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails{ID: "5a7805061c76ada191ed06f989cc3dac"}, nil
+					},
+				},
+				kube: &test.MockClient{
+					MockGet:    secretKube().MockGet,
+					MockUpdate: test.NewMockUpdateFn(errBoom),
+				},
+			},
+			args: args{
+				mg: certificateBuild(
+					withZone(testZone),
+					withSecretRefs(),
+					withExternalName(testCertificateID),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errOriginPullCertificatePersist),
+			},
+		},
+		"Success": {
+			reason: "We should upload a replacement certificate and delete the existing one when an Origin Pull Certificate is updated",
+			fields: fields{
+				client: fake.MockClient{
+					MockUploadPerZoneAuthenticatedOriginPullsCertificate: func(ctx context.Context, zoneID string, params cloudflare.PerZoneAuthenticatedOriginPullsCertificateParams) (cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails, error) {
+						want := cloudflare.PerZoneAuthenticatedOriginPullsCertificateParams{Certificate: "certificate", PrivateKey: "key"}
+						if diff := cmp.Diff(want, params); diff != "" {
+							t.Errorf("MockUploadPerZoneAuthenticatedOriginPullsCertificate(...): -want, +got:\n%s\n", diff)
+						}
+						return cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails{ID: "5a7805061c76ada191ed06f989cc3dac"}, nil
+					},
+					MockDeletePerZoneAuthenticatedOriginPullsCertificate: func(ctx context.Context, zoneID, certificateID string) (cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails, error) {
+						if certificateID != testCertificateID {
+							t.Errorf("MockDeletePerZoneAuthenticatedOriginPullsCertificate(...): unexpected delete of %s", certificateID)
+						}
+						return cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails{}, nil
+					},
+				},
+				kube: secretKube(),
+			},
+			args: args{
+				mg: certificateBuild(
+					withZone(testZone),
+					withSecretRefs(),
+					withExternalName(testCertificateID),
+				),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client, kube: tc.fields.kube}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := errors.New("HTTP status 404: Not Found")
+
+	type fields struct {
+		client originpulls.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotOriginPullCertificate": {
+			reason: "An error should be returned if the managed resource is not an *OriginPullCertificate",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotOriginPullCertificate),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the Origin Pull Certificate has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: certificateBuild(withExternalName(testCertificateID)),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoZone), errOriginPullCertificateDeletion),
+			},
+		},
+		"ErrOriginPullCertificateDelete": {
+			reason: "We should return any errors during the delete process",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeletePerZoneAuthenticatedOriginPullsCertificate: func(ctx context.Context, zoneID, certificateID string) (cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails, error) {
+						return cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: certificateBuild(withZone(testZone), withExternalName(testCertificateID)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errOriginPullCertificateDeletion),
+			},
+		},
+		"OriginPullCertificateNotFound": {
+			reason: "We should return no error if the Origin Pull Certificate was already deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeletePerZoneAuthenticatedOriginPullsCertificate: func(ctx context.Context, zoneID, certificateID string) (cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails, error) {
+						return cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails{}, errNotFound
+					},
+				},
+			},
+			args: args{
+				mg: certificateBuild(withZone(testZone), withExternalName(testCertificateID)),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"Success": {
+			reason: "We should return no error when an Origin Pull Certificate is deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeletePerZoneAuthenticatedOriginPullsCertificate: func(ctx context.Context, zoneID, certificateID string) (cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails, error) {
+						return cloudflare.PerZoneAuthenticatedOriginPullsCertificateDetails{}, nil
+					},
+				},
+			},
+			args: args{
+				mg: certificateBuild(withZone(testZone), withExternalName(testCertificateID)),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package totaltls
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/ssl/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/ssl/totaltls"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotTotalTLS = "managed resource is not a TotalTLS custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errTotalTLSLookup      = "cannot lookup total tls"
+	errCertificatePackList = "cannot list certificate packs"
+	errTotalTLSCreation    = "cannot enable total tls"
+	errTotalTLSUpdate      = "cannot update total tls"
+	errTotalTLSDeletion    = "cannot disable total tls"
+	errTotalTLSNoZone      = "no zone found"
+)
+
+// Setup adds a controller that reconciles TotalTLS managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.TotalTLSGroupKind)
+	o = o.For(v1alpha1.TotalTLSGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.TotalTLSGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (totaltls.Client, error) {
+				return totaltls.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.TotalTLS{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (totaltls.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.TotalTLS)
+	if !ok {
+		return nil, errors.New(errNotTotalTLS)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client totaltls.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.TotalTLS)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTotalTLS)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalObservation{}, errors.New(errTotalTLSNoZone)
+	}
+
+	s, err := totaltls.TotalTLS(ctx, e.client, *cr.Spec.ForProvider.Zone)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errTotalTLSLookup)
+	}
+
+	// Total TLS is a setting of the Zone, so it exists only while enabled.
+	if !s.Enabled {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cps, err := totaltls.CertificatePacks(ctx, e.client, *cr.Spec.ForProvider.Zone)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errCertificatePackList)
+	}
+
+	cr.Status.AtProvider = totaltls.GenerateObservation(*s, cps)
+
+	// Certificates are issued asynchronously, so Total TLS is only
+	// available once every hostname is covered by an active certificate.
+	if totaltls.CertificatesActive(cr.Status.AtProvider) {
+		cr.Status.SetConditions(rtv1.Available())
+	} else {
+		cr.Status.SetConditions(rtv1.Unavailable())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: totaltls.UpToDate(&cr.Spec.ForProvider, *s),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.TotalTLS)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTotalTLS)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalCreation{}, errors.Wrap(errors.New(errTotalTLSNoZone), errTotalTLSCreation)
+	}
+
+	cr.SetConditions(rtv1.Creating())
+
+	_, err := totaltls.Enable(ctx, e.client, *cr.Spec.ForProvider.Zone, cr.Spec.ForProvider)
+	return managed.ExternalCreation{}, errors.Wrap(err, errTotalTLSCreation)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.TotalTLS)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotTotalTLS)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalUpdate{}, errors.Wrap(errors.New(errTotalTLSNoZone), errTotalTLSUpdate)
+	}
+
+	// Enabling Total TLS again changes its certificate authority.
+	_, err := totaltls.Enable(ctx, e.client, *cr.Spec.ForProvider.Zone, cr.Spec.ForProvider)
+	return managed.ExternalUpdate{}, errors.Wrap(err, errTotalTLSUpdate)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.TotalTLS)
+	if !ok {
+		return errors.New(errNotTotalTLS)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return errors.Wrap(errors.New(errTotalTLSNoZone), errTotalTLSDeletion)
+	}
+
+	return errors.Wrap(totaltls.Disable(ctx, e.client, *cr.Spec.ForProvider.Zone), errTotalTLSDeletion)
+}
@@ -0,0 +1,609 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package totaltls
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/benagricola/provider-cloudflare/apis/ssl/v1alpha1"
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/ssl/totaltls"
+	"github.com/benagricola/provider-cloudflare/internal/clients/ssl/totaltls/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const testZone = "023e105f4ecef8ad9ca31a8372d0c353"
+
+type totalTLSModifier func(*v1alpha1.TotalTLS)
+
+func withZone(zoneID string) totalTLSModifier {
+	return func(r *v1alpha1.TotalTLS) { r.Spec.ForProvider.Zone = &zoneID }
+}
+
+func withCertificateAuthority(ca string) totalTLSModifier {
+	return func(r *v1alpha1.TotalTLS) { r.Spec.ForProvider.CertificateAuthority = &ca }
+}
+
+func withObservation(o v1alpha1.TotalTLSObservation) totalTLSModifier {
+	return func(r *v1alpha1.TotalTLS) { r.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) totalTLSModifier {
+	return func(r *v1alpha1.TotalTLS) { r.Status.SetConditions(c...) }
+}
+
+func totalTLS(m ...totalTLSModifier) *v1alpha1.TotalTLS {
+	cr := &v1alpha1.TotalTLS{}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+// mockRaw returns a Raw function that responds to Total TLS and
+// certificate pack requests with the passed responses.
+func mockRaw(settings, packs string, err error) func(method, endpoint string, data interface{}) (json.RawMessage, error) {
+	return func(method, endpoint string, data interface{}) (json.RawMessage, error) {
+		if strings.Contains(endpoint, "/ssl/certificate_packs") {
+			return json.RawMessage(packs), err
+		}
+		return json.RawMessage(settings), err
+	}
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (totaltls.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTotalTLS": {
+			reason: "An error should be returned if the managed resource is not a *TotalTLS",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTotalTLS),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube: mc,
+			},
+			args: args{
+				mg: &v1alpha1.TotalTLS{
+					Spec: v1alpha1.TotalTLSSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when a client is created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *pcv1alpha1.ProviderConfig:
+							o.Spec.Credentials.Source = "Secret"
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								Key: "creds",
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{
+								"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+							}
+						}
+						return nil
+					}),
+				},
+				newClient: totaltls.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.TotalTLS{
+					Spec: v1alpha1.TotalTLSSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (totaltls.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client totaltls.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		cr  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTotalTLS": {
+			reason: "An error should be returned if the managed resource is not a *TotalTLS",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTotalTLS),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the TotalTLS has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: totalTLS(),
+			},
+			want: want{
+				cr:  totalTLS(),
+				err: errors.New(errTotalTLSNoZone),
+			},
+		},
+		"ErrTotalTLSLookup": {
+			reason: "We should return an empty observation and an error if the API returned an error",
+			fields: fields{
+				client: fake.MockClient{MockRawContext: mockRaw("", "", errBoom)},
+			},
+			args: args{
+				mg: totalTLS(withZone(testZone)),
+			},
+			want: want{
+				cr:  totalTLS(withZone(testZone)),
+				err: errors.Wrap(errBoom, errTotalTLSLookup),
+			},
+		},
+		"Disabled": {
+			reason: "We should return ResourceExists: false if Total TLS is disabled on the zone",
+			fields: fields{
+				client: fake.MockClient{MockRawContext: mockRaw(`{"enabled":false}`, "", nil)},
+			},
+			args: args{
+				mg: totalTLS(withZone(testZone)),
+			},
+			want: want{
+				cr: totalTLS(withZone(testZone)),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Pending": {
+			reason: "We should observe certificate status without setting Available while certificates are pending",
+			fields: fields{
+				client: fake.MockClient{MockRawContext: mockRaw(
+					`{"enabled":true,"certificate_authority":"google","validity_days":90}`,
+					`[{"id":"pack","type":"universal","hosts":["example.com"],"status":"pending_validation","certificate_authority":"google"}]`,
+					nil,
+				)},
+			},
+			args: args{
+				mg: totalTLS(withZone(testZone), withCertificateAuthority("lets_encrypt")),
+			},
+			want: want{
+				cr: totalTLS(
+					withZone(testZone),
+					withCertificateAuthority("lets_encrypt"),
+					withObservation(v1alpha1.TotalTLSObservation{
+						CertificateAuthority: "google",
+						ValidityDays:         90,
+						Certificates: []v1alpha1.HostnameCertificate{
+							{Hostname: "example.com", CertificatePackID: "pack", Type: "universal", CertificateAuthority: "google", Status: "pending_validation"},
+						},
+					}),
+					withConditions(xpv1.Unavailable()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"Active": {
+			reason: "We should set Available when every certificate is active",
+			fields: fields{
+				client: fake.MockClient{MockRawContext: mockRaw(
+					`{"enabled":true,"certificate_authority":"google"}`,
+					`[{"id":"pack","type":"universal","hosts":["example.com"],"status":"active","certificate_authority":"google"}]`,
+					nil,
+				)},
+			},
+			args: args{
+				mg: totalTLS(withZone(testZone), withCertificateAuthority("google")),
+			},
+			want: want{
+				cr: totalTLS(
+					withZone(testZone),
+					withCertificateAuthority("google"),
+					withObservation(v1alpha1.TotalTLSObservation{
+						CertificateAuthority: "google",
+						Certificates: []v1alpha1.HostnameCertificate{
+							{Hostname: "example.com", CertificatePackID: "pack", Type: "universal", CertificateAuthority: "google", Status: "active"},
+						},
+					}),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want cr, +got cr:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client totaltls.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTotalTLS": {
+			reason: "An error should be returned if the managed resource is not a *TotalTLS",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTotalTLS),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the TotalTLS has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: totalTLS(),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errTotalTLSNoZone), errTotalTLSCreation),
+			},
+		},
+		"ErrTotalTLSCreate": {
+			reason: "We should return any errors while enabling Total TLS",
+			fields: fields{
+				client: fake.MockClient{MockRawContext: mockRaw("", "", errBoom)},
+			},
+			args: args{
+				mg: totalTLS(withZone(testZone)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errTotalTLSCreation),
+			},
+		},
+		"Success": {
+			reason: "We should enable Total TLS with the requested certificate authority and return no error",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if diff := cmp.Diff(totaltls.Settings{Enabled: true, CertificateAuthority: "google"}, data); diff != "" {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{"enabled":true,"certificate_authority":"google"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: totalTLS(withZone(testZone), withCertificateAuthority("google")),
+			},
+			want: want{
+				o: managed.ExternalCreation{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client totaltls.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTotalTLS": {
+			reason: "An error should be returned if the managed resource is not a *TotalTLS",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTotalTLS),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the TotalTLS has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: totalTLS(),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errTotalTLSNoZone), errTotalTLSUpdate),
+			},
+		},
+		"ErrTotalTLSUpdate": {
+			reason: "We should return any errors while updating Total TLS",
+			fields: fields{
+				client: fake.MockClient{MockRawContext: mockRaw("", "", errBoom)},
+			},
+			args: args{
+				mg: totalTLS(withZone(testZone), withCertificateAuthority("ssl_com")),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errTotalTLSUpdate),
+			},
+		},
+		"Success": {
+			reason: "We should change the certificate authority and return no error",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if diff := cmp.Diff(totaltls.Settings{Enabled: true, CertificateAuthority: "ssl_com"}, data); diff != "" {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{"enabled":true,"certificate_authority":"ssl_com"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: totalTLS(withZone(testZone), withCertificateAuthority("ssl_com")),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client totaltls.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTotalTLS": {
+			reason: "An error should be returned if the managed resource is not a *TotalTLS",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTotalTLS),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the TotalTLS has no zone",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: totalTLS(),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errTotalTLSNoZone), errTotalTLSDeletion),
+			},
+		},
+		"ErrTotalTLSDelete": {
+			reason: "We should return any errors while disabling Total TLS",
+			fields: fields{
+				client: fake.MockClient{MockRawContext: mockRaw("", "", errBoom)},
+			},
+			args: args{
+				mg: totalTLS(withZone(testZone)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errTotalTLSDeletion),
+			},
+		},
+		"Success": {
+			reason: "We should disable Total TLS and return no error",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if diff := cmp.Diff(totaltls.Settings{Enabled: false}, data); diff != "" {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{"enabled":false}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: totalTLS(withZone(testZone), withCertificateAuthority("google")),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
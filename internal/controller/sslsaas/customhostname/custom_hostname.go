@@ -18,25 +18,24 @@ package customhostname
 
 import (
 	"context"
-	"time"
 
 	"github.com/pkg/errors"
-	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 
 	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
-	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
-	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
+	"github.com/cloudflare/cloudflare-go"
+
 	"github.com/benagricola/provider-cloudflare/apis/sslsaas/v1alpha1"
 	clients "github.com/benagricola/provider-cloudflare/internal/clients"
 	customhostnames "github.com/benagricola/provider-cloudflare/internal/clients/sslsaas/customhostnames"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
 	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
 )
 
@@ -45,47 +44,61 @@ const (
 
 	errClientConfig = "error getting client config"
 
-	errCustomHostnameLookup   = "cannot lookup custom hostname"
-	errCustomHostnameCreation = "cannot create custom hostname"
-	errCustomHostnameUpdate   = "cannot update record"
-	errCustomHostnameDeletion = "cannot delete record"
-	errCustomHostnameNoZone   = "cannot create custom hostname no zone found"
+	errCustomHostnameLookup    = "cannot lookup custom hostname"
+	errCustomHostnameCertParse = "cannot parse custom hostname certificate"
+	errCustomHostnameCreation  = "cannot create custom hostname"
+	errCustomHostnameUpdate    = "cannot update record"
+	errCustomHostnameDeletion  = "cannot delete record"
+	errCustomHostnameNoZone    = "cannot create custom hostname no zone found"
+
+	errCustomHostnameDeprovisioning = "cannot delete custom hostname until its certificate is deprovisioned"
+
+	errCustomHostnameValidationRecordLookup  = "cannot lookup custom hostname validation records"
+	errCustomHostnameValidationRecordPublish = "cannot publish custom hostname validation records"
+	errCustomHostnameValidationRecordDelete  = "cannot delete custom hostname validation records"
+
+	errCustomHostnameInvalidSSLType   = "unsupported SSL type %q"
+	errCustomHostnameInvalidSSLMethod = "unsupported SSL method %q"
 )
 
 const (
 	customHostnameStatusActive = "active"
 
-	maxConcurrency = 5
+	// sslStatusPendingValidation is the status of a certificate while
+	// Cloudflare checks its validation records.
+	sslStatusPendingValidation = "pending_validation"
 )
 
 // Setup adds a controller that reconciles CustomHostname managed resources.
-func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+func Setup(mgr ctrl.Manager, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.CustomHostnameGroupKind)
-
-	o := controller.Options{
-		RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
-		MaxConcurrentReconciles: maxConcurrency,
-	}
+	o = o.For(v1alpha1.CustomHostnameGroupKind)
 
 	hc := metrics.NewInstrumentedHTTPClient(name)
+	zl := customhostnames.NewZoneRateLimiter(o.CustomHostnameZoneQPS, o.CustomHostnameZoneBurst)
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.CustomHostnameGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
 			kube: mgr.GetClient(),
 			newCloudflareClientFn: func(cfg clients.Config) (customhostnames.Client, error) {
-				return customhostnames.NewClient(cfg, hc)
+				c, err := customhostnames.NewClient(cfg, hc)
+				if err != nil {
+					return nil, err
+				}
+				return customhostnames.WithRateLimiter(c, zl), nil
 			},
-		}),
-		managed.WithLogger(l.WithValues("controller", name)),
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
-		managed.WithPollInterval(5*time.Minute),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
 		// Do not initialize external-name field.
 		managed.WithInitializers(),
 	)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		WithOptions(o).
+		WithOptions(o.ForControllerRuntime()).
 		For(&v1alpha1.CustomHostname{}).
 		Complete(r)
 }
@@ -123,6 +136,10 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
 	client customhostnames.Client
+
+	// observed is the Custom Hostname last observed, so that it is not
+	// looked up again on update.
+	observed *cloudflare.CustomHostname
 }
 
 func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -141,7 +158,7 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
 
-	ch, err := e.client.CustomHostname(ctx, *cr.Spec.ForProvider.Zone, chid)
+	ch, raw, err := customhostnames.GetCustomHostname(ctx, e.client, *cr.Spec.ForProvider.Zone, chid)
 
 	if err != nil {
 		if customhostnames.IsCustomHostnameNotFound(err) {
@@ -150,26 +167,48 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.Wrap(err, errCustomHostnameLookup)
 	}
 
-	cr.Status.AtProvider = customhostnames.GenerateObservation(ch)
+	e.observed = &ch
 
-	// Mark as ready when the Hostname is ready
-	// Note that this does not mean that the SSL Certificate is ready
-	// That status is available here - cr.Status.AtProvider.SSL.Status
+	// The TXT records we published are not returned by Cloudflare, so they
+	// are kept across observations.
+	published := cr.Status.AtProvider.ValidationDNSRecords
+	cr.Status.AtProvider = customhostnames.GenerateObservation(ch)
+	cr.Status.AtProvider.ValidationDNSRecords = published
 
-	// We've made the decision to mark the resource as ready when
-	// Cloudflare can accept traffic for it on any port (in this case
-	// 80/http). 443/https traffic would receive a certificate error
-	// until cr.Status.AtProvider.SSL.Status returns ready as well.
-	// If this is necessary, both statuses can be checked by using a
-	// readinessCheck in a Composition.
+	if err := customhostnames.ParseCertificate(raw, &cr.Status.AtProvider.SSL); err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errCustomHostnameCertParse)
+	}
 
-	if cr.Status.AtProvider.Status == customHostnameStatusActive {
+	// Mark as ready only once both the Hostname and its SSL certificate
+	// are active, as until then HTTPS traffic to the Hostname receives
+	// a certificate error. The status of the certificate is surfaced
+	// while it is being validated, issued and deployed.
+	switch {
+	case cr.Status.AtProvider.SSL.Status != customHostnameStatusActive:
+		cr.Status.SetConditions(v1alpha1.CertificateNotActive(cr.Status.AtProvider.SSL.Status))
+	case cr.Status.AtProvider.Status == customHostnameStatusActive:
 		cr.Status.SetConditions(rtv1.Available())
+	default:
+		cr.Status.SetConditions(rtv1.Unavailable())
+	}
+
+	upToDate := customhostnames.UpToDate(&cr.Spec.ForProvider, ch)
+
+	// The Custom Hostname is also out of date when we publish its TXT
+	// validation records and they do not exist yet while validation is
+	// pending, or when records we published are no longer needed.
+	zone := validationRecordZone(cr)
+	if upToDate && (zone != "" || len(published) > 0) {
+		upToDate, err = customhostnames.ValidationRecordsUpToDate(ctx, e.client, zone, published,
+			cr.Status.AtProvider.SSL.ValidationRecords, cr.Status.AtProvider.SSL.Status == sslStatusPendingValidation)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errCustomHostnameValidationRecordLookup)
+		}
 	}
 
 	return managed.ExternalObservation{
 		ResourceExists:   true,
-		ResourceUpToDate: customhostnames.UpToDate(&cr.Spec.ForProvider, ch),
+		ResourceUpToDate: upToDate,
 	}, nil
 }
 
@@ -187,6 +226,22 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errCustomHostnameCreation)
 	}
 
+	if !customhostnames.IsValidSSLType(*cr.Spec.ForProvider.SSL.Type) {
+		return managed.ExternalCreation{}, errors.Wrap(
+			errors.Errorf(errCustomHostnameInvalidSSLType, *cr.Spec.ForProvider.SSL.Type),
+			errCustomHostnameCreation)
+	}
+
+	if !customhostnames.IsValidSSLMethod(*cr.Spec.ForProvider.SSL.Method) {
+		return managed.ExternalCreation{}, errors.Wrap(
+			errors.Errorf(errCustomHostnameInvalidSSLMethod, *cr.Spec.ForProvider.SSL.Method),
+			errCustomHostnameCreation)
+	}
+
+	if err := customhostnames.ValidateCustomMetadata(cr.Spec.ForProvider.CustomMetadata); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCustomHostnameCreation)
+	}
+
 	rch, err := e.client.CreateCustomHostname(
 		ctx,
 		*cr.Spec.ForProvider.Zone,
@@ -215,6 +270,22 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errCustomHostnameUpdate)
 	}
 
+	if !customhostnames.IsValidSSLType(*cr.Spec.ForProvider.SSL.Type) {
+		return managed.ExternalUpdate{}, errors.Wrap(
+			errors.Errorf(errCustomHostnameInvalidSSLType, *cr.Spec.ForProvider.SSL.Type),
+			errCustomHostnameUpdate)
+	}
+
+	if !customhostnames.IsValidSSLMethod(*cr.Spec.ForProvider.SSL.Method) {
+		return managed.ExternalUpdate{}, errors.Wrap(
+			errors.Errorf(errCustomHostnameInvalidSSLMethod, *cr.Spec.ForProvider.SSL.Method),
+			errCustomHostnameUpdate)
+	}
+
+	if err := customhostnames.ValidateCustomMetadata(cr.Spec.ForProvider.CustomMetadata); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errCustomHostnameUpdate)
+	}
+
 	chid := meta.GetExternalName(cr)
 
 	// Update should never be called on a nonexistent resource
@@ -222,17 +293,48 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errCustomHostnameUpdate)
 	}
 
-	_, err := e.client.UpdateCustomHostname(
-		ctx,
-		*cr.Spec.ForProvider.Zone,
-		chid,
-		customhostnames.ParametersToCustomHostname(cr.Spec.ForProvider),
-	)
-	return managed.ExternalUpdate{},
-		errors.Wrap(
-			err,
-			errCustomHostnameUpdate,
+	// Update should never be called before the Custom Hostname is observed
+	if e.observed == nil {
+		return managed.ExternalUpdate{}, errors.New(errCustomHostnameUpdate)
+	}
+
+	// Updating the SSL settings of a Custom Hostname may issue new
+	// validation records, so it is only updated when it has drifted and
+	// not when just its validation records need publishing.
+	if !customhostnames.UpToDate(&cr.Spec.ForProvider, *e.observed) {
+		res, err := e.client.UpdateCustomHostname(
+			ctx,
+			*cr.Spec.ForProvider.Zone,
+			chid,
+			customhostnames.ParametersToCustomHostname(cr.Spec.ForProvider),
 		)
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errCustomHostnameUpdate)
+		}
+
+		// Changing the SSL method issues new validation records, so show
+		// them straight away rather than waiting for the next observation.
+		if res != nil && res.Result.ID != "" {
+			exp := cr.Status.AtProvider.SSL.ExpiresOn
+			published := cr.Status.AtProvider.ValidationDNSRecords
+			cr.Status.AtProvider = customhostnames.GenerateObservation(res.Result)
+			cr.Status.AtProvider.SSL.ExpiresOn = exp
+			cr.Status.AtProvider.ValidationDNSRecords = published
+		}
+	}
+
+	// Records we published are deleted when the zone is removed, so they
+	// are reconciled whenever status lists any.
+	if zone := validationRecordZone(cr); zone != "" || len(cr.Status.AtProvider.ValidationDNSRecords) > 0 {
+		published, err := customhostnames.PublishValidationRecords(ctx, e.client, zone, chid,
+			cr.Status.AtProvider.ValidationDNSRecords, cr.Status.AtProvider.SSL.ValidationRecords)
+		cr.Status.AtProvider.ValidationDNSRecords = published
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errCustomHostnameValidationRecordPublish)
+		}
+	}
+
+	return managed.ExternalUpdate{}, nil
 }
 
 func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
@@ -252,7 +354,37 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.New(errCustomHostnameDeletion)
 	}
 
-	return errors.Wrap(
-		e.client.DeleteCustomHostname(ctx, *cr.Spec.ForProvider.Zone, chid),
-		errCustomHostnameDeletion)
+	// Records we published are deleted whatever the spec says, along with
+	// any found by their tag that status has lost track of.
+	published, err := customhostnames.CollectValidationRecords(ctx, e.client, validationRecordZone(cr), chid,
+		cr.Status.AtProvider.ValidationDNSRecords)
+	if err != nil {
+		return errors.Wrap(err, errCustomHostnameValidationRecordDelete)
+	}
+	remaining, err := customhostnames.DeleteValidationRecords(ctx, e.client, published)
+	cr.Status.AtProvider.ValidationDNSRecords = remaining
+	if err != nil {
+		return errors.Wrap(err, errCustomHostnameValidationRecordDelete)
+	}
+
+	err = e.client.DeleteCustomHostname(ctx, *cr.Spec.ForProvider.Zone, chid)
+
+	// Deleting a custom hostname fails while its certificate is being
+	// deprovisioned. The delete is retried by requeueing the resource
+	// rather than by waiting in this reconcile.
+	if customhostnames.IsCertificateDeprovisioning(err) {
+		return errors.Wrap(err, errCustomHostnameDeprovisioning)
+	}
+
+	return errors.Wrap(err, errCustomHostnameDeletion)
+}
+
+// validationRecordZone returns the ID of the zone the TXT validation
+// records of the passed Custom Hostname are published on, or an empty
+// string if they are not published.
+func validationRecordZone(cr *v1alpha1.CustomHostname) string {
+	if cr.Spec.ForProvider.ValidationRecordZone == nil {
+		return ""
+	}
+	return *cr.Spec.ForProvider.ValidationRecordZone
 }
@@ -18,6 +18,7 @@ package customhostname
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"testing"
 
@@ -69,6 +70,30 @@ func withSSLSettings(settings *v1alpha1.CustomHostnameSSL) customHostnameModifie
 	return func(r *v1alpha1.CustomHostname) { r.Spec.ForProvider.SSL = *settings }
 }
 
+func withSSLType(typ string) customHostnameModifier {
+	return func(r *v1alpha1.CustomHostname) { r.Spec.ForProvider.SSL.Type = &typ }
+}
+
+func withSSLMethod(method string) customHostnameModifier {
+	return func(r *v1alpha1.CustomHostname) { r.Spec.ForProvider.SSL.Method = &method }
+}
+
+func withCustomMetadata(md map[string]string) customHostnameModifier {
+	return func(r *v1alpha1.CustomHostname) { r.Spec.ForProvider.CustomMetadata = md }
+}
+
+func withCustomOriginServer(origin string) customHostnameModifier {
+	return func(r *v1alpha1.CustomHostname) { r.Spec.ForProvider.CustomOriginServer = &origin }
+}
+
+func withValidationRecordZone(zoneID string) customHostnameModifier {
+	return func(r *v1alpha1.CustomHostname) { r.Spec.ForProvider.ValidationRecordZone = &zoneID }
+}
+
+func withValidationDNSRecords(rs ...v1alpha1.CustomHostnameValidationDNSRecord) customHostnameModifier {
+	return func(r *v1alpha1.CustomHostname) { r.Status.AtProvider.ValidationDNSRecords = rs }
+}
+
 func customHostname(m ...customHostnameModifier) *v1alpha1.CustomHostname {
 	cr := &v1alpha1.CustomHostname{}
 	for _, f := range m {
@@ -197,6 +222,9 @@ func TestConnect(t *testing.T) {
 func TestObserve(t *testing.T) {
 	errBoom := errors.New("boom")
 
+	badCert := json.RawMessage(`{"ssl":{"expires_on":"never"}}`)
+	errBadCert := customhostnames.ParseCertificate(badCert, &v1alpha1.CustomHostnameSSLObserved{})
+
 	type fields struct {
 		client customhostnames.Client
 	}
@@ -242,8 +270,8 @@ func TestObserve(t *testing.T) {
 			reason: "We should return an empty observation and an error if the API returned an error",
 			fields: fields{
 				client: fake.MockClient{
-					MockCustomHostname: func(ctx context.Context, zoneID string, customHostnameID string) (cloudflare.CustomHostname, error) {
-						return cloudflare.CustomHostname{}, errBoom
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
 					},
 				},
 			},
@@ -275,12 +303,108 @@ func TestObserve(t *testing.T) {
 				err: errors.New(errCustomHostnameNoZone),
 			},
 		},
+		"ErrCustomHostnameCertParse": {
+			reason: "We should return an error if we cannot parse the certificate of a CustomHostname",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return badCert, nil
+					},
+				},
+			},
+			args: args{
+				mg: customHostname(
+					withZone(zone),
+					withExternalName(externalName),
+				),
+			},
+			want: want{
+				o:   managed.ExternalObservation{},
+				err: errors.Wrap(errBadCert, errCustomHostnameCertParse),
+			},
+		},
+		"ValidationRecordsNotPublished": {
+			reason: "We should return ResourceUpToDate: false when the TXT validation records we publish do not exist",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"ssl":{"status":"pending_validation","txt_name":"_acme-challenge.host.zone.com","txt_value":"abc"}}`), nil
+					},
+					MockDNSRecords: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+						return nil, nil
+					},
+				},
+			},
+			args: args{
+				mg: customHostname(
+					withZone(zone),
+					withExternalName(externalName),
+					withValidationRecordZone(zone),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"ValidationRecordsNotPending": {
+			reason: "We should not look up the TXT validation records we publish unless validation is pending",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"ssl":{"status":"pending_issuance","txt_name":"_acme-challenge.host.zone.com","txt_value":"abc"}}`), nil
+					},
+					MockDNSRecords: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: customHostname(
+					withZone(zone),
+					withExternalName(externalName),
+					withValidationRecordZone(zone),
+					withValidationDNSRecords(v1alpha1.CustomHostnameValidationDNSRecord{Zone: zone, ID: "1234", Name: "_acme-challenge.host.zone.com", Value: "abc"}),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"ValidationRecordsNoZone": {
+			reason: "We should return ResourceUpToDate: false when records we published remain after the validation record zone is removed",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"ssl":{"status":"pending_validation","txt_name":"_acme-challenge.host.zone.com","txt_value":"abc"}}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: customHostname(
+					withZone(zone),
+					withExternalName(externalName),
+					withValidationDNSRecords(v1alpha1.CustomHostnameValidationDNSRecord{Zone: zone, ID: "1234", Name: "_acme-challenge.host.zone.com", Value: "abc"}),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
 		"Success": {
 			reason: "We should return ResourceExists: true and no error when a CustomHostname is found",
 			fields: fields{
 				client: fake.MockClient{
-					MockCustomHostname: func(ctx context.Context, zoneID, customHostnameID string) (cloudflare.CustomHostname, error) {
-						return cloudflare.CustomHostname{}, nil
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{}`), nil
 					},
 				},
 			},
@@ -314,6 +438,145 @@ func TestObserve(t *testing.T) {
 	}
 }
 
+func TestObserveSSLStatusReadiness(t *testing.T) {
+	type args struct {
+		status    cloudflare.CustomHostnameStatus
+		sslStatus string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   xpv1.Condition
+	}{
+		"Initializing": {
+			reason: "A custom hostname whose certificate is initializing should not be ready",
+			args: args{
+				status:    customHostnameStatusActive,
+				sslStatus: "initializing",
+			},
+			want: v1alpha1.CertificateNotActive("initializing"),
+		},
+		"PendingValidation": {
+			reason: "A custom hostname whose certificate is pending validation should not be ready",
+			args: args{
+				status:    customHostnameStatusActive,
+				sslStatus: "pending_validation",
+			},
+			want: v1alpha1.CertificateNotActive("pending_validation"),
+		},
+		"PendingIssuance": {
+			reason: "A custom hostname whose certificate is pending issuance should not be ready",
+			args: args{
+				status:    customHostnameStatusActive,
+				sslStatus: "pending_issuance",
+			},
+			want: v1alpha1.CertificateNotActive("pending_issuance"),
+		},
+		"PendingDeployment": {
+			reason: "A custom hostname whose certificate is pending deployment should not be ready",
+			args: args{
+				status:    customHostnameStatusActive,
+				sslStatus: "pending_deployment",
+			},
+			want: v1alpha1.CertificateNotActive("pending_deployment"),
+		},
+		"Active": {
+			reason: "A custom hostname whose certificate is active should be ready",
+			args: args{
+				status:    customHostnameStatusActive,
+				sslStatus: "active",
+			},
+			want: xpv1.Available(),
+		},
+		"HostnamePending": {
+			reason: "A custom hostname that is not active should not be ready even if its certificate is active",
+			args: args{
+				status:    "pending",
+				sslStatus: "active",
+			},
+			want: xpv1.Unavailable(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: fake.MockClient{
+				MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+					return json.Marshal(cloudflare.CustomHostname{
+						ID:     externalName,
+						Status: tc.args.status,
+						SSL: cloudflare.CustomHostnameSSL{
+							Status: tc.args.sslStatus,
+						},
+					})
+				},
+			}}
+
+			cr := customHostname(withZone(zone), withExternalName(externalName))
+			if _, err := e.Observe(context.Background(), cr); err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): unexpected error: %s\n", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, cr.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveValidationRecords(t *testing.T) {
+	e := external{client: fake.MockClient{
+		MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+			return json.RawMessage(`{"status":"active","ssl":{"method":"txt","status":"pending_validation","validation_records":[{"txt_name":"_acme-challenge.host.zone.com","txt_value":"abc"}]}}`), nil
+		},
+	}}
+
+	cr := customHostname(withZone(zone), withExternalName(externalName))
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatalf("e.Observe(...): unexpected error: %s", err)
+	}
+
+	want := []v1alpha1.CustomHostnameSSLValidationRecord{
+		{TXTName: "_acme-challenge.host.zone.com", TXTValue: "abc"},
+	}
+	if diff := cmp.Diff(want, cr.Status.AtProvider.SSL.ValidationRecords); diff != "" {
+		t.Errorf("e.Observe(...): -want validation records, +got validation records:\n%s", diff)
+	}
+	if diff := cmp.Diff(v1alpha1.CertificateNotActive("pending_validation"), cr.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+		t.Errorf("e.Observe(...): -want condition, +got condition:\n%s", diff)
+	}
+}
+
+func TestObserveValidationDNSRecords(t *testing.T) {
+	published := []v1alpha1.CustomHostnameValidationDNSRecord{
+		{ID: "1234", Name: "_acme-challenge.host.zone.com", Value: "abc"},
+	}
+
+	e := external{client: fake.MockClient{
+		MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+			return json.RawMessage(`{"status":"active","ssl":{"status":"active"}}`), nil
+		},
+	}}
+
+	cr := customHostname(
+		withZone(zone),
+		withExternalName(externalName),
+		withValidationRecordZone(zone),
+		withValidationDNSRecords(published...),
+	)
+	got, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(published, cr.Status.AtProvider.ValidationDNSRecords); diff != "" {
+		t.Errorf("e.Observe(...): -want published records, +got published records:\n%s", diff)
+	}
+	if got.ResourceUpToDate {
+		t.Errorf("e.Observe(...): a CustomHostname with published records that are no longer needed should not be up to date")
+	}
+}
+
 func TestCreate(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -346,6 +609,52 @@ func TestCreate(t *testing.T) {
 				err: errors.New(errNotCustomHostname),
 			},
 		},
+		"ErrCustomHostnameCreateInvalidSSLType": {
+			reason: "We should return an error if the SSL type is not supported",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateCustomHostname: func(ctx context.Context, zoneID string, rr cloudflare.CustomHostname) (*cloudflare.CustomHostnameResponse, error) {
+						return &cloudflare.CustomHostnameResponse{
+							Result: rr,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				mg: customHostname(
+					withZone(zone),
+					withHostname(hostname),
+					withSSLSettings(sslSettings),
+					withSSLType("ov"),
+				),
+			},
+			want: want{
+				o:   managed.ExternalCreation{},
+				err: errors.Wrap(errors.Errorf(errCustomHostnameInvalidSSLType, "ov"), errCustomHostnameCreation),
+			},
+		},
+		"ErrCustomHostnameInvalidMetadata": {
+			reason: "We should return an error if a custom metadata key is empty",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateCustomHostname: func(ctx context.Context, zoneID string, rr cloudflare.CustomHostname) (*cloudflare.CustomHostnameResponse, error) {
+						return &cloudflare.CustomHostnameResponse{}, nil
+					},
+				},
+			},
+			args: args{
+				mg: customHostname(
+					withZone(zone),
+					withHostname(hostname),
+					withSSLSettings(sslSettings),
+					withCustomMetadata(map[string]string{"": "value"}),
+				),
+			},
+			want: want{
+				o:   managed.ExternalCreation{},
+				err: errors.Wrap(errors.New("custom metadata keys must not be empty"), errCustomHostnameCreation),
+			},
+		},
 		"ErrCustomHostnameCreate": {
 			reason: "We should return any errors during the create process",
 			fields: fields{
@@ -368,6 +677,35 @@ func TestCreate(t *testing.T) {
 				err: errors.Wrap(errBoom, errCustomHostnameCreation),
 			},
 		},
+		"SuccessCustomMetadata": {
+			reason: "We should create a CustomHostname with its custom metadata",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateCustomHostname: func(ctx context.Context, zoneID string, rr cloudflare.CustomHostname) (*cloudflare.CustomHostnameResponse, error) {
+						if diff := cmp.Diff(cloudflare.CustomMetadata{"tier": "gold"}, rr.CustomMetadata); diff != "" {
+							return nil, errors.New(diff)
+						}
+						return &cloudflare.CustomHostnameResponse{
+							Result: rr,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				mg: customHostname(
+					withZone(zone),
+					withHostname(hostname),
+					withSSLSettings(sslSettings),
+					withCustomMetadata(map[string]string{"tier": "gold"}),
+				),
+			},
+			want: want{
+				o: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+				},
+				err: nil,
+			},
+		},
 		"Success": {
 			reason: "We should return ExternalNameAssigned: true and no error when a CustomHostname is created",
 			fields: fields{
@@ -409,11 +747,43 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestCreateExternalName(t *testing.T) {
+	cr := customHostname(
+		withZone(zone),
+		withHostname(hostname),
+		withSSLSettings(sslSettings.DeepCopy()),
+	)
+
+	// Unlike most mocks this one does not echo its input, so that the
+	// external name must come from the ID assigned by the API.
+	e := external{client: fake.MockClient{
+		MockCreateCustomHostname: func(ctx context.Context, zoneID string, ch cloudflare.CustomHostname) (*cloudflare.CustomHostnameResponse, error) {
+			if ch.ID != "" {
+				return nil, errors.Errorf("unexpected custom hostname ID %q", ch.ID)
+			}
+			ch.ID = "0d89c70d-ad9f-4843-b99f-6cc0252067e9"
+			return &cloudflare.CustomHostnameResponse{Result: ch}, nil
+		},
+	}}
+
+	got, err := e.Create(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Create(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(managed.ExternalCreation{ExternalNameAssigned: true}, got); diff != "" {
+		t.Errorf("e.Create(...): -want, +got:\n%s\n", diff)
+	}
+	if diff := cmp.Diff("0d89c70d-ad9f-4843-b99f-6cc0252067e9", meta.GetExternalName(cr)); diff != "" {
+		t.Errorf("e.Create(...): the external name should be the ID assigned by the API: -want, +got:\n%s\n", diff)
+	}
+}
+
 func TestUpdate(t *testing.T) {
 	errBoom := errors.New("boom")
 
 	type fields struct {
-		client customhostnames.Client
+		client   customhostnames.Client
+		observed *cloudflare.CustomHostname
 	}
 
 	type args struct {
@@ -459,6 +829,70 @@ func TestUpdate(t *testing.T) {
 				err: errors.New(errCustomHostnameUpdate),
 			},
 		},
+		"ErrCustomHostnameUpdateInvalidSSLType": {
+			reason: "We should return an error if the SSL type is not supported",
+			fields: fields{
+				client: fake.MockClient{
+					MockUpdateCustomHostname: func(ctx context.Context, zoneID, CustomHostnameID string, rr cloudflare.CustomHostname) (*cloudflare.CustomHostnameResponse, error) {
+						return &cloudflare.CustomHostnameResponse{}, nil
+					},
+				},
+			},
+			args: args{
+				mg: customHostname(
+					withExternalName(externalName),
+					withZone(zone),
+					withHostname(hostname),
+					withSSLSettings(sslSettings),
+					withSSLType("DV"),
+				),
+			},
+			want: want{
+				o:   managed.ExternalUpdate{},
+				err: errors.Wrap(errors.Errorf(errCustomHostnameInvalidSSLType, "DV"), errCustomHostnameUpdate),
+			},
+		},
+		"ErrCustomHostnameUpdateInvalidSSLMethod": {
+			reason: "We should return an error if the SSL method is not supported",
+			fields: fields{
+				client: fake.MockClient{
+					MockUpdateCustomHostname: func(ctx context.Context, zoneID, CustomHostnameID string, rr cloudflare.CustomHostname) (*cloudflare.CustomHostnameResponse, error) {
+						return &cloudflare.CustomHostnameResponse{}, nil
+					},
+				},
+			},
+			args: args{
+				mg: customHostname(
+					withExternalName(externalName),
+					withZone(zone),
+					withHostname(hostname),
+					withSSLSettings(sslSettings),
+					withSSLMethod("cname"),
+				),
+			},
+			want: want{
+				o:   managed.ExternalUpdate{},
+				err: errors.Wrap(errors.Errorf(errCustomHostnameInvalidSSLMethod, "cname"), errCustomHostnameUpdate),
+			},
+		},
+		"ErrNotObserved": {
+			reason: "We should return an error if the CustomHostname was not observed before it is updated",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: customHostname(
+					withExternalName(externalName),
+					withZone(zone),
+					withHostname(hostname),
+					withSSLSettings(sslSettings),
+				),
+			},
+			want: want{
+				o:   managed.ExternalUpdate{},
+				err: errors.New(errCustomHostnameUpdate),
+			},
+		},
 		"ErrCustomHostnameUpdate": {
 			reason: "We should return any errors during the update process",
 			fields: fields{
@@ -467,6 +901,7 @@ func TestUpdate(t *testing.T) {
 						return &cloudflare.CustomHostnameResponse{}, errBoom
 					},
 				},
+				observed: &cloudflare.CustomHostname{},
 			},
 			args: args{
 				mg: customHostname(
@@ -485,15 +920,11 @@ func TestUpdate(t *testing.T) {
 			reason: "We should return no error when a CustomHostname is updated",
 			fields: fields{
 				client: fake.MockClient{
-					MockCustomHostname: func(ctx context.Context, zoneID string, CustomHostnameID string) (cloudflare.CustomHostname, error) {
-						return cloudflare.CustomHostname{
-							ID: zoneID,
-						}, nil
-					},
 					MockUpdateCustomHostname: func(ctx context.Context, zoneID, CustomHostnameID string, rr cloudflare.CustomHostname) (*cloudflare.CustomHostnameResponse, error) {
 						return &cloudflare.CustomHostnameResponse{}, nil
 					},
 				},
+				observed: &cloudflare.CustomHostname{ID: externalName},
 			},
 			args: args{
 				mg: customHostname(
@@ -512,7 +943,7 @@ func TestUpdate(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{client: tc.fields.client}
+			e := external{client: tc.fields.client, observed: tc.fields.observed}
 			got, err := e.Update(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
@@ -524,8 +955,184 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestUpdateSSLMethodChange(t *testing.T) {
+	cr := customHostname(
+		withExternalName(externalName),
+		withZone(zone),
+		withHostname(hostname),
+		withSSLSettings(sslSettings.DeepCopy()),
+	)
+	cr.Status.AtProvider.SSL = v1alpha1.CustomHostnameSSLObserved{
+		Status:   "pending_validation",
+		Method:   "http",
+		HTTPUrl:  "http://" + hostname + "/.well-known/pki-validation/ca3-0123456789abcdef.txt",
+		HTTPBody: "ca3-0123456789abcdef",
+	}
+
+	e := external{observed: &cloudflare.CustomHostname{ID: externalName}, client: fake.MockClient{
+		MockUpdateCustomHostname: func(ctx context.Context, zoneID, customHostnameID string, ch cloudflare.CustomHostname) (*cloudflare.CustomHostnameResponse, error) {
+			if ch.SSL.Method != "txt" {
+				return nil, errors.Errorf("unexpected SSL method %q", ch.SSL.Method)
+			}
+			return &cloudflare.CustomHostnameResponse{
+				Result: cloudflare.CustomHostname{
+					ID:       customHostnameID,
+					Hostname: ch.Hostname,
+					Status:   "pending",
+					SSL: cloudflare.CustomHostnameSSL{
+						Status:      "pending_validation",
+						Method:      ch.SSL.Method,
+						CnameName:   "_acme-challenge." + hostname,
+						CnameTarget: "example.dcv.cloudflare.com",
+					},
+				},
+			}, nil
+		},
+	}}
+
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): unexpected error: %s", err)
+	}
+
+	want := v1alpha1.CustomHostnameSSLObserved{
+		Status:      "pending_validation",
+		Method:      "txt",
+		CnameName:   "_acme-challenge." + hostname,
+		CnameTarget: "example.dcv.cloudflare.com",
+	}
+	if diff := cmp.Diff(want, cr.Status.AtProvider.SSL); diff != "" {
+		t.Errorf("e.Update(...): changing the SSL method should surface its validation records: -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestUpdatePublishValidationRecords(t *testing.T) {
+	vzone := "customer.com"
+	cr := customHostname(
+		withExternalName(externalName),
+		withZone(zone),
+		withHostname(hostname),
+		withValidationRecordZone(vzone),
+	)
+	cr.Status.AtProvider.SSL.ValidationRecords = []v1alpha1.CustomHostnameSSLValidationRecord{
+		{TXTName: "_acme-challenge." + hostname, TXTValue: "abc"},
+	}
+
+	var created []string
+	e := external{observed: &cloudflare.CustomHostname{ID: externalName, Hostname: hostname}, client: fake.MockClient{
+		MockUpdateCustomHostname: func(ctx context.Context, zoneID, customHostnameID string, ch cloudflare.CustomHostname) (*cloudflare.CustomHostnameResponse, error) {
+			return nil, errors.New("an up to date custom hostname should not be updated")
+		},
+		MockDNSRecords: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+			return nil, nil
+		},
+		MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+			if method == http.MethodGet {
+				return json.RawMessage(`[]`), nil
+			}
+			if endpoint != "/zones/"+vzone+"/dns_records" {
+				return nil, errors.Errorf("unexpected endpoint %q", endpoint)
+			}
+			b, _ := json.Marshal(data)
+			created = append(created, string(b))
+			return json.RawMessage(`{"id":"1234"}`), nil
+		},
+	}}
+
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): unexpected error: %s", err)
+	}
+
+	want := []string{
+		`{"type":"TXT","name":"_acme-challenge.` + hostname + `","content":"abc","ttl":1,"comment":"crossplane: custom hostname ` + externalName + ` validation"}`,
+	}
+	if diff := cmp.Diff(want, created); diff != "" {
+		t.Errorf("e.Update(...): -want created records, +got created records:\n%s", diff)
+	}
+
+	published := []v1alpha1.CustomHostnameValidationDNSRecord{
+		{Zone: vzone, ID: "1234", Name: "_acme-challenge." + hostname, Value: "abc"},
+	}
+	if diff := cmp.Diff(published, cr.Status.AtProvider.ValidationDNSRecords); diff != "" {
+		t.Errorf("e.Update(...): -want published records, +got published records:\n%s", diff)
+	}
+}
+
+func TestUpdateDeleteValidationRecords(t *testing.T) {
+	vzone := "customer.com"
+	cr := customHostname(
+		withExternalName(externalName),
+		withZone(zone),
+		withHostname(hostname),
+		withValidationRecordZone(vzone),
+		withValidationDNSRecords(v1alpha1.CustomHostnameValidationDNSRecord{ID: "1234", Name: "_acme-challenge." + hostname, Value: "abc"}),
+	)
+
+	// Cloudflare no longer returns the validation records once the
+	// certificate is active.
+	cr.Status.AtProvider.SSL.Status = "active"
+
+	var deleted []string
+	e := external{observed: &cloudflare.CustomHostname{ID: externalName, Hostname: hostname}, client: fake.MockClient{
+		MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+			return json.RawMessage(`[]`), nil
+		},
+		MockDeleteDNSRecord: func(ctx context.Context, zoneID, recordID string) error {
+			if zoneID != vzone {
+				return errors.Errorf("unexpected zone %q", zoneID)
+			}
+			deleted = append(deleted, recordID)
+			return nil
+		},
+	}}
+
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff([]string{"1234"}, deleted); diff != "" {
+		t.Errorf("e.Update(...): -want deleted records, +got deleted records:\n%s", diff)
+	}
+	if len(cr.Status.AtProvider.ValidationDNSRecords) != 0 {
+		t.Errorf("e.Update(...): want no published records, got %v", cr.Status.AtProvider.ValidationDNSRecords)
+	}
+}
+
+func TestUpdateCustomOriginServerChange(t *testing.T) {
+	origin := "origin.zone.com"
+	cr := customHostname(
+		withExternalName(externalName),
+		withZone(zone),
+		withHostname(hostname),
+		withSSLSettings(sslSettings.DeepCopy()),
+		withCustomOriginServer(origin),
+	)
+
+	e := external{observed: &cloudflare.CustomHostname{ID: externalName}, client: fake.MockClient{
+		MockUpdateCustomHostname: func(ctx context.Context, zoneID, customHostnameID string, ch cloudflare.CustomHostname) (*cloudflare.CustomHostnameResponse, error) {
+			if ch.CustomOriginServer != origin {
+				return nil, errors.Errorf("unexpected custom origin server %q", ch.CustomOriginServer)
+			}
+			return &cloudflare.CustomHostnameResponse{
+				Result: cloudflare.CustomHostname{
+					ID:                 customHostnameID,
+					Hostname:           ch.Hostname,
+					CustomOriginServer: ch.CustomOriginServer,
+				},
+			}, nil
+		},
+	}}
+
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Errorf("e.Update(...): changing the custom origin server should update it: unexpected error: %s", err)
+	}
+}
+
 func TestDelete(t *testing.T) {
 	errBoom := errors.New("boom")
+	errDeprovisioning := &cloudflare.APIRequestError{
+		StatusCode: http.StatusConflict,
+		Errors:     []cloudflare.ResponseInfo{{Code: 1414}},
+	}
 
 	type fields struct {
 		client customhostnames.Client
@@ -592,6 +1199,95 @@ func TestDelete(t *testing.T) {
 				err: errors.Wrap(errBoom, errCustomHostnameDeletion),
 			},
 		},
+		"ErrCertificateDeprovisioning": {
+			reason: "We should return an error so that the delete is retried while the certificate is being deprovisioned",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteCustomHostname: func(ctx context.Context, zoneID, CustomHostnameID string) error {
+						return errDeprovisioning
+					},
+				},
+			},
+			args: args{
+				mg: customHostname(
+					withExternalName(externalName),
+					withZone(zone),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errDeprovisioning, errCustomHostnameDeprovisioning),
+			},
+		},
+		"ErrValidationRecordDelete": {
+			reason: "We should return any errors deleting the validation records of a CustomHostname",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`[]`), nil
+					},
+					MockDeleteDNSRecord: func(ctx context.Context, zoneID, recordID string) error {
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: customHostname(
+					withExternalName(externalName),
+					withZone(zone),
+					withValidationRecordZone(zone),
+					withValidationDNSRecords(v1alpha1.CustomHostnameValidationDNSRecord{ID: "1234", Name: "_acme-challenge." + hostname, Value: "abc"}),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errCustomHostnameValidationRecordDelete),
+			},
+		},
+		"ErrValidationRecordLookup": {
+			reason: "We should return any errors finding the tagged validation records of a CustomHostname",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: customHostname(
+					withExternalName(externalName),
+					withZone(zone),
+					withValidationRecordZone(zone),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errCustomHostnameValidationRecordDelete),
+			},
+		},
+		"ValidationRecordsWithoutZone": {
+			reason: "We should delete the validation records listed in status even when no validation record zone is set",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteDNSRecord: func(ctx context.Context, zoneID, recordID string) error {
+						if zoneID != "customer.com" || recordID != "1234" {
+							return errBoom
+						}
+						return nil
+					},
+					MockDeleteCustomHostname: func(ctx context.Context, zoneID, CustomHostnameID string) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: customHostname(
+					withExternalName(externalName),
+					withZone(zone),
+					withValidationDNSRecords(v1alpha1.CustomHostnameValidationDNSRecord{Zone: "customer.com", ID: "1234", Name: "_acme-challenge." + hostname, Value: "abc"}),
+				),
+			},
+			want: want{
+				err: nil,
+			},
+		},
 		"Success": {
 			reason: "We should return no error when a CustomHostname is deleted",
 			fields: fields{
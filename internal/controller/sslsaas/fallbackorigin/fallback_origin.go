@@ -18,18 +18,13 @@ package fallbackorigin
 
 import (
 	"context"
-	"time"
 
 	"github.com/pkg/errors"
-	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 
 	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
-	"github.com/crossplane/crossplane-runtime/pkg/logging"
-	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
@@ -38,6 +33,8 @@ import (
 	"github.com/benagricola/provider-cloudflare/apis/sslsaas/v1alpha1"
 	clients "github.com/benagricola/provider-cloudflare/internal/clients"
 	fallbackorigins "github.com/benagricola/provider-cloudflare/internal/clients/sslsaas/fallbackorigins"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
 	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
 )
 
@@ -52,40 +49,38 @@ const (
 	errFallbackOriginDeletion = "cannot delete fallback origin"
 	errFallbackOriginNoZone   = "cannot create fallback origin no zone found"
 
+	errFallbackOriginResolve   = "cannot lookup fallback origin DNS record"
+	errFallbackOriginNotInZone = "fallback origin %q is not a proxied A, AAAA or CNAME record in the zone"
+
 	// String returned if the Fallback Origin is active
 	fallbackOriginStatusActive = "active"
-
-	maxConcurrency = 5
 )
 
 // Setup adds a controller that reconciles FallbackOrigin managed resources.
-func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+func Setup(mgr ctrl.Manager, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.FallbackOriginGroupKind)
-
-	o := controller.Options{
-		RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
-		MaxConcurrentReconciles: maxConcurrency,
-	}
+	o = o.For(v1alpha1.FallbackOriginGroupKind)
 
 	hc := metrics.NewInstrumentedHTTPClient(name)
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.FallbackOriginGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
 			kube: mgr.GetClient(),
 			newCloudflareClientFn: func(cfg clients.Config) (fallbackorigins.Client, error) {
 				return fallbackorigins.NewClient(cfg, hc)
 			},
-		}),
-		managed.WithLogger(l.WithValues("controller", name)),
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
-		managed.WithPollInterval(5*time.Minute),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
 		// Do not initialize external-name field.
 		managed.WithInitializers(),
 	)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		WithOptions(o).
+		WithOptions(o.ForControllerRuntime()).
 		For(&v1alpha1.FallbackOrigin{}).
 		Complete(r)
 }
@@ -146,8 +141,12 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	cr.Status.AtProvider = fallbackorigins.GenerateObservation(fallbackorigin)
 
+	// The Fallback Origin is only ready once Cloudflare has deployed it,
+	// which happens some time after it is set.
 	if cr.Status.AtProvider.Status == fallbackOriginStatusActive {
 		cr.Status.SetConditions(rtv1.Available())
+	} else {
+		cr.Status.SetConditions(v1alpha1.FallbackOriginNotActive(cr.Status.AtProvider.Status, cr.Status.AtProvider.Errors))
 	}
 
 	return managed.ExternalObservation{
@@ -171,6 +170,10 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errFallbackOriginCreation)
 	}
 
+	if err := e.verifyOrigin(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errFallbackOriginCreation)
+	}
+
 	cr.SetConditions(rtv1.Creating())
 
 	_, err := e.client.UpdateCustomHostnameFallbackOrigin(
@@ -199,6 +202,10 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errFallbackOriginUpdate)
 	}
 
+	if err := e.verifyOrigin(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errFallbackOriginUpdate)
+	}
+
 	er := fallbackorigins.UpdateFallbackOrigin(ctx, e.client, &cr.Spec.ForProvider)
 
 	return managed.ExternalUpdate{},
@@ -208,6 +215,22 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		)
 }
 
+// verifyOrigin returns an error if the origin of the Fallback Origin does
+// not resolve within its zone, as Cloudflare cannot deploy it otherwise.
+func (e *external) verifyOrigin(ctx context.Context, cr *v1alpha1.FallbackOrigin) error {
+	if cr.Spec.ForProvider.Origin == nil {
+		return nil
+	}
+	ok, err := fallbackorigins.OriginResolves(ctx, e.client, *cr.Spec.ForProvider.Zone, *cr.Spec.ForProvider.Origin)
+	if err != nil {
+		return errors.Wrap(err, errFallbackOriginResolve)
+	}
+	if !ok {
+		return errors.Errorf(errFallbackOriginNotInZone, *cr.Spec.ForProvider.Origin)
+	}
+	return nil
+}
+
 func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 	cr, ok := mg.(*v1alpha1.FallbackOrigin)
 	if !ok {
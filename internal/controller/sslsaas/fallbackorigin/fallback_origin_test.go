@@ -26,6 +26,7 @@ import (
 	"github.com/pkg/errors"
 
 	corev1 "k8s.io/api/core/v1"
+	ptr "k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
@@ -72,6 +73,11 @@ const (
 	origin = "fallback.zone.com"
 )
 
+// originRecords returns a proxied DNS record for the fallback origin.
+func originRecords(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+	return []cloudflare.DNSRecord{{Type: "CNAME", Name: origin, Proxied: ptr.BoolPtr(true)}}, nil
+}
+
 func TestConnect(t *testing.T) {
 	mc := &test.MockClient{
 		MockGet: test.NewMockGetFn(nil),
@@ -293,6 +299,52 @@ func TestObserve(t *testing.T) {
 	}
 }
 
+func TestObserveReadiness(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		fo     cloudflare.CustomHostnameFallbackOrigin
+		want   xpv1.Condition
+	}{
+		"PendingDeployment": {
+			reason: "A fallback origin that is pending deployment should not be ready",
+			fo:     cloudflare.CustomHostnameFallbackOrigin{Origin: origin, Status: "pending_deployment"},
+			want:   v1alpha1.FallbackOriginNotActive("pending_deployment", nil),
+		},
+		"DeploymentTimedOut": {
+			reason: "A fallback origin that failed to deploy should not be ready and report its errors",
+			fo: cloudflare.CustomHostnameFallbackOrigin{
+				Origin: origin,
+				Status: "deployment_timed_out",
+				Errors: []string{"DNS records are not setup correctly"},
+			},
+			want: v1alpha1.FallbackOriginNotActive("deployment_timed_out", []string{"DNS records are not setup correctly"}),
+		},
+		"Active": {
+			reason: "An active fallback origin should be ready",
+			fo:     cloudflare.CustomHostnameFallbackOrigin{Origin: origin, Status: "active"},
+			want:   xpv1.Available(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: fake.MockClient{
+				MockCustomHostnameFallbackOrigin: func(ctx context.Context, zoneID string) (cloudflare.CustomHostnameFallbackOrigin, error) {
+					return tc.fo, nil
+				},
+			}}
+
+			cr := fallbackOrigin(withZone(zone), withOrigin(origin))
+			if _, err := e.Observe(context.Background(), cr); err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): unexpected error: %s\n", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, cr.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestCreate(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -329,6 +381,7 @@ func TestCreate(t *testing.T) {
 			reason: "We should return any errors during the create process",
 			fields: fields{
 				client: fake.MockClient{
+					MockDNSRecords: originRecords,
 					MockUpdateCustomHostnameFallbackOrigin: func(ctx context.Context, zoneID string, chfo cloudflare.CustomHostnameFallbackOrigin) (*cloudflare.CustomHostnameFallbackOriginResponse, error) {
 						return nil, errBoom
 					},
@@ -345,10 +398,31 @@ func TestCreate(t *testing.T) {
 				err: errors.Wrap(errBoom, errFallbackOriginCreation),
 			},
 		},
+		"ErrFallbackOriginNotInZone": {
+			reason: "We should return an error if the origin is not a proxied record in the zone",
+			fields: fields{
+				client: fake.MockClient{
+					MockDNSRecords: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+						return []cloudflare.DNSRecord{{Type: "CNAME", Name: origin, Proxied: ptr.BoolPtr(false)}}, nil
+					},
+				},
+			},
+			args: args{
+				mg: fallbackOrigin(
+					withZone(zone),
+					withOrigin(origin),
+				),
+			},
+			want: want{
+				o:   managed.ExternalCreation{},
+				err: errors.Wrap(errors.Errorf(errFallbackOriginNotInZone, origin), errFallbackOriginCreation),
+			},
+		},
 		"Success": {
 			reason: "We should return no error when a FallbackOrigin is created",
 			fields: fields{
 				client: fake.MockClient{
+					MockDNSRecords: originRecords,
 					MockUpdateCustomHostnameFallbackOrigin: func(ctx context.Context, zoneID string, chfo cloudflare.CustomHostnameFallbackOrigin) (*cloudflare.CustomHostnameFallbackOriginResponse, error) {
 						return &cloudflare.CustomHostnameFallbackOriginResponse{
 							Result: chfo,
@@ -419,6 +493,7 @@ func TestUpdate(t *testing.T) {
 			reason: "We should return any errors during the update process",
 			fields: fields{
 				client: fake.MockClient{
+					MockDNSRecords: originRecords,
 					MockUpdateCustomHostnameFallbackOrigin: func(ctx context.Context, zoneID string, chfo cloudflare.CustomHostnameFallbackOrigin) (*cloudflare.CustomHostnameFallbackOriginResponse, error) {
 						return &cloudflare.CustomHostnameFallbackOriginResponse{}, errBoom
 					},
@@ -435,6 +510,26 @@ func TestUpdate(t *testing.T) {
 				err: errors.Wrap(errBoom, errFallbackOriginUpdate),
 			},
 		},
+		"ErrFallbackOriginNotInZone": {
+			reason: "We should return an error if the origin is not a proxied record in the zone",
+			fields: fields{
+				client: fake.MockClient{
+					MockDNSRecords: func(ctx context.Context, zoneID string, rr cloudflare.DNSRecord) ([]cloudflare.DNSRecord, error) {
+						return []cloudflare.DNSRecord{{Type: "CNAME", Name: origin, Proxied: ptr.BoolPtr(false)}}, nil
+					},
+				},
+			},
+			args: args{
+				mg: fallbackOrigin(
+					withZone(zone),
+					withOrigin(origin),
+				),
+			},
+			want: want{
+				o:   managed.ExternalUpdate{},
+				err: errors.Wrap(errors.Errorf(errFallbackOriginNotInZone, origin), errFallbackOriginUpdate),
+			},
+		},
 		"Success": {
 			reason: "We should return no error when a FallbackOrigin is updated",
 			fields: fields{
@@ -444,6 +539,7 @@ func TestUpdate(t *testing.T) {
 							Origin: origin,
 						}, nil
 					},
+					MockDNSRecords: originRecords,
 					MockUpdateCustomHostnameFallbackOrigin: func(ctx context.Context, zoneID string, chfo cloudflare.CustomHostnameFallbackOrigin) (*cloudflare.CustomHostnameFallbackOriginResponse, error) {
 						return &cloudflare.CustomHostnameFallbackOriginResponse{}, nil
 					},
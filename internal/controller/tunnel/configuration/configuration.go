@@ -0,0 +1,193 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/tunnel/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/tunnel/configuration"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotTunnelConfiguration = "managed resource is not a TunnelConfiguration custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errTunnelConfigurationLookup   = "cannot lookup tunnel configuration"
+	errTunnelConfigurationCreation = "cannot create tunnel configuration"
+	errTunnelConfigurationUpdate   = "cannot update tunnel configuration"
+	errTunnelConfigurationDeletion = "cannot delete tunnel configuration"
+	errNoAccount                   = "no account found"
+	errNoTunnel                    = "no tunnel found"
+)
+
+// Setup adds a controller that reconciles TunnelConfiguration managed
+// resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.TunnelConfigurationGroupKind)
+	o = o.For(v1alpha1.TunnelConfigurationGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.TunnelConfigurationGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (configuration.Client, error) {
+				return configuration.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.TunnelConfiguration{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (configuration.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.TunnelConfiguration)
+	if !ok {
+		return nil, errors.New(errNotTunnelConfiguration)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client configuration.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.TunnelConfiguration)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTunnelConfiguration)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalObservation{}, errors.New(errNoAccount)
+	}
+
+	if cr.Spec.ForProvider.Tunnel == nil {
+		return managed.ExternalObservation{}, errors.New(errNoTunnel)
+	}
+
+	c, err := configuration.TunnelConfiguration(ctx, e.client, *cr.Spec.ForProvider.Account, *cr.Spec.ForProvider.Tunnel)
+	if err != nil {
+		return managed.ExternalObservation{},
+			errors.Wrap(resource.Ignore(configuration.IsTunnelConfigurationNotFound, err), errTunnelConfigurationLookup)
+	}
+
+	// A Tunnel always has a configuration, so treat one without
+	// ingress rules as not existing.
+	if !configuration.Exists(c) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider = configuration.GenerateObservation(*c)
+
+	cr.SetConditions(rtv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: configuration.UpToDate(&cr.Spec.ForProvider, *c),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.TunnelConfiguration)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTunnelConfiguration)
+	}
+
+	cr.SetConditions(rtv1.Creating())
+
+	return managed.ExternalCreation{},
+		errors.Wrap(configuration.UpdateTunnelConfiguration(ctx, e.client, cr.Spec.ForProvider), errTunnelConfigurationCreation)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.TunnelConfiguration)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotTunnelConfiguration)
+	}
+
+	return managed.ExternalUpdate{},
+		errors.Wrap(configuration.UpdateTunnelConfiguration(ctx, e.client, cr.Spec.ForProvider), errTunnelConfigurationUpdate)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.TunnelConfiguration)
+	if !ok {
+		return errors.New(errNotTunnelConfiguration)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return errors.Wrap(errors.New(errNoAccount), errTunnelConfigurationDeletion)
+	}
+
+	if cr.Spec.ForProvider.Tunnel == nil {
+		return errors.Wrap(errors.New(errNoTunnel), errTunnelConfigurationDeletion)
+	}
+
+	return errors.Wrap(
+		resource.Ignore(configuration.IsTunnelConfigurationNotFound,
+			configuration.DeleteTunnelConfiguration(ctx, e.client, *cr.Spec.ForProvider.Account, *cr.Spec.ForProvider.Tunnel)),
+		errTunnelConfigurationDeletion)
+}
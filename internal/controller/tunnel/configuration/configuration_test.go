@@ -0,0 +1,624 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/benagricola/provider-cloudflare/apis/tunnel/v1alpha1"
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/tunnel/configuration"
+	"github.com/benagricola/provider-cloudflare/internal/clients/tunnel/configuration/fake"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+type configurationModifier func(*v1alpha1.TunnelConfiguration)
+
+func withAccount(account string) configurationModifier {
+	return func(c *v1alpha1.TunnelConfiguration) { c.Spec.ForProvider.Account = &account }
+}
+
+func withTunnel(tunnel string) configurationModifier {
+	return func(c *v1alpha1.TunnelConfiguration) { c.Spec.ForProvider.Tunnel = &tunnel }
+}
+
+func withIngress(service string) configurationModifier {
+	return func(c *v1alpha1.TunnelConfiguration) {
+		c.Spec.ForProvider.Ingress = append(c.Spec.ForProvider.Ingress, v1alpha1.TunnelIngressRule{Service: service})
+	}
+}
+
+func configurationBuild(m ...configurationModifier) *v1alpha1.TunnelConfiguration {
+	cr := &v1alpha1.TunnelConfiguration{}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (configuration.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTunnelConfiguration": {
+			reason: "An error should be returned if the managed resource is not a *TunnelConfiguration",
+			fields: fields{
+				newClient: configuration.NewClient,
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTunnelConfiguration),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube:      mc,
+				newClient: configuration.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.TunnelConfiguration{
+					Spec: v1alpha1.TunnelConfigurationSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when a client is created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *pcv1alpha1.ProviderConfig:
+							o.Spec.Credentials.Source = "Secret"
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								Key: "creds",
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{
+								"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+							}
+						}
+						return nil
+					}),
+				},
+				newClient: configuration.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.TunnelConfiguration{
+					Spec: v1alpha1.TunnelConfigurationSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (configuration.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client configuration.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTunnelConfiguration": {
+			reason: "An error should be returned if the managed resource is not a *TunnelConfiguration",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTunnelConfiguration),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the Tunnel Configuration has no account",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: configurationBuild(),
+			},
+			want: want{
+				err: errors.New(errNoAccount),
+			},
+		},
+		"ErrNoTunnel": {
+			reason: "We should return an error if the Tunnel Configuration has no tunnel",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: configurationBuild(withAccount("Test Account")),
+			},
+			want: want{
+				err: errors.New(errNoTunnel),
+			},
+		},
+		"ErrTunnelConfigurationLookup": {
+			reason: "We should return an empty observation and an error if the API returned an error",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: configurationBuild(
+					withAccount("Test Account"),
+					withTunnel("f174e90a-fafe-4643-bbbc-4a0ed4fc8415"),
+					withIngress("http_status:404"),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errTunnelConfigurationLookup),
+			},
+		},
+		"TunnelConfigurationEmpty": {
+			reason: "We should return ResourceExists: false if the Tunnel has no ingress rules",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"version":0,"config":null}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: configurationBuild(
+					withAccount("Test Account"),
+					withTunnel("f174e90a-fafe-4643-bbbc-4a0ed4fc8415"),
+					withIngress("http_status:404"),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Success": {
+			reason: "We should return ResourceExists: true and no error when a Tunnel Configuration is found",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"version":3,"config":{"ingress":[{"service":"http_status:404"}]}}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: configurationBuild(
+					withAccount("Test Account"),
+					withTunnel("f174e90a-fafe-4643-bbbc-4a0ed4fc8415"),
+					withIngress("http_status:404"),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client configuration.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTunnelConfiguration": {
+			reason: "An error should be returned if the managed resource is not a *TunnelConfiguration",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTunnelConfiguration),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the Tunnel Configuration has no account",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: configurationBuild(),
+			},
+			want: want{
+				err: errors.Wrap(errors.New("account ID is required"), errTunnelConfigurationCreation),
+			},
+		},
+		"ErrTunnelConfigurationCreate": {
+			reason: "We should return any errors during the create process",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: configurationBuild(
+					withAccount("Test Account"),
+					withTunnel("f174e90a-fafe-4643-bbbc-4a0ed4fc8415"),
+					withIngress("http_status:404"),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errTunnelConfigurationCreation),
+			},
+		},
+		"Success": {
+			reason: "We should return no error when a Tunnel Configuration is created",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: configurationBuild(
+					withAccount("Test Account"),
+					withTunnel("f174e90a-fafe-4643-bbbc-4a0ed4fc8415"),
+					withIngress("http_status:404"),
+				),
+			},
+			want: want{
+				o: managed.ExternalCreation{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client configuration.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTunnelConfiguration": {
+			reason: "An error should be returned if the managed resource is not a *TunnelConfiguration",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTunnelConfiguration),
+			},
+		},
+		"ErrTunnelConfigurationUpdate": {
+			reason: "We should return any errors during the update process",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: configurationBuild(
+					withAccount("Test Account"),
+					withTunnel("f174e90a-fafe-4643-bbbc-4a0ed4fc8415"),
+					withIngress("http_status:404"),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errTunnelConfigurationUpdate),
+			},
+		},
+		"Success": {
+			reason: "We should return no error when a Tunnel Configuration is updated",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: configurationBuild(
+					withAccount("Test Account"),
+					withTunnel("f174e90a-fafe-4643-bbbc-4a0ed4fc8415"),
+					withIngress("http_status:404"),
+				),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client configuration.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTunnelConfiguration": {
+			reason: "An error should be returned if the managed resource is not a *TunnelConfiguration",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTunnelConfiguration),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the Tunnel Configuration has no account",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: configurationBuild(),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoAccount), errTunnelConfigurationDeletion),
+			},
+		},
+		"ErrNoTunnel": {
+			reason: "We should return an error if the Tunnel Configuration has no tunnel",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: configurationBuild(withAccount("Test Account")),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoTunnel), errTunnelConfigurationDeletion),
+			},
+		},
+		"ErrTunnelConfigurationDelete": {
+			reason: "We should return any errors during the delete process",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: configurationBuild(
+					withAccount("Test Account"),
+					withTunnel("f174e90a-fafe-4643-bbbc-4a0ed4fc8415"),
+					withIngress("http_status:404"),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errTunnelConfigurationDeletion),
+			},
+		},
+		"Success": {
+			reason: "We should return no error when a Tunnel Configuration is deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: configurationBuild(
+					withAccount("Test Account"),
+					withTunnel("f174e90a-fafe-4643-bbbc-4a0ed4fc8415"),
+					withIngress("http_status:404"),
+				),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,612 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/benagricola/provider-cloudflare/apis/tunnel/v1alpha1"
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/tunnel/route"
+	"github.com/benagricola/provider-cloudflare/internal/clients/tunnel/route/fake"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+type routeModifier func(*v1alpha1.TunnelRoute)
+
+func withAccount(account string) routeModifier {
+	return func(r *v1alpha1.TunnelRoute) { r.Spec.ForProvider.Account = &account }
+}
+
+func withTunnel(tunnel string) routeModifier {
+	return func(r *v1alpha1.TunnelRoute) { r.Spec.ForProvider.Tunnel = &tunnel }
+}
+
+func withNetwork(network string) routeModifier {
+	return func(r *v1alpha1.TunnelRoute) { r.Spec.ForProvider.Network = network }
+}
+
+func withExternalName(routeID string) routeModifier {
+	return func(r *v1alpha1.TunnelRoute) { meta.SetExternalName(r, routeID) }
+}
+
+func routeBuild(m ...routeModifier) *v1alpha1.TunnelRoute {
+	cr := &v1alpha1.TunnelRoute{}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (route.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTunnelRoute": {
+			reason: "An error should be returned if the managed resource is not a *TunnelRoute",
+			fields: fields{
+				newClient: route.NewClient,
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTunnelRoute),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube:      mc,
+				newClient: route.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.TunnelRoute{
+					Spec: v1alpha1.TunnelRouteSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when a client is created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *pcv1alpha1.ProviderConfig:
+							o.Spec.Credentials.Source = "Secret"
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								Key: "creds",
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{
+								"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+							}
+						}
+						return nil
+					}),
+				},
+				newClient: route.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.TunnelRoute{
+					Spec: v1alpha1.TunnelRouteSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (route.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client route.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTunnelRoute": {
+			reason: "An error should be returned if the managed resource is not a *TunnelRoute",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTunnelRoute),
+			},
+		},
+		"ErrNoTunnelRoute": {
+			reason: "We should return ResourceExists: false when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: &v1alpha1.TunnelRoute{},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the Tunnel Route has no account",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: routeBuild(withExternalName("f70ff985-a4ef-4643-bbbc-4a0ed4fc8415")),
+			},
+			want: want{
+				err: errors.New(errNoAccount),
+			},
+		},
+		"ErrTunnelRouteLookup": {
+			reason: "We should return an empty observation and an error if the API returned an error",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: routeBuild(
+					withAccount("Test Account"),
+					withExternalName("f70ff985-a4ef-4643-bbbc-4a0ed4fc8415"),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errTunnelRouteLookup),
+			},
+		},
+		"TunnelRouteDeleted": {
+			reason: "We should return ResourceExists: false if the Tunnel Route has been deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"id":"f70ff985-a4ef-4643-bbbc-4a0ed4fc8415","deleted_at":"2021-01-25T18:22:34.317854Z"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: routeBuild(
+					withAccount("Test Account"),
+					withExternalName("f70ff985-a4ef-4643-bbbc-4a0ed4fc8415"),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Success": {
+			reason: "We should return ResourceExists: true and no error when a Tunnel Route is found",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"id":"f70ff985-a4ef-4643-bbbc-4a0ed4fc8415","network":"172.16.0.0/16","tunnel_id":"f174e90a-fafe-4643-bbbc-4a0ed4fc8415"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: routeBuild(
+					withAccount("Test Account"),
+					withTunnel("f174e90a-fafe-4643-bbbc-4a0ed4fc8415"),
+					withNetwork("172.16.0.0/16"),
+					withExternalName("f70ff985-a4ef-4643-bbbc-4a0ed4fc8415"),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client route.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTunnelRoute": {
+			reason: "An error should be returned if the managed resource is not a *TunnelRoute",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTunnelRoute),
+			},
+		},
+		"ErrTunnelRouteCreate": {
+			reason: "We should return any errors during the create process",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: routeBuild(
+					withAccount("Test Account"),
+					withTunnel("f174e90a-fafe-4643-bbbc-4a0ed4fc8415"),
+					withNetwork("172.16.0.0/16"),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errTunnelRouteCreation),
+			},
+		},
+		"Success": {
+			reason: "We should return ExternalNameAssigned: true and no error when a Tunnel Route is created",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"id":"f70ff985-a4ef-4643-bbbc-4a0ed4fc8415","network":"172.16.0.0/16"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: routeBuild(
+					withAccount("Test Account"),
+					withTunnel("f174e90a-fafe-4643-bbbc-4a0ed4fc8415"),
+					withNetwork("172.16.0.0/16"),
+				),
+			},
+			want: want{
+				o: managed.ExternalCreation{ExternalNameAssigned: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client route.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTunnelRoute": {
+			reason: "An error should be returned if the managed resource is not a *TunnelRoute",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTunnelRoute),
+			},
+		},
+		"ErrNoTunnelRoute": {
+			reason: "We should return an error when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: routeBuild(withAccount("Test Account")),
+			},
+			want: want{
+				err: errors.New(errTunnelRouteUpdate),
+			},
+		},
+		"ErrTunnelRouteUpdate": {
+			reason: "We should return any errors during the update process",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: routeBuild(
+					withAccount("Test Account"),
+					withTunnel("f174e90a-fafe-4643-bbbc-4a0ed4fc8415"),
+					withExternalName("f70ff985-a4ef-4643-bbbc-4a0ed4fc8415"),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errTunnelRouteUpdate),
+			},
+		},
+		"Success": {
+			reason: "We should return no error when a Tunnel Route is updated",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: routeBuild(
+					withAccount("Test Account"),
+					withTunnel("f174e90a-fafe-4643-bbbc-4a0ed4fc8415"),
+					withExternalName("f70ff985-a4ef-4643-bbbc-4a0ed4fc8415"),
+				),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client route.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTunnelRoute": {
+			reason: "An error should be returned if the managed resource is not a *TunnelRoute",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTunnelRoute),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the Tunnel Route has no account",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: routeBuild(withExternalName("f70ff985-a4ef-4643-bbbc-4a0ed4fc8415")),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoAccount), errTunnelRouteDeletion),
+			},
+		},
+		"ErrTunnelRouteDelete": {
+			reason: "We should return any errors during the delete process",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: routeBuild(
+					withAccount("Test Account"),
+					withExternalName("f70ff985-a4ef-4643-bbbc-4a0ed4fc8415"),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errTunnelRouteDeletion),
+			},
+		},
+		"Success": {
+			reason: "We should return no error when a Tunnel Route is deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: routeBuild(
+					withAccount("Test Account"),
+					withExternalName("f70ff985-a4ef-4643-bbbc-4a0ed4fc8415"),
+				),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
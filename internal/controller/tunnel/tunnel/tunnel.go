@@ -0,0 +1,221 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/tunnel/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/tunnel/tunnel"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotTunnel = "managed resource is not a Tunnel custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errTunnelLookup   = "cannot lookup tunnel"
+	errTunnelCreation = "cannot create tunnel"
+	errTunnelDeletion = "cannot delete tunnel"
+	errNoAccount      = "no account found"
+)
+
+// Setup adds a controller that reconciles Tunnel managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.TunnelGroupKind)
+	o = o.For(v1alpha1.TunnelGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.TunnelGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (tunnel.Client, error) {
+				return tunnel.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.Tunnel{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (tunnel.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.Tunnel)
+	if !ok {
+		return nil, errors.New(errNotTunnel)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client tunnel.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Tunnel)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTunnel)
+	}
+
+	// Tunnel does not exist if we dont have an ID stored in external-name
+	tid := meta.GetExternalName(cr)
+	if tid == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalObservation{}, errors.New(errNoAccount)
+	}
+
+	t, err := e.client.ArgoTunnel(ctx, *cr.Spec.ForProvider.Account, tid)
+	if err != nil {
+		return managed.ExternalObservation{},
+			errors.Wrap(resource.Ignore(tunnel.IsTunnelNotFound, err), errTunnelLookup)
+	}
+
+	// Deleted Tunnels are still returned by the API for a while.
+	if t.DeletedAt != nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider = tunnel.GenerateObservation(t)
+
+	cr.Status.SetConditions(rtv1.Available())
+
+	// The name and secret of a Tunnel cannot be changed, so it is
+	// always up to date.
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Tunnel)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTunnel)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalCreation{}, errors.New(errNoAccount)
+	}
+
+	name := cr.GetName()
+	if cr.Spec.ForProvider.Name != nil {
+		name = *cr.Spec.ForProvider.Name
+	}
+
+	t, cd, err := tunnel.CreateTunnel(ctx, e.client, *cr.Spec.ForProvider.Account, name)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errTunnelCreation)
+	}
+
+	cr.Status.AtProvider = tunnel.GenerateObservation(*t)
+
+	// Update the external name with the ID of the new Tunnel
+	meta.SetExternalName(cr, t.ID)
+
+	return managed.ExternalCreation{
+		ExternalNameAssigned: true,
+		ConnectionDetails:    cd,
+	}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	_, ok := mg.(*v1alpha1.Tunnel)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotTunnel)
+	}
+
+	// Tunnels have no mutable fields.
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Tunnel)
+	if !ok {
+		return errors.New(errNotTunnel)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return errors.Wrap(errors.New(errNoAccount), errTunnelDeletion)
+	}
+
+	tid := meta.GetExternalName(cr)
+
+	// Delete should never be called on a nonexistent resource
+	if tid == "" {
+		return errors.New(errTunnelDeletion)
+	}
+
+	// A Tunnel cannot be deleted while cloudflared connections remain.
+	if err := e.client.CleanupArgoTunnelConnections(ctx, *cr.Spec.ForProvider.Account, tid); resource.Ignore(tunnel.IsTunnelNotFound, err) != nil {
+		return errors.Wrap(err, errTunnelDeletion)
+	}
+
+	return errors.Wrap(
+		resource.Ignore(tunnel.IsTunnelNotFound,
+			e.client.DeleteArgoTunnel(ctx, *cr.Spec.ForProvider.Account, tid)),
+		errTunnelDeletion)
+}
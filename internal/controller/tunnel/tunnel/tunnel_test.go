@@ -0,0 +1,549 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/benagricola/provider-cloudflare/apis/tunnel/v1alpha1"
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/tunnel/tunnel"
+	"github.com/benagricola/provider-cloudflare/internal/clients/tunnel/tunnel/fake"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+type tunnelModifier func(*v1alpha1.Tunnel)
+
+func withAccount(account string) tunnelModifier {
+	return func(t *v1alpha1.Tunnel) { t.Spec.ForProvider.Account = &account }
+}
+
+func withName(name string) tunnelModifier {
+	return func(t *v1alpha1.Tunnel) { t.Spec.ForProvider.Name = &name }
+}
+
+func withExternalName(tunnelID string) tunnelModifier {
+	return func(t *v1alpha1.Tunnel) { meta.SetExternalName(t, tunnelID) }
+}
+
+func tunnelBuild(m ...tunnelModifier) *v1alpha1.Tunnel {
+	cr := &v1alpha1.Tunnel{}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (tunnel.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTunnel": {
+			reason: "An error should be returned if the managed resource is not a *Tunnel",
+			fields: fields{
+				newClient: tunnel.NewClient,
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTunnel),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube:      mc,
+				newClient: tunnel.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.Tunnel{
+					Spec: v1alpha1.TunnelSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when a client is created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *pcv1alpha1.ProviderConfig:
+							o.Spec.Credentials.Source = "Secret"
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								Key: "creds",
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{
+								"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+							}
+						}
+						return nil
+					}),
+				},
+				newClient: tunnel.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.Tunnel{
+					Spec: v1alpha1.TunnelSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (tunnel.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	deleted := time.Now()
+
+	type fields struct {
+		client tunnel.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTunnel": {
+			reason: "An error should be returned if the managed resource is not a *Tunnel",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTunnel),
+			},
+		},
+		"ErrNoTunnel": {
+			reason: "We should return ResourceExists: false when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: &v1alpha1.Tunnel{},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the Tunnel has no account",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: tunnelBuild(withExternalName("f174e90a-fafe-4643-bbbc-4a0ed4fc8415")),
+			},
+			want: want{
+				err: errors.New(errNoAccount),
+			},
+		},
+		"ErrTunnelLookup": {
+			reason: "We should return an empty observation and an error if the API returned an error",
+			fields: fields{
+				client: fake.MockClient{
+					MockArgoTunnel: func(ctx context.Context, accountID, tunnelUUID string) (cloudflare.ArgoTunnel, error) {
+						return cloudflare.ArgoTunnel{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: tunnelBuild(
+					withAccount("Test Account"),
+					withExternalName("f174e90a-fafe-4643-bbbc-4a0ed4fc8415"),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errTunnelLookup),
+			},
+		},
+		"TunnelDeleted": {
+			reason: "We should return ResourceExists: false if the Tunnel has been deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockArgoTunnel: func(ctx context.Context, accountID, tunnelUUID string) (cloudflare.ArgoTunnel, error) {
+						return cloudflare.ArgoTunnel{ID: tunnelUUID, DeletedAt: &deleted}, nil
+					},
+				},
+			},
+			args: args{
+				mg: tunnelBuild(
+					withAccount("Test Account"),
+					withExternalName("f174e90a-fafe-4643-bbbc-4a0ed4fc8415"),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Success": {
+			reason: "We should return ResourceExists: true and no error when a Tunnel is found",
+			fields: fields{
+				client: fake.MockClient{
+					MockArgoTunnel: func(ctx context.Context, accountID, tunnelUUID string) (cloudflare.ArgoTunnel, error) {
+						return cloudflare.ArgoTunnel{ID: tunnelUUID, Name: "example"}, nil
+					},
+				},
+			},
+			args: args{
+				mg: tunnelBuild(
+					withAccount("Test Account"),
+					withExternalName("f174e90a-fafe-4643-bbbc-4a0ed4fc8415"),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client tunnel.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTunnel": {
+			reason: "An error should be returned if the managed resource is not a *Tunnel",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTunnel),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the Tunnel has no account",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: tunnelBuild(),
+			},
+			want: want{
+				err: errors.New(errNoAccount),
+			},
+		},
+		"ErrTunnelCreate": {
+			reason: "We should return any errors during the create process",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateArgoTunnel: func(ctx context.Context, accountID, name, secret string) (cloudflare.ArgoTunnel, error) {
+						return cloudflare.ArgoTunnel{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: tunnelBuild(withAccount("Test Account"), withName("example")),
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errBoom, "error creating tunnel"), errTunnelCreation),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreateConnectionDetails(t *testing.T) {
+	var name, secret string
+	e := external{client: fake.MockClient{
+		MockCreateArgoTunnel: func(ctx context.Context, accountID, n, s string) (cloudflare.ArgoTunnel, error) {
+			name, secret = n, s
+			return cloudflare.ArgoTunnel{ID: "f174e90a-fafe-4643-bbbc-4a0ed4fc8415", Name: n}, nil
+		},
+	}}
+
+	cr := tunnelBuild(withAccount("Test Account"))
+	cr.SetName("example")
+
+	got, err := e.Create(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Create(...): unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff("example", name); diff != "" {
+		t.Errorf("e.Create(...): Tunnel name should default to the resource name: -want, +got:\n%s\n", diff)
+	}
+
+	want, _ := tunnel.ConnectionDetails("Test Account", secret, cloudflare.ArgoTunnel{ID: "f174e90a-fafe-4643-bbbc-4a0ed4fc8415", Name: "example"})
+	if diff := cmp.Diff(managed.ExternalCreation{ExternalNameAssigned: true, ConnectionDetails: want}, got); diff != "" {
+		t.Errorf("e.Create(...): -want, +got:\n%s\n", diff)
+	}
+
+	if diff := cmp.Diff("f174e90a-fafe-4643-bbbc-4a0ed4fc8415", meta.GetExternalName(cr)); diff != "" {
+		t.Errorf("e.Create(...): -want external name, +got external name:\n%s\n", diff)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client tunnel.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTunnel": {
+			reason: "An error should be returned if the managed resource is not a *Tunnel",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTunnel),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the Tunnel has no account",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: tunnelBuild(withExternalName("f174e90a-fafe-4643-bbbc-4a0ed4fc8415")),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoAccount), errTunnelDeletion),
+			},
+		},
+		"ErrCleanupConnections": {
+			reason: "We should return any errors cleaning up Tunnel connections",
+			fields: fields{
+				client: fake.MockClient{
+					MockCleanupArgoTunnelConnections: func(ctx context.Context, accountID, tunnelUUID string) error {
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: tunnelBuild(
+					withAccount("Test Account"),
+					withExternalName("f174e90a-fafe-4643-bbbc-4a0ed4fc8415"),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errTunnelDeletion),
+			},
+		},
+		"ErrTunnelDelete": {
+			reason: "We should return any errors during the delete process",
+			fields: fields{
+				client: fake.MockClient{
+					MockCleanupArgoTunnelConnections: func(ctx context.Context, accountID, tunnelUUID string) error {
+						return nil
+					},
+					MockDeleteArgoTunnel: func(ctx context.Context, accountID, tunnelUUID string) error {
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: tunnelBuild(
+					withAccount("Test Account"),
+					withExternalName("f174e90a-fafe-4643-bbbc-4a0ed4fc8415"),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errTunnelDeletion),
+			},
+		},
+		"Success": {
+			reason: "We should return no error when a Tunnel is deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockCleanupArgoTunnelConnections: func(ctx context.Context, accountID, tunnelUUID string) error {
+						return nil
+					},
+					MockDeleteArgoTunnel: func(ctx context.Context, accountID, tunnelUUID string) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: tunnelBuild(
+					withAccount("Test Account"),
+					withExternalName("f174e90a-fafe-4643-bbbc-4a0ed4fc8415"),
+				),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
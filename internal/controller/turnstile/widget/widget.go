@@ -0,0 +1,217 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package widget
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/turnstile/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/turnstile"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotTurnstileWidget = "managed resource is not a TurnstileWidget custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errTurnstileWidgetLookup   = "cannot lookup turnstile widget"
+	errTurnstileWidgetCreation = "cannot create turnstile widget"
+	errTurnstileWidgetUpdate   = "cannot update turnstile widget"
+	errTurnstileWidgetDeletion = "cannot delete turnstile widget"
+	errNoAccount               = "no account found"
+)
+
+// Setup adds a controller that reconciles TurnstileWidget managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.TurnstileWidgetGroupKind)
+	o = o.For(v1alpha1.TurnstileWidgetGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.TurnstileWidgetGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (turnstile.Client, error) {
+				return turnstile.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.TurnstileWidget{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (turnstile.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.TurnstileWidget)
+	if !ok {
+		return nil, errors.New(errNotTurnstileWidget)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client turnstile.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.TurnstileWidget)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTurnstileWidget)
+	}
+
+	// TurnstileWidget does not exist if we dont have a sitekey stored in external-name
+	sitekey := meta.GetExternalName(cr)
+	if sitekey == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalObservation{}, errors.New(errNoAccount)
+	}
+
+	w, err := turnstile.GetWidget(ctx, e.client, *cr.Spec.ForProvider.Account, sitekey)
+	if err != nil {
+		return managed.ExternalObservation{},
+			errors.Wrap(resource.Ignore(turnstile.IsWidgetNotFound, err), errTurnstileWidgetLookup)
+	}
+
+	cr.Status.AtProvider = turnstile.GenerateObservation(*w)
+
+	cr.SetConditions(rtv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  turnstile.UpToDate(&cr.Spec.ForProvider, *w),
+		ConnectionDetails: turnstile.ConnectionDetails(*w),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.TurnstileWidget)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTurnstileWidget)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalCreation{}, errors.Wrap(errors.New(errNoAccount), errTurnstileWidgetCreation)
+	}
+
+	w, err := turnstile.CreateWidget(ctx, e.client, *cr.Spec.ForProvider.Account, cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errTurnstileWidgetCreation)
+	}
+
+	cr.Status.AtProvider = turnstile.GenerateObservation(*w)
+
+	// Update the external name with the sitekey of the new TurnstileWidget
+	meta.SetExternalName(cr, w.Sitekey)
+
+	return managed.ExternalCreation{
+		ExternalNameAssigned: true,
+		ConnectionDetails:    turnstile.ConnectionDetails(*w),
+	}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.TurnstileWidget)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotTurnstileWidget)
+	}
+
+	sitekey := meta.GetExternalName(cr)
+
+	// Update should never be called on a nonexistent resource
+	if sitekey == "" {
+		return managed.ExternalUpdate{}, errors.New(errTurnstileWidgetUpdate)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalUpdate{}, errors.Wrap(errors.New(errNoAccount), errTurnstileWidgetUpdate)
+	}
+
+	return managed.ExternalUpdate{},
+		errors.Wrap(turnstile.UpdateWidget(ctx, e.client, *cr.Spec.ForProvider.Account, sitekey, cr.Spec.ForProvider), errTurnstileWidgetUpdate)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.TurnstileWidget)
+	if !ok {
+		return errors.New(errNotTurnstileWidget)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return errors.Wrap(errors.New(errNoAccount), errTurnstileWidgetDeletion)
+	}
+
+	sitekey := meta.GetExternalName(cr)
+
+	// Delete should never be called on a nonexistent resource
+	if sitekey == "" {
+		return errors.New(errTurnstileWidgetDeletion)
+	}
+
+	return errors.Wrap(
+		resource.Ignore(turnstile.IsWidgetNotFound,
+			turnstile.DeleteWidget(ctx, e.client, *cr.Spec.ForProvider.Account, sitekey)),
+		errTurnstileWidgetDeletion)
+}
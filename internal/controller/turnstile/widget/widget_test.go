@@ -0,0 +1,663 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package widget
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/benagricola/provider-cloudflare/apis/turnstile/v1alpha1"
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/turnstile"
+	"github.com/benagricola/provider-cloudflare/internal/clients/turnstile/fake"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const (
+	testAccount = "023e105f4ecef8ad9ca31a8372d0c353"
+	testSitekey = "0x4AAF00AAAABn0R22HWm-YUc"
+)
+
+type widgetModifier func(*v1alpha1.TurnstileWidget)
+
+func withAccount(account string) widgetModifier {
+	return func(w *v1alpha1.TurnstileWidget) { w.Spec.ForProvider.Account = &account }
+}
+
+func withMode(mode string) widgetModifier {
+	return func(w *v1alpha1.TurnstileWidget) { w.Spec.ForProvider.Mode = &mode }
+}
+
+func withExternalName(sitekey string) widgetModifier {
+	return func(w *v1alpha1.TurnstileWidget) { meta.SetExternalName(w, sitekey) }
+}
+
+func withObservation(o v1alpha1.TurnstileWidgetObservation) widgetModifier {
+	return func(w *v1alpha1.TurnstileWidget) { w.Status.AtProvider = o }
+}
+
+func withConditions(c ...xpv1.Condition) widgetModifier {
+	return func(w *v1alpha1.TurnstileWidget) { w.Status.SetConditions(c...) }
+}
+
+func widgetBuild(m ...widgetModifier) *v1alpha1.TurnstileWidget {
+	cr := &v1alpha1.TurnstileWidget{
+		Spec: v1alpha1.TurnstileWidgetSpec{
+			ForProvider: v1alpha1.TurnstileWidgetParameters{
+				Name:    "login",
+				Domains: []string{"example.com"},
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+var connectionDetails = managed.ConnectionDetails{
+	turnstile.ConnectionKeySitekey: []byte(testSitekey),
+	turnstile.ConnectionKeySecret:  []byte("0x4AAF00AAAABn0R22HWm098HVBjhdsYUc"),
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (turnstile.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTurnstileWidget": {
+			reason: "An error should be returned if the managed resource is not a *TurnstileWidget",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTurnstileWidget),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube: mc,
+			},
+			args: args{
+				mg: &v1alpha1.TurnstileWidget{
+					Spec: v1alpha1.TurnstileWidgetSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when a client is created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *pcv1alpha1.ProviderConfig:
+							o.Spec.Credentials.Source = "Secret"
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								Key: "creds",
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{
+								"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+							}
+						}
+						return nil
+					}),
+				},
+				newClient: turnstile.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.TurnstileWidget{
+					Spec: v1alpha1.TurnstileWidgetSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (turnstile.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := errors.New("HTTP status 404: not found")
+
+	type fields struct {
+		client turnstile.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		cr  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTurnstileWidget": {
+			reason: "An error should be returned if the managed resource is not a *TurnstileWidget",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTurnstileWidget),
+			},
+		},
+		"ErrNoTurnstileWidget": {
+			reason: "We should return ResourceExists: false when no external name is set",
+			args: args{
+				mg: widgetBuild(withAccount(testAccount)),
+			},
+			want: want{
+				cr: widgetBuild(withAccount(testAccount)),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the TurnstileWidget has no account",
+			args: args{
+				mg: widgetBuild(withExternalName(testSitekey)),
+			},
+			want: want{
+				cr:  widgetBuild(withExternalName(testSitekey)),
+				err: errors.New(errNoAccount),
+			},
+		},
+		"ErrTurnstileWidgetLookup": {
+			reason: "We should return an error if the TurnstileWidget could not be looked up",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: widgetBuild(withAccount(testAccount), withExternalName(testSitekey)),
+			},
+			want: want{
+				cr:  widgetBuild(withAccount(testAccount), withExternalName(testSitekey)),
+				err: errors.Wrap(errBoom, errTurnstileWidgetLookup),
+			},
+		},
+		"NotFound": {
+			reason: "We should return ResourceExists: false if the TurnstileWidget was deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errNotFound
+					},
+				},
+			},
+			args: args{
+				mg: widgetBuild(withAccount(testAccount), withExternalName(testSitekey)),
+			},
+			want: want{
+				cr: widgetBuild(withAccount(testAccount), withExternalName(testSitekey)),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "We should return ResourceUpToDate: true when the TurnstileWidget matches",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"sitekey":"0x4AAF00AAAABn0R22HWm-YUc","secret":"0x4AAF00AAAABn0R22HWm098HVBjhdsYUc","name":"login","domains":["example.com"],"mode":"invisible","bot_fight_mode":false,"region":"world"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: widgetBuild(withAccount(testAccount), withExternalName(testSitekey), withMode(v1alpha1.ModeInvisible)),
+			},
+			want: want{
+				cr: widgetBuild(
+					withAccount(testAccount),
+					withExternalName(testSitekey),
+					withMode(v1alpha1.ModeInvisible),
+					withObservation(v1alpha1.TurnstileWidgetObservation{Sitekey: testSitekey}),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: connectionDetails,
+				},
+			},
+		},
+		"NotUpToDate": {
+			reason: "We should return ResourceUpToDate: false when the mode differs",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"sitekey":"0x4AAF00AAAABn0R22HWm-YUc","secret":"0x4AAF00AAAABn0R22HWm098HVBjhdsYUc","name":"login","domains":["example.com"],"mode":"managed","bot_fight_mode":false,"region":"world"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: widgetBuild(withAccount(testAccount), withExternalName(testSitekey), withMode(v1alpha1.ModeInvisible)),
+			},
+			want: want{
+				cr: widgetBuild(
+					withAccount(testAccount),
+					withExternalName(testSitekey),
+					withMode(v1alpha1.ModeInvisible),
+					withObservation(v1alpha1.TurnstileWidgetObservation{Sitekey: testSitekey}),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: connectionDetails,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want cr, +got cr:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client turnstile.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		cr  resource.Managed
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTurnstileWidget": {
+			reason: "An error should be returned if the managed resource is not a *TurnstileWidget",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTurnstileWidget),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the TurnstileWidget has no account",
+			args: args{
+				mg: widgetBuild(),
+			},
+			want: want{
+				cr:  widgetBuild(),
+				err: errors.Wrap(errors.New(errNoAccount), errTurnstileWidgetCreation),
+			},
+		},
+		"ErrTurnstileWidgetCreate": {
+			reason: "We should return any errors creating the TurnstileWidget",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: widgetBuild(withAccount(testAccount)),
+			},
+			want: want{
+				cr:  widgetBuild(withAccount(testAccount)),
+				err: errors.Wrap(errors.Wrap(errBoom, "error creating turnstile widget"), errTurnstileWidgetCreation),
+			},
+		},
+		"Success": {
+			reason: "We should set the external name and publish the connection details of a created TurnstileWidget",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return json.RawMessage(`{"sitekey":"0x4AAF00AAAABn0R22HWm-YUc","secret":"0x4AAF00AAAABn0R22HWm098HVBjhdsYUc","name":"login","domains":["example.com"],"mode":"invisible","bot_fight_mode":false,"region":"world"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: widgetBuild(withAccount(testAccount), withMode(v1alpha1.ModeInvisible)),
+			},
+			want: want{
+				cr: widgetBuild(
+					withAccount(testAccount),
+					withMode(v1alpha1.ModeInvisible),
+					withExternalName(testSitekey),
+					withObservation(v1alpha1.TurnstileWidgetObservation{Sitekey: testSitekey}),
+				),
+				o: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+					ConnectionDetails:    connectionDetails,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want cr, +got cr:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client turnstile.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTurnstileWidget": {
+			reason: "An error should be returned if the managed resource is not a *TurnstileWidget",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTurnstileWidget),
+			},
+		},
+		"ErrNoTurnstileWidget": {
+			reason: "We should return an error when no external name is set",
+			args: args{
+				mg: widgetBuild(withAccount(testAccount)),
+			},
+			want: want{
+				err: errors.New(errTurnstileWidgetUpdate),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the TurnstileWidget has no account",
+			args: args{
+				mg: widgetBuild(withExternalName(testSitekey)),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoAccount), errTurnstileWidgetUpdate),
+			},
+		},
+		"ErrTurnstileWidgetUpdate": {
+			reason: "We should return any errors updating the TurnstileWidget",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: widgetBuild(withAccount(testAccount), withExternalName(testSitekey)),
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errBoom, "error updating turnstile widget"), errTurnstileWidgetUpdate),
+			},
+		},
+		"Success": {
+			reason: "We should replace the TurnstileWidget",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if method != http.MethodPut || endpoint != "/accounts/"+testAccount+"/challenges/widgets/"+testSitekey {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: widgetBuild(withAccount(testAccount), withExternalName(testSitekey), withMode(v1alpha1.ModeInvisible)),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := errors.New("HTTP status 404: not found")
+
+	type fields struct {
+		client turnstile.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotTurnstileWidget": {
+			reason: "An error should be returned if the managed resource is not a *TurnstileWidget",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotTurnstileWidget),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the TurnstileWidget has no account",
+			args: args{
+				mg: widgetBuild(withExternalName(testSitekey)),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoAccount), errTurnstileWidgetDeletion),
+			},
+		},
+		"ErrNoTurnstileWidget": {
+			reason: "We should return an error when no external name is set",
+			args: args{
+				mg: widgetBuild(withAccount(testAccount)),
+			},
+			want: want{
+				err: errors.New(errTurnstileWidgetDeletion),
+			},
+		},
+		"ErrTurnstileWidgetDelete": {
+			reason: "We should return any errors deleting the TurnstileWidget",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: widgetBuild(withAccount(testAccount), withExternalName(testSitekey)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errTurnstileWidgetDeletion),
+			},
+		},
+		"NotFound": {
+			reason: "We should not return an error if the TurnstileWidget was already deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						return nil, errNotFound
+					},
+				},
+			},
+			args: args{
+				mg: widgetBuild(withAccount(testAccount), withExternalName(testSitekey)),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
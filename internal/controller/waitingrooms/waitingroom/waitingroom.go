@@ -0,0 +1,215 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package waitingroom
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/waitingrooms/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/waitingrooms"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotWaitingRoom = "managed resource is not a WaitingRoom custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errWaitingRoomLookup   = "cannot lookup waiting room"
+	errWaitingRoomCreation = "cannot create waiting room"
+	errWaitingRoomUpdate   = "cannot update waiting room"
+	errWaitingRoomDeletion = "cannot delete waiting room"
+	errNoZone              = "no zone found"
+)
+
+// Setup adds a controller that reconciles WaitingRoom managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.WaitingRoomGroupKind)
+	o = o.For(v1alpha1.WaitingRoomGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.WaitingRoomGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (waitingrooms.Client, error) {
+				return waitingrooms.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.WaitingRoom{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (waitingrooms.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, ok := mg.(*v1alpha1.WaitingRoom)
+	if !ok {
+		return nil, errors.New(errNotWaitingRoom)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client waitingrooms.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.WaitingRoom)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotWaitingRoom)
+	}
+
+	// WaitingRoom does not exist if we dont have an ID stored in external-name
+	wid := meta.GetExternalName(cr)
+	if wid == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalObservation{}, errors.New(errNoZone)
+	}
+
+	// The queueing method is only observed when we manage it, as it
+	// is not supported by the go library and needs a raw API request.
+	wr, qm, err := waitingrooms.GetWaitingRoom(ctx, e.client, *cr.Spec.ForProvider.Zone, wid, cr.Spec.ForProvider.QueueingMethod != nil)
+	if err != nil {
+		return managed.ExternalObservation{},
+			errors.Wrap(resource.Ignore(waitingrooms.IsWaitingRoomNotFound, err), errWaitingRoomLookup)
+	}
+
+	cr.Status.AtProvider = waitingrooms.GenerateObservation(wr)
+
+	cr.SetConditions(rtv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: waitingrooms.UpToDate(&cr.Spec.ForProvider, wr, qm),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.WaitingRoom)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotWaitingRoom)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalCreation{}, errors.Wrap(errors.New(errNoZone), errWaitingRoomCreation)
+	}
+
+	wr, err := waitingrooms.CreateWaitingRoom(ctx, e.client, *cr.Spec.ForProvider.Zone, cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errWaitingRoomCreation)
+	}
+
+	cr.Status.AtProvider = waitingrooms.GenerateObservation(*wr)
+
+	// Update the external name with the ID of the new WaitingRoom
+	meta.SetExternalName(cr, wr.ID)
+
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.WaitingRoom)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotWaitingRoom)
+	}
+
+	wid := meta.GetExternalName(cr)
+
+	// Update should never be called on a nonexistent resource
+	if wid == "" {
+		return managed.ExternalUpdate{}, errors.New(errWaitingRoomUpdate)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return managed.ExternalUpdate{}, errors.Wrap(errors.New(errNoZone), errWaitingRoomUpdate)
+	}
+
+	return managed.ExternalUpdate{},
+		errors.Wrap(waitingrooms.UpdateWaitingRoom(ctx, e.client, *cr.Spec.ForProvider.Zone, wid, cr.Spec.ForProvider), errWaitingRoomUpdate)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.WaitingRoom)
+	if !ok {
+		return errors.New(errNotWaitingRoom)
+	}
+
+	if cr.Spec.ForProvider.Zone == nil {
+		return errors.Wrap(errors.New(errNoZone), errWaitingRoomDeletion)
+	}
+
+	wid := meta.GetExternalName(cr)
+
+	// Delete should never be called on a nonexistent resource
+	if wid == "" {
+		return errors.New(errWaitingRoomDeletion)
+	}
+
+	return errors.Wrap(
+		resource.Ignore(waitingrooms.IsWaitingRoomNotFound,
+			e.client.DeleteWaitingRoom(ctx, *cr.Spec.ForProvider.Zone, wid)),
+		errWaitingRoomDeletion)
+}
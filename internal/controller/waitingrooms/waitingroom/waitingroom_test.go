@@ -0,0 +1,672 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package waitingroom
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/apis/waitingrooms/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/waitingrooms"
+	"github.com/benagricola/provider-cloudflare/internal/clients/waitingrooms/fake"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const (
+	testZone          = "023e105f4ecef8ad9ca31a8372d0c353"
+	testWaitingRoomID = "699d98642c564d2e855e9661899b7252"
+)
+
+type waitingRoomModifier func(*v1alpha1.WaitingRoom)
+
+func withZone(zone string) waitingRoomModifier {
+	return func(w *v1alpha1.WaitingRoom) { w.Spec.ForProvider.Zone = &zone }
+}
+
+func withTotalActiveUsers(n int) waitingRoomModifier {
+	return func(w *v1alpha1.WaitingRoom) { w.Spec.ForProvider.TotalActiveUsers = n }
+}
+
+func withQueueingMethod(m string) waitingRoomModifier {
+	return func(w *v1alpha1.WaitingRoom) { w.Spec.ForProvider.QueueingMethod = &m }
+}
+
+func withExternalName(waitingRoomID string) waitingRoomModifier {
+	return func(w *v1alpha1.WaitingRoom) { meta.SetExternalName(w, waitingRoomID) }
+}
+
+func withConditions(c ...xpv1.Condition) waitingRoomModifier {
+	return func(w *v1alpha1.WaitingRoom) { w.Status.SetConditions(c...) }
+}
+
+func waitingRoomBuild(m ...waitingRoomModifier) *v1alpha1.WaitingRoom {
+	cr := &v1alpha1.WaitingRoom{
+		Spec: v1alpha1.WaitingRoomSpec{
+			ForProvider: v1alpha1.WaitingRoomParameters{
+				Name:              "sale",
+				Host:              "shop.example.com",
+				TotalActiveUsers:  500,
+				NewUsersPerMinute: 200,
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (waitingrooms.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotWaitingRoom": {
+			reason: "An error should be returned if the managed resource is not a *WaitingRoom",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotWaitingRoom),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube: mc,
+			},
+			args: args{
+				mg: &v1alpha1.WaitingRoom{
+					Spec: v1alpha1.WaitingRoomSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when a client is created",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						switch o := obj.(type) {
+						case *pcv1alpha1.ProviderConfig:
+							o.Spec.Credentials.Source = "Secret"
+							o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+								Key: "creds",
+							}
+						case *corev1.Secret:
+							o.Data = map[string][]byte{
+								"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+							}
+						}
+						return nil
+					}),
+				},
+				newClient: waitingrooms.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.WaitingRoom{
+					Spec: v1alpha1.WaitingRoomSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			nc := func(cfg clients.Config) (waitingrooms.Client, error) {
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := errors.New("HTTP status 404: not found")
+
+	type fields struct {
+		client waitingrooms.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		cr  resource.Managed
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotWaitingRoom": {
+			reason: "An error should be returned if the managed resource is not a *WaitingRoom",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotWaitingRoom),
+			},
+		},
+		"ErrNoWaitingRoom": {
+			reason: "We should return ResourceExists: false when no external name is set",
+			args: args{
+				mg: waitingRoomBuild(withZone(testZone)),
+			},
+			want: want{
+				cr: waitingRoomBuild(withZone(testZone)),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the WaitingRoom has no zone",
+			args: args{
+				mg: waitingRoomBuild(withExternalName(testWaitingRoomID)),
+			},
+			want: want{
+				cr:  waitingRoomBuild(withExternalName(testWaitingRoomID)),
+				err: errors.New(errNoZone),
+			},
+		},
+		"ErrWaitingRoomLookup": {
+			reason: "We should return an error if the WaitingRoom could not be looked up",
+			fields: fields{
+				client: fake.MockClient{
+					MockWaitingRoom: func(ctx context.Context, zoneID, waitingRoomID string) (cloudflare.WaitingRoom, error) {
+						return cloudflare.WaitingRoom{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: waitingRoomBuild(withZone(testZone), withExternalName(testWaitingRoomID)),
+			},
+			want: want{
+				cr:  waitingRoomBuild(withZone(testZone), withExternalName(testWaitingRoomID)),
+				err: errors.Wrap(errBoom, errWaitingRoomLookup),
+			},
+		},
+		"NotFound": {
+			reason: "We should return ResourceExists: false if the WaitingRoom was deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockWaitingRoom: func(ctx context.Context, zoneID, waitingRoomID string) (cloudflare.WaitingRoom, error) {
+						return cloudflare.WaitingRoom{}, errNotFound
+					},
+				},
+			},
+			args: args{
+				mg: waitingRoomBuild(withZone(testZone), withExternalName(testWaitingRoomID)),
+			},
+			want: want{
+				cr: waitingRoomBuild(withZone(testZone), withExternalName(testWaitingRoomID)),
+				o:  managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "We should return ResourceUpToDate: true when the WaitingRoom matches",
+			fields: fields{
+				client: fake.MockClient{
+					MockWaitingRoom: func(ctx context.Context, zoneID, waitingRoomID string) (cloudflare.WaitingRoom, error) {
+						return cloudflare.WaitingRoom{ID: testWaitingRoomID, Name: "sale", Host: "shop.example.com", TotalActiveUsers: 1000, NewUsersPerMinute: 200}, nil
+					},
+				},
+			},
+			args: args{
+				mg: waitingRoomBuild(withZone(testZone), withExternalName(testWaitingRoomID), withTotalActiveUsers(1000)),
+			},
+			want: want{
+				cr: waitingRoomBuild(
+					withZone(testZone),
+					withExternalName(testWaitingRoomID),
+					withTotalActiveUsers(1000),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"NotUpToDate": {
+			reason: "We should return ResourceUpToDate: false when the number of active users differs",
+			fields: fields{
+				client: fake.MockClient{
+					MockWaitingRoom: func(ctx context.Context, zoneID, waitingRoomID string) (cloudflare.WaitingRoom, error) {
+						return cloudflare.WaitingRoom{ID: testWaitingRoomID, Name: "sale", Host: "shop.example.com", TotalActiveUsers: 500, NewUsersPerMinute: 200}, nil
+					},
+				},
+			},
+			args: args{
+				mg: waitingRoomBuild(withZone(testZone), withExternalName(testWaitingRoomID), withTotalActiveUsers(1000)),
+			},
+			want: want{
+				cr: waitingRoomBuild(
+					withZone(testZone),
+					withExternalName(testWaitingRoomID),
+					withTotalActiveUsers(1000),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"QueueingMethodNotUpToDate": {
+			reason: "We should observe the queueing method using a raw request when it is managed",
+			fields: fields{
+				client: fake.MockClient{
+					MockRawContext: func(_ context.Context, method, endpoint string, data interface{}) (json.RawMessage, error) {
+						if method != http.MethodGet || endpoint != "/zones/"+testZone+"/waiting_rooms/"+testWaitingRoomID {
+							return nil, errBoom
+						}
+						return json.RawMessage(`{"id":"699d98642c564d2e855e9661899b7252","name":"sale","host":"shop.example.com","total_active_users":500,"new_users_per_minute":200,"queueing_method":"fifo"}`), nil
+					},
+				},
+			},
+			args: args{
+				mg: waitingRoomBuild(withZone(testZone), withExternalName(testWaitingRoomID), withQueueingMethod("random")),
+			},
+			want: want{
+				cr: waitingRoomBuild(
+					withZone(testZone),
+					withExternalName(testWaitingRoomID),
+					withQueueingMethod("random"),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want cr, +got cr:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client waitingrooms.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		cr  resource.Managed
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotWaitingRoom": {
+			reason: "An error should be returned if the managed resource is not a *WaitingRoom",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotWaitingRoom),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the WaitingRoom has no zone",
+			args: args{
+				mg: waitingRoomBuild(),
+			},
+			want: want{
+				cr:  waitingRoomBuild(),
+				err: errors.Wrap(errors.New(errNoZone), errWaitingRoomCreation),
+			},
+		},
+		"ErrWaitingRoomCreate": {
+			reason: "We should return any errors creating the WaitingRoom",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateWaitingRoom: func(ctx context.Context, zoneID string, waitingRoom cloudflare.WaitingRoom) (*cloudflare.WaitingRoom, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: waitingRoomBuild(withZone(testZone)),
+			},
+			want: want{
+				cr:  waitingRoomBuild(withZone(testZone)),
+				err: errors.Wrap(errors.Wrap(errBoom, "error creating waiting room"), errWaitingRoomCreation),
+			},
+		},
+		"Success": {
+			reason: "We should set the external name of a created WaitingRoom",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateWaitingRoom: func(ctx context.Context, zoneID string, waitingRoom cloudflare.WaitingRoom) (*cloudflare.WaitingRoom, error) {
+						return &cloudflare.WaitingRoom{ID: testWaitingRoomID, Name: "sale", Host: "shop.example.com", TotalActiveUsers: 1000, NewUsersPerMinute: 200}, nil
+					},
+				},
+			},
+			args: args{
+				mg: waitingRoomBuild(withZone(testZone), withTotalActiveUsers(1000)),
+			},
+			want: want{
+				cr: waitingRoomBuild(
+					withZone(testZone),
+					withTotalActiveUsers(1000),
+					withExternalName(testWaitingRoomID),
+				),
+				o: managed.ExternalCreation{ExternalNameAssigned: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want cr, +got cr:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client waitingrooms.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotWaitingRoom": {
+			reason: "An error should be returned if the managed resource is not a *WaitingRoom",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotWaitingRoom),
+			},
+		},
+		"ErrNoWaitingRoom": {
+			reason: "We should return an error when no external name is set",
+			args: args{
+				mg: waitingRoomBuild(withZone(testZone)),
+			},
+			want: want{
+				err: errors.New(errWaitingRoomUpdate),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the WaitingRoom has no zone",
+			args: args{
+				mg: waitingRoomBuild(withExternalName(testWaitingRoomID)),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoZone), errWaitingRoomUpdate),
+			},
+		},
+		"ErrWaitingRoomUpdate": {
+			reason: "We should return any errors updating the WaitingRoom",
+			fields: fields{
+				client: fake.MockClient{
+					MockUpdateWaitingRoom: func(ctx context.Context, zoneID string, waitingRoom cloudflare.WaitingRoom) (cloudflare.WaitingRoom, error) {
+						return cloudflare.WaitingRoom{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: waitingRoomBuild(withZone(testZone), withExternalName(testWaitingRoomID)),
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errBoom, "error updating waiting room"), errWaitingRoomUpdate),
+			},
+		},
+		"Success": {
+			reason: "We should replace the WaitingRoom",
+			fields: fields{
+				client: fake.MockClient{
+					MockUpdateWaitingRoom: func(ctx context.Context, zoneID string, waitingRoom cloudflare.WaitingRoom) (cloudflare.WaitingRoom, error) {
+						if zoneID != testZone || waitingRoom.ID != testWaitingRoomID || waitingRoom.TotalActiveUsers != 1000 {
+							return cloudflare.WaitingRoom{}, errBoom
+						}
+						return waitingRoom, nil
+					},
+				},
+			},
+			args: args{
+				mg: waitingRoomBuild(withZone(testZone), withExternalName(testWaitingRoomID), withTotalActiveUsers(1000)),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := errors.New("HTTP status 404: not found")
+
+	type fields struct {
+		client waitingrooms.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotWaitingRoom": {
+			reason: "An error should be returned if the managed resource is not a *WaitingRoom",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotWaitingRoom),
+			},
+		},
+		"ErrNoZone": {
+			reason: "We should return an error if the WaitingRoom has no zone",
+			args: args{
+				mg: waitingRoomBuild(withExternalName(testWaitingRoomID)),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNoZone), errWaitingRoomDeletion),
+			},
+		},
+		"ErrNoWaitingRoom": {
+			reason: "We should return an error when no external name is set",
+			args: args{
+				mg: waitingRoomBuild(withZone(testZone)),
+			},
+			want: want{
+				err: errors.New(errWaitingRoomDeletion),
+			},
+		},
+		"ErrWaitingRoomDelete": {
+			reason: "We should return any errors deleting the WaitingRoom",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteWaitingRoom: func(ctx context.Context, zoneID, waitingRoomID string) error {
+						return errBoom
+					},
+				},
+			},
+			args: args{
+				mg: waitingRoomBuild(withZone(testZone), withExternalName(testWaitingRoomID)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errWaitingRoomDeletion),
+			},
+		},
+		"NotFound": {
+			reason: "We should not return an error if the WaitingRoom was already deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteWaitingRoom: func(ctx context.Context, zoneID, waitingRoomID string) error {
+						return errNotFound
+					},
+				},
+			},
+			args: args{
+				mg: waitingRoomBuild(withZone(testZone), withExternalName(testWaitingRoomID)),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kvnamespace
+
+import (
+	"context"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/workers/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/workers/kvnamespace"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotNamespace = "managed resource is not a WorkersKVNamespace custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errNamespaceLookup    = "cannot lookup workers kv namespace"
+	errNamespaceCreation  = "cannot create workers kv namespace"
+	errNamespaceUpdate    = "cannot update workers kv namespace"
+	errNamespaceDeletion  = "cannot delete workers kv namespace"
+	errNamespaceNoAccount = "no account found"
+)
+
+// Setup adds a controller that reconciles WorkersKVNamespace managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.WorkersKVNamespaceGroupKind)
+	o = o.For(v1alpha1.WorkersKVNamespaceGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.WorkersKVNamespaceGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (kvnamespace.Client, error) {
+				return kvnamespace.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.WorkersKVNamespace{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (kvnamespace.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.WorkersKVNamespace)
+	if !ok {
+		return nil, errors.New(errNotNamespace)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	// KV Namespaces are managed on an account rather than a zone.
+	if cr.Spec.ForProvider.Account != nil {
+		config.AccountID = cr.Spec.ForProvider.Account
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client kvnamespace.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.WorkersKVNamespace)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotNamespace)
+	}
+
+	// Namespace does not exist if we dont have an ID stored in external-name
+	nid := meta.GetExternalName(cr)
+	if nid == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalObservation{}, errors.New(errNamespaceNoAccount)
+	}
+
+	ns, err := kvnamespace.LookupNamespace(ctx, e.client, nid)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errNamespaceLookup)
+	}
+	if ns == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	li := kvnamespace.LateInitialize(&cr.Spec.ForProvider, *ns)
+
+	cr.Status.SetConditions(rtv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        kvnamespace.UpToDate(&cr.Spec.ForProvider, *ns),
+		ResourceLateInitialized: li,
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.WorkersKVNamespace)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotNamespace)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalCreation{}, errors.Wrap(errors.New(errNamespaceNoAccount), errNamespaceCreation)
+	}
+
+	title := cr.GetName()
+	if cr.Spec.ForProvider.Title != nil {
+		title = *cr.Spec.ForProvider.Title
+	}
+
+	r, err := e.client.CreateWorkersKVNamespace(ctx, &cloudflare.WorkersKVNamespaceRequest{Title: title})
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errNamespaceCreation)
+	}
+
+	// Update the external name with the ID of the new Namespace
+	meta.SetExternalName(cr, r.Result.ID)
+
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.WorkersKVNamespace)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotNamespace)
+	}
+
+	nid := meta.GetExternalName(cr)
+	if nid == "" {
+		return managed.ExternalUpdate{}, errors.New(errNamespaceUpdate)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalUpdate{}, errors.Wrap(errors.New(errNamespaceNoAccount), errNamespaceUpdate)
+	}
+
+	return managed.ExternalUpdate{},
+		errors.Wrap(
+			kvnamespace.UpdateNamespace(ctx, e.client, nid, &cr.Spec.ForProvider),
+			errNamespaceUpdate,
+		)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.WorkersKVNamespace)
+	if !ok {
+		return errors.New(errNotNamespace)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return errors.Wrap(errors.New(errNamespaceNoAccount), errNamespaceDeletion)
+	}
+
+	nid := meta.GetExternalName(cr)
+	if nid == "" {
+		return errors.New(errNamespaceDeletion)
+	}
+
+	_, err := e.client.DeleteWorkersKVNamespace(ctx, nid)
+
+	return errors.Wrap(resource.Ignore(kvnamespace.IsNamespaceNotFound, err), errNamespaceDeletion)
+}
@@ -0,0 +1,650 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kvnamespace
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/apis/workers/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	kvnamespace "github.com/benagricola/provider-cloudflare/internal/clients/workers/kvnamespace"
+	"github.com/benagricola/provider-cloudflare/internal/clients/workers/kvnamespace/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	ptr "k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+type NamespaceModifier func(*v1alpha1.WorkersKVNamespace)
+
+func withAccount(accountID string) NamespaceModifier {
+	return func(r *v1alpha1.WorkersKVNamespace) { r.Spec.ForProvider.Account = &accountID }
+}
+
+func withExternalName(id string) NamespaceModifier {
+	return func(r *v1alpha1.WorkersKVNamespace) { meta.SetExternalName(r, id) }
+}
+
+func withTitle(title string) NamespaceModifier {
+	return func(r *v1alpha1.WorkersKVNamespace) { r.Spec.ForProvider.Title = &title }
+}
+
+func Namespace(m ...NamespaceModifier) *v1alpha1.WorkersKVNamespace {
+	cr := &v1alpha1.WorkersKVNamespace{}
+	cr.SetName("my-namespace")
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func existing(id, title string) func(ctx context.Context) ([]cloudflare.WorkersKVNamespace, error) {
+	return func(ctx context.Context) ([]cloudflare.WorkersKVNamespace, error) {
+		return []cloudflare.WorkersKVNamespace{{ID: id, Title: title}}, nil
+	}
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	kube := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			switch o := obj.(type) {
+			case *pcv1alpha1.ProviderConfig:
+				o.Spec.Credentials.Source = "Secret"
+				o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+					Key: "creds",
+				}
+			case *corev1.Secret:
+				o.Data = map[string][]byte{
+					"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+				}
+			}
+			return nil
+		}),
+		MockCreate: test.NewMockCreateFn(nil),
+	}
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (kvnamespace.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err     error
+		account *string
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotNamespace": {
+			reason: "An error should be returned if the managed resource is not a *WorkersKVNamespace",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotNamespace),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube: mc,
+			},
+			args: args{
+				mg: &v1alpha1.WorkersKVNamespace{
+					Spec: v1alpha1.WorkersKVNamespaceSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"ConnectAccountScoped": {
+			reason: "Connect should scope the client to the account of the WorkersKVNamespace",
+			fields: fields{
+				kube:      kube,
+				newClient: kvnamespace.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.WorkersKVNamespace{
+					Spec: v1alpha1.WorkersKVNamespaceSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+						ForProvider: v1alpha1.WorkersKVNamespaceParameters{
+							Account: ptr.StringPtr("01a7362d577a6c3019a474fd6f485823"),
+						},
+					},
+				},
+			},
+			want: want{
+				err:     nil,
+				account: ptr.StringPtr("01a7362d577a6c3019a474fd6f485823"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var account *string
+			nc := func(cfg clients.Config) (kvnamespace.Client, error) {
+				account = cfg.AccountID
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.account, account); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want account, +got account:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client kvnamespace.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotNamespace": {
+			reason: "An error should be returned if the managed resource is not a *WorkersKVNamespace",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotNamespace),
+			},
+		},
+		"ErrNoNamespace": {
+			reason: "We should return ResourceExists: false when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: Namespace(),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the WorkersKVNamespace has no account",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: Namespace(withExternalName("0f2ac74b498b48028cb68387c421e279")),
+			},
+			want: want{
+				err: errors.New(errNamespaceNoAccount),
+			},
+		},
+		"ErrNamespaceLookup": {
+			reason: "We should return an empty observation and an error if the API returned an error",
+			fields: fields{
+				client: fake.MockClient{
+					MockListWorkersKVNamespaces: func(ctx context.Context) ([]cloudflare.WorkersKVNamespace, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: Namespace(
+					withExternalName("0f2ac74b498b48028cb68387c421e279"),
+					withAccount("01a7362d577a6c3019a474fd6f485823"),
+				),
+			},
+			want: want{
+				o:   managed.ExternalObservation{},
+				err: errors.Wrap(errors.Wrap(errBoom, "error listing workers kv namespaces"), errNamespaceLookup),
+			},
+		},
+		"ErrNamespaceNotFound": {
+			reason: "We should return ResourceExists: false if the namespace is not found (deleted on CF side)",
+			fields: fields{
+				client: fake.MockClient{
+					MockListWorkersKVNamespaces: existing("other", "other"),
+				},
+			},
+			args: args{
+				mg: Namespace(
+					withExternalName("0f2ac74b498b48028cb68387c421e279"),
+					withAccount("01a7362d577a6c3019a474fd6f485823"),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"LateInitialized": {
+			reason: "We should late initialize the title when it is not set",
+			fields: fields{
+				client: fake.MockClient{
+					MockListWorkersKVNamespaces: existing("0f2ac74b498b48028cb68387c421e279", "my-namespace"),
+				},
+			},
+			args: args{
+				mg: Namespace(
+					withExternalName("0f2ac74b498b48028cb68387c421e279"),
+					withAccount("01a7362d577a6c3019a474fd6f485823"),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+			},
+		},
+		"NotUpToDate": {
+			reason: "We should return ResourceUpToDate: false when the title differs",
+			fields: fields{
+				client: fake.MockClient{
+					MockListWorkersKVNamespaces: existing("0f2ac74b498b48028cb68387c421e279", "old-title"),
+				},
+			},
+			args: args{
+				mg: Namespace(
+					withExternalName("0f2ac74b498b48028cb68387c421e279"),
+					withAccount("01a7362d577a6c3019a474fd6f485823"),
+					withTitle("new-title"),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client kvnamespace.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotNamespace": {
+			reason: "An error should be returned if the managed resource is not a *WorkersKVNamespace",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotNamespace),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the WorkersKVNamespace has no account",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: Namespace(),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errNamespaceNoAccount), errNamespaceCreation),
+			},
+		},
+		"ErrNamespaceCreate": {
+			reason: "We should return any errors during the create process",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateWorkersKVNamespace: func(ctx context.Context, req *cloudflare.WorkersKVNamespaceRequest) (cloudflare.WorkersKVNamespaceResponse, error) {
+						return cloudflare.WorkersKVNamespaceResponse{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: Namespace(withAccount("01a7362d577a6c3019a474fd6f485823")),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errNamespaceCreation),
+			},
+		},
+		"Success": {
+			reason: "We should return ExternalNameAssigned: true and no error when a namespace is created",
+			fields: fields{
+				client: fake.MockClient{
+					MockCreateWorkersKVNamespace: func(ctx context.Context, req *cloudflare.WorkersKVNamespaceRequest) (cloudflare.WorkersKVNamespaceResponse, error) {
+						if req.Title != "my-namespace" {
+							return cloudflare.WorkersKVNamespaceResponse{}, errBoom
+						}
+						return cloudflare.WorkersKVNamespaceResponse{
+							Result: cloudflare.WorkersKVNamespace{
+								ID:    "0f2ac74b498b48028cb68387c421e279",
+								Title: req.Title,
+							},
+						}, nil
+					},
+				},
+			},
+			args: args{
+				mg: Namespace(withAccount("01a7362d577a6c3019a474fd6f485823")),
+			},
+			want: want{
+				o: managed.ExternalCreation{ExternalNameAssigned: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client kvnamespace.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotNamespace": {
+			reason: "An error should be returned if the managed resource is not a *WorkersKVNamespace",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotNamespace),
+			},
+		},
+		"ErrNoExternalName": {
+			reason: "We should return an error if the namespace has no external name",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: Namespace(withAccount("01a7362d577a6c3019a474fd6f485823")),
+			},
+			want: want{
+				err: errors.New(errNamespaceUpdate),
+			},
+		},
+		"ErrNamespaceUpdate": {
+			reason: "We should return any errors during the update process",
+			fields: fields{
+				client: fake.MockClient{
+					MockUpdateWorkersKVNamespace: func(ctx context.Context, namespaceID string, req *cloudflare.WorkersKVNamespaceRequest) (cloudflare.Response, error) {
+						return cloudflare.Response{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: Namespace(
+					withExternalName("0f2ac74b498b48028cb68387c421e279"),
+					withAccount("01a7362d577a6c3019a474fd6f485823"),
+					withTitle("new-title"),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errBoom, "error updating workers kv namespace"), errNamespaceUpdate),
+			},
+		},
+		"Success": {
+			reason: "We should return no error when the namespace is updated",
+			fields: fields{
+				client: fake.MockClient{
+					MockUpdateWorkersKVNamespace: func(ctx context.Context, namespaceID string, req *cloudflare.WorkersKVNamespaceRequest) (cloudflare.Response, error) {
+						return cloudflare.Response{}, nil
+					},
+				},
+			},
+			args: args{
+				mg: Namespace(
+					withExternalName("0f2ac74b498b48028cb68387c421e279"),
+					withAccount("01a7362d577a6c3019a474fd6f485823"),
+					withTitle("new-title"),
+				),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client kvnamespace.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotNamespace": {
+			reason: "An error should be returned if the managed resource is not a *WorkersKVNamespace",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotNamespace),
+			},
+		},
+		"ErrNamespaceDelete": {
+			reason: "We should return any errors during the delete process",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteWorkersKVNamespace: func(ctx context.Context, namespaceID string) (cloudflare.Response, error) {
+						return cloudflare.Response{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: Namespace(
+					withExternalName("0f2ac74b498b48028cb68387c421e279"),
+					withAccount("01a7362d577a6c3019a474fd6f485823"),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errNamespaceDeletion),
+			},
+		},
+		"NamespaceNotFound": {
+			reason: "We should not return an error if the namespace was already deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteWorkersKVNamespace: func(ctx context.Context, namespaceID string) (cloudflare.Response, error) {
+						return cloudflare.Response{}, errors.New("HTTP status 404: not found")
+					},
+				},
+			},
+			args: args{
+				mg: Namespace(
+					withExternalName("0f2ac74b498b48028cb68387c421e279"),
+					withAccount("01a7362d577a6c3019a474fd6f485823"),
+				),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"Success": {
+			reason: "We should return no error when the namespace is deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteWorkersKVNamespace: func(ctx context.Context, namespaceID string) (cloudflare.Response, error) {
+						return cloudflare.Response{}, nil
+					},
+				},
+			},
+			args: args{
+				mg: Namespace(
+					withExternalName("0f2ac74b498b48028cb68387c421e279"),
+					withAccount("01a7362d577a6c3019a474fd6f485823"),
+				),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
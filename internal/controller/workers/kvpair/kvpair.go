@@ -0,0 +1,225 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kvpair
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/workers/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/workers/kvpair"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotPair = "managed resource is not a WorkersKVPair custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errPairLookup      = "cannot lookup workers kv pair"
+	errPairCreation    = "cannot create workers kv pair"
+	errPairUpdate      = "cannot update workers kv pair"
+	errPairDeletion    = "cannot delete workers kv pair"
+	errPairValue       = "cannot resolve workers kv pair value"
+	errPairNoNamespace = "no namespace found"
+)
+
+// Setup adds a controller that reconciles WorkersKVPair managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.WorkersKVPairGroupKind)
+	o = o.For(v1alpha1.WorkersKVPairGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.WorkersKVPairGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube: mgr.GetClient(),
+			newCloudflareClientFn: func(cfg clients.Config) (kvpair.Client, error) {
+				return kvpair.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.WorkersKVPair{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	newCloudflareClientFn func(cfg clients.Config) (kvpair.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.WorkersKVPair)
+	if !ok {
+		return nil, errors.New(errNotPair)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	// KV pairs are managed on an account rather than a zone.
+	if cr.Spec.ForProvider.Account != nil {
+		config.AccountID = cr.Spec.ForProvider.Account
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client, kube: c.kube}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client kvpair.Client
+	kube   client.Client
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.WorkersKVPair)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotPair)
+	}
+
+	// Pair does not exist if we dont have a key stored in external-name
+	key := meta.GetExternalName(cr)
+	if key == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if cr.Spec.ForProvider.Namespace == nil {
+		return managed.ExternalObservation{}, errors.New(errPairNoNamespace)
+	}
+
+	o, err := e.client.ReadWorkersKV(ctx, *cr.Spec.ForProvider.Namespace, key)
+	if err != nil {
+		return managed.ExternalObservation{},
+			errors.Wrap(resource.Ignore(kvpair.IsPairNotFound, err), errPairLookup)
+	}
+
+	v, err := kvpair.ResolveValue(ctx, e.kube, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errPairValue)
+	}
+
+	cr.Status.SetConditions(rtv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: kvpair.UpToDate(v, o),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.WorkersKVPair)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotPair)
+	}
+
+	if cr.Spec.ForProvider.Namespace == nil {
+		return managed.ExternalCreation{}, errors.Wrap(errors.New(errPairNoNamespace), errPairCreation)
+	}
+
+	v, err := kvpair.ResolveValue(ctx, e.kube, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(errors.Wrap(err, errPairValue), errPairCreation)
+	}
+
+	if _, err := e.client.WriteWorkersKV(ctx, *cr.Spec.ForProvider.Namespace, cr.Spec.ForProvider.Key, v); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errPairCreation)
+	}
+
+	// KV pairs are identified by their key
+	meta.SetExternalName(cr, cr.Spec.ForProvider.Key)
+
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.WorkersKVPair)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotPair)
+	}
+
+	key := meta.GetExternalName(cr)
+	if key == "" {
+		return managed.ExternalUpdate{}, errors.New(errPairUpdate)
+	}
+
+	if cr.Spec.ForProvider.Namespace == nil {
+		return managed.ExternalUpdate{}, errors.Wrap(errors.New(errPairNoNamespace), errPairUpdate)
+	}
+
+	v, err := kvpair.ResolveValue(ctx, e.kube, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(errors.Wrap(err, errPairValue), errPairUpdate)
+	}
+
+	_, err = e.client.WriteWorkersKV(ctx, *cr.Spec.ForProvider.Namespace, key, v)
+	return managed.ExternalUpdate{}, errors.Wrap(err, errPairUpdate)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.WorkersKVPair)
+	if !ok {
+		return errors.New(errNotPair)
+	}
+
+	if cr.Spec.ForProvider.Namespace == nil {
+		return errors.Wrap(errors.New(errPairNoNamespace), errPairDeletion)
+	}
+
+	key := meta.GetExternalName(cr)
+	if key == "" {
+		return errors.New(errPairDeletion)
+	}
+
+	_, err := e.client.DeleteWorkersKV(ctx, *cr.Spec.ForProvider.Namespace, key)
+
+	return errors.Wrap(resource.Ignore(kvpair.IsPairNotFound, err), errPairDeletion)
+}
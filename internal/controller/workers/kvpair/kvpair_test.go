@@ -0,0 +1,647 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kvpair
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/apis/workers/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	kvpair "github.com/benagricola/provider-cloudflare/internal/clients/workers/kvpair"
+	"github.com/benagricola/provider-cloudflare/internal/clients/workers/kvpair/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	ptr "k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const testNamespace = "0f2ac74b498b48028cb68387c421e279"
+
+type PairModifier func(*v1alpha1.WorkersKVPair)
+
+func withNamespace(namespaceID string) PairModifier {
+	return func(r *v1alpha1.WorkersKVPair) { r.Spec.ForProvider.Namespace = &namespaceID }
+}
+
+func withExternalName(key string) PairModifier {
+	return func(r *v1alpha1.WorkersKVPair) { meta.SetExternalName(r, key) }
+}
+
+func withValue(value string) PairModifier {
+	return func(r *v1alpha1.WorkersKVPair) { r.Spec.ForProvider.Value = &value }
+}
+
+func withValueSecretRef(sel xpv1.SecretKeySelector) PairModifier {
+	return func(r *v1alpha1.WorkersKVPair) { r.Spec.ForProvider.ValueSecretRef = &sel }
+}
+
+func Pair(m ...PairModifier) *v1alpha1.WorkersKVPair {
+	cr := &v1alpha1.WorkersKVPair{
+		Spec: v1alpha1.WorkersKVPairSpec{
+			ForProvider: v1alpha1.WorkersKVPairParameters{
+				Key: "greeting",
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	kube := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			switch o := obj.(type) {
+			case *pcv1alpha1.ProviderConfig:
+				o.Spec.Credentials.Source = "Secret"
+				o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+					Key: "creds",
+				}
+			case *corev1.Secret:
+				o.Data = map[string][]byte{
+					"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+				}
+			}
+			return nil
+		}),
+		MockCreate: test.NewMockCreateFn(nil),
+	}
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (kvpair.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err     error
+		account *string
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotPair": {
+			reason: "An error should be returned if the managed resource is not a *WorkersKVPair",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotPair),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube: mc,
+			},
+			args: args{
+				mg: &v1alpha1.WorkersKVPair{
+					Spec: v1alpha1.WorkersKVPairSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"ConnectAccountScoped": {
+			reason: "Connect should scope the client to the account of the WorkersKVPair",
+			fields: fields{
+				kube:      kube,
+				newClient: kvpair.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.WorkersKVPair{
+					Spec: v1alpha1.WorkersKVPairSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+						ForProvider: v1alpha1.WorkersKVPairParameters{
+							Account: ptr.StringPtr("01a7362d577a6c3019a474fd6f485823"),
+						},
+					},
+				},
+			},
+			want: want{
+				err:     nil,
+				account: ptr.StringPtr("01a7362d577a6c3019a474fd6f485823"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var account *string
+			nc := func(cfg clients.Config) (kvpair.Client, error) {
+				account = cfg.AccountID
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.account, account); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want account, +got account:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client kvpair.Client
+		kube   client.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotPair": {
+			reason: "An error should be returned if the managed resource is not a *WorkersKVPair",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotPair),
+			},
+		},
+		"ErrNoPair": {
+			reason: "We should return ResourceExists: false when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: Pair(),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrNoNamespace": {
+			reason: "We should return an error if the WorkersKVPair has no namespace",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: Pair(withExternalName("greeting")),
+			},
+			want: want{
+				err: errors.New(errPairNoNamespace),
+			},
+		},
+		"ErrPairLookup": {
+			reason: "We should return an empty observation and an error if the API returned an error",
+			fields: fields{
+				client: fake.MockClient{
+					MockReadWorkersKV: func(ctx context.Context, namespaceID, key string) ([]byte, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: Pair(withExternalName("greeting"), withNamespace(testNamespace)),
+			},
+			want: want{
+				o:   managed.ExternalObservation{},
+				err: errors.Wrap(errBoom, errPairLookup),
+			},
+		},
+		"ErrPairNotFound": {
+			reason: "We should return ResourceExists: false if the key is not found (deleted on CF side)",
+			fields: fields{
+				client: fake.MockClient{
+					MockReadWorkersKV: func(ctx context.Context, namespaceID, key string) ([]byte, error) {
+						return nil, errors.New("HTTP status 404: key not found")
+					},
+				},
+			},
+			args: args{
+				mg: Pair(withExternalName("greeting"), withNamespace(testNamespace)),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrPairValue": {
+			reason: "We should return an error if the value cannot be read from the referenced Secret",
+			fields: fields{
+				client: fake.MockClient{
+					MockReadWorkersKV: func(ctx context.Context, namespaceID, key string) ([]byte, error) {
+						return []byte("hello"), nil
+					},
+				},
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+			},
+			args: args{
+				mg: Pair(
+					withExternalName("greeting"),
+					withNamespace(testNamespace),
+					withValueSecretRef(xpv1.SecretKeySelector{Key: "value"}),
+				),
+			},
+			want: want{
+				o:   managed.ExternalObservation{},
+				err: errors.Wrap(errors.Wrap(errBoom, "cannot get Secret"), errPairValue),
+			},
+		},
+		"NotUpToDate": {
+			reason: "We should return ResourceUpToDate: false when the value differs",
+			fields: fields{
+				client: fake.MockClient{
+					MockReadWorkersKV: func(ctx context.Context, namespaceID, key string) ([]byte, error) {
+						return []byte("hello"), nil
+					},
+				},
+			},
+			args: args{
+				mg: Pair(withExternalName("greeting"), withNamespace(testNamespace), withValue("goodbye")),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"UpToDate": {
+			reason: "We should return ResourceUpToDate: true when the value matches",
+			fields: fields{
+				client: fake.MockClient{
+					MockReadWorkersKV: func(ctx context.Context, namespaceID, key string) ([]byte, error) {
+						return []byte("hello"), nil
+					},
+				},
+			},
+			args: args{
+				mg: Pair(withExternalName("greeting"), withNamespace(testNamespace), withValue("hello")),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client, kube: tc.fields.kube}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client kvpair.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotPair": {
+			reason: "An error should be returned if the managed resource is not a *WorkersKVPair",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotPair),
+			},
+		},
+		"ErrNoNamespace": {
+			reason: "We should return an error if the WorkersKVPair has no namespace",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: Pair(),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errPairNoNamespace), errPairCreation),
+			},
+		},
+		"ErrPairCreate": {
+			reason: "We should return any errors during the create process",
+			fields: fields{
+				client: fake.MockClient{
+					MockWriteWorkersKV: func(ctx context.Context, namespaceID, key string, value []byte) (cloudflare.Response, error) {
+						return cloudflare.Response{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: Pair(withNamespace(testNamespace), withValue("hello")),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errPairCreation),
+			},
+		},
+		"Success": {
+			reason: "We should return ExternalNameAssigned: true and no error when a pair is created",
+			fields: fields{
+				client: fake.MockClient{
+					MockWriteWorkersKV: func(ctx context.Context, namespaceID, key string, value []byte) (cloudflare.Response, error) {
+						return cloudflare.Response{}, nil
+					},
+				},
+			},
+			args: args{
+				mg: Pair(withNamespace(testNamespace), withValue("hello")),
+			},
+			want: want{
+				o: managed.ExternalCreation{ExternalNameAssigned: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client kvpair.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotPair": {
+			reason: "An error should be returned if the managed resource is not a *WorkersKVPair",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotPair),
+			},
+		},
+		"ErrNoExternalName": {
+			reason: "We should return an error if the pair has no external name",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: Pair(withNamespace(testNamespace)),
+			},
+			want: want{
+				err: errors.New(errPairUpdate),
+			},
+		},
+		"ErrPairUpdate": {
+			reason: "We should return any errors during the update process",
+			fields: fields{
+				client: fake.MockClient{
+					MockWriteWorkersKV: func(ctx context.Context, namespaceID, key string, value []byte) (cloudflare.Response, error) {
+						return cloudflare.Response{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: Pair(withExternalName("greeting"), withNamespace(testNamespace), withValue("hello")),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errPairUpdate),
+			},
+		},
+		"Success": {
+			reason: "We should return no error when the pair is updated",
+			fields: fields{
+				client: fake.MockClient{
+					MockWriteWorkersKV: func(ctx context.Context, namespaceID, key string, value []byte) (cloudflare.Response, error) {
+						return cloudflare.Response{}, nil
+					},
+				},
+			},
+			args: args{
+				mg: Pair(withExternalName("greeting"), withNamespace(testNamespace), withValue("hello")),
+			},
+			want: want{
+				o: managed.ExternalUpdate{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client kvpair.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotPair": {
+			reason: "An error should be returned if the managed resource is not a *WorkersKVPair",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotPair),
+			},
+		},
+		"ErrPairDelete": {
+			reason: "We should return any errors during the delete process",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteWorkersKV: func(ctx context.Context, namespaceID, key string) (cloudflare.Response, error) {
+						return cloudflare.Response{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: Pair(withExternalName("greeting"), withNamespace(testNamespace)),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errPairDeletion),
+			},
+		},
+		"PairNotFound": {
+			reason: "We should not return an error if the pair was already deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteWorkersKV: func(ctx context.Context, namespaceID, key string) (cloudflare.Response, error) {
+						return cloudflare.Response{}, errors.New("HTTP status 404: key not found")
+					},
+				},
+			},
+			args: args{
+				mg: Pair(withExternalName("greeting"), withNamespace(testNamespace)),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"Success": {
+			reason: "We should return no error when the pair is deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteWorkersKV: func(ctx context.Context, namespaceID, key string) (cloudflare.Response, error) {
+						return cloudflare.Response{}, nil
+					},
+				},
+			},
+			args: args{
+				mg: Pair(withExternalName("greeting"), withNamespace(testNamespace)),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
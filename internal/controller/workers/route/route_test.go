@@ -258,7 +258,7 @@ func TestObserve(t *testing.T) {
 			fields: fields{
 				client: fake.MockClient{
 					MockGetWorkerRoute: func(ctx context.Context, zoneID string, routeID string) (cloudflare.WorkerRouteResponse, error) {
-						return cloudflare.WorkerRouteResponse{}, errors.New("10007")
+						return cloudflare.WorkerRouteResponse{}, &cloudflare.APIRequestError{StatusCode: http.StatusNotFound, Errors: []cloudflare.ResponseInfo{{Code: 10007, Message: "workers.api.error.route_not_found"}}}
 					},
 				},
 			},
@@ -273,6 +273,87 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
+		"LateInitScript": {
+			reason: "We should late initialize the script of a Route from the remote route",
+			fields: fields{
+				client: fake.MockClient{
+					MockGetWorkerRoute: func(ctx context.Context, zoneID string, routeID string) (cloudflare.WorkerRouteResponse, error) {
+						return cloudflare.WorkerRouteResponse{
+							WorkerRoute: cloudflare.WorkerRoute{
+								ID:      routeID,
+								Pattern: "example.com/*",
+								Script:  "test-worker",
+							},
+						}, nil
+					},
+				},
+			},
+			args: args{
+				mg: Route(withExternalName("1234beef"), withZone("foo.com"), withPattern("example.com/*")),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceLateInitialized: true,
+					ResourceUpToDate:        true,
+				},
+				err: nil,
+			},
+		},
+		"PatternChanged": {
+			reason: "We should return ResourceUpToDate: false when the pattern of a Route has changed",
+			fields: fields{
+				client: fake.MockClient{
+					MockGetWorkerRoute: func(ctx context.Context, zoneID string, routeID string) (cloudflare.WorkerRouteResponse, error) {
+						return cloudflare.WorkerRouteResponse{
+							WorkerRoute: cloudflare.WorkerRoute{
+								ID:      routeID,
+								Pattern: "example.com/*",
+								Script:  "test-worker",
+							},
+						}, nil
+					},
+				},
+			},
+			args: args{
+				mg: Route(withExternalName("1234beef"), withZone("foo.com"),
+					withPattern("example.com/api/*"), withScript("test-worker")),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				err: nil,
+			},
+		},
+		"ScriptChanged": {
+			reason: "We should return ResourceUpToDate: false when the script of a Route has changed",
+			fields: fields{
+				client: fake.MockClient{
+					MockGetWorkerRoute: func(ctx context.Context, zoneID string, routeID string) (cloudflare.WorkerRouteResponse, error) {
+						return cloudflare.WorkerRouteResponse{
+							WorkerRoute: cloudflare.WorkerRoute{
+								ID:      routeID,
+								Pattern: "example.com/*",
+								Script:  "test-worker",
+							},
+						}, nil
+					},
+				},
+			},
+			args: args{
+				mg: Route(withExternalName("1234beef"), withZone("foo.com"),
+					withPattern("example.com/*"), withScript("new-worker")),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				err: nil,
+			},
+		},
 		"Success": {
 			reason: "We should return ResourceExists: true and no error when a Route is found",
 			fields: fields{
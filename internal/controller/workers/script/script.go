@@ -0,0 +1,268 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package script
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/benagricola/provider-cloudflare/apis/workers/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	"github.com/benagricola/provider-cloudflare/internal/clients/workers/script"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
+	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
+)
+
+const (
+	errNotScript = "managed resource is not a WorkerScript custom resource"
+
+	errClientConfig = "error getting client config"
+
+	errScriptLookup    = "cannot lookup worker script"
+	errScriptCreation  = "cannot create worker script"
+	errScriptUpdate    = "cannot update worker script"
+	errScriptDeletion  = "cannot delete worker script"
+	errScriptContent   = "cannot resolve worker script content"
+	errScriptBindings  = "cannot resolve worker script bindings"
+	errScriptNoAccount = "no account found"
+
+	// scriptDownloadTimeout bounds how long downloading script content
+	// from a URL may take.
+	scriptDownloadTimeout = 30 * time.Second
+)
+
+// Setup adds a controller that reconciles WorkerScript managed resources.
+func Setup(mgr ctrl.Manager, o options.Options) error {
+	name := managed.ControllerName(v1alpha1.WorkerScriptGroupKind)
+	o = o.For(v1alpha1.WorkerScriptGroupKind)
+
+	hc := metrics.NewInstrumentedHTTPClient(name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.WorkerScriptGroupVersionKind),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube:    mgr.GetClient(),
+			fetcher: script.NewURLFetcher(&http.Client{Timeout: scriptDownloadTimeout}, o.WorkerScriptHosts),
+			newCloudflareClientFn: func(cfg clients.Config) (script.Client, error) {
+				return script.NewClient(cfg, hc)
+			},
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
+		// Do not initialize external-name field.
+		managed.WithInitializers(),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1alpha1.WorkerScript{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                  client.Client
+	fetcher               *script.URLFetcher
+	newCloudflareClientFn func(cfg clients.Config) (script.Client, error)
+}
+
+// Connect produces a valid configuration for a Cloudflare API
+// instance, and returns it as an external client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.WorkerScript)
+	if !ok {
+		return nil, errors.New(errNotScript)
+	}
+
+	// Get client configuration
+	config, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errClientConfig)
+	}
+
+	// Scripts are uploaded to an account rather than a zone.
+	if cr.Spec.ForProvider.Account != nil {
+		config.AccountID = cr.Spec.ForProvider.Account
+	}
+
+	client, err := c.newCloudflareClientFn(*config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{client: client, kube: c.kube, fetcher: c.fetcher}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client  script.Client
+	kube    client.Client
+	fetcher *script.URLFetcher
+}
+
+// desired resolves the content and bindings the Worker Script should have.
+func (e *external) desired(ctx context.Context, cr *v1alpha1.WorkerScript) (string, map[string]cloudflare.WorkerBinding, error) {
+	s, err := script.ResolveScript(ctx, e.kube, e.fetcher, cr.Spec.ForProvider.Script)
+	if err != nil {
+		return "", nil, errors.Wrap(err, errScriptContent)
+	}
+	b, err := script.ResolveBindings(ctx, e.kube, cr.Spec.ForProvider.Bindings)
+	if err != nil {
+		return "", nil, errors.Wrap(err, errScriptBindings)
+	}
+	return s, b, nil
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.WorkerScript)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotScript)
+	}
+
+	// Script does not exist if we dont have a name stored in external-name
+	name := meta.GetExternalName(cr)
+	if name == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalObservation{}, errors.New(errScriptNoAccount)
+	}
+
+	m, err := script.LookupScript(ctx, e.client, name)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errScriptLookup)
+	}
+	if m == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	rp := &cloudflare.WorkerRequestParams{ScriptName: name}
+	o, err := e.client.DownloadWorker(ctx, rp)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errScriptLookup)
+	}
+	ob, err := e.client.ListWorkerBindings(ctx, rp)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errScriptLookup)
+	}
+
+	// Content is not resolved with desired, which would download scripts
+	// from their URL on every poll.
+	upToDate, err := script.ScriptUpToDate(ctx, e.kube, cr.Spec.ForProvider.Script, o.Script)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errScriptContent)
+	}
+	b, err := script.ResolveBindings(ctx, e.kube, cr.Spec.ForProvider.Bindings)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errScriptBindings)
+	}
+
+	cr.Status.AtProvider = script.GenerateObservation(*m)
+	cr.Status.SetConditions(rtv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate && script.BindingsUpToDate(b, ob.BindingList),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.WorkerScript)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotScript)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalCreation{}, errors.Wrap(errors.New(errScriptNoAccount), errScriptCreation)
+	}
+
+	s, b, err := e.desired(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errScriptCreation)
+	}
+
+	if _, err := script.UploadScript(ctx, e.client, cr.Spec.ForProvider.Name, s, b); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errScriptCreation)
+	}
+
+	// Worker Scripts are identified by their name
+	meta.SetExternalName(cr, cr.Spec.ForProvider.Name)
+
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.WorkerScript)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotScript)
+	}
+
+	name := meta.GetExternalName(cr)
+	if name == "" {
+		return managed.ExternalUpdate{}, errors.New(errScriptUpdate)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return managed.ExternalUpdate{}, errors.Wrap(errors.New(errScriptNoAccount), errScriptUpdate)
+	}
+
+	s, b, err := e.desired(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errScriptUpdate)
+	}
+
+	_, err = script.UploadScript(ctx, e.client, name, s, b)
+	return managed.ExternalUpdate{}, errors.Wrap(err, errScriptUpdate)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.WorkerScript)
+	if !ok {
+		return errors.New(errNotScript)
+	}
+
+	if cr.Spec.ForProvider.Account == nil {
+		return errors.Wrap(errors.New(errScriptNoAccount), errScriptDeletion)
+	}
+
+	name := meta.GetExternalName(cr)
+	if name == "" {
+		return errors.New(errScriptDeletion)
+	}
+
+	_, err := e.client.DeleteWorker(ctx, &cloudflare.WorkerRequestParams{ScriptName: name})
+
+	return errors.Wrap(resource.Ignore(script.IsScriptNotFound, err), errScriptDeletion)
+}
@@ -0,0 +1,723 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package script
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	pcv1alpha1 "github.com/benagricola/provider-cloudflare/apis/v1alpha1"
+	"github.com/benagricola/provider-cloudflare/apis/workers/v1alpha1"
+	clients "github.com/benagricola/provider-cloudflare/internal/clients"
+	script "github.com/benagricola/provider-cloudflare/internal/clients/workers/script"
+	"github.com/benagricola/provider-cloudflare/internal/clients/workers/script/fake"
+
+	corev1 "k8s.io/api/core/v1"
+	ptr "k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	rtfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+const (
+	testContent       = "addEventListener('fetch', e => e.respondWith(new Response('ok')))"
+	testContentSHA256 = "a3f8d02866aec050994d85dad1a2332e7cbe17afd686e0bc5e1e92f312dcdeaf"
+)
+
+type ScriptModifier func(*v1alpha1.WorkerScript)
+
+func withAccount(accountID string) ScriptModifier {
+	return func(r *v1alpha1.WorkerScript) { r.Spec.ForProvider.Account = &accountID }
+}
+
+func withExternalName(name string) ScriptModifier {
+	return func(r *v1alpha1.WorkerScript) { meta.SetExternalName(r, name) }
+}
+
+func withSource(src v1alpha1.WorkerScriptSource) ScriptModifier {
+	return func(r *v1alpha1.WorkerScript) { r.Spec.ForProvider.Script = src }
+}
+
+func Script(m ...ScriptModifier) *v1alpha1.WorkerScript {
+	cr := &v1alpha1.WorkerScript{
+		Spec: v1alpha1.WorkerScriptSpec{
+			ForProvider: v1alpha1.WorkerScriptParameters{
+				Name: "hello",
+				Script: v1alpha1.WorkerScriptSource{
+					Inline: ptr.StringPtr(testContent),
+				},
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func existing(name string) func(ctx context.Context) (cloudflare.WorkerListResponse, error) {
+	return func(ctx context.Context) (cloudflare.WorkerListResponse, error) {
+		return cloudflare.WorkerListResponse{
+			WorkerList: []cloudflare.WorkerMetaData{{ID: name, ETAG: "etag"}},
+		}, nil
+	}
+}
+
+func TestConnect(t *testing.T) {
+	mc := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil),
+	}
+
+	_, errGetProviderConfig := clients.GetConfig(context.Background(), mc, &rtfake.Managed{})
+
+	kube := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			switch o := obj.(type) {
+			case *pcv1alpha1.ProviderConfig:
+				o.Spec.Credentials.Source = "Secret"
+				o.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{
+					Key: "creds",
+				}
+			case *corev1.Secret:
+				o.Data = map[string][]byte{
+					"creds": []byte("{\"APIKey\":\"foo\",\"Email\":\"foo@bar.com\"}"),
+				}
+			}
+			return nil
+		}),
+		MockCreate: test.NewMockCreateFn(nil),
+	}
+
+	type fields struct {
+		kube      client.Client
+		newClient func(cfg clients.Config, hc *http.Client) (script.Client, error)
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err     error
+		account *string
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotScript": {
+			reason: "An error should be returned if the managed resource is not a *WorkerScript",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotScript),
+			},
+		},
+		"ErrGetConfig": {
+			reason: "Any errors from GetConfig should be wrapped",
+			fields: fields{
+				kube: mc,
+			},
+			args: args{
+				mg: &v1alpha1.WorkerScript{
+					Spec: v1alpha1.WorkerScriptSpec{
+						ResourceSpec: xpv1.ResourceSpec{},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errGetProviderConfig, errClientConfig),
+			},
+		},
+		"ConnectAccountScoped": {
+			reason: "Connect should scope the client to the account of the WorkerScript",
+			fields: fields{
+				kube:      kube,
+				newClient: script.NewClient,
+			},
+			args: args{
+				mg: &v1alpha1.WorkerScript{
+					Spec: v1alpha1.WorkerScriptSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{
+								Name: "blah",
+							},
+						},
+						ForProvider: v1alpha1.WorkerScriptParameters{
+							Account: ptr.StringPtr("01a7362d577a6c3019a474fd6f485823"),
+						},
+					},
+				},
+			},
+			want: want{
+				err:     nil,
+				account: ptr.StringPtr("01a7362d577a6c3019a474fd6f485823"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var account *string
+			nc := func(cfg clients.Config) (script.Client, error) {
+				account = cfg.AccountID
+				return tc.fields.newClient(cfg, nil)
+			}
+			e := &connector{kube: tc.fields.kube, newCloudflareClientFn: nc}
+			_, err := e.Connect(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.account, account); diff != "" {
+				t.Errorf("\n%s\ne.Connect(...): -want account, +got account:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client script.Client
+		kube   client.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotScript": {
+			reason: "An error should be returned if the managed resource is not a *WorkerScript",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotScript),
+			},
+		},
+		"ErrNoScript": {
+			reason: "We should return ResourceExists: false when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: Script(),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the WorkerScript has no account",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: Script(withExternalName("hello")),
+			},
+			want: want{
+				err: errors.New(errScriptNoAccount),
+			},
+		},
+		"ErrScriptLookup": {
+			reason: "We should return an empty observation and an error if the API returned an error",
+			fields: fields{
+				client: fake.MockClient{
+					MockListWorkerScripts: func(ctx context.Context) (cloudflare.WorkerListResponse, error) {
+						return cloudflare.WorkerListResponse{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: Script(withExternalName("hello"), withAccount("01a7362d577a6c3019a474fd6f485823")),
+			},
+			want: want{
+				o:   managed.ExternalObservation{},
+				err: errors.Wrap(errors.Wrap(errBoom, "error listing worker scripts"), errScriptLookup),
+			},
+		},
+		"ErrScriptNotFound": {
+			reason: "We should return ResourceExists: false if the WorkerScript is not found (deleted on CF side)",
+			fields: fields{
+				client: fake.MockClient{
+					MockListWorkerScripts: existing("other"),
+				},
+			},
+			args: args{
+				mg: Script(withExternalName("hello"), withAccount("01a7362d577a6c3019a474fd6f485823")),
+			},
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: false},
+				err: nil,
+			},
+		},
+		"ErrScriptContent": {
+			reason: "We should return an error if the script content cannot be resolved",
+			fields: fields{
+				client: fake.MockClient{
+					MockListWorkerScripts: existing("hello"),
+					MockDownloadWorker: func(ctx context.Context, rp *cloudflare.WorkerRequestParams) (cloudflare.WorkerScriptResponse, error) {
+						return cloudflare.WorkerScriptResponse{}, nil
+					},
+					MockListWorkerBindings: func(ctx context.Context, rp *cloudflare.WorkerRequestParams) (cloudflare.WorkerBindingListResponse, error) {
+						return cloudflare.WorkerBindingListResponse{}, nil
+					},
+				},
+			},
+			args: args{
+				mg: Script(
+					withExternalName("hello"),
+					withAccount("01a7362d577a6c3019a474fd6f485823"),
+					withSource(v1alpha1.WorkerScriptSource{}),
+				),
+			},
+			want: want{
+				o:   managed.ExternalObservation{},
+				err: errors.Wrap(errors.New("no script source specified"), errScriptContent),
+			},
+		},
+		"NotUpToDate": {
+			reason: "We should return ResourceUpToDate: false when the script content differs",
+			fields: fields{
+				client: fake.MockClient{
+					MockListWorkerScripts: existing("hello"),
+					MockDownloadWorker: func(ctx context.Context, rp *cloudflare.WorkerRequestParams) (cloudflare.WorkerScriptResponse, error) {
+						r := cloudflare.WorkerScriptResponse{}
+						r.Script = "old content"
+						return r, nil
+					},
+					MockListWorkerBindings: func(ctx context.Context, rp *cloudflare.WorkerRequestParams) (cloudflare.WorkerBindingListResponse, error) {
+						return cloudflare.WorkerBindingListResponse{}, nil
+					},
+				},
+			},
+			args: args{
+				mg: Script(withExternalName("hello"), withAccount("01a7362d577a6c3019a474fd6f485823")),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				err: nil,
+			},
+		},
+		"URLNotDownloaded": {
+			reason: "We should compare the content of a script from a URL against its checksum rather than download it",
+			fields: fields{
+				client: fake.MockClient{
+					MockListWorkerScripts: existing("hello"),
+					MockDownloadWorker: func(ctx context.Context, rp *cloudflare.WorkerRequestParams) (cloudflare.WorkerScriptResponse, error) {
+						r := cloudflare.WorkerScriptResponse{}
+						r.Script = "old content"
+						return r, nil
+					},
+					MockListWorkerBindings: func(ctx context.Context, rp *cloudflare.WorkerRequestParams) (cloudflare.WorkerBindingListResponse, error) {
+						return cloudflare.WorkerBindingListResponse{}, nil
+					},
+				},
+			},
+			args: args{
+				mg: Script(
+					withExternalName("hello"),
+					withAccount("01a7362d577a6c3019a474fd6f485823"),
+					withSource(v1alpha1.WorkerScriptSource{
+						URL:    ptr.StringPtr("https://example.com/worker.js"),
+						SHA256: ptr.StringPtr(testContentSHA256),
+					}),
+				),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				err: nil,
+			},
+		},
+		"Success": {
+			reason: "We should return ResourceExists: true and no error when a WorkerScript is found",
+			fields: fields{
+				client: fake.MockClient{
+					MockListWorkerScripts: existing("hello"),
+					MockDownloadWorker: func(ctx context.Context, rp *cloudflare.WorkerRequestParams) (cloudflare.WorkerScriptResponse, error) {
+						r := cloudflare.WorkerScriptResponse{}
+						r.Script = testContent
+						return r, nil
+					},
+					MockListWorkerBindings: func(ctx context.Context, rp *cloudflare.WorkerRequestParams) (cloudflare.WorkerBindingListResponse, error) {
+						return cloudflare.WorkerBindingListResponse{}, nil
+					},
+				},
+			},
+			args: args{
+				mg: Script(withExternalName("hello"), withAccount("01a7362d577a6c3019a474fd6f485823")),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client, kube: tc.fields.kube}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client script.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotScript": {
+			reason: "An error should be returned if the managed resource is not a *WorkerScript",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotScript),
+			},
+		},
+		"ErrNoAccount": {
+			reason: "We should return an error if the WorkerScript has no account",
+			args: args{
+				mg: Script(),
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errScriptNoAccount), errScriptCreation),
+			},
+		},
+		"ErrScriptCreate": {
+			reason: "We should return any errors during the create process",
+			fields: fields{
+				client: fake.MockClient{
+					MockUploadWorkerWithBindings: func(ctx context.Context, rp *cloudflare.WorkerRequestParams, data *cloudflare.WorkerScriptParams) (cloudflare.WorkerScriptResponse, error) {
+						return cloudflare.WorkerScriptResponse{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: Script(withAccount("01a7362d577a6c3019a474fd6f485823")),
+			},
+			want: want{
+				o:   managed.ExternalCreation{},
+				err: errors.Wrap(errors.Wrap(errBoom, "error uploading worker script"), errScriptCreation),
+			},
+		},
+		"Success": {
+			reason: "We should return ExternalNameAssigned: true and no error when a WorkerScript is created",
+			fields: fields{
+				client: fake.MockClient{
+					MockUploadWorkerWithBindings: func(ctx context.Context, rp *cloudflare.WorkerRequestParams, data *cloudflare.WorkerScriptParams) (cloudflare.WorkerScriptResponse, error) {
+						if rp.ScriptName != "hello" || data.Script != testContent {
+							return cloudflare.WorkerScriptResponse{}, errBoom
+						}
+						return cloudflare.WorkerScriptResponse{}, nil
+					},
+				},
+			},
+			args: args{
+				mg: Script(withAccount("01a7362d577a6c3019a474fd6f485823")),
+			},
+			want: want{
+				o: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client script.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalUpdate
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotScript": {
+			reason: "An error should be returned if the managed resource is not a *WorkerScript",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotScript),
+			},
+		},
+		"ErrNoScript": {
+			reason: "We should return an error when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: Script(),
+			},
+			want: want{
+				o:   managed.ExternalUpdate{},
+				err: errors.New(errScriptUpdate),
+			},
+		},
+		"ErrScriptUpdate": {
+			reason: "We should return any errors during the update process",
+			fields: fields{
+				client: fake.MockClient{
+					MockUploadWorkerWithBindings: func(ctx context.Context, rp *cloudflare.WorkerRequestParams, data *cloudflare.WorkerScriptParams) (cloudflare.WorkerScriptResponse, error) {
+						return cloudflare.WorkerScriptResponse{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: Script(withExternalName("hello"), withAccount("01a7362d577a6c3019a474fd6f485823")),
+			},
+			want: want{
+				o:   managed.ExternalUpdate{},
+				err: errors.Wrap(errors.Wrap(errBoom, "error uploading worker script"), errScriptUpdate),
+			},
+		},
+		"Success": {
+			reason: "We should return no error when a WorkerScript is updated",
+			fields: fields{
+				client: fake.MockClient{
+					MockUploadWorkerWithBindings: func(ctx context.Context, rp *cloudflare.WorkerRequestParams, data *cloudflare.WorkerScriptParams) (cloudflare.WorkerScriptResponse, error) {
+						return cloudflare.WorkerScriptResponse{}, nil
+					},
+				},
+			},
+			args: args{
+				mg: Script(withExternalName("hello"), withAccount("01a7362d577a6c3019a474fd6f485823")),
+			},
+			want: want{
+				o:   managed.ExternalUpdate{},
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client script.Client
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotScript": {
+			reason: "An error should be returned if the managed resource is not a *WorkerScript",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotScript),
+			},
+		},
+		"ErrNoScript": {
+			reason: "We should return an error when no external name is set",
+			fields: fields{
+				client: fake.MockClient{},
+			},
+			args: args{
+				mg: Script(withAccount("01a7362d577a6c3019a474fd6f485823")),
+			},
+			want: want{
+				err: errors.New(errScriptDeletion),
+			},
+		},
+		"ErrScriptDelete": {
+			reason: "We should return any errors during the delete process",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteWorker: func(ctx context.Context, rp *cloudflare.WorkerRequestParams) (cloudflare.WorkerScriptResponse, error) {
+						return cloudflare.WorkerScriptResponse{}, errBoom
+					},
+				},
+			},
+			args: args{
+				mg: Script(withExternalName("hello"), withAccount("01a7362d577a6c3019a474fd6f485823")),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errScriptDeletion),
+			},
+		},
+		"ScriptNotFound": {
+			reason: "We should return no error if the WorkerScript was already deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteWorker: func(ctx context.Context, rp *cloudflare.WorkerRequestParams) (cloudflare.WorkerScriptResponse, error) {
+						return cloudflare.WorkerScriptResponse{}, &cloudflare.APIRequestError{StatusCode: http.StatusNotFound, Errors: []cloudflare.ResponseInfo{{Code: 10007, Message: "workers.api.error.script_not_found"}}}
+					},
+				},
+			},
+			args: args{
+				mg: Script(withExternalName("hello"), withAccount("01a7362d577a6c3019a474fd6f485823")),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"Success": {
+			reason: "We should return no error when a WorkerScript is deleted",
+			fields: fields{
+				client: fake.MockClient{
+					MockDeleteWorker: func(ctx context.Context, rp *cloudflare.WorkerRequestParams) (cloudflare.WorkerScriptResponse, error) {
+						return cloudflare.WorkerScriptResponse{}, nil
+					},
+				},
+			},
+			args: args{
+				mg: Script(withExternalName("hello"), withAccount("01a7362d577a6c3019a474fd6f485823")),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client}
+			err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
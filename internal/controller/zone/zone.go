@@ -18,27 +18,29 @@ package zone
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/cloudflare/cloudflare-go"
 	"github.com/pkg/errors"
 
-	"k8s.io/client-go/util/workqueue"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 
 	rtv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
-	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
-	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/benagricola/provider-cloudflare/apis/zone/v1alpha1"
 	clients "github.com/benagricola/provider-cloudflare/internal/clients"
 	zones "github.com/benagricola/provider-cloudflare/internal/clients/zones"
+	"github.com/benagricola/provider-cloudflare/internal/controller/options"
+	"github.com/benagricola/provider-cloudflare/internal/controller/policy"
 	metrics "github.com/benagricola/provider-cloudflare/internal/metrics"
 )
 
@@ -53,39 +55,53 @@ const (
 	errZoneUpdate      = "cannot update zone"
 	errZoneDeletion    = "cannot delete zone"
 
-	maxConcurrency = 5
-
 	zoneStatusActive = "active"
+
+	// devModeExpiryWarning is how long before Development Mode is
+	// automatically disabled that we emit an event about it.
+	devModeExpiryWarning = 15 * time.Minute
+
+	// settingsCacheTTL is how long the settings observed on a Zone are
+	// reused before they are looked up again. Settings are looked up
+	// again as soon as we change them, so this only delays noticing
+	// settings that were changed outside of Crossplane.
+	settingsCacheTTL = 15 * time.Minute
+
+	reasonDevModeExpiring event.Reason = "DevelopmentModeExpiring"
+	reasonPlanGated       event.Reason = "PlanGatedSettings"
+
+	msgDevModeExpiring = "development mode will be disabled in %s"
+	msgPlanGated       = "settings %s may only be enabled on a paid plan"
 )
 
 // Setup adds a controller that reconciles Zone managed resources.
-func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+func Setup(mgr ctrl.Manager, o options.Options) error {
 	name := managed.ControllerName(v1alpha1.ZoneGroupKind)
-
-	o := controller.Options{
-		RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
-		MaxConcurrentReconciles: maxConcurrency,
-	}
+	o = o.For(v1alpha1.ZoneGroupKind)
 
 	hc := metrics.NewInstrumentedHTTPClient(name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.ZoneGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
-			kube: mgr.GetClient(),
+		managed.WithExternalConnecter(policy.NewConnecter(&connector{
+			kube:     mgr.GetClient(),
+			recorder: recorder,
+			settings: zones.NewSettingsCache(settingsCacheTTL),
 			newCloudflareClientFn: func(cfg clients.Config) (zones.Client, error) {
 				return zones.NewClient(cfg, hc)
 			},
-		}),
-		managed.WithLogger(l.WithValues("controller", name)),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
-		managed.WithPollInterval(5*time.Minute),
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithRecorder(recorder),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(o.Timeout),
 		// Do not initialize external-name field.
 		managed.WithInitializers(),
 	)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		WithOptions(o).
+		WithOptions(o.ForControllerRuntime()).
 		For(&v1alpha1.Zone{}).
 		Complete(r)
 }
@@ -94,6 +110,8 @@ func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
 // is called.
 type connector struct {
 	kube                  client.Client
+	recorder              event.Recorder
+	settings              *zones.SettingsCache
 	newCloudflareClientFn func(cfg clients.Config) (zones.Client, error)
 }
 
@@ -116,13 +134,15 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, err
 	}
 
-	return &external{client: client}, nil
+	return &external{client: client, recorder: c.recorder, settings: c.settings}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	client zones.Client
+	client   zones.Client
+	recorder event.Recorder
+	settings *zones.SettingsCache
 }
 
 func (e *external) Observe(ctx context.Context,
@@ -145,27 +165,102 @@ func (e *external) Observe(ctx context.Context,
 			errors.Wrap(resource.Ignore(zones.IsZoneNotFound, err), errZoneLookup)
 	}
 
+	prev := cr.Status.AtProvider
 	cr.Status.AtProvider = zones.GenerateObservation(z)
 
-	if cr.Status.AtProvider.Status == zoneStatusActive {
+	// Cloudflare disables Development Mode automatically once the timer
+	// runs out, so let users know before this happens. The event is only
+	// emitted when Development Mode starts expiring, not on every poll.
+	if devModeExpiring(z.DevMode) && !devModeExpiring(prev.DevModeTimer) {
+		remaining := time.Duration(z.DevMode) * time.Second
+		e.recorder.Event(cr, event.Normal(reasonDevModeExpiring, fmt.Sprintf(msgDevModeExpiring, remaining)))
+	}
+
+	// Settings that need a paid plan would only fail to apply on a
+	// free Zone, so let users know why before we try. The warning is
+	// only emitted when these settings change, not on every poll.
+	keys := zones.PlanGatedSettings(&cr.Spec.ForProvider.Settings, z.Plan)
+	cr.Status.AtProvider.PlanGatedSettings = keys
+	if len(keys) > 0 && strings.Join(keys, ",") != strings.Join(prev.PlanGatedSettings, ",") {
+		e.recorder.Event(cr, event.Warning(reasonPlanGated, errors.Errorf(msgPlanGated, strings.Join(keys, ", "))))
+	}
+
+	// A partial Zone is not activated until its setup records are
+	// created elsewhere, so tell the user which records they need.
+	switch {
+	case cr.Status.AtProvider.Status == zoneStatusActive:
 		cr.Status.SetConditions(rtv1.Available())
-	} else {
+	case z.Type == zones.TypePartial:
+		cr.Status.SetConditions(v1alpha1.PendingActivation(zones.ActivationRecords(z)))
+	default:
 		cr.Status.SetConditions(rtv1.Unavailable())
 	}
 
 	observedSettings := &v1alpha1.ZoneSettings{}
-	if err := zones.LoadSettingsForZone(ctx, e.client, z.ID, observedSettings); err != nil {
+	ne, err := e.settings.Load(ctx, e.client, z.ID, observedSettings)
+	if err != nil {
 		return managed.ExternalObservation{ResourceExists: true},
 			errors.Wrap(err, errZoneObservation)
 	}
 
+	li := zones.LateInitialize(&cr.Spec.ForProvider, z, observedSettings)
+
+	// Settings outside of the allowlist are not managed by us.
+	desired := cr.Spec.ForProvider.DeepCopy()
+	zones.FilterSettings(&desired.Settings, desired.SettingsAllowlist)
+
+	// Settings that are desired but cannot be edited are reported
+	// and ignored, rather than repeatedly failing to update them.
+	if nes := zones.NonEditableSettings(&desired.Settings, ne); len(nes) > 0 {
+		cr.Status.SetConditions(v1alpha1.SettingsNotEditable(nes))
+		zones.RemoveSettings(&desired.Settings, nes...)
+	} else {
+		cr.Status.SetConditions(v1alpha1.SettingsEditable())
+	}
+
+	zones.SetSettingsSnapshot(&cr.Status.AtProvider, prev, observedSettings, &desired.Settings, metav1.Now())
+	managedSettings := cr.Spec.ForProvider.Settings.DeepCopy()
+	zones.FilterSettings(managedSettings, desired.SettingsAllowlist)
+	cr.Status.AtProvider.ManagedSettings = zones.ManagedSettingKeys(managedSettings)
+
+	// Settings that previously could not be applied no longer differ,
+	// either because they were applied or are no longer desired.
+	utd := zones.UpToDate(desired, z, observedSettings)
+	cr.Status.AtProvider.UnappliedSettings = prev.UnappliedSettings
+	switch {
+	case utd:
+		cr.Status.SetConditions(v1alpha1.SettingsApplied(), v1alpha1.SettingsEntitled())
+		cr.Status.AtProvider.UnappliedSettings = nil
+	case z.Status != zoneStatusActive && onlySettingsDiffer(desired, z, observedSettings):
+		// Settings cannot be changed until the Zone is active, so
+		// report them as not applied rather than calling Update on
+		// every poll until it is. They still show as changed.
+		cr.Status.SetConditions(v1alpha1.SettingsDeferred(z.Status))
+		utd = true
+	}
+
 	return managed.ExternalObservation{
 		ResourceExists:          true,
-		ResourceLateInitialized: zones.LateInitialize(&cr.Spec.ForProvider, z, observedSettings),
-		ResourceUpToDate:        zones.UpToDate(&cr.Spec.ForProvider, z, observedSettings),
+		ResourceLateInitialized: li,
+		ResourceUpToDate:        utd,
 	}, nil
 }
 
+// devModeExpiring returns true if Development Mode is enabled and will be
+// disabled within devModeExpiryWarning, given the seconds left on its timer.
+func devModeExpiring(timer int) bool {
+	remaining := time.Duration(timer) * time.Second
+	return remaining > 0 && remaining <= devModeExpiryWarning
+}
+
+// onlySettingsDiffer returns true if the passed Zone would be up to date
+// with the passed parameters if its settings were.
+func onlySettingsDiffer(desired *v1alpha1.ZoneParameters, z cloudflare.Zone, ozs *v1alpha1.ZoneSettings) bool {
+	d := desired.DeepCopy()
+	d.Settings = *ozs.DeepCopy()
+	return zones.UpToDate(d, z, ozs)
+}
+
 func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
 	cr, ok := mg.(*v1alpha1.Zone)
 	if !ok {
@@ -199,6 +294,11 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		*cr.Spec.ForProvider.Type,
 	)
 	if err != nil {
+		// A held zone cannot be created until the hold is released,
+		// so tell the user why rather than just retrying.
+		if zones.IsZoneHeld(err) {
+			cr.Status.SetConditions(v1alpha1.Held())
+		}
 		return managed.ExternalCreation{}, errors.Wrap(err, errZoneCreation)
 	}
 
@@ -221,14 +321,50 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errZoneUpdate)
 	}
 
-	return managed.ExternalUpdate{}, errors.Wrap(
-		zones.UpdateZone(
-			ctx,
-			e.client,
-			zid,
-			cr.Spec.ForProvider,
-		),
-		errZoneUpdate)
+	observedSettings := &v1alpha1.ZoneSettings{}
+	err := zones.UpdateZone(ctx, e.client, zid, cr.Spec.ForProvider, observedSettings, cr.Status.AtProvider.UnappliedSettings)
+	e.settings.Invalidate(zid)
+
+	// Settings of a Zone that is not active yet are applied once it is.
+	if status, ok := zones.SettingsDeferred(err); ok {
+		cr.Status.SetConditions(v1alpha1.SettingsDeferred(status))
+		return managed.ExternalUpdate{}, nil
+	}
+
+	// Cloudflare returns the settings it updated, so the settings
+	// snapshot reflects them without waiting for the next observation.
+	failed, notEntitled := zones.FailedSettings(err)
+	if err == nil || len(failed)+len(notEntitled) > 0 {
+		// Settings outside of the allowlist are not managed by us, as
+		// when observing.
+		desired := cr.Spec.ForProvider.Settings.DeepCopy()
+		zones.FilterSettings(desired, cr.Spec.ForProvider.SettingsAllowlist)
+		zones.RefreshSettingsSnapshot(&cr.Status.AtProvider, observedSettings, desired, metav1.Now())
+	}
+
+	// Settings that could not be applied are reported on the Zone as
+	// well as failing the update, though all other changes were made.
+	// They are retried on their own the next time the Zone is found to
+	// be outdated. Settings the account is not entitled to use are
+	// reported apart from invalid settings, as they cannot be fixed in
+	// the Zone spec.
+	if len(failed)+len(notEntitled) > 0 {
+		applied, entitled := v1alpha1.SettingsApplied(), v1alpha1.SettingsEntitled()
+		if len(failed) > 0 {
+			applied = v1alpha1.SettingsNotApplied(failed)
+		}
+		if len(notEntitled) > 0 {
+			entitled = v1alpha1.SettingsNotEntitled(notEntitled)
+		}
+		cr.Status.SetConditions(applied, entitled)
+		cr.Status.AtProvider.UnappliedSettings = append(failed, notEntitled...)
+		sort.Strings(cr.Status.AtProvider.UnappliedSettings)
+	}
+	if err == nil {
+		cr.Status.SetConditions(v1alpha1.SettingsApplied(), v1alpha1.SettingsEntitled())
+		cr.Status.AtProvider.UnappliedSettings = nil
+	}
+	return managed.ExternalUpdate{}, errors.Wrap(err, errZoneUpdate)
 }
 
 func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
@@ -245,5 +381,6 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 	}
 
 	_, err := e.client.DeleteZone(ctx, zid)
+	e.settings.Invalidate(zid)
 	return errors.Wrap(err, errZoneDeletion)
 }
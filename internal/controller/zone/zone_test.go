@@ -23,14 +23,17 @@ import (
 
 	"github.com/cloudflare/cloudflare-go"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 
 	"github.com/pkg/errors"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	ptr "k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
@@ -79,6 +82,19 @@ func zone(m ...zoneModifier) *v1alpha1.Zone {
 	return cr
 }
 
+// eventRecorder records the events emitted during a test.
+type eventRecorder struct {
+	events []event.Event
+}
+
+func (r *eventRecorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *eventRecorder) WithAnnotations(_ ...string) event.Recorder {
+	return r
+}
+
 func TestConnect(t *testing.T) {
 	mc := &test.MockClient{
 		MockGet: test.NewMockGetFn(nil),
@@ -376,6 +392,206 @@ func TestObserve(t *testing.T) {
 	}
 }
 
+func TestObserveDevModeTimer(t *testing.T) {
+	type args struct {
+		prevDevMode int
+		devMode     int
+	}
+
+	type want struct {
+		timer  int
+		events []event.Event
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"DevModeDisabled": {
+			reason: "No event should be emitted when development mode is disabled",
+			args: args{
+				devMode: 0,
+			},
+			want: want{
+				timer: 0,
+			},
+		},
+		"DevModeNotExpiring": {
+			reason: "No event should be emitted when development mode is not about to expire",
+			args: args{
+				devMode: 3600,
+			},
+			want: want{
+				timer: 3600,
+			},
+		},
+		"DevModeExpiring": {
+			reason: "An event should be emitted when development mode is about to expire",
+			args: args{
+				devMode: 300,
+			},
+			want: want{
+				timer: 300,
+				events: []event.Event{
+					event.Normal(reasonDevModeExpiring, "development mode will be disabled in 5m0s"),
+				},
+			},
+		},
+		"DevModeStartsExpiring": {
+			reason: "An event should be emitted when development mode was not about to expire when last observed",
+			args: args{
+				prevDevMode: 1200,
+				devMode:     600,
+			},
+			want: want{
+				timer: 600,
+				events: []event.Event{
+					event.Normal(reasonDevModeExpiring, "development mode will be disabled in 10m0s"),
+				},
+			},
+		},
+		"DevModeStillExpiring": {
+			reason: "No event should be emitted when development mode was already about to expire when last observed",
+			args: args{
+				prevDevMode: 600,
+				devMode:     300,
+			},
+			want: want{
+				timer: 300,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec := &eventRecorder{}
+			e := external{
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{ID: zoneID, DevMode: tc.args.devMode}, nil
+					},
+					MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
+						return &cloudflare.ZoneSettingResponse{}, nil
+					},
+				},
+				recorder: rec,
+			}
+
+			cr := zone(withExternalName("1234beef"))
+			cr.Status.AtProvider.DevModeTimer = tc.args.prevDevMode
+			if _, err := e.Observe(context.Background(), cr); err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): unexpected error: %s\n", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.timer, cr.Status.AtProvider.DevModeTimer); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want timer, +got timer:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.events, rec.events); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want events, +got events:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveSettingsNotEditable(t *testing.T) {
+	type args struct {
+		editable bool
+		// edgeCacheTTL is the observed edge cache TTL, which is
+		// desired to be 900.
+		edgeCacheTTL int64
+	}
+
+	type want struct {
+		o         managed.ExternalObservation
+		condition xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"SettingEditable": {
+			reason: "A changed setting that is editable should need updating",
+			args: args{
+				editable:     true,
+				edgeCacheTTL: 900,
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				condition: v1alpha1.SettingsEditable(),
+			},
+		},
+		"SettingNoLongerEditable": {
+			reason: "A changed setting that is no longer editable should be reported rather than updated",
+			args: args{
+				editable:     false,
+				edgeCacheTTL: 900,
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				condition: v1alpha1.SettingsNotEditable([]string{"0rtt"}),
+			},
+		},
+		"SettingDesiredNotEditable": {
+			reason: "A desired setting that is not editable should be reported while other changed settings are still updated",
+			args: args{
+				editable:     false,
+				edgeCacheTTL: 7200,
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+				condition: v1alpha1.SettingsNotEditable([]string{"0rtt"}),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{ID: zoneID}, nil
+					},
+					MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
+						return &cloudflare.ZoneSettingResponse{
+							Result: []cloudflare.ZoneSetting{
+								{ID: "0rtt", Value: "off", Editable: tc.args.editable},
+								{ID: "edge_cache_ttl", Value: tc.args.edgeCacheTTL, Editable: true},
+							},
+						}, nil
+					},
+				},
+			}
+
+			cr := zone(
+				withExternalName("1234beef"),
+				withZeroRTT(ptr.StringPtr("on")),
+				withEdgeCacheTTL(ptr.Int64Ptr(900)),
+			)
+			got, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): unexpected error: %s\n", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.condition, cr.GetCondition(v1alpha1.TypeSettingsEditable), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestCreate(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -466,6 +682,56 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestCreateZoneHeld(t *testing.T) {
+	errHeld := errors.New("HTTP status 400: Zone hold prevents this zone from being added")
+	errBoom := errors.New("boom")
+
+	type want struct {
+		err    error
+		reason xpv1.ConditionReason
+	}
+
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   want
+	}{
+		"ZoneHeld": {
+			reason: "We should set a Held condition when a hold exists on the domain",
+			err:    errHeld,
+			want: want{
+				err:    errors.Wrap(errHeld, errZoneCreation),
+				reason: v1alpha1.ReasonHeld,
+			},
+		},
+		"OtherError": {
+			reason: "We should not set a Held condition for other errors",
+			err:    errBoom,
+			want: want{
+				err: errors.Wrap(errBoom, errZoneCreation),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: fake.MockClient{
+				MockCreateZone: func(ctx context.Context, name string, jumpstart bool, account cloudflare.Account, zoneType string) (cloudflare.Zone, error) {
+					return cloudflare.Zone{}, tc.err
+				},
+			}}
+			cr := zone(withType(ptr.StringPtr("full")))
+			_, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.reason, cr.GetCondition(xpv1.TypeReady).Reason); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want reason, +got reason:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestUpdate(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -519,6 +785,7 @@ func TestUpdate(t *testing.T) {
 						return cloudflare.Zone{
 							ID:     zoneID,
 							Paused: false,
+							Status: "active",
 						}, nil
 					},
 					MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
@@ -554,6 +821,7 @@ func TestUpdate(t *testing.T) {
 						return cloudflare.Zone{
 							ID:     zoneID,
 							Paused: false,
+							Status: "active",
 						}, nil
 					},
 					MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
@@ -604,6 +872,470 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestObservePlanGatedSettings(t *testing.T) {
+	rec := &eventRecorder{}
+	e := external{
+		client: fake.MockClient{
+			MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+				return cloudflare.Zone{ID: zoneID, Plan: cloudflare.ZonePlan{LegacyID: "free"}}, nil
+			},
+			MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
+				return &cloudflare.ZoneSettingResponse{}, nil
+			},
+		},
+		recorder: rec,
+	}
+
+	cr := zone(withExternalName("1234beef"))
+	cr.Spec.ForProvider.Settings.H2Prioritization = ptr.StringPtr("on")
+
+	// The warning should only be emitted once while the plan gated
+	// settings stay the same.
+	for i := 0; i < 2; i++ {
+		if _, err := e.Observe(context.Background(), cr); err != nil {
+			t.Fatalf("e.Observe(...): unexpected error: %s", err)
+		}
+	}
+
+	want := []event.Event{
+		event.Warning(reasonPlanGated, errors.New("settings h2_prioritization may only be enabled on a paid plan")),
+	}
+	if diff := cmp.Diff(want, rec.events); diff != "" {
+		t.Errorf("e.Observe(...): enabling a plan gated setting on a free Zone should emit a warning: -want events, +got events:\n%s\n", diff)
+	}
+}
+
+func TestObservePendingActivation(t *testing.T) {
+	type want struct {
+		ready xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		reason string
+		z      cloudflare.Zone
+		want   want
+	}{
+		"PartialPending": {
+			reason: "A pending partial Zone should surface the records needed to activate it",
+			z:      cloudflare.Zone{Name: "example.com", Type: "partial", Status: "pending", VerificationKey: "123-456"},
+			want: want{
+				ready: v1alpha1.PendingActivation([]string{
+					"TXT cloudflare-verify.example.com 123-456",
+					"CNAME <hostname>.example.com <hostname>.example.com.cdn.cloudflare.net",
+				}),
+			},
+		},
+		"PartialActive": {
+			reason: "An active partial Zone should be available",
+			z:      cloudflare.Zone{Name: "example.com", Type: "partial", Status: "active", VerificationKey: "123-456"},
+			want: want{
+				ready: xpv1.Available(),
+			},
+		},
+		"FullPending": {
+			reason: "A pending full Zone should be unavailable without any setup records",
+			z:      cloudflare.Zone{Name: "example.com", Type: "full", Status: "pending"},
+			want: want{
+				ready: xpv1.Unavailable(),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						z := tc.z
+						z.ID = zoneID
+						return z, nil
+					},
+					MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
+						return &cloudflare.ZoneSettingResponse{}, nil
+					},
+				},
+				recorder: &eventRecorder{},
+			}
+
+			cr := zone(withExternalName("1234beef"))
+			if _, err := e.Observe(context.Background(), cr); err != nil {
+				t.Fatalf("e.Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.ready, cr.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveManagedSettings(t *testing.T) {
+	e := external{
+		client: fake.MockClient{
+			MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+				return cloudflare.Zone{ID: zoneID}, nil
+			},
+			MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
+				return &cloudflare.ZoneSettingResponse{
+					Result: []cloudflare.ZoneSetting{
+						{ID: "0rtt", Value: "off", Editable: true},
+						{ID: "brotli", Value: "on", Editable: true},
+						{ID: "edge_cache_ttl", Value: 3600, Editable: true},
+					},
+				}, nil
+			},
+		},
+		recorder: &eventRecorder{},
+	}
+
+	cr := zone(
+		withExternalName("1234beef"),
+		withZeroRTT(ptr.StringPtr("on")),
+		withEdgeCacheTTL(ptr.Int64Ptr(900)),
+	)
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatalf("e.Observe(...): unexpected error: %s", err)
+	}
+
+	// Settings that are not in the spec are late initialized, after
+	// which they are managed too.
+	want := []string{"0rtt", "brotli", "edge_cache_ttl"}
+	if diff := cmp.Diff(want, cr.Status.AtProvider.ManagedSettings); diff != "" {
+		t.Errorf("e.Observe(...): -want managed settings, +got managed settings:\n%s\n", diff)
+	}
+}
+
+func TestUpdateSettingsSnapshotAllowlist(t *testing.T) {
+	e := external{
+		client: fake.MockClient{
+			MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+				return cloudflare.Zone{ID: zoneID, Status: "active"}, nil
+			},
+			MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
+				return &cloudflare.ZoneSettingResponse{
+					Result: []cloudflare.ZoneSetting{
+						{ID: "0rtt", Value: "off", Editable: true},
+						{ID: "brotli", Value: "off", Editable: true},
+					},
+				}, nil
+			},
+			MockUpdateZoneSettings: func(ctx context.Context, zoneID string, cs []cloudflare.ZoneSetting) (*cloudflare.ZoneSettingResponse, error) {
+				return &cloudflare.ZoneSettingResponse{Result: cs}, nil
+			},
+		},
+	}
+
+	// Brotli was changed before it was left out of the allowlist, so it
+	// is no longer managed by us and must not be reported as changed.
+	cr := zone(
+		withExternalName("1234beef"),
+		withZeroRTT(ptr.StringPtr("on")),
+	)
+	cr.Spec.ForProvider.Settings.Brotli = ptr.StringPtr("on")
+	cr.Spec.ForProvider.SettingsAllowlist = []string{"0rtt"}
+	cr.Status.AtProvider.ChangedSettings = []string{"0rtt", "brotli"}
+
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([]string(nil), cr.Status.AtProvider.ChangedSettings); diff != "" {
+		t.Errorf("e.Update(...): settings outside of the allowlist should not be reported as changed: -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestUpdateSettingsNotApplied(t *testing.T) {
+	errInvalid := errors.New("invalid value")
+	errNotEntitled := errors.New("setting is not entitled for this account")
+
+	type args struct {
+		invalid     string
+		notEntitled string
+		unapplied   []string
+	}
+
+	type want struct {
+		err       error
+		applied   []string
+		calls     int
+		condition xpv1.Condition
+		entitled  xpv1.Condition
+		changed   []string
+		unapplied []string
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"AllSettingsApplied": {
+			reason: "All changed settings should be applied together",
+			want: want{
+				applied:   []string{"0rtt", "edge_cache_ttl"},
+				calls:     1,
+				condition: v1alpha1.SettingsApplied(),
+				entitled:  v1alpha1.SettingsEntitled(),
+				changed:   nil,
+			},
+		},
+		"PreviouslyUnappliedSettingsApplied": {
+			reason: "Settings that could not be applied before should be updated on their own and forgotten once applied",
+			args: args{
+				unapplied: []string{"0rtt"},
+			},
+			want: want{
+				applied:   []string{"0rtt", "edge_cache_ttl"},
+				calls:     2,
+				condition: v1alpha1.SettingsApplied(),
+				entitled:  v1alpha1.SettingsEntitled(),
+				changed:   nil,
+			},
+		},
+		"SettingNotApplied": {
+			reason: "A setting that cannot be applied should be reported and returned as an error while other settings are still applied",
+			args: args{
+				invalid: "0rtt",
+			},
+			want: want{
+				err:       errors.Wrap(&zones.SettingsError{Errors: map[string]error{"0rtt": errInvalid}}, errZoneUpdate),
+				applied:   []string{"edge_cache_ttl"},
+				calls:     3,
+				condition: v1alpha1.SettingsNotApplied([]string{"0rtt"}),
+				entitled:  v1alpha1.SettingsEntitled(),
+				changed:   []string{"0rtt"},
+				unapplied: []string{"0rtt"},
+			},
+		},
+		"SettingStillNotApplied": {
+			reason: "A setting that could not be applied before should be updated on its own rather than falling back to updating every setting on its own again",
+			args: args{
+				invalid:   "0rtt",
+				unapplied: []string{"0rtt"},
+			},
+			want: want{
+				err:       errors.Wrap(&zones.SettingsError{Errors: map[string]error{"0rtt": errInvalid}}, errZoneUpdate),
+				applied:   []string{"edge_cache_ttl"},
+				calls:     2,
+				condition: v1alpha1.SettingsNotApplied([]string{"0rtt"}),
+				entitled:  v1alpha1.SettingsEntitled(),
+				changed:   []string{"0rtt"},
+				unapplied: []string{"0rtt"},
+			},
+		},
+		"SettingNotEntitled": {
+			reason: "A setting the account is not entitled to should be reported apart from invalid settings",
+			args: args{
+				notEntitled: "0rtt",
+			},
+			want: want{
+				err:       errors.Wrap(&zones.SettingsError{Errors: map[string]error{"0rtt": errNotEntitled}}, errZoneUpdate),
+				applied:   []string{"edge_cache_ttl"},
+				calls:     3,
+				condition: v1alpha1.SettingsApplied(),
+				entitled:  v1alpha1.SettingsNotEntitled([]string{"0rtt"}),
+				changed:   []string{"0rtt"},
+				unapplied: []string{"0rtt"},
+			},
+		},
+		"SettingsNotAppliedAndNotEntitled": {
+			reason: "Invalid settings and settings the account is not entitled to should be reported separately",
+			args: args{
+				invalid:     "edge_cache_ttl",
+				notEntitled: "0rtt",
+			},
+			want: want{
+				err: errors.Wrap(&zones.SettingsError{Errors: map[string]error{
+					"0rtt":           errNotEntitled,
+					"edge_cache_ttl": errInvalid,
+				}}, errZoneUpdate),
+				applied:   []string{},
+				calls:     3,
+				condition: v1alpha1.SettingsNotApplied([]string{"edge_cache_ttl"}),
+				entitled:  v1alpha1.SettingsNotEntitled([]string{"0rtt"}),
+				changed:   []string{"0rtt", "edge_cache_ttl"},
+				unapplied: []string{"0rtt", "edge_cache_ttl"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			applied := []string{}
+			calls := 0
+			e := external{
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{ID: zoneID, Status: "active"}, nil
+					},
+					MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
+						return &cloudflare.ZoneSettingResponse{
+							Result: []cloudflare.ZoneSetting{
+								{ID: "0rtt", Value: "off", Editable: true},
+								{ID: "edge_cache_ttl", Value: 3600, Editable: true},
+							},
+						}, nil
+					},
+					MockUpdateZoneSettings: func(ctx context.Context, zoneID string, cs []cloudflare.ZoneSetting) (*cloudflare.ZoneSettingResponse, error) {
+						calls++
+						for _, s := range cs {
+							if s.ID == tc.args.invalid {
+								return nil, errInvalid
+							}
+							if s.ID == tc.args.notEntitled {
+								return nil, errNotEntitled
+							}
+						}
+						for _, s := range cs {
+							applied = append(applied, s.ID)
+						}
+						return &cloudflare.ZoneSettingResponse{Result: cs}, nil
+					},
+				},
+			}
+
+			cr := zone(
+				withExternalName("1234beef"),
+				withZeroRTT(ptr.StringPtr("on")),
+				withEdgeCacheTTL(ptr.Int64Ptr(900)),
+			)
+			cr.Status.AtProvider.ChangedSettings = []string{"0rtt", "edge_cache_ttl"}
+			cr.Status.AtProvider.UnappliedSettings = tc.args.unapplied
+			_, err := e.Update(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			sortOpt := cmpopts.SortSlices(func(x, y string) bool { return x < y })
+			if diff := cmp.Diff(tc.want.applied, applied, sortOpt); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want applied settings, +got applied settings:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want settings updates, +got settings updates:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.unapplied, cr.Status.AtProvider.UnappliedSettings); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want unapplied settings, +got unapplied settings:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.condition, cr.GetCondition(v1alpha1.TypeSettingsApplied), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.entitled, cr.GetCondition(v1alpha1.TypeSettingsEntitled), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want entitled condition, +got entitled condition:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.changed, cr.Status.AtProvider.ChangedSettings); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want changed settings, +got changed settings:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveSettingsDeferred(t *testing.T) {
+	type want struct {
+		upToDate  bool
+		condition xpv1.Condition
+		changed   []string
+	}
+
+	cases := map[string]struct {
+		reason string
+		status string
+		paused bool
+		want   want
+	}{
+		"PendingSettingsChanged": {
+			reason: "Settings of a pending zone should be reported as deferred rather than the zone being outdated",
+			status: "pending",
+			want: want{
+				upToDate:  true,
+				condition: v1alpha1.SettingsDeferred("pending"),
+				changed:   []string{"0rtt"},
+			},
+		},
+		"PendingOtherChanges": {
+			reason: "A pending zone should be outdated if more than its settings differ",
+			status: "pending",
+			paused: true,
+			want: want{
+				upToDate:  false,
+				condition: xpv1.Condition{Type: v1alpha1.TypeSettingsApplied, Status: corev1.ConditionUnknown},
+				changed:   []string{"0rtt"},
+			},
+		},
+		"ActiveSettingsChanged": {
+			reason: "An active zone should be outdated if its settings differ",
+			status: "active",
+			want: want{
+				upToDate:  false,
+				condition: xpv1.Condition{Type: v1alpha1.TypeSettingsApplied, Status: corev1.ConditionUnknown},
+				changed:   []string{"0rtt"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{
+				client: fake.MockClient{
+					MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+						return cloudflare.Zone{ID: zoneID, Status: tc.status, Paused: tc.paused}, nil
+					},
+					MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
+						return &cloudflare.ZoneSettingResponse{
+							Result: []cloudflare.ZoneSetting{
+								{ID: "0rtt", Value: "off", Editable: true},
+							},
+						}, nil
+					},
+				},
+				recorder: &eventRecorder{},
+			}
+
+			cr := zone(
+				withExternalName("1234beef"),
+				withZeroRTT(ptr.StringPtr("on")),
+			)
+			cr.Spec.ForProvider.Paused = ptr.BoolPtr(false)
+			obs, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("e.Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.upToDate, obs.ResourceUpToDate); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want up to date, +got up to date:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.condition, cr.GetCondition(v1alpha1.TypeSettingsApplied), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.changed, cr.Status.AtProvider.ChangedSettings); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want changed settings, +got changed settings:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdateSettingsDeferred(t *testing.T) {
+	e := external{
+		client: fake.MockClient{
+			MockZoneDetails: func(ctx context.Context, zoneID string) (cloudflare.Zone, error) {
+				return cloudflare.Zone{ID: zoneID, Status: "pending"}, nil
+			},
+			MockZoneSettings: func(ctx context.Context, zoneID string) (*cloudflare.ZoneSettingResponse, error) {
+				return nil, errors.New("settings should not be loaded on a pending zone")
+			},
+			MockUpdateZoneSettings: func(ctx context.Context, zoneID string, cs []cloudflare.ZoneSetting) (*cloudflare.ZoneSettingResponse, error) {
+				return nil, errors.New("settings should not be updated on a pending zone")
+			},
+		},
+	}
+
+	cr := zone(
+		withExternalName("1234beef"),
+		withZeroRTT(ptr.StringPtr("on")),
+	)
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(v1alpha1.SettingsDeferred("pending"), cr.GetCondition(v1alpha1.TypeSettingsApplied), test.EquateConditions()); diff != "" {
+		t.Errorf("e.Update(...): settings of a pending zone should be deferred: -want condition, +got condition:\n%s\n", diff)
+	}
+}
+
 func TestDelete(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -17,11 +17,16 @@ limitations under the License.
 package metrics
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
 )
 
 var (
@@ -55,6 +60,14 @@ var (
 		},
 		[]string{"controller", "event"},
 	)
+	opDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "external_operation_duration_seconds",
+			Help:    "External resource operation duration histogram, by controller and operation.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"controller", "operation"},
+	)
 )
 
 // Init registers metric types that can be instrumented on
@@ -65,6 +78,7 @@ func init() {
 		reqTotal,
 		reqLatency,
 		reqEventsLatency,
+		opDuration,
 	)
 }
 
@@ -117,3 +131,54 @@ func InstrumentHTTPClient(hc *http.Client, n string) {
 		),
 	)
 }
+
+// InstrumentConnecter returns a managed.ExternalConnecter that wraps the
+// clients returned by the supplied managed.ExternalConnecter, so that the
+// duration of each of their operations is recorded. The duration of a whole
+// reconcile is already recorded by controller-runtime.
+func InstrumentConnecter(c managed.ExternalConnecter, n string) managed.ExternalConnecter {
+	return &connecter{ExternalConnecter: c, od: opDuration.MustCurryWith(prometheus.Labels{"controller": n})}
+}
+
+type connecter struct {
+	managed.ExternalConnecter
+	od prometheus.ObserverVec
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{ExternalClient: ec, od: c.od}, nil
+}
+
+type external struct {
+	managed.ExternalClient
+	od prometheus.ObserverVec
+}
+
+// observe records the time since start for the passed operation.
+func (e *external) observe(op string, start time.Time) {
+	e.od.WithLabelValues(op).Observe(time.Since(start).Seconds())
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	defer e.observe("observe", time.Now())
+	return e.ExternalClient.Observe(ctx, mg)
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	defer e.observe("create", time.Now())
+	return e.ExternalClient.Create(ctx, mg)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	defer e.observe("update", time.Now())
+	return e.ExternalClient.Update(ctx, mg)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	defer e.observe("delete", time.Now())
+	return e.ExternalClient.Delete(ctx, mg)
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// operationCount returns the number of operation durations observed
+// for the passed controller and operation.
+func operationCount(t *testing.T, n, op string) uint64 {
+	t.Helper()
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(opDuration)
+
+	mfs, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("cannot gather metrics: %s", err)
+	}
+
+	for _, mf := range mfs {
+		for _, m := range mf.GetMetric() {
+			l := map[string]string{}
+			for _, lp := range m.GetLabel() {
+				l[lp.GetName()] = lp.GetValue()
+			}
+			if l["controller"] == n && l["operation"] == op {
+				return m.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	return 0
+}
+
+func TestInstrumentConnecter(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		err   error
+		count uint64
+	}
+
+	cases := map[string]struct {
+		reason string
+		op     string
+		ec     managed.ExternalClient
+		fn     func(ctx context.Context, ec managed.ExternalClient) error
+		want   want
+	}{
+		"Observe": {
+			reason: "An observation should record its duration",
+			op:     "observe",
+			ec: &managed.ExternalClientFns{
+				ObserveFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+					return managed.ExternalObservation{}, nil
+				},
+			},
+			fn: func(ctx context.Context, ec managed.ExternalClient) error {
+				_, err := ec.Observe(ctx, nil)
+				return err
+			},
+			want: want{
+				count: 1,
+			},
+		},
+		"Create": {
+			reason: "A creation should record its duration",
+			op:     "create",
+			ec: &managed.ExternalClientFns{
+				CreateFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+					return managed.ExternalCreation{}, nil
+				},
+			},
+			fn: func(ctx context.Context, ec managed.ExternalClient) error {
+				_, err := ec.Create(ctx, nil)
+				return err
+			},
+			want: want{
+				count: 1,
+			},
+		},
+		"UpdateError": {
+			reason: "A failed update should record its duration and return its error",
+			op:     "update",
+			ec: &managed.ExternalClientFns{
+				UpdateFn: func(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+					return managed.ExternalUpdate{}, errBoom
+				},
+			},
+			fn: func(ctx context.Context, ec managed.ExternalClient) error {
+				_, err := ec.Update(ctx, nil)
+				return err
+			},
+			want: want{
+				err:   errBoom,
+				count: 1,
+			},
+		},
+		"Delete": {
+			reason: "A deletion should record its duration",
+			op:     "delete",
+			ec: &managed.ExternalClientFns{
+				DeleteFn: func(ctx context.Context, mg resource.Managed) error {
+					return nil
+				},
+			},
+			fn: func(ctx context.Context, ec managed.ExternalClient) error {
+				return ec.Delete(ctx, nil)
+			},
+			want: want{
+				count: 1,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := InstrumentConnecter(managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+				return tc.ec, nil
+			}), name)
+			ec, err := c.Connect(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("Connect(...): %s", err)
+			}
+			err = tc.fn(context.Background(), ec)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nExternalClient(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.count, operationCount(t, name, tc.op)); diff != "" {
+				t.Errorf("\n%s\nexternal_operation_duration_seconds count: -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}